@@ -0,0 +1,60 @@
+// breachindex builds the breached password index from a "Pwned Passwords"
+// SHA-1 dump ordered by hash, as produced by the official downloader:
+//
+//	haveibeenpwned-downloader pwnedpasswords
+//	go run ./cmd/breachindex -dump pwnedpasswords.txt -out pwned.idx
+//
+// Point app.passwordPolicy.breachIndex at the resulting file.
+package main
+
+import (
+	"flag"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/service/security/password"
+)
+
+func main() {
+	dumpPath := flag.String("dump", "-", "SHA-1 dump ordered by hash, - reads stdin")
+	outPath := flag.String("out", "pwned.idx", "index file to write")
+	minCount := flag.Int("min-count", 1, "skip hashes seen fewer times than this")
+	flag.Parse()
+
+	var dump io.Reader = os.Stdin
+	if *dumpPath != "-" {
+		file, err := os.Open(*dumpPath)
+		if err != nil {
+			log.Fatalf("failed to open dump: %v", err)
+		}
+		defer file.Close()
+		dump = file
+	}
+
+	// Write next to the target and rename at the end, so a running server
+	// never opens a half written index.
+	tmpPath := *outPath + ".tmp"
+	index, err := os.Create(tmpPath)
+	if err != nil {
+		log.Fatalf("failed to create index: %v", err)
+	}
+
+	start := time.Now()
+	written, err := password.BuildBreachIndex(dump, index, *minCount)
+	if err != nil {
+		index.Close()
+		os.Remove(tmpPath)
+		log.Fatalf("failed to build index: %v", err)
+	}
+	if err := index.Close(); err != nil {
+		os.Remove(tmpPath)
+		log.Fatalf("failed to write index: %v", err)
+	}
+	if err := os.Rename(tmpPath, *outPath); err != nil {
+		log.Fatalf("failed to move index into place: %v", err)
+	}
+
+	log.Printf("indexed %d hashes into %s in %s", written, *outPath, time.Since(start).Round(time.Second))
+}
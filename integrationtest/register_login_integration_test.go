@@ -74,7 +74,7 @@ func TestIntegrationRegisterLogin(t *testing.T) {
 	t.Run("A new user can register", func(t *testing.T) {
 		testUser := "testuser"
 		testEmail := "testuser@test.io"
-		testPassword := "testuserPassword123!"
+		testPassword := "Quiet-Harbor-Lantern-42!"
 
 		formData := url.Values{
 			"username": {testUser},
@@ -96,7 +96,7 @@ func TestIntegrationRegisterLogin(t *testing.T) {
 	t.Run("A user cannot register with an existing email", func(t *testing.T) {
 		testUser := "othertestuser"
 		testEmail := "othertestuser@test.io"
-		testPassword := "othertestuserPassword123!"
+		testPassword := "Quiet-Harbor-Lantern-42!"
 
 		formData := url.Values{
 			"username": {testUser},
@@ -122,7 +122,7 @@ func TestIntegrationRegisterLogin(t *testing.T) {
 	t.Run("A registered user can login", func(t *testing.T) {
 		testUser := "anothertestuser"
 		testEmail := "anothertestuser@test.io"
-		testPassword := "anothertestuserPassword123!"
+		testPassword := "Quiet-Harbor-Lantern-42!"
 
 		formData := url.Values{
 			"username": {testUser},
@@ -164,4 +164,108 @@ func TestIntegrationRegisterLogin(t *testing.T) {
 		assert.Equal(t, "/", tokenCookie.Path, "Token cookie path is incorrect")
 		assert.Equal(t, http.SameSiteLaxMode, tokenCookie.SameSite, "Token cookie SameSite attribute is incorrect")
 	})
+	t.Run("A logged in user can rotate the refresh token exactly once", func(t *testing.T) {
+		testUser := "refreshtestuser"
+		testEmail := "refreshtestuser@test.io"
+		testPassword := "Quiet-Harbor-Lantern-42!"
+
+		formData := url.Values{
+			"username": {testUser},
+			"email":    {testEmail},
+			"password": {testPassword},
+		}
+		resp, err := http.PostForm("http://localhost:8081/api/register", formData)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		formData = url.Values{
+			"email":    {testEmail},
+			"password": {testPassword},
+		}
+		resp, err = http.PostForm("http://localhost:8081/api/login", formData)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		refreshCookie := findCookie(resp.Cookies(), "refresh_token")
+		require.NotNil(t, refreshCookie, "Refresh token cookie not found in the response")
+		assert.Equal(t, "/api", refreshCookie.Path, "Refresh token cookie path is incorrect")
+
+		resp = postWithCookie(t, "http://localhost:8081/api/token/refresh", refreshCookie)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		rotatedCookie := findCookie(resp.Cookies(), "refresh_token")
+		require.NotNil(t, rotatedCookie, "Rotated refresh token cookie not found in the response")
+		assert.NotEqual(t, refreshCookie.Value, rotatedCookie.Value)
+		assert.NotNil(t, findCookie(resp.Cookies(), "token"), "Token cookie not found in the response")
+
+		// replaying the old refresh token revokes the whole family
+		resp = postWithCookie(t, "http://localhost:8081/api/token/refresh", refreshCookie)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		resp = postWithCookie(t, "http://localhost:8081/api/token/refresh", rotatedCookie)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("A logged out token is rejected", func(t *testing.T) {
+		testUser := "logouttestuser"
+		testEmail := "logouttestuser@test.io"
+		testPassword := "Quiet-Harbor-Lantern-42!"
+
+		formData := url.Values{
+			"username": {testUser},
+			"email":    {testEmail},
+			"password": {testPassword},
+		}
+		resp, err := http.PostForm("http://localhost:8081/api/register", formData)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		formData = url.Values{
+			"email":    {testEmail},
+			"password": {testPassword},
+		}
+		resp, err = http.PostForm("http://localhost:8081/api/login", formData)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		tokenCookie := findCookie(resp.Cookies(), "token")
+		refreshCookie := findCookie(resp.Cookies(), "refresh_token")
+		require.NotNil(t, tokenCookie)
+		require.NotNil(t, refreshCookie)
+
+		resp = requestWithCookies(t, http.MethodGet, "http://localhost:8081/restricted", tokenCookie)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp = postWithCookie(t, "http://localhost:8081/api/logout", tokenCookie, refreshCookie)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp = requestWithCookies(t, http.MethodGet, "http://localhost:8081/restricted", tokenCookie)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		resp = postWithCookie(t, "http://localhost:8081/api/token/refresh", refreshCookie)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+func findCookie(cookies []*http.Cookie, name string) *http.Cookie {
+	for _, cookie := range cookies {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+
+	return nil
+}
+
+func postWithCookie(t *testing.T, target string, cookies ...*http.Cookie) *http.Response {
+	t.Helper()
+	return requestWithCookies(t, http.MethodPost, target, cookies...)
+}
+
+func requestWithCookies(t *testing.T, method, target string, cookies ...*http.Cookie) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, target, nil)
+	require.NoError(t, err)
+	for _, cookie := range cookies {
+		req.AddCookie(&http.Cookie{Name: cookie.Name, Value: cookie.Value})
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	return resp
 }
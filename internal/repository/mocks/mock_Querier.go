@@ -39,25 +39,5563 @@ func (_m *MockQuerier) EXPECT() *MockQuerier_Expecter {
 	return &MockQuerier_Expecter{mock: &_m.Mock}
 }
 
+// AcceptInvitation provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) AcceptInvitation(ctx context.Context, tokenHash string) (repository.Invitation, error) {
+	ret := _mock.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptInvitation")
+	}
+
+	var r0 repository.Invitation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (repository.Invitation, error)); ok {
+		return returnFunc(ctx, tokenHash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) repository.Invitation); ok {
+		r0 = returnFunc(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(repository.Invitation)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_AcceptInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcceptInvitation'
+type MockQuerier_AcceptInvitation_Call struct {
+	*mock.Call
+}
+
+// AcceptInvitation is a helper method to define mock.On call
+//   - ctx
+//   - tokenHash
+func (_e *MockQuerier_Expecter) AcceptInvitation(ctx interface{}, tokenHash interface{}) *MockQuerier_AcceptInvitation_Call {
+	return &MockQuerier_AcceptInvitation_Call{Call: _e.mock.On("AcceptInvitation", ctx, tokenHash)}
+}
+
+func (_c *MockQuerier_AcceptInvitation_Call) Run(run func(ctx context.Context, tokenHash string)) *MockQuerier_AcceptInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_AcceptInvitation_Call) Return(invitation repository.Invitation, err error) *MockQuerier_AcceptInvitation_Call {
+	_c.Call.Return(invitation, err)
+	return _c
+}
+
+func (_c *MockQuerier_AcceptInvitation_Call) RunAndReturn(run func(ctx context.Context, tokenHash string) (repository.Invitation, error)) *MockQuerier_AcceptInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddRolePermissions provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) AddRolePermissions(ctx context.Context, arg repository.AddRolePermissionsParams) error {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddRolePermissions")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.AddRolePermissionsParams) error); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_AddRolePermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddRolePermissions'
+type MockQuerier_AddRolePermissions_Call struct {
+	*mock.Call
+}
+
+// AddRolePermissions is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) AddRolePermissions(ctx interface{}, arg interface{}) *MockQuerier_AddRolePermissions_Call {
+	return &MockQuerier_AddRolePermissions_Call{Call: _e.mock.On("AddRolePermissions", ctx, arg)}
+}
+
+func (_c *MockQuerier_AddRolePermissions_Call) Run(run func(ctx context.Context, arg repository.AddRolePermissionsParams)) *MockQuerier_AddRolePermissions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.AddRolePermissionsParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_AddRolePermissions_Call) Return(err error) *MockQuerier_AddRolePermissions_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_AddRolePermissions_Call) RunAndReturn(run func(ctx context.Context, arg repository.AddRolePermissionsParams) error) *MockQuerier_AddRolePermissions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ApproveUser provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ApproveUser(ctx context.Context, id pgtype.UUID) (repository.User, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveUser")
+	}
+
+	var r0 repository.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (repository.User, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) repository.User); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(repository.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ApproveUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApproveUser'
+type MockQuerier_ApproveUser_Call struct {
+	*mock.Call
+}
+
+// ApproveUser is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockQuerier_Expecter) ApproveUser(ctx interface{}, id interface{}) *MockQuerier_ApproveUser_Call {
+	return &MockQuerier_ApproveUser_Call{Call: _e.mock.On("ApproveUser", ctx, id)}
+}
+
+func (_c *MockQuerier_ApproveUser_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockQuerier_ApproveUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ApproveUser_Call) Return(user repository.User, err error) *MockQuerier_ApproveUser_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockQuerier_ApproveUser_Call) RunAndReturn(run func(ctx context.Context, id pgtype.UUID) (repository.User, error)) *MockQuerier_ApproveUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConfirmEmailChange provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ConfirmEmailChange(ctx context.Context, confirmTokenHash string) (repository.EmailChange, error) {
+	ret := _mock.Called(ctx, confirmTokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmEmailChange")
+	}
+
+	var r0 repository.EmailChange
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (repository.EmailChange, error)); ok {
+		return returnFunc(ctx, confirmTokenHash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) repository.EmailChange); ok {
+		r0 = returnFunc(ctx, confirmTokenHash)
+	} else {
+		r0 = ret.Get(0).(repository.EmailChange)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, confirmTokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ConfirmEmailChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmEmailChange'
+type MockQuerier_ConfirmEmailChange_Call struct {
+	*mock.Call
+}
+
+// ConfirmEmailChange is a helper method to define mock.On call
+//   - ctx
+//   - confirmTokenHash
+func (_e *MockQuerier_Expecter) ConfirmEmailChange(ctx interface{}, confirmTokenHash interface{}) *MockQuerier_ConfirmEmailChange_Call {
+	return &MockQuerier_ConfirmEmailChange_Call{Call: _e.mock.On("ConfirmEmailChange", ctx, confirmTokenHash)}
+}
+
+func (_c *MockQuerier_ConfirmEmailChange_Call) Run(run func(ctx context.Context, confirmTokenHash string)) *MockQuerier_ConfirmEmailChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ConfirmEmailChange_Call) Return(emailChange repository.EmailChange, err error) *MockQuerier_ConfirmEmailChange_Call {
+	_c.Call.Return(emailChange, err)
+	return _c
+}
+
+func (_c *MockQuerier_ConfirmEmailChange_Call) RunAndReturn(run func(ctx context.Context, confirmTokenHash string) (repository.EmailChange, error)) *MockQuerier_ConfirmEmailChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConsumeEmailVerificationToken provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ConsumeEmailVerificationToken(ctx context.Context, id pgtype.UUID) (repository.EmailVerificationToken, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeEmailVerificationToken")
+	}
+
+	var r0 repository.EmailVerificationToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (repository.EmailVerificationToken, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) repository.EmailVerificationToken); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(repository.EmailVerificationToken)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ConsumeEmailVerificationToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConsumeEmailVerificationToken'
+type MockQuerier_ConsumeEmailVerificationToken_Call struct {
+	*mock.Call
+}
+
+// ConsumeEmailVerificationToken is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockQuerier_Expecter) ConsumeEmailVerificationToken(ctx interface{}, id interface{}) *MockQuerier_ConsumeEmailVerificationToken_Call {
+	return &MockQuerier_ConsumeEmailVerificationToken_Call{Call: _e.mock.On("ConsumeEmailVerificationToken", ctx, id)}
+}
+
+func (_c *MockQuerier_ConsumeEmailVerificationToken_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockQuerier_ConsumeEmailVerificationToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ConsumeEmailVerificationToken_Call) Return(emailVerificationToken repository.EmailVerificationToken, err error) *MockQuerier_ConsumeEmailVerificationToken_Call {
+	_c.Call.Return(emailVerificationToken, err)
+	return _c
+}
+
+func (_c *MockQuerier_ConsumeEmailVerificationToken_Call) RunAndReturn(run func(ctx context.Context, id pgtype.UUID) (repository.EmailVerificationToken, error)) *MockQuerier_ConsumeEmailVerificationToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConsumePasswordResetToken provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ConsumePasswordResetToken(ctx context.Context, tokenHash string) (repository.PasswordResetToken, error) {
+	ret := _mock.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumePasswordResetToken")
+	}
+
+	var r0 repository.PasswordResetToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (repository.PasswordResetToken, error)); ok {
+		return returnFunc(ctx, tokenHash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) repository.PasswordResetToken); ok {
+		r0 = returnFunc(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(repository.PasswordResetToken)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ConsumePasswordResetToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConsumePasswordResetToken'
+type MockQuerier_ConsumePasswordResetToken_Call struct {
+	*mock.Call
+}
+
+// ConsumePasswordResetToken is a helper method to define mock.On call
+//   - ctx
+//   - tokenHash
+func (_e *MockQuerier_Expecter) ConsumePasswordResetToken(ctx interface{}, tokenHash interface{}) *MockQuerier_ConsumePasswordResetToken_Call {
+	return &MockQuerier_ConsumePasswordResetToken_Call{Call: _e.mock.On("ConsumePasswordResetToken", ctx, tokenHash)}
+}
+
+func (_c *MockQuerier_ConsumePasswordResetToken_Call) Run(run func(ctx context.Context, tokenHash string)) *MockQuerier_ConsumePasswordResetToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ConsumePasswordResetToken_Call) Return(passwordResetToken repository.PasswordResetToken, err error) *MockQuerier_ConsumePasswordResetToken_Call {
+	_c.Call.Return(passwordResetToken, err)
+	return _c
+}
+
+func (_c *MockQuerier_ConsumePasswordResetToken_Call) RunAndReturn(run func(ctx context.Context, tokenHash string) (repository.PasswordResetToken, error)) *MockQuerier_ConsumePasswordResetToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConsumeWebauthnChallenge provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ConsumeWebauthnChallenge(ctx context.Context, arg repository.ConsumeWebauthnChallengeParams) (repository.WebauthnChallenge, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeWebauthnChallenge")
+	}
+
+	var r0 repository.WebauthnChallenge
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ConsumeWebauthnChallengeParams) (repository.WebauthnChallenge, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ConsumeWebauthnChallengeParams) repository.WebauthnChallenge); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.WebauthnChallenge)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.ConsumeWebauthnChallengeParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ConsumeWebauthnChallenge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConsumeWebauthnChallenge'
+type MockQuerier_ConsumeWebauthnChallenge_Call struct {
+	*mock.Call
+}
+
+// ConsumeWebauthnChallenge is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) ConsumeWebauthnChallenge(ctx interface{}, arg interface{}) *MockQuerier_ConsumeWebauthnChallenge_Call {
+	return &MockQuerier_ConsumeWebauthnChallenge_Call{Call: _e.mock.On("ConsumeWebauthnChallenge", ctx, arg)}
+}
+
+func (_c *MockQuerier_ConsumeWebauthnChallenge_Call) Run(run func(ctx context.Context, arg repository.ConsumeWebauthnChallengeParams)) *MockQuerier_ConsumeWebauthnChallenge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.ConsumeWebauthnChallengeParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ConsumeWebauthnChallenge_Call) Return(webauthnChallenge repository.WebauthnChallenge, err error) *MockQuerier_ConsumeWebauthnChallenge_Call {
+	_c.Call.Return(webauthnChallenge, err)
+	return _c
+}
+
+func (_c *MockQuerier_ConsumeWebauthnChallenge_Call) RunAndReturn(run func(ctx context.Context, arg repository.ConsumeWebauthnChallengeParams) (repository.WebauthnChallenge, error)) *MockQuerier_ConsumeWebauthnChallenge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountOrganizationMembersWithRole provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CountOrganizationMembersWithRole(ctx context.Context, arg repository.CountOrganizationMembersWithRoleParams) (int64, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountOrganizationMembersWithRole")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CountOrganizationMembersWithRoleParams) (int64, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CountOrganizationMembersWithRoleParams) int64); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.CountOrganizationMembersWithRoleParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_CountOrganizationMembersWithRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountOrganizationMembersWithRole'
+type MockQuerier_CountOrganizationMembersWithRole_Call struct {
+	*mock.Call
+}
+
+// CountOrganizationMembersWithRole is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) CountOrganizationMembersWithRole(ctx interface{}, arg interface{}) *MockQuerier_CountOrganizationMembersWithRole_Call {
+	return &MockQuerier_CountOrganizationMembersWithRole_Call{Call: _e.mock.On("CountOrganizationMembersWithRole", ctx, arg)}
+}
+
+func (_c *MockQuerier_CountOrganizationMembersWithRole_Call) Run(run func(ctx context.Context, arg repository.CountOrganizationMembersWithRoleParams)) *MockQuerier_CountOrganizationMembersWithRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.CountOrganizationMembersWithRoleParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CountOrganizationMembersWithRole_Call) Return(n int64, err error) *MockQuerier_CountOrganizationMembersWithRole_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockQuerier_CountOrganizationMembersWithRole_Call) RunAndReturn(run func(ctx context.Context, arg repository.CountOrganizationMembersWithRoleParams) (int64, error)) *MockQuerier_CountOrganizationMembersWithRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountRoleMemberships provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CountRoleMemberships(ctx context.Context, roleID pgtype.UUID) (int64, error) {
+	ret := _mock.Called(ctx, roleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountRoleMemberships")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (int64, error)); ok {
+		return returnFunc(ctx, roleID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) int64); ok {
+		r0 = returnFunc(ctx, roleID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = returnFunc(ctx, roleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_CountRoleMemberships_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountRoleMemberships'
+type MockQuerier_CountRoleMemberships_Call struct {
+	*mock.Call
+}
+
+// CountRoleMemberships is a helper method to define mock.On call
+//   - ctx
+//   - roleID
+func (_e *MockQuerier_Expecter) CountRoleMemberships(ctx interface{}, roleID interface{}) *MockQuerier_CountRoleMemberships_Call {
+	return &MockQuerier_CountRoleMemberships_Call{Call: _e.mock.On("CountRoleMemberships", ctx, roleID)}
+}
+
+func (_c *MockQuerier_CountRoleMemberships_Call) Run(run func(ctx context.Context, roleID pgtype.UUID)) *MockQuerier_CountRoleMemberships_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CountRoleMemberships_Call) Return(n int64, err error) *MockQuerier_CountRoleMemberships_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockQuerier_CountRoleMemberships_Call) RunAndReturn(run func(ctx context.Context, roleID pgtype.UUID) (int64, error)) *MockQuerier_CountRoleMemberships_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountUsers provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CountUsers(ctx context.Context, arg repository.CountUsersParams) (int64, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUsers")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CountUsersParams) (int64, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CountUsersParams) int64); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.CountUsersParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_CountUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountUsers'
+type MockQuerier_CountUsers_Call struct {
+	*mock.Call
+}
+
+// CountUsers is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) CountUsers(ctx interface{}, arg interface{}) *MockQuerier_CountUsers_Call {
+	return &MockQuerier_CountUsers_Call{Call: _e.mock.On("CountUsers", ctx, arg)}
+}
+
+func (_c *MockQuerier_CountUsers_Call) Run(run func(ctx context.Context, arg repository.CountUsersParams)) *MockQuerier_CountUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.CountUsersParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CountUsers_Call) Return(n int64, err error) *MockQuerier_CountUsers_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockQuerier_CountUsers_Call) RunAndReturn(run func(ctx context.Context, arg repository.CountUsersParams) (int64, error)) *MockQuerier_CountUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateAdminUser provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreateAdminUser(ctx context.Context, arg repository.CreateAdminUserParams) (repository.User, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAdminUser")
+	}
+
+	var r0 repository.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreateAdminUserParams) (repository.User, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreateAdminUserParams) repository.User); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.CreateAdminUserParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_CreateAdminUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAdminUser'
+type MockQuerier_CreateAdminUser_Call struct {
+	*mock.Call
+}
+
+// CreateAdminUser is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) CreateAdminUser(ctx interface{}, arg interface{}) *MockQuerier_CreateAdminUser_Call {
+	return &MockQuerier_CreateAdminUser_Call{Call: _e.mock.On("CreateAdminUser", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateAdminUser_Call) Run(run func(ctx context.Context, arg repository.CreateAdminUserParams)) *MockQuerier_CreateAdminUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.CreateAdminUserParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateAdminUser_Call) Return(user repository.User, err error) *MockQuerier_CreateAdminUser_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockQuerier_CreateAdminUser_Call) RunAndReturn(run func(ctx context.Context, arg repository.CreateAdminUserParams) (repository.User, error)) *MockQuerier_CreateAdminUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateEmailChange provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreateEmailChange(ctx context.Context, arg repository.CreateEmailChangeParams) error {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEmailChange")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreateEmailChangeParams) error); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_CreateEmailChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEmailChange'
+type MockQuerier_CreateEmailChange_Call struct {
+	*mock.Call
+}
+
+// CreateEmailChange is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) CreateEmailChange(ctx interface{}, arg interface{}) *MockQuerier_CreateEmailChange_Call {
+	return &MockQuerier_CreateEmailChange_Call{Call: _e.mock.On("CreateEmailChange", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateEmailChange_Call) Run(run func(ctx context.Context, arg repository.CreateEmailChangeParams)) *MockQuerier_CreateEmailChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.CreateEmailChangeParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateEmailChange_Call) Return(err error) *MockQuerier_CreateEmailChange_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_CreateEmailChange_Call) RunAndReturn(run func(ctx context.Context, arg repository.CreateEmailChangeParams) error) *MockQuerier_CreateEmailChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateEmailVerificationToken provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreateEmailVerificationToken(ctx context.Context, arg repository.CreateEmailVerificationTokenParams) (repository.EmailVerificationToken, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEmailVerificationToken")
+	}
+
+	var r0 repository.EmailVerificationToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreateEmailVerificationTokenParams) (repository.EmailVerificationToken, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreateEmailVerificationTokenParams) repository.EmailVerificationToken); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.EmailVerificationToken)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.CreateEmailVerificationTokenParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_CreateEmailVerificationToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEmailVerificationToken'
+type MockQuerier_CreateEmailVerificationToken_Call struct {
+	*mock.Call
+}
+
+// CreateEmailVerificationToken is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) CreateEmailVerificationToken(ctx interface{}, arg interface{}) *MockQuerier_CreateEmailVerificationToken_Call {
+	return &MockQuerier_CreateEmailVerificationToken_Call{Call: _e.mock.On("CreateEmailVerificationToken", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateEmailVerificationToken_Call) Run(run func(ctx context.Context, arg repository.CreateEmailVerificationTokenParams)) *MockQuerier_CreateEmailVerificationToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.CreateEmailVerificationTokenParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateEmailVerificationToken_Call) Return(emailVerificationToken repository.EmailVerificationToken, err error) *MockQuerier_CreateEmailVerificationToken_Call {
+	_c.Call.Return(emailVerificationToken, err)
+	return _c
+}
+
+func (_c *MockQuerier_CreateEmailVerificationToken_Call) RunAndReturn(run func(ctx context.Context, arg repository.CreateEmailVerificationTokenParams) (repository.EmailVerificationToken, error)) *MockQuerier_CreateEmailVerificationToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateInvitation provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreateInvitation(ctx context.Context, arg repository.CreateInvitationParams) (repository.Invitation, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateInvitation")
+	}
+
+	var r0 repository.Invitation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreateInvitationParams) (repository.Invitation, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreateInvitationParams) repository.Invitation); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.Invitation)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.CreateInvitationParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_CreateInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateInvitation'
+type MockQuerier_CreateInvitation_Call struct {
+	*mock.Call
+}
+
+// CreateInvitation is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) CreateInvitation(ctx interface{}, arg interface{}) *MockQuerier_CreateInvitation_Call {
+	return &MockQuerier_CreateInvitation_Call{Call: _e.mock.On("CreateInvitation", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateInvitation_Call) Run(run func(ctx context.Context, arg repository.CreateInvitationParams)) *MockQuerier_CreateInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.CreateInvitationParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateInvitation_Call) Return(invitation repository.Invitation, err error) *MockQuerier_CreateInvitation_Call {
+	_c.Call.Return(invitation, err)
+	return _c
+}
+
+func (_c *MockQuerier_CreateInvitation_Call) RunAndReturn(run func(ctx context.Context, arg repository.CreateInvitationParams) (repository.Invitation, error)) *MockQuerier_CreateInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateMembership provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreateMembership(ctx context.Context, arg repository.CreateMembershipParams) error {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMembership")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreateMembershipParams) error); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_CreateMembership_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateMembership'
+type MockQuerier_CreateMembership_Call struct {
+	*mock.Call
+}
+
+// CreateMembership is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) CreateMembership(ctx interface{}, arg interface{}) *MockQuerier_CreateMembership_Call {
+	return &MockQuerier_CreateMembership_Call{Call: _e.mock.On("CreateMembership", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateMembership_Call) Run(run func(ctx context.Context, arg repository.CreateMembershipParams)) *MockQuerier_CreateMembership_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.CreateMembershipParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateMembership_Call) Return(err error) *MockQuerier_CreateMembership_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_CreateMembership_Call) RunAndReturn(run func(ctx context.Context, arg repository.CreateMembershipParams) error) *MockQuerier_CreateMembership_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrganization provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreateOrganization(ctx context.Context, name string) (repository.Organization, error) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrganization")
+	}
+
+	var r0 repository.Organization
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (repository.Organization, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) repository.Organization); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		r0 = ret.Get(0).(repository.Organization)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_CreateOrganization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrganization'
+type MockQuerier_CreateOrganization_Call struct {
+	*mock.Call
+}
+
+// CreateOrganization is a helper method to define mock.On call
+//   - ctx
+//   - name
+func (_e *MockQuerier_Expecter) CreateOrganization(ctx interface{}, name interface{}) *MockQuerier_CreateOrganization_Call {
+	return &MockQuerier_CreateOrganization_Call{Call: _e.mock.On("CreateOrganization", ctx, name)}
+}
+
+func (_c *MockQuerier_CreateOrganization_Call) Run(run func(ctx context.Context, name string)) *MockQuerier_CreateOrganization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateOrganization_Call) Return(organization repository.Organization, err error) *MockQuerier_CreateOrganization_Call {
+	_c.Call.Return(organization, err)
+	return _c
+}
+
+func (_c *MockQuerier_CreateOrganization_Call) RunAndReturn(run func(ctx context.Context, name string) (repository.Organization, error)) *MockQuerier_CreateOrganization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatePasswordHistoryEntry provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreatePasswordHistoryEntry(ctx context.Context, arg repository.CreatePasswordHistoryEntryParams) error {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePasswordHistoryEntry")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreatePasswordHistoryEntryParams) error); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_CreatePasswordHistoryEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePasswordHistoryEntry'
+type MockQuerier_CreatePasswordHistoryEntry_Call struct {
+	*mock.Call
+}
+
+// CreatePasswordHistoryEntry is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) CreatePasswordHistoryEntry(ctx interface{}, arg interface{}) *MockQuerier_CreatePasswordHistoryEntry_Call {
+	return &MockQuerier_CreatePasswordHistoryEntry_Call{Call: _e.mock.On("CreatePasswordHistoryEntry", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreatePasswordHistoryEntry_Call) Run(run func(ctx context.Context, arg repository.CreatePasswordHistoryEntryParams)) *MockQuerier_CreatePasswordHistoryEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.CreatePasswordHistoryEntryParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreatePasswordHistoryEntry_Call) Return(err error) *MockQuerier_CreatePasswordHistoryEntry_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_CreatePasswordHistoryEntry_Call) RunAndReturn(run func(ctx context.Context, arg repository.CreatePasswordHistoryEntryParams) error) *MockQuerier_CreatePasswordHistoryEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatePasswordResetToken provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreatePasswordResetToken(ctx context.Context, arg repository.CreatePasswordResetTokenParams) error {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePasswordResetToken")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreatePasswordResetTokenParams) error); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_CreatePasswordResetToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePasswordResetToken'
+type MockQuerier_CreatePasswordResetToken_Call struct {
+	*mock.Call
+}
+
+// CreatePasswordResetToken is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) CreatePasswordResetToken(ctx interface{}, arg interface{}) *MockQuerier_CreatePasswordResetToken_Call {
+	return &MockQuerier_CreatePasswordResetToken_Call{Call: _e.mock.On("CreatePasswordResetToken", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreatePasswordResetToken_Call) Run(run func(ctx context.Context, arg repository.CreatePasswordResetTokenParams)) *MockQuerier_CreatePasswordResetToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.CreatePasswordResetTokenParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreatePasswordResetToken_Call) Return(err error) *MockQuerier_CreatePasswordResetToken_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_CreatePasswordResetToken_Call) RunAndReturn(run func(ctx context.Context, arg repository.CreatePasswordResetTokenParams) error) *MockQuerier_CreatePasswordResetToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatePersonalAccessToken provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreatePersonalAccessToken(ctx context.Context, arg repository.CreatePersonalAccessTokenParams) (repository.PersonalAccessToken, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePersonalAccessToken")
+	}
+
+	var r0 repository.PersonalAccessToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreatePersonalAccessTokenParams) (repository.PersonalAccessToken, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreatePersonalAccessTokenParams) repository.PersonalAccessToken); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.PersonalAccessToken)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.CreatePersonalAccessTokenParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_CreatePersonalAccessToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePersonalAccessToken'
+type MockQuerier_CreatePersonalAccessToken_Call struct {
+	*mock.Call
+}
+
+// CreatePersonalAccessToken is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) CreatePersonalAccessToken(ctx interface{}, arg interface{}) *MockQuerier_CreatePersonalAccessToken_Call {
+	return &MockQuerier_CreatePersonalAccessToken_Call{Call: _e.mock.On("CreatePersonalAccessToken", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreatePersonalAccessToken_Call) Run(run func(ctx context.Context, arg repository.CreatePersonalAccessTokenParams)) *MockQuerier_CreatePersonalAccessToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.CreatePersonalAccessTokenParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreatePersonalAccessToken_Call) Return(personalAccessToken repository.PersonalAccessToken, err error) *MockQuerier_CreatePersonalAccessToken_Call {
+	_c.Call.Return(personalAccessToken, err)
+	return _c
+}
+
+func (_c *MockQuerier_CreatePersonalAccessToken_Call) RunAndReturn(run func(ctx context.Context, arg repository.CreatePersonalAccessTokenParams) (repository.PersonalAccessToken, error)) *MockQuerier_CreatePersonalAccessToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateRecoveryCode provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreateRecoveryCode(ctx context.Context, arg repository.CreateRecoveryCodeParams) error {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRecoveryCode")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreateRecoveryCodeParams) error); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_CreateRecoveryCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRecoveryCode'
+type MockQuerier_CreateRecoveryCode_Call struct {
+	*mock.Call
+}
+
+// CreateRecoveryCode is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) CreateRecoveryCode(ctx interface{}, arg interface{}) *MockQuerier_CreateRecoveryCode_Call {
+	return &MockQuerier_CreateRecoveryCode_Call{Call: _e.mock.On("CreateRecoveryCode", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateRecoveryCode_Call) Run(run func(ctx context.Context, arg repository.CreateRecoveryCodeParams)) *MockQuerier_CreateRecoveryCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.CreateRecoveryCodeParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateRecoveryCode_Call) Return(err error) *MockQuerier_CreateRecoveryCode_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_CreateRecoveryCode_Call) RunAndReturn(run func(ctx context.Context, arg repository.CreateRecoveryCodeParams) error) *MockQuerier_CreateRecoveryCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateRole provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreateRole(ctx context.Context, arg repository.CreateRoleParams) (repository.Role, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRole")
+	}
+
+	var r0 repository.Role
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreateRoleParams) (repository.Role, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreateRoleParams) repository.Role); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.Role)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.CreateRoleParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_CreateRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRole'
+type MockQuerier_CreateRole_Call struct {
+	*mock.Call
+}
+
+// CreateRole is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) CreateRole(ctx interface{}, arg interface{}) *MockQuerier_CreateRole_Call {
+	return &MockQuerier_CreateRole_Call{Call: _e.mock.On("CreateRole", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateRole_Call) Run(run func(ctx context.Context, arg repository.CreateRoleParams)) *MockQuerier_CreateRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.CreateRoleParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateRole_Call) Return(role repository.Role, err error) *MockQuerier_CreateRole_Call {
+	_c.Call.Return(role, err)
+	return _c
+}
+
+func (_c *MockQuerier_CreateRole_Call) RunAndReturn(run func(ctx context.Context, arg repository.CreateRoleParams) (repository.Role, error)) *MockQuerier_CreateRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateServiceAccount provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreateServiceAccount(ctx context.Context, arg repository.CreateServiceAccountParams) (repository.ServiceAccount, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateServiceAccount")
+	}
+
+	var r0 repository.ServiceAccount
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreateServiceAccountParams) (repository.ServiceAccount, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreateServiceAccountParams) repository.ServiceAccount); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.ServiceAccount)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.CreateServiceAccountParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_CreateServiceAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateServiceAccount'
+type MockQuerier_CreateServiceAccount_Call struct {
+	*mock.Call
+}
+
+// CreateServiceAccount is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) CreateServiceAccount(ctx interface{}, arg interface{}) *MockQuerier_CreateServiceAccount_Call {
+	return &MockQuerier_CreateServiceAccount_Call{Call: _e.mock.On("CreateServiceAccount", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateServiceAccount_Call) Run(run func(ctx context.Context, arg repository.CreateServiceAccountParams)) *MockQuerier_CreateServiceAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.CreateServiceAccountParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateServiceAccount_Call) Return(serviceAccount repository.ServiceAccount, err error) *MockQuerier_CreateServiceAccount_Call {
+	_c.Call.Return(serviceAccount, err)
+	return _c
+}
+
+func (_c *MockQuerier_CreateServiceAccount_Call) RunAndReturn(run func(ctx context.Context, arg repository.CreateServiceAccountParams) (repository.ServiceAccount, error)) *MockQuerier_CreateServiceAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateServiceAccountCredential provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreateServiceAccountCredential(ctx context.Context, arg repository.CreateServiceAccountCredentialParams) (repository.ServiceAccountCredential, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateServiceAccountCredential")
+	}
+
+	var r0 repository.ServiceAccountCredential
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreateServiceAccountCredentialParams) (repository.ServiceAccountCredential, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreateServiceAccountCredentialParams) repository.ServiceAccountCredential); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.ServiceAccountCredential)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.CreateServiceAccountCredentialParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_CreateServiceAccountCredential_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateServiceAccountCredential'
+type MockQuerier_CreateServiceAccountCredential_Call struct {
+	*mock.Call
+}
+
+// CreateServiceAccountCredential is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) CreateServiceAccountCredential(ctx interface{}, arg interface{}) *MockQuerier_CreateServiceAccountCredential_Call {
+	return &MockQuerier_CreateServiceAccountCredential_Call{Call: _e.mock.On("CreateServiceAccountCredential", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateServiceAccountCredential_Call) Run(run func(ctx context.Context, arg repository.CreateServiceAccountCredentialParams)) *MockQuerier_CreateServiceAccountCredential_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.CreateServiceAccountCredentialParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateServiceAccountCredential_Call) Return(serviceAccountCredential repository.ServiceAccountCredential, err error) *MockQuerier_CreateServiceAccountCredential_Call {
+	_c.Call.Return(serviceAccountCredential, err)
+	return _c
+}
+
+func (_c *MockQuerier_CreateServiceAccountCredential_Call) RunAndReturn(run func(ctx context.Context, arg repository.CreateServiceAccountCredentialParams) (repository.ServiceAccountCredential, error)) *MockQuerier_CreateServiceAccountCredential_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateSession provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreateSession(ctx context.Context, arg repository.CreateSessionParams) (repository.Session, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSession")
+	}
+
+	var r0 repository.Session
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreateSessionParams) (repository.Session, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreateSessionParams) repository.Session); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.Session)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.CreateSessionParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_CreateSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSession'
+type MockQuerier_CreateSession_Call struct {
+	*mock.Call
+}
+
+// CreateSession is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) CreateSession(ctx interface{}, arg interface{}) *MockQuerier_CreateSession_Call {
+	return &MockQuerier_CreateSession_Call{Call: _e.mock.On("CreateSession", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateSession_Call) Run(run func(ctx context.Context, arg repository.CreateSessionParams)) *MockQuerier_CreateSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.CreateSessionParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateSession_Call) Return(session repository.Session, err error) *MockQuerier_CreateSession_Call {
+	_c.Call.Return(session, err)
+	return _c
+}
+
+func (_c *MockQuerier_CreateSession_Call) RunAndReturn(run func(ctx context.Context, arg repository.CreateSessionParams) (repository.Session, error)) *MockQuerier_CreateSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateUser provides a mock function for the type MockQuerier
 func (_mock *MockQuerier) CreateUser(ctx context.Context, arg repository.CreateUserParams) (repository.User, error) {
 	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateUser")
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 repository.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreateUserParams) (repository.User, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreateUserParams) repository.User); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.CreateUserParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
+type MockQuerier_CreateUser_Call struct {
+	*mock.Call
+}
+
+// CreateUser is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) CreateUser(ctx interface{}, arg interface{}) *MockQuerier_CreateUser_Call {
+	return &MockQuerier_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateUser_Call) Run(run func(ctx context.Context, arg repository.CreateUserParams)) *MockQuerier_CreateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.CreateUserParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateUser_Call) Return(user repository.User, err error) *MockQuerier_CreateUser_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockQuerier_CreateUser_Call) RunAndReturn(run func(ctx context.Context, arg repository.CreateUserParams) (repository.User, error)) *MockQuerier_CreateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWebauthnChallenge provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreateWebauthnChallenge(ctx context.Context, arg repository.CreateWebauthnChallengeParams) (repository.WebauthnChallenge, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebauthnChallenge")
+	}
+
+	var r0 repository.WebauthnChallenge
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreateWebauthnChallengeParams) (repository.WebauthnChallenge, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreateWebauthnChallengeParams) repository.WebauthnChallenge); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.WebauthnChallenge)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.CreateWebauthnChallengeParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_CreateWebauthnChallenge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebauthnChallenge'
+type MockQuerier_CreateWebauthnChallenge_Call struct {
+	*mock.Call
+}
+
+// CreateWebauthnChallenge is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) CreateWebauthnChallenge(ctx interface{}, arg interface{}) *MockQuerier_CreateWebauthnChallenge_Call {
+	return &MockQuerier_CreateWebauthnChallenge_Call{Call: _e.mock.On("CreateWebauthnChallenge", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateWebauthnChallenge_Call) Run(run func(ctx context.Context, arg repository.CreateWebauthnChallengeParams)) *MockQuerier_CreateWebauthnChallenge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.CreateWebauthnChallengeParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateWebauthnChallenge_Call) Return(webauthnChallenge repository.WebauthnChallenge, err error) *MockQuerier_CreateWebauthnChallenge_Call {
+	_c.Call.Return(webauthnChallenge, err)
+	return _c
+}
+
+func (_c *MockQuerier_CreateWebauthnChallenge_Call) RunAndReturn(run func(ctx context.Context, arg repository.CreateWebauthnChallengeParams) (repository.WebauthnChallenge, error)) *MockQuerier_CreateWebauthnChallenge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWebauthnCredential provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreateWebauthnCredential(ctx context.Context, arg repository.CreateWebauthnCredentialParams) (repository.WebauthnCredential, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebauthnCredential")
+	}
+
+	var r0 repository.WebauthnCredential
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreateWebauthnCredentialParams) (repository.WebauthnCredential, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreateWebauthnCredentialParams) repository.WebauthnCredential); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.WebauthnCredential)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.CreateWebauthnCredentialParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_CreateWebauthnCredential_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebauthnCredential'
+type MockQuerier_CreateWebauthnCredential_Call struct {
+	*mock.Call
+}
+
+// CreateWebauthnCredential is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) CreateWebauthnCredential(ctx interface{}, arg interface{}) *MockQuerier_CreateWebauthnCredential_Call {
+	return &MockQuerier_CreateWebauthnCredential_Call{Call: _e.mock.On("CreateWebauthnCredential", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateWebauthnCredential_Call) Run(run func(ctx context.Context, arg repository.CreateWebauthnCredentialParams)) *MockQuerier_CreateWebauthnCredential_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.CreateWebauthnCredentialParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateWebauthnCredential_Call) Return(webauthnCredential repository.WebauthnCredential, err error) *MockQuerier_CreateWebauthnCredential_Call {
+	_c.Call.Return(webauthnCredential, err)
+	return _c
+}
+
+func (_c *MockQuerier_CreateWebauthnCredential_Call) RunAndReturn(run func(ctx context.Context, arg repository.CreateWebauthnCredentialParams) (repository.WebauthnCredential, error)) *MockQuerier_CreateWebauthnCredential_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteEmailChange provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) DeleteEmailChange(ctx context.Context, id pgtype.UUID) (int64, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEmailChange")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (int64, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) int64); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_DeleteEmailChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEmailChange'
+type MockQuerier_DeleteEmailChange_Call struct {
+	*mock.Call
+}
+
+// DeleteEmailChange is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockQuerier_Expecter) DeleteEmailChange(ctx interface{}, id interface{}) *MockQuerier_DeleteEmailChange_Call {
+	return &MockQuerier_DeleteEmailChange_Call{Call: _e.mock.On("DeleteEmailChange", ctx, id)}
+}
+
+func (_c *MockQuerier_DeleteEmailChange_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockQuerier_DeleteEmailChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteEmailChange_Call) Return(n int64, err error) *MockQuerier_DeleteEmailChange_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteEmailChange_Call) RunAndReturn(run func(ctx context.Context, id pgtype.UUID) (int64, error)) *MockQuerier_DeleteEmailChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteEmailVerificationTokensByUserId provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) DeleteEmailVerificationTokensByUserId(ctx context.Context, userID pgtype.UUID) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEmailVerificationTokensByUserId")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_DeleteEmailVerificationTokensByUserId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEmailVerificationTokensByUserId'
+type MockQuerier_DeleteEmailVerificationTokensByUserId_Call struct {
+	*mock.Call
+}
+
+// DeleteEmailVerificationTokensByUserId is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockQuerier_Expecter) DeleteEmailVerificationTokensByUserId(ctx interface{}, userID interface{}) *MockQuerier_DeleteEmailVerificationTokensByUserId_Call {
+	return &MockQuerier_DeleteEmailVerificationTokensByUserId_Call{Call: _e.mock.On("DeleteEmailVerificationTokensByUserId", ctx, userID)}
+}
+
+func (_c *MockQuerier_DeleteEmailVerificationTokensByUserId_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockQuerier_DeleteEmailVerificationTokensByUserId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteEmailVerificationTokensByUserId_Call) Return(err error) *MockQuerier_DeleteEmailVerificationTokensByUserId_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteEmailVerificationTokensByUserId_Call) RunAndReturn(run func(ctx context.Context, userID pgtype.UUID) error) *MockQuerier_DeleteEmailVerificationTokensByUserId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteExpiredClientAssertions provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) DeleteExpiredClientAssertions(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpiredClientAssertions")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_DeleteExpiredClientAssertions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExpiredClientAssertions'
+type MockQuerier_DeleteExpiredClientAssertions_Call struct {
+	*mock.Call
+}
+
+// DeleteExpiredClientAssertions is a helper method to define mock.On call
+//   - ctx
+func (_e *MockQuerier_Expecter) DeleteExpiredClientAssertions(ctx interface{}) *MockQuerier_DeleteExpiredClientAssertions_Call {
+	return &MockQuerier_DeleteExpiredClientAssertions_Call{Call: _e.mock.On("DeleteExpiredClientAssertions", ctx)}
+}
+
+func (_c *MockQuerier_DeleteExpiredClientAssertions_Call) Run(run func(ctx context.Context)) *MockQuerier_DeleteExpiredClientAssertions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteExpiredClientAssertions_Call) Return(err error) *MockQuerier_DeleteExpiredClientAssertions_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteExpiredClientAssertions_Call) RunAndReturn(run func(ctx context.Context) error) *MockQuerier_DeleteExpiredClientAssertions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteExpiredRevokedTokens provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) DeleteExpiredRevokedTokens(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpiredRevokedTokens")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_DeleteExpiredRevokedTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExpiredRevokedTokens'
+type MockQuerier_DeleteExpiredRevokedTokens_Call struct {
+	*mock.Call
+}
+
+// DeleteExpiredRevokedTokens is a helper method to define mock.On call
+//   - ctx
+func (_e *MockQuerier_Expecter) DeleteExpiredRevokedTokens(ctx interface{}) *MockQuerier_DeleteExpiredRevokedTokens_Call {
+	return &MockQuerier_DeleteExpiredRevokedTokens_Call{Call: _e.mock.On("DeleteExpiredRevokedTokens", ctx)}
+}
+
+func (_c *MockQuerier_DeleteExpiredRevokedTokens_Call) Run(run func(ctx context.Context)) *MockQuerier_DeleteExpiredRevokedTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteExpiredRevokedTokens_Call) Return(err error) *MockQuerier_DeleteExpiredRevokedTokens_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteExpiredRevokedTokens_Call) RunAndReturn(run func(ctx context.Context) error) *MockQuerier_DeleteExpiredRevokedTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteExpiredWebauthnChallenges provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) DeleteExpiredWebauthnChallenges(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpiredWebauthnChallenges")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_DeleteExpiredWebauthnChallenges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExpiredWebauthnChallenges'
+type MockQuerier_DeleteExpiredWebauthnChallenges_Call struct {
+	*mock.Call
+}
+
+// DeleteExpiredWebauthnChallenges is a helper method to define mock.On call
+//   - ctx
+func (_e *MockQuerier_Expecter) DeleteExpiredWebauthnChallenges(ctx interface{}) *MockQuerier_DeleteExpiredWebauthnChallenges_Call {
+	return &MockQuerier_DeleteExpiredWebauthnChallenges_Call{Call: _e.mock.On("DeleteExpiredWebauthnChallenges", ctx)}
+}
+
+func (_c *MockQuerier_DeleteExpiredWebauthnChallenges_Call) Run(run func(ctx context.Context)) *MockQuerier_DeleteExpiredWebauthnChallenges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteExpiredWebauthnChallenges_Call) Return(err error) *MockQuerier_DeleteExpiredWebauthnChallenges_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteExpiredWebauthnChallenges_Call) RunAndReturn(run func(ctx context.Context) error) *MockQuerier_DeleteExpiredWebauthnChallenges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteInvitation provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) DeleteInvitation(ctx context.Context, id pgtype.UUID) (int64, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteInvitation")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (int64, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) int64); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_DeleteInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteInvitation'
+type MockQuerier_DeleteInvitation_Call struct {
+	*mock.Call
+}
+
+// DeleteInvitation is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockQuerier_Expecter) DeleteInvitation(ctx interface{}, id interface{}) *MockQuerier_DeleteInvitation_Call {
+	return &MockQuerier_DeleteInvitation_Call{Call: _e.mock.On("DeleteInvitation", ctx, id)}
+}
+
+func (_c *MockQuerier_DeleteInvitation_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockQuerier_DeleteInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteInvitation_Call) Return(n int64, err error) *MockQuerier_DeleteInvitation_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteInvitation_Call) RunAndReturn(run func(ctx context.Context, id pgtype.UUID) (int64, error)) *MockQuerier_DeleteInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteLoginThrottle provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) DeleteLoginThrottle(ctx context.Context, arg repository.DeleteLoginThrottleParams) (int64, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLoginThrottle")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.DeleteLoginThrottleParams) (int64, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.DeleteLoginThrottleParams) int64); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.DeleteLoginThrottleParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_DeleteLoginThrottle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteLoginThrottle'
+type MockQuerier_DeleteLoginThrottle_Call struct {
+	*mock.Call
+}
+
+// DeleteLoginThrottle is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) DeleteLoginThrottle(ctx interface{}, arg interface{}) *MockQuerier_DeleteLoginThrottle_Call {
+	return &MockQuerier_DeleteLoginThrottle_Call{Call: _e.mock.On("DeleteLoginThrottle", ctx, arg)}
+}
+
+func (_c *MockQuerier_DeleteLoginThrottle_Call) Run(run func(ctx context.Context, arg repository.DeleteLoginThrottleParams)) *MockQuerier_DeleteLoginThrottle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.DeleteLoginThrottleParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteLoginThrottle_Call) Return(n int64, err error) *MockQuerier_DeleteLoginThrottle_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteLoginThrottle_Call) RunAndReturn(run func(ctx context.Context, arg repository.DeleteLoginThrottleParams) (int64, error)) *MockQuerier_DeleteLoginThrottle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMembership provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) DeleteMembership(ctx context.Context, arg repository.DeleteMembershipParams) (int64, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMembership")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.DeleteMembershipParams) (int64, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.DeleteMembershipParams) int64); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.DeleteMembershipParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_DeleteMembership_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMembership'
+type MockQuerier_DeleteMembership_Call struct {
+	*mock.Call
+}
+
+// DeleteMembership is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) DeleteMembership(ctx interface{}, arg interface{}) *MockQuerier_DeleteMembership_Call {
+	return &MockQuerier_DeleteMembership_Call{Call: _e.mock.On("DeleteMembership", ctx, arg)}
+}
+
+func (_c *MockQuerier_DeleteMembership_Call) Run(run func(ctx context.Context, arg repository.DeleteMembershipParams)) *MockQuerier_DeleteMembership_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.DeleteMembershipParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteMembership_Call) Return(n int64, err error) *MockQuerier_DeleteMembership_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteMembership_Call) RunAndReturn(run func(ctx context.Context, arg repository.DeleteMembershipParams) (int64, error)) *MockQuerier_DeleteMembership_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletePasswordResetTokensByUserId provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) DeletePasswordResetTokensByUserId(ctx context.Context, userID pgtype.UUID) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePasswordResetTokensByUserId")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_DeletePasswordResetTokensByUserId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePasswordResetTokensByUserId'
+type MockQuerier_DeletePasswordResetTokensByUserId_Call struct {
+	*mock.Call
+}
+
+// DeletePasswordResetTokensByUserId is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockQuerier_Expecter) DeletePasswordResetTokensByUserId(ctx interface{}, userID interface{}) *MockQuerier_DeletePasswordResetTokensByUserId_Call {
+	return &MockQuerier_DeletePasswordResetTokensByUserId_Call{Call: _e.mock.On("DeletePasswordResetTokensByUserId", ctx, userID)}
+}
+
+func (_c *MockQuerier_DeletePasswordResetTokensByUserId_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockQuerier_DeletePasswordResetTokensByUserId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeletePasswordResetTokensByUserId_Call) Return(err error) *MockQuerier_DeletePasswordResetTokensByUserId_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_DeletePasswordResetTokensByUserId_Call) RunAndReturn(run func(ctx context.Context, userID pgtype.UUID) error) *MockQuerier_DeletePasswordResetTokensByUserId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletePendingEmailChangesByUserId provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) DeletePendingEmailChangesByUserId(ctx context.Context, userID pgtype.UUID) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePendingEmailChangesByUserId")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_DeletePendingEmailChangesByUserId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePendingEmailChangesByUserId'
+type MockQuerier_DeletePendingEmailChangesByUserId_Call struct {
+	*mock.Call
+}
+
+// DeletePendingEmailChangesByUserId is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockQuerier_Expecter) DeletePendingEmailChangesByUserId(ctx interface{}, userID interface{}) *MockQuerier_DeletePendingEmailChangesByUserId_Call {
+	return &MockQuerier_DeletePendingEmailChangesByUserId_Call{Call: _e.mock.On("DeletePendingEmailChangesByUserId", ctx, userID)}
+}
+
+func (_c *MockQuerier_DeletePendingEmailChangesByUserId_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockQuerier_DeletePendingEmailChangesByUserId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeletePendingEmailChangesByUserId_Call) Return(err error) *MockQuerier_DeletePendingEmailChangesByUserId_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_DeletePendingEmailChangesByUserId_Call) RunAndReturn(run func(ctx context.Context, userID pgtype.UUID) error) *MockQuerier_DeletePendingEmailChangesByUserId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletePersonalAccessToken provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) DeletePersonalAccessToken(ctx context.Context, arg repository.DeletePersonalAccessTokenParams) (int64, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePersonalAccessToken")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.DeletePersonalAccessTokenParams) (int64, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.DeletePersonalAccessTokenParams) int64); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.DeletePersonalAccessTokenParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_DeletePersonalAccessToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePersonalAccessToken'
+type MockQuerier_DeletePersonalAccessToken_Call struct {
+	*mock.Call
+}
+
+// DeletePersonalAccessToken is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) DeletePersonalAccessToken(ctx interface{}, arg interface{}) *MockQuerier_DeletePersonalAccessToken_Call {
+	return &MockQuerier_DeletePersonalAccessToken_Call{Call: _e.mock.On("DeletePersonalAccessToken", ctx, arg)}
+}
+
+func (_c *MockQuerier_DeletePersonalAccessToken_Call) Run(run func(ctx context.Context, arg repository.DeletePersonalAccessTokenParams)) *MockQuerier_DeletePersonalAccessToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.DeletePersonalAccessTokenParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeletePersonalAccessToken_Call) Return(n int64, err error) *MockQuerier_DeletePersonalAccessToken_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockQuerier_DeletePersonalAccessToken_Call) RunAndReturn(run func(ctx context.Context, arg repository.DeletePersonalAccessTokenParams) (int64, error)) *MockQuerier_DeletePersonalAccessToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRecoveryCodes provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) DeleteRecoveryCodes(ctx context.Context, userID pgtype.UUID) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRecoveryCodes")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_DeleteRecoveryCodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRecoveryCodes'
+type MockQuerier_DeleteRecoveryCodes_Call struct {
+	*mock.Call
+}
+
+// DeleteRecoveryCodes is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockQuerier_Expecter) DeleteRecoveryCodes(ctx interface{}, userID interface{}) *MockQuerier_DeleteRecoveryCodes_Call {
+	return &MockQuerier_DeleteRecoveryCodes_Call{Call: _e.mock.On("DeleteRecoveryCodes", ctx, userID)}
+}
+
+func (_c *MockQuerier_DeleteRecoveryCodes_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockQuerier_DeleteRecoveryCodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteRecoveryCodes_Call) Return(err error) *MockQuerier_DeleteRecoveryCodes_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteRecoveryCodes_Call) RunAndReturn(run func(ctx context.Context, userID pgtype.UUID) error) *MockQuerier_DeleteRecoveryCodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRole provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) DeleteRole(ctx context.Context, name string) (int64, error) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRole")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_DeleteRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRole'
+type MockQuerier_DeleteRole_Call struct {
+	*mock.Call
+}
+
+// DeleteRole is a helper method to define mock.On call
+//   - ctx
+//   - name
+func (_e *MockQuerier_Expecter) DeleteRole(ctx interface{}, name interface{}) *MockQuerier_DeleteRole_Call {
+	return &MockQuerier_DeleteRole_Call{Call: _e.mock.On("DeleteRole", ctx, name)}
+}
+
+func (_c *MockQuerier_DeleteRole_Call) Run(run func(ctx context.Context, name string)) *MockQuerier_DeleteRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteRole_Call) Return(n int64, err error) *MockQuerier_DeleteRole_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteRole_Call) RunAndReturn(run func(ctx context.Context, name string) (int64, error)) *MockQuerier_DeleteRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteServiceAccountCredential provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) DeleteServiceAccountCredential(ctx context.Context, arg repository.DeleteServiceAccountCredentialParams) (int64, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteServiceAccountCredential")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.DeleteServiceAccountCredentialParams) (int64, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.DeleteServiceAccountCredentialParams) int64); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.DeleteServiceAccountCredentialParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_DeleteServiceAccountCredential_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteServiceAccountCredential'
+type MockQuerier_DeleteServiceAccountCredential_Call struct {
+	*mock.Call
+}
+
+// DeleteServiceAccountCredential is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) DeleteServiceAccountCredential(ctx interface{}, arg interface{}) *MockQuerier_DeleteServiceAccountCredential_Call {
+	return &MockQuerier_DeleteServiceAccountCredential_Call{Call: _e.mock.On("DeleteServiceAccountCredential", ctx, arg)}
+}
+
+func (_c *MockQuerier_DeleteServiceAccountCredential_Call) Run(run func(ctx context.Context, arg repository.DeleteServiceAccountCredentialParams)) *MockQuerier_DeleteServiceAccountCredential_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.DeleteServiceAccountCredentialParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteServiceAccountCredential_Call) Return(n int64, err error) *MockQuerier_DeleteServiceAccountCredential_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteServiceAccountCredential_Call) RunAndReturn(run func(ctx context.Context, arg repository.DeleteServiceAccountCredentialParams) (int64, error)) *MockQuerier_DeleteServiceAccountCredential_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUser provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) DeleteUser(ctx context.Context, id pgtype.UUID) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
+type MockQuerier_DeleteUser_Call struct {
+	*mock.Call
+}
+
+// DeleteUser is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockQuerier_Expecter) DeleteUser(ctx interface{}, id interface{}) *MockQuerier_DeleteUser_Call {
+	return &MockQuerier_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, id)}
+}
+
+func (_c *MockQuerier_DeleteUser_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockQuerier_DeleteUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteUser_Call) Return(err error) *MockQuerier_DeleteUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteUser_Call) RunAndReturn(run func(ctx context.Context, id pgtype.UUID) error) *MockQuerier_DeleteUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUserMfa provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) DeleteUserMfa(ctx context.Context, userID pgtype.UUID) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUserMfa")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_DeleteUserMfa_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUserMfa'
+type MockQuerier_DeleteUserMfa_Call struct {
+	*mock.Call
+}
+
+// DeleteUserMfa is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockQuerier_Expecter) DeleteUserMfa(ctx interface{}, userID interface{}) *MockQuerier_DeleteUserMfa_Call {
+	return &MockQuerier_DeleteUserMfa_Call{Call: _e.mock.On("DeleteUserMfa", ctx, userID)}
+}
+
+func (_c *MockQuerier_DeleteUserMfa_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockQuerier_DeleteUserMfa_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteUserMfa_Call) Return(err error) *MockQuerier_DeleteUserMfa_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteUserMfa_Call) RunAndReturn(run func(ctx context.Context, userID pgtype.UUID) error) *MockQuerier_DeleteUserMfa_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebauthnCredential provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) DeleteWebauthnCredential(ctx context.Context, arg repository.DeleteWebauthnCredentialParams) (int64, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebauthnCredential")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.DeleteWebauthnCredentialParams) (int64, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.DeleteWebauthnCredentialParams) int64); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.DeleteWebauthnCredentialParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_DeleteWebauthnCredential_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebauthnCredential'
+type MockQuerier_DeleteWebauthnCredential_Call struct {
+	*mock.Call
+}
+
+// DeleteWebauthnCredential is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) DeleteWebauthnCredential(ctx interface{}, arg interface{}) *MockQuerier_DeleteWebauthnCredential_Call {
+	return &MockQuerier_DeleteWebauthnCredential_Call{Call: _e.mock.On("DeleteWebauthnCredential", ctx, arg)}
+}
+
+func (_c *MockQuerier_DeleteWebauthnCredential_Call) Run(run func(ctx context.Context, arg repository.DeleteWebauthnCredentialParams)) *MockQuerier_DeleteWebauthnCredential_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.DeleteWebauthnCredentialParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteWebauthnCredential_Call) Return(n int64, err error) *MockQuerier_DeleteWebauthnCredential_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteWebauthnCredential_Call) RunAndReturn(run func(ctx context.Context, arg repository.DeleteWebauthnCredentialParams) (int64, error)) *MockQuerier_DeleteWebauthnCredential_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DropAllUsers provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) DropAllUsers(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DropAllUsers")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_DropAllUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DropAllUsers'
+type MockQuerier_DropAllUsers_Call struct {
+	*mock.Call
+}
+
+// DropAllUsers is a helper method to define mock.On call
+//   - ctx
+func (_e *MockQuerier_Expecter) DropAllUsers(ctx interface{}) *MockQuerier_DropAllUsers_Call {
+	return &MockQuerier_DropAllUsers_Call{Call: _e.mock.On("DropAllUsers", ctx)}
+}
+
+func (_c *MockQuerier_DropAllUsers_Call) Run(run func(ctx context.Context)) *MockQuerier_DropAllUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DropAllUsers_Call) Return(err error) *MockQuerier_DropAllUsers_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_DropAllUsers_Call) RunAndReturn(run func(ctx context.Context) error) *MockQuerier_DropAllUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnableUserMfa provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) EnableUserMfa(ctx context.Context, userID pgtype.UUID) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnableUserMfa")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_EnableUserMfa_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnableUserMfa'
+type MockQuerier_EnableUserMfa_Call struct {
+	*mock.Call
+}
+
+// EnableUserMfa is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockQuerier_Expecter) EnableUserMfa(ctx interface{}, userID interface{}) *MockQuerier_EnableUserMfa_Call {
+	return &MockQuerier_EnableUserMfa_Call{Call: _e.mock.On("EnableUserMfa", ctx, userID)}
+}
+
+func (_c *MockQuerier_EnableUserMfa_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockQuerier_EnableUserMfa_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_EnableUserMfa_Call) Return(err error) *MockQuerier_EnableUserMfa_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_EnableUserMfa_Call) RunAndReturn(run func(ctx context.Context, userID pgtype.UUID) error) *MockQuerier_EnableUserMfa_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEmailChangeByRevertToken provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetEmailChangeByRevertToken(ctx context.Context, revertTokenHash string) (repository.EmailChange, error) {
+	ret := _mock.Called(ctx, revertTokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEmailChangeByRevertToken")
+	}
+
+	var r0 repository.EmailChange
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (repository.EmailChange, error)); ok {
+		return returnFunc(ctx, revertTokenHash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) repository.EmailChange); ok {
+		r0 = returnFunc(ctx, revertTokenHash)
+	} else {
+		r0 = ret.Get(0).(repository.EmailChange)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, revertTokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetEmailChangeByRevertToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEmailChangeByRevertToken'
+type MockQuerier_GetEmailChangeByRevertToken_Call struct {
+	*mock.Call
+}
+
+// GetEmailChangeByRevertToken is a helper method to define mock.On call
+//   - ctx
+//   - revertTokenHash
+func (_e *MockQuerier_Expecter) GetEmailChangeByRevertToken(ctx interface{}, revertTokenHash interface{}) *MockQuerier_GetEmailChangeByRevertToken_Call {
+	return &MockQuerier_GetEmailChangeByRevertToken_Call{Call: _e.mock.On("GetEmailChangeByRevertToken", ctx, revertTokenHash)}
+}
+
+func (_c *MockQuerier_GetEmailChangeByRevertToken_Call) Run(run func(ctx context.Context, revertTokenHash string)) *MockQuerier_GetEmailChangeByRevertToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetEmailChangeByRevertToken_Call) Return(emailChange repository.EmailChange, err error) *MockQuerier_GetEmailChangeByRevertToken_Call {
+	_c.Call.Return(emailChange, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetEmailChangeByRevertToken_Call) RunAndReturn(run func(ctx context.Context, revertTokenHash string) (repository.EmailChange, error)) *MockQuerier_GetEmailChangeByRevertToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInvitationById provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetInvitationById(ctx context.Context, id pgtype.UUID) (repository.Invitation, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInvitationById")
+	}
+
+	var r0 repository.Invitation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (repository.Invitation, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) repository.Invitation); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(repository.Invitation)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetInvitationById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInvitationById'
+type MockQuerier_GetInvitationById_Call struct {
+	*mock.Call
+}
+
+// GetInvitationById is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockQuerier_Expecter) GetInvitationById(ctx interface{}, id interface{}) *MockQuerier_GetInvitationById_Call {
+	return &MockQuerier_GetInvitationById_Call{Call: _e.mock.On("GetInvitationById", ctx, id)}
+}
+
+func (_c *MockQuerier_GetInvitationById_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockQuerier_GetInvitationById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetInvitationById_Call) Return(invitation repository.Invitation, err error) *MockQuerier_GetInvitationById_Call {
+	_c.Call.Return(invitation, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetInvitationById_Call) RunAndReturn(run func(ctx context.Context, id pgtype.UUID) (repository.Invitation, error)) *MockQuerier_GetInvitationById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLatestEmailVerificationToken provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetLatestEmailVerificationToken(ctx context.Context, userID pgtype.UUID) (repository.EmailVerificationToken, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestEmailVerificationToken")
+	}
+
+	var r0 repository.EmailVerificationToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (repository.EmailVerificationToken, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) repository.EmailVerificationToken); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(repository.EmailVerificationToken)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetLatestEmailVerificationToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLatestEmailVerificationToken'
+type MockQuerier_GetLatestEmailVerificationToken_Call struct {
+	*mock.Call
+}
+
+// GetLatestEmailVerificationToken is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockQuerier_Expecter) GetLatestEmailVerificationToken(ctx interface{}, userID interface{}) *MockQuerier_GetLatestEmailVerificationToken_Call {
+	return &MockQuerier_GetLatestEmailVerificationToken_Call{Call: _e.mock.On("GetLatestEmailVerificationToken", ctx, userID)}
+}
+
+func (_c *MockQuerier_GetLatestEmailVerificationToken_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockQuerier_GetLatestEmailVerificationToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetLatestEmailVerificationToken_Call) Return(emailVerificationToken repository.EmailVerificationToken, err error) *MockQuerier_GetLatestEmailVerificationToken_Call {
+	_c.Call.Return(emailVerificationToken, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetLatestEmailVerificationToken_Call) RunAndReturn(run func(ctx context.Context, userID pgtype.UUID) (repository.EmailVerificationToken, error)) *MockQuerier_GetLatestEmailVerificationToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLatestPasswordResetToken provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetLatestPasswordResetToken(ctx context.Context, userID pgtype.UUID) (repository.PasswordResetToken, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestPasswordResetToken")
+	}
+
+	var r0 repository.PasswordResetToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (repository.PasswordResetToken, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) repository.PasswordResetToken); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(repository.PasswordResetToken)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetLatestPasswordResetToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLatestPasswordResetToken'
+type MockQuerier_GetLatestPasswordResetToken_Call struct {
+	*mock.Call
+}
+
+// GetLatestPasswordResetToken is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockQuerier_Expecter) GetLatestPasswordResetToken(ctx interface{}, userID interface{}) *MockQuerier_GetLatestPasswordResetToken_Call {
+	return &MockQuerier_GetLatestPasswordResetToken_Call{Call: _e.mock.On("GetLatestPasswordResetToken", ctx, userID)}
+}
+
+func (_c *MockQuerier_GetLatestPasswordResetToken_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockQuerier_GetLatestPasswordResetToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetLatestPasswordResetToken_Call) Return(passwordResetToken repository.PasswordResetToken, err error) *MockQuerier_GetLatestPasswordResetToken_Call {
+	_c.Call.Return(passwordResetToken, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetLatestPasswordResetToken_Call) RunAndReturn(run func(ctx context.Context, userID pgtype.UUID) (repository.PasswordResetToken, error)) *MockQuerier_GetLatestPasswordResetToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLoginThrottle provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetLoginThrottle(ctx context.Context, arg repository.GetLoginThrottleParams) (repository.LoginThrottle, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoginThrottle")
+	}
+
+	var r0 repository.LoginThrottle
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.GetLoginThrottleParams) (repository.LoginThrottle, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.GetLoginThrottleParams) repository.LoginThrottle); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.LoginThrottle)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.GetLoginThrottleParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetLoginThrottle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoginThrottle'
+type MockQuerier_GetLoginThrottle_Call struct {
+	*mock.Call
+}
+
+// GetLoginThrottle is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) GetLoginThrottle(ctx interface{}, arg interface{}) *MockQuerier_GetLoginThrottle_Call {
+	return &MockQuerier_GetLoginThrottle_Call{Call: _e.mock.On("GetLoginThrottle", ctx, arg)}
+}
+
+func (_c *MockQuerier_GetLoginThrottle_Call) Run(run func(ctx context.Context, arg repository.GetLoginThrottleParams)) *MockQuerier_GetLoginThrottle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.GetLoginThrottleParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetLoginThrottle_Call) Return(loginThrottle repository.LoginThrottle, err error) *MockQuerier_GetLoginThrottle_Call {
+	_c.Call.Return(loginThrottle, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetLoginThrottle_Call) RunAndReturn(run func(ctx context.Context, arg repository.GetLoginThrottleParams) (repository.LoginThrottle, error)) *MockQuerier_GetLoginThrottle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMembership provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetMembership(ctx context.Context, arg repository.GetMembershipParams) (repository.GetMembershipRow, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMembership")
+	}
+
+	var r0 repository.GetMembershipRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.GetMembershipParams) (repository.GetMembershipRow, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.GetMembershipParams) repository.GetMembershipRow); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.GetMembershipRow)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.GetMembershipParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetMembership_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMembership'
+type MockQuerier_GetMembership_Call struct {
+	*mock.Call
+}
+
+// GetMembership is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) GetMembership(ctx interface{}, arg interface{}) *MockQuerier_GetMembership_Call {
+	return &MockQuerier_GetMembership_Call{Call: _e.mock.On("GetMembership", ctx, arg)}
+}
+
+func (_c *MockQuerier_GetMembership_Call) Run(run func(ctx context.Context, arg repository.GetMembershipParams)) *MockQuerier_GetMembership_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.GetMembershipParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetMembership_Call) Return(getMembershipRow repository.GetMembershipRow, err error) *MockQuerier_GetMembership_Call {
+	_c.Call.Return(getMembershipRow, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetMembership_Call) RunAndReturn(run func(ctx context.Context, arg repository.GetMembershipParams) (repository.GetMembershipRow, error)) *MockQuerier_GetMembership_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOrganizationById provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetOrganizationById(ctx context.Context, id pgtype.UUID) (repository.Organization, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrganizationById")
+	}
+
+	var r0 repository.Organization
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (repository.Organization, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) repository.Organization); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(repository.Organization)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetOrganizationById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrganizationById'
+type MockQuerier_GetOrganizationById_Call struct {
+	*mock.Call
+}
+
+// GetOrganizationById is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockQuerier_Expecter) GetOrganizationById(ctx interface{}, id interface{}) *MockQuerier_GetOrganizationById_Call {
+	return &MockQuerier_GetOrganizationById_Call{Call: _e.mock.On("GetOrganizationById", ctx, id)}
+}
+
+func (_c *MockQuerier_GetOrganizationById_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockQuerier_GetOrganizationById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetOrganizationById_Call) Return(organization repository.Organization, err error) *MockQuerier_GetOrganizationById_Call {
+	_c.Call.Return(organization, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetOrganizationById_Call) RunAndReturn(run func(ctx context.Context, id pgtype.UUID) (repository.Organization, error)) *MockQuerier_GetOrganizationById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPasswordResetToken provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetPasswordResetToken(ctx context.Context, tokenHash string) (repository.PasswordResetToken, error) {
+	ret := _mock.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPasswordResetToken")
+	}
+
+	var r0 repository.PasswordResetToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (repository.PasswordResetToken, error)); ok {
+		return returnFunc(ctx, tokenHash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) repository.PasswordResetToken); ok {
+		r0 = returnFunc(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(repository.PasswordResetToken)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetPasswordResetToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPasswordResetToken'
+type MockQuerier_GetPasswordResetToken_Call struct {
+	*mock.Call
+}
+
+// GetPasswordResetToken is a helper method to define mock.On call
+//   - ctx
+//   - tokenHash
+func (_e *MockQuerier_Expecter) GetPasswordResetToken(ctx interface{}, tokenHash interface{}) *MockQuerier_GetPasswordResetToken_Call {
+	return &MockQuerier_GetPasswordResetToken_Call{Call: _e.mock.On("GetPasswordResetToken", ctx, tokenHash)}
+}
+
+func (_c *MockQuerier_GetPasswordResetToken_Call) Run(run func(ctx context.Context, tokenHash string)) *MockQuerier_GetPasswordResetToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetPasswordResetToken_Call) Return(passwordResetToken repository.PasswordResetToken, err error) *MockQuerier_GetPasswordResetToken_Call {
+	_c.Call.Return(passwordResetToken, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetPasswordResetToken_Call) RunAndReturn(run func(ctx context.Context, tokenHash string) (repository.PasswordResetToken, error)) *MockQuerier_GetPasswordResetToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingEmailChange provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetPendingEmailChange(ctx context.Context, confirmTokenHash string) (repository.EmailChange, error) {
+	ret := _mock.Called(ctx, confirmTokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingEmailChange")
+	}
+
+	var r0 repository.EmailChange
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (repository.EmailChange, error)); ok {
+		return returnFunc(ctx, confirmTokenHash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) repository.EmailChange); ok {
+		r0 = returnFunc(ctx, confirmTokenHash)
+	} else {
+		r0 = ret.Get(0).(repository.EmailChange)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, confirmTokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetPendingEmailChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingEmailChange'
+type MockQuerier_GetPendingEmailChange_Call struct {
+	*mock.Call
+}
+
+// GetPendingEmailChange is a helper method to define mock.On call
+//   - ctx
+//   - confirmTokenHash
+func (_e *MockQuerier_Expecter) GetPendingEmailChange(ctx interface{}, confirmTokenHash interface{}) *MockQuerier_GetPendingEmailChange_Call {
+	return &MockQuerier_GetPendingEmailChange_Call{Call: _e.mock.On("GetPendingEmailChange", ctx, confirmTokenHash)}
+}
+
+func (_c *MockQuerier_GetPendingEmailChange_Call) Run(run func(ctx context.Context, confirmTokenHash string)) *MockQuerier_GetPendingEmailChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetPendingEmailChange_Call) Return(emailChange repository.EmailChange, err error) *MockQuerier_GetPendingEmailChange_Call {
+	_c.Call.Return(emailChange, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetPendingEmailChange_Call) RunAndReturn(run func(ctx context.Context, confirmTokenHash string) (repository.EmailChange, error)) *MockQuerier_GetPendingEmailChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingInvitation provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetPendingInvitation(ctx context.Context, tokenHash string) (repository.Invitation, error) {
+	ret := _mock.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingInvitation")
+	}
+
+	var r0 repository.Invitation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (repository.Invitation, error)); ok {
+		return returnFunc(ctx, tokenHash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) repository.Invitation); ok {
+		r0 = returnFunc(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(repository.Invitation)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetPendingInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingInvitation'
+type MockQuerier_GetPendingInvitation_Call struct {
+	*mock.Call
+}
+
+// GetPendingInvitation is a helper method to define mock.On call
+//   - ctx
+//   - tokenHash
+func (_e *MockQuerier_Expecter) GetPendingInvitation(ctx interface{}, tokenHash interface{}) *MockQuerier_GetPendingInvitation_Call {
+	return &MockQuerier_GetPendingInvitation_Call{Call: _e.mock.On("GetPendingInvitation", ctx, tokenHash)}
+}
+
+func (_c *MockQuerier_GetPendingInvitation_Call) Run(run func(ctx context.Context, tokenHash string)) *MockQuerier_GetPendingInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetPendingInvitation_Call) Return(invitation repository.Invitation, err error) *MockQuerier_GetPendingInvitation_Call {
+	_c.Call.Return(invitation, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetPendingInvitation_Call) RunAndReturn(run func(ctx context.Context, tokenHash string) (repository.Invitation, error)) *MockQuerier_GetPendingInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRecentPasswordHashes provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetRecentPasswordHashes(ctx context.Context, arg repository.GetRecentPasswordHashesParams) ([]string, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecentPasswordHashes")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.GetRecentPasswordHashesParams) ([]string, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.GetRecentPasswordHashesParams) []string); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.GetRecentPasswordHashesParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetRecentPasswordHashes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRecentPasswordHashes'
+type MockQuerier_GetRecentPasswordHashes_Call struct {
+	*mock.Call
+}
+
+// GetRecentPasswordHashes is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) GetRecentPasswordHashes(ctx interface{}, arg interface{}) *MockQuerier_GetRecentPasswordHashes_Call {
+	return &MockQuerier_GetRecentPasswordHashes_Call{Call: _e.mock.On("GetRecentPasswordHashes", ctx, arg)}
+}
+
+func (_c *MockQuerier_GetRecentPasswordHashes_Call) Run(run func(ctx context.Context, arg repository.GetRecentPasswordHashesParams)) *MockQuerier_GetRecentPasswordHashes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.GetRecentPasswordHashesParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetRecentPasswordHashes_Call) Return(ss []string, err error) *MockQuerier_GetRecentPasswordHashes_Call {
+	_c.Call.Return(ss, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetRecentPasswordHashes_Call) RunAndReturn(run func(ctx context.Context, arg repository.GetRecentPasswordHashesParams) ([]string, error)) *MockQuerier_GetRecentPasswordHashes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRoleByName provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetRoleByName(ctx context.Context, name string) (repository.Role, error) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoleByName")
+	}
+
+	var r0 repository.Role
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (repository.Role, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) repository.Role); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		r0 = ret.Get(0).(repository.Role)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetRoleByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoleByName'
+type MockQuerier_GetRoleByName_Call struct {
+	*mock.Call
+}
+
+// GetRoleByName is a helper method to define mock.On call
+//   - ctx
+//   - name
+func (_e *MockQuerier_Expecter) GetRoleByName(ctx interface{}, name interface{}) *MockQuerier_GetRoleByName_Call {
+	return &MockQuerier_GetRoleByName_Call{Call: _e.mock.On("GetRoleByName", ctx, name)}
+}
+
+func (_c *MockQuerier_GetRoleByName_Call) Run(run func(ctx context.Context, name string)) *MockQuerier_GetRoleByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetRoleByName_Call) Return(role repository.Role, err error) *MockQuerier_GetRoleByName_Call {
+	_c.Call.Return(role, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetRoleByName_Call) RunAndReturn(run func(ctx context.Context, name string) (repository.Role, error)) *MockQuerier_GetRoleByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetServiceAccount provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetServiceAccount(ctx context.Context, userID pgtype.UUID) (repository.GetServiceAccountRow, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetServiceAccount")
+	}
+
+	var r0 repository.GetServiceAccountRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (repository.GetServiceAccountRow, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) repository.GetServiceAccountRow); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(repository.GetServiceAccountRow)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetServiceAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServiceAccount'
+type MockQuerier_GetServiceAccount_Call struct {
+	*mock.Call
+}
+
+// GetServiceAccount is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockQuerier_Expecter) GetServiceAccount(ctx interface{}, userID interface{}) *MockQuerier_GetServiceAccount_Call {
+	return &MockQuerier_GetServiceAccount_Call{Call: _e.mock.On("GetServiceAccount", ctx, userID)}
+}
+
+func (_c *MockQuerier_GetServiceAccount_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockQuerier_GetServiceAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetServiceAccount_Call) Return(getServiceAccountRow repository.GetServiceAccountRow, err error) *MockQuerier_GetServiceAccount_Call {
+	_c.Call.Return(getServiceAccountRow, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetServiceAccount_Call) RunAndReturn(run func(ctx context.Context, userID pgtype.UUID) (repository.GetServiceAccountRow, error)) *MockQuerier_GetServiceAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetServiceAccountSecret provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetServiceAccountSecret(ctx context.Context, arg repository.GetServiceAccountSecretParams) (repository.ServiceAccountCredential, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetServiceAccountSecret")
+	}
+
+	var r0 repository.ServiceAccountCredential
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.GetServiceAccountSecretParams) (repository.ServiceAccountCredential, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.GetServiceAccountSecretParams) repository.ServiceAccountCredential); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.ServiceAccountCredential)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.GetServiceAccountSecretParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetServiceAccountSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServiceAccountSecret'
+type MockQuerier_GetServiceAccountSecret_Call struct {
+	*mock.Call
+}
+
+// GetServiceAccountSecret is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) GetServiceAccountSecret(ctx interface{}, arg interface{}) *MockQuerier_GetServiceAccountSecret_Call {
+	return &MockQuerier_GetServiceAccountSecret_Call{Call: _e.mock.On("GetServiceAccountSecret", ctx, arg)}
+}
+
+func (_c *MockQuerier_GetServiceAccountSecret_Call) Run(run func(ctx context.Context, arg repository.GetServiceAccountSecretParams)) *MockQuerier_GetServiceAccountSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.GetServiceAccountSecretParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetServiceAccountSecret_Call) Return(serviceAccountCredential repository.ServiceAccountCredential, err error) *MockQuerier_GetServiceAccountSecret_Call {
+	_c.Call.Return(serviceAccountCredential, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetServiceAccountSecret_Call) RunAndReturn(run func(ctx context.Context, arg repository.GetServiceAccountSecretParams) (repository.ServiceAccountCredential, error)) *MockQuerier_GetServiceAccountSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSessionByRefreshTokenHash provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetSessionByRefreshTokenHash(ctx context.Context, refreshTokenHash string) (repository.Session, error) {
+	ret := _mock.Called(ctx, refreshTokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSessionByRefreshTokenHash")
+	}
+
+	var r0 repository.Session
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (repository.Session, error)); ok {
+		return returnFunc(ctx, refreshTokenHash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) repository.Session); ok {
+		r0 = returnFunc(ctx, refreshTokenHash)
+	} else {
+		r0 = ret.Get(0).(repository.Session)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, refreshTokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetSessionByRefreshTokenHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSessionByRefreshTokenHash'
+type MockQuerier_GetSessionByRefreshTokenHash_Call struct {
+	*mock.Call
+}
+
+// GetSessionByRefreshTokenHash is a helper method to define mock.On call
+//   - ctx
+//   - refreshTokenHash
+func (_e *MockQuerier_Expecter) GetSessionByRefreshTokenHash(ctx interface{}, refreshTokenHash interface{}) *MockQuerier_GetSessionByRefreshTokenHash_Call {
+	return &MockQuerier_GetSessionByRefreshTokenHash_Call{Call: _e.mock.On("GetSessionByRefreshTokenHash", ctx, refreshTokenHash)}
+}
+
+func (_c *MockQuerier_GetSessionByRefreshTokenHash_Call) Run(run func(ctx context.Context, refreshTokenHash string)) *MockQuerier_GetSessionByRefreshTokenHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetSessionByRefreshTokenHash_Call) Return(session repository.Session, err error) *MockQuerier_GetSessionByRefreshTokenHash_Call {
+	_c.Call.Return(session, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetSessionByRefreshTokenHash_Call) RunAndReturn(run func(ctx context.Context, refreshTokenHash string) (repository.Session, error)) *MockQuerier_GetSessionByRefreshTokenHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByEmail provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetUserByEmail(ctx context.Context, email string) (repository.User, error) {
+	ret := _mock.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByEmail")
+	}
+
+	var r0 repository.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (repository.User, error)); ok {
+		return returnFunc(ctx, email)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) repository.User); ok {
+		r0 = returnFunc(ctx, email)
+	} else {
+		r0 = ret.Get(0).(repository.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetUserByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByEmail'
+type MockQuerier_GetUserByEmail_Call struct {
+	*mock.Call
+}
+
+// GetUserByEmail is a helper method to define mock.On call
+//   - ctx
+//   - email
+func (_e *MockQuerier_Expecter) GetUserByEmail(ctx interface{}, email interface{}) *MockQuerier_GetUserByEmail_Call {
+	return &MockQuerier_GetUserByEmail_Call{Call: _e.mock.On("GetUserByEmail", ctx, email)}
+}
+
+func (_c *MockQuerier_GetUserByEmail_Call) Run(run func(ctx context.Context, email string)) *MockQuerier_GetUserByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetUserByEmail_Call) Return(user repository.User, err error) *MockQuerier_GetUserByEmail_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetUserByEmail_Call) RunAndReturn(run func(ctx context.Context, email string) (repository.User, error)) *MockQuerier_GetUserByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserById provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetUserById(ctx context.Context, id pgtype.UUID) (repository.User, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserById")
+	}
+
+	var r0 repository.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (repository.User, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) repository.User); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(repository.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetUserById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserById'
+type MockQuerier_GetUserById_Call struct {
+	*mock.Call
+}
+
+// GetUserById is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockQuerier_Expecter) GetUserById(ctx interface{}, id interface{}) *MockQuerier_GetUserById_Call {
+	return &MockQuerier_GetUserById_Call{Call: _e.mock.On("GetUserById", ctx, id)}
+}
+
+func (_c *MockQuerier_GetUserById_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockQuerier_GetUserById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetUserById_Call) Return(user repository.User, err error) *MockQuerier_GetUserById_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetUserById_Call) RunAndReturn(run func(ctx context.Context, id pgtype.UUID) (repository.User, error)) *MockQuerier_GetUserById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserMfa provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetUserMfa(ctx context.Context, userID pgtype.UUID) (repository.UserMfa, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserMfa")
+	}
+
+	var r0 repository.UserMfa
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (repository.UserMfa, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) repository.UserMfa); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(repository.UserMfa)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetUserMfa_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserMfa'
+type MockQuerier_GetUserMfa_Call struct {
+	*mock.Call
+}
+
+// GetUserMfa is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockQuerier_Expecter) GetUserMfa(ctx interface{}, userID interface{}) *MockQuerier_GetUserMfa_Call {
+	return &MockQuerier_GetUserMfa_Call{Call: _e.mock.On("GetUserMfa", ctx, userID)}
+}
+
+func (_c *MockQuerier_GetUserMfa_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockQuerier_GetUserMfa_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetUserMfa_Call) Return(userMfa repository.UserMfa, err error) *MockQuerier_GetUserMfa_Call {
+	_c.Call.Return(userMfa, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetUserMfa_Call) RunAndReturn(run func(ctx context.Context, userID pgtype.UUID) (repository.UserMfa, error)) *MockQuerier_GetUserMfa_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetValidPersonalAccessToken provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetValidPersonalAccessToken(ctx context.Context, tokenHash string) (repository.PersonalAccessToken, error) {
+	ret := _mock.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetValidPersonalAccessToken")
+	}
+
+	var r0 repository.PersonalAccessToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (repository.PersonalAccessToken, error)); ok {
+		return returnFunc(ctx, tokenHash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) repository.PersonalAccessToken); ok {
+		r0 = returnFunc(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(repository.PersonalAccessToken)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetValidPersonalAccessToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetValidPersonalAccessToken'
+type MockQuerier_GetValidPersonalAccessToken_Call struct {
+	*mock.Call
+}
+
+// GetValidPersonalAccessToken is a helper method to define mock.On call
+//   - ctx
+//   - tokenHash
+func (_e *MockQuerier_Expecter) GetValidPersonalAccessToken(ctx interface{}, tokenHash interface{}) *MockQuerier_GetValidPersonalAccessToken_Call {
+	return &MockQuerier_GetValidPersonalAccessToken_Call{Call: _e.mock.On("GetValidPersonalAccessToken", ctx, tokenHash)}
+}
+
+func (_c *MockQuerier_GetValidPersonalAccessToken_Call) Run(run func(ctx context.Context, tokenHash string)) *MockQuerier_GetValidPersonalAccessToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetValidPersonalAccessToken_Call) Return(personalAccessToken repository.PersonalAccessToken, err error) *MockQuerier_GetValidPersonalAccessToken_Call {
+	_c.Call.Return(personalAccessToken, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetValidPersonalAccessToken_Call) RunAndReturn(run func(ctx context.Context, tokenHash string) (repository.PersonalAccessToken, error)) *MockQuerier_GetValidPersonalAccessToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebauthnCredentialByCredentialId provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetWebauthnCredentialByCredentialId(ctx context.Context, credentialID []byte) (repository.WebauthnCredential, error) {
+	ret := _mock.Called(ctx, credentialID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebauthnCredentialByCredentialId")
+	}
+
+	var r0 repository.WebauthnCredential
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte) (repository.WebauthnCredential, error)); ok {
+		return returnFunc(ctx, credentialID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte) repository.WebauthnCredential); ok {
+		r0 = returnFunc(ctx, credentialID)
+	} else {
+		r0 = ret.Get(0).(repository.WebauthnCredential)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = returnFunc(ctx, credentialID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetWebauthnCredentialByCredentialId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebauthnCredentialByCredentialId'
+type MockQuerier_GetWebauthnCredentialByCredentialId_Call struct {
+	*mock.Call
+}
+
+// GetWebauthnCredentialByCredentialId is a helper method to define mock.On call
+//   - ctx
+//   - credentialID
+func (_e *MockQuerier_Expecter) GetWebauthnCredentialByCredentialId(ctx interface{}, credentialID interface{}) *MockQuerier_GetWebauthnCredentialByCredentialId_Call {
+	return &MockQuerier_GetWebauthnCredentialByCredentialId_Call{Call: _e.mock.On("GetWebauthnCredentialByCredentialId", ctx, credentialID)}
+}
+
+func (_c *MockQuerier_GetWebauthnCredentialByCredentialId_Call) Run(run func(ctx context.Context, credentialID []byte)) *MockQuerier_GetWebauthnCredentialByCredentialId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetWebauthnCredentialByCredentialId_Call) Return(webauthnCredential repository.WebauthnCredential, err error) *MockQuerier_GetWebauthnCredentialByCredentialId_Call {
+	_c.Call.Return(webauthnCredential, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetWebauthnCredentialByCredentialId_Call) RunAndReturn(run func(ctx context.Context, credentialID []byte) (repository.WebauthnCredential, error)) *MockQuerier_GetWebauthnCredentialByCredentialId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsTokenRevoked provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) IsTokenRevoked(ctx context.Context, arg repository.IsTokenRevokedParams) (bool, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsTokenRevoked")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.IsTokenRevokedParams) (bool, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.IsTokenRevokedParams) bool); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.IsTokenRevokedParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_IsTokenRevoked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsTokenRevoked'
+type MockQuerier_IsTokenRevoked_Call struct {
+	*mock.Call
+}
+
+// IsTokenRevoked is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) IsTokenRevoked(ctx interface{}, arg interface{}) *MockQuerier_IsTokenRevoked_Call {
+	return &MockQuerier_IsTokenRevoked_Call{Call: _e.mock.On("IsTokenRevoked", ctx, arg)}
+}
+
+func (_c *MockQuerier_IsTokenRevoked_Call) Run(run func(ctx context.Context, arg repository.IsTokenRevokedParams)) *MockQuerier_IsTokenRevoked_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.IsTokenRevokedParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_IsTokenRevoked_Call) Return(b bool, err error) *MockQuerier_IsTokenRevoked_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockQuerier_IsTokenRevoked_Call) RunAndReturn(run func(ctx context.Context, arg repository.IsTokenRevokedParams) (bool, error)) *MockQuerier_IsTokenRevoked_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListInvitations provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListInvitations(ctx context.Context) ([]repository.Invitation, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListInvitations")
+	}
+
+	var r0 []repository.Invitation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]repository.Invitation, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []repository.Invitation); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.Invitation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListInvitations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListInvitations'
+type MockQuerier_ListInvitations_Call struct {
+	*mock.Call
+}
+
+// ListInvitations is a helper method to define mock.On call
+//   - ctx
+func (_e *MockQuerier_Expecter) ListInvitations(ctx interface{}) *MockQuerier_ListInvitations_Call {
+	return &MockQuerier_ListInvitations_Call{Call: _e.mock.On("ListInvitations", ctx)}
+}
+
+func (_c *MockQuerier_ListInvitations_Call) Run(run func(ctx context.Context)) *MockQuerier_ListInvitations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListInvitations_Call) Return(invitations []repository.Invitation, err error) *MockQuerier_ListInvitations_Call {
+	_c.Call.Return(invitations, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListInvitations_Call) RunAndReturn(run func(ctx context.Context) ([]repository.Invitation, error)) *MockQuerier_ListInvitations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListLockedLoginThrottles provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListLockedLoginThrottles(ctx context.Context) ([]repository.LoginThrottle, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLockedLoginThrottles")
+	}
+
+	var r0 []repository.LoginThrottle
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]repository.LoginThrottle, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []repository.LoginThrottle); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.LoginThrottle)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListLockedLoginThrottles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListLockedLoginThrottles'
+type MockQuerier_ListLockedLoginThrottles_Call struct {
+	*mock.Call
+}
+
+// ListLockedLoginThrottles is a helper method to define mock.On call
+//   - ctx
+func (_e *MockQuerier_Expecter) ListLockedLoginThrottles(ctx interface{}) *MockQuerier_ListLockedLoginThrottles_Call {
+	return &MockQuerier_ListLockedLoginThrottles_Call{Call: _e.mock.On("ListLockedLoginThrottles", ctx)}
+}
+
+func (_c *MockQuerier_ListLockedLoginThrottles_Call) Run(run func(ctx context.Context)) *MockQuerier_ListLockedLoginThrottles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListLockedLoginThrottles_Call) Return(loginThrottles []repository.LoginThrottle, err error) *MockQuerier_ListLockedLoginThrottles_Call {
+	_c.Call.Return(loginThrottles, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListLockedLoginThrottles_Call) RunAndReturn(run func(ctx context.Context) ([]repository.LoginThrottle, error)) *MockQuerier_ListLockedLoginThrottles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListMembershipPermissionNames provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListMembershipPermissionNames(ctx context.Context, arg repository.ListMembershipPermissionNamesParams) ([]string, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListMembershipPermissionNames")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListMembershipPermissionNamesParams) ([]string, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListMembershipPermissionNamesParams) []string); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.ListMembershipPermissionNamesParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListMembershipPermissionNames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListMembershipPermissionNames'
+type MockQuerier_ListMembershipPermissionNames_Call struct {
+	*mock.Call
+}
+
+// ListMembershipPermissionNames is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) ListMembershipPermissionNames(ctx interface{}, arg interface{}) *MockQuerier_ListMembershipPermissionNames_Call {
+	return &MockQuerier_ListMembershipPermissionNames_Call{Call: _e.mock.On("ListMembershipPermissionNames", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListMembershipPermissionNames_Call) Run(run func(ctx context.Context, arg repository.ListMembershipPermissionNamesParams)) *MockQuerier_ListMembershipPermissionNames_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.ListMembershipPermissionNamesParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListMembershipPermissionNames_Call) Return(ss []string, err error) *MockQuerier_ListMembershipPermissionNames_Call {
+	_c.Call.Return(ss, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListMembershipPermissionNames_Call) RunAndReturn(run func(ctx context.Context, arg repository.ListMembershipPermissionNamesParams) ([]string, error)) *MockQuerier_ListMembershipPermissionNames_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOrganizationInvitations provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListOrganizationInvitations(ctx context.Context, organizationID pgtype.UUID) ([]repository.Invitation, error) {
+	ret := _mock.Called(ctx, organizationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOrganizationInvitations")
+	}
+
+	var r0 []repository.Invitation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) ([]repository.Invitation, error)); ok {
+		return returnFunc(ctx, organizationID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) []repository.Invitation); ok {
+		r0 = returnFunc(ctx, organizationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.Invitation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = returnFunc(ctx, organizationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListOrganizationInvitations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOrganizationInvitations'
+type MockQuerier_ListOrganizationInvitations_Call struct {
+	*mock.Call
+}
+
+// ListOrganizationInvitations is a helper method to define mock.On call
+//   - ctx
+//   - organizationID
+func (_e *MockQuerier_Expecter) ListOrganizationInvitations(ctx interface{}, organizationID interface{}) *MockQuerier_ListOrganizationInvitations_Call {
+	return &MockQuerier_ListOrganizationInvitations_Call{Call: _e.mock.On("ListOrganizationInvitations", ctx, organizationID)}
+}
+
+func (_c *MockQuerier_ListOrganizationInvitations_Call) Run(run func(ctx context.Context, organizationID pgtype.UUID)) *MockQuerier_ListOrganizationInvitations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListOrganizationInvitations_Call) Return(invitations []repository.Invitation, err error) *MockQuerier_ListOrganizationInvitations_Call {
+	_c.Call.Return(invitations, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListOrganizationInvitations_Call) RunAndReturn(run func(ctx context.Context, organizationID pgtype.UUID) ([]repository.Invitation, error)) *MockQuerier_ListOrganizationInvitations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOrganizationMembers provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListOrganizationMembers(ctx context.Context, organizationID pgtype.UUID) ([]repository.ListOrganizationMembersRow, error) {
+	ret := _mock.Called(ctx, organizationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOrganizationMembers")
+	}
+
+	var r0 []repository.ListOrganizationMembersRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) ([]repository.ListOrganizationMembersRow, error)); ok {
+		return returnFunc(ctx, organizationID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) []repository.ListOrganizationMembersRow); ok {
+		r0 = returnFunc(ctx, organizationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.ListOrganizationMembersRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = returnFunc(ctx, organizationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListOrganizationMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOrganizationMembers'
+type MockQuerier_ListOrganizationMembers_Call struct {
+	*mock.Call
+}
+
+// ListOrganizationMembers is a helper method to define mock.On call
+//   - ctx
+//   - organizationID
+func (_e *MockQuerier_Expecter) ListOrganizationMembers(ctx interface{}, organizationID interface{}) *MockQuerier_ListOrganizationMembers_Call {
+	return &MockQuerier_ListOrganizationMembers_Call{Call: _e.mock.On("ListOrganizationMembers", ctx, organizationID)}
+}
+
+func (_c *MockQuerier_ListOrganizationMembers_Call) Run(run func(ctx context.Context, organizationID pgtype.UUID)) *MockQuerier_ListOrganizationMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListOrganizationMembers_Call) Return(listOrganizationMembersRows []repository.ListOrganizationMembersRow, err error) *MockQuerier_ListOrganizationMembers_Call {
+	_c.Call.Return(listOrganizationMembersRows, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListOrganizationMembers_Call) RunAndReturn(run func(ctx context.Context, organizationID pgtype.UUID) ([]repository.ListOrganizationMembersRow, error)) *MockQuerier_ListOrganizationMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPermissions provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListPermissions(ctx context.Context) ([]repository.Permission, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPermissions")
+	}
+
+	var r0 []repository.Permission
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]repository.Permission, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []repository.Permission); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.Permission)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListPermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPermissions'
+type MockQuerier_ListPermissions_Call struct {
+	*mock.Call
+}
+
+// ListPermissions is a helper method to define mock.On call
+//   - ctx
+func (_e *MockQuerier_Expecter) ListPermissions(ctx interface{}) *MockQuerier_ListPermissions_Call {
+	return &MockQuerier_ListPermissions_Call{Call: _e.mock.On("ListPermissions", ctx)}
+}
+
+func (_c *MockQuerier_ListPermissions_Call) Run(run func(ctx context.Context)) *MockQuerier_ListPermissions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListPermissions_Call) Return(permissions []repository.Permission, err error) *MockQuerier_ListPermissions_Call {
+	_c.Call.Return(permissions, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListPermissions_Call) RunAndReturn(run func(ctx context.Context) ([]repository.Permission, error)) *MockQuerier_ListPermissions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRolePermissionNames provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListRolePermissionNames(ctx context.Context) ([]repository.ListRolePermissionNamesRow, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRolePermissionNames")
+	}
+
+	var r0 []repository.ListRolePermissionNamesRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]repository.ListRolePermissionNamesRow, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []repository.ListRolePermissionNamesRow); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.ListRolePermissionNamesRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListRolePermissionNames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRolePermissionNames'
+type MockQuerier_ListRolePermissionNames_Call struct {
+	*mock.Call
+}
+
+// ListRolePermissionNames is a helper method to define mock.On call
+//   - ctx
+func (_e *MockQuerier_Expecter) ListRolePermissionNames(ctx interface{}) *MockQuerier_ListRolePermissionNames_Call {
+	return &MockQuerier_ListRolePermissionNames_Call{Call: _e.mock.On("ListRolePermissionNames", ctx)}
+}
+
+func (_c *MockQuerier_ListRolePermissionNames_Call) Run(run func(ctx context.Context)) *MockQuerier_ListRolePermissionNames_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListRolePermissionNames_Call) Return(listRolePermissionNamesRows []repository.ListRolePermissionNamesRow, err error) *MockQuerier_ListRolePermissionNames_Call {
+	_c.Call.Return(listRolePermissionNamesRows, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListRolePermissionNames_Call) RunAndReturn(run func(ctx context.Context) ([]repository.ListRolePermissionNamesRow, error)) *MockQuerier_ListRolePermissionNames_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRoles provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListRoles(ctx context.Context) ([]repository.Role, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRoles")
+	}
+
+	var r0 []repository.Role
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]repository.Role, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []repository.Role); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.Role)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRoles'
+type MockQuerier_ListRoles_Call struct {
+	*mock.Call
+}
+
+// ListRoles is a helper method to define mock.On call
+//   - ctx
+func (_e *MockQuerier_Expecter) ListRoles(ctx interface{}) *MockQuerier_ListRoles_Call {
+	return &MockQuerier_ListRoles_Call{Call: _e.mock.On("ListRoles", ctx)}
+}
+
+func (_c *MockQuerier_ListRoles_Call) Run(run func(ctx context.Context)) *MockQuerier_ListRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListRoles_Call) Return(roles []repository.Role, err error) *MockQuerier_ListRoles_Call {
+	_c.Call.Return(roles, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListRoles_Call) RunAndReturn(run func(ctx context.Context) ([]repository.Role, error)) *MockQuerier_ListRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListServiceAccountCredentials provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListServiceAccountCredentials(ctx context.Context, userID pgtype.UUID) ([]repository.ServiceAccountCredential, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListServiceAccountCredentials")
+	}
+
+	var r0 []repository.ServiceAccountCredential
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) ([]repository.ServiceAccountCredential, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) []repository.ServiceAccountCredential); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.ServiceAccountCredential)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListServiceAccountCredentials_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListServiceAccountCredentials'
+type MockQuerier_ListServiceAccountCredentials_Call struct {
+	*mock.Call
+}
+
+// ListServiceAccountCredentials is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockQuerier_Expecter) ListServiceAccountCredentials(ctx interface{}, userID interface{}) *MockQuerier_ListServiceAccountCredentials_Call {
+	return &MockQuerier_ListServiceAccountCredentials_Call{Call: _e.mock.On("ListServiceAccountCredentials", ctx, userID)}
+}
+
+func (_c *MockQuerier_ListServiceAccountCredentials_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockQuerier_ListServiceAccountCredentials_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListServiceAccountCredentials_Call) Return(serviceAccountCredentials []repository.ServiceAccountCredential, err error) *MockQuerier_ListServiceAccountCredentials_Call {
+	_c.Call.Return(serviceAccountCredentials, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListServiceAccountCredentials_Call) RunAndReturn(run func(ctx context.Context, userID pgtype.UUID) ([]repository.ServiceAccountCredential, error)) *MockQuerier_ListServiceAccountCredentials_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListServiceAccounts provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListServiceAccounts(ctx context.Context) ([]repository.ListServiceAccountsRow, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListServiceAccounts")
+	}
+
+	var r0 []repository.ListServiceAccountsRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]repository.ListServiceAccountsRow, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []repository.ListServiceAccountsRow); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.ListServiceAccountsRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListServiceAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListServiceAccounts'
+type MockQuerier_ListServiceAccounts_Call struct {
+	*mock.Call
+}
+
+// ListServiceAccounts is a helper method to define mock.On call
+//   - ctx
+func (_e *MockQuerier_Expecter) ListServiceAccounts(ctx interface{}) *MockQuerier_ListServiceAccounts_Call {
+	return &MockQuerier_ListServiceAccounts_Call{Call: _e.mock.On("ListServiceAccounts", ctx)}
+}
+
+func (_c *MockQuerier_ListServiceAccounts_Call) Run(run func(ctx context.Context)) *MockQuerier_ListServiceAccounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListServiceAccounts_Call) Return(listServiceAccountsRows []repository.ListServiceAccountsRow, err error) *MockQuerier_ListServiceAccounts_Call {
+	_c.Call.Return(listServiceAccountsRows, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListServiceAccounts_Call) RunAndReturn(run func(ctx context.Context) ([]repository.ListServiceAccountsRow, error)) *MockQuerier_ListServiceAccounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUserMemberships provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListUserMemberships(ctx context.Context, userID pgtype.UUID) ([]repository.ListUserMembershipsRow, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserMemberships")
+	}
+
+	var r0 []repository.ListUserMembershipsRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) ([]repository.ListUserMembershipsRow, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) []repository.ListUserMembershipsRow); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.ListUserMembershipsRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListUserMemberships_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUserMemberships'
+type MockQuerier_ListUserMemberships_Call struct {
+	*mock.Call
+}
+
+// ListUserMemberships is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockQuerier_Expecter) ListUserMemberships(ctx interface{}, userID interface{}) *MockQuerier_ListUserMemberships_Call {
+	return &MockQuerier_ListUserMemberships_Call{Call: _e.mock.On("ListUserMemberships", ctx, userID)}
+}
+
+func (_c *MockQuerier_ListUserMemberships_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockQuerier_ListUserMemberships_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListUserMemberships_Call) Return(listUserMembershipsRows []repository.ListUserMembershipsRow, err error) *MockQuerier_ListUserMemberships_Call {
+	_c.Call.Return(listUserMembershipsRows, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListUserMemberships_Call) RunAndReturn(run func(ctx context.Context, userID pgtype.UUID) ([]repository.ListUserMembershipsRow, error)) *MockQuerier_ListUserMemberships_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUserPermissionNames provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListUserPermissionNames(ctx context.Context, id pgtype.UUID) ([]string, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserPermissionNames")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) ([]string, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) []string); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListUserPermissionNames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUserPermissionNames'
+type MockQuerier_ListUserPermissionNames_Call struct {
+	*mock.Call
+}
+
+// ListUserPermissionNames is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockQuerier_Expecter) ListUserPermissionNames(ctx interface{}, id interface{}) *MockQuerier_ListUserPermissionNames_Call {
+	return &MockQuerier_ListUserPermissionNames_Call{Call: _e.mock.On("ListUserPermissionNames", ctx, id)}
+}
+
+func (_c *MockQuerier_ListUserPermissionNames_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockQuerier_ListUserPermissionNames_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListUserPermissionNames_Call) Return(ss []string, err error) *MockQuerier_ListUserPermissionNames_Call {
+	_c.Call.Return(ss, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListUserPermissionNames_Call) RunAndReturn(run func(ctx context.Context, id pgtype.UUID) ([]string, error)) *MockQuerier_ListUserPermissionNames_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUserPersonalAccessTokens provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListUserPersonalAccessTokens(ctx context.Context, userID pgtype.UUID) ([]repository.PersonalAccessToken, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserPersonalAccessTokens")
+	}
+
+	var r0 []repository.PersonalAccessToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) ([]repository.PersonalAccessToken, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) []repository.PersonalAccessToken); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.PersonalAccessToken)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListUserPersonalAccessTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUserPersonalAccessTokens'
+type MockQuerier_ListUserPersonalAccessTokens_Call struct {
+	*mock.Call
+}
+
+// ListUserPersonalAccessTokens is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockQuerier_Expecter) ListUserPersonalAccessTokens(ctx interface{}, userID interface{}) *MockQuerier_ListUserPersonalAccessTokens_Call {
+	return &MockQuerier_ListUserPersonalAccessTokens_Call{Call: _e.mock.On("ListUserPersonalAccessTokens", ctx, userID)}
+}
+
+func (_c *MockQuerier_ListUserPersonalAccessTokens_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockQuerier_ListUserPersonalAccessTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListUserPersonalAccessTokens_Call) Return(personalAccessTokens []repository.PersonalAccessToken, err error) *MockQuerier_ListUserPersonalAccessTokens_Call {
+	_c.Call.Return(personalAccessTokens, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListUserPersonalAccessTokens_Call) RunAndReturn(run func(ctx context.Context, userID pgtype.UUID) ([]repository.PersonalAccessToken, error)) *MockQuerier_ListUserPersonalAccessTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUserRoleNames provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListUserRoleNames(ctx context.Context, id pgtype.UUID) ([]string, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserRoleNames")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) ([]string, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) []string); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListUserRoleNames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUserRoleNames'
+type MockQuerier_ListUserRoleNames_Call struct {
+	*mock.Call
+}
+
+// ListUserRoleNames is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockQuerier_Expecter) ListUserRoleNames(ctx interface{}, id interface{}) *MockQuerier_ListUserRoleNames_Call {
+	return &MockQuerier_ListUserRoleNames_Call{Call: _e.mock.On("ListUserRoleNames", ctx, id)}
+}
+
+func (_c *MockQuerier_ListUserRoleNames_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockQuerier_ListUserRoleNames_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListUserRoleNames_Call) Return(ss []string, err error) *MockQuerier_ListUserRoleNames_Call {
+	_c.Call.Return(ss, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListUserRoleNames_Call) RunAndReturn(run func(ctx context.Context, id pgtype.UUID) ([]string, error)) *MockQuerier_ListUserRoleNames_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUsers provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListUsers(ctx context.Context, arg repository.ListUsersParams) ([]repository.User, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsers")
+	}
+
+	var r0 []repository.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListUsersParams) ([]repository.User, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListUsersParams) []repository.User); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.ListUsersParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUsers'
+type MockQuerier_ListUsers_Call struct {
+	*mock.Call
+}
+
+// ListUsers is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) ListUsers(ctx interface{}, arg interface{}) *MockQuerier_ListUsers_Call {
+	return &MockQuerier_ListUsers_Call{Call: _e.mock.On("ListUsers", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListUsers_Call) Run(run func(ctx context.Context, arg repository.ListUsersParams)) *MockQuerier_ListUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.ListUsersParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListUsers_Call) Return(users []repository.User, err error) *MockQuerier_ListUsers_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListUsers_Call) RunAndReturn(run func(ctx context.Context, arg repository.ListUsersParams) ([]repository.User, error)) *MockQuerier_ListUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWebauthnCredentialsByUserId provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListWebauthnCredentialsByUserId(ctx context.Context, userID pgtype.UUID) ([]repository.WebauthnCredential, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebauthnCredentialsByUserId")
+	}
+
+	var r0 []repository.WebauthnCredential
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) ([]repository.WebauthnCredential, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) []repository.WebauthnCredential); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.WebauthnCredential)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListWebauthnCredentialsByUserId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebauthnCredentialsByUserId'
+type MockQuerier_ListWebauthnCredentialsByUserId_Call struct {
+	*mock.Call
+}
+
+// ListWebauthnCredentialsByUserId is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockQuerier_Expecter) ListWebauthnCredentialsByUserId(ctx interface{}, userID interface{}) *MockQuerier_ListWebauthnCredentialsByUserId_Call {
+	return &MockQuerier_ListWebauthnCredentialsByUserId_Call{Call: _e.mock.On("ListWebauthnCredentialsByUserId", ctx, userID)}
+}
+
+func (_c *MockQuerier_ListWebauthnCredentialsByUserId_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockQuerier_ListWebauthnCredentialsByUserId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListWebauthnCredentialsByUserId_Call) Return(webauthnCredentials []repository.WebauthnCredential, err error) *MockQuerier_ListWebauthnCredentialsByUserId_Call {
+	_c.Call.Return(webauthnCredentials, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListWebauthnCredentialsByUserId_Call) RunAndReturn(run func(ctx context.Context, userID pgtype.UUID) ([]repository.WebauthnCredential, error)) *MockQuerier_ListWebauthnCredentialsByUserId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkSessionRotated provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) MarkSessionRotated(ctx context.Context, id pgtype.UUID) (int64, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkSessionRotated")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (int64, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) int64); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_MarkSessionRotated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkSessionRotated'
+type MockQuerier_MarkSessionRotated_Call struct {
+	*mock.Call
+}
+
+// MarkSessionRotated is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockQuerier_Expecter) MarkSessionRotated(ctx interface{}, id interface{}) *MockQuerier_MarkSessionRotated_Call {
+	return &MockQuerier_MarkSessionRotated_Call{Call: _e.mock.On("MarkSessionRotated", ctx, id)}
+}
+
+func (_c *MockQuerier_MarkSessionRotated_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockQuerier_MarkSessionRotated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_MarkSessionRotated_Call) Return(n int64, err error) *MockQuerier_MarkSessionRotated_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockQuerier_MarkSessionRotated_Call) RunAndReturn(run func(ctx context.Context, id pgtype.UUID) (int64, error)) *MockQuerier_MarkSessionRotated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkUserEmailVerified provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) MarkUserEmailVerified(ctx context.Context, arg repository.MarkUserEmailVerifiedParams) (int64, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkUserEmailVerified")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.MarkUserEmailVerifiedParams) (int64, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.MarkUserEmailVerifiedParams) int64); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.MarkUserEmailVerifiedParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_MarkUserEmailVerified_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkUserEmailVerified'
+type MockQuerier_MarkUserEmailVerified_Call struct {
+	*mock.Call
+}
+
+// MarkUserEmailVerified is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) MarkUserEmailVerified(ctx interface{}, arg interface{}) *MockQuerier_MarkUserEmailVerified_Call {
+	return &MockQuerier_MarkUserEmailVerified_Call{Call: _e.mock.On("MarkUserEmailVerified", ctx, arg)}
+}
+
+func (_c *MockQuerier_MarkUserEmailVerified_Call) Run(run func(ctx context.Context, arg repository.MarkUserEmailVerifiedParams)) *MockQuerier_MarkUserEmailVerified_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.MarkUserEmailVerifiedParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_MarkUserEmailVerified_Call) Return(n int64, err error) *MockQuerier_MarkUserEmailVerified_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockQuerier_MarkUserEmailVerified_Call) RunAndReturn(run func(ctx context.Context, arg repository.MarkUserEmailVerifiedParams) (int64, error)) *MockQuerier_MarkUserEmailVerified_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PrunePasswordHistory provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) PrunePasswordHistory(ctx context.Context, arg repository.PrunePasswordHistoryParams) error {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PrunePasswordHistory")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.PrunePasswordHistoryParams) error); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_PrunePasswordHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PrunePasswordHistory'
+type MockQuerier_PrunePasswordHistory_Call struct {
+	*mock.Call
+}
+
+// PrunePasswordHistory is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) PrunePasswordHistory(ctx interface{}, arg interface{}) *MockQuerier_PrunePasswordHistory_Call {
+	return &MockQuerier_PrunePasswordHistory_Call{Call: _e.mock.On("PrunePasswordHistory", ctx, arg)}
+}
+
+func (_c *MockQuerier_PrunePasswordHistory_Call) Run(run func(ctx context.Context, arg repository.PrunePasswordHistoryParams)) *MockQuerier_PrunePasswordHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.PrunePasswordHistoryParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_PrunePasswordHistory_Call) Return(err error) *MockQuerier_PrunePasswordHistory_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_PrunePasswordHistory_Call) RunAndReturn(run func(ctx context.Context, arg repository.PrunePasswordHistoryParams) error) *MockQuerier_PrunePasswordHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordLoginFailure provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) RecordLoginFailure(ctx context.Context, arg repository.RecordLoginFailureParams) (repository.LoginThrottle, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordLoginFailure")
+	}
+
+	var r0 repository.LoginThrottle
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.RecordLoginFailureParams) (repository.LoginThrottle, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.RecordLoginFailureParams) repository.LoginThrottle); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.LoginThrottle)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.RecordLoginFailureParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_RecordLoginFailure_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordLoginFailure'
+type MockQuerier_RecordLoginFailure_Call struct {
+	*mock.Call
+}
+
+// RecordLoginFailure is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) RecordLoginFailure(ctx interface{}, arg interface{}) *MockQuerier_RecordLoginFailure_Call {
+	return &MockQuerier_RecordLoginFailure_Call{Call: _e.mock.On("RecordLoginFailure", ctx, arg)}
+}
+
+func (_c *MockQuerier_RecordLoginFailure_Call) Run(run func(ctx context.Context, arg repository.RecordLoginFailureParams)) *MockQuerier_RecordLoginFailure_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.RecordLoginFailureParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RecordLoginFailure_Call) Return(loginThrottle repository.LoginThrottle, err error) *MockQuerier_RecordLoginFailure_Call {
+	_c.Call.Return(loginThrottle, err)
+	return _c
+}
+
+func (_c *MockQuerier_RecordLoginFailure_Call) RunAndReturn(run func(ctx context.Context, arg repository.RecordLoginFailureParams) (repository.LoginThrottle, error)) *MockQuerier_RecordLoginFailure_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenameOrganization provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) RenameOrganization(ctx context.Context, arg repository.RenameOrganizationParams) (repository.Organization, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenameOrganization")
+	}
+
+	var r0 repository.Organization
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.RenameOrganizationParams) (repository.Organization, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.RenameOrganizationParams) repository.Organization); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.Organization)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.RenameOrganizationParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_RenameOrganization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenameOrganization'
+type MockQuerier_RenameOrganization_Call struct {
+	*mock.Call
+}
+
+// RenameOrganization is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) RenameOrganization(ctx interface{}, arg interface{}) *MockQuerier_RenameOrganization_Call {
+	return &MockQuerier_RenameOrganization_Call{Call: _e.mock.On("RenameOrganization", ctx, arg)}
+}
+
+func (_c *MockQuerier_RenameOrganization_Call) Run(run func(ctx context.Context, arg repository.RenameOrganizationParams)) *MockQuerier_RenameOrganization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.RenameOrganizationParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RenameOrganization_Call) Return(organization repository.Organization, err error) *MockQuerier_RenameOrganization_Call {
+	_c.Call.Return(organization, err)
+	return _c
+}
+
+func (_c *MockQuerier_RenameOrganization_Call) RunAndReturn(run func(ctx context.Context, arg repository.RenameOrganizationParams) (repository.Organization, error)) *MockQuerier_RenameOrganization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenewInvitation provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) RenewInvitation(ctx context.Context, arg repository.RenewInvitationParams) (repository.Invitation, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenewInvitation")
+	}
+
+	var r0 repository.Invitation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.RenewInvitationParams) (repository.Invitation, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.RenewInvitationParams) repository.Invitation); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.Invitation)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.RenewInvitationParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_RenewInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenewInvitation'
+type MockQuerier_RenewInvitation_Call struct {
+	*mock.Call
+}
+
+// RenewInvitation is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) RenewInvitation(ctx interface{}, arg interface{}) *MockQuerier_RenewInvitation_Call {
+	return &MockQuerier_RenewInvitation_Call{Call: _e.mock.On("RenewInvitation", ctx, arg)}
+}
+
+func (_c *MockQuerier_RenewInvitation_Call) Run(run func(ctx context.Context, arg repository.RenewInvitationParams)) *MockQuerier_RenewInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.RenewInvitationParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RenewInvitation_Call) Return(invitation repository.Invitation, err error) *MockQuerier_RenewInvitation_Call {
+	_c.Call.Return(invitation, err)
+	return _c
+}
+
+func (_c *MockQuerier_RenewInvitation_Call) RunAndReturn(run func(ctx context.Context, arg repository.RenewInvitationParams) (repository.Invitation, error)) *MockQuerier_RenewInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequireUserPasswordReset provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) RequireUserPasswordReset(ctx context.Context, id pgtype.UUID) (int64, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequireUserPasswordReset")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (int64, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) int64); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_RequireUserPasswordReset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequireUserPasswordReset'
+type MockQuerier_RequireUserPasswordReset_Call struct {
+	*mock.Call
+}
+
+// RequireUserPasswordReset is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockQuerier_Expecter) RequireUserPasswordReset(ctx interface{}, id interface{}) *MockQuerier_RequireUserPasswordReset_Call {
+	return &MockQuerier_RequireUserPasswordReset_Call{Call: _e.mock.On("RequireUserPasswordReset", ctx, id)}
+}
+
+func (_c *MockQuerier_RequireUserPasswordReset_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockQuerier_RequireUserPasswordReset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RequireUserPasswordReset_Call) Return(n int64, err error) *MockQuerier_RequireUserPasswordReset_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockQuerier_RequireUserPasswordReset_Call) RunAndReturn(run func(ctx context.Context, id pgtype.UUID) (int64, error)) *MockQuerier_RequireUserPasswordReset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeOtherUserSessions provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) RevokeOtherUserSessions(ctx context.Context, arg repository.RevokeOtherUserSessionsParams) error {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeOtherUserSessions")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.RevokeOtherUserSessionsParams) error); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_RevokeOtherUserSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeOtherUserSessions'
+type MockQuerier_RevokeOtherUserSessions_Call struct {
+	*mock.Call
+}
+
+// RevokeOtherUserSessions is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) RevokeOtherUserSessions(ctx interface{}, arg interface{}) *MockQuerier_RevokeOtherUserSessions_Call {
+	return &MockQuerier_RevokeOtherUserSessions_Call{Call: _e.mock.On("RevokeOtherUserSessions", ctx, arg)}
+}
+
+func (_c *MockQuerier_RevokeOtherUserSessions_Call) Run(run func(ctx context.Context, arg repository.RevokeOtherUserSessionsParams)) *MockQuerier_RevokeOtherUserSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.RevokeOtherUserSessionsParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RevokeOtherUserSessions_Call) Return(err error) *MockQuerier_RevokeOtherUserSessions_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_RevokeOtherUserSessions_Call) RunAndReturn(run func(ctx context.Context, arg repository.RevokeOtherUserSessionsParams) error) *MockQuerier_RevokeOtherUserSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeSessionByRefreshTokenHash provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) RevokeSessionByRefreshTokenHash(ctx context.Context, refreshTokenHash string) error {
+	ret := _mock.Called(ctx, refreshTokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSessionByRefreshTokenHash")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, refreshTokenHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_RevokeSessionByRefreshTokenHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSessionByRefreshTokenHash'
+type MockQuerier_RevokeSessionByRefreshTokenHash_Call struct {
+	*mock.Call
+}
+
+// RevokeSessionByRefreshTokenHash is a helper method to define mock.On call
+//   - ctx
+//   - refreshTokenHash
+func (_e *MockQuerier_Expecter) RevokeSessionByRefreshTokenHash(ctx interface{}, refreshTokenHash interface{}) *MockQuerier_RevokeSessionByRefreshTokenHash_Call {
+	return &MockQuerier_RevokeSessionByRefreshTokenHash_Call{Call: _e.mock.On("RevokeSessionByRefreshTokenHash", ctx, refreshTokenHash)}
+}
+
+func (_c *MockQuerier_RevokeSessionByRefreshTokenHash_Call) Run(run func(ctx context.Context, refreshTokenHash string)) *MockQuerier_RevokeSessionByRefreshTokenHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RevokeSessionByRefreshTokenHash_Call) Return(err error) *MockQuerier_RevokeSessionByRefreshTokenHash_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_RevokeSessionByRefreshTokenHash_Call) RunAndReturn(run func(ctx context.Context, refreshTokenHash string) error) *MockQuerier_RevokeSessionByRefreshTokenHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeSessionFamily provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) RevokeSessionFamily(ctx context.Context, familyID pgtype.UUID) error {
+	ret := _mock.Called(ctx, familyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSessionFamily")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) error); ok {
+		r0 = returnFunc(ctx, familyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_RevokeSessionFamily_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSessionFamily'
+type MockQuerier_RevokeSessionFamily_Call struct {
+	*mock.Call
+}
+
+// RevokeSessionFamily is a helper method to define mock.On call
+//   - ctx
+//   - familyID
+func (_e *MockQuerier_Expecter) RevokeSessionFamily(ctx interface{}, familyID interface{}) *MockQuerier_RevokeSessionFamily_Call {
+	return &MockQuerier_RevokeSessionFamily_Call{Call: _e.mock.On("RevokeSessionFamily", ctx, familyID)}
+}
+
+func (_c *MockQuerier_RevokeSessionFamily_Call) Run(run func(ctx context.Context, familyID pgtype.UUID)) *MockQuerier_RevokeSessionFamily_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RevokeSessionFamily_Call) Return(err error) *MockQuerier_RevokeSessionFamily_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_RevokeSessionFamily_Call) RunAndReturn(run func(ctx context.Context, familyID pgtype.UUID) error) *MockQuerier_RevokeSessionFamily_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeToken provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) RevokeToken(ctx context.Context, arg repository.RevokeTokenParams) error {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeToken")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.RevokeTokenParams) error); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_RevokeToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeToken'
+type MockQuerier_RevokeToken_Call struct {
+	*mock.Call
+}
+
+// RevokeToken is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) RevokeToken(ctx interface{}, arg interface{}) *MockQuerier_RevokeToken_Call {
+	return &MockQuerier_RevokeToken_Call{Call: _e.mock.On("RevokeToken", ctx, arg)}
+}
+
+func (_c *MockQuerier_RevokeToken_Call) Run(run func(ctx context.Context, arg repository.RevokeTokenParams)) *MockQuerier_RevokeToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.RevokeTokenParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RevokeToken_Call) Return(err error) *MockQuerier_RevokeToken_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_RevokeToken_Call) RunAndReturn(run func(ctx context.Context, arg repository.RevokeTokenParams) error) *MockQuerier_RevokeToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeUserSessions provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) RevokeUserSessions(ctx context.Context, userID pgtype.UUID) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeUserSessions")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_RevokeUserSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeUserSessions'
+type MockQuerier_RevokeUserSessions_Call struct {
+	*mock.Call
+}
+
+// RevokeUserSessions is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockQuerier_Expecter) RevokeUserSessions(ctx interface{}, userID interface{}) *MockQuerier_RevokeUserSessions_Call {
+	return &MockQuerier_RevokeUserSessions_Call{Call: _e.mock.On("RevokeUserSessions", ctx, userID)}
+}
+
+func (_c *MockQuerier_RevokeUserSessions_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockQuerier_RevokeUserSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RevokeUserSessions_Call) Return(err error) *MockQuerier_RevokeUserSessions_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_RevokeUserSessions_Call) RunAndReturn(run func(ctx context.Context, userID pgtype.UUID) error) *MockQuerier_RevokeUserSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeUserTokensIssuedBefore provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) RevokeUserTokensIssuedBefore(ctx context.Context, arg repository.RevokeUserTokensIssuedBeforeParams) error {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeUserTokensIssuedBefore")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.RevokeUserTokensIssuedBeforeParams) error); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_RevokeUserTokensIssuedBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeUserTokensIssuedBefore'
+type MockQuerier_RevokeUserTokensIssuedBefore_Call struct {
+	*mock.Call
+}
+
+// RevokeUserTokensIssuedBefore is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) RevokeUserTokensIssuedBefore(ctx interface{}, arg interface{}) *MockQuerier_RevokeUserTokensIssuedBefore_Call {
+	return &MockQuerier_RevokeUserTokensIssuedBefore_Call{Call: _e.mock.On("RevokeUserTokensIssuedBefore", ctx, arg)}
+}
+
+func (_c *MockQuerier_RevokeUserTokensIssuedBefore_Call) Run(run func(ctx context.Context, arg repository.RevokeUserTokensIssuedBeforeParams)) *MockQuerier_RevokeUserTokensIssuedBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.RevokeUserTokensIssuedBeforeParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_RevokeUserTokensIssuedBefore_Call) Return(err error) *MockQuerier_RevokeUserTokensIssuedBefore_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_RevokeUserTokensIssuedBefore_Call) RunAndReturn(run func(ctx context.Context, arg repository.RevokeUserTokensIssuedBeforeParams) error) *MockQuerier_RevokeUserTokensIssuedBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetLoginLockedUntil provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) SetLoginLockedUntil(ctx context.Context, arg repository.SetLoginLockedUntilParams) error {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLoginLockedUntil")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.SetLoginLockedUntilParams) error); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_SetLoginLockedUntil_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLoginLockedUntil'
+type MockQuerier_SetLoginLockedUntil_Call struct {
+	*mock.Call
+}
+
+// SetLoginLockedUntil is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) SetLoginLockedUntil(ctx interface{}, arg interface{}) *MockQuerier_SetLoginLockedUntil_Call {
+	return &MockQuerier_SetLoginLockedUntil_Call{Call: _e.mock.On("SetLoginLockedUntil", ctx, arg)}
+}
+
+func (_c *MockQuerier_SetLoginLockedUntil_Call) Run(run func(ctx context.Context, arg repository.SetLoginLockedUntilParams)) *MockQuerier_SetLoginLockedUntil_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.SetLoginLockedUntilParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_SetLoginLockedUntil_Call) Return(err error) *MockQuerier_SetLoginLockedUntil_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_SetLoginLockedUntil_Call) RunAndReturn(run func(ctx context.Context, arg repository.SetLoginLockedUntilParams) error) *MockQuerier_SetLoginLockedUntil_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetRolePermissions provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) SetRolePermissions(ctx context.Context, arg repository.SetRolePermissionsParams) error {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRolePermissions")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.SetRolePermissionsParams) error); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_SetRolePermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetRolePermissions'
+type MockQuerier_SetRolePermissions_Call struct {
+	*mock.Call
+}
+
+// SetRolePermissions is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) SetRolePermissions(ctx interface{}, arg interface{}) *MockQuerier_SetRolePermissions_Call {
+	return &MockQuerier_SetRolePermissions_Call{Call: _e.mock.On("SetRolePermissions", ctx, arg)}
+}
+
+func (_c *MockQuerier_SetRolePermissions_Call) Run(run func(ctx context.Context, arg repository.SetRolePermissionsParams)) *MockQuerier_SetRolePermissions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.SetRolePermissionsParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_SetRolePermissions_Call) Return(err error) *MockQuerier_SetRolePermissions_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_SetRolePermissions_Call) RunAndReturn(run func(ctx context.Context, arg repository.SetRolePermissionsParams) error) *MockQuerier_SetRolePermissions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetUserDisabled provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) SetUserDisabled(ctx context.Context, arg repository.SetUserDisabledParams) (repository.User, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserDisabled")
+	}
+
+	var r0 repository.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.SetUserDisabledParams) (repository.User, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.SetUserDisabledParams) repository.User); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.SetUserDisabledParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_SetUserDisabled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUserDisabled'
+type MockQuerier_SetUserDisabled_Call struct {
+	*mock.Call
+}
+
+// SetUserDisabled is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) SetUserDisabled(ctx interface{}, arg interface{}) *MockQuerier_SetUserDisabled_Call {
+	return &MockQuerier_SetUserDisabled_Call{Call: _e.mock.On("SetUserDisabled", ctx, arg)}
+}
+
+func (_c *MockQuerier_SetUserDisabled_Call) Run(run func(ctx context.Context, arg repository.SetUserDisabledParams)) *MockQuerier_SetUserDisabled_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.SetUserDisabledParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_SetUserDisabled_Call) Return(user repository.User, err error) *MockQuerier_SetUserDisabled_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockQuerier_SetUserDisabled_Call) RunAndReturn(run func(ctx context.Context, arg repository.SetUserDisabledParams) (repository.User, error)) *MockQuerier_SetUserDisabled_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetUserRoles provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) SetUserRoles(ctx context.Context, arg repository.SetUserRolesParams) error {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserRoles")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.SetUserRolesParams) error); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_SetUserRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUserRoles'
+type MockQuerier_SetUserRoles_Call struct {
+	*mock.Call
+}
+
+// SetUserRoles is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) SetUserRoles(ctx interface{}, arg interface{}) *MockQuerier_SetUserRoles_Call {
+	return &MockQuerier_SetUserRoles_Call{Call: _e.mock.On("SetUserRoles", ctx, arg)}
+}
+
+func (_c *MockQuerier_SetUserRoles_Call) Run(run func(ctx context.Context, arg repository.SetUserRolesParams)) *MockQuerier_SetUserRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.SetUserRolesParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_SetUserRoles_Call) Return(err error) *MockQuerier_SetUserRoles_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_SetUserRoles_Call) RunAndReturn(run func(ctx context.Context, arg repository.SetUserRolesParams) error) *MockQuerier_SetUserRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TouchPersonalAccessToken provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) TouchPersonalAccessToken(ctx context.Context, id pgtype.UUID) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TouchPersonalAccessToken")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_TouchPersonalAccessToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TouchPersonalAccessToken'
+type MockQuerier_TouchPersonalAccessToken_Call struct {
+	*mock.Call
+}
+
+// TouchPersonalAccessToken is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockQuerier_Expecter) TouchPersonalAccessToken(ctx interface{}, id interface{}) *MockQuerier_TouchPersonalAccessToken_Call {
+	return &MockQuerier_TouchPersonalAccessToken_Call{Call: _e.mock.On("TouchPersonalAccessToken", ctx, id)}
+}
+
+func (_c *MockQuerier_TouchPersonalAccessToken_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockQuerier_TouchPersonalAccessToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_TouchPersonalAccessToken_Call) Return(err error) *MockQuerier_TouchPersonalAccessToken_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_TouchPersonalAccessToken_Call) RunAndReturn(run func(ctx context.Context, id pgtype.UUID) error) *MockQuerier_TouchPersonalAccessToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TouchServiceAccountCredential provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) TouchServiceAccountCredential(ctx context.Context, id pgtype.UUID) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TouchServiceAccountCredential")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_TouchServiceAccountCredential_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TouchServiceAccountCredential'
+type MockQuerier_TouchServiceAccountCredential_Call struct {
+	*mock.Call
+}
+
+// TouchServiceAccountCredential is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockQuerier_Expecter) TouchServiceAccountCredential(ctx interface{}, id interface{}) *MockQuerier_TouchServiceAccountCredential_Call {
+	return &MockQuerier_TouchServiceAccountCredential_Call{Call: _e.mock.On("TouchServiceAccountCredential", ctx, id)}
+}
+
+func (_c *MockQuerier_TouchServiceAccountCredential_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockQuerier_TouchServiceAccountCredential_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_TouchServiceAccountCredential_Call) Return(err error) *MockQuerier_TouchServiceAccountCredential_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_TouchServiceAccountCredential_Call) RunAndReturn(run func(ctx context.Context, id pgtype.UUID) error) *MockQuerier_TouchServiceAccountCredential_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateMembershipRole provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) UpdateMembershipRole(ctx context.Context, arg repository.UpdateMembershipRoleParams) (int64, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMembershipRole")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.UpdateMembershipRoleParams) (int64, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.UpdateMembershipRoleParams) int64); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.UpdateMembershipRoleParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_UpdateMembershipRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMembershipRole'
+type MockQuerier_UpdateMembershipRole_Call struct {
+	*mock.Call
+}
+
+// UpdateMembershipRole is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) UpdateMembershipRole(ctx interface{}, arg interface{}) *MockQuerier_UpdateMembershipRole_Call {
+	return &MockQuerier_UpdateMembershipRole_Call{Call: _e.mock.On("UpdateMembershipRole", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpdateMembershipRole_Call) Run(run func(ctx context.Context, arg repository.UpdateMembershipRoleParams)) *MockQuerier_UpdateMembershipRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.UpdateMembershipRoleParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpdateMembershipRole_Call) Return(n int64, err error) *MockQuerier_UpdateMembershipRole_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockQuerier_UpdateMembershipRole_Call) RunAndReturn(run func(ctx context.Context, arg repository.UpdateMembershipRoleParams) (int64, error)) *MockQuerier_UpdateMembershipRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateMfaLastUsedStep provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) UpdateMfaLastUsedStep(ctx context.Context, arg repository.UpdateMfaLastUsedStepParams) (int64, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMfaLastUsedStep")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.UpdateMfaLastUsedStepParams) (int64, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.UpdateMfaLastUsedStepParams) int64); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.UpdateMfaLastUsedStepParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_UpdateMfaLastUsedStep_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMfaLastUsedStep'
+type MockQuerier_UpdateMfaLastUsedStep_Call struct {
+	*mock.Call
+}
+
+// UpdateMfaLastUsedStep is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) UpdateMfaLastUsedStep(ctx interface{}, arg interface{}) *MockQuerier_UpdateMfaLastUsedStep_Call {
+	return &MockQuerier_UpdateMfaLastUsedStep_Call{Call: _e.mock.On("UpdateMfaLastUsedStep", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpdateMfaLastUsedStep_Call) Run(run func(ctx context.Context, arg repository.UpdateMfaLastUsedStepParams)) *MockQuerier_UpdateMfaLastUsedStep_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.UpdateMfaLastUsedStepParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpdateMfaLastUsedStep_Call) Return(n int64, err error) *MockQuerier_UpdateMfaLastUsedStep_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockQuerier_UpdateMfaLastUsedStep_Call) RunAndReturn(run func(ctx context.Context, arg repository.UpdateMfaLastUsedStepParams) (int64, error)) *MockQuerier_UpdateMfaLastUsedStep_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateServiceAccountScopes provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) UpdateServiceAccountScopes(ctx context.Context, arg repository.UpdateServiceAccountScopesParams) (int64, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateServiceAccountScopes")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.UpdateServiceAccountScopesParams) (int64, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.UpdateServiceAccountScopesParams) int64); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.UpdateServiceAccountScopesParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_UpdateServiceAccountScopes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateServiceAccountScopes'
+type MockQuerier_UpdateServiceAccountScopes_Call struct {
+	*mock.Call
+}
+
+// UpdateServiceAccountScopes is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) UpdateServiceAccountScopes(ctx interface{}, arg interface{}) *MockQuerier_UpdateServiceAccountScopes_Call {
+	return &MockQuerier_UpdateServiceAccountScopes_Call{Call: _e.mock.On("UpdateServiceAccountScopes", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpdateServiceAccountScopes_Call) Run(run func(ctx context.Context, arg repository.UpdateServiceAccountScopesParams)) *MockQuerier_UpdateServiceAccountScopes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.UpdateServiceAccountScopesParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpdateServiceAccountScopes_Call) Return(n int64, err error) *MockQuerier_UpdateServiceAccountScopes_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockQuerier_UpdateServiceAccountScopes_Call) RunAndReturn(run func(ctx context.Context, arg repository.UpdateServiceAccountScopesParams) (int64, error)) *MockQuerier_UpdateServiceAccountScopes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUser provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) UpdateUser(ctx context.Context, arg repository.UpdateUserParams) (repository.User, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUser")
 	}
 
 	var r0 repository.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreateUserParams) (repository.User, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.UpdateUserParams) (repository.User, error)); ok {
 		return returnFunc(ctx, arg)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.CreateUserParams) repository.User); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.UpdateUserParams) repository.User); ok {
 		r0 = returnFunc(ctx, arg)
 	} else {
 		r0 = ret.Get(0).(repository.User)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.CreateUserParams) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.UpdateUserParams) error); ok {
 		r1 = returnFunc(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -65,255 +5603,302 @@ func (_mock *MockQuerier) CreateUser(ctx context.Context, arg repository.CreateU
 	return r0, r1
 }
 
-// MockQuerier_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
-type MockQuerier_CreateUser_Call struct {
+// MockQuerier_UpdateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUser'
+type MockQuerier_UpdateUser_Call struct {
 	*mock.Call
 }
 
-// CreateUser is a helper method to define mock.On call
+// UpdateUser is a helper method to define mock.On call
 //   - ctx
 //   - arg
-func (_e *MockQuerier_Expecter) CreateUser(ctx interface{}, arg interface{}) *MockQuerier_CreateUser_Call {
-	return &MockQuerier_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, arg)}
+func (_e *MockQuerier_Expecter) UpdateUser(ctx interface{}, arg interface{}) *MockQuerier_UpdateUser_Call {
+	return &MockQuerier_UpdateUser_Call{Call: _e.mock.On("UpdateUser", ctx, arg)}
 }
 
-func (_c *MockQuerier_CreateUser_Call) Run(run func(ctx context.Context, arg repository.CreateUserParams)) *MockQuerier_CreateUser_Call {
+func (_c *MockQuerier_UpdateUser_Call) Run(run func(ctx context.Context, arg repository.UpdateUserParams)) *MockQuerier_UpdateUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(repository.CreateUserParams))
+		run(args[0].(context.Context), args[1].(repository.UpdateUserParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_CreateUser_Call) Return(user repository.User, err error) *MockQuerier_CreateUser_Call {
+func (_c *MockQuerier_UpdateUser_Call) Return(user repository.User, err error) *MockQuerier_UpdateUser_Call {
 	_c.Call.Return(user, err)
 	return _c
 }
 
-func (_c *MockQuerier_CreateUser_Call) RunAndReturn(run func(ctx context.Context, arg repository.CreateUserParams) (repository.User, error)) *MockQuerier_CreateUser_Call {
+func (_c *MockQuerier_UpdateUser_Call) RunAndReturn(run func(ctx context.Context, arg repository.UpdateUserParams) (repository.User, error)) *MockQuerier_UpdateUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteUser provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) DeleteUser(ctx context.Context, id pgtype.UUID) error {
-	ret := _mock.Called(ctx, id)
+// UpdateUserPassword provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) UpdateUserPassword(ctx context.Context, arg repository.UpdateUserPasswordParams) error {
+	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteUser")
+		panic("no return value specified for UpdateUserPassword")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) error); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.UpdateUserPasswordParams) error); ok {
+		r0 = returnFunc(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockQuerier_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
-type MockQuerier_DeleteUser_Call struct {
+// MockQuerier_UpdateUserPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserPassword'
+type MockQuerier_UpdateUserPassword_Call struct {
 	*mock.Call
 }
 
-// DeleteUser is a helper method to define mock.On call
+// UpdateUserPassword is a helper method to define mock.On call
 //   - ctx
-//   - id
-func (_e *MockQuerier_Expecter) DeleteUser(ctx interface{}, id interface{}) *MockQuerier_DeleteUser_Call {
-	return &MockQuerier_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, id)}
+//   - arg
+func (_e *MockQuerier_Expecter) UpdateUserPassword(ctx interface{}, arg interface{}) *MockQuerier_UpdateUserPassword_Call {
+	return &MockQuerier_UpdateUserPassword_Call{Call: _e.mock.On("UpdateUserPassword", ctx, arg)}
 }
 
-func (_c *MockQuerier_DeleteUser_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockQuerier_DeleteUser_Call {
+func (_c *MockQuerier_UpdateUserPassword_Call) Run(run func(ctx context.Context, arg repository.UpdateUserPasswordParams)) *MockQuerier_UpdateUserPassword_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(pgtype.UUID))
+		run(args[0].(context.Context), args[1].(repository.UpdateUserPasswordParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_DeleteUser_Call) Return(err error) *MockQuerier_DeleteUser_Call {
+func (_c *MockQuerier_UpdateUserPassword_Call) Return(err error) *MockQuerier_UpdateUserPassword_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockQuerier_DeleteUser_Call) RunAndReturn(run func(ctx context.Context, id pgtype.UUID) error) *MockQuerier_DeleteUser_Call {
+func (_c *MockQuerier_UpdateUserPassword_Call) RunAndReturn(run func(ctx context.Context, arg repository.UpdateUserPasswordParams) error) *MockQuerier_UpdateUserPassword_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DropAllUsers provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) DropAllUsers(ctx context.Context) error {
-	ret := _mock.Called(ctx)
+// UpdateUserRole provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) UpdateUserRole(ctx context.Context, arg repository.UpdateUserRoleParams) (repository.User, error) {
+	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DropAllUsers")
+		panic("no return value specified for UpdateUserRole")
+	}
+
+	var r0 repository.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.UpdateUserRoleParams) (repository.User, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.UpdateUserRoleParams) repository.User); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.UpdateUserRoleParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_UpdateUserRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserRole'
+type MockQuerier_UpdateUserRole_Call struct {
+	*mock.Call
+}
+
+// UpdateUserRole is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockQuerier_Expecter) UpdateUserRole(ctx interface{}, arg interface{}) *MockQuerier_UpdateUserRole_Call {
+	return &MockQuerier_UpdateUserRole_Call{Call: _e.mock.On("UpdateUserRole", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpdateUserRole_Call) Run(run func(ctx context.Context, arg repository.UpdateUserRoleParams)) *MockQuerier_UpdateUserRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.UpdateUserRoleParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpdateUserRole_Call) Return(user repository.User, err error) *MockQuerier_UpdateUserRole_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockQuerier_UpdateUserRole_Call) RunAndReturn(run func(ctx context.Context, arg repository.UpdateUserRoleParams) (repository.User, error)) *MockQuerier_UpdateUserRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateWebauthnCredentialSignCount provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) UpdateWebauthnCredentialSignCount(ctx context.Context, arg repository.UpdateWebauthnCredentialSignCountParams) error {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWebauthnCredentialSignCount")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.UpdateWebauthnCredentialSignCountParams) error); ok {
+		r0 = returnFunc(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockQuerier_DropAllUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DropAllUsers'
-type MockQuerier_DropAllUsers_Call struct {
+// MockQuerier_UpdateWebauthnCredentialSignCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWebauthnCredentialSignCount'
+type MockQuerier_UpdateWebauthnCredentialSignCount_Call struct {
 	*mock.Call
 }
 
-// DropAllUsers is a helper method to define mock.On call
+// UpdateWebauthnCredentialSignCount is a helper method to define mock.On call
 //   - ctx
-func (_e *MockQuerier_Expecter) DropAllUsers(ctx interface{}) *MockQuerier_DropAllUsers_Call {
-	return &MockQuerier_DropAllUsers_Call{Call: _e.mock.On("DropAllUsers", ctx)}
+//   - arg
+func (_e *MockQuerier_Expecter) UpdateWebauthnCredentialSignCount(ctx interface{}, arg interface{}) *MockQuerier_UpdateWebauthnCredentialSignCount_Call {
+	return &MockQuerier_UpdateWebauthnCredentialSignCount_Call{Call: _e.mock.On("UpdateWebauthnCredentialSignCount", ctx, arg)}
 }
 
-func (_c *MockQuerier_DropAllUsers_Call) Run(run func(ctx context.Context)) *MockQuerier_DropAllUsers_Call {
+func (_c *MockQuerier_UpdateWebauthnCredentialSignCount_Call) Run(run func(ctx context.Context, arg repository.UpdateWebauthnCredentialSignCountParams)) *MockQuerier_UpdateWebauthnCredentialSignCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(repository.UpdateWebauthnCredentialSignCountParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_DropAllUsers_Call) Return(err error) *MockQuerier_DropAllUsers_Call {
+func (_c *MockQuerier_UpdateWebauthnCredentialSignCount_Call) Return(err error) *MockQuerier_UpdateWebauthnCredentialSignCount_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockQuerier_DropAllUsers_Call) RunAndReturn(run func(ctx context.Context) error) *MockQuerier_DropAllUsers_Call {
+func (_c *MockQuerier_UpdateWebauthnCredentialSignCount_Call) RunAndReturn(run func(ctx context.Context, arg repository.UpdateWebauthnCredentialSignCountParams) error) *MockQuerier_UpdateWebauthnCredentialSignCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserByEmail provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) GetUserByEmail(ctx context.Context, email string) (repository.User, error) {
-	ret := _mock.Called(ctx, email)
+// UpsertPendingUserMfa provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) UpsertPendingUserMfa(ctx context.Context, arg repository.UpsertPendingUserMfaParams) error {
+	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserByEmail")
+		panic("no return value specified for UpsertPendingUserMfa")
 	}
 
-	var r0 repository.User
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (repository.User, error)); ok {
-		return returnFunc(ctx, email)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) repository.User); ok {
-		r0 = returnFunc(ctx, email)
-	} else {
-		r0 = ret.Get(0).(repository.User)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, email)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.UpsertPendingUserMfaParams) error); ok {
+		r0 = returnFunc(ctx, arg)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockQuerier_GetUserByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByEmail'
-type MockQuerier_GetUserByEmail_Call struct {
+// MockQuerier_UpsertPendingUserMfa_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertPendingUserMfa'
+type MockQuerier_UpsertPendingUserMfa_Call struct {
 	*mock.Call
 }
 
-// GetUserByEmail is a helper method to define mock.On call
+// UpsertPendingUserMfa is a helper method to define mock.On call
 //   - ctx
-//   - email
-func (_e *MockQuerier_Expecter) GetUserByEmail(ctx interface{}, email interface{}) *MockQuerier_GetUserByEmail_Call {
-	return &MockQuerier_GetUserByEmail_Call{Call: _e.mock.On("GetUserByEmail", ctx, email)}
+//   - arg
+func (_e *MockQuerier_Expecter) UpsertPendingUserMfa(ctx interface{}, arg interface{}) *MockQuerier_UpsertPendingUserMfa_Call {
+	return &MockQuerier_UpsertPendingUserMfa_Call{Call: _e.mock.On("UpsertPendingUserMfa", ctx, arg)}
 }
 
-func (_c *MockQuerier_GetUserByEmail_Call) Run(run func(ctx context.Context, email string)) *MockQuerier_GetUserByEmail_Call {
+func (_c *MockQuerier_UpsertPendingUserMfa_Call) Run(run func(ctx context.Context, arg repository.UpsertPendingUserMfaParams)) *MockQuerier_UpsertPendingUserMfa_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(repository.UpsertPendingUserMfaParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_GetUserByEmail_Call) Return(user repository.User, err error) *MockQuerier_GetUserByEmail_Call {
-	_c.Call.Return(user, err)
+func (_c *MockQuerier_UpsertPendingUserMfa_Call) Return(err error) *MockQuerier_UpsertPendingUserMfa_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockQuerier_GetUserByEmail_Call) RunAndReturn(run func(ctx context.Context, email string) (repository.User, error)) *MockQuerier_GetUserByEmail_Call {
+func (_c *MockQuerier_UpsertPendingUserMfa_Call) RunAndReturn(run func(ctx context.Context, arg repository.UpsertPendingUserMfaParams) error) *MockQuerier_UpsertPendingUserMfa_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserById provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) GetUserById(ctx context.Context, id pgtype.UUID) (repository.User, error) {
-	ret := _mock.Called(ctx, id)
+// UseClientAssertion provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) UseClientAssertion(ctx context.Context, arg repository.UseClientAssertionParams) (int64, error) {
+	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserById")
+		panic("no return value specified for UseClientAssertion")
 	}
 
-	var r0 repository.User
+	var r0 int64
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (repository.User, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.UseClientAssertionParams) (int64, error)); ok {
+		return returnFunc(ctx, arg)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.UUID) repository.User); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.UseClientAssertionParams) int64); ok {
+		r0 = returnFunc(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(repository.User)
+		r0 = ret.Get(0).(int64)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
-		r1 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.UseClientAssertionParams) error); ok {
+		r1 = returnFunc(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockQuerier_GetUserById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserById'
-type MockQuerier_GetUserById_Call struct {
+// MockQuerier_UseClientAssertion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseClientAssertion'
+type MockQuerier_UseClientAssertion_Call struct {
 	*mock.Call
 }
 
-// GetUserById is a helper method to define mock.On call
+// UseClientAssertion is a helper method to define mock.On call
 //   - ctx
-//   - id
-func (_e *MockQuerier_Expecter) GetUserById(ctx interface{}, id interface{}) *MockQuerier_GetUserById_Call {
-	return &MockQuerier_GetUserById_Call{Call: _e.mock.On("GetUserById", ctx, id)}
+//   - arg
+func (_e *MockQuerier_Expecter) UseClientAssertion(ctx interface{}, arg interface{}) *MockQuerier_UseClientAssertion_Call {
+	return &MockQuerier_UseClientAssertion_Call{Call: _e.mock.On("UseClientAssertion", ctx, arg)}
 }
 
-func (_c *MockQuerier_GetUserById_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockQuerier_GetUserById_Call {
+func (_c *MockQuerier_UseClientAssertion_Call) Run(run func(ctx context.Context, arg repository.UseClientAssertionParams)) *MockQuerier_UseClientAssertion_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(pgtype.UUID))
+		run(args[0].(context.Context), args[1].(repository.UseClientAssertionParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_GetUserById_Call) Return(user repository.User, err error) *MockQuerier_GetUserById_Call {
-	_c.Call.Return(user, err)
+func (_c *MockQuerier_UseClientAssertion_Call) Return(n int64, err error) *MockQuerier_UseClientAssertion_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockQuerier_GetUserById_Call) RunAndReturn(run func(ctx context.Context, id pgtype.UUID) (repository.User, error)) *MockQuerier_GetUserById_Call {
+func (_c *MockQuerier_UseClientAssertion_Call) RunAndReturn(run func(ctx context.Context, arg repository.UseClientAssertionParams) (int64, error)) *MockQuerier_UseClientAssertion_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateUser provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) UpdateUser(ctx context.Context, arg repository.UpdateUserParams) (repository.User, error) {
+// UseRecoveryCode provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) UseRecoveryCode(ctx context.Context, arg repository.UseRecoveryCodeParams) (int64, error) {
 	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateUser")
+		panic("no return value specified for UseRecoveryCode")
 	}
 
-	var r0 repository.User
+	var r0 int64
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.UpdateUserParams) (repository.User, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.UseRecoveryCodeParams) (int64, error)); ok {
 		return returnFunc(ctx, arg)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.UpdateUserParams) repository.User); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.UseRecoveryCodeParams) int64); ok {
 		r0 = returnFunc(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(repository.User)
+		r0 = ret.Get(0).(int64)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.UpdateUserParams) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.UseRecoveryCodeParams) error); ok {
 		r1 = returnFunc(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -321,31 +5906,31 @@ func (_mock *MockQuerier) UpdateUser(ctx context.Context, arg repository.UpdateU
 	return r0, r1
 }
 
-// MockQuerier_UpdateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUser'
-type MockQuerier_UpdateUser_Call struct {
+// MockQuerier_UseRecoveryCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseRecoveryCode'
+type MockQuerier_UseRecoveryCode_Call struct {
 	*mock.Call
 }
 
-// UpdateUser is a helper method to define mock.On call
+// UseRecoveryCode is a helper method to define mock.On call
 //   - ctx
 //   - arg
-func (_e *MockQuerier_Expecter) UpdateUser(ctx interface{}, arg interface{}) *MockQuerier_UpdateUser_Call {
-	return &MockQuerier_UpdateUser_Call{Call: _e.mock.On("UpdateUser", ctx, arg)}
+func (_e *MockQuerier_Expecter) UseRecoveryCode(ctx interface{}, arg interface{}) *MockQuerier_UseRecoveryCode_Call {
+	return &MockQuerier_UseRecoveryCode_Call{Call: _e.mock.On("UseRecoveryCode", ctx, arg)}
 }
 
-func (_c *MockQuerier_UpdateUser_Call) Run(run func(ctx context.Context, arg repository.UpdateUserParams)) *MockQuerier_UpdateUser_Call {
+func (_c *MockQuerier_UseRecoveryCode_Call) Run(run func(ctx context.Context, arg repository.UseRecoveryCodeParams)) *MockQuerier_UseRecoveryCode_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(repository.UpdateUserParams))
+		run(args[0].(context.Context), args[1].(repository.UseRecoveryCodeParams))
 	})
 	return _c
 }
 
-func (_c *MockQuerier_UpdateUser_Call) Return(user repository.User, err error) *MockQuerier_UpdateUser_Call {
-	_c.Call.Return(user, err)
+func (_c *MockQuerier_UseRecoveryCode_Call) Return(n int64, err error) *MockQuerier_UseRecoveryCode_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockQuerier_UpdateUser_Call) RunAndReturn(run func(ctx context.Context, arg repository.UpdateUserParams) (repository.User, error)) *MockQuerier_UpdateUser_Call {
+func (_c *MockQuerier_UseRecoveryCode_Call) RunAndReturn(run func(ctx context.Context, arg repository.UseRecoveryCodeParams) (int64, error)) *MockQuerier_UseRecoveryCode_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -404,3 +5989,58 @@ func (_c *MockQuerier_UserExistsByEmail_Call) RunAndReturn(run func(ctx context.
 	_c.Call.Return(run)
 	return _c
 }
+
+// UserExistsByUsername provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) UserExistsByUsername(ctx context.Context, username string) (bool, error) {
+	ret := _mock.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserExistsByUsername")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return returnFunc(ctx, username)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(ctx, username)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_UserExistsByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserExistsByUsername'
+type MockQuerier_UserExistsByUsername_Call struct {
+	*mock.Call
+}
+
+// UserExistsByUsername is a helper method to define mock.On call
+//   - ctx
+//   - username
+func (_e *MockQuerier_Expecter) UserExistsByUsername(ctx interface{}, username interface{}) *MockQuerier_UserExistsByUsername_Call {
+	return &MockQuerier_UserExistsByUsername_Call{Call: _e.mock.On("UserExistsByUsername", ctx, username)}
+}
+
+func (_c *MockQuerier_UserExistsByUsername_Call) Run(run func(ctx context.Context, username string)) *MockQuerier_UserExistsByUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UserExistsByUsername_Call) Return(b bool, err error) *MockQuerier_UserExistsByUsername_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockQuerier_UserExistsByUsername_Call) RunAndReturn(run func(ctx context.Context, username string) (bool, error)) *MockQuerier_UserExistsByUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
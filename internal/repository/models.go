@@ -8,12 +8,210 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-type User struct {
+type EmailChange struct {
+	ID               pgtype.UUID        `json:"id"`
+	UserID           pgtype.UUID        `json:"user_id"`
+	OldEmail         string             `json:"old_email"`
+	NewEmail         string             `json:"new_email"`
+	ConfirmTokenHash string             `json:"confirm_token_hash"`
+	RevertTokenHash  string             `json:"revert_token_hash"`
+	ExpiresAt        pgtype.Timestamptz `json:"expires_at"`
+	RevertExpiresAt  pgtype.Timestamptz `json:"revert_expires_at"`
+	ConfirmedAt      pgtype.Timestamptz `json:"confirmed_at"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+}
+
+type EmailVerificationToken struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	Email     string             `json:"email"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type Invitation struct {
+	ID             pgtype.UUID        `json:"id"`
+	Email          string             `json:"email"`
+	Role           string             `json:"role"`
+	OrganizationID pgtype.UUID        `json:"organization_id"`
+	InvitedBy      pgtype.UUID        `json:"invited_by"`
+	TokenHash      string             `json:"token_hash"`
+	ExpiresAt      pgtype.Timestamptz `json:"expires_at"`
+	AcceptedAt     pgtype.Timestamptz `json:"accepted_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type LoginThrottle struct {
+	ID             pgtype.UUID        `json:"id"`
+	Scope          string             `json:"scope"`
+	Subject        string             `json:"subject"`
+	FailedAttempts int32              `json:"failed_attempts"`
+	LastFailedAt   pgtype.Timestamptz `json:"last_failed_at"`
+	LockedUntil    pgtype.Timestamptz `json:"locked_until"`
+}
+
+type Membership struct {
+	OrganizationID pgtype.UUID        `json:"organization_id"`
+	UserID         pgtype.UUID        `json:"user_id"`
+	RoleID         pgtype.UUID        `json:"role_id"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type MfaRecoveryCode struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	CodeHash  string             `json:"code_hash"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type Organization struct {
+	ID        pgtype.UUID        `json:"id"`
+	Name      string             `json:"name"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type PasswordHistory struct {
 	ID           pgtype.UUID        `json:"id"`
-	Username     string             `json:"username"`
-	Email        string             `json:"email"`
+	UserID       pgtype.UUID        `json:"user_id"`
 	PasswordHash string             `json:"password_hash"`
-	UserRole     string             `json:"user_role"`
 	CreatedAt    pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+}
+
+type PasswordResetToken struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	TokenHash string             `json:"token_hash"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type Permission struct {
+	ID          pgtype.UUID `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+}
+
+type PersonalAccessToken struct {
+	ID          pgtype.UUID        `json:"id"`
+	UserID      pgtype.UUID        `json:"user_id"`
+	Name        string             `json:"name"`
+	TokenPrefix string             `json:"token_prefix"`
+	TokenHash   string             `json:"token_hash"`
+	Scopes      []string           `json:"scopes"`
+	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+	LastUsedAt  pgtype.Timestamptz `json:"last_used_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type RevokedToken struct {
+	Jti       string             `json:"jti"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	RevokedAt pgtype.Timestamptz `json:"revoked_at"`
+}
+
+type Role struct {
+	ID          pgtype.UUID        `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type RolePermission struct {
+	RoleID       pgtype.UUID `json:"role_id"`
+	PermissionID pgtype.UUID `json:"permission_id"`
+}
+
+type ServiceAccount struct {
+	UserID      pgtype.UUID        `json:"user_id"`
+	Description string             `json:"description"`
+	Scopes      []string           `json:"scopes"`
+	CreatedBy   pgtype.UUID        `json:"created_by"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+type ServiceAccountCredential struct {
+	ID             pgtype.UUID        `json:"id"`
+	UserID         pgtype.UUID        `json:"user_id"`
+	CredentialType string             `json:"credential_type"`
+	SecretHash     pgtype.Text        `json:"secret_hash"`
+	PublicKey      pgtype.Text        `json:"public_key"`
+	LastUsedAt     pgtype.Timestamptz `json:"last_used_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type Session struct {
+	ID               pgtype.UUID        `json:"id"`
+	UserID           pgtype.UUID        `json:"user_id"`
+	FamilyID         pgtype.UUID        `json:"family_id"`
+	RefreshTokenHash string             `json:"refresh_token_hash"`
+	ExpiresAt        pgtype.Timestamptz `json:"expires_at"`
+	RotatedAt        pgtype.Timestamptz `json:"rotated_at"`
+	RevokedAt        pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	OrganizationID   pgtype.UUID        `json:"organization_id"`
+}
+
+type UsedClientAssertion struct {
+	UserID    pgtype.UUID        `json:"user_id"`
+	Jti       string             `json:"jti"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+type User struct {
+	ID                    pgtype.UUID        `json:"id"`
+	Username              string             `json:"username"`
+	Email                 string             `json:"email"`
+	PasswordHash          string             `json:"password_hash"`
+	UserRole              string             `json:"user_role"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt             pgtype.Timestamptz `json:"updated_at"`
+	EmailVerifiedAt       pgtype.Timestamptz `json:"email_verified_at"`
+	PasswordChangedAt     pgtype.Timestamptz `json:"password_changed_at"`
+	DisabledAt            pgtype.Timestamptz `json:"disabled_at"`
+	PasswordResetRequired bool               `json:"password_reset_required"`
+	PendingApproval       bool               `json:"pending_approval"`
+	AccountType           string             `json:"account_type"`
+}
+
+type UserMfa struct {
+	UserID          pgtype.UUID        `json:"user_id"`
+	EncryptedSecret string             `json:"encrypted_secret"`
+	EnabledAt       pgtype.Timestamptz `json:"enabled_at"`
+	LastUsedStep    int64              `json:"last_used_step"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+}
+
+type UserRole struct {
+	UserID    pgtype.UUID        `json:"user_id"`
+	RoleID    pgtype.UUID        `json:"role_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type UserTokenRevocation struct {
+	UserID        pgtype.UUID        `json:"user_id"`
+	RevokedBefore pgtype.Timestamptz `json:"revoked_before"`
+}
+
+type WebauthnChallenge struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	Ceremony  string             `json:"ceremony"`
+	Challenge string             `json:"challenge"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type WebauthnCredential struct {
+	ID           pgtype.UUID        `json:"id"`
+	UserID       pgtype.UUID        `json:"user_id"`
+	CredentialID []byte             `json:"credential_id"`
+	PublicKey    []byte             `json:"public_key"`
+	SignCount    int64              `json:"sign_count"`
+	Name         string             `json:"name"`
+	LastUsedAt   pgtype.Timestamptz `json:"last_used_at"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
 }
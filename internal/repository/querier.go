@@ -11,13 +11,120 @@ import (
 )
 
 type Querier interface {
+	AcceptInvitation(ctx context.Context, tokenHash string) (Invitation, error)
+	AddRolePermissions(ctx context.Context, arg AddRolePermissionsParams) error
+	ApproveUser(ctx context.Context, id pgtype.UUID) (User, error)
+	ConfirmEmailChange(ctx context.Context, confirmTokenHash string) (EmailChange, error)
+	ConsumeEmailVerificationToken(ctx context.Context, id pgtype.UUID) (EmailVerificationToken, error)
+	ConsumePasswordResetToken(ctx context.Context, tokenHash string) (PasswordResetToken, error)
+	ConsumeWebauthnChallenge(ctx context.Context, arg ConsumeWebauthnChallengeParams) (WebauthnChallenge, error)
+	CountOrganizationMembersWithRole(ctx context.Context, arg CountOrganizationMembersWithRoleParams) (int64, error)
+	CountRoleMemberships(ctx context.Context, roleID pgtype.UUID) (int64, error)
+	CountUsers(ctx context.Context, arg CountUsersParams) (int64, error)
+	CreateAdminUser(ctx context.Context, arg CreateAdminUserParams) (User, error)
+	CreateEmailChange(ctx context.Context, arg CreateEmailChangeParams) error
+	CreateEmailVerificationToken(ctx context.Context, arg CreateEmailVerificationTokenParams) (EmailVerificationToken, error)
+	CreateInvitation(ctx context.Context, arg CreateInvitationParams) (Invitation, error)
+	CreateMembership(ctx context.Context, arg CreateMembershipParams) error
+	CreateOrganization(ctx context.Context, name string) (Organization, error)
+	CreatePasswordHistoryEntry(ctx context.Context, arg CreatePasswordHistoryEntryParams) error
+	CreatePasswordResetToken(ctx context.Context, arg CreatePasswordResetTokenParams) error
+	CreatePersonalAccessToken(ctx context.Context, arg CreatePersonalAccessTokenParams) (PersonalAccessToken, error)
+	CreateRecoveryCode(ctx context.Context, arg CreateRecoveryCodeParams) error
+	CreateRole(ctx context.Context, arg CreateRoleParams) (Role, error)
+	CreateServiceAccount(ctx context.Context, arg CreateServiceAccountParams) (ServiceAccount, error)
+	CreateServiceAccountCredential(ctx context.Context, arg CreateServiceAccountCredentialParams) (ServiceAccountCredential, error)
+	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateWebauthnChallenge(ctx context.Context, arg CreateWebauthnChallengeParams) (WebauthnChallenge, error)
+	CreateWebauthnCredential(ctx context.Context, arg CreateWebauthnCredentialParams) (WebauthnCredential, error)
+	DeleteEmailChange(ctx context.Context, id pgtype.UUID) (int64, error)
+	DeleteEmailVerificationTokensByUserId(ctx context.Context, userID pgtype.UUID) error
+	DeleteExpiredClientAssertions(ctx context.Context) error
+	DeleteExpiredRevokedTokens(ctx context.Context) error
+	DeleteExpiredWebauthnChallenges(ctx context.Context) error
+	DeleteInvitation(ctx context.Context, id pgtype.UUID) (int64, error)
+	DeleteLoginThrottle(ctx context.Context, arg DeleteLoginThrottleParams) (int64, error)
+	DeleteMembership(ctx context.Context, arg DeleteMembershipParams) (int64, error)
+	DeletePasswordResetTokensByUserId(ctx context.Context, userID pgtype.UUID) error
+	DeletePendingEmailChangesByUserId(ctx context.Context, userID pgtype.UUID) error
+	DeletePersonalAccessToken(ctx context.Context, arg DeletePersonalAccessTokenParams) (int64, error)
+	DeleteRecoveryCodes(ctx context.Context, userID pgtype.UUID) error
+	DeleteRole(ctx context.Context, name string) (int64, error)
+	DeleteServiceAccountCredential(ctx context.Context, arg DeleteServiceAccountCredentialParams) (int64, error)
 	DeleteUser(ctx context.Context, id pgtype.UUID) error
+	DeleteUserMfa(ctx context.Context, userID pgtype.UUID) error
+	DeleteWebauthnCredential(ctx context.Context, arg DeleteWebauthnCredentialParams) (int64, error)
 	DropAllUsers(ctx context.Context) error
+	EnableUserMfa(ctx context.Context, userID pgtype.UUID) error
+	GetEmailChangeByRevertToken(ctx context.Context, revertTokenHash string) (EmailChange, error)
+	GetInvitationById(ctx context.Context, id pgtype.UUID) (Invitation, error)
+	GetLatestEmailVerificationToken(ctx context.Context, userID pgtype.UUID) (EmailVerificationToken, error)
+	GetLatestPasswordResetToken(ctx context.Context, userID pgtype.UUID) (PasswordResetToken, error)
+	GetLoginThrottle(ctx context.Context, arg GetLoginThrottleParams) (LoginThrottle, error)
+	GetMembership(ctx context.Context, arg GetMembershipParams) (GetMembershipRow, error)
+	GetOrganizationById(ctx context.Context, id pgtype.UUID) (Organization, error)
+	GetPasswordResetToken(ctx context.Context, tokenHash string) (PasswordResetToken, error)
+	GetPendingEmailChange(ctx context.Context, confirmTokenHash string) (EmailChange, error)
+	GetPendingInvitation(ctx context.Context, tokenHash string) (Invitation, error)
+	GetRecentPasswordHashes(ctx context.Context, arg GetRecentPasswordHashesParams) ([]string, error)
+	GetRoleByName(ctx context.Context, name string) (Role, error)
+	GetServiceAccount(ctx context.Context, userID pgtype.UUID) (GetServiceAccountRow, error)
+	GetServiceAccountSecret(ctx context.Context, arg GetServiceAccountSecretParams) (ServiceAccountCredential, error)
+	GetSessionByRefreshTokenHash(ctx context.Context, refreshTokenHash string) (Session, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserById(ctx context.Context, id pgtype.UUID) (User, error)
+	GetUserMfa(ctx context.Context, userID pgtype.UUID) (UserMfa, error)
+	GetValidPersonalAccessToken(ctx context.Context, tokenHash string) (PersonalAccessToken, error)
+	GetWebauthnCredentialByCredentialId(ctx context.Context, credentialID []byte) (WebauthnCredential, error)
+	IsTokenRevoked(ctx context.Context, arg IsTokenRevokedParams) (bool, error)
+	ListInvitations(ctx context.Context) ([]Invitation, error)
+	ListLockedLoginThrottles(ctx context.Context) ([]LoginThrottle, error)
+	ListMembershipPermissionNames(ctx context.Context, arg ListMembershipPermissionNamesParams) ([]string, error)
+	ListOrganizationInvitations(ctx context.Context, organizationID pgtype.UUID) ([]Invitation, error)
+	ListOrganizationMembers(ctx context.Context, organizationID pgtype.UUID) ([]ListOrganizationMembersRow, error)
+	ListPermissions(ctx context.Context) ([]Permission, error)
+	ListRolePermissionNames(ctx context.Context) ([]ListRolePermissionNamesRow, error)
+	ListRoles(ctx context.Context) ([]Role, error)
+	ListServiceAccountCredentials(ctx context.Context, userID pgtype.UUID) ([]ServiceAccountCredential, error)
+	ListServiceAccounts(ctx context.Context) ([]ListServiceAccountsRow, error)
+	ListUserMemberships(ctx context.Context, userID pgtype.UUID) ([]ListUserMembershipsRow, error)
+	ListUserPermissionNames(ctx context.Context, id pgtype.UUID) ([]string, error)
+	ListUserPersonalAccessTokens(ctx context.Context, userID pgtype.UUID) ([]PersonalAccessToken, error)
+	ListUserRoleNames(ctx context.Context, id pgtype.UUID) ([]string, error)
+	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	ListWebauthnCredentialsByUserId(ctx context.Context, userID pgtype.UUID) ([]WebauthnCredential, error)
+	MarkSessionRotated(ctx context.Context, id pgtype.UUID) (int64, error)
+	MarkUserEmailVerified(ctx context.Context, arg MarkUserEmailVerifiedParams) (int64, error)
+	PrunePasswordHistory(ctx context.Context, arg PrunePasswordHistoryParams) error
+	RecordLoginFailure(ctx context.Context, arg RecordLoginFailureParams) (LoginThrottle, error)
+	RenameOrganization(ctx context.Context, arg RenameOrganizationParams) (Organization, error)
+	RenewInvitation(ctx context.Context, arg RenewInvitationParams) (Invitation, error)
+	RequireUserPasswordReset(ctx context.Context, id pgtype.UUID) (int64, error)
+	RevokeOtherUserSessions(ctx context.Context, arg RevokeOtherUserSessionsParams) error
+	RevokeSessionByRefreshTokenHash(ctx context.Context, refreshTokenHash string) error
+	RevokeSessionFamily(ctx context.Context, familyID pgtype.UUID) error
+	RevokeToken(ctx context.Context, arg RevokeTokenParams) error
+	RevokeUserSessions(ctx context.Context, userID pgtype.UUID) error
+	RevokeUserTokensIssuedBefore(ctx context.Context, arg RevokeUserTokensIssuedBeforeParams) error
+	SetLoginLockedUntil(ctx context.Context, arg SetLoginLockedUntilParams) error
+	SetRolePermissions(ctx context.Context, arg SetRolePermissionsParams) error
+	SetUserDisabled(ctx context.Context, arg SetUserDisabledParams) (User, error)
+	SetUserRoles(ctx context.Context, arg SetUserRolesParams) error
+	TouchPersonalAccessToken(ctx context.Context, id pgtype.UUID) error
+	TouchServiceAccountCredential(ctx context.Context, id pgtype.UUID) error
+	UpdateMembershipRole(ctx context.Context, arg UpdateMembershipRoleParams) (int64, error)
+	UpdateMfaLastUsedStep(ctx context.Context, arg UpdateMfaLastUsedStepParams) (int64, error)
+	UpdateServiceAccountScopes(ctx context.Context, arg UpdateServiceAccountScopesParams) (int64, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
+	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error)
+	UpdateWebauthnCredentialSignCount(ctx context.Context, arg UpdateWebauthnCredentialSignCountParams) error
+	UpsertPendingUserMfa(ctx context.Context, arg UpsertPendingUserMfaParams) error
+	UseClientAssertion(ctx context.Context, arg UseClientAssertionParams) (int64, error)
+	UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (int64, error)
 	UserExistsByEmail(ctx context.Context, email string) (bool, error)
+	UserExistsByUsername(ctx context.Context, username string) (bool, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,25 @@
+-- name: CreateEmailChange :exec
+INSERT INTO email_changes (user_id, old_email, new_email, confirm_token_hash, revert_token_hash, expires_at, revert_expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: DeletePendingEmailChangesByUserId :exec
+DELETE FROM email_changes
+WHERE user_id = $1 AND confirmed_at IS NULL;
+
+-- name: GetPendingEmailChange :one
+SELECT * FROM email_changes
+WHERE confirm_token_hash = $1 AND confirmed_at IS NULL AND expires_at > NOW() LIMIT 1;
+
+-- name: ConfirmEmailChange :one
+UPDATE email_changes
+SET confirmed_at = NOW()
+WHERE confirm_token_hash = $1 AND confirmed_at IS NULL AND expires_at > NOW()
+RETURNING *;
+
+-- name: GetEmailChangeByRevertToken :one
+SELECT * FROM email_changes
+WHERE revert_token_hash = $1 AND revert_expires_at > NOW() LIMIT 1;
+
+-- name: DeleteEmailChange :execrows
+DELETE FROM email_changes
+WHERE id = $1;
@@ -0,0 +1,19 @@
+-- name: CreateEmailVerificationToken :one
+INSERT INTO email_verification_tokens (user_id, email, expires_at)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetLatestEmailVerificationToken :one
+SELECT * FROM email_verification_tokens
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: ConsumeEmailVerificationToken :one
+DELETE FROM email_verification_tokens
+WHERE id = $1 AND expires_at > NOW()
+RETURNING *;
+
+-- name: DeleteEmailVerificationTokensByUserId :exec
+DELETE FROM email_verification_tokens
+WHERE user_id = $1;
@@ -0,0 +1,38 @@
+-- name: CreateInvitation :one
+INSERT INTO invitations (email, role, organization_id, invited_by, token_hash, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetInvitationById :one
+SELECT * FROM invitations
+WHERE id = $1 AND accepted_at IS NULL LIMIT 1;
+
+-- name: GetPendingInvitation :one
+SELECT * FROM invitations
+WHERE token_hash = $1 AND accepted_at IS NULL AND expires_at > NOW() LIMIT 1;
+
+-- name: ListInvitations :many
+SELECT * FROM invitations
+WHERE accepted_at IS NULL
+ORDER BY created_at DESC;
+
+-- name: ListOrganizationInvitations :many
+SELECT * FROM invitations
+WHERE organization_id = $1 AND accepted_at IS NULL
+ORDER BY created_at DESC;
+
+-- name: AcceptInvitation :one
+UPDATE invitations
+SET accepted_at = NOW()
+WHERE token_hash = $1 AND accepted_at IS NULL AND expires_at > NOW()
+RETURNING *;
+
+-- name: RenewInvitation :one
+UPDATE invitations
+SET token_hash = $2, expires_at = $3
+WHERE id = $1 AND accepted_at IS NULL
+RETURNING *;
+
+-- name: DeleteInvitation :execrows
+DELETE FROM invitations
+WHERE id = $1 AND accepted_at IS NULL;
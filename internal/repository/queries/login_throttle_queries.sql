@@ -0,0 +1,28 @@
+-- name: GetLoginThrottle :one
+SELECT * FROM login_throttles
+WHERE scope = $1 AND subject = $2 LIMIT 1;
+
+-- name: RecordLoginFailure :one
+INSERT INTO login_throttles (scope, subject)
+VALUES ($1, $2)
+ON CONFLICT (scope, subject) DO UPDATE
+SET failed_attempts = CASE
+        WHEN login_throttles.last_failed_at < sqlc.arg(window_start) THEN 1
+        ELSE login_throttles.failed_attempts + 1
+    END,
+    last_failed_at = NOW()
+RETURNING *;
+
+-- name: SetLoginLockedUntil :exec
+UPDATE login_throttles
+SET locked_until = $3
+WHERE scope = $1 AND subject = $2;
+
+-- name: ListLockedLoginThrottles :many
+SELECT * FROM login_throttles
+WHERE locked_until > NOW()
+ORDER BY locked_until DESC;
+
+-- name: DeleteLoginThrottle :execrows
+DELETE FROM login_throttles
+WHERE scope = $1 AND subject = $2;
@@ -0,0 +1,69 @@
+-- name: CreateOrganization :one
+INSERT INTO organizations (name)
+VALUES ($1)
+RETURNING *;
+
+-- name: GetOrganizationById :one
+SELECT * FROM organizations
+WHERE id = $1 LIMIT 1;
+
+-- name: RenameOrganization :one
+UPDATE organizations
+SET name = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateMembership :exec
+INSERT INTO memberships (organization_id, user_id, role_id)
+VALUES ($1, $2, $3);
+
+-- name: GetMembership :one
+SELECT m.organization_id, o.name AS organization_name, m.user_id, r.name AS role_name, m.created_at
+FROM memberships m
+JOIN organizations o ON o.id = m.organization_id
+JOIN roles r ON r.id = m.role_id
+WHERE m.organization_id = $1 AND m.user_id = $2;
+
+-- name: ListUserMemberships :many
+SELECT m.organization_id, o.name AS organization_name, m.user_id, r.name AS role_name, m.created_at
+FROM memberships m
+JOIN organizations o ON o.id = m.organization_id
+JOIN roles r ON r.id = m.role_id
+WHERE m.user_id = $1
+ORDER BY o.name;
+
+-- name: ListOrganizationMembers :many
+SELECT m.user_id, u.username, u.email, r.name AS role_name, m.created_at
+FROM memberships m
+JOIN users u ON u.id = m.user_id
+JOIN roles r ON r.id = m.role_id
+WHERE m.organization_id = $1
+ORDER BY u.username;
+
+-- name: ListMembershipPermissionNames :many
+SELECT p.name
+FROM memberships m
+JOIN role_permissions rp ON rp.role_id = m.role_id
+JOIN permissions p ON p.id = rp.permission_id
+WHERE m.organization_id = $1 AND m.user_id = $2
+ORDER BY p.name;
+
+-- name: UpdateMembershipRole :execrows
+UPDATE memberships
+SET role_id = $3
+WHERE organization_id = $1 AND user_id = $2;
+
+-- name: DeleteMembership :execrows
+DELETE FROM memberships
+WHERE organization_id = $1 AND user_id = $2;
+
+-- name: CountOrganizationMembersWithRole :one
+SELECT COUNT(*)
+FROM memberships m
+JOIN roles r ON r.id = m.role_id
+WHERE m.organization_id = $1 AND r.name = $2;
+
+-- name: CountRoleMemberships :one
+SELECT COUNT(*)
+FROM memberships
+WHERE role_id = $1;
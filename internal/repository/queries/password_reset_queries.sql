@@ -0,0 +1,22 @@
+-- name: CreatePasswordResetToken :exec
+INSERT INTO password_reset_tokens (user_id, token_hash, expires_at)
+VALUES ($1, $2, $3);
+
+-- name: ConsumePasswordResetToken :one
+DELETE FROM password_reset_tokens
+WHERE token_hash = $1 AND expires_at > NOW()
+RETURNING *;
+
+-- name: DeletePasswordResetTokensByUserId :exec
+DELETE FROM password_reset_tokens
+WHERE user_id = $1;
+
+-- name: GetPasswordResetToken :one
+SELECT * FROM password_reset_tokens
+WHERE token_hash = $1 AND expires_at > NOW() LIMIT 1;
+
+-- name: GetLatestPasswordResetToken :one
+SELECT * FROM password_reset_tokens
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT 1;
@@ -0,0 +1,80 @@
+-- name: ListRoles :many
+SELECT * FROM roles
+ORDER BY name;
+
+-- name: GetRoleByName :one
+SELECT * FROM roles
+WHERE name = $1 LIMIT 1;
+
+-- name: CreateRole :one
+INSERT INTO roles (name, description)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: DeleteRole :execrows
+DELETE FROM roles
+WHERE name = $1;
+
+-- name: ListPermissions :many
+SELECT * FROM permissions
+ORDER BY name;
+
+-- name: ListRolePermissionNames :many
+SELECT r.name AS role_name, p.name AS permission_name
+FROM role_permissions rp
+JOIN roles r ON r.id = rp.role_id
+JOIN permissions p ON p.id = rp.permission_id
+ORDER BY r.name, p.name;
+
+-- name: SetRolePermissions :exec
+WITH removed AS (
+    DELETE FROM role_permissions rp
+    USING permissions p
+    WHERE rp.role_id = sqlc.arg(role_id)::uuid
+      AND p.id = rp.permission_id
+      AND NOT p.name = ANY(sqlc.arg(permissions)::text[])
+)
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT sqlc.arg(role_id)::uuid, p.id
+FROM permissions p
+WHERE p.name = ANY(sqlc.arg(permissions)::text[])
+ON CONFLICT DO NOTHING;
+
+-- name: AddRolePermissions :exec
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT sqlc.arg(role_id)::uuid, p.id
+FROM permissions p
+WHERE p.name = ANY(sqlc.arg(permissions)::text[])
+ON CONFLICT DO NOTHING;
+
+-- name: ListUserRoleNames :many
+SELECT r.name
+FROM users u
+JOIN roles r ON r.name = u.user_role
+    OR r.id IN (SELECT ur.role_id FROM user_roles ur WHERE ur.user_id = u.id)
+WHERE u.id = $1
+ORDER BY r.name;
+
+-- name: ListUserPermissionNames :many
+SELECT DISTINCT p.name
+FROM users u
+JOIN roles r ON r.name = u.user_role
+    OR r.id IN (SELECT ur.role_id FROM user_roles ur WHERE ur.user_id = u.id)
+JOIN role_permissions rp ON rp.role_id = r.id
+JOIN permissions p ON p.id = rp.permission_id
+WHERE u.id = $1
+ORDER BY p.name;
+
+-- name: SetUserRoles :exec
+WITH removed AS (
+    DELETE FROM user_roles ur
+    USING roles r
+    WHERE ur.user_id = sqlc.arg(user_id)::uuid
+      AND r.id = ur.role_id
+      AND NOT r.name = ANY(sqlc.arg(roles)::text[])
+)
+INSERT INTO user_roles (user_id, role_id)
+SELECT sqlc.arg(user_id)::uuid, r.id
+FROM roles r
+WHERE r.name = ANY(sqlc.arg(roles)::text[])
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,59 @@
+-- name: CreateServiceAccount :one
+WITH created_user AS (
+    INSERT INTO users (username, email, password_hash, user_role, account_type)
+    VALUES (sqlc.arg(username), sqlc.arg(email), '', sqlc.arg(user_role), 'service')
+    RETURNING id
+)
+INSERT INTO service_accounts (user_id, description, scopes, created_by)
+SELECT id, sqlc.arg(description)::text, sqlc.arg(scopes)::text[], sqlc.arg(created_by)::uuid
+FROM created_user
+RETURNING *;
+
+-- name: GetServiceAccount :one
+SELECT sa.user_id, u.username, u.disabled_at, sa.description, sa.scopes, sa.created_at, sa.updated_at
+FROM service_accounts sa
+JOIN users u ON u.id = sa.user_id
+WHERE sa.user_id = $1;
+
+-- name: ListServiceAccounts :many
+SELECT sa.user_id, u.username, u.disabled_at, sa.description, sa.scopes, sa.created_at, sa.updated_at
+FROM service_accounts sa
+JOIN users u ON u.id = sa.user_id
+ORDER BY u.username;
+
+-- name: UpdateServiceAccountScopes :execrows
+UPDATE service_accounts
+SET scopes = $2, updated_at = NOW()
+WHERE user_id = $1;
+
+-- name: CreateServiceAccountCredential :one
+INSERT INTO service_account_credentials (user_id, credential_type, secret_hash, public_key)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListServiceAccountCredentials :many
+SELECT * FROM service_account_credentials
+WHERE user_id = $1
+ORDER BY created_at;
+
+-- name: GetServiceAccountSecret :one
+SELECT * FROM service_account_credentials
+WHERE user_id = $1 AND credential_type = 'secret' AND secret_hash = $2 LIMIT 1;
+
+-- name: TouchServiceAccountCredential :exec
+UPDATE service_account_credentials
+SET last_used_at = NOW()
+WHERE id = $1;
+
+-- name: DeleteServiceAccountCredential :execrows
+DELETE FROM service_account_credentials
+WHERE id = $1 AND user_id = $2;
+
+-- name: UseClientAssertion :execrows
+INSERT INTO used_client_assertions (user_id, jti, expires_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, jti) DO NOTHING;
+
+-- name: DeleteExpiredClientAssertions :exec
+DELETE FROM used_client_assertions
+WHERE expires_at < NOW();
@@ -0,0 +1,37 @@
+-- name: CreateSession :one
+INSERT INTO sessions (user_id, family_id, refresh_token_hash, expires_at, organization_id)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetSessionByRefreshTokenHash :one
+SELECT * FROM sessions
+WHERE refresh_token_hash = $1 LIMIT 1;
+
+-- name: MarkSessionRotated :execrows
+UPDATE sessions
+SET rotated_at = NOW()
+WHERE id = $1 AND rotated_at IS NULL AND revoked_at IS NULL;
+
+-- name: RevokeSessionFamily :exec
+UPDATE sessions
+SET revoked_at = NOW()
+WHERE family_id = $1 AND revoked_at IS NULL;
+
+-- name: RevokeSessionByRefreshTokenHash :exec
+UPDATE sessions
+SET revoked_at = NOW()
+WHERE family_id = (
+    SELECT s.family_id FROM sessions s WHERE s.refresh_token_hash = $1
+) AND revoked_at IS NULL;
+
+-- name: RevokeUserSessions :exec
+UPDATE sessions
+SET revoked_at = NOW()
+WHERE user_id = $1 AND revoked_at IS NULL;
+
+-- name: RevokeOtherUserSessions :exec
+UPDATE sessions
+SET revoked_at = NOW()
+WHERE user_id = sqlc.arg(user_id) AND revoked_at IS NULL AND family_id NOT IN (
+    SELECT s.family_id FROM sessions s WHERE s.refresh_token_hash = sqlc.arg(refresh_token_hash)
+);
@@ -11,14 +11,28 @@ SELECT EXISTS (
     SELECT 1 FROM users WHERE email = $1
 ) AS exists;
 
+-- name: UserExistsByUsername :one
+SELECT EXISTS (
+    SELECT 1 FROM users WHERE username = $1
+) AS exists;
+
 -- name: CreateUser :one
-INSERT INTO users (username, email, password_hash, user_role)
-VALUES ($1, $2, $3, $4)
+INSERT INTO users (username, email, password_hash, user_role, pending_approval)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: CreateAdminUser :one
+INSERT INTO users (username, email, password_hash, user_role, email_verified_at, password_reset_required)
+VALUES ($1, $2, $3, $4, NOW(), TRUE)
 RETURNING *;
 
 -- name: UpdateUser :one
 UPDATE users
-SET username = $1, email = $2, password_hash = $3
+SET username = $1,
+    email = $2,
+    password_hash = $3,
+    email_verified_at = CASE WHEN email = $2 THEN email_verified_at END,
+    updated_at = NOW()
 WHERE id = $4
 RETURNING *;
 
@@ -28,3 +42,57 @@ WHERE id = $1;
 
 -- name: DropAllUsers :exec
 DELETE FROM users;
+
+-- name: MarkUserEmailVerified :execrows
+UPDATE users
+SET email_verified_at = NOW()
+WHERE id = $1 AND email = $2;
+
+-- name: UpdateUserPassword :exec
+UPDATE users
+SET password_hash = $2, password_changed_at = NOW(), password_reset_required = FALSE, updated_at = NOW()
+WHERE id = $1;
+
+-- name: ListUsers :many
+SELECT * FROM users
+WHERE (sqlc.arg(search)::text = '' OR username ILIKE '%' || sqlc.arg(search)::text || '%' OR email ILIKE '%' || sqlc.arg(search)::text || '%')
+  AND (sqlc.arg(role)::text = '' OR user_role = sqlc.arg(role)::text)
+  AND (sqlc.arg(status)::text = ''
+    OR (sqlc.arg(status)::text = 'active' AND disabled_at IS NULL AND NOT pending_approval)
+    OR (sqlc.arg(status)::text = 'disabled' AND disabled_at IS NOT NULL)
+    OR (sqlc.arg(status)::text = 'pending' AND pending_approval))
+ORDER BY created_at DESC, id
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: CountUsers :one
+SELECT COUNT(*) FROM users
+WHERE (sqlc.arg(search)::text = '' OR username ILIKE '%' || sqlc.arg(search)::text || '%' OR email ILIKE '%' || sqlc.arg(search)::text || '%')
+  AND (sqlc.arg(role)::text = '' OR user_role = sqlc.arg(role)::text)
+  AND (sqlc.arg(status)::text = ''
+    OR (sqlc.arg(status)::text = 'active' AND disabled_at IS NULL AND NOT pending_approval)
+    OR (sqlc.arg(status)::text = 'disabled' AND disabled_at IS NOT NULL)
+    OR (sqlc.arg(status)::text = 'pending' AND pending_approval));
+
+-- name: UpdateUserRole :one
+UPDATE users
+SET user_role = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: SetUserDisabled :one
+UPDATE users
+SET disabled_at = CASE WHEN sqlc.arg(disabled)::boolean THEN COALESCE(disabled_at, NOW()) END,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: RequireUserPasswordReset :execrows
+UPDATE users
+SET password_reset_required = TRUE, updated_at = NOW()
+WHERE id = $1;
+
+-- name: ApproveUser :one
+UPDATE users
+SET pending_approval = FALSE, updated_at = NOW()
+WHERE id = $1 AND pending_approval
+RETURNING *;
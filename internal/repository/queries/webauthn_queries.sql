@@ -0,0 +1,36 @@
+-- name: CreateWebauthnCredential :one
+INSERT INTO webauthn_credentials (user_id, credential_id, public_key, sign_count, name)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetWebauthnCredentialByCredentialId :one
+SELECT * FROM webauthn_credentials
+WHERE credential_id = $1 LIMIT 1;
+
+-- name: ListWebauthnCredentialsByUserId :many
+SELECT * FROM webauthn_credentials
+WHERE user_id = $1
+ORDER BY created_at;
+
+-- name: UpdateWebauthnCredentialSignCount :exec
+UPDATE webauthn_credentials
+SET sign_count = $2, last_used_at = NOW()
+WHERE id = $1;
+
+-- name: DeleteWebauthnCredential :execrows
+DELETE FROM webauthn_credentials
+WHERE id = $1 AND user_id = $2;
+
+-- name: CreateWebauthnChallenge :one
+INSERT INTO webauthn_challenges (user_id, ceremony, challenge, expires_at)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ConsumeWebauthnChallenge :one
+DELETE FROM webauthn_challenges
+WHERE id = $1 AND ceremony = $2
+RETURNING *;
+
+-- name: DeleteExpiredWebauthnChallenges :exec
+DELETE FROM webauthn_challenges
+WHERE expires_at < NOW();
@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErrTenantMismatch is returned by TenantQuerier for queries that would read
+// or change another organization than the tenant of the context.
+var ErrTenantMismatch = errors.New("query reaches outside of the tenant")
+
+type tenantContextKey struct{}
+
+// WithTenant returns a context that scopes the queries of a TenantQuerier to
+// the given organization.
+func WithTenant(ctx context.Context, organizationID uuid.UUID) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, organizationID)
+}
+
+// TenantFromContext returns the organization set by WithTenant.
+func TenantFromContext(ctx context.Context) (uuid.UUID, bool) {
+	organizationID, ok := ctx.Value(tenantContextKey{}).(uuid.UUID)
+
+	return organizationID, ok && organizationID != uuid.Nil
+}
+
+// TenantQuerier checks the organization of every organization scoped query
+// against the tenant of the context, so that a request scoped to one
+// organization cannot reach another one even if a service passes the wrong
+// id. Contexts without a tenant are not restricted, organizations are also
+// created, joined and switched between outside of a tenant.
+type TenantQuerier struct {
+	Querier
+}
+
+func NewTenantQuerier(queries Querier) *TenantQuerier {
+	return &TenantQuerier{
+		Querier: queries,
+	}
+}
+
+func checkTenant(ctx context.Context, organizationID pgtype.UUID) error {
+	tenantID, ok := TenantFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	if !organizationID.Valid || uuid.UUID(organizationID.Bytes) != tenantID {
+		return ErrTenantMismatch
+	}
+
+	return nil
+}
+
+func (q *TenantQuerier) GetOrganizationById(ctx context.Context, id pgtype.UUID) (Organization, error) {
+	if err := checkTenant(ctx, id); err != nil {
+		return Organization{}, err
+	}
+
+	return q.Querier.GetOrganizationById(ctx, id)
+}
+
+func (q *TenantQuerier) RenameOrganization(ctx context.Context, arg RenameOrganizationParams) (Organization, error) {
+	if err := checkTenant(ctx, arg.ID); err != nil {
+		return Organization{}, err
+	}
+
+	return q.Querier.RenameOrganization(ctx, arg)
+}
+
+func (q *TenantQuerier) CreateMembership(ctx context.Context, arg CreateMembershipParams) error {
+	if err := checkTenant(ctx, arg.OrganizationID); err != nil {
+		return err
+	}
+
+	return q.Querier.CreateMembership(ctx, arg)
+}
+
+func (q *TenantQuerier) GetMembership(ctx context.Context, arg GetMembershipParams) (GetMembershipRow, error) {
+	if err := checkTenant(ctx, arg.OrganizationID); err != nil {
+		return GetMembershipRow{}, err
+	}
+
+	return q.Querier.GetMembership(ctx, arg)
+}
+
+func (q *TenantQuerier) ListOrganizationMembers(ctx context.Context, organizationID pgtype.UUID) ([]ListOrganizationMembersRow, error) {
+	if err := checkTenant(ctx, organizationID); err != nil {
+		return nil, err
+	}
+
+	return q.Querier.ListOrganizationMembers(ctx, organizationID)
+}
+
+func (q *TenantQuerier) ListMembershipPermissionNames(ctx context.Context, arg ListMembershipPermissionNamesParams) ([]string, error) {
+	if err := checkTenant(ctx, arg.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	return q.Querier.ListMembershipPermissionNames(ctx, arg)
+}
+
+func (q *TenantQuerier) UpdateMembershipRole(ctx context.Context, arg UpdateMembershipRoleParams) (int64, error) {
+	if err := checkTenant(ctx, arg.OrganizationID); err != nil {
+		return 0, err
+	}
+
+	return q.Querier.UpdateMembershipRole(ctx, arg)
+}
+
+func (q *TenantQuerier) DeleteMembership(ctx context.Context, arg DeleteMembershipParams) (int64, error) {
+	if err := checkTenant(ctx, arg.OrganizationID); err != nil {
+		return 0, err
+	}
+
+	return q.Querier.DeleteMembership(ctx, arg)
+}
+
+func (q *TenantQuerier) CountOrganizationMembersWithRole(ctx context.Context, arg CountOrganizationMembersWithRoleParams) (int64, error) {
+	if err := checkTenant(ctx, arg.OrganizationID); err != nil {
+		return 0, err
+	}
+
+	return q.Querier.CountOrganizationMembersWithRole(ctx, arg)
+}
+
+func (q *TenantQuerier) CreateInvitation(ctx context.Context, arg CreateInvitationParams) (Invitation, error) {
+	if err := checkTenant(ctx, arg.OrganizationID); err != nil {
+		return Invitation{}, err
+	}
+
+	return q.Querier.CreateInvitation(ctx, arg)
+}
+
+func (q *TenantQuerier) ListOrganizationInvitations(ctx context.Context, organizationID pgtype.UUID) ([]Invitation, error) {
+	if err := checkTenant(ctx, organizationID); err != nil {
+		return nil, err
+	}
+
+	return q.Querier.ListOrganizationInvitations(ctx, organizationID)
+}
+
+// ListInvitations lists the invitations of all organizations, which no tenant
+// may see.
+func (q *TenantQuerier) ListInvitations(ctx context.Context) ([]Invitation, error) {
+	if _, ok := TenantFromContext(ctx); ok {
+		return nil, ErrTenantMismatch
+	}
+
+	return q.Querier.ListInvitations(ctx)
+}
+
+// GetInvitationById does not find the invitations of other organizations.
+func (q *TenantQuerier) GetInvitationById(ctx context.Context, id pgtype.UUID) (Invitation, error) {
+	invitation, err := q.Querier.GetInvitationById(ctx, id)
+	if err != nil {
+		return Invitation{}, err
+	}
+	if err := checkTenant(ctx, invitation.OrganizationID); err != nil {
+		return Invitation{}, sql.ErrNoRows
+	}
+
+	return invitation, nil
+}
+
+// RenewInvitation and DeleteInvitation only get the id of the invitation, its
+// organization is looked up before it is changed.
+func (q *TenantQuerier) RenewInvitation(ctx context.Context, arg RenewInvitationParams) (Invitation, error) {
+	if err := q.checkInvitationTenant(ctx, arg.ID); err != nil {
+		return Invitation{}, err
+	}
+
+	return q.Querier.RenewInvitation(ctx, arg)
+}
+
+func (q *TenantQuerier) DeleteInvitation(ctx context.Context, id pgtype.UUID) (int64, error) {
+	if err := q.checkInvitationTenant(ctx, id); err != nil {
+		return 0, err
+	}
+
+	return q.Querier.DeleteInvitation(ctx, id)
+}
+
+func (q *TenantQuerier) checkInvitationTenant(ctx context.Context, id pgtype.UUID) error {
+	if _, ok := TenantFromContext(ctx); !ok {
+		return nil
+	}
+	_, err := q.GetInvitationById(ctx, id)
+
+	return err
+}
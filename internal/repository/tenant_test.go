@@ -0,0 +1,86 @@
+//go:build unittest
+
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	repositoryMocks "github.com/fgeck/gotth-postgres/internal/repository/mocks"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTenantQuerierTest(t *testing.T) (*repositoryMocks.MockQuerier, *repository.TenantQuerier) {
+	queries := repositoryMocks.NewMockQuerier(t)
+	return queries, repository.NewTenantQuerier(queries)
+}
+
+func TestTenantQuerier(t *testing.T) {
+	tenantID := uuid.New()
+	otherID := uuid.New()
+	pgTenantID := pgtype.UUID{Bytes: tenantID, Valid: true}
+	pgOtherID := pgtype.UUID{Bytes: otherID, Valid: true}
+	ctx := repository.WithTenant(context.Background(), tenantID)
+
+	t.Run("passes queries of the tenant", func(t *testing.T) {
+		queries, tenantQueries := setupTenantQuerierTest(t)
+		queries.On("ListOrganizationMembers", ctx, pgTenantID).Return([]repository.ListOrganizationMembersRow{}, nil)
+
+		_, err := tenantQueries.ListOrganizationMembers(ctx, pgTenantID)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects queries of other organizations", func(t *testing.T) {
+		queries, tenantQueries := setupTenantQuerierTest(t)
+
+		_, err := tenantQueries.DeleteMembership(ctx, repository.DeleteMembershipParams{
+			OrganizationID: pgOtherID,
+			UserID:         pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		})
+
+		require.ErrorIs(t, err, repository.ErrTenantMismatch)
+		queries.AssertNotCalled(t, "DeleteMembership", mock.Anything, mock.Anything)
+	})
+
+	t.Run("does not restrict contexts without a tenant", func(t *testing.T) {
+		queries, tenantQueries := setupTenantQuerierTest(t)
+		ctx := context.Background()
+		queries.On("GetOrganizationById", ctx, pgOtherID).Return(repository.Organization{ID: pgOtherID}, nil)
+		queries.On("ListInvitations", ctx).Return([]repository.Invitation{}, nil)
+
+		_, err := tenantQueries.GetOrganizationById(ctx, pgOtherID)
+		require.NoError(t, err)
+		_, err = tenantQueries.ListInvitations(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("does not list the invitations of all organizations", func(t *testing.T) {
+		queries, tenantQueries := setupTenantQuerierTest(t)
+
+		_, err := tenantQueries.ListInvitations(ctx)
+
+		require.ErrorIs(t, err, repository.ErrTenantMismatch)
+		queries.AssertNotCalled(t, "ListInvitations", mock.Anything)
+	})
+
+	t.Run("does not find or revoke invitations of other organizations", func(t *testing.T) {
+		queries, tenantQueries := setupTenantQuerierTest(t)
+		id := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+		queries.On("GetInvitationById", ctx, id).Return(repository.Invitation{ID: id, OrganizationID: pgOtherID}, nil)
+
+		_, err := tenantQueries.GetInvitationById(ctx, id)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+
+		deleted, err := tenantQueries.DeleteInvitation(ctx, id)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+		assert.Zero(t, deleted)
+		queries.AssertNotCalled(t, "DeleteInvitation", mock.Anything, mock.Anything)
+	})
+}
@@ -11,25 +11,117 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const createUser = `-- name: CreateUser :one
-INSERT INTO users (username, email, password_hash, user_role)
-VALUES ($1, $2, $3, $4)
-RETURNING id, username, email, password_hash, user_role, created_at, updated_at
+const approveUser = `-- name: ApproveUser :one
+UPDATE users
+SET pending_approval = FALSE, updated_at = NOW()
+WHERE id = $1 AND pending_approval
+RETURNING id, username, email, password_hash, user_role, created_at, updated_at, email_verified_at, password_changed_at, disabled_at, password_reset_required, pending_approval, account_type
 `
 
-type CreateUserParams struct {
+func (q *Queries) ApproveUser(ctx context.Context, id pgtype.UUID) (User, error) {
+	row := q.db.QueryRow(ctx, approveUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.PasswordHash,
+		&i.UserRole,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.EmailVerifiedAt,
+		&i.PasswordChangedAt,
+		&i.DisabledAt,
+		&i.PasswordResetRequired,
+		&i.PendingApproval,
+		&i.AccountType,
+	)
+	return i, err
+}
+
+const countUsers = `-- name: CountUsers :one
+SELECT COUNT(*) FROM users
+WHERE ($1::text = '' OR username ILIKE '%' || $1::text || '%' OR email ILIKE '%' || $1::text || '%')
+  AND ($2::text = '' OR user_role = $2::text)
+  AND ($3::text = ''
+    OR ($3::text = 'active' AND disabled_at IS NULL AND NOT pending_approval)
+    OR ($3::text = 'disabled' AND disabled_at IS NOT NULL)
+    OR ($3::text = 'pending' AND pending_approval))
+`
+
+type CountUsersParams struct {
+	Search string `json:"search"`
+	Role   string `json:"role"`
+	Status string `json:"status"`
+}
+
+func (q *Queries) CountUsers(ctx context.Context, arg CountUsersParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countUsers, arg.Search, arg.Role, arg.Status)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createAdminUser = `-- name: CreateAdminUser :one
+INSERT INTO users (username, email, password_hash, user_role, email_verified_at, password_reset_required)
+VALUES ($1, $2, $3, $4, NOW(), TRUE)
+RETURNING id, username, email, password_hash, user_role, created_at, updated_at, email_verified_at, password_changed_at, disabled_at, password_reset_required, pending_approval, account_type
+`
+
+type CreateAdminUserParams struct {
 	Username     string `json:"username"`
 	Email        string `json:"email"`
 	PasswordHash string `json:"password_hash"`
 	UserRole     string `json:"user_role"`
 }
 
+func (q *Queries) CreateAdminUser(ctx context.Context, arg CreateAdminUserParams) (User, error) {
+	row := q.db.QueryRow(ctx, createAdminUser,
+		arg.Username,
+		arg.Email,
+		arg.PasswordHash,
+		arg.UserRole,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.PasswordHash,
+		&i.UserRole,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.EmailVerifiedAt,
+		&i.PasswordChangedAt,
+		&i.DisabledAt,
+		&i.PasswordResetRequired,
+		&i.PendingApproval,
+		&i.AccountType,
+	)
+	return i, err
+}
+
+const createUser = `-- name: CreateUser :one
+INSERT INTO users (username, email, password_hash, user_role, pending_approval)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, username, email, password_hash, user_role, created_at, updated_at, email_verified_at, password_changed_at, disabled_at, password_reset_required, pending_approval, account_type
+`
+
+type CreateUserParams struct {
+	Username        string `json:"username"`
+	Email           string `json:"email"`
+	PasswordHash    string `json:"password_hash"`
+	UserRole        string `json:"user_role"`
+	PendingApproval bool   `json:"pending_approval"`
+}
+
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
 	row := q.db.QueryRow(ctx, createUser,
 		arg.Username,
 		arg.Email,
 		arg.PasswordHash,
 		arg.UserRole,
+		arg.PendingApproval,
 	)
 	var i User
 	err := row.Scan(
@@ -40,6 +132,12 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.UserRole,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.EmailVerifiedAt,
+		&i.PasswordChangedAt,
+		&i.DisabledAt,
+		&i.PasswordResetRequired,
+		&i.PendingApproval,
+		&i.AccountType,
 	)
 	return i, err
 }
@@ -64,7 +162,7 @@ func (q *Queries) DropAllUsers(ctx context.Context) error {
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, username, email, password_hash, user_role, created_at, updated_at FROM users 
+SELECT id, username, email, password_hash, user_role, created_at, updated_at, email_verified_at, password_changed_at, disabled_at, password_reset_required, pending_approval, account_type FROM users 
 WHERE email = $1 LIMIT 1
 `
 
@@ -79,12 +177,18 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.UserRole,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.EmailVerifiedAt,
+		&i.PasswordChangedAt,
+		&i.DisabledAt,
+		&i.PasswordResetRequired,
+		&i.PendingApproval,
+		&i.AccountType,
 	)
 	return i, err
 }
 
 const getUserById = `-- name: GetUserById :one
-SELECT id, username, email, password_hash, user_role, created_at, updated_at FROM users 
+SELECT id, username, email, password_hash, user_role, created_at, updated_at, email_verified_at, password_changed_at, disabled_at, password_reset_required, pending_approval, account_type FROM users 
 WHERE id = $1 LIMIT 1
 `
 
@@ -99,15 +203,152 @@ func (q *Queries) GetUserById(ctx context.Context, id pgtype.UUID) (User, error)
 		&i.UserRole,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.EmailVerifiedAt,
+		&i.PasswordChangedAt,
+		&i.DisabledAt,
+		&i.PasswordResetRequired,
+		&i.PendingApproval,
+		&i.AccountType,
+	)
+	return i, err
+}
+
+const listUsers = `-- name: ListUsers :many
+SELECT id, username, email, password_hash, user_role, created_at, updated_at, email_verified_at, password_changed_at, disabled_at, password_reset_required, pending_approval, account_type FROM users
+WHERE ($1::text = '' OR username ILIKE '%' || $1::text || '%' OR email ILIKE '%' || $1::text || '%')
+  AND ($2::text = '' OR user_role = $2::text)
+  AND ($3::text = ''
+    OR ($3::text = 'active' AND disabled_at IS NULL AND NOT pending_approval)
+    OR ($3::text = 'disabled' AND disabled_at IS NOT NULL)
+    OR ($3::text = 'pending' AND pending_approval))
+ORDER BY created_at DESC, id
+LIMIT $4 OFFSET $5
+`
+
+type ListUsersParams struct {
+	Search     string `json:"search"`
+	Role       string `json:"role"`
+	Status     string `json:"status"`
+	PageLimit  int32  `json:"page_limit"`
+	PageOffset int32  `json:"page_offset"`
+}
+
+func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsers,
+		arg.Search,
+		arg.Role,
+		arg.Status,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Email,
+			&i.PasswordHash,
+			&i.UserRole,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.EmailVerifiedAt,
+			&i.PasswordChangedAt,
+			&i.DisabledAt,
+			&i.PasswordResetRequired,
+			&i.PendingApproval,
+			&i.AccountType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markUserEmailVerified = `-- name: MarkUserEmailVerified :execrows
+UPDATE users
+SET email_verified_at = NOW()
+WHERE id = $1 AND email = $2
+`
+
+type MarkUserEmailVerifiedParams struct {
+	ID    pgtype.UUID `json:"id"`
+	Email string      `json:"email"`
+}
+
+func (q *Queries) MarkUserEmailVerified(ctx context.Context, arg MarkUserEmailVerifiedParams) (int64, error) {
+	result, err := q.db.Exec(ctx, markUserEmailVerified, arg.ID, arg.Email)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const requireUserPasswordReset = `-- name: RequireUserPasswordReset :execrows
+UPDATE users
+SET password_reset_required = TRUE, updated_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) RequireUserPasswordReset(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, requireUserPasswordReset, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setUserDisabled = `-- name: SetUserDisabled :one
+UPDATE users
+SET disabled_at = CASE WHEN $1::boolean THEN COALESCE(disabled_at, NOW()) END,
+    updated_at = NOW()
+WHERE id = $2
+RETURNING id, username, email, password_hash, user_role, created_at, updated_at, email_verified_at, password_changed_at, disabled_at, password_reset_required, pending_approval, account_type
+`
+
+type SetUserDisabledParams struct {
+	Disabled bool        `json:"disabled"`
+	ID       pgtype.UUID `json:"id"`
+}
+
+func (q *Queries) SetUserDisabled(ctx context.Context, arg SetUserDisabledParams) (User, error) {
+	row := q.db.QueryRow(ctx, setUserDisabled, arg.Disabled, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.PasswordHash,
+		&i.UserRole,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.EmailVerifiedAt,
+		&i.PasswordChangedAt,
+		&i.DisabledAt,
+		&i.PasswordResetRequired,
+		&i.PendingApproval,
+		&i.AccountType,
 	)
 	return i, err
 }
 
 const updateUser = `-- name: UpdateUser :one
 UPDATE users
-SET username = $1, email = $2, password_hash = $3
+SET username = $1,
+    email = $2,
+    password_hash = $3,
+    email_verified_at = CASE WHEN email = $2 THEN email_verified_at END,
+    updated_at = NOW()
 WHERE id = $4
-RETURNING id, username, email, password_hash, user_role, created_at, updated_at
+RETURNING id, username, email, password_hash, user_role, created_at, updated_at, email_verified_at, password_changed_at, disabled_at, password_reset_required, pending_approval, account_type
 `
 
 type UpdateUserParams struct {
@@ -133,6 +374,61 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.UserRole,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.EmailVerifiedAt,
+		&i.PasswordChangedAt,
+		&i.DisabledAt,
+		&i.PasswordResetRequired,
+		&i.PendingApproval,
+		&i.AccountType,
+	)
+	return i, err
+}
+
+const updateUserPassword = `-- name: UpdateUserPassword :exec
+UPDATE users
+SET password_hash = $2, password_changed_at = NOW(), password_reset_required = FALSE, updated_at = NOW()
+WHERE id = $1
+`
+
+type UpdateUserPasswordParams struct {
+	ID           pgtype.UUID `json:"id"`
+	PasswordHash string      `json:"password_hash"`
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	_, err := q.db.Exec(ctx, updateUserPassword, arg.ID, arg.PasswordHash)
+	return err
+}
+
+const updateUserRole = `-- name: UpdateUserRole :one
+UPDATE users
+SET user_role = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING id, username, email, password_hash, user_role, created_at, updated_at, email_verified_at, password_changed_at, disabled_at, password_reset_required, pending_approval, account_type
+`
+
+type UpdateUserRoleParams struct {
+	ID       pgtype.UUID `json:"id"`
+	UserRole string      `json:"user_role"`
+}
+
+func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserRole, arg.ID, arg.UserRole)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.PasswordHash,
+		&i.UserRole,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.EmailVerifiedAt,
+		&i.PasswordChangedAt,
+		&i.DisabledAt,
+		&i.PasswordResetRequired,
+		&i.PendingApproval,
+		&i.AccountType,
 	)
 	return i, err
 }
@@ -149,3 +445,16 @@ func (q *Queries) UserExistsByEmail(ctx context.Context, email string) (bool, er
 	err := row.Scan(&exists)
 	return exists, err
 }
+
+const userExistsByUsername = `-- name: UserExistsByUsername :one
+SELECT EXISTS (
+    SELECT 1 FROM users WHERE username = $1
+) AS exists
+`
+
+func (q *Queries) UserExistsByUsername(ctx context.Context, username string) (bool, error) {
+	row := q.db.QueryRow(ctx, userExistsByUsername, username)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
@@ -0,0 +1,172 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fgeck/gotth-postgres/internal/service/emailChange"
+	"github.com/fgeck/gotth-postgres/internal/service/passwordHistory"
+	"github.com/fgeck/gotth-postgres/internal/service/passwordReset"
+	"github.com/fgeck/gotth-postgres/internal/service/security/password"
+	"github.com/fgeck/gotth-postgres/internal/service/session"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	"github.com/fgeck/gotth-postgres/internal/service/validation"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidCurrentPassword = errors.New("current password is incorrect")
+	ErrUsernameTaken          = errors.New("username is already taken")
+)
+
+type AccountServiceInterface interface {
+	GetAccount(ctx context.Context, userID uuid.UUID) (*user.UserDto, error)
+	UpdateProfile(ctx context.Context, userID uuid.UUID, username, email, currentPassword string) (*user.UserDto, error)
+	ChangePassword(ctx context.Context, userID uuid.UUID, currentPassword, newPassword, refreshToken string) error
+}
+
+// AccountService lets logged in users edit their own account.
+type AccountService struct {
+	userService            user.UserServiceInterface
+	passwordService        password.PasswordServiceInterface
+	passwordHistoryService passwordHistory.PasswordHistoryServiceInterface
+	sessionService         session.SessionServiceInterface
+	emailChangeService     emailChange.EmailChangeServiceInterface
+	passwordResetService   passwordReset.PasswordResetServiceInterface
+	validator              validation.ValidationServiceInterface
+}
+
+func NewAccountService(
+	userService user.UserServiceInterface,
+	passwordService password.PasswordServiceInterface,
+	passwordHistoryService passwordHistory.PasswordHistoryServiceInterface,
+	sessionService session.SessionServiceInterface,
+	emailChangeService emailChange.EmailChangeServiceInterface,
+	passwordResetService passwordReset.PasswordResetServiceInterface,
+	validator validation.ValidationServiceInterface,
+) *AccountService {
+	return &AccountService{
+		userService:            userService,
+		passwordService:        passwordService,
+		passwordHistoryService: passwordHistoryService,
+		sessionService:         sessionService,
+		emailChangeService:     emailChangeService,
+		passwordResetService:   passwordResetService,
+		validator:              validator,
+	}
+}
+
+func (s *AccountService) GetAccount(ctx context.Context, userID uuid.UUID) (*user.UserDto, error) {
+	userDto, err := s.userService.GetUserById(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return userDto, nil
+}
+
+// UpdateProfile changes the username right away. The email address is the
+// login identifier, so changing it requires the current password and only
+// starts a change that the new address has to confirm. The returned user
+// still has the old address in that case. An address that already belongs to
+// an account is not revealed, its owner gets a notice like on registration
+// instead of a confirmation link.
+func (s *AccountService) UpdateProfile(
+	ctx context.Context,
+	userID uuid.UUID,
+	username string,
+	email string,
+	currentPassword string,
+) (*user.UserDto, error) {
+	userDto, err := s.GetAccount(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	usernameChanged := username != userDto.Username
+	emailChanged := email != userDto.Email
+	if !usernameChanged && !emailChanged {
+		return userDto, nil
+	}
+
+	if usernameChanged {
+		if err := s.validator.ValidateUsername(username); err != nil {
+			return nil, err
+		}
+		exists, err := s.userService.UserExistsByUsername(ctx, username)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check username: %w", err)
+		}
+		if exists {
+			return nil, ErrUsernameTaken
+		}
+	}
+
+	var emailTaken bool
+	if emailChanged {
+		if err := s.passwordService.ComparePassword(userDto.PasswordHash, currentPassword); err != nil {
+			return nil, ErrInvalidCurrentPassword
+		}
+		if err := s.validator.ValidateEmail(email); err != nil {
+			return nil, err
+		}
+		emailTaken, err = s.userService.UserExistsByEmail(ctx, email)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check email: %w", err)
+		}
+	}
+
+	updatedUser := userDto
+	if usernameChanged {
+		updatedUser, err = s.userService.UpdateUser(ctx, userID, username, userDto.Email, userDto.PasswordHash)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case emailChanged && emailTaken:
+		if err := s.passwordResetService.NotifyExistingAccount(ctx, email); err != nil {
+			return nil, fmt.Errorf("failed to notify existing account: %w", err)
+		}
+	case emailChanged:
+		if err := s.emailChangeService.RequestChange(ctx, updatedUser, email); err != nil {
+			return nil, fmt.Errorf("failed to request email change: %w", err)
+		}
+	}
+
+	return updatedUser, nil
+}
+
+// ChangePassword sets a new password after checking the current one. All
+// other sessions of the user are logged out. The calling one, identified by
+// its refresh token, is kept but needs a new access token.
+func (s *AccountService) ChangePassword(
+	ctx context.Context,
+	userID uuid.UUID,
+	currentPassword string,
+	newPassword string,
+	refreshToken string,
+) error {
+	userDto, err := s.GetAccount(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.passwordService.ComparePassword(userDto.PasswordHash, currentPassword); err != nil {
+		return ErrInvalidCurrentPassword
+	}
+
+	if err := s.passwordHistoryService.ValidateNewPassword(ctx, userDto, newPassword); err != nil {
+		return err
+	}
+	if err := s.passwordHistoryService.ChangePassword(ctx, userDto, newPassword); err != nil {
+		return err
+	}
+
+	if err := s.sessionService.RevokeOtherUserSessions(ctx, userID, refreshToken); err != nil {
+		return fmt.Errorf("failed to revoke other sessions: %w", err)
+	}
+
+	return nil
+}
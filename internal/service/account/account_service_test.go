@@ -0,0 +1,242 @@
+//go:build unittest
+
+package account_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fgeck/gotth-postgres/internal/service/account"
+	emailChangeMocks "github.com/fgeck/gotth-postgres/internal/service/emailChange/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/passwordHistory"
+	passwordHistoryMocks "github.com/fgeck/gotth-postgres/internal/service/passwordHistory/mocks"
+	passwordResetMocks "github.com/fgeck/gotth-postgres/internal/service/passwordReset/mocks"
+	passwordMocks "github.com/fgeck/gotth-postgres/internal/service/security/password/mocks"
+	sessionMocks "github.com/fgeck/gotth-postgres/internal/service/session/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	userMocks "github.com/fgeck/gotth-postgres/internal/service/user/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/validation"
+	validationMocks "github.com/fgeck/gotth-postgres/internal/service/validation/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	USERNAME         = "testuser"
+	EMAIL            = "testuser@example.com"
+	PASSWORD_HASH    = "hashedpassword"
+	CURRENT_PASSWORD = "Current-Pass-1!"
+	NEW_PASSWORD     = "Quiet-Harbor-Lantern-42!"
+	REFRESH_TOKEN    = "refresh-token"
+)
+
+type accountServiceMocks struct {
+	userService            *userMocks.MockUserServiceInterface
+	passwordService        *passwordMocks.MockPasswordServiceInterface
+	passwordHistoryService *passwordHistoryMocks.MockPasswordHistoryServiceInterface
+	sessionService         *sessionMocks.MockSessionServiceInterface
+	emailChangeService     *emailChangeMocks.MockEmailChangeServiceInterface
+	passwordResetService   *passwordResetMocks.MockPasswordResetServiceInterface
+	validator              *validationMocks.MockValidationServiceInterface
+}
+
+func setupAccountServiceTest(t *testing.T) (*accountServiceMocks, *account.AccountService) {
+	mocks := &accountServiceMocks{
+		userService:            userMocks.NewMockUserServiceInterface(t),
+		passwordService:        passwordMocks.NewMockPasswordServiceInterface(t),
+		passwordHistoryService: passwordHistoryMocks.NewMockPasswordHistoryServiceInterface(t),
+		sessionService:         sessionMocks.NewMockSessionServiceInterface(t),
+		emailChangeService:     emailChangeMocks.NewMockEmailChangeServiceInterface(t),
+		passwordResetService:   passwordResetMocks.NewMockPasswordResetServiceInterface(t),
+		validator:              validationMocks.NewMockValidationServiceInterface(t),
+	}
+	service := account.NewAccountService(
+		mocks.userService,
+		mocks.passwordService,
+		mocks.passwordHistoryService,
+		mocks.sessionService,
+		mocks.emailChangeService,
+		mocks.passwordResetService,
+		mocks.validator,
+	)
+	return mocks, service
+}
+
+func newUserDto(id uuid.UUID) *user.UserDto {
+	return &user.UserDto{
+		ID:           id,
+		Username:     USERNAME,
+		Email:        EMAIL,
+		PasswordHash: PASSWORD_HASH,
+		Role:         user.UserRoleUser,
+	}
+}
+
+func TestUpdateProfile(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+
+	t.Run("changes the username without the current password", func(t *testing.T) {
+		mocks, service := setupAccountServiceTest(t)
+		updated := newUserDto(id)
+		updated.Username = "newname"
+
+		mocks.userService.On("GetUserById", ctx, id).Return(newUserDto(id), nil)
+		mocks.validator.On("ValidateUsername", "newname").Return(nil)
+		mocks.userService.On("UserExistsByUsername", ctx, "newname").Return(false, nil)
+		mocks.userService.On("UpdateUser", ctx, id, "newname", EMAIL, PASSWORD_HASH).Return(updated, nil)
+
+		result, err := service.UpdateProfile(ctx, id, "newname", EMAIL, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, "newname", result.Username)
+	})
+
+	t.Run("keeps the email address until the new one is confirmed", func(t *testing.T) {
+		mocks, service := setupAccountServiceTest(t)
+		userDto := newUserDto(id)
+
+		mocks.userService.On("GetUserById", ctx, id).Return(userDto, nil)
+		mocks.passwordService.On("ComparePassword", PASSWORD_HASH, CURRENT_PASSWORD).Return(nil)
+		mocks.validator.On("ValidateEmail", "new@example.com").Return(nil)
+		mocks.userService.On("UserExistsByEmail", ctx, "new@example.com").Return(false, nil)
+		mocks.emailChangeService.On("RequestChange", ctx, userDto, "new@example.com").Return(nil)
+
+		result, err := service.UpdateProfile(ctx, id, USERNAME, "new@example.com", CURRENT_PASSWORD)
+
+		require.NoError(t, err)
+		assert.Equal(t, EMAIL, result.Email)
+		mocks.userService.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("changes the username and requests the email change together", func(t *testing.T) {
+		mocks, service := setupAccountServiceTest(t)
+		updated := newUserDto(id)
+		updated.Username = "newname"
+
+		mocks.userService.On("GetUserById", ctx, id).Return(newUserDto(id), nil)
+		mocks.validator.On("ValidateUsername", "newname").Return(nil)
+		mocks.userService.On("UserExistsByUsername", ctx, "newname").Return(false, nil)
+		mocks.passwordService.On("ComparePassword", PASSWORD_HASH, CURRENT_PASSWORD).Return(nil)
+		mocks.validator.On("ValidateEmail", "new@example.com").Return(nil)
+		mocks.userService.On("UserExistsByEmail", ctx, "new@example.com").Return(false, nil)
+		mocks.userService.On("UpdateUser", ctx, id, "newname", EMAIL, PASSWORD_HASH).Return(updated, nil)
+		mocks.emailChangeService.On("RequestChange", ctx, updated, "new@example.com").Return(nil)
+
+		result, err := service.UpdateProfile(ctx, id, "newname", "new@example.com", CURRENT_PASSWORD)
+
+		require.NoError(t, err)
+		assert.Equal(t, "newname", result.Username)
+		assert.Equal(t, EMAIL, result.Email)
+	})
+
+	t.Run("requires the current password to change the email address", func(t *testing.T) {
+		mocks, service := setupAccountServiceTest(t)
+
+		mocks.userService.On("GetUserById", ctx, id).Return(newUserDto(id), nil)
+		mocks.passwordService.On("ComparePassword", PASSWORD_HASH, "wrong").Return(errors.New("mismatch"))
+
+		result, err := service.UpdateProfile(ctx, id, USERNAME, "new@example.com", "wrong")
+
+		require.ErrorIs(t, err, account.ErrInvalidCurrentPassword)
+		assert.Nil(t, result)
+		mocks.userService.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a username that is taken", func(t *testing.T) {
+		mocks, service := setupAccountServiceTest(t)
+
+		mocks.userService.On("GetUserById", ctx, id).Return(newUserDto(id), nil)
+		mocks.validator.On("ValidateUsername", "taken").Return(nil)
+		mocks.userService.On("UserExistsByUsername", ctx, "taken").Return(true, nil)
+
+		_, err := service.UpdateProfile(ctx, id, "taken", EMAIL, "")
+
+		require.ErrorIs(t, err, account.ErrUsernameTaken)
+	})
+
+	t.Run("notifies the owner of an email address that is in use", func(t *testing.T) {
+		mocks, service := setupAccountServiceTest(t)
+		userDto := newUserDto(id)
+
+		mocks.userService.On("GetUserById", ctx, id).Return(userDto, nil)
+		mocks.passwordService.On("ComparePassword", PASSWORD_HASH, CURRENT_PASSWORD).Return(nil)
+		mocks.validator.On("ValidateEmail", "taken@example.com").Return(nil)
+		mocks.userService.On("UserExistsByEmail", ctx, "taken@example.com").Return(true, nil)
+		mocks.passwordResetService.On("NotifyExistingAccount", ctx, "taken@example.com").Return(nil)
+
+		result, err := service.UpdateProfile(ctx, id, USERNAME, "taken@example.com", CURRENT_PASSWORD)
+
+		require.NoError(t, err)
+		assert.Equal(t, EMAIL, result.Email)
+		mocks.emailChangeService.AssertNotCalled(t, "RequestChange", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("re-runs the username validation", func(t *testing.T) {
+		mocks, service := setupAccountServiceTest(t)
+
+		mocks.userService.On("GetUserById", ctx, id).Return(newUserDto(id), nil)
+		mocks.validator.On("ValidateUsername", "no spaces").Return(validation.ErrInvalidUsername)
+
+		_, err := service.UpdateProfile(ctx, id, "no spaces", EMAIL, "")
+
+		require.ErrorIs(t, err, validation.ErrInvalidUsername)
+	})
+
+	t.Run("does nothing when nothing changed", func(t *testing.T) {
+		mocks, service := setupAccountServiceTest(t)
+
+		mocks.userService.On("GetUserById", ctx, id).Return(newUserDto(id), nil)
+
+		result, err := service.UpdateProfile(ctx, id, USERNAME, EMAIL, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, USERNAME, result.Username)
+	})
+}
+
+func TestChangePassword(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+
+	t.Run("changes the password and logs out other sessions", func(t *testing.T) {
+		mocks, service := setupAccountServiceTest(t)
+		userDto := newUserDto(id)
+
+		mocks.userService.On("GetUserById", ctx, id).Return(userDto, nil)
+		mocks.passwordService.On("ComparePassword", PASSWORD_HASH, CURRENT_PASSWORD).Return(nil)
+		mocks.passwordHistoryService.On("ValidateNewPassword", ctx, userDto, NEW_PASSWORD).Return(nil)
+		mocks.passwordHistoryService.On("ChangePassword", ctx, userDto, NEW_PASSWORD).Return(nil)
+		mocks.sessionService.On("RevokeOtherUserSessions", ctx, id, REFRESH_TOKEN).Return(nil)
+
+		require.NoError(t, service.ChangePassword(ctx, id, CURRENT_PASSWORD, NEW_PASSWORD, REFRESH_TOKEN))
+	})
+
+	t.Run("requires the current password", func(t *testing.T) {
+		mocks, service := setupAccountServiceTest(t)
+
+		mocks.userService.On("GetUserById", ctx, id).Return(newUserDto(id), nil)
+		mocks.passwordService.On("ComparePassword", PASSWORD_HASH, "wrong").Return(errors.New("mismatch"))
+
+		err := service.ChangePassword(ctx, id, "wrong", NEW_PASSWORD, REFRESH_TOKEN)
+
+		require.ErrorIs(t, err, account.ErrInvalidCurrentPassword)
+	})
+
+	t.Run("keeps the password and sessions when the new one is rejected", func(t *testing.T) {
+		mocks, service := setupAccountServiceTest(t)
+		userDto := newUserDto(id)
+
+		mocks.userService.On("GetUserById", ctx, id).Return(userDto, nil)
+		mocks.passwordService.On("ComparePassword", PASSWORD_HASH, CURRENT_PASSWORD).Return(nil)
+		mocks.passwordHistoryService.On("ValidateNewPassword", ctx, userDto, NEW_PASSWORD).Return(passwordHistory.ErrPasswordReused)
+
+		err := service.ChangePassword(ctx, id, CURRENT_PASSWORD, NEW_PASSWORD, REFRESH_TOKEN)
+
+		require.ErrorIs(t, err, passwordHistory.ErrPasswordReused)
+		mocks.sessionService.AssertNotCalled(t, "RevokeOtherUserSessions", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -3,17 +3,137 @@ package config
 import (
 	"log"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 type AppConfig struct {
-	Host          string `mapstructure:"host"`
-	Port          string `mapstructure:"port"`
-	JwtSecret     string `mapstructure:"jwtSecret"`
-	AdminUser     string `mapstructure:"adminUser"`
-	AdminPassword string `mapstructure:"adminPassword"`
-	AdminEmail    string `mapstructure:"adminEmail"`
+	Host                 string                `mapstructure:"host"`
+	Port                 string                `mapstructure:"port"`
+	PublicUrl            string                `mapstructure:"publicUrl"`
+	JwtSecret            string                `mapstructure:"jwtSecret"`
+	JwtActiveKid         string                `mapstructure:"jwtActiveKid"`
+	JwtKeys              []JwtKeyConfig        `mapstructure:"jwtKeys"`
+	MfaEncryptionKey     string                `mapstructure:"mfaEncryptionKey"`
+	Webauthn             WebauthnConfig        `mapstructure:"webauthn"`
+	RequireVerifiedEmail bool                  `mapstructure:"requireVerifiedEmail"`
+	Mail                 MailConfig            `mapstructure:"mail"`
+	TrustProxy           bool                  `mapstructure:"trustProxy"`
+	LoginThrottle        ThrottleConfig        `mapstructure:"loginThrottle"`
+	PasswordHashing      PasswordHashingConfig `mapstructure:"passwordHashing"`
+	Registration         RegistrationConfig    `mapstructure:"registration"`
+	PasswordPolicy       PasswordPolicyConfig  `mapstructure:"passwordPolicy"`
+	PasswordHistory      PasswordHistoryConfig `mapstructure:"passwordHistory"`
+	AdminUser            string                `mapstructure:"adminUser"`
+	AdminPassword        string                `mapstructure:"adminPassword"`
+	AdminEmail           string                `mapstructure:"adminEmail"`
+}
+
+// JwtKeyConfig describes one PEM encoded signing key. Retired keys only need
+// a publicKeyPath so that tokens they signed stay valid until they expire.
+type JwtKeyConfig struct {
+	Kid            string `mapstructure:"kid"`
+	Algorithm      string `mapstructure:"algorithm"`
+	PrivateKeyPath string `mapstructure:"privateKeyPath"`
+	PublicKeyPath  string `mapstructure:"publicKeyPath"`
+}
+
+// WebauthnConfig identifies this site as passkey relying party. The rpId is
+// the registrable domain and every origin serving the login page is listed.
+type WebauthnConfig struct {
+	RpID    string   `mapstructure:"rpId"`
+	RpName  string   `mapstructure:"rpName"`
+	Origins []string `mapstructure:"origins"`
+}
+
+// MailConfig selects how outgoing email is delivered: "smtp", "file" writes
+// .eml files into directory and "log" prints them to the server log.
+type MailConfig struct {
+	Transport string     `mapstructure:"transport"`
+	From      string     `mapstructure:"from"`
+	Directory string     `mapstructure:"directory"`
+	Smtp      SmtpConfig `mapstructure:"smtp"`
+}
+
+type SmtpConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     string `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+}
+
+// ThrottleConfig limits failed logins. After freeAttempts failures every
+// further one doubles the wait, starting at baseDelay and capped at maxDelay.
+// Reaching maxAttempts locks logins for lockoutDuration.
+type ThrottleConfig struct {
+	Window    time.Duration       `mapstructure:"window"`
+	BaseDelay time.Duration       `mapstructure:"baseDelay"`
+	MaxDelay  time.Duration       `mapstructure:"maxDelay"`
+	Account   ThrottleLimitConfig `mapstructure:"account"`
+	Ip        ThrottleLimitConfig `mapstructure:"ip"`
+}
+
+type ThrottleLimitConfig struct {
+	FreeAttempts    int           `mapstructure:"freeAttempts"`
+	MaxAttempts     int           `mapstructure:"maxAttempts"`
+	LockoutDuration time.Duration `mapstructure:"lockoutDuration"`
+}
+
+// PasswordHashingConfig selects the algorithm for new password hashes.
+// Existing hashes of the other algorithm or with lower costs are replaced on
+// the next successful login.
+type PasswordHashingConfig struct {
+	Algorithm  string       `mapstructure:"algorithm"`
+	BcryptCost int          `mapstructure:"bcryptCost"`
+	Argon2     Argon2Config `mapstructure:"argon2"`
+}
+
+// Argon2Config holds the argon2id costs, memory is given in KiB.
+type Argon2Config struct {
+	Memory      uint32 `mapstructure:"memory"`
+	Iterations  uint32 `mapstructure:"iterations"`
+	Parallelism uint8  `mapstructure:"parallelism"`
+}
+
+// RegistrationConfig controls sign ups. Mode is one of open, closed (only
+// admins create accounts), invite (only invitations), domain (only addresses
+// of allowedDomains) or approval (new accounts wait for an admin), empty
+// means open. With enumerationSafe, registering an address that already has
+// an account looks like a successful registration and the owner is notified
+// by email instead.
+type RegistrationConfig struct {
+	Mode            string   `mapstructure:"mode"`
+	AllowedDomains  []string `mapstructure:"allowedDomains"`
+	EnumerationSafe bool     `mapstructure:"enumerationSafe"`
+}
+
+// PasswordPolicyConfig describes the rules for new passwords. Nist follows
+// NIST SP 800-63B: only length limits and banned words, no composition rules.
+// MinScore is the lowest accepted strength score from 0 to 4, 0 disables it.
+// BreachIndex is the file written by cmd/breachindex, empty skips the check.
+type PasswordPolicyConfig struct {
+	MinLength        int      `mapstructure:"minLength"`
+	MaxLength        int      `mapstructure:"maxLength"`
+	RequireUpper     bool     `mapstructure:"requireUpper"`
+	RequireLower     bool     `mapstructure:"requireLower"`
+	RequireDigit     bool     `mapstructure:"requireDigit"`
+	RequireSpecial   bool     `mapstructure:"requireSpecial"`
+	MaxRepeatedChars int      `mapstructure:"maxRepeatedChars"`
+	BannedWords      []string `mapstructure:"bannedWords"`
+	Nist             bool     `mapstructure:"nist"`
+	MinScore         int      `mapstructure:"minScore"`
+	BreachIndex      string   `mapstructure:"breachIndex"`
+}
+
+// PasswordHistoryConfig limits password reuse and age. A new password must
+// differ from the last reuseLimit passwords including the current one, 0
+// disables the check. Passwords of the expiringRoles must be changed once they are
+// older than maxAge, 0 disables expiry.
+type PasswordHistoryConfig struct {
+	ReuseLimit    int           `mapstructure:"reuseLimit"`
+	MaxAge        time.Duration `mapstructure:"maxAge"`
+	ExpiringRoles []string      `mapstructure:"expiringRoles"`
 }
 
 type DbConfig struct {
@@ -0,0 +1,254 @@
+package emailChange
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	"github.com/fgeck/gotth-postgres/internal/service/mail"
+	"github.com/fgeck/gotth-postgres/internal/service/session"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	CONFIRM_EMAIL_CHANGE_PATH = "/confirm-email-change"
+	REVERT_EMAIL_CHANGE_PATH  = "/revert-email-change"
+	CHANGE_TOKEN_BYTES        = 32
+	CONFIRM_TOKEN_TTL         = 24 * time.Hour
+	REVERT_TOKEN_TTL          = 7 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidChangeToken = errors.New("email change link is invalid or expired")
+	ErrEmailTaken         = errors.New("email address is already in use")
+)
+
+type EmailChangeServiceInterface interface {
+	RequestChange(ctx context.Context, userDto *user.UserDto, newEmail string) error
+	ConfirmChange(ctx context.Context, token string) (*user.UserDto, error)
+	RevertChange(ctx context.Context, token string) error
+}
+
+// EmailChangeService changes the email address of an account in two steps:
+// the new address has to confirm the change, the old one is told about it
+// and can undo it.
+type EmailChangeService struct {
+	queries        repository.Querier
+	userService    user.UserServiceInterface
+	sessionService session.SessionServiceInterface
+	mailer         mail.Mailer
+	publicUrl      string
+}
+
+func NewEmailChangeService(
+	queries repository.Querier,
+	userService user.UserServiceInterface,
+	sessionService session.SessionServiceInterface,
+	mailer mail.Mailer,
+	publicUrl string,
+) *EmailChangeService {
+	return &EmailChangeService{
+		queries:        queries,
+		userService:    userService,
+		sessionService: sessionService,
+		mailer:         mailer,
+		publicUrl:      publicUrl,
+	}
+}
+
+// RequestChange stores a pending change and sends the confirmation link to
+// the new address and the revert link to the current one. Only the most
+// recent request of a user stays pending. Callers validate the new address.
+func (s *EmailChangeService) RequestChange(ctx context.Context, userDto *user.UserDto, newEmail string) error {
+	userID := pgtype.UUID{Bytes: userDto.ID, Valid: true}
+	if err := s.queries.DeletePendingEmailChangesByUserId(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete pending email changes: %w", err)
+	}
+
+	confirmToken, err := generateChangeToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate email change token: %w", err)
+	}
+	revertToken, err := generateChangeToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate email change token: %w", err)
+	}
+
+	now := time.Now()
+	err = s.queries.CreateEmailChange(
+		ctx,
+		repository.CreateEmailChangeParams{
+			UserID:           userID,
+			OldEmail:         userDto.Email,
+			NewEmail:         newEmail,
+			ConfirmTokenHash: hashChangeToken(confirmToken),
+			RevertTokenHash:  hashChangeToken(revertToken),
+			ExpiresAt:        pgtype.Timestamptz{Time: now.Add(CONFIRM_TOKEN_TTL), Valid: true},
+			RevertExpiresAt:  pgtype.Timestamptz{Time: now.Add(REVERT_TOKEN_TTL), Valid: true},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store email change: %w", err)
+	}
+
+	confirmMessage, err := mail.NewEmailChangeConfirmMessage(ctx, newEmail, s.link(CONFIRM_EMAIL_CHANGE_PATH, confirmToken))
+	if err != nil {
+		return fmt.Errorf("failed to render email change confirmation: %w", err)
+	}
+	if err := s.mailer.Send(ctx, confirmMessage); err != nil {
+		return fmt.Errorf("failed to send email change confirmation: %w", err)
+	}
+
+	noticeMessage, err := mail.NewEmailChangeNoticeMessage(ctx, userDto.Email, newEmail, s.link(REVERT_EMAIL_CHANGE_PATH, revertToken))
+	if err != nil {
+		return fmt.Errorf("failed to render email change notice: %w", err)
+	}
+	if err := s.mailer.Send(ctx, noticeMessage); err != nil {
+		return fmt.Errorf("failed to send email change notice: %w", err)
+	}
+
+	return nil
+}
+
+// ConfirmChange switches the account to the new address. The address is
+// checked again since it may have been registered after the request. The
+// confirmed address counts as verified.
+func (s *EmailChangeService) ConfirmChange(ctx context.Context, token string) (*user.UserDto, error) {
+	tokenHash := hashChangeToken(token)
+	change, err := s.queries.GetPendingEmailChange(ctx, tokenHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvalidChangeToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email change: %w", err)
+	}
+
+	userDto, err := s.userService.GetUserById(ctx, uuid.UUID(change.UserID.Bytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	// The address was changed in another way since the request.
+	if userDto.Email != change.OldEmail {
+		return nil, ErrInvalidChangeToken
+	}
+
+	exists, err := s.userService.UserExistsByEmail(ctx, change.NewEmail)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check email: %w", err)
+	}
+	if exists {
+		return nil, ErrEmailTaken
+	}
+
+	// Confirming can still fail if the link was used concurrently.
+	if _, err := s.queries.ConfirmEmailChange(ctx, tokenHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidChangeToken
+		}
+
+		return nil, fmt.Errorf("failed to confirm email change: %w", err)
+	}
+
+	return s.switchEmail(ctx, userDto, change.NewEmail)
+}
+
+// RevertChange cancels a pending change or, once it was confirmed, restores
+// the old address. A confirmed change may have been made by somebody who took
+// over the account, so all sessions are logged out in that case.
+func (s *EmailChangeService) RevertChange(ctx context.Context, token string) error {
+	change, err := s.queries.GetEmailChangeByRevertToken(ctx, hashChangeToken(token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidChangeToken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get email change: %w", err)
+	}
+
+	deleted, err := s.queries.DeleteEmailChange(ctx, change.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete email change: %w", err)
+	}
+	if deleted == 0 {
+		return ErrInvalidChangeToken
+	}
+	if !change.ConfirmedAt.Valid {
+		return nil
+	}
+
+	userID := uuid.UUID(change.UserID.Bytes)
+	userDto, err := s.userService.GetUserById(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if userDto.Email != change.OldEmail {
+		exists, err := s.userService.UserExistsByEmail(ctx, change.OldEmail)
+		if err != nil {
+			return fmt.Errorf("failed to check email: %w", err)
+		}
+		if exists {
+			return ErrEmailTaken
+		}
+		if _, err := s.switchEmail(ctx, userDto, change.OldEmail); err != nil {
+			return err
+		}
+	}
+
+	if err := s.sessionService.RevokeAllUserSessions(ctx, userID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return nil
+}
+
+// switchEmail stores the address and marks it as verified, since whoever
+// follows one of the links proves to own it.
+func (s *EmailChangeService) switchEmail(ctx context.Context, userDto *user.UserDto, email string) (*user.UserDto, error) {
+	updatedUser, err := s.userService.UpdateUser(ctx, userDto.ID, userDto.Username, email, userDto.PasswordHash)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.queries.MarkUserEmailVerified(
+		ctx,
+		repository.MarkUserEmailVerifiedParams{
+			ID:    pgtype.UUID{Bytes: userDto.ID, Valid: true},
+			Email: email,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark email as verified: %w", err)
+	}
+	verifiedAt := time.Now()
+	updatedUser.EmailVerifiedAt = &verifiedAt
+
+	return updatedUser, nil
+}
+
+func (s *EmailChangeService) link(path, token string) string {
+	return s.publicUrl + path + "?token=" + url.QueryEscape(token)
+}
+
+func generateChangeToken() (string, error) {
+	buf := make([]byte, CHANGE_TOKEN_BYTES)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashChangeToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
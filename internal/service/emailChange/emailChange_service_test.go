@@ -0,0 +1,209 @@
+//go:build unittest
+
+package emailChange_test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	repositoryMocks "github.com/fgeck/gotth-postgres/internal/repository/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/emailChange"
+	"github.com/fgeck/gotth-postgres/internal/service/mail"
+	mailMocks "github.com/fgeck/gotth-postgres/internal/service/mail/mocks"
+	sessionMocks "github.com/fgeck/gotth-postgres/internal/service/session/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	userMocks "github.com/fgeck/gotth-postgres/internal/service/user/mocks"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	USERNAME      = "testuser"
+	OLD_EMAIL     = "old@example.com"
+	NEW_EMAIL     = "new@example.com"
+	PASSWORD_HASH = "hashedpassword"
+	TOKEN         = "change-token"
+)
+
+type emailChangeServiceMocks struct {
+	queries        *repositoryMocks.MockQuerier
+	userService    *userMocks.MockUserServiceInterface
+	sessionService *sessionMocks.MockSessionServiceInterface
+	mailer         *mailMocks.MockMailer
+}
+
+func setupEmailChangeServiceTest(t *testing.T) (*emailChangeServiceMocks, *emailChange.EmailChangeService) {
+	mocks := &emailChangeServiceMocks{
+		queries:        repositoryMocks.NewMockQuerier(t),
+		userService:    userMocks.NewMockUserServiceInterface(t),
+		sessionService: sessionMocks.NewMockSessionServiceInterface(t),
+		mailer:         mailMocks.NewMockMailer(t),
+	}
+	service := emailChange.NewEmailChangeService(
+		mocks.queries,
+		mocks.userService,
+		mocks.sessionService,
+		mocks.mailer,
+		"http://localhost:8081",
+	)
+	return mocks, service
+}
+
+func newUserDto(id uuid.UUID, email string) *user.UserDto {
+	return &user.UserDto{
+		ID:           id,
+		Username:     USERNAME,
+		Email:        email,
+		PasswordHash: PASSWORD_HASH,
+		Role:         user.UserRoleUser,
+	}
+}
+
+func TestRequestChange(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	pgUserID := pgtype.UUID{Bytes: userID, Valid: true}
+
+	mocks, service := setupEmailChangeServiceTest(t)
+
+	mocks.queries.On("DeletePendingEmailChangesByUserId", ctx, pgUserID).Return(nil)
+	mocks.queries.On("CreateEmailChange", ctx, mock.MatchedBy(func(params repository.CreateEmailChangeParams) bool {
+		return params.UserID == pgUserID &&
+			params.OldEmail == OLD_EMAIL &&
+			params.NewEmail == NEW_EMAIL &&
+			len(params.ConfirmTokenHash) == 64 &&
+			len(params.RevertTokenHash) == 64 &&
+			params.ConfirmTokenHash != params.RevertTokenHash &&
+			params.ExpiresAt.Time.Before(time.Now().Add(emailChange.CONFIRM_TOKEN_TTL+time.Second)) &&
+			params.RevertExpiresAt.Time.After(params.ExpiresAt.Time)
+	})).Return(nil)
+	mocks.mailer.On("Send", ctx, mock.MatchedBy(func(message *mail.Message) bool {
+		return message.To == NEW_EMAIL && strings.Contains(message.TextBody, "http://localhost:8081/confirm-email-change?token=")
+	})).Return(nil)
+	mocks.mailer.On("Send", ctx, mock.MatchedBy(func(message *mail.Message) bool {
+		return message.To == OLD_EMAIL &&
+			strings.Contains(message.TextBody, NEW_EMAIL) &&
+			strings.Contains(message.TextBody, "http://localhost:8081/revert-email-change?token=")
+	})).Return(nil)
+
+	require.NoError(t, service.RequestChange(ctx, newUserDto(userID, OLD_EMAIL), NEW_EMAIL))
+}
+
+func TestConfirmChange(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	pgUserID := pgtype.UUID{Bytes: userID, Valid: true}
+	change := repository.EmailChange{UserID: pgUserID, OldEmail: OLD_EMAIL, NewEmail: NEW_EMAIL}
+
+	t.Run("switches to the new address and marks it verified", func(t *testing.T) {
+		mocks, service := setupEmailChangeServiceTest(t)
+
+		mocks.queries.On("GetPendingEmailChange", ctx, mock.AnythingOfType("string")).Return(change, nil)
+		mocks.userService.On("GetUserById", ctx, userID).Return(newUserDto(userID, OLD_EMAIL), nil)
+		mocks.userService.On("UserExistsByEmail", ctx, NEW_EMAIL).Return(false, nil)
+		mocks.queries.On("ConfirmEmailChange", ctx, mock.AnythingOfType("string")).Return(change, nil)
+		mocks.userService.On("UpdateUser", ctx, userID, USERNAME, NEW_EMAIL, PASSWORD_HASH).Return(newUserDto(userID, NEW_EMAIL), nil)
+		mocks.queries.On("MarkUserEmailVerified", ctx, repository.MarkUserEmailVerifiedParams{ID: pgUserID, Email: NEW_EMAIL}).Return(int64(1), nil)
+
+		updated, err := service.ConfirmChange(ctx, TOKEN)
+
+		require.NoError(t, err)
+		assert.Equal(t, NEW_EMAIL, updated.Email)
+		assert.True(t, updated.IsEmailVerified())
+	})
+
+	t.Run("rejects an address registered in the meantime", func(t *testing.T) {
+		mocks, service := setupEmailChangeServiceTest(t)
+
+		mocks.queries.On("GetPendingEmailChange", ctx, mock.AnythingOfType("string")).Return(change, nil)
+		mocks.userService.On("GetUserById", ctx, userID).Return(newUserDto(userID, OLD_EMAIL), nil)
+		mocks.userService.On("UserExistsByEmail", ctx, NEW_EMAIL).Return(true, nil)
+
+		_, err := service.ConfirmChange(ctx, TOKEN)
+
+		require.ErrorIs(t, err, emailChange.ErrEmailTaken)
+		mocks.queries.AssertNotCalled(t, "ConfirmEmailChange", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a used or expired token", func(t *testing.T) {
+		mocks, service := setupEmailChangeServiceTest(t)
+
+		mocks.queries.On("GetPendingEmailChange", ctx, mock.AnythingOfType("string")).Return(repository.EmailChange{}, sql.ErrNoRows)
+
+		_, err := service.ConfirmChange(ctx, TOKEN)
+
+		require.ErrorIs(t, err, emailChange.ErrInvalidChangeToken)
+	})
+
+	t.Run("rejects a change of an address that was changed since", func(t *testing.T) {
+		mocks, service := setupEmailChangeServiceTest(t)
+
+		mocks.queries.On("GetPendingEmailChange", ctx, mock.AnythingOfType("string")).Return(change, nil)
+		mocks.userService.On("GetUserById", ctx, userID).Return(newUserDto(userID, "other@example.com"), nil)
+
+		_, err := service.ConfirmChange(ctx, TOKEN)
+
+		require.ErrorIs(t, err, emailChange.ErrInvalidChangeToken)
+	})
+}
+
+func TestRevertChange(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	pgUserID := pgtype.UUID{Bytes: userID, Valid: true}
+	changeID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	pending := repository.EmailChange{ID: changeID, UserID: pgUserID, OldEmail: OLD_EMAIL, NewEmail: NEW_EMAIL}
+	confirmed := pending
+	confirmed.ConfirmedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+
+	t.Run("cancels a pending change", func(t *testing.T) {
+		mocks, service := setupEmailChangeServiceTest(t)
+
+		mocks.queries.On("GetEmailChangeByRevertToken", ctx, mock.AnythingOfType("string")).Return(pending, nil)
+		mocks.queries.On("DeleteEmailChange", ctx, changeID).Return(int64(1), nil)
+
+		require.NoError(t, service.RevertChange(ctx, TOKEN))
+		mocks.sessionService.AssertNotCalled(t, "RevokeAllUserSessions", mock.Anything, mock.Anything)
+	})
+
+	t.Run("restores the old address and logs out everywhere", func(t *testing.T) {
+		mocks, service := setupEmailChangeServiceTest(t)
+
+		mocks.queries.On("GetEmailChangeByRevertToken", ctx, mock.AnythingOfType("string")).Return(confirmed, nil)
+		mocks.queries.On("DeleteEmailChange", ctx, changeID).Return(int64(1), nil)
+		mocks.userService.On("GetUserById", ctx, userID).Return(newUserDto(userID, NEW_EMAIL), nil)
+		mocks.userService.On("UserExistsByEmail", ctx, OLD_EMAIL).Return(false, nil)
+		mocks.userService.On("UpdateUser", ctx, userID, USERNAME, OLD_EMAIL, PASSWORD_HASH).Return(newUserDto(userID, OLD_EMAIL), nil)
+		mocks.queries.On("MarkUserEmailVerified", ctx, repository.MarkUserEmailVerifiedParams{ID: pgUserID, Email: OLD_EMAIL}).Return(int64(1), nil)
+		mocks.sessionService.On("RevokeAllUserSessions", ctx, userID).Return(nil)
+
+		require.NoError(t, service.RevertChange(ctx, TOKEN))
+	})
+
+	t.Run("does not take an address somebody else registered", func(t *testing.T) {
+		mocks, service := setupEmailChangeServiceTest(t)
+
+		mocks.queries.On("GetEmailChangeByRevertToken", ctx, mock.AnythingOfType("string")).Return(confirmed, nil)
+		mocks.queries.On("DeleteEmailChange", ctx, changeID).Return(int64(1), nil)
+		mocks.userService.On("GetUserById", ctx, userID).Return(newUserDto(userID, NEW_EMAIL), nil)
+		mocks.userService.On("UserExistsByEmail", ctx, OLD_EMAIL).Return(true, nil)
+
+		require.ErrorIs(t, service.RevertChange(ctx, TOKEN), emailChange.ErrEmailTaken)
+	})
+
+	t.Run("rejects a token used concurrently", func(t *testing.T) {
+		mocks, service := setupEmailChangeServiceTest(t)
+
+		mocks.queries.On("GetEmailChangeByRevertToken", ctx, mock.AnythingOfType("string")).Return(pending, nil)
+		mocks.queries.On("DeleteEmailChange", ctx, changeID).Return(int64(0), nil)
+
+		require.ErrorIs(t, service.RevertChange(ctx, TOKEN), emailChange.ErrInvalidChangeToken)
+	})
+}
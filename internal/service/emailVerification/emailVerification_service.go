@@ -0,0 +1,169 @@
+package emailVerification
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	"github.com/fgeck/gotth-postgres/internal/service/mail"
+	"github.com/fgeck/gotth-postgres/internal/service/security/jwt"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	VERIFY_EMAIL_PATH = "/verify-email"
+	// Minimum time between two verification emails for the same account.
+	RESEND_INTERVAL = time.Minute
+)
+
+var (
+	ErrEmailNotVerified         = errors.New("email address is not verified")
+	ErrInvalidVerificationToken = errors.New("verification link is invalid or expired")
+	ErrResendThrottled          = errors.New("a verification email was sent recently")
+)
+
+type EmailVerificationServiceInterface interface {
+	SendVerification(ctx context.Context, email string) error
+	VerifyEmail(ctx context.Context, token string) error
+	CheckLoginAllowed(user *user.UserDto) error
+}
+
+type EmailVerificationService struct {
+	queries              repository.Querier
+	userService          user.UserServiceInterface
+	jwtService           jwt.JwtServiceInterface
+	mailer               mail.Mailer
+	publicUrl            string
+	requireVerifiedEmail bool
+}
+
+func NewEmailVerificationService(
+	queries repository.Querier,
+	userService user.UserServiceInterface,
+	jwtService jwt.JwtServiceInterface,
+	mailer mail.Mailer,
+	publicUrl string,
+	requireVerifiedEmail bool,
+) *EmailVerificationService {
+	return &EmailVerificationService{
+		queries:              queries,
+		userService:          userService,
+		jwtService:           jwtService,
+		mailer:               mailer,
+		publicUrl:            publicUrl,
+		requireVerifiedEmail: requireVerifiedEmail,
+	}
+}
+
+// SendVerification issues a new verification link and invalidates older ones.
+// Unknown and already verified addresses are silently ignored so that the
+// resend endpoint cannot be used to probe for accounts.
+func (s *EmailVerificationService) SendVerification(ctx context.Context, email string) error {
+	userDto, err := s.userService.GetUserByEmail(ctx, email)
+	if errors.Is(err, user.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if userDto.IsEmailVerified() {
+		return nil
+	}
+
+	userID := pgtype.UUID{Bytes: userDto.ID, Valid: true}
+	latest, err := s.queries.GetLatestEmailVerificationToken(ctx, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to get latest verification token: %w", err)
+	}
+	if err == nil && time.Since(latest.CreatedAt.Time) < RESEND_INTERVAL {
+		return ErrResendThrottled
+	}
+
+	if err := s.queries.DeleteEmailVerificationTokensByUserId(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete verification tokens: %w", err)
+	}
+
+	stored, err := s.queries.CreateEmailVerificationToken(
+		ctx,
+		repository.CreateEmailVerificationTokenParams{
+			UserID:    userID,
+			Email:     userDto.Email,
+			ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(jwt.EMAIL_VERIFICATION_TOKEN_EXPIRATION), Valid: true},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store verification token: %w", err)
+	}
+
+	token, err := s.jwtService.GenerateEmailVerificationToken(userDto, uuid.UUID(stored.ID.Bytes))
+	if err != nil {
+		return fmt.Errorf("failed to generate verification token: %w", err)
+	}
+
+	message, err := mail.NewVerificationMessage(ctx, userDto.Email, s.publicUrl+VERIFY_EMAIL_PATH+"?token="+url.QueryEscape(token))
+	if err != nil {
+		return fmt.Errorf("failed to render verification email: %w", err)
+	}
+	if err := s.mailer.Send(ctx, message); err != nil {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyEmail marks the address the link was issued for as verified. The
+// link stops working once it is used or the user changed the email address.
+func (s *EmailVerificationService) VerifyEmail(ctx context.Context, token string) error {
+	claims, err := s.jwtService.ValidateEmailVerificationToken(token)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidVerificationToken, err)
+	}
+
+	tokenID, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return fmt.Errorf("%w: failed to parse token id: %w", ErrInvalidVerificationToken, err)
+	}
+
+	stored, err := s.queries.ConsumeEmailVerificationToken(ctx, pgtype.UUID{Bytes: tokenID, Valid: true})
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidVerificationToken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to consume verification token: %w", err)
+	}
+	if uuid.UUID(stored.UserID.Bytes).String() != claims.UserId {
+		return ErrInvalidVerificationToken
+	}
+
+	updated, err := s.queries.MarkUserEmailVerified(
+		ctx,
+		repository.MarkUserEmailVerifiedParams{
+			ID:    stored.UserID,
+			Email: stored.Email,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark email as verified: %w", err)
+	}
+	if updated == 0 {
+		return ErrInvalidVerificationToken
+	}
+
+	return nil
+}
+
+// CheckLoginAllowed rejects unverified accounts when the configuration
+// requires a verified email address to log in.
+func (s *EmailVerificationService) CheckLoginAllowed(user *user.UserDto) error {
+	if s.requireVerifiedEmail && !user.IsEmailVerified() {
+		return ErrEmailNotVerified
+	}
+
+	return nil
+}
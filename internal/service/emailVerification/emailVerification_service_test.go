@@ -0,0 +1,177 @@
+//go:build unittest
+
+package emailVerification_test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	repositoryMocks "github.com/fgeck/gotth-postgres/internal/repository/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/emailVerification"
+	"github.com/fgeck/gotth-postgres/internal/service/mail"
+	mailMocks "github.com/fgeck/gotth-postgres/internal/service/mail/mocks"
+	jwtService "github.com/fgeck/gotth-postgres/internal/service/security/jwt"
+	jwtMocks "github.com/fgeck/gotth-postgres/internal/service/security/jwt/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	userMocks "github.com/fgeck/gotth-postgres/internal/service/user/mocks"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	PUBLIC_URL = "http://localhost:8081"
+	EMAIL      = "user@example.com"
+	TOKEN      = "signed-token"
+)
+
+type emailVerificationServiceMocks struct {
+	queries     *repositoryMocks.MockQuerier
+	userService *userMocks.MockUserServiceInterface
+	jwtService  *jwtMocks.MockJwtServiceInterface
+	mailer      *mailMocks.MockMailer
+}
+
+func setupEmailVerificationServiceTest(t *testing.T, requireVerifiedEmail bool) (*emailVerificationServiceMocks, *emailVerification.EmailVerificationService) {
+	mocks := &emailVerificationServiceMocks{
+		queries:     repositoryMocks.NewMockQuerier(t),
+		userService: userMocks.NewMockUserServiceInterface(t),
+		jwtService:  jwtMocks.NewMockJwtServiceInterface(t),
+		mailer:      mailMocks.NewMockMailer(t),
+	}
+	service := emailVerification.NewEmailVerificationService(
+		mocks.queries,
+		mocks.userService,
+		mocks.jwtService,
+		mocks.mailer,
+		PUBLIC_URL,
+		requireVerifiedEmail,
+	)
+	return mocks, service
+}
+
+func TestSendVerification(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	pgUserID := pgtype.UUID{Bytes: userID, Valid: true}
+	userDto := &user.UserDto{ID: userID, Email: EMAIL, Role: user.UserRoleUser}
+
+	t.Run("issues a new token for an unverified user", func(t *testing.T) {
+		mocks, service := setupEmailVerificationServiceTest(t, false)
+		tokenID := uuid.New()
+
+		mocks.userService.On("GetUserByEmail", ctx, EMAIL).Return(userDto, nil)
+		mocks.queries.On("GetLatestEmailVerificationToken", ctx, pgUserID).Return(repository.EmailVerificationToken{}, sql.ErrNoRows)
+		mocks.queries.On("DeleteEmailVerificationTokensByUserId", ctx, pgUserID).Return(nil)
+		mocks.queries.On("CreateEmailVerificationToken", ctx, mock.MatchedBy(func(params repository.CreateEmailVerificationTokenParams) bool {
+			return params.UserID == pgUserID && params.Email == EMAIL && params.ExpiresAt.Time.After(time.Now())
+		})).Return(repository.EmailVerificationToken{ID: pgtype.UUID{Bytes: tokenID, Valid: true}}, nil)
+		mocks.jwtService.On("GenerateEmailVerificationToken", userDto, tokenID).Return(TOKEN, nil)
+		mocks.mailer.On("Send", ctx, mock.MatchedBy(func(message *mail.Message) bool {
+			return message.To == EMAIL && strings.Contains(message.TextBody, PUBLIC_URL+"/verify-email?token="+TOKEN)
+		})).Return(nil)
+
+		require.NoError(t, service.SendVerification(ctx, EMAIL))
+	})
+
+	t.Run("is throttled right after the last email", func(t *testing.T) {
+		mocks, service := setupEmailVerificationServiceTest(t, false)
+
+		mocks.userService.On("GetUserByEmail", ctx, EMAIL).Return(userDto, nil)
+		mocks.queries.On("GetLatestEmailVerificationToken", ctx, pgUserID).Return(repository.EmailVerificationToken{
+			CreatedAt: pgtype.Timestamptz{Time: time.Now().Add(-10 * time.Second), Valid: true},
+		}, nil)
+
+		require.ErrorIs(t, service.SendVerification(ctx, EMAIL), emailVerification.ErrResendThrottled)
+	})
+
+	t.Run("ignores unknown addresses", func(t *testing.T) {
+		mocks, service := setupEmailVerificationServiceTest(t, false)
+
+		mocks.userService.On("GetUserByEmail", ctx, EMAIL).Return(nil, user.ErrUserNotFound)
+
+		require.NoError(t, service.SendVerification(ctx, EMAIL))
+	})
+
+	t.Run("ignores verified addresses", func(t *testing.T) {
+		mocks, service := setupEmailVerificationServiceTest(t, false)
+		verifiedAt := time.Now()
+
+		mocks.userService.On("GetUserByEmail", ctx, EMAIL).Return(&user.UserDto{ID: userID, Email: EMAIL, EmailVerifiedAt: &verifiedAt}, nil)
+
+		require.NoError(t, service.SendVerification(ctx, EMAIL))
+	})
+}
+
+func TestVerifyEmail(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	pgUserID := pgtype.UUID{Bytes: userID, Valid: true}
+	tokenID := uuid.New()
+	pgTokenID := pgtype.UUID{Bytes: tokenID, Valid: true}
+	claims := &jwtService.JwtCustomClaims{UserId: userID.String()}
+	claims.ID = tokenID.String()
+	storedToken := repository.EmailVerificationToken{ID: pgTokenID, UserID: pgUserID, Email: EMAIL}
+
+	t.Run("marks the email as verified", func(t *testing.T) {
+		mocks, service := setupEmailVerificationServiceTest(t, false)
+
+		mocks.jwtService.On("ValidateEmailVerificationToken", TOKEN).Return(claims, nil)
+		mocks.queries.On("ConsumeEmailVerificationToken", ctx, pgTokenID).Return(storedToken, nil)
+		mocks.queries.On("MarkUserEmailVerified", ctx, repository.MarkUserEmailVerifiedParams{ID: pgUserID, Email: EMAIL}).Return(int64(1), nil)
+
+		require.NoError(t, service.VerifyEmail(ctx, TOKEN))
+	})
+
+	t.Run("rejects a used or expired token", func(t *testing.T) {
+		mocks, service := setupEmailVerificationServiceTest(t, false)
+
+		mocks.jwtService.On("ValidateEmailVerificationToken", TOKEN).Return(claims, nil)
+		mocks.queries.On("ConsumeEmailVerificationToken", ctx, pgTokenID).Return(repository.EmailVerificationToken{}, sql.ErrNoRows)
+
+		require.ErrorIs(t, service.VerifyEmail(ctx, TOKEN), emailVerification.ErrInvalidVerificationToken)
+	})
+
+	t.Run("rejects a token for an address the user no longer has", func(t *testing.T) {
+		mocks, service := setupEmailVerificationServiceTest(t, false)
+
+		mocks.jwtService.On("ValidateEmailVerificationToken", TOKEN).Return(claims, nil)
+		mocks.queries.On("ConsumeEmailVerificationToken", ctx, pgTokenID).Return(storedToken, nil)
+		mocks.queries.On("MarkUserEmailVerified", ctx, mock.Anything).Return(int64(0), nil)
+
+		require.ErrorIs(t, service.VerifyEmail(ctx, TOKEN), emailVerification.ErrInvalidVerificationToken)
+	})
+
+	t.Run("rejects a token with a bad signature", func(t *testing.T) {
+		mocks, service := setupEmailVerificationServiceTest(t, false)
+
+		mocks.jwtService.On("ValidateEmailVerificationToken", TOKEN).Return(nil, jwtService.ErrInvalidTokenClaims)
+
+		require.ErrorIs(t, service.VerifyEmail(ctx, TOKEN), emailVerification.ErrInvalidVerificationToken)
+	})
+}
+
+func TestCheckLoginAllowed(t *testing.T) {
+	verifiedAt := time.Now()
+	verified := &user.UserDto{EmailVerifiedAt: &verifiedAt}
+	unverified := &user.UserDto{}
+
+	t.Run("blocks unverified accounts when required", func(t *testing.T) {
+		_, service := setupEmailVerificationServiceTest(t, true)
+
+		require.ErrorIs(t, service.CheckLoginAllowed(unverified), emailVerification.ErrEmailNotVerified)
+		assert.NoError(t, service.CheckLoginAllowed(verified))
+	})
+
+	t.Run("allows unverified accounts otherwise", func(t *testing.T) {
+		_, service := setupEmailVerificationServiceTest(t, false)
+
+		assert.NoError(t, service.CheckLoginAllowed(unverified))
+	})
+}
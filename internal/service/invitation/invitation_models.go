@@ -0,0 +1,38 @@
+package invitation
+
+import (
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	"github.com/google/uuid"
+)
+
+// InvitationDto never contains the token, which is only sent by email.
+type InvitationDto struct {
+	ID             uuid.UUID  `json:"id"`
+	Email          string     `json:"email"`
+	Role           string     `json:"role"`
+	OrganizationID *uuid.UUID `json:"organizationId,omitempty"`
+	ExpiresAt      time.Time  `json:"expiresAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+func NewInvitationDto(invitation repository.Invitation) *InvitationDto {
+	dto := &InvitationDto{
+		ID:        uuid.UUID(invitation.ID.Bytes),
+		Email:     invitation.Email,
+		Role:      invitation.Role,
+		ExpiresAt: invitation.ExpiresAt.Time,
+		CreatedAt: invitation.CreatedAt.Time,
+	}
+	if invitation.OrganizationID.Valid {
+		organizationID := uuid.UUID(invitation.OrganizationID.Bytes)
+		dto.OrganizationID = &organizationID
+	}
+
+	return dto
+}
+
+func (i *InvitationDto) IsExpired() bool {
+	return time.Now().After(i.ExpiresAt)
+}
@@ -0,0 +1,405 @@
+package invitation
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	"github.com/fgeck/gotth-postgres/internal/service/mail"
+	"github.com/fgeck/gotth-postgres/internal/service/organization"
+	"github.com/fgeck/gotth-postgres/internal/service/registration"
+	"github.com/fgeck/gotth-postgres/internal/service/security/password"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	"github.com/fgeck/gotth-postgres/internal/service/validation"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	ACCEPT_INVITATION_PATH = "/accept-invite"
+	INVITATION_TOKEN_BYTES = 32
+	INVITATION_TTL         = 7 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidInvitation  = errors.New("invitation is invalid or expired")
+	ErrInvitationNotFound = errors.New("invitation not found")
+	ErrUnknownRole        = errors.New("unknown role")
+	ErrEmailTaken         = errors.New("email address is already in use")
+	ErrUsernameTaken      = errors.New("username is already taken")
+)
+
+type InvitationServiceInterface interface {
+	Invite(ctx context.Context, inviterID uuid.UUID, email, role string) (*InvitationDto, error)
+	ListInvitations(ctx context.Context) ([]*InvitationDto, error)
+	ResendInvitation(ctx context.Context, id uuid.UUID) (*InvitationDto, error)
+	RevokeInvitation(ctx context.Context, id uuid.UUID) error
+	GetInvitation(ctx context.Context, token string) (*InvitationDto, error)
+	AcceptInvitation(ctx context.Context, token, username, password string) (*user.UserDto, error)
+}
+
+// InvitationService creates accounts for invited email addresses. Like the
+// tenant methods of the OrganizationService it works on the invitations of
+// the organization in the context, see organization.WithTenant. Without one
+// it manages all invitations and invites with a base role instead. Invitations
+// work in every registration mode except closed.
+type InvitationService struct {
+	queries            repository.Querier
+	userService        user.UserServiceInterface
+	passwordService    password.PasswordServiceInterface
+	validator          validation.ValidationServiceInterface
+	mailer             mail.Mailer
+	registrationPolicy *registration.RegistrationPolicy
+	publicUrl          string
+}
+
+func NewInvitationService(
+	queries repository.Querier,
+	userService user.UserServiceInterface,
+	passwordService password.PasswordServiceInterface,
+	validator validation.ValidationServiceInterface,
+	mailer mail.Mailer,
+	registrationPolicy *registration.RegistrationPolicy,
+	publicUrl string,
+) *InvitationService {
+	return &InvitationService{
+		queries:            queries,
+		userService:        userService,
+		passwordService:    passwordService,
+		validator:          validator,
+		mailer:             mailer,
+		registrationPolicy: registrationPolicy,
+		publicUrl:          publicUrl,
+	}
+}
+
+// Invite emails a single-use link to create an account. Organizations invite
+// with a membership role, admins with a base role.
+func (s *InvitationService) Invite(ctx context.Context, inviterID uuid.UUID, email, role string) (*InvitationDto, error) {
+	if err := s.registrationPolicy.CheckInvitation(); err != nil {
+		return nil, err
+	}
+	email = strings.TrimSpace(email)
+	if err := s.validator.ValidateEmail(email); err != nil {
+		return nil, err
+	}
+	organizationID, inOrganization := organization.TenantFromContext(ctx)
+	role, err := s.parseRole(ctx, role, inOrganization)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := s.userService.UserExistsByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check email: %w", err)
+	}
+	if exists {
+		return nil, ErrEmailTaken
+	}
+
+	token, err := generateInvitationToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate invitation token: %w", err)
+	}
+
+	created, err := s.queries.CreateInvitation(
+		ctx,
+		repository.CreateInvitationParams{
+			Email:          email,
+			Role:           role,
+			OrganizationID: pgtype.UUID{Bytes: organizationID, Valid: inOrganization},
+			InvitedBy:      pgtype.UUID{Bytes: inviterID, Valid: true},
+			TokenHash:      hashInvitationToken(token),
+			ExpiresAt:      pgtype.Timestamptz{Time: time.Now().Add(INVITATION_TTL), Valid: true},
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	if err := s.send(ctx, created, token); err != nil {
+		return nil, err
+	}
+
+	return NewInvitationDto(created), nil
+}
+
+// ListInvitations returns the invitations that were not accepted yet,
+// including expired ones that can be resent.
+func (s *InvitationService) ListInvitations(ctx context.Context) ([]*InvitationDto, error) {
+	var invitations []repository.Invitation
+	var err error
+	if organizationID, ok := organization.TenantFromContext(ctx); ok {
+		invitations, err = s.queries.ListOrganizationInvitations(ctx, pgtype.UUID{Bytes: organizationID, Valid: true})
+	} else {
+		invitations, err = s.queries.ListInvitations(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+
+	dtos := make([]*InvitationDto, 0, len(invitations))
+	for _, invitation := range invitations {
+		dtos = append(dtos, NewInvitationDto(invitation))
+	}
+
+	return dtos, nil
+}
+
+// ResendInvitation sends a new link that is valid for the full time again.
+// The link sent before stops working.
+func (s *InvitationService) ResendInvitation(ctx context.Context, id uuid.UUID) (*InvitationDto, error) {
+	if err := s.registrationPolicy.CheckInvitation(); err != nil {
+		return nil, err
+	}
+	if _, err := s.findInvitation(ctx, id); err != nil {
+		return nil, err
+	}
+
+	token, err := generateInvitationToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate invitation token: %w", err)
+	}
+
+	renewed, err := s.queries.RenewInvitation(
+		ctx,
+		repository.RenewInvitationParams{
+			ID:        pgtype.UUID{Bytes: id, Valid: true},
+			TokenHash: hashInvitationToken(token),
+			ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(INVITATION_TTL), Valid: true},
+		},
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvitationNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to renew invitation: %w", err)
+	}
+
+	if err := s.send(ctx, renewed, token); err != nil {
+		return nil, err
+	}
+
+	return NewInvitationDto(renewed), nil
+}
+
+func (s *InvitationService) RevokeInvitation(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.findInvitation(ctx, id); err != nil {
+		return err
+	}
+
+	deleted, err := s.queries.DeleteInvitation(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		return fmt.Errorf("failed to revoke invitation: %w", err)
+	}
+	if deleted == 0 {
+		return ErrInvitationNotFound
+	}
+
+	return nil
+}
+
+// GetInvitation returns the invitation of an emailed link without using it up.
+func (s *InvitationService) GetInvitation(ctx context.Context, token string) (*InvitationDto, error) {
+	invitation, err := s.queries.GetPendingInvitation(ctx, hashInvitationToken(token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvalidInvitation
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+
+	return NewInvitationDto(invitation), nil
+}
+
+// AcceptInvitation creates the account with the same validation as a
+// registration. The invited address counts as verified, since the link was
+// sent to it.
+func (s *InvitationService) AcceptInvitation(ctx context.Context, token, username, password string) (*user.UserDto, error) {
+	if err := s.registrationPolicy.CheckInvitation(); err != nil {
+		return nil, err
+	}
+	tokenHash := hashInvitationToken(token)
+	invitation, err := s.queries.GetPendingInvitation(ctx, tokenHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvalidInvitation
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+
+	if err := s.userService.ValidateCreateUserParams(username, invitation.Email, password); err != nil {
+		return nil, err
+	}
+	exists, err := s.userService.UserExistsByUsername(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check username: %w", err)
+	}
+	if exists {
+		return nil, ErrUsernameTaken
+	}
+	exists, err = s.userService.UserExistsByEmail(ctx, invitation.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check email: %w", err)
+	}
+	if exists {
+		return nil, ErrEmailTaken
+	}
+
+	hashedPassword, err := s.passwordService.HashAndSaltPassword(password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to salt and hash password: %w", err)
+	}
+
+	// Accepting can still fail if the link was used concurrently.
+	if _, err := s.queries.AcceptInvitation(ctx, tokenHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidInvitation
+		}
+
+		return nil, fmt.Errorf("failed to accept invitation: %w", err)
+	}
+
+	baseRole := invitation.Role
+	if invitation.OrganizationID.Valid {
+		baseRole = user.UserRoleUser.Name
+	}
+	created, err := s.queries.CreateUser(
+		ctx,
+		repository.CreateUserParams{
+			Username:     username,
+			Email:        invitation.Email,
+			PasswordHash: hashedPassword,
+			UserRole:     baseRole,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	_, err = s.queries.MarkUserEmailVerified(
+		ctx,
+		repository.MarkUserEmailVerifiedParams{
+			ID:    created.ID,
+			Email: created.Email,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark email as verified: %w", err)
+	}
+
+	if invitation.OrganizationID.Valid {
+		role, err := s.queries.GetRoleByName(ctx, invitation.Role)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get role: %w", err)
+		}
+		err = s.queries.CreateMembership(
+			ctx,
+			repository.CreateMembershipParams{
+				OrganizationID: invitation.OrganizationID,
+				UserID:         created.ID,
+				RoleID:         role.ID,
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add member: %w", err)
+		}
+	}
+
+	userDto := user.NewUserDto(created)
+	verifiedAt := time.Now()
+	userDto.EmailVerifiedAt = &verifiedAt
+
+	return userDto, nil
+}
+
+// findInvitation only finds invitations of the organization in the context,
+// if there is one.
+func (s *InvitationService) findInvitation(ctx context.Context, id uuid.UUID) (repository.Invitation, error) {
+	invitation, err := s.queries.GetInvitationById(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.Invitation{}, ErrInvitationNotFound
+	}
+	if err != nil {
+		return repository.Invitation{}, fmt.Errorf("failed to get invitation: %w", err)
+	}
+	if organizationID, ok := organization.TenantFromContext(ctx); ok {
+		if !invitation.OrganizationID.Valid || uuid.UUID(invitation.OrganizationID.Bytes) != organizationID {
+			return repository.Invitation{}, ErrInvitationNotFound
+		}
+	}
+
+	return invitation, nil
+}
+
+// parseRole accepts membership roles for organizations and the base roles of
+// accounts otherwise.
+func (s *InvitationService) parseRole(ctx context.Context, role string, inOrganization bool) (string, error) {
+	role = strings.ToUpper(strings.TrimSpace(role))
+	isBaseRole := role == user.UserRoleUser.Name || role == user.UserRoleAdmin.Name
+	if !inOrganization {
+		if !isBaseRole {
+			return "", ErrUnknownRole
+		}
+
+		return role, nil
+	}
+	if isBaseRole {
+		return "", ErrUnknownRole
+	}
+
+	if _, err := s.queries.GetRoleByName(ctx, role); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrUnknownRole
+		}
+
+		return "", fmt.Errorf("failed to get role: %w", err)
+	}
+
+	return role, nil
+}
+
+func (s *InvitationService) send(ctx context.Context, invitation repository.Invitation, token string) error {
+	organizationName := ""
+	if invitation.OrganizationID.Valid {
+		invitingOrganization, err := s.queries.GetOrganizationById(ctx, invitation.OrganizationID)
+		if err != nil {
+			return fmt.Errorf("failed to get organization: %w", err)
+		}
+		organizationName = invitingOrganization.Name
+	}
+
+	link := s.publicUrl + ACCEPT_INVITATION_PATH + "?token=" + url.QueryEscape(token)
+	message, err := mail.NewInvitationMessage(ctx, invitation.Email, organizationName, link)
+	if err != nil {
+		return fmt.Errorf("failed to render invitation: %w", err)
+	}
+	if err := s.mailer.Send(ctx, message); err != nil {
+		return fmt.Errorf("failed to send invitation: %w", err)
+	}
+
+	return nil
+}
+
+func generateInvitationToken() (string, error) {
+	buf := make([]byte, INVITATION_TOKEN_BYTES)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,304 @@
+//go:build unittest
+
+package invitation_test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	repositoryMocks "github.com/fgeck/gotth-postgres/internal/repository/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/config"
+	"github.com/fgeck/gotth-postgres/internal/service/invitation"
+	"github.com/fgeck/gotth-postgres/internal/service/mail"
+	mailMocks "github.com/fgeck/gotth-postgres/internal/service/mail/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/organization"
+	"github.com/fgeck/gotth-postgres/internal/service/rbac"
+	"github.com/fgeck/gotth-postgres/internal/service/registration"
+	passwordMocks "github.com/fgeck/gotth-postgres/internal/service/security/password/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	userMocks "github.com/fgeck/gotth-postgres/internal/service/user/mocks"
+	validationMocks "github.com/fgeck/gotth-postgres/internal/service/validation/mocks"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	EMAIL         = "invited@example.com"
+	USERNAME      = "invited"
+	PASSWORD      = "Str0ng-Passw0rd!"
+	PASSWORD_HASH = "hashedpassword"
+	TOKEN         = "invitation-token"
+)
+
+type invitationServiceMocks struct {
+	queries         *repositoryMocks.MockQuerier
+	userService     *userMocks.MockUserServiceInterface
+	passwordService *passwordMocks.MockPasswordServiceInterface
+	validator       *validationMocks.MockValidationServiceInterface
+	mailer          *mailMocks.MockMailer
+}
+
+func setupInvitationServiceTest(t *testing.T) (*invitationServiceMocks, *invitation.InvitationService) {
+	return setupInvitationServiceTestWithMode(t, registration.MODE_OPEN)
+}
+
+func setupInvitationServiceTestWithMode(t *testing.T, mode string) (*invitationServiceMocks, *invitation.InvitationService) {
+	registrationPolicy, err := registration.NewRegistrationPolicy(config.RegistrationConfig{Mode: mode})
+	require.NoError(t, err)
+	mocks := &invitationServiceMocks{
+		queries:         repositoryMocks.NewMockQuerier(t),
+		userService:     userMocks.NewMockUserServiceInterface(t),
+		passwordService: passwordMocks.NewMockPasswordServiceInterface(t),
+		validator:       validationMocks.NewMockValidationServiceInterface(t),
+		mailer:          mailMocks.NewMockMailer(t),
+	}
+	service := invitation.NewInvitationService(
+		mocks.queries,
+		mocks.userService,
+		mocks.passwordService,
+		mocks.validator,
+		mocks.mailer,
+		registrationPolicy,
+		"http://localhost:8081",
+	)
+	return mocks, service
+}
+
+func newInvitation(role string, organizationID pgtype.UUID) repository.Invitation {
+	return repository.Invitation{
+		ID:             pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Email:          EMAIL,
+		Role:           role,
+		OrganizationID: organizationID,
+		ExpiresAt:      pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+	}
+}
+
+func TestInvite(t *testing.T) {
+	inviterID := uuid.New()
+
+	t.Run("invites with a base role", func(t *testing.T) {
+		ctx := context.Background()
+		mocks, service := setupInvitationServiceTest(t)
+		created := newInvitation(user.UserRoleAdmin.Name, pgtype.UUID{})
+
+		mocks.validator.On("ValidateEmail", EMAIL).Return(nil)
+		mocks.userService.On("UserExistsByEmail", ctx, EMAIL).Return(false, nil)
+		mocks.queries.On("CreateInvitation", ctx, mock.MatchedBy(func(params repository.CreateInvitationParams) bool {
+			return params.Email == EMAIL &&
+				params.Role == user.UserRoleAdmin.Name &&
+				!params.OrganizationID.Valid &&
+				params.InvitedBy == pgtype.UUID{Bytes: inviterID, Valid: true} &&
+				len(params.TokenHash) == 64 &&
+				params.ExpiresAt.Time.After(time.Now().Add(invitation.INVITATION_TTL-time.Minute))
+		})).Return(created, nil)
+		mocks.mailer.On("Send", ctx, mock.MatchedBy(func(message *mail.Message) bool {
+			return message.To == EMAIL && strings.Contains(message.TextBody, "http://localhost:8081/accept-invite?token=")
+		})).Return(nil)
+
+		dto, err := service.Invite(ctx, inviterID, EMAIL, "admin")
+
+		require.NoError(t, err)
+		assert.Equal(t, user.UserRoleAdmin.Name, dto.Role)
+		assert.Nil(t, dto.OrganizationID)
+	})
+
+	t.Run("invites into the organization of the context", func(t *testing.T) {
+		organizationID := uuid.New()
+		pgOrganizationID := pgtype.UUID{Bytes: organizationID, Valid: true}
+		ctx := organization.WithTenant(context.Background(), organizationID)
+		mocks, service := setupInvitationServiceTest(t)
+		created := newInvitation(rbac.ROLE_ORG_MEMBER, pgOrganizationID)
+
+		mocks.validator.On("ValidateEmail", EMAIL).Return(nil)
+		mocks.queries.On("GetRoleByName", ctx, rbac.ROLE_ORG_MEMBER).Return(repository.Role{Name: rbac.ROLE_ORG_MEMBER}, nil)
+		mocks.userService.On("UserExistsByEmail", ctx, EMAIL).Return(false, nil)
+		mocks.queries.On("CreateInvitation", ctx, mock.MatchedBy(func(params repository.CreateInvitationParams) bool {
+			return params.OrganizationID == pgOrganizationID && params.Role == rbac.ROLE_ORG_MEMBER
+		})).Return(created, nil)
+		mocks.queries.On("GetOrganizationById", ctx, pgOrganizationID).Return(repository.Organization{Name: "Acme"}, nil)
+		mocks.mailer.On("Send", ctx, mock.MatchedBy(func(message *mail.Message) bool {
+			return strings.Contains(message.TextBody, "join Acme")
+		})).Return(nil)
+
+		dto, err := service.Invite(ctx, inviterID, EMAIL, rbac.ROLE_ORG_MEMBER)
+
+		require.NoError(t, err)
+		assert.Equal(t, organizationID, *dto.OrganizationID)
+	})
+
+	t.Run("rejects base roles in organizations", func(t *testing.T) {
+		ctx := organization.WithTenant(context.Background(), uuid.New())
+		mocks, service := setupInvitationServiceTest(t)
+
+		mocks.validator.On("ValidateEmail", EMAIL).Return(nil)
+
+		_, err := service.Invite(ctx, inviterID, EMAIL, user.UserRoleAdmin.Name)
+
+		require.ErrorIs(t, err, invitation.ErrUnknownRole)
+	})
+
+	t.Run("rejects registered addresses", func(t *testing.T) {
+		ctx := context.Background()
+		mocks, service := setupInvitationServiceTest(t)
+
+		mocks.validator.On("ValidateEmail", EMAIL).Return(nil)
+		mocks.userService.On("UserExistsByEmail", ctx, EMAIL).Return(true, nil)
+
+		_, err := service.Invite(ctx, inviterID, EMAIL, user.UserRoleUser.Name)
+
+		require.ErrorIs(t, err, invitation.ErrEmailTaken)
+		mocks.queries.AssertNotCalled(t, "CreateInvitation", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails when registration is closed", func(t *testing.T) {
+		_, service := setupInvitationServiceTestWithMode(t, registration.MODE_CLOSED)
+
+		_, err := service.Invite(context.Background(), inviterID, EMAIL, user.UserRoleUser.Name)
+
+		require.ErrorIs(t, err, registration.ErrRegistrationClosed)
+	})
+}
+
+func TestResendInvitation(t *testing.T) {
+	t.Run("sends a new link", func(t *testing.T) {
+		ctx := context.Background()
+		mocks, service := setupInvitationServiceTest(t)
+		pending := newInvitation(user.UserRoleUser.Name, pgtype.UUID{})
+		id := uuid.UUID(pending.ID.Bytes)
+
+		mocks.queries.On("GetInvitationById", ctx, pending.ID).Return(pending, nil)
+		mocks.queries.On("RenewInvitation", ctx, mock.MatchedBy(func(params repository.RenewInvitationParams) bool {
+			return params.ID == pending.ID && len(params.TokenHash) == 64
+		})).Return(pending, nil)
+		mocks.mailer.On("Send", ctx, mock.Anything).Return(nil)
+
+		_, err := service.ResendInvitation(ctx, id)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("does not find invitations of other organizations", func(t *testing.T) {
+		ctx := organization.WithTenant(context.Background(), uuid.New())
+		mocks, service := setupInvitationServiceTest(t)
+		pending := newInvitation(rbac.ROLE_ORG_MEMBER, pgtype.UUID{Bytes: uuid.New(), Valid: true})
+
+		mocks.queries.On("GetInvitationById", ctx, pending.ID).Return(pending, nil)
+
+		_, err := service.ResendInvitation(ctx, uuid.UUID(pending.ID.Bytes))
+
+		require.ErrorIs(t, err, invitation.ErrInvitationNotFound)
+		mocks.queries.AssertNotCalled(t, "RenewInvitation", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRevokeInvitation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("deletes a pending invitation", func(t *testing.T) {
+		mocks, service := setupInvitationServiceTest(t)
+		pending := newInvitation(user.UserRoleUser.Name, pgtype.UUID{})
+
+		mocks.queries.On("GetInvitationById", ctx, pending.ID).Return(pending, nil)
+		mocks.queries.On("DeleteInvitation", ctx, pending.ID).Return(int64(1), nil)
+
+		require.NoError(t, service.RevokeInvitation(ctx, uuid.UUID(pending.ID.Bytes)))
+	})
+
+	t.Run("fails for accepted invitations", func(t *testing.T) {
+		mocks, service := setupInvitationServiceTest(t)
+		id := uuid.New()
+
+		mocks.queries.On("GetInvitationById", ctx, pgtype.UUID{Bytes: id, Valid: true}).Return(repository.Invitation{}, sql.ErrNoRows)
+
+		require.ErrorIs(t, service.RevokeInvitation(ctx, id), invitation.ErrInvitationNotFound)
+	})
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	ctx := context.Background()
+	userID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	expectNewAccount := func(mocks *invitationServiceMocks, pending repository.Invitation, role string) {
+		mocks.queries.On("GetPendingInvitation", ctx, mock.AnythingOfType("string")).Return(pending, nil)
+		mocks.userService.On("ValidateCreateUserParams", USERNAME, EMAIL, PASSWORD).Return(nil)
+		mocks.userService.On("UserExistsByUsername", ctx, USERNAME).Return(false, nil)
+		mocks.userService.On("UserExistsByEmail", ctx, EMAIL).Return(false, nil)
+		mocks.passwordService.On("HashAndSaltPassword", PASSWORD).Return(PASSWORD_HASH, nil)
+		mocks.queries.On("AcceptInvitation", ctx, mock.AnythingOfType("string")).Return(pending, nil)
+		mocks.queries.On("CreateUser", ctx, repository.CreateUserParams{
+			Username:     USERNAME,
+			Email:        EMAIL,
+			PasswordHash: PASSWORD_HASH,
+			UserRole:     role,
+		}).Return(repository.User{ID: userID, Username: USERNAME, Email: EMAIL, UserRole: role}, nil)
+		mocks.queries.On("MarkUserEmailVerified", ctx, repository.MarkUserEmailVerifiedParams{ID: userID, Email: EMAIL}).Return(int64(1), nil)
+	}
+
+	t.Run("creates a verified account with the invited role", func(t *testing.T) {
+		mocks, service := setupInvitationServiceTest(t)
+		expectNewAccount(mocks, newInvitation(user.UserRoleAdmin.Name, pgtype.UUID{}), user.UserRoleAdmin.Name)
+
+		created, err := service.AcceptInvitation(ctx, TOKEN, USERNAME, PASSWORD)
+
+		require.NoError(t, err)
+		assert.Equal(t, user.UserRoleAdmin, created.Role)
+		assert.True(t, created.IsEmailVerified())
+	})
+
+	t.Run("adds the account to the inviting organization", func(t *testing.T) {
+		mocks, service := setupInvitationServiceTest(t)
+		organizationID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+		memberRole := repository.Role{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, Name: rbac.ROLE_ORG_MEMBER}
+		expectNewAccount(mocks, newInvitation(rbac.ROLE_ORG_MEMBER, organizationID), user.UserRoleUser.Name)
+		mocks.queries.On("GetRoleByName", ctx, rbac.ROLE_ORG_MEMBER).Return(memberRole, nil)
+		mocks.queries.On("CreateMembership", ctx, repository.CreateMembershipParams{
+			OrganizationID: organizationID,
+			UserID:         userID,
+			RoleID:         memberRole.ID,
+		}).Return(nil)
+
+		_, err := service.AcceptInvitation(ctx, TOKEN, USERNAME, PASSWORD)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects a used or expired token", func(t *testing.T) {
+		mocks, service := setupInvitationServiceTest(t)
+
+		mocks.queries.On("GetPendingInvitation", ctx, mock.AnythingOfType("string")).Return(repository.Invitation{}, sql.ErrNoRows)
+
+		_, err := service.AcceptInvitation(ctx, TOKEN, USERNAME, PASSWORD)
+
+		require.ErrorIs(t, err, invitation.ErrInvalidInvitation)
+	})
+
+	t.Run("rejects invitations when registration is closed", func(t *testing.T) {
+		mocks, service := setupInvitationServiceTestWithMode(t, registration.MODE_CLOSED)
+
+		_, err := service.AcceptInvitation(ctx, TOKEN, USERNAME, PASSWORD)
+
+		require.ErrorIs(t, err, registration.ErrRegistrationClosed)
+		mocks.queries.AssertNotCalled(t, "GetPendingInvitation", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a taken username", func(t *testing.T) {
+		mocks, service := setupInvitationServiceTest(t)
+
+		mocks.queries.On("GetPendingInvitation", ctx, mock.AnythingOfType("string")).Return(newInvitation(user.UserRoleUser.Name, pgtype.UUID{}), nil)
+		mocks.userService.On("ValidateCreateUserParams", USERNAME, EMAIL, PASSWORD).Return(nil)
+		mocks.userService.On("UserExistsByUsername", ctx, USERNAME).Return(true, nil)
+
+		_, err := service.AcceptInvitation(ctx, TOKEN, USERNAME, PASSWORD)
+
+		require.ErrorIs(t, err, invitation.ErrUsernameTaken)
+		mocks.queries.AssertNotCalled(t, "AcceptInvitation", mock.Anything, mock.Anything)
+	})
+}
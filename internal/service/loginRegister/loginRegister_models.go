@@ -0,0 +1,39 @@
+package loginRegister
+
+import "time"
+
+type TokensDto struct {
+	AccessToken           string    `json:"accessToken"`
+	RefreshToken          string    `json:"refreshToken"`
+	RefreshTokenExpiresAt time.Time `json:"refreshTokenExpiresAt"`
+	MfaPendingToken       string    `json:"mfaPendingToken,omitempty"`
+	PasswordChangeToken   string    `json:"passwordChangeToken,omitempty"`
+}
+
+func NewTokensDto(accessToken, refreshToken string, refreshTokenExpiresAt time.Time) *TokensDto {
+	return &TokensDto{
+		AccessToken:           accessToken,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refreshTokenExpiresAt,
+	}
+}
+
+// NewMfaPendingTokensDto is returned by a login whose password step succeeded
+// but which still needs a second factor before any session is created.
+func NewMfaPendingTokensDto(mfaPendingToken string) *TokensDto {
+	return &TokensDto{MfaPendingToken: mfaPendingToken}
+}
+
+func (t *TokensDto) MfaRequired() bool {
+	return t.MfaPendingToken != ""
+}
+
+// NewPasswordChangeTokensDto is returned by a login whose password has
+// expired. No session is created until a new password is set.
+func NewPasswordChangeTokensDto(passwordChangeToken string) *TokensDto {
+	return &TokensDto{PasswordChangeToken: passwordChangeToken}
+}
+
+func (t *TokensDto) PasswordChangeRequired() bool {
+	return t.PasswordChangeToken != ""
+}
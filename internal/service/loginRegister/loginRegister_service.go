@@ -2,52 +2,465 @@ package loginRegister
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 
+	"github.com/fgeck/gotth-postgres/internal/service/emailVerification"
 	customErrors "github.com/fgeck/gotth-postgres/internal/service/errors"
+	"github.com/fgeck/gotth-postgres/internal/service/loginThrottle"
+	"github.com/fgeck/gotth-postgres/internal/service/mfa"
+	"github.com/fgeck/gotth-postgres/internal/service/organization"
+	"github.com/fgeck/gotth-postgres/internal/service/passkey"
+	"github.com/fgeck/gotth-postgres/internal/service/passwordHistory"
+	"github.com/fgeck/gotth-postgres/internal/service/passwordReset"
+	"github.com/fgeck/gotth-postgres/internal/service/rbac"
+	"github.com/fgeck/gotth-postgres/internal/service/registration"
 	"github.com/fgeck/gotth-postgres/internal/service/security/jwt"
 	"github.com/fgeck/gotth-postgres/internal/service/security/password"
+	"github.com/fgeck/gotth-postgres/internal/service/security/webauthn"
+	"github.com/fgeck/gotth-postgres/internal/service/session"
 	"github.com/fgeck/gotth-postgres/internal/service/user"
+	"github.com/fgeck/gotth-postgres/internal/service/validation"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidCredentials         = errors.New("invalid email or password")
+	ErrInvalidPasswordChangeToken = errors.New("password change token is invalid or expired")
 )
 
 type LoginRegisterServiceInterface interface {
-	LoginUser(ctx context.Context, email, password string) (string, error)
+	LoginUser(ctx context.Context, email, password, clientIP string) (*TokensDto, error)
+	VerifyMfaLogin(ctx context.Context, mfaPendingToken, code, clientIP string) (*TokensDto, error)
+	ChangeExpiredPassword(ctx context.Context, passwordChangeToken, newPassword string) (*TokensDto, error)
+	LoginWithPasskey(ctx context.Context, ceremonyID uuid.UUID, response *webauthn.AuthenticationResponse) (*TokensDto, error)
+	RefreshTokens(ctx context.Context, refreshToken string) (*TokensDto, error)
+	SwitchOrganization(ctx context.Context, userID uuid.UUID, refreshToken string, organizationID uuid.UUID) (*TokensDto, error)
+	LogoutUser(ctx context.Context, accessToken, refreshToken string) error
 	RegisterUser(ctx context.Context, username, email, password string) (*user.UserCreatedDto, error)
 }
 
 type LoginRegisterService struct {
-	userService     user.UserServiceInterface
-	passwordService password.PasswordServiceInterface
-	jwtService      jwt.JwtServiceInterface
+	userService              user.UserServiceInterface
+	passwordService          password.PasswordServiceInterface
+	jwtService               jwt.JwtServiceInterface
+	sessionService           session.SessionServiceInterface
+	mfaService               mfa.MfaServiceInterface
+	passkeyService           passkey.PasskeyServiceInterface
+	emailVerificationService emailVerification.EmailVerificationServiceInterface
+	loginThrottleService     loginThrottle.LoginThrottleServiceInterface
+	passwordResetService     passwordReset.PasswordResetServiceInterface
+	passwordHistoryService   passwordHistory.PasswordHistoryServiceInterface
+	rbacService              rbac.RbacServiceInterface
+	organizationService      organization.OrganizationServiceInterface
+	registrationPolicy       *registration.RegistrationPolicy
 }
 
 func NewLoginRegisterService(
 	userService user.UserServiceInterface,
 	passwordService password.PasswordServiceInterface,
 	jwtService jwt.JwtServiceInterface,
+	sessionService session.SessionServiceInterface,
+	mfaService mfa.MfaServiceInterface,
+	passkeyService passkey.PasskeyServiceInterface,
+	emailVerificationService emailVerification.EmailVerificationServiceInterface,
+	loginThrottleService loginThrottle.LoginThrottleServiceInterface,
+	passwordResetService passwordReset.PasswordResetServiceInterface,
+	passwordHistoryService passwordHistory.PasswordHistoryServiceInterface,
+	rbacService rbac.RbacServiceInterface,
+	organizationService organization.OrganizationServiceInterface,
+	registrationPolicy *registration.RegistrationPolicy,
 ) *LoginRegisterService {
-	return &LoginRegisterService{userService: userService, passwordService: passwordService, jwtService: jwtService}
+	return &LoginRegisterService{
+		userService:              userService,
+		passwordService:          passwordService,
+		jwtService:               jwtService,
+		sessionService:           sessionService,
+		mfaService:               mfaService,
+		passkeyService:           passkeyService,
+		emailVerificationService: emailVerificationService,
+		loginThrottleService:     loginThrottleService,
+		passwordResetService:     passwordResetService,
+		passwordHistoryService:   passwordHistoryService,
+		rbacService:              rbacService,
+		organizationService:      organizationService,
+		registrationPolicy:       registrationPolicy,
+	}
 }
 
-func (s *LoginRegisterService) LoginUser(ctx context.Context, email, password string) (string, error) {
-	user, err := s.userService.GetUserByEmail(ctx, email)
+// LoginUser checks the password of an account. Unknown emails and wrong
+// passwords both take a full hash comparison and fail with
+// ErrInvalidCredentials, so neither the answer nor its timing tells which
+// accounts exist. Failed attempts are counted per account and client IP and
+// answered with a ThrottledError once the configured limits are exceeded.
+// An expired password is answered with a password change token instead of a
+// session, after the second factor if the user has one.
+func (s *LoginRegisterService) LoginUser(ctx context.Context, email, password, clientIP string) (*TokensDto, error) {
+	if err := s.loginThrottleService.Check(ctx, email, clientIP); err != nil {
+		return nil, err
+	}
+
+	userDto, err := s.userService.GetUserByEmail(ctx, email)
+	if errors.Is(err, user.ErrUserNotFound) {
+		s.passwordService.CompareDummyPassword(password)
+		return nil, s.recordFailure(ctx, email, clientIP, ErrInvalidCredentials)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Service accounts have no password, they look like unknown users.
+	if userDto.IsServiceAccount() {
+		s.passwordService.CompareDummyPassword(password)
+		return nil, s.recordFailure(ctx, email, clientIP, ErrInvalidCredentials)
+	}
+
+	if err := s.passwordService.ComparePassword(userDto.PasswordHash, password); err != nil {
+		return nil, s.recordFailure(ctx, email, clientIP, ErrInvalidCredentials)
+	}
+
+	if s.passwordService.NeedsRehash(userDto.PasswordHash) {
+		s.rehashPassword(ctx, userDto, password)
+	}
+
+	if userDto.IsDisabled() {
+		return nil, user.ErrUserDisabled
+	}
+	if userDto.PendingApproval {
+		return nil, user.ErrUserPendingApproval
+	}
+	if err := s.emailVerificationService.CheckLoginAllowed(userDto); err != nil {
+		return nil, err
+	}
+
+	mfaEnabled, err := s.mfaService.IsEnabled(ctx, userDto.ID)
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("failed to check mfa: %w", err)
 	}
+	// With two-factor authentication the failed attempts are only reset by
+	// VerifyMfaLogin, otherwise every login with the known password would
+	// allow guessing further codes.
+	if mfaEnabled {
+		mfaPendingToken, err := s.jwtService.GenerateMfaPendingToken(userDto)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate mfa pending token: %w", err)
+		}
 
-	if err := s.passwordService.ComparePassword(user.PasswordHash, password); err != nil {
-		return "", customErrors.NewInternal("invalid password")
+		return NewMfaPendingTokensDto(mfaPendingToken), nil
+	}
+	if err := s.loginThrottleService.Reset(ctx, email); err != nil {
+		return nil, err
+	}
+
+	return s.completeLogin(ctx, userDto)
+}
+
+// VerifyMfaLogin completes a login that LoginUser answered with an MFA pending
+// token. The code can be a TOTP code or an unused recovery code. Wrong codes
+// count as failed logins of the account and the client IP, so guessing codes
+// locks the account like guessing passwords does.
+func (s *LoginRegisterService) VerifyMfaLogin(ctx context.Context, mfaPendingToken, code, clientIP string) (*TokensDto, error) {
+	claims, err := s.jwtService.ValidateMfaPendingToken(mfaPendingToken)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mfa pending token: %w", err)
+	}
+
+	userID, err := uuid.Parse(claims.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse userId claim: %w", err)
+	}
+
+	userDto, err := s.userService.GetUserById(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if err := s.loginThrottleService.Check(ctx, userDto.Email, clientIP); err != nil {
+		return nil, err
+	}
+
+	err = s.mfaService.Verify(ctx, userID, code)
+	if errors.Is(err, mfa.ErrInvalidMfaCode) {
+		return nil, s.recordFailure(ctx, userDto.Email, clientIP, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.loginThrottleService.Reset(ctx, userDto.Email); err != nil {
+		return nil, err
 	}
 
-	return s.jwtService.GenerateToken(user)
+	return s.completeLogin(ctx, userDto)
 }
 
+// ChangeExpiredPassword completes a login that LoginUser or VerifyMfaLogin
+// answered with a password change token. The token is only good while the
+// password is still expired, so it cannot be used for a second change.
+func (s *LoginRegisterService) ChangeExpiredPassword(
+	ctx context.Context,
+	passwordChangeToken string,
+	newPassword string,
+) (*TokensDto, error) {
+	claims, err := s.jwtService.ValidatePasswordChangeToken(passwordChangeToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPasswordChangeToken, err)
+	}
+
+	userID, err := uuid.Parse(claims.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse userId claim: %w", err)
+	}
+
+	userDto, err := s.userService.GetUserById(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if err := s.rbacService.LoadPermissions(ctx, userDto); err != nil {
+		return nil, fmt.Errorf("failed to load permissions: %w", err)
+	}
+	if !s.passwordHistoryService.IsExpired(userDto) {
+		return nil, ErrInvalidPasswordChangeToken
+	}
+
+	if err := s.passwordHistoryService.ValidateNewPassword(ctx, userDto, newPassword); err != nil {
+		return nil, err
+	}
+	if err := s.passwordHistoryService.ChangePassword(ctx, userDto, newPassword); err != nil {
+		return nil, err
+	}
+
+	return s.startSession(ctx, userDto)
+}
+
+// LoginWithPasskey finishes a passwordless login. Passkey assertions require
+// user verification, so they replace the TOTP step as well.
+func (s *LoginRegisterService) LoginWithPasskey(
+	ctx context.Context,
+	ceremonyID uuid.UUID,
+	response *webauthn.AuthenticationResponse,
+) (*TokensDto, error) {
+	userID, err := s.passkeyService.FinishLogin(ctx, ceremonyID, response)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userService.GetUserById(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := s.emailVerificationService.CheckLoginAllowed(user); err != nil {
+		return nil, err
+	}
+
+	return s.startSession(ctx, user)
+}
+
+// rehashPassword upgrades a hash that uses an outdated algorithm or cost
+// while the plaintext password is at hand. Failures are only logged since the
+// old hash keeps working.
+func (s *LoginRegisterService) rehashPassword(ctx context.Context, userDto *user.UserDto, password string) {
+	hashedPassword, err := s.passwordService.HashAndSaltPassword(password)
+	if err != nil {
+		log.Printf("Failed to rehash password of user %s: %v\n", userDto.ID, err)
+		return
+	}
+
+	if _, err := s.userService.UpdateUser(ctx, userDto.ID, userDto.Username, userDto.Email, hashedPassword); err != nil {
+		log.Printf("Failed to store rehashed password of user %s: %v\n", userDto.ID, err)
+		return
+	}
+	userDto.PasswordHash = hashedPassword
+}
+
+// recordFailure counts the failed attempt and passes on the login error.
+func (s *LoginRegisterService) recordFailure(ctx context.Context, email, clientIP string, loginErr error) error {
+	if err := s.loginThrottleService.RecordFailure(ctx, email, clientIP); err != nil {
+		return err
+	}
+
+	return loginErr
+}
+
+// completeLogin starts a session unless the password has expired. Passkey
+// logins skip it, as they do not use the password. The roles are loaded first,
+// since granted roles can have expiring passwords too.
+func (s *LoginRegisterService) completeLogin(ctx context.Context, userDto *user.UserDto) (*TokensDto, error) {
+	if err := s.rbacService.LoadPermissions(ctx, userDto); err != nil {
+		return nil, fmt.Errorf("failed to load permissions: %w", err)
+	}
+	if !s.passwordHistoryService.IsExpired(userDto) {
+		return s.startSession(ctx, userDto)
+	}
+
+	passwordChangeToken, err := s.jwtService.GeneratePasswordChangeToken(userDto)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate password change token: %w", err)
+	}
+
+	return NewPasswordChangeTokensDto(passwordChangeToken), nil
+}
+
+// startSession is the last step of every login path, so it also turns away
+// users that were disabled in the meantime or were never approved.
+func (s *LoginRegisterService) startSession(ctx context.Context, userDto *user.UserDto) (*TokensDto, error) {
+	if userDto.IsServiceAccount() {
+		return nil, user.ErrServiceAccount
+	}
+	if userDto.IsDisabled() {
+		return nil, user.ErrUserDisabled
+	}
+	if userDto.PendingApproval {
+		return nil, user.ErrUserPendingApproval
+	}
+
+	accessToken, err := s.generateAccessToken(ctx, userDto, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionToken, err := s.sessionService.CreateSession(ctx, userDto.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return NewTokensDto(accessToken, sessionToken.RefreshToken, sessionToken.ExpiresAt), nil
+}
+
+func (s *LoginRegisterService) RefreshTokens(ctx context.Context, refreshToken string) (*TokensDto, error) {
+	sessionToken, err := s.sessionService.RotateSession(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.continueSession(ctx, sessionToken)
+}
+
+// SwitchOrganization lets the session of the user act in one of their
+// organizations, or outside of any for uuid.Nil. The refresh token has to
+// belong to the same user as the access token of the request.
+func (s *LoginRegisterService) SwitchOrganization(
+	ctx context.Context,
+	userID uuid.UUID,
+	refreshToken string,
+	organizationID uuid.UUID,
+) (*TokensDto, error) {
+	if organizationID != uuid.Nil {
+		if _, err := s.organizationService.GetMembership(ctx, organizationID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	sessionToken, err := s.sessionService.SwitchOrganization(ctx, refreshToken, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if sessionToken.UserID != userID {
+		if err := s.sessionService.RevokeSession(ctx, sessionToken.RefreshToken); err != nil {
+			return nil, err
+		}
+
+		return nil, session.ErrInvalidRefreshToken
+	}
+
+	return s.continueSession(ctx, sessionToken)
+}
+
+// continueSession issues the access token for a rotated refresh token.
+func (s *LoginRegisterService) continueSession(ctx context.Context, sessionToken *session.SessionTokenDto) (*TokensDto, error) {
+	userDto, err := s.userService.GetUserById(ctx, sessionToken.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session user: %w", err)
+	}
+	if userDto.IsDisabled() {
+		return nil, user.ErrUserDisabled
+	}
+
+	accessToken, err := s.generateAccessToken(ctx, userDto, sessionToken.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTokensDto(accessToken, sessionToken.RefreshToken, sessionToken.ExpiresAt), nil
+}
+
+// generateAccessToken puts the current roles and permissions of the user
+// into the token, so a refresh picks up changes to them. Sessions in an
+// organization also get the tenant of the membership, which is dropped once
+// the user was removed from the organization.
+func (s *LoginRegisterService) generateAccessToken(ctx context.Context, userDto *user.UserDto, organizationID uuid.UUID) (string, error) {
+	if err := s.rbacService.LoadPermissions(ctx, userDto); err != nil {
+		return "", fmt.Errorf("failed to load permissions: %w", err)
+	}
+	if organizationID != uuid.Nil {
+		err := s.organizationService.LoadTenant(ctx, userDto, organizationID)
+		if err != nil && !errors.Is(err, organization.ErrNotMember) {
+			return "", fmt.Errorf("failed to load tenant: %w", err)
+		}
+	}
+
+	accessToken, err := s.jwtService.GenerateToken(userDto)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	return accessToken, nil
+}
+
+// LogoutUser revokes whatever credentials the client still holds. An access
+// token that no longer validates is expired or forged and needs no revocation.
+func (s *LoginRegisterService) LogoutUser(ctx context.Context, accessToken, refreshToken string) error {
+	if accessToken != "" {
+		if claims, err := s.jwtService.ValidateAndExtractClaims(accessToken); err == nil {
+			userID, err := uuid.Parse(claims.UserId)
+			if err != nil {
+				return fmt.Errorf("failed to parse userId claim: %w", err)
+			}
+
+			if err := s.sessionService.RevokeAccessToken(ctx, claims.ID, userID, claims.ExpiresAt.Time); err != nil {
+				return err
+			}
+		}
+	}
+
+	if refreshToken != "" {
+		if err := s.sessionService.RevokeSession(ctx, refreshToken); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RegisterUser creates an account and sends the verification email, if the
+// registration mode lets the address sign up. In approval mode the account
+// cannot log in until an admin approved it. In enumeration safe mode an
+// already registered address gets the same answer as a new one and its owner
+// is notified by email. The password is hashed in both cases, so the response
+// time does not differ either.
 func (s *LoginRegisterService) RegisterUser(
 	ctx context.Context,
 	username string,
 	email string,
 	password string,
 ) (*user.UserCreatedDto, error) {
+	if err := s.userService.ValidateCreateUserParams(username, email, password); err != nil {
+		// Policy errors keep their list of violations for the form.
+		var policyErr *validation.PasswordPolicyError
+		if errors.As(err, &policyErr) {
+			return nil, policyErr
+		}
+
+		return nil, customErrors.NewUserFacing("failed to validate create user parameters: " + err.Error())
+	}
+	if err := s.registrationPolicy.CheckSignUp(email); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := s.passwordService.HashAndSaltPassword(password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to salt and hash password: %w", err)
+	}
+
 	userExists, err := s.userService.UserExistsByEmail(ctx, email)
 	if err != nil {
 		// Todo log error
@@ -55,22 +468,36 @@ func (s *LoginRegisterService) RegisterUser(
 	}
 
 	if userExists {
-		return nil, customErrors.NewUserFacing("user already exists")
-	}
+		if !s.registrationPolicy.EnumerationSafe() {
+			return nil, customErrors.NewUserFacing("user already exists")
+		}
+		if err := s.passwordResetService.NotifyExistingAccount(ctx, email); err != nil {
+			return nil, fmt.Errorf("failed to notify existing account: %w", err)
+		}
 
-	if err := s.userService.ValidateCreateUserParams(username, email, password); err != nil {
-		return nil, customErrors.NewUserFacing("failed to validate create user parameters: " + err.Error())
+		return user.NewUserCreatedDto(username, email), nil
 	}
 
-	hashedPassword, err := s.passwordService.HashAndSaltPassword(password)
+	// Enumeration safe mode only hides registered addresses. A taken username
+	// has to be reported, otherwise the insert fails on its unique constraint.
+	usernameTaken, err := s.userService.UserExistsByUsername(ctx, username)
 	if err != nil {
-		return nil, fmt.Errorf("failed to salt and hash password: %w", err)
+		return nil, fmt.Errorf("failed to check username: %w", err)
+	}
+	if usernameTaken {
+		return nil, customErrors.NewUserFacing("username is already taken")
 	}
 
-	userCreatedDto, err := s.userService.CreateUser(ctx, username, email, hashedPassword)
+	userCreatedDto, err := s.userService.CreateUser(ctx, username, email, hashedPassword, s.registrationPolicy.RequiresApproval())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
+	// The account exists at this point, failing would only make the user retry
+	// into "already exists". The verification email can be resent instead.
+	if err := s.emailVerificationService.SendVerification(ctx, email); err != nil {
+		log.Printf("Failed to send verification email to new user %s: %v\n", userCreatedDto.Username, err)
+	}
+
 	return userCreatedDto, nil
 }
@@ -6,26 +6,95 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	gojwt "github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 
+	"github.com/fgeck/gotth-postgres/internal/service/config"
+	"github.com/fgeck/gotth-postgres/internal/service/emailVerification"
+	emailVerificationMocks "github.com/fgeck/gotth-postgres/internal/service/emailVerification/mocks"
 	customErrors "github.com/fgeck/gotth-postgres/internal/service/errors"
 	"github.com/fgeck/gotth-postgres/internal/service/loginRegister"
+	"github.com/fgeck/gotth-postgres/internal/service/loginThrottle"
+	loginThrottleMocks "github.com/fgeck/gotth-postgres/internal/service/loginThrottle/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/mfa"
+	mfaMocks "github.com/fgeck/gotth-postgres/internal/service/mfa/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/organization"
+	organizationMocks "github.com/fgeck/gotth-postgres/internal/service/organization/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/passkey"
+	passkeyMocks "github.com/fgeck/gotth-postgres/internal/service/passkey/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/passwordHistory"
+	passwordHistoryMocks "github.com/fgeck/gotth-postgres/internal/service/passwordHistory/mocks"
+	passwordResetMocks "github.com/fgeck/gotth-postgres/internal/service/passwordReset/mocks"
+	rbacMocks "github.com/fgeck/gotth-postgres/internal/service/rbac/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/registration"
+	jwtService "github.com/fgeck/gotth-postgres/internal/service/security/jwt"
 	jwt "github.com/fgeck/gotth-postgres/internal/service/security/jwt/mocks"
 	password "github.com/fgeck/gotth-postgres/internal/service/security/password/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/security/webauthn"
+	"github.com/fgeck/gotth-postgres/internal/service/session"
+	sessionMocks "github.com/fgeck/gotth-postgres/internal/service/session/mocks"
 	"github.com/fgeck/gotth-postgres/internal/service/user"
 	userMocks "github.com/fgeck/gotth-postgres/internal/service/user/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/validation"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
-func setupLoginRegisterServiceTest(t *testing.T) (*userMocks.MockUserServiceInterface, *password.MockPasswordServiceInterface, *jwt.MockJwtServiceInterface, *loginRegister.LoginRegisterService) {
-	mockUserService := userMocks.NewMockUserServiceInterface(t)
-	mockPasswordService := password.NewMockPasswordServiceInterface(t)
-	mockJwtService := jwt.NewMockJwtServiceInterface(t)
-	service := loginRegister.NewLoginRegisterService(mockUserService, mockPasswordService, mockJwtService)
-	return mockUserService, mockPasswordService, mockJwtService, service
+type loginRegisterServiceMocks struct {
+	userService              *userMocks.MockUserServiceInterface
+	passwordService          *password.MockPasswordServiceInterface
+	jwtService               *jwt.MockJwtServiceInterface
+	sessionService           *sessionMocks.MockSessionServiceInterface
+	mfaService               *mfaMocks.MockMfaServiceInterface
+	passkeyService           *passkeyMocks.MockPasskeyServiceInterface
+	emailVerificationService *emailVerificationMocks.MockEmailVerificationServiceInterface
+	loginThrottleService     *loginThrottleMocks.MockLoginThrottleServiceInterface
+	passwordResetService     *passwordResetMocks.MockPasswordResetServiceInterface
+	passwordHistoryService   *passwordHistoryMocks.MockPasswordHistoryServiceInterface
+	rbacService              *rbacMocks.MockRbacServiceInterface
+	organizationService      *organizationMocks.MockOrganizationServiceInterface
+}
+
+func setupLoginRegisterServiceTest(t *testing.T) (*loginRegisterServiceMocks, *loginRegister.LoginRegisterService) {
+	return setupLoginRegisterServiceTestWithMode(t, config.RegistrationConfig{})
+}
+
+func setupLoginRegisterServiceTestWithMode(t *testing.T, registrationConfig config.RegistrationConfig) (*loginRegisterServiceMocks, *loginRegister.LoginRegisterService) {
+	registrationPolicy, err := registration.NewRegistrationPolicy(registrationConfig)
+	require.NoError(t, err)
+	mocks := &loginRegisterServiceMocks{
+		userService:              userMocks.NewMockUserServiceInterface(t),
+		passwordService:          password.NewMockPasswordServiceInterface(t),
+		jwtService:               jwt.NewMockJwtServiceInterface(t),
+		sessionService:           sessionMocks.NewMockSessionServiceInterface(t),
+		mfaService:               mfaMocks.NewMockMfaServiceInterface(t),
+		passkeyService:           passkeyMocks.NewMockPasskeyServiceInterface(t),
+		emailVerificationService: emailVerificationMocks.NewMockEmailVerificationServiceInterface(t),
+		loginThrottleService:     loginThrottleMocks.NewMockLoginThrottleServiceInterface(t),
+		passwordResetService:     passwordResetMocks.NewMockPasswordResetServiceInterface(t),
+		passwordHistoryService:   passwordHistoryMocks.NewMockPasswordHistoryServiceInterface(t),
+		rbacService:              rbacMocks.NewMockRbacServiceInterface(t),
+		organizationService:      organizationMocks.NewMockOrganizationServiceInterface(t),
+	}
+	service := loginRegister.NewLoginRegisterService(
+		mocks.userService,
+		mocks.passwordService,
+		mocks.jwtService,
+		mocks.sessionService,
+		mocks.mfaService,
+		mocks.passkeyService,
+		mocks.emailVerificationService,
+		mocks.loginThrottleService,
+		mocks.passwordResetService,
+		mocks.passwordHistoryService,
+		mocks.rbacService,
+		mocks.organizationService,
+		registrationPolicy,
+	)
+	return mocks, service
 }
 
 func TestLoginUser(t *testing.T) {
@@ -36,60 +105,814 @@ func TestLoginUser(t *testing.T) {
 	password := "Valid1@"
 	hashedPassword := "hashedpassword"
 	token := "mockJwtToken"
+	refreshToken := "mockRefreshToken"
+	refreshTokenExpiresAt := time.Now().Add(time.Hour)
+	clientIP := "203.0.113.7"
 
 	t.Run("successfully logs in user", func(t *testing.T) {
-		mockUserService, mockPasswordService, mockJwtService, service := setupLoginRegisterServiceTest(t)
+		mocks, service := setupLoginRegisterServiceTest(t)
+		mocks.loginThrottleService.On("Check", ctx, email, clientIP).Return(nil)
 
-		mockUserService.On("GetUserByEmail", ctx, email).Return(&user.UserDto{
+		mocks.userService.On("GetUserByEmail", ctx, email).Return(&user.UserDto{
 			ID:           id,
 			Username:     username,
 			Email:        email,
 			PasswordHash: hashedPassword,
 		}, nil)
-		mockPasswordService.On("ComparePassword", hashedPassword, password).Return(nil)
-		mockJwtService.On("GenerateToken", mock.Anything).Return(token, nil)
+		mocks.passwordService.On("ComparePassword", hashedPassword, password).Return(nil)
+		mocks.loginThrottleService.On("Reset", ctx, email).Return(nil)
+		mocks.passwordService.On("NeedsRehash", hashedPassword).Return(false)
+		mocks.emailVerificationService.On("CheckLoginAllowed", mock.Anything).Return(nil)
+		mocks.mfaService.On("IsEnabled", ctx, id).Return(false, nil)
+		mocks.passwordHistoryService.On("IsExpired", mock.Anything).Return(false)
+		mocks.rbacService.On("LoadPermissions", ctx, mock.Anything).Return(nil)
+		mocks.jwtService.On("GenerateToken", mock.Anything).Return(token, nil)
+		mocks.sessionService.On("CreateSession", ctx, id).Return(&session.SessionTokenDto{
+			UserID:       id,
+			RefreshToken: refreshToken,
+			ExpiresAt:    refreshTokenExpiresAt,
+		}, nil)
 
-		result, err := service.LoginUser(ctx, email, password)
+		result, err := service.LoginUser(ctx, email, password, clientIP)
 
 		require.NoError(t, err)
-		assert.Equal(t, token, result)
+		assert.Equal(t, token, result.AccessToken)
+		assert.Equal(t, refreshToken, result.RefreshToken)
+		assert.Equal(t, refreshTokenExpiresAt, result.RefreshTokenExpiresAt)
 
-		mockUserService.AssertExpectations(t)
-		mockPasswordService.AssertExpectations(t)
-		mockJwtService.AssertExpectations(t)
+		mocks.userService.AssertExpectations(t)
+		mocks.passwordService.AssertExpectations(t)
+		mocks.jwtService.AssertExpectations(t)
+		mocks.sessionService.AssertExpectations(t)
 	})
 
-	t.Run("fails when user does not exist", func(t *testing.T) {
-		mockUserService, _, _, service := setupLoginRegisterServiceTest(t)
+	t.Run("fails when session cannot be created", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+		mocks.loginThrottleService.On("Check", ctx, email, clientIP).Return(nil)
 
-		mockUserService.On("GetUserByEmail", ctx, email).Return(nil, errors.New("user not found"))
+		mocks.userService.On("GetUserByEmail", ctx, email).Return(&user.UserDto{
+			ID:           id,
+			Email:        email,
+			PasswordHash: hashedPassword,
+		}, nil)
+		mocks.passwordService.On("ComparePassword", hashedPassword, password).Return(nil)
+		mocks.loginThrottleService.On("Reset", ctx, email).Return(nil)
+		mocks.passwordService.On("NeedsRehash", hashedPassword).Return(false)
+		mocks.emailVerificationService.On("CheckLoginAllowed", mock.Anything).Return(nil)
+		mocks.mfaService.On("IsEnabled", ctx, id).Return(false, nil)
+		mocks.passwordHistoryService.On("IsExpired", mock.Anything).Return(false)
+		mocks.rbacService.On("LoadPermissions", ctx, mock.Anything).Return(nil)
+		mocks.jwtService.On("GenerateToken", mock.Anything).Return(token, nil)
+		mocks.sessionService.On("CreateSession", ctx, id).Return(nil, errors.New("database error"))
 
-		result, err := service.LoginUser(ctx, email, password)
+		result, err := service.LoginUser(ctx, email, password, clientIP)
 
 		require.Error(t, err)
-		assert.Empty(t, result)
-		assert.Equal(t, "user not found", err.Error())
+		assert.Nil(t, result)
+		assert.Equal(t, "failed to create session: database error", err.Error())
+	})
+
+	t.Run("fails when user does not exist", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+		mocks.loginThrottleService.On("Check", ctx, email, clientIP).Return(nil)
 
-		mockUserService.AssertExpectations(t)
+		mocks.userService.On("GetUserByEmail", ctx, email).Return(nil, user.ErrUserNotFound)
+		mocks.passwordService.On("CompareDummyPassword", password).Return()
+		mocks.loginThrottleService.On("RecordFailure", ctx, email, clientIP).Return(nil)
+
+		result, err := service.LoginUser(ctx, email, password, clientIP)
+
+		require.ErrorIs(t, err, loginRegister.ErrInvalidCredentials)
+		assert.Nil(t, result)
+		mocks.passwordService.AssertExpectations(t)
+
+		mocks.userService.AssertExpectations(t)
+	})
+
+	t.Run("fails for a service account", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+		mocks.loginThrottleService.On("Check", ctx, email, clientIP).Return(nil)
+
+		mocks.userService.On("GetUserByEmail", ctx, email).Return(&user.UserDto{
+			ID:          id,
+			Email:       email,
+			AccountType: user.ACCOUNT_TYPE_SERVICE,
+		}, nil)
+		mocks.passwordService.On("CompareDummyPassword", password).Return()
+		mocks.loginThrottleService.On("RecordFailure", ctx, email, clientIP).Return(nil)
+
+		result, err := service.LoginUser(ctx, email, password, clientIP)
+
+		require.ErrorIs(t, err, loginRegister.ErrInvalidCredentials)
+		assert.Nil(t, result)
+		mocks.passwordService.AssertNotCalled(t, "ComparePassword", mock.Anything, mock.Anything)
 	})
 
 	t.Run("fails when password is invalid", func(t *testing.T) {
-		mockUserService, mockPasswordService, _, service := setupLoginRegisterServiceTest(t)
+		mocks, service := setupLoginRegisterServiceTest(t)
+		mocks.loginThrottleService.On("Check", ctx, email, clientIP).Return(nil)
 
-		mockUserService.On("GetUserByEmail", ctx, email).Return(&user.UserDto{
+		mocks.userService.On("GetUserByEmail", ctx, email).Return(&user.UserDto{
 			Email:        email,
 			PasswordHash: hashedPassword,
 		}, nil)
-		mockPasswordService.On("ComparePassword", hashedPassword, password).Return(errors.New("invalid password"))
+		mocks.passwordService.On("ComparePassword", hashedPassword, password).Return(errors.New("invalid password"))
+		mocks.loginThrottleService.On("RecordFailure", ctx, email, clientIP).Return(nil)
 
-		result, err := service.LoginUser(ctx, email, password)
+		result, err := service.LoginUser(ctx, email, password, clientIP)
 
-		require.Error(t, err)
-		assert.Empty(t, result)
-		assert.Equal(t, "InternalError: invalid password", err.Error())
+		require.ErrorIs(t, err, loginRegister.ErrInvalidCredentials)
+		assert.Nil(t, result)
 
-		mockUserService.AssertExpectations(t)
-		mockPasswordService.AssertExpectations(t)
+		mocks.userService.AssertExpectations(t)
+		mocks.passwordService.AssertExpectations(t)
+	})
+
+	t.Run("returns only an mfa pending token when mfa is enabled", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+		mocks.loginThrottleService.On("Check", ctx, email, clientIP).Return(nil)
+
+		userDto := &user.UserDto{
+			ID:           id,
+			Email:        email,
+			PasswordHash: hashedPassword,
+		}
+		mocks.userService.On("GetUserByEmail", ctx, email).Return(userDto, nil)
+		mocks.passwordService.On("ComparePassword", hashedPassword, password).Return(nil)
+		mocks.passwordService.On("NeedsRehash", hashedPassword).Return(false)
+		mocks.emailVerificationService.On("CheckLoginAllowed", mock.Anything).Return(nil)
+		mocks.mfaService.On("IsEnabled", ctx, id).Return(true, nil)
+		mocks.jwtService.On("GenerateMfaPendingToken", userDto).Return("mfaPendingToken", nil)
+
+		result, err := service.LoginUser(ctx, email, password, clientIP)
+
+		require.NoError(t, err)
+		assert.True(t, result.MfaRequired())
+		assert.Equal(t, "mfaPendingToken", result.MfaPendingToken)
+		assert.Empty(t, result.AccessToken)
+		assert.Empty(t, result.RefreshToken)
+		mocks.sessionService.AssertNotCalled(t, "CreateSession", mock.Anything, mock.Anything)
+		mocks.loginThrottleService.AssertNotCalled(t, "Reset", mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns only a password change token when the password has expired", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+		mocks.loginThrottleService.On("Check", ctx, email, clientIP).Return(nil)
+
+		userDto := &user.UserDto{
+			ID:           id,
+			Email:        email,
+			PasswordHash: hashedPassword,
+			Role:         user.UserRoleAdmin,
+		}
+		mocks.userService.On("GetUserByEmail", ctx, email).Return(userDto, nil)
+		mocks.passwordService.On("ComparePassword", hashedPassword, password).Return(nil)
+		mocks.loginThrottleService.On("Reset", ctx, email).Return(nil)
+		mocks.passwordService.On("NeedsRehash", hashedPassword).Return(false)
+		mocks.emailVerificationService.On("CheckLoginAllowed", mock.Anything).Return(nil)
+		mocks.mfaService.On("IsEnabled", ctx, id).Return(false, nil)
+		mocks.rbacService.On("LoadPermissions", ctx, userDto).Return(nil)
+		mocks.passwordHistoryService.On("IsExpired", userDto).Return(true)
+		mocks.jwtService.On("GeneratePasswordChangeToken", userDto).Return("passwordChangeToken", nil)
+
+		result, err := service.LoginUser(ctx, email, password, clientIP)
+
+		require.NoError(t, err)
+		assert.True(t, result.PasswordChangeRequired())
+		assert.Equal(t, "passwordChangeToken", result.PasswordChangeToken)
+		assert.Empty(t, result.AccessToken)
+		assert.Empty(t, result.RefreshToken)
+		mocks.sessionService.AssertNotCalled(t, "CreateSession", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails when the email address is not verified", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+		mocks.loginThrottleService.On("Check", ctx, email, clientIP).Return(nil)
+
+		userDto := &user.UserDto{
+			ID:           id,
+			Email:        email,
+			PasswordHash: hashedPassword,
+		}
+		mocks.userService.On("GetUserByEmail", ctx, email).Return(userDto, nil)
+		mocks.passwordService.On("ComparePassword", hashedPassword, password).Return(nil)
+		mocks.passwordService.On("NeedsRehash", hashedPassword).Return(false)
+		mocks.emailVerificationService.On("CheckLoginAllowed", userDto).Return(emailVerification.ErrEmailNotVerified)
+
+		result, err := service.LoginUser(ctx, email, password, clientIP)
+
+		require.ErrorIs(t, err, emailVerification.ErrEmailNotVerified)
+		assert.Nil(t, result)
+		mocks.sessionService.AssertNotCalled(t, "CreateSession", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails when the user is disabled", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+		mocks.loginThrottleService.On("Check", ctx, email, clientIP).Return(nil)
+
+		disabledAt := time.Now()
+		userDto := &user.UserDto{
+			ID:           id,
+			Email:        email,
+			PasswordHash: hashedPassword,
+			DisabledAt:   &disabledAt,
+		}
+		mocks.userService.On("GetUserByEmail", ctx, email).Return(userDto, nil)
+		mocks.passwordService.On("ComparePassword", hashedPassword, password).Return(nil)
+		mocks.passwordService.On("NeedsRehash", hashedPassword).Return(false)
+
+		result, err := service.LoginUser(ctx, email, password, clientIP)
+
+		require.ErrorIs(t, err, user.ErrUserDisabled)
+		assert.Nil(t, result)
+		mocks.sessionService.AssertNotCalled(t, "CreateSession", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails when the user waits for approval", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+		mocks.loginThrottleService.On("Check", ctx, email, clientIP).Return(nil)
+
+		userDto := &user.UserDto{
+			ID:              id,
+			Email:           email,
+			PasswordHash:    hashedPassword,
+			PendingApproval: true,
+		}
+		mocks.userService.On("GetUserByEmail", ctx, email).Return(userDto, nil)
+		mocks.passwordService.On("ComparePassword", hashedPassword, password).Return(nil)
+		mocks.passwordService.On("NeedsRehash", hashedPassword).Return(false)
+
+		result, err := service.LoginUser(ctx, email, password, clientIP)
+
+		require.ErrorIs(t, err, user.ErrUserPendingApproval)
+		assert.Nil(t, result)
+		mocks.sessionService.AssertNotCalled(t, "CreateSession", mock.Anything, mock.Anything)
+	})
+
+	t.Run("upgrades an outdated password hash", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+		mocks.loginThrottleService.On("Check", ctx, email, clientIP).Return(nil)
+
+		userDto := &user.UserDto{
+			ID:           id,
+			Username:     username,
+			Email:        email,
+			PasswordHash: hashedPassword,
+		}
+		mocks.userService.On("GetUserByEmail", ctx, email).Return(userDto, nil)
+		mocks.passwordService.On("ComparePassword", hashedPassword, password).Return(nil)
+		mocks.loginThrottleService.On("Reset", ctx, email).Return(nil)
+		mocks.passwordService.On("NeedsRehash", hashedPassword).Return(true)
+		mocks.passwordService.On("HashAndSaltPassword", password).Return("rehashedpassword", nil)
+		mocks.userService.On("UpdateUser", ctx, id, username, email, "rehashedpassword").Return(userDto, nil)
+		mocks.emailVerificationService.On("CheckLoginAllowed", mock.Anything).Return(nil)
+		mocks.mfaService.On("IsEnabled", ctx, id).Return(false, nil)
+		mocks.passwordHistoryService.On("IsExpired", mock.Anything).Return(false)
+		mocks.rbacService.On("LoadPermissions", ctx, mock.Anything).Return(nil)
+		mocks.jwtService.On("GenerateToken", mock.Anything).Return(token, nil)
+		mocks.sessionService.On("CreateSession", ctx, id).Return(&session.SessionTokenDto{
+			UserID:       id,
+			RefreshToken: refreshToken,
+			ExpiresAt:    refreshTokenExpiresAt,
+		}, nil)
+
+		result, err := service.LoginUser(ctx, email, password, clientIP)
+
+		require.NoError(t, err)
+		assert.Equal(t, token, result.AccessToken)
+		mocks.userService.AssertExpectations(t)
+	})
+
+	t.Run("logs in even if the upgraded hash cannot be stored", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+		mocks.loginThrottleService.On("Check", ctx, email, clientIP).Return(nil)
+
+		userDto := &user.UserDto{
+			ID:           id,
+			Username:     username,
+			Email:        email,
+			PasswordHash: hashedPassword,
+		}
+		mocks.userService.On("GetUserByEmail", ctx, email).Return(userDto, nil)
+		mocks.passwordService.On("ComparePassword", hashedPassword, password).Return(nil)
+		mocks.loginThrottleService.On("Reset", ctx, email).Return(nil)
+		mocks.passwordService.On("NeedsRehash", hashedPassword).Return(true)
+		mocks.passwordService.On("HashAndSaltPassword", password).Return("rehashedpassword", nil)
+		mocks.userService.On("UpdateUser", ctx, id, username, email, "rehashedpassword").Return(nil, errors.New("database error"))
+		mocks.emailVerificationService.On("CheckLoginAllowed", mock.Anything).Return(nil)
+		mocks.mfaService.On("IsEnabled", ctx, id).Return(false, nil)
+		mocks.passwordHistoryService.On("IsExpired", mock.Anything).Return(false)
+		mocks.rbacService.On("LoadPermissions", ctx, mock.Anything).Return(nil)
+		mocks.jwtService.On("GenerateToken", mock.Anything).Return(token, nil)
+		mocks.sessionService.On("CreateSession", ctx, id).Return(&session.SessionTokenDto{
+			UserID:       id,
+			RefreshToken: refreshToken,
+			ExpiresAt:    refreshTokenExpiresAt,
+		}, nil)
+
+		result, err := service.LoginUser(ctx, email, password, clientIP)
+
+		require.NoError(t, err)
+		assert.Equal(t, token, result.AccessToken)
+	})
+
+	t.Run("rejects attempts while throttled", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+		mocks.loginThrottleService.On("Check", ctx, email, clientIP).Return(&loginThrottle.ThrottledError{RetryAfter: time.Minute})
+
+		result, err := service.LoginUser(ctx, email, password, clientIP)
+
+		require.ErrorIs(t, err, loginThrottle.ErrLoginThrottled)
+		assert.Nil(t, result)
+		mocks.userService.AssertNotCalled(t, "GetUserByEmail", mock.Anything, mock.Anything)
+	})
+}
+
+func TestVerifyMfaLogin(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	email := "test@example.com"
+	clientIP := "192.0.2.1"
+	mfaPendingToken := "mfaPendingToken"
+	code := "123456"
+	token := "mockJwtToken"
+	refreshToken := "mockRefreshToken"
+	refreshTokenExpiresAt := time.Now().Add(time.Hour)
+	pendingClaims := &jwtService.JwtCustomClaims{
+		UserId:  id.String(),
+		Purpose: jwtService.PURPOSE_MFA_PENDING,
+	}
+
+	t.Run("starts a session after a valid code", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+
+		userDto := &user.UserDto{ID: id, Email: email, Role: user.UserRoleUser}
+		mocks.jwtService.On("ValidateMfaPendingToken", mfaPendingToken).Return(pendingClaims, nil)
+		mocks.userService.On("GetUserById", ctx, id).Return(userDto, nil)
+		mocks.loginThrottleService.On("Check", ctx, email, clientIP).Return(nil)
+		mocks.mfaService.On("Verify", ctx, id, code).Return(nil)
+		mocks.loginThrottleService.On("Reset", ctx, email).Return(nil)
+		mocks.passwordHistoryService.On("IsExpired", userDto).Return(false)
+		mocks.rbacService.On("LoadPermissions", ctx, userDto).Return(nil)
+		mocks.jwtService.On("GenerateToken", userDto).Return(token, nil)
+		mocks.sessionService.On("CreateSession", ctx, id).Return(&session.SessionTokenDto{
+			UserID:       id,
+			RefreshToken: refreshToken,
+			ExpiresAt:    refreshTokenExpiresAt,
+		}, nil)
+
+		result, err := service.VerifyMfaLogin(ctx, mfaPendingToken, code, clientIP)
+
+		require.NoError(t, err)
+		assert.False(t, result.MfaRequired())
+		assert.Equal(t, token, result.AccessToken)
+		assert.Equal(t, refreshToken, result.RefreshToken)
+	})
+
+	t.Run("asks for a new password after a valid code if the password has expired", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+
+		userDto := &user.UserDto{ID: id, Email: email, Role: user.UserRoleAdmin}
+		mocks.jwtService.On("ValidateMfaPendingToken", mfaPendingToken).Return(pendingClaims, nil)
+		mocks.userService.On("GetUserById", ctx, id).Return(userDto, nil)
+		mocks.loginThrottleService.On("Check", ctx, email, clientIP).Return(nil)
+		mocks.mfaService.On("Verify", ctx, id, code).Return(nil)
+		mocks.loginThrottleService.On("Reset", ctx, email).Return(nil)
+		mocks.rbacService.On("LoadPermissions", ctx, userDto).Return(nil)
+		mocks.passwordHistoryService.On("IsExpired", userDto).Return(true)
+		mocks.jwtService.On("GeneratePasswordChangeToken", userDto).Return("passwordChangeToken", nil)
+
+		result, err := service.VerifyMfaLogin(ctx, mfaPendingToken, code, clientIP)
+
+		require.NoError(t, err)
+		assert.True(t, result.PasswordChangeRequired())
+		assert.Empty(t, result.AccessToken)
+		mocks.sessionService.AssertNotCalled(t, "CreateSession", mock.Anything, mock.Anything)
+	})
+
+	t.Run("records a failed attempt for an invalid code", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+
+		userDto := &user.UserDto{ID: id, Email: email, Role: user.UserRoleUser}
+		mocks.jwtService.On("ValidateMfaPendingToken", mfaPendingToken).Return(pendingClaims, nil)
+		mocks.userService.On("GetUserById", ctx, id).Return(userDto, nil)
+		mocks.loginThrottleService.On("Check", ctx, email, clientIP).Return(nil)
+		mocks.mfaService.On("Verify", ctx, id, code).Return(mfa.ErrInvalidMfaCode)
+		mocks.loginThrottleService.On("RecordFailure", ctx, email, clientIP).Return(nil)
+
+		result, err := service.VerifyMfaLogin(ctx, mfaPendingToken, code, clientIP)
+
+		require.ErrorIs(t, err, mfa.ErrInvalidMfaCode)
+		assert.Nil(t, result)
+		mocks.loginThrottleService.AssertNotCalled(t, "Reset", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects codes while the account is locked", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+
+		userDto := &user.UserDto{ID: id, Email: email, Role: user.UserRoleUser}
+		mocks.jwtService.On("ValidateMfaPendingToken", mfaPendingToken).Return(pendingClaims, nil)
+		mocks.userService.On("GetUserById", ctx, id).Return(userDto, nil)
+		mocks.loginThrottleService.On("Check", ctx, email, clientIP).Return(&loginThrottle.ThrottledError{RetryAfter: time.Minute})
+
+		result, err := service.VerifyMfaLogin(ctx, mfaPendingToken, code, clientIP)
+
+		require.ErrorIs(t, err, loginThrottle.ErrLoginThrottled)
+		assert.Nil(t, result)
+		mocks.mfaService.AssertNotCalled(t, "Verify", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails for an invalid mfa pending token", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+
+		mocks.jwtService.On("ValidateMfaPendingToken", mfaPendingToken).Return(nil, jwtService.ErrUnexpectedPurpose)
+
+		result, err := service.VerifyMfaLogin(ctx, mfaPendingToken, code, clientIP)
+
+		require.ErrorIs(t, err, jwtService.ErrUnexpectedPurpose)
+		assert.Nil(t, result)
+	})
+}
+
+func TestChangeExpiredPassword(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	passwordChangeToken := "passwordChangeToken"
+	newPassword := "Quiet-Harbor-Lantern-42!"
+	userDto := &user.UserDto{ID: id, Role: user.UserRoleAdmin}
+	claims := &jwtService.JwtCustomClaims{
+		UserId:  id.String(),
+		Purpose: jwtService.PURPOSE_PASSWORD_CHANGE,
+	}
+
+	t.Run("changes the password and starts a session", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+
+		mocks.jwtService.On("ValidatePasswordChangeToken", passwordChangeToken).Return(claims, nil)
+		mocks.userService.On("GetUserById", ctx, id).Return(userDto, nil)
+		mocks.rbacService.On("LoadPermissions", ctx, userDto).Return(nil)
+		mocks.passwordHistoryService.On("IsExpired", userDto).Return(true)
+		mocks.passwordHistoryService.On("ValidateNewPassword", ctx, userDto, newPassword).Return(nil)
+		mocks.passwordHistoryService.On("ChangePassword", ctx, userDto, newPassword).Return(nil)
+		mocks.jwtService.On("GenerateToken", userDto).Return("mockJwtToken", nil)
+		mocks.sessionService.On("CreateSession", ctx, id).Return(&session.SessionTokenDto{
+			UserID:       id,
+			RefreshToken: "mockRefreshToken",
+			ExpiresAt:    time.Now().Add(time.Hour),
+		}, nil)
+
+		result, err := service.ChangeExpiredPassword(ctx, passwordChangeToken, newPassword)
+
+		require.NoError(t, err)
+		assert.Equal(t, "mockJwtToken", result.AccessToken)
+		assert.Equal(t, "mockRefreshToken", result.RefreshToken)
+	})
+
+	t.Run("rejects a previously used password", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+
+		mocks.jwtService.On("ValidatePasswordChangeToken", passwordChangeToken).Return(claims, nil)
+		mocks.userService.On("GetUserById", ctx, id).Return(userDto, nil)
+		mocks.rbacService.On("LoadPermissions", ctx, userDto).Return(nil)
+		mocks.passwordHistoryService.On("IsExpired", userDto).Return(true)
+		mocks.passwordHistoryService.On("ValidateNewPassword", ctx, userDto, newPassword).Return(passwordHistory.ErrPasswordReused)
+
+		result, err := service.ChangeExpiredPassword(ctx, passwordChangeToken, newPassword)
+
+		require.ErrorIs(t, err, passwordHistory.ErrPasswordReused)
+		assert.Nil(t, result)
+		mocks.passwordHistoryService.AssertNotCalled(t, "ChangePassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects the token once the password was changed", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+
+		mocks.jwtService.On("ValidatePasswordChangeToken", passwordChangeToken).Return(claims, nil)
+		mocks.userService.On("GetUserById", ctx, id).Return(userDto, nil)
+		mocks.rbacService.On("LoadPermissions", ctx, userDto).Return(nil)
+		mocks.passwordHistoryService.On("IsExpired", userDto).Return(false)
+
+		result, err := service.ChangeExpiredPassword(ctx, passwordChangeToken, newPassword)
+
+		require.ErrorIs(t, err, loginRegister.ErrInvalidPasswordChangeToken)
+		assert.Nil(t, result)
+	})
+
+	t.Run("rejects tokens issued for another purpose", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+
+		mocks.jwtService.On("ValidatePasswordChangeToken", passwordChangeToken).Return(nil, jwtService.ErrUnexpectedPurpose)
+
+		result, err := service.ChangeExpiredPassword(ctx, passwordChangeToken, newPassword)
+
+		require.ErrorIs(t, err, loginRegister.ErrInvalidPasswordChangeToken)
+		require.ErrorIs(t, err, jwtService.ErrUnexpectedPurpose)
+		assert.Nil(t, result)
+	})
+}
+
+func TestLoginWithPasskey(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	ceremonyID := uuid.New()
+	response := &webauthn.AuthenticationResponse{ID: "credential"}
+	token := "mockJwtToken"
+	refreshToken := "mockRefreshToken"
+	refreshTokenExpiresAt := time.Now().Add(time.Hour)
+
+	t.Run("starts a session for the passkey owner", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+
+		userDto := &user.UserDto{ID: id, Role: user.UserRoleUser}
+		mocks.passkeyService.On("FinishLogin", ctx, ceremonyID, response).Return(id, nil)
+		mocks.userService.On("GetUserById", ctx, id).Return(userDto, nil)
+		mocks.emailVerificationService.On("CheckLoginAllowed", userDto).Return(nil)
+		mocks.rbacService.On("LoadPermissions", ctx, userDto).Return(nil)
+		mocks.jwtService.On("GenerateToken", userDto).Return(token, nil)
+		mocks.sessionService.On("CreateSession", ctx, id).Return(&session.SessionTokenDto{
+			UserID:       id,
+			RefreshToken: refreshToken,
+			ExpiresAt:    refreshTokenExpiresAt,
+		}, nil)
+
+		result, err := service.LoginWithPasskey(ctx, ceremonyID, response)
+
+		require.NoError(t, err)
+		assert.Equal(t, token, result.AccessToken)
+		assert.Equal(t, refreshToken, result.RefreshToken)
+	})
+
+	t.Run("fails when the assertion is rejected", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+
+		mocks.passkeyService.On("FinishLogin", ctx, ceremonyID, response).Return(uuid.Nil, passkey.ErrPasskeyNotFound)
+
+		result, err := service.LoginWithPasskey(ctx, ceremonyID, response)
+
+		require.ErrorIs(t, err, passkey.ErrPasskeyNotFound)
+		assert.Nil(t, result)
+	})
+}
+
+func TestRefreshTokens(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	refreshToken := "oldRefreshToken"
+	newRefreshToken := "newRefreshToken"
+	refreshTokenExpiresAt := time.Now().Add(time.Hour)
+	token := "mockJwtToken"
+
+	t.Run("successfully refreshes tokens", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+
+		userDto := &user.UserDto{ID: id, Role: user.UserRoleUser}
+		mocks.sessionService.On("RotateSession", ctx, refreshToken).Return(&session.SessionTokenDto{
+			UserID:       id,
+			RefreshToken: newRefreshToken,
+			ExpiresAt:    refreshTokenExpiresAt,
+		}, nil)
+		mocks.userService.On("GetUserById", ctx, id).Return(userDto, nil)
+		mocks.rbacService.On("LoadPermissions", ctx, userDto).Return(nil)
+		mocks.jwtService.On("GenerateToken", userDto).Return(token, nil)
+
+		result, err := service.RefreshTokens(ctx, refreshToken)
+
+		require.NoError(t, err)
+		assert.Equal(t, token, result.AccessToken)
+		assert.Equal(t, newRefreshToken, result.RefreshToken)
+		assert.Equal(t, refreshTokenExpiresAt, result.RefreshTokenExpiresAt)
+	})
+
+	t.Run("puts the current permissions into the access token", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+
+		userDto := &user.UserDto{ID: id, Role: user.UserRoleUser}
+		mocks.sessionService.On("RotateSession", ctx, refreshToken).Return(&session.SessionTokenDto{
+			UserID:       id,
+			RefreshToken: newRefreshToken,
+			ExpiresAt:    refreshTokenExpiresAt,
+		}, nil)
+		mocks.userService.On("GetUserById", ctx, id).Return(userDto, nil)
+		mocks.rbacService.On("LoadPermissions", ctx, userDto).Run(func(args mock.Arguments) {
+			args.Get(1).(*user.UserDto).Permissions = []string{"users:read"}
+		}).Return(nil)
+		mocks.jwtService.On("GenerateToken", mock.MatchedBy(func(u *user.UserDto) bool {
+			return u.HasPermission("users:read")
+		})).Return(token, nil)
+
+		_, err := service.RefreshTokens(ctx, refreshToken)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("fails when refresh token was reused", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+
+		mocks.sessionService.On("RotateSession", ctx, refreshToken).Return(nil, session.ErrRefreshTokenReused)
+
+		result, err := service.RefreshTokens(ctx, refreshToken)
+
+		require.ErrorIs(t, err, session.ErrRefreshTokenReused)
+		assert.Nil(t, result)
+	})
+
+	t.Run("fails when session user does not exist anymore", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+
+		mocks.sessionService.On("RotateSession", ctx, refreshToken).Return(&session.SessionTokenDto{
+			UserID:       id,
+			RefreshToken: newRefreshToken,
+			ExpiresAt:    refreshTokenExpiresAt,
+		}, nil)
+		mocks.userService.On("GetUserById", ctx, id).Return(nil, user.ErrUserNotFound)
+
+		result, err := service.RefreshTokens(ctx, refreshToken)
+
+		require.ErrorIs(t, err, user.ErrUserNotFound)
+		assert.Nil(t, result)
+	})
+
+	t.Run("fails when the session user was disabled", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+
+		disabledAt := time.Now()
+		mocks.sessionService.On("RotateSession", ctx, refreshToken).Return(&session.SessionTokenDto{
+			UserID:       id,
+			RefreshToken: newRefreshToken,
+			ExpiresAt:    refreshTokenExpiresAt,
+		}, nil)
+		mocks.userService.On("GetUserById", ctx, id).Return(&user.UserDto{ID: id, DisabledAt: &disabledAt}, nil)
+
+		result, err := service.RefreshTokens(ctx, refreshToken)
+
+		require.ErrorIs(t, err, user.ErrUserDisabled)
+		assert.Nil(t, result)
+	})
+
+	t.Run("keeps the tenant of the session", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+
+		organizationID := uuid.New()
+		userDto := &user.UserDto{ID: id, Role: user.UserRoleUser}
+		mocks.sessionService.On("RotateSession", ctx, refreshToken).Return(&session.SessionTokenDto{
+			UserID:         id,
+			OrganizationID: organizationID,
+			RefreshToken:   newRefreshToken,
+			ExpiresAt:      refreshTokenExpiresAt,
+		}, nil)
+		mocks.userService.On("GetUserById", ctx, id).Return(userDto, nil)
+		mocks.rbacService.On("LoadPermissions", ctx, userDto).Return(nil)
+		mocks.organizationService.On("LoadTenant", ctx, userDto, organizationID).Return(nil)
+		mocks.jwtService.On("GenerateToken", userDto).Return(token, nil)
+
+		_, err := service.RefreshTokens(ctx, refreshToken)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("drops the tenant of a removed member", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+
+		organizationID := uuid.New()
+		userDto := &user.UserDto{ID: id, Role: user.UserRoleUser}
+		mocks.sessionService.On("RotateSession", ctx, refreshToken).Return(&session.SessionTokenDto{
+			UserID:         id,
+			OrganizationID: organizationID,
+			RefreshToken:   newRefreshToken,
+			ExpiresAt:      refreshTokenExpiresAt,
+		}, nil)
+		mocks.userService.On("GetUserById", ctx, id).Return(userDto, nil)
+		mocks.rbacService.On("LoadPermissions", ctx, userDto).Return(nil)
+		mocks.organizationService.On("LoadTenant", ctx, userDto, organizationID).Return(organization.ErrNotMember)
+		mocks.jwtService.On("GenerateToken", userDto).Return(token, nil)
+
+		result, err := service.RefreshTokens(ctx, refreshToken)
+
+		require.NoError(t, err)
+		assert.Equal(t, token, result.AccessToken)
+	})
+}
+
+func TestSwitchOrganization(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	organizationID := uuid.New()
+	refreshToken := "oldRefreshToken"
+	newRefreshToken := "newRefreshToken"
+	refreshTokenExpiresAt := time.Now().Add(time.Hour)
+	token := "mockJwtToken"
+
+	t.Run("switches members into the organization", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+
+		userDto := &user.UserDto{ID: id, Role: user.UserRoleUser}
+		mocks.organizationService.On("GetMembership", ctx, organizationID, id).Return(&organization.MembershipDto{OrganizationID: organizationID}, nil)
+		mocks.sessionService.On("SwitchOrganization", ctx, refreshToken, organizationID).Return(&session.SessionTokenDto{
+			UserID:         id,
+			OrganizationID: organizationID,
+			RefreshToken:   newRefreshToken,
+			ExpiresAt:      refreshTokenExpiresAt,
+		}, nil)
+		mocks.userService.On("GetUserById", ctx, id).Return(userDto, nil)
+		mocks.rbacService.On("LoadPermissions", ctx, userDto).Return(nil)
+		mocks.organizationService.On("LoadTenant", ctx, userDto, organizationID).Return(nil)
+		mocks.jwtService.On("GenerateToken", userDto).Return(token, nil)
+
+		result, err := service.SwitchOrganization(ctx, id, refreshToken, organizationID)
+
+		require.NoError(t, err)
+		assert.Equal(t, token, result.AccessToken)
+		assert.Equal(t, newRefreshToken, result.RefreshToken)
+	})
+
+	t.Run("leaves the organization without a membership check", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+
+		userDto := &user.UserDto{ID: id, Role: user.UserRoleUser}
+		mocks.sessionService.On("SwitchOrganization", ctx, refreshToken, uuid.Nil).Return(&session.SessionTokenDto{
+			UserID:       id,
+			RefreshToken: newRefreshToken,
+			ExpiresAt:    refreshTokenExpiresAt,
+		}, nil)
+		mocks.userService.On("GetUserById", ctx, id).Return(userDto, nil)
+		mocks.rbacService.On("LoadPermissions", ctx, userDto).Return(nil)
+		mocks.jwtService.On("GenerateToken", userDto).Return(token, nil)
+
+		_, err := service.SwitchOrganization(ctx, id, refreshToken, uuid.Nil)
+
+		require.NoError(t, err)
+		mocks.organizationService.AssertNotCalled(t, "LoadTenant", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails for non members", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+
+		mocks.organizationService.On("GetMembership", ctx, organizationID, id).Return(nil, organization.ErrNotMember)
+
+		_, err := service.SwitchOrganization(ctx, id, refreshToken, organizationID)
+
+		require.ErrorIs(t, err, organization.ErrNotMember)
+		mocks.sessionService.AssertNotCalled(t, "SwitchOrganization", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails for the refresh token of another user", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+
+		mocks.sessionService.On("SwitchOrganization", ctx, refreshToken, uuid.Nil).Return(&session.SessionTokenDto{
+			UserID:       uuid.New(),
+			RefreshToken: newRefreshToken,
+			ExpiresAt:    refreshTokenExpiresAt,
+		}, nil)
+		mocks.sessionService.On("RevokeSession", ctx, newRefreshToken).Return(nil)
+
+		_, err := service.SwitchOrganization(ctx, id, refreshToken, uuid.Nil)
+
+		require.ErrorIs(t, err, session.ErrInvalidRefreshToken)
+	})
+}
+
+func TestLogoutUser(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	accessToken := "mockJwtToken"
+	refreshToken := "mockRefreshToken"
+	expiresAt := time.Now().Add(time.Minute)
+
+	t.Run("revokes access token and session", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+
+		mocks.jwtService.On("ValidateAndExtractClaims", accessToken).Return(&jwtService.JwtCustomClaims{
+			UserId: id.String(),
+			RegisteredClaims: gojwt.RegisteredClaims{
+				ID:        "some-jti",
+				ExpiresAt: gojwt.NewNumericDate(expiresAt),
+			},
+		}, nil)
+		mocks.sessionService.On("RevokeAccessToken", ctx, "some-jti", id, expiresAt.Truncate(time.Second)).Return(nil)
+		mocks.sessionService.On("RevokeSession", ctx, refreshToken).Return(nil)
+
+		err := service.LogoutUser(ctx, accessToken, refreshToken)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("skips revocation of an invalid access token", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+
+		mocks.jwtService.On("ValidateAndExtractClaims", accessToken).Return(nil, errors.New("token is expired"))
+		mocks.sessionService.On("RevokeSession", ctx, refreshToken).Return(nil)
+
+		err := service.LogoutUser(ctx, accessToken, refreshToken)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("does nothing without credentials", func(t *testing.T) {
+		_, service := setupLoginRegisterServiceTest(t)
+
+		err := service.LogoutUser(ctx, "", "")
+
+		require.NoError(t, err)
 	})
 }
 
@@ -101,15 +924,17 @@ func TestRegisterUser(t *testing.T) {
 	hashedPassword := "hashedpassword"
 
 	t.Run("successfully registers user", func(t *testing.T) {
-		mockUserService, mockPasswordService, _, service := setupLoginRegisterServiceTest(t)
+		mocks, service := setupLoginRegisterServiceTest(t)
 
-		mockUserService.On("UserExistsByEmail", ctx, email).Return(false, nil)
-		mockUserService.On("ValidateCreateUserParams", username, email, password).Return(nil)
-		mockPasswordService.On("HashAndSaltPassword", password).Return(hashedPassword, nil)
-		mockUserService.On("CreateUser", ctx, username, email, hashedPassword).Return(&user.UserCreatedDto{
+		mocks.userService.On("UserExistsByEmail", ctx, email).Return(false, nil)
+		mocks.userService.On("UserExistsByUsername", ctx, username).Return(false, nil)
+		mocks.userService.On("ValidateCreateUserParams", username, email, password).Return(nil)
+		mocks.passwordService.On("HashAndSaltPassword", password).Return(hashedPassword, nil)
+		mocks.userService.On("CreateUser", ctx, username, email, hashedPassword, false).Return(&user.UserCreatedDto{
 			Username: username,
 			Email:    email,
 		}, nil)
+		mocks.emailVerificationService.On("SendVerification", ctx, email).Return(nil)
 
 		result, err := service.RegisterUser(ctx, username, email, password)
 
@@ -118,14 +943,16 @@ func TestRegisterUser(t *testing.T) {
 		assert.Equal(t, username, result.Username)
 		assert.Equal(t, email, result.Email)
 
-		mockUserService.AssertExpectations(t)
-		mockPasswordService.AssertExpectations(t)
+		mocks.userService.AssertExpectations(t)
+		mocks.passwordService.AssertExpectations(t)
 	})
 
 	t.Run("fails when user already exists", func(t *testing.T) {
-		mockUserService, _, _, service := setupLoginRegisterServiceTest(t)
+		mocks, service := setupLoginRegisterServiceTest(t)
 
-		mockUserService.On("UserExistsByEmail", ctx, email).Return(true, nil)
+		mocks.userService.On("ValidateCreateUserParams", username, email, password).Return(nil)
+		mocks.passwordService.On("HashAndSaltPassword", password).Return(hashedPassword, nil)
+		mocks.userService.On("UserExistsByEmail", ctx, email).Return(true, nil)
 
 		result, err := service.RegisterUser(ctx, username, email, password)
 
@@ -137,14 +964,13 @@ func TestRegisterUser(t *testing.T) {
 		assert.True(t, ok, "expected a UserFacingError")
 		assert.Equal(t, "user already exists", ufe.Message)
 
-		mockUserService.AssertExpectations(t)
+		mocks.userService.AssertExpectations(t)
 	})
 
 	t.Run("fails when validation fails", func(t *testing.T) {
-		mockUserService, _, _, service := setupLoginRegisterServiceTest(t)
+		mocks, service := setupLoginRegisterServiceTest(t)
 
-		mockUserService.On("UserExistsByEmail", ctx, email).Return(false, nil)
-		mockUserService.On("ValidateCreateUserParams", username, email, password).Return(customErrors.NewUserFacing("invalid input"))
+		mocks.userService.On("ValidateCreateUserParams", username, email, password).Return(customErrors.NewUserFacing("invalid input"))
 
 		result, err := service.RegisterUser(ctx, username, email, password)
 
@@ -156,15 +982,31 @@ func TestRegisterUser(t *testing.T) {
 		assert.True(t, ok, "expected a UserFacingError")
 		assert.Equal(t, "failed to validate create user parameters: invalid input", ufe.Message)
 
-		mockUserService.AssertExpectations(t)
+		mocks.userService.AssertExpectations(t)
+	})
+
+	t.Run("returns every password policy violation", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+		violations := []string{"must contain a number", "must contain a special character"}
+
+		mocks.userService.On("ValidateCreateUserParams", username, email, password).
+			Return(&validation.PasswordPolicyError{Violations: violations})
+
+		result, err := service.RegisterUser(ctx, username, email, password)
+
+		assert.Nil(t, result)
+		var policyErr *validation.PasswordPolicyError
+		require.ErrorAs(t, err, &policyErr)
+		assert.Equal(t, violations, policyErr.Violations)
+
+		mocks.userService.AssertExpectations(t)
 	})
 
 	t.Run("fails when hashing password fails", func(t *testing.T) {
-		mockUserService, mockPasswordService, _, service := setupLoginRegisterServiceTest(t)
+		mocks, service := setupLoginRegisterServiceTest(t)
 
-		mockUserService.On("UserExistsByEmail", ctx, email).Return(false, nil)
-		mockUserService.On("ValidateCreateUserParams", username, email, password).Return(nil)
-		mockPasswordService.On("HashAndSaltPassword", password).Return("", errors.New("hashing error"))
+		mocks.userService.On("ValidateCreateUserParams", username, email, password).Return(nil)
+		mocks.passwordService.On("HashAndSaltPassword", password).Return("", errors.New("hashing error"))
 
 		result, err := service.RegisterUser(ctx, username, email, password)
 
@@ -172,7 +1014,94 @@ func TestRegisterUser(t *testing.T) {
 		assert.Nil(t, result)
 		assert.Equal(t, "failed to salt and hash password: hashing error", err.Error())
 
-		mockUserService.AssertExpectations(t)
-		mockPasswordService.AssertExpectations(t)
+		mocks.userService.AssertExpectations(t)
+		mocks.passwordService.AssertExpectations(t)
+	})
+
+	t.Run("answers like a new registration in enumeration safe mode", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTestWithMode(t, config.RegistrationConfig{EnumerationSafe: true})
+
+		mocks.userService.On("ValidateCreateUserParams", username, email, password).Return(nil)
+		mocks.passwordService.On("HashAndSaltPassword", password).Return(hashedPassword, nil)
+		mocks.userService.On("UserExistsByEmail", ctx, email).Return(true, nil)
+		mocks.passwordResetService.On("NotifyExistingAccount", ctx, email).Return(nil)
+
+		result, err := service.RegisterUser(ctx, username, email, password)
+
+		require.NoError(t, err)
+		assert.Equal(t, &user.UserCreatedDto{Username: username, Email: email}, result)
+		mocks.userService.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mocks.emailVerificationService.AssertNotCalled(t, "SendVerification", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails when the registration mode does not allow signing up", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTestWithMode(t, config.RegistrationConfig{
+			Mode:           registration.MODE_DOMAIN,
+			AllowedDomains: []string{"example.org"},
+		})
+
+		mocks.userService.On("ValidateCreateUserParams", username, email, password).Return(nil)
+
+		result, err := service.RegisterUser(ctx, username, email, password)
+
+		require.ErrorIs(t, err, registration.ErrEmailDomainRejected)
+		assert.Nil(t, result)
+		mocks.passwordService.AssertNotCalled(t, "HashAndSaltPassword", mock.Anything)
+	})
+
+	t.Run("requires approval in approval mode", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTestWithMode(t, config.RegistrationConfig{Mode: registration.MODE_APPROVAL})
+
+		mocks.userService.On("ValidateCreateUserParams", username, email, password).Return(nil)
+		mocks.passwordService.On("HashAndSaltPassword", password).Return(hashedPassword, nil)
+		mocks.userService.On("UserExistsByEmail", ctx, email).Return(false, nil)
+		mocks.userService.On("UserExistsByUsername", ctx, username).Return(false, nil)
+		mocks.userService.On("CreateUser", ctx, username, email, hashedPassword, true).Return(&user.UserCreatedDto{
+			Username: username,
+			Email:    email,
+		}, nil)
+		mocks.emailVerificationService.On("SendVerification", ctx, email).Return(nil)
+
+		result, err := service.RegisterUser(ctx, username, email, password)
+
+		require.NoError(t, err)
+		assert.Equal(t, email, result.Email)
+		mocks.userService.AssertExpectations(t)
+	})
+	t.Run("fails when the username is taken in enumeration safe mode", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTestWithMode(t, config.RegistrationConfig{EnumerationSafe: true})
+
+		mocks.userService.On("ValidateCreateUserParams", username, email, password).Return(nil)
+		mocks.passwordService.On("HashAndSaltPassword", password).Return(hashedPassword, nil)
+		mocks.userService.On("UserExistsByEmail", ctx, email).Return(false, nil)
+		mocks.userService.On("UserExistsByUsername", ctx, username).Return(true, nil)
+
+		result, err := service.RegisterUser(ctx, username, email, password)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		ufe, ok := err.(*customErrors.UserFacingError)
+		assert.True(t, ok, "expected a UserFacingError")
+		assert.Equal(t, "username is already taken", ufe.Message)
+		mocks.userService.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("registers the user when the verification email fails", func(t *testing.T) {
+		mocks, service := setupLoginRegisterServiceTest(t)
+
+		mocks.userService.On("ValidateCreateUserParams", username, email, password).Return(nil)
+		mocks.passwordService.On("HashAndSaltPassword", password).Return(hashedPassword, nil)
+		mocks.userService.On("UserExistsByEmail", ctx, email).Return(false, nil)
+		mocks.userService.On("UserExistsByUsername", ctx, username).Return(false, nil)
+		mocks.userService.On("CreateUser", ctx, username, email, hashedPassword, false).Return(&user.UserCreatedDto{
+			Username: username,
+			Email:    email,
+		}, nil)
+		mocks.emailVerificationService.On("SendVerification", ctx, email).Return(errors.New("smtp error"))
+
+		result, err := service.RegisterUser(ctx, username, email, password)
+
+		require.NoError(t, err)
+		assert.Equal(t, email, result.Email)
 	})
 }
@@ -7,7 +7,10 @@ package loginRegister
 import (
 	"context"
 
+	"github.com/fgeck/gotth-postgres/internal/service/loginRegister"
+	"github.com/fgeck/gotth-postgres/internal/service/security/webauthn"
 	"github.com/fgeck/gotth-postgres/internal/service/user"
+	"github.com/google/uuid"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -38,26 +41,86 @@ func (_m *MockLoginRegisterServiceInterface) EXPECT() *MockLoginRegisterServiceI
 	return &MockLoginRegisterServiceInterface_Expecter{mock: &_m.Mock}
 }
 
+// ChangeExpiredPassword provides a mock function for the type MockLoginRegisterServiceInterface
+func (_mock *MockLoginRegisterServiceInterface) ChangeExpiredPassword(ctx context.Context, passwordChangeToken string, newPassword string) (*loginRegister.TokensDto, error) {
+	ret := _mock.Called(ctx, passwordChangeToken, newPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeExpiredPassword")
+	}
+
+	var r0 *loginRegister.TokensDto
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*loginRegister.TokensDto, error)); ok {
+		return returnFunc(ctx, passwordChangeToken, newPassword)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *loginRegister.TokensDto); ok {
+		r0 = returnFunc(ctx, passwordChangeToken, newPassword)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*loginRegister.TokensDto)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, passwordChangeToken, newPassword)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLoginRegisterServiceInterface_ChangeExpiredPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeExpiredPassword'
+type MockLoginRegisterServiceInterface_ChangeExpiredPassword_Call struct {
+	*mock.Call
+}
+
+// ChangeExpiredPassword is a helper method to define mock.On call
+//   - ctx
+//   - passwordChangeToken
+//   - newPassword
+func (_e *MockLoginRegisterServiceInterface_Expecter) ChangeExpiredPassword(ctx interface{}, passwordChangeToken interface{}, newPassword interface{}) *MockLoginRegisterServiceInterface_ChangeExpiredPassword_Call {
+	return &MockLoginRegisterServiceInterface_ChangeExpiredPassword_Call{Call: _e.mock.On("ChangeExpiredPassword", ctx, passwordChangeToken, newPassword)}
+}
+
+func (_c *MockLoginRegisterServiceInterface_ChangeExpiredPassword_Call) Run(run func(ctx context.Context, passwordChangeToken string, newPassword string)) *MockLoginRegisterServiceInterface_ChangeExpiredPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockLoginRegisterServiceInterface_ChangeExpiredPassword_Call) Return(tokensDto *loginRegister.TokensDto, err error) *MockLoginRegisterServiceInterface_ChangeExpiredPassword_Call {
+	_c.Call.Return(tokensDto, err)
+	return _c
+}
+
+func (_c *MockLoginRegisterServiceInterface_ChangeExpiredPassword_Call) RunAndReturn(run func(ctx context.Context, passwordChangeToken string, newPassword string) (*loginRegister.TokensDto, error)) *MockLoginRegisterServiceInterface_ChangeExpiredPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // LoginUser provides a mock function for the type MockLoginRegisterServiceInterface
-func (_mock *MockLoginRegisterServiceInterface) LoginUser(ctx context.Context, email string, password string) (string, error) {
-	ret := _mock.Called(ctx, email, password)
+func (_mock *MockLoginRegisterServiceInterface) LoginUser(ctx context.Context, email string, password string, clientIP string) (*loginRegister.TokensDto, error) {
+	ret := _mock.Called(ctx, email, password, clientIP)
 
 	if len(ret) == 0 {
 		panic("no return value specified for LoginUser")
 	}
 
-	var r0 string
+	var r0 *loginRegister.TokensDto
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
-		return returnFunc(ctx, email, password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (*loginRegister.TokensDto, error)); ok {
+		return returnFunc(ctx, email, password, clientIP)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
-		r0 = returnFunc(ctx, email, password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) *loginRegister.TokensDto); ok {
+		r0 = returnFunc(ctx, email, password, clientIP)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*loginRegister.TokensDto)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, email, password)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, email, password, clientIP)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -73,23 +136,186 @@ type MockLoginRegisterServiceInterface_LoginUser_Call struct {
 //   - ctx
 //   - email
 //   - password
-func (_e *MockLoginRegisterServiceInterface_Expecter) LoginUser(ctx interface{}, email interface{}, password interface{}) *MockLoginRegisterServiceInterface_LoginUser_Call {
-	return &MockLoginRegisterServiceInterface_LoginUser_Call{Call: _e.mock.On("LoginUser", ctx, email, password)}
+//   - clientIP
+func (_e *MockLoginRegisterServiceInterface_Expecter) LoginUser(ctx interface{}, email interface{}, password interface{}, clientIP interface{}) *MockLoginRegisterServiceInterface_LoginUser_Call {
+	return &MockLoginRegisterServiceInterface_LoginUser_Call{Call: _e.mock.On("LoginUser", ctx, email, password, clientIP)}
 }
 
-func (_c *MockLoginRegisterServiceInterface_LoginUser_Call) Run(run func(ctx context.Context, email string, password string)) *MockLoginRegisterServiceInterface_LoginUser_Call {
+func (_c *MockLoginRegisterServiceInterface_LoginUser_Call) Run(run func(ctx context.Context, email string, password string, clientIP string)) *MockLoginRegisterServiceInterface_LoginUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockLoginRegisterServiceInterface_LoginUser_Call) Return(tokensDto *loginRegister.TokensDto, err error) *MockLoginRegisterServiceInterface_LoginUser_Call {
+	_c.Call.Return(tokensDto, err)
+	return _c
+}
+
+func (_c *MockLoginRegisterServiceInterface_LoginUser_Call) RunAndReturn(run func(ctx context.Context, email string, password string, clientIP string) (*loginRegister.TokensDto, error)) *MockLoginRegisterServiceInterface_LoginUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoginWithPasskey provides a mock function for the type MockLoginRegisterServiceInterface
+func (_mock *MockLoginRegisterServiceInterface) LoginWithPasskey(ctx context.Context, ceremonyID uuid.UUID, response *webauthn.AuthenticationResponse) (*loginRegister.TokensDto, error) {
+	ret := _mock.Called(ctx, ceremonyID, response)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoginWithPasskey")
+	}
+
+	var r0 *loginRegister.TokensDto
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *webauthn.AuthenticationResponse) (*loginRegister.TokensDto, error)); ok {
+		return returnFunc(ctx, ceremonyID, response)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, *webauthn.AuthenticationResponse) *loginRegister.TokensDto); ok {
+		r0 = returnFunc(ctx, ceremonyID, response)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*loginRegister.TokensDto)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, *webauthn.AuthenticationResponse) error); ok {
+		r1 = returnFunc(ctx, ceremonyID, response)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLoginRegisterServiceInterface_LoginWithPasskey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoginWithPasskey'
+type MockLoginRegisterServiceInterface_LoginWithPasskey_Call struct {
+	*mock.Call
+}
+
+// LoginWithPasskey is a helper method to define mock.On call
+//   - ctx
+//   - ceremonyID
+//   - response
+func (_e *MockLoginRegisterServiceInterface_Expecter) LoginWithPasskey(ctx interface{}, ceremonyID interface{}, response interface{}) *MockLoginRegisterServiceInterface_LoginWithPasskey_Call {
+	return &MockLoginRegisterServiceInterface_LoginWithPasskey_Call{Call: _e.mock.On("LoginWithPasskey", ctx, ceremonyID, response)}
+}
+
+func (_c *MockLoginRegisterServiceInterface_LoginWithPasskey_Call) Run(run func(ctx context.Context, ceremonyID uuid.UUID, response *webauthn.AuthenticationResponse)) *MockLoginRegisterServiceInterface_LoginWithPasskey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*webauthn.AuthenticationResponse))
+	})
+	return _c
+}
+
+func (_c *MockLoginRegisterServiceInterface_LoginWithPasskey_Call) Return(tokensDto *loginRegister.TokensDto, err error) *MockLoginRegisterServiceInterface_LoginWithPasskey_Call {
+	_c.Call.Return(tokensDto, err)
+	return _c
+}
+
+func (_c *MockLoginRegisterServiceInterface_LoginWithPasskey_Call) RunAndReturn(run func(ctx context.Context, ceremonyID uuid.UUID, response *webauthn.AuthenticationResponse) (*loginRegister.TokensDto, error)) *MockLoginRegisterServiceInterface_LoginWithPasskey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LogoutUser provides a mock function for the type MockLoginRegisterServiceInterface
+func (_mock *MockLoginRegisterServiceInterface) LogoutUser(ctx context.Context, accessToken string, refreshToken string) error {
+	ret := _mock.Called(ctx, accessToken, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogoutUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, accessToken, refreshToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockLoginRegisterServiceInterface_LogoutUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogoutUser'
+type MockLoginRegisterServiceInterface_LogoutUser_Call struct {
+	*mock.Call
+}
+
+// LogoutUser is a helper method to define mock.On call
+//   - ctx
+//   - accessToken
+//   - refreshToken
+func (_e *MockLoginRegisterServiceInterface_Expecter) LogoutUser(ctx interface{}, accessToken interface{}, refreshToken interface{}) *MockLoginRegisterServiceInterface_LogoutUser_Call {
+	return &MockLoginRegisterServiceInterface_LogoutUser_Call{Call: _e.mock.On("LogoutUser", ctx, accessToken, refreshToken)}
+}
+
+func (_c *MockLoginRegisterServiceInterface_LogoutUser_Call) Run(run func(ctx context.Context, accessToken string, refreshToken string)) *MockLoginRegisterServiceInterface_LogoutUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *MockLoginRegisterServiceInterface_LoginUser_Call) Return(s string, err error) *MockLoginRegisterServiceInterface_LoginUser_Call {
-	_c.Call.Return(s, err)
+func (_c *MockLoginRegisterServiceInterface_LogoutUser_Call) Return(err error) *MockLoginRegisterServiceInterface_LogoutUser_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockLoginRegisterServiceInterface_LoginUser_Call) RunAndReturn(run func(ctx context.Context, email string, password string) (string, error)) *MockLoginRegisterServiceInterface_LoginUser_Call {
+func (_c *MockLoginRegisterServiceInterface_LogoutUser_Call) RunAndReturn(run func(ctx context.Context, accessToken string, refreshToken string) error) *MockLoginRegisterServiceInterface_LogoutUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RefreshTokens provides a mock function for the type MockLoginRegisterServiceInterface
+func (_mock *MockLoginRegisterServiceInterface) RefreshTokens(ctx context.Context, refreshToken string) (*loginRegister.TokensDto, error) {
+	ret := _mock.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshTokens")
+	}
+
+	var r0 *loginRegister.TokensDto
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*loginRegister.TokensDto, error)); ok {
+		return returnFunc(ctx, refreshToken)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *loginRegister.TokensDto); ok {
+		r0 = returnFunc(ctx, refreshToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*loginRegister.TokensDto)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLoginRegisterServiceInterface_RefreshTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshTokens'
+type MockLoginRegisterServiceInterface_RefreshTokens_Call struct {
+	*mock.Call
+}
+
+// RefreshTokens is a helper method to define mock.On call
+//   - ctx
+//   - refreshToken
+func (_e *MockLoginRegisterServiceInterface_Expecter) RefreshTokens(ctx interface{}, refreshToken interface{}) *MockLoginRegisterServiceInterface_RefreshTokens_Call {
+	return &MockLoginRegisterServiceInterface_RefreshTokens_Call{Call: _e.mock.On("RefreshTokens", ctx, refreshToken)}
+}
+
+func (_c *MockLoginRegisterServiceInterface_RefreshTokens_Call) Run(run func(ctx context.Context, refreshToken string)) *MockLoginRegisterServiceInterface_RefreshTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockLoginRegisterServiceInterface_RefreshTokens_Call) Return(tokensDto *loginRegister.TokensDto, err error) *MockLoginRegisterServiceInterface_RefreshTokens_Call {
+	_c.Call.Return(tokensDto, err)
+	return _c
+}
+
+func (_c *MockLoginRegisterServiceInterface_RefreshTokens_Call) RunAndReturn(run func(ctx context.Context, refreshToken string) (*loginRegister.TokensDto, error)) *MockLoginRegisterServiceInterface_RefreshTokens_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -152,3 +378,121 @@ func (_c *MockLoginRegisterServiceInterface_RegisterUser_Call) RunAndReturn(run
 	_c.Call.Return(run)
 	return _c
 }
+
+// SwitchOrganization provides a mock function for the type MockLoginRegisterServiceInterface
+func (_mock *MockLoginRegisterServiceInterface) SwitchOrganization(ctx context.Context, userID uuid.UUID, refreshToken string, organizationID uuid.UUID) (*loginRegister.TokensDto, error) {
+	ret := _mock.Called(ctx, userID, refreshToken, organizationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SwitchOrganization")
+	}
+
+	var r0 *loginRegister.TokensDto
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, uuid.UUID) (*loginRegister.TokensDto, error)); ok {
+		return returnFunc(ctx, userID, refreshToken, organizationID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, uuid.UUID) *loginRegister.TokensDto); ok {
+		r0 = returnFunc(ctx, userID, refreshToken, organizationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*loginRegister.TokensDto)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID, refreshToken, organizationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLoginRegisterServiceInterface_SwitchOrganization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SwitchOrganization'
+type MockLoginRegisterServiceInterface_SwitchOrganization_Call struct {
+	*mock.Call
+}
+
+// SwitchOrganization is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - refreshToken
+//   - organizationID
+func (_e *MockLoginRegisterServiceInterface_Expecter) SwitchOrganization(ctx interface{}, userID interface{}, refreshToken interface{}, organizationID interface{}) *MockLoginRegisterServiceInterface_SwitchOrganization_Call {
+	return &MockLoginRegisterServiceInterface_SwitchOrganization_Call{Call: _e.mock.On("SwitchOrganization", ctx, userID, refreshToken, organizationID)}
+}
+
+func (_c *MockLoginRegisterServiceInterface_SwitchOrganization_Call) Run(run func(ctx context.Context, userID uuid.UUID, refreshToken string, organizationID uuid.UUID)) *MockLoginRegisterServiceInterface_SwitchOrganization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockLoginRegisterServiceInterface_SwitchOrganization_Call) Return(tokensDto *loginRegister.TokensDto, err error) *MockLoginRegisterServiceInterface_SwitchOrganization_Call {
+	_c.Call.Return(tokensDto, err)
+	return _c
+}
+
+func (_c *MockLoginRegisterServiceInterface_SwitchOrganization_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, refreshToken string, organizationID uuid.UUID) (*loginRegister.TokensDto, error)) *MockLoginRegisterServiceInterface_SwitchOrganization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyMfaLogin provides a mock function for the type MockLoginRegisterServiceInterface
+func (_mock *MockLoginRegisterServiceInterface) VerifyMfaLogin(ctx context.Context, mfaPendingToken string, code string, clientIP string) (*loginRegister.TokensDto, error) {
+	ret := _mock.Called(ctx, mfaPendingToken, code, clientIP)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyMfaLogin")
+	}
+
+	var r0 *loginRegister.TokensDto
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (*loginRegister.TokensDto, error)); ok {
+		return returnFunc(ctx, mfaPendingToken, code, clientIP)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) *loginRegister.TokensDto); ok {
+		r0 = returnFunc(ctx, mfaPendingToken, code, clientIP)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*loginRegister.TokensDto)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, mfaPendingToken, code, clientIP)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLoginRegisterServiceInterface_VerifyMfaLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyMfaLogin'
+type MockLoginRegisterServiceInterface_VerifyMfaLogin_Call struct {
+	*mock.Call
+}
+
+// VerifyMfaLogin is a helper method to define mock.On call
+//   - ctx
+//   - mfaPendingToken
+//   - code
+//   - clientIP
+func (_e *MockLoginRegisterServiceInterface_Expecter) VerifyMfaLogin(ctx interface{}, mfaPendingToken interface{}, code interface{}, clientIP interface{}) *MockLoginRegisterServiceInterface_VerifyMfaLogin_Call {
+	return &MockLoginRegisterServiceInterface_VerifyMfaLogin_Call{Call: _e.mock.On("VerifyMfaLogin", ctx, mfaPendingToken, code, clientIP)}
+}
+
+func (_c *MockLoginRegisterServiceInterface_VerifyMfaLogin_Call) Run(run func(ctx context.Context, mfaPendingToken string, code string, clientIP string)) *MockLoginRegisterServiceInterface_VerifyMfaLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockLoginRegisterServiceInterface_VerifyMfaLogin_Call) Return(tokensDto *loginRegister.TokensDto, err error) *MockLoginRegisterServiceInterface_VerifyMfaLogin_Call {
+	_c.Call.Return(tokensDto, err)
+	return _c
+}
+
+func (_c *MockLoginRegisterServiceInterface_VerifyMfaLogin_Call) RunAndReturn(run func(ctx context.Context, mfaPendingToken string, code string, clientIP string) (*loginRegister.TokensDto, error)) *MockLoginRegisterServiceInterface_VerifyMfaLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
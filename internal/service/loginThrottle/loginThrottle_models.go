@@ -0,0 +1,39 @@
+package loginThrottle
+
+import (
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+)
+
+type ThrottleDto struct {
+	Scope          string    `json:"scope"`
+	Subject        string    `json:"subject"`
+	FailedAttempts int32     `json:"failedAttempts"`
+	LastFailedAt   time.Time `json:"lastFailedAt"`
+	LockedUntil    time.Time `json:"lockedUntil"`
+}
+
+func NewThrottleDto(throttle repository.LoginThrottle) *ThrottleDto {
+	return &ThrottleDto{
+		Scope:          throttle.Scope,
+		Subject:        throttle.Subject,
+		FailedAttempts: throttle.FailedAttempts,
+		LastFailedAt:   throttle.LastFailedAt.Time,
+		LockedUntil:    throttle.LockedUntil.Time,
+	}
+}
+
+// ThrottledError is returned while logins are blocked. It matches
+// ErrLoginThrottled and tells the client when to try again.
+type ThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	return ErrLoginThrottled.Error()
+}
+
+func (e *ThrottledError) Is(target error) bool {
+	return target == ErrLoginThrottled
+}
@@ -0,0 +1,219 @@
+package loginThrottle
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	"github.com/fgeck/gotth-postgres/internal/service/config"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	SCOPE_ACCOUNT = "account"
+	SCOPE_IP      = "ip"
+)
+
+var (
+	ErrLoginThrottled   = errors.New("too many failed login attempts")
+	ErrUnknownScope     = errors.New("unknown login throttle scope")
+	ErrThrottleNotFound = errors.New("login throttle not found")
+)
+
+type LoginThrottleServiceInterface interface {
+	Check(ctx context.Context, email, clientIP string) error
+	RecordFailure(ctx context.Context, email, clientIP string) error
+	Reset(ctx context.Context, email string) error
+	ListLocked(ctx context.Context) ([]*ThrottleDto, error)
+	Clear(ctx context.Context, scope, subject string) error
+}
+
+type LoginThrottleService struct {
+	queries repository.Querier
+	cfg     config.ThrottleConfig
+}
+
+func NewLoginThrottleService(queries repository.Querier, cfg config.ThrottleConfig) *LoginThrottleService {
+	return &LoginThrottleService{
+		queries: queries,
+		cfg:     cfg,
+	}
+}
+
+// Check returns a ThrottledError if either the account or the client IP is
+// currently locked. Accounts are identified by email whether they exist or
+// not, so the answer does not reveal registered addresses.
+func (s *LoginThrottleService) Check(ctx context.Context, email, clientIP string) error {
+	now := time.Now()
+	var retryAfter time.Duration
+	for _, target := range subjects(email, clientIP) {
+		throttle, err := s.queries.GetLoginThrottle(
+			ctx,
+			repository.GetLoginThrottleParams{
+				Scope:   target.scope,
+				Subject: target.subject,
+			},
+		)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get login throttle: %w", err)
+		}
+		if throttle.LockedUntil.Valid && throttle.LockedUntil.Time.After(now) {
+			retryAfter = max(retryAfter, throttle.LockedUntil.Time.Sub(now))
+		}
+	}
+
+	if retryAfter > 0 {
+		return &ThrottledError{RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+// RecordFailure counts a failed login for the account and the client IP and
+// blocks further attempts according to the configured backoff.
+func (s *LoginThrottleService) RecordFailure(ctx context.Context, email, clientIP string) error {
+	now := time.Now()
+	for _, target := range subjects(email, clientIP) {
+		throttle, err := s.queries.RecordLoginFailure(
+			ctx,
+			repository.RecordLoginFailureParams{
+				Scope:       target.scope,
+				Subject:     target.subject,
+				WindowStart: pgtype.Timestamptz{Time: now.Add(-s.cfg.Window), Valid: true},
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to record login failure: %w", err)
+		}
+
+		delay := s.delay(s.limit(target.scope), int(throttle.FailedAttempts))
+		if delay == 0 {
+			continue
+		}
+		err = s.queries.SetLoginLockedUntil(
+			ctx,
+			repository.SetLoginLockedUntilParams{
+				Scope:       target.scope,
+				Subject:     target.subject,
+				LockedUntil: pgtype.Timestamptz{Time: now.Add(delay), Valid: true},
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to lock login: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Reset forgets the failures of an account after a successful login. The
+// counter of the client IP is kept, otherwise an attacker could clear it by
+// logging into an account of their own.
+func (s *LoginThrottleService) Reset(ctx context.Context, email string) error {
+	_, err := s.queries.DeleteLoginThrottle(
+		ctx,
+		repository.DeleteLoginThrottleParams{
+			Scope:   SCOPE_ACCOUNT,
+			Subject: normalizeEmail(email),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to reset login throttle: %w", err)
+	}
+
+	return nil
+}
+
+func (s *LoginThrottleService) ListLocked(ctx context.Context) ([]*ThrottleDto, error) {
+	throttles, err := s.queries.ListLockedLoginThrottles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list login throttles: %w", err)
+	}
+
+	locked := make([]*ThrottleDto, 0, len(throttles))
+	for _, throttle := range throttles {
+		locked = append(locked, NewThrottleDto(throttle))
+	}
+
+	return locked, nil
+}
+
+// Clear lifts a lockout and resets its failure count.
+func (s *LoginThrottleService) Clear(ctx context.Context, scope, subject string) error {
+	switch scope {
+	case SCOPE_ACCOUNT:
+		subject = normalizeEmail(subject)
+	case SCOPE_IP:
+	default:
+		return ErrUnknownScope
+	}
+
+	deleted, err := s.queries.DeleteLoginThrottle(
+		ctx,
+		repository.DeleteLoginThrottleParams{
+			Scope:   scope,
+			Subject: subject,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to clear login throttle: %w", err)
+	}
+	if deleted == 0 {
+		return ErrThrottleNotFound
+	}
+
+	return nil
+}
+
+func (s *LoginThrottleService) limit(scope string) config.ThrottleLimitConfig {
+	if scope == SCOPE_IP {
+		return s.cfg.Ip
+	}
+
+	return s.cfg.Account
+}
+
+// delay is how long logins are blocked after the given number of failures.
+func (s *LoginThrottleService) delay(limit config.ThrottleLimitConfig, failedAttempts int) time.Duration {
+	if limit.MaxAttempts > 0 && failedAttempts >= limit.MaxAttempts {
+		return limit.LockoutDuration
+	}
+	if failedAttempts <= limit.FreeAttempts || s.cfg.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := s.cfg.BaseDelay
+	for i := limit.FreeAttempts + 1; i < failedAttempts && delay < s.cfg.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, s.cfg.MaxDelay)
+}
+
+type throttleSubject struct {
+	scope   string
+	subject string
+}
+
+func subjects(email, clientIP string) []throttleSubject {
+	subjects := make([]throttleSubject, 0, 2)
+	if email := normalizeEmail(email); email != "" {
+		subjects = append(subjects, throttleSubject{scope: SCOPE_ACCOUNT, subject: email})
+	}
+	if clientIP != "" {
+		subjects = append(subjects, throttleSubject{scope: SCOPE_IP, subject: clientIP})
+	}
+
+	return subjects
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
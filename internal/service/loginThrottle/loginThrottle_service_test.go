@@ -0,0 +1,222 @@
+//go:build unittest
+
+package loginThrottle_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	repositoryMocks "github.com/fgeck/gotth-postgres/internal/repository/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/config"
+	"github.com/fgeck/gotth-postgres/internal/service/loginThrottle"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	EMAIL     = "user@example.com"
+	CLIENT_IP = "203.0.113.7"
+)
+
+var throttleConfig = config.ThrottleConfig{
+	Window:    15 * time.Minute,
+	BaseDelay: time.Second,
+	MaxDelay:  10 * time.Second,
+	Account: config.ThrottleLimitConfig{
+		FreeAttempts:    3,
+		MaxAttempts:     10,
+		LockoutDuration: 15 * time.Minute,
+	},
+	Ip: config.ThrottleLimitConfig{
+		FreeAttempts:    20,
+		MaxAttempts:     100,
+		LockoutDuration: time.Hour,
+	},
+}
+
+func setupLoginThrottleServiceTest(t *testing.T) (*repositoryMocks.MockQuerier, *loginThrottle.LoginThrottleService) {
+	mockQueries := repositoryMocks.NewMockQuerier(t)
+	service := loginThrottle.NewLoginThrottleService(mockQueries, throttleConfig)
+	return mockQueries, service
+}
+
+func accountParams(email string) repository.GetLoginThrottleParams {
+	return repository.GetLoginThrottleParams{Scope: loginThrottle.SCOPE_ACCOUNT, Subject: email}
+}
+
+func ipParams(ip string) repository.GetLoginThrottleParams {
+	return repository.GetLoginThrottleParams{Scope: loginThrottle.SCOPE_IP, Subject: ip}
+}
+
+func lockedUntil(d time.Duration) pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: time.Now().Add(d), Valid: true}
+}
+
+func TestCheck(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("allows logins without recorded failures", func(t *testing.T) {
+		mockQueries, service := setupLoginThrottleServiceTest(t)
+
+		mockQueries.On("GetLoginThrottle", ctx, accountParams(EMAIL)).Return(repository.LoginThrottle{}, sql.ErrNoRows)
+		mockQueries.On("GetLoginThrottle", ctx, ipParams(CLIENT_IP)).Return(repository.LoginThrottle{}, sql.ErrNoRows)
+
+		require.NoError(t, service.Check(ctx, EMAIL, CLIENT_IP))
+	})
+
+	t.Run("allows logins once the lock expired", func(t *testing.T) {
+		mockQueries, service := setupLoginThrottleServiceTest(t)
+
+		mockQueries.On("GetLoginThrottle", ctx, accountParams(EMAIL)).Return(repository.LoginThrottle{
+			FailedAttempts: 5,
+			LockedUntil:    lockedUntil(-time.Second),
+		}, nil)
+		mockQueries.On("GetLoginThrottle", ctx, ipParams(CLIENT_IP)).Return(repository.LoginThrottle{}, sql.ErrNoRows)
+
+		require.NoError(t, service.Check(ctx, EMAIL, CLIENT_IP))
+	})
+
+	t.Run("reports the longest remaining lock", func(t *testing.T) {
+		mockQueries, service := setupLoginThrottleServiceTest(t)
+
+		mockQueries.On("GetLoginThrottle", ctx, accountParams(EMAIL)).Return(repository.LoginThrottle{LockedUntil: lockedUntil(time.Minute)}, nil)
+		mockQueries.On("GetLoginThrottle", ctx, ipParams(CLIENT_IP)).Return(repository.LoginThrottle{LockedUntil: lockedUntil(time.Hour)}, nil)
+
+		err := service.Check(ctx, EMAIL, CLIENT_IP)
+
+		require.ErrorIs(t, err, loginThrottle.ErrLoginThrottled)
+		var throttledErr *loginThrottle.ThrottledError
+		require.ErrorAs(t, err, &throttledErr)
+		assert.InDelta(t, time.Hour.Seconds(), throttledErr.RetryAfter.Seconds(), 5)
+	})
+
+	t.Run("normalizes the email address", func(t *testing.T) {
+		mockQueries, service := setupLoginThrottleServiceTest(t)
+
+		mockQueries.On("GetLoginThrottle", ctx, accountParams(EMAIL)).Return(repository.LoginThrottle{LockedUntil: lockedUntil(time.Minute)}, nil)
+
+		require.ErrorIs(t, service.Check(ctx, "  User@Example.com ", ""), loginThrottle.ErrLoginThrottled)
+	})
+}
+
+func TestRecordFailure(t *testing.T) {
+	ctx := context.Background()
+
+	expectFailure := func(mockQueries *repositoryMocks.MockQuerier, scope, subject string, failedAttempts int32) {
+		mockQueries.On("RecordLoginFailure", ctx, mock.MatchedBy(func(params repository.RecordLoginFailureParams) bool {
+			return params.Scope == scope &&
+				params.Subject == subject &&
+				params.WindowStart.Time.Before(time.Now().Add(-throttleConfig.Window+time.Second))
+		})).Return(repository.LoginThrottle{Scope: scope, Subject: subject, FailedAttempts: failedAttempts}, nil)
+	}
+	expectLock := func(mockQueries *repositoryMocks.MockQuerier, scope, subject string, delay time.Duration) {
+		mockQueries.On("SetLoginLockedUntil", ctx, mock.MatchedBy(func(params repository.SetLoginLockedUntilParams) bool {
+			remaining := time.Until(params.LockedUntil.Time)
+			return params.Scope == scope &&
+				params.Subject == subject &&
+				remaining > delay-5*time.Second && remaining <= delay
+		})).Return(nil)
+	}
+
+	t.Run("does not delay the first attempts", func(t *testing.T) {
+		mockQueries, service := setupLoginThrottleServiceTest(t)
+
+		expectFailure(mockQueries, loginThrottle.SCOPE_ACCOUNT, EMAIL, 3)
+		expectFailure(mockQueries, loginThrottle.SCOPE_IP, CLIENT_IP, 3)
+
+		require.NoError(t, service.RecordFailure(ctx, EMAIL, CLIENT_IP))
+		mockQueries.AssertNotCalled(t, "SetLoginLockedUntil", mock.Anything, mock.Anything)
+	})
+
+	t.Run("doubles the delay with every further failure up to the maximum", func(t *testing.T) {
+		for failedAttempts, delay := range map[int32]time.Duration{
+			4: time.Second,
+			5: 2 * time.Second,
+			6: 4 * time.Second,
+			7: 8 * time.Second,
+			9: 10 * time.Second,
+		} {
+			mockQueries, service := setupLoginThrottleServiceTest(t)
+
+			expectFailure(mockQueries, loginThrottle.SCOPE_ACCOUNT, EMAIL, failedAttempts)
+			expectLock(mockQueries, loginThrottle.SCOPE_ACCOUNT, EMAIL, delay)
+
+			require.NoError(t, service.RecordFailure(ctx, EMAIL, ""))
+		}
+	})
+
+	t.Run("locks out after the maximum attempts", func(t *testing.T) {
+		mockQueries, service := setupLoginThrottleServiceTest(t)
+
+		expectFailure(mockQueries, loginThrottle.SCOPE_ACCOUNT, EMAIL, 10)
+		expectFailure(mockQueries, loginThrottle.SCOPE_IP, CLIENT_IP, 100)
+		expectLock(mockQueries, loginThrottle.SCOPE_ACCOUNT, EMAIL, 15*time.Minute)
+		expectLock(mockQueries, loginThrottle.SCOPE_IP, CLIENT_IP, time.Hour)
+
+		require.NoError(t, service.RecordFailure(ctx, EMAIL, CLIENT_IP))
+	})
+}
+
+func TestReset(t *testing.T) {
+	ctx := context.Background()
+	mockQueries, service := setupLoginThrottleServiceTest(t)
+
+	mockQueries.On("DeleteLoginThrottle", ctx, repository.DeleteLoginThrottleParams{
+		Scope:   loginThrottle.SCOPE_ACCOUNT,
+		Subject: EMAIL,
+	}).Return(int64(1), nil)
+
+	require.NoError(t, service.Reset(ctx, "User@Example.com"))
+}
+
+func TestClear(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("deletes the throttle", func(t *testing.T) {
+		mockQueries, service := setupLoginThrottleServiceTest(t)
+
+		mockQueries.On("DeleteLoginThrottle", ctx, repository.DeleteLoginThrottleParams{
+			Scope:   loginThrottle.SCOPE_IP,
+			Subject: CLIENT_IP,
+		}).Return(int64(1), nil)
+
+		require.NoError(t, service.Clear(ctx, loginThrottle.SCOPE_IP, CLIENT_IP))
+	})
+
+	t.Run("fails for an unknown throttle", func(t *testing.T) {
+		mockQueries, service := setupLoginThrottleServiceTest(t)
+
+		mockQueries.On("DeleteLoginThrottle", ctx, mock.Anything).Return(int64(0), nil)
+
+		require.ErrorIs(t, service.Clear(ctx, loginThrottle.SCOPE_ACCOUNT, EMAIL), loginThrottle.ErrThrottleNotFound)
+	})
+
+	t.Run("rejects an unknown scope", func(t *testing.T) {
+		_, service := setupLoginThrottleServiceTest(t)
+
+		require.ErrorIs(t, service.Clear(ctx, "user", EMAIL), loginThrottle.ErrUnknownScope)
+	})
+}
+
+func TestListLocked(t *testing.T) {
+	ctx := context.Background()
+	mockQueries, service := setupLoginThrottleServiceTest(t)
+	until := lockedUntil(time.Minute)
+
+	mockQueries.On("ListLockedLoginThrottles", ctx).Return([]repository.LoginThrottle{
+		{Scope: loginThrottle.SCOPE_ACCOUNT, Subject: EMAIL, FailedAttempts: 10, LockedUntil: until},
+	}, nil)
+
+	locked, err := service.ListLocked(ctx)
+
+	require.NoError(t, err)
+	require.Len(t, locked, 1)
+	assert.Equal(t, EMAIL, locked[0].Subject)
+	assert.Equal(t, int32(10), locked[0].FailedAttempts)
+	assert.Equal(t, until.Time, locked[0].LockedUntil)
+}
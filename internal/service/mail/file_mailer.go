@@ -0,0 +1,47 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log"
+	netmail "net/mail"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FileMailer writes every message as .eml file into a directory, where it
+// can be opened with any mail client.
+type FileMailer struct {
+	directory string
+	from      *netmail.Address
+}
+
+func NewFileMailer(directory string, from *netmail.Address) *FileMailer {
+	return &FileMailer{
+		directory: directory,
+		from:      from,
+	}
+}
+
+func (m *FileMailer) Send(_ context.Context, message *Message) error {
+	now := time.Now()
+	data, err := encodeMessage(m.from, message, now)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(m.directory, 0o750); err != nil {
+		return fmt.Errorf("failed to create mail directory: %w", err)
+	}
+	path := filepath.Join(m.directory, now.Format("20060102T150405")+"-"+uuid.New().String()+".eml")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write mail: %w", err)
+	}
+
+	log.Printf("Mail %q to %s written to %s\n", message.Subject, message.To, path)
+
+	return nil
+}
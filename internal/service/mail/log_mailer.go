@@ -0,0 +1,22 @@
+package mail
+
+import (
+	"context"
+	"log"
+	netmail "net/mail"
+)
+
+// LogMailer only prints the plaintext body to the server log.
+type LogMailer struct {
+	from *netmail.Address
+}
+
+func NewLogMailer(from *netmail.Address) *LogMailer {
+	return &LogMailer{from: from}
+}
+
+func (m *LogMailer) Send(_ context.Context, message *Message) error {
+	log.Printf("Mail from %s to %s\nSubject: %s\n\n%s\n", m.from, message.To, message.Subject, message.TextBody)
+
+	return nil
+}
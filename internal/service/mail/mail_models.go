@@ -0,0 +1,18 @@
+package mail
+
+// Message is a single email with a plaintext and an HTML alternative.
+type Message struct {
+	To       string
+	Subject  string
+	TextBody string
+	HtmlBody string
+}
+
+func NewMessage(to, subject, textBody, htmlBody string) *Message {
+	return &Message{
+		To:       to,
+		Subject:  subject,
+		TextBody: textBody,
+		HtmlBody: htmlBody,
+	}
+}
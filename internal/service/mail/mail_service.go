@@ -0,0 +1,117 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	netmail "net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/service/config"
+	"github.com/google/uuid"
+)
+
+const (
+	TRANSPORT_SMTP = "smtp"
+	TRANSPORT_FILE = "file"
+	TRANSPORT_LOG  = "log"
+)
+
+var (
+	ErrUnknownTransport = errors.New("unknown mail transport")
+	ErrInvalidAddress   = errors.New("invalid email address")
+)
+
+// Mailer delivers outgoing email. The transport is chosen by configuration so
+// that development and tests do not need a mail server.
+type Mailer interface {
+	Send(ctx context.Context, message *Message) error
+}
+
+func NewMailer(cfg config.MailConfig) (Mailer, error) {
+	from, err := netmail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("%w: from %q: %w", ErrInvalidAddress, cfg.From, err)
+	}
+
+	switch cfg.Transport {
+	case TRANSPORT_SMTP:
+		return NewSmtpMailer(cfg.Smtp, from), nil
+	case TRANSPORT_FILE:
+		return NewFileMailer(cfg.Directory, from), nil
+	case TRANSPORT_LOG, "":
+		return NewLogMailer(from), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownTransport, cfg.Transport)
+	}
+}
+
+// encodeMessage renders the message as multipart/alternative MIME document,
+// ready to be handed to an SMTP server or written to an .eml file.
+func encodeMessage(from *netmail.Address, message *Message, now time.Time) ([]byte, error) {
+	to, err := netmail.ParseAddress(message.To)
+	if err != nil {
+		return nil, fmt.Errorf("%w: to %q: %w", ErrInvalidAddress, message.To, err)
+	}
+
+	var buf bytes.Buffer
+	body := multipart.NewWriter(&buf)
+
+	headers := []struct{ name, value string }{
+		{"From", from.String()},
+		{"To", to.String()},
+		{"Subject", mime.QEncoding.Encode("utf-8", message.Subject)},
+		{"Date", now.Format(time.RFC1123Z)},
+		{"Message-ID", "<" + uuid.New().String() + "@" + domainOf(from.Address) + ">"},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "multipart/alternative; boundary=" + body.Boundary()},
+	}
+	var header bytes.Buffer
+	for _, h := range headers {
+		fmt.Fprintf(&header, "%s: %s\r\n", h.name, h.value)
+	}
+	header.WriteString("\r\n")
+
+	parts := []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", message.TextBody},
+		{"text/html; charset=utf-8", message.HtmlBody},
+	}
+	for _, part := range parts {
+		if part.content == "" {
+			continue
+		}
+		writer, err := body.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create mime part: %w", err)
+		}
+		encoder := quotedprintable.NewWriter(writer)
+		if _, err := encoder.Write([]byte(part.content)); err != nil {
+			return nil, fmt.Errorf("failed to encode mime part: %w", err)
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, fmt.Errorf("failed to encode mime part: %w", err)
+		}
+	}
+	if err := body.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close mime message: %w", err)
+	}
+
+	return append(header.Bytes(), buf.Bytes()...), nil
+}
+
+func domainOf(address string) string {
+	if at := strings.LastIndex(address, "@"); at >= 0 {
+		return address[at+1:]
+	}
+
+	return "localhost"
+}
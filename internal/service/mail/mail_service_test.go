@@ -0,0 +1,268 @@
+//go:build unittest
+
+package mail_test
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	netmail "net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fgeck/gotth-postgres/internal/service/config"
+	"github.com/fgeck/gotth-postgres/internal/service/mail"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	FROM = "gotth-postgres <no-reply@example.com>"
+	TO   = "user@example.com"
+	LINK = "http://localhost:8081/verify-email?token=abc"
+)
+
+func TestNewMailer(t *testing.T) {
+	t.Run("selects the configured transport", func(t *testing.T) {
+		for transport, expected := range map[string]any{
+			"":                  &mail.LogMailer{},
+			mail.TRANSPORT_LOG:  &mail.LogMailer{},
+			mail.TRANSPORT_FILE: &mail.FileMailer{},
+			mail.TRANSPORT_SMTP: &mail.SmtpMailer{},
+		} {
+			mailer, err := mail.NewMailer(config.MailConfig{Transport: transport, From: FROM})
+			require.NoError(t, err)
+			assert.IsType(t, expected, mailer)
+		}
+	})
+
+	t.Run("rejects an unknown transport", func(t *testing.T) {
+		_, err := mail.NewMailer(config.MailConfig{Transport: "pigeon", From: FROM})
+		require.ErrorIs(t, err, mail.ErrUnknownTransport)
+	})
+
+	t.Run("rejects an invalid sender", func(t *testing.T) {
+		_, err := mail.NewMailer(config.MailConfig{Transport: mail.TRANSPORT_LOG, From: "not an address"})
+		require.ErrorIs(t, err, mail.ErrInvalidAddress)
+	})
+}
+
+func TestFileMailerSend(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("writes a multipart message", func(t *testing.T) {
+		directory := filepath.Join(t.TempDir(), "mails")
+		mailer, err := mail.NewMailer(config.MailConfig{Transport: mail.TRANSPORT_FILE, From: FROM, Directory: directory})
+		require.NoError(t, err)
+		message, err := mail.NewVerificationMessage(ctx, TO, LINK)
+		require.NoError(t, err)
+
+		require.NoError(t, mailer.Send(ctx, message))
+
+		files, err := filepath.Glob(filepath.Join(directory, "*.eml"))
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		data, err := os.ReadFile(files[0])
+		require.NoError(t, err)
+		assertMessage(t, string(data), message)
+	})
+
+	t.Run("rejects an invalid recipient", func(t *testing.T) {
+		mailer, err := mail.NewMailer(config.MailConfig{Transport: mail.TRANSPORT_FILE, From: FROM, Directory: t.TempDir()})
+		require.NoError(t, err)
+
+		err = mailer.Send(ctx, mail.NewMessage("not an address", "Subject", "text", ""))
+		require.ErrorIs(t, err, mail.ErrInvalidAddress)
+	})
+}
+
+func TestSmtpMailerSend(t *testing.T) {
+	ctx := context.Background()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	received := make(chan string, 1)
+	go serveSmtp(listener, received)
+
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	mailer, err := mail.NewMailer(config.MailConfig{
+		Transport: mail.TRANSPORT_SMTP,
+		From:      FROM,
+		Smtp:      config.SmtpConfig{Host: host, Port: port},
+	})
+	require.NoError(t, err)
+	message, err := mail.NewPasswordResetMessage(ctx, TO, LINK)
+	require.NoError(t, err)
+
+	require.NoError(t, mailer.Send(ctx, message))
+	assertMessage(t, <-received, message)
+}
+
+func TestMessageTemplates(t *testing.T) {
+	ctx := context.Background()
+
+	for name, build := range map[string]func(context.Context, string, string) (*mail.Message, error){
+		"verification":         mail.NewVerificationMessage,
+		"password reset":       mail.NewPasswordResetMessage,
+		"email change confirm": mail.NewEmailChangeConfirmMessage,
+	} {
+		t.Run(name, func(t *testing.T) {
+			message, err := build(ctx, TO, LINK)
+			require.NoError(t, err)
+
+			assert.Equal(t, TO, message.To)
+			assert.NotEmpty(t, message.Subject)
+			assert.Contains(t, message.TextBody, LINK)
+			assert.Contains(t, message.HtmlBody, `href="http://localhost:8081/verify-email?token=abc"`)
+		})
+	}
+}
+
+func TestAccountExistsMessage(t *testing.T) {
+	loginLink := "http://localhost:8081/login"
+
+	message, err := mail.NewAccountExistsMessage(context.Background(), TO, loginLink, LINK)
+
+	require.NoError(t, err)
+	assert.Contains(t, message.TextBody, loginLink)
+	assert.Contains(t, message.TextBody, LINK)
+	assert.Contains(t, message.HtmlBody, `href="http://localhost:8081/login"`)
+}
+
+func TestEmailChangeNoticeMessage(t *testing.T) {
+	newEmail := "new@example.com"
+
+	message, err := mail.NewEmailChangeNoticeMessage(context.Background(), TO, newEmail, LINK)
+
+	require.NoError(t, err)
+	assert.Contains(t, message.TextBody, newEmail)
+	assert.Contains(t, message.TextBody, LINK)
+	assert.Contains(t, message.HtmlBody, newEmail)
+	assert.Contains(t, message.HtmlBody, `href="http://localhost:8081/verify-email?token=abc"`)
+}
+
+func TestInvitationMessage(t *testing.T) {
+	t.Run("names the organization", func(t *testing.T) {
+		message, err := mail.NewInvitationMessage(context.Background(), TO, "Acme", LINK)
+
+		require.NoError(t, err)
+		assert.Contains(t, message.TextBody, "join Acme")
+		assert.Contains(t, message.TextBody, LINK)
+		assert.Contains(t, message.HtmlBody, "join Acme")
+	})
+
+	t.Run("invites to create an account", func(t *testing.T) {
+		message, err := mail.NewInvitationMessage(context.Background(), TO, "", LINK)
+
+		require.NoError(t, err)
+		assert.Contains(t, message.TextBody, "create an account")
+		assert.Contains(t, message.HtmlBody, `href="http://localhost:8081/verify-email?token=abc"`)
+	})
+}
+
+func TestAccountApprovalMessages(t *testing.T) {
+	t.Run("links to the login", func(t *testing.T) {
+		message, err := mail.NewAccountApprovedMessage(context.Background(), TO, LINK)
+
+		require.NoError(t, err)
+		assert.Contains(t, message.TextBody, LINK)
+		assert.Contains(t, message.HtmlBody, `href="http://localhost:8081/verify-email?token=abc"`)
+	})
+
+	t.Run("tells about the rejection", func(t *testing.T) {
+		message, err := mail.NewAccountRejectedMessage(context.Background(), TO)
+
+		require.NoError(t, err)
+		assert.Equal(t, TO, message.To)
+		assert.Contains(t, message.TextBody, "declined your registration")
+	})
+}
+
+func assertMessage(t *testing.T, raw string, expected *mail.Message) {
+	t.Helper()
+	parsed, err := netmail.ReadMessage(strings.NewReader(raw))
+	require.NoError(t, err)
+
+	from, err := parsed.Header.AddressList("From")
+	require.NoError(t, err)
+	assert.Equal(t, "no-reply@example.com", from[0].Address)
+	to, err := parsed.Header.AddressList("To")
+	require.NoError(t, err)
+	assert.Equal(t, TO, to[0].Address)
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, expected.Subject, subject)
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	bodies := map[string]string{}
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	for {
+		part, err := reader.NextRawPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(quotedprintable.NewReader(part))
+		require.NoError(t, err)
+		contentType, _, err := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		require.NoError(t, err)
+		// SMTP transports line breaks as CRLF.
+		bodies[contentType] = strings.ReplaceAll(string(content), "\r\n", "\n")
+	}
+	assert.Equal(t, expected.TextBody, bodies["text/plain"])
+	assert.Equal(t, expected.HtmlBody, bodies["text/html"])
+}
+
+// serveSmtp accepts a single connection and answers just enough of the SMTP
+// protocol for net/smtp to deliver one message without authentication.
+func serveSmtp(listener net.Listener, received chan<- string) {
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+	reply("220 localhost ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		switch command := strings.ToUpper(strings.TrimSpace(line)); {
+		case strings.HasPrefix(command, "EHLO"):
+			reply("250 localhost")
+		case strings.HasPrefix(command, "DATA"):
+			reply("354 go ahead")
+			var data strings.Builder
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(line, "."))
+			}
+			received <- data.String()
+			reply("250 queued")
+		case strings.HasPrefix(command, "QUIT"):
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
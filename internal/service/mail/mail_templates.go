@@ -0,0 +1,79 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/a-h/templ"
+	"github.com/fgeck/gotth-postgres/templates/emails"
+)
+
+func NewVerificationMessage(ctx context.Context, to, link string) (*Message, error) {
+	return render(ctx, to, "Verify your email address", emails.VerifyEmailHtml(link), emails.VERIFY_EMAIL_TEXT, emails.LinkData{Link: link})
+}
+
+func NewPasswordResetMessage(ctx context.Context, to, link string) (*Message, error) {
+	return render(ctx, to, "Reset your password", emails.PasswordResetHtml(link), emails.PASSWORD_RESET_TEXT, emails.LinkData{Link: link})
+}
+
+func NewAccountExistsMessage(ctx context.Context, to, loginLink, resetLink string) (*Message, error) {
+	return render(
+		ctx,
+		to,
+		"You already have an account",
+		emails.AccountExistsHtml(loginLink, resetLink),
+		emails.ACCOUNT_EXISTS_TEXT,
+		emails.AccountExistsData{LoginLink: loginLink, ResetLink: resetLink},
+	)
+}
+
+func NewEmailChangeConfirmMessage(ctx context.Context, to, link string) (*Message, error) {
+	return render(ctx, to, "Confirm your new email address", emails.EmailChangeConfirmHtml(link), emails.EMAIL_CHANGE_CONFIRM_TEXT, emails.LinkData{Link: link})
+}
+
+func NewEmailChangeNoticeMessage(ctx context.Context, to, newEmail, revertLink string) (*Message, error) {
+	return render(
+		ctx,
+		to,
+		"Your email address is being changed",
+		emails.EmailChangeNoticeHtml(newEmail, revertLink),
+		emails.EMAIL_CHANGE_NOTICE_TEXT,
+		emails.EmailChangeNoticeData{NewEmail: newEmail, RevertLink: revertLink},
+	)
+}
+
+// NewInvitationMessage invites to the organization, or just to create an
+// account if organizationName is empty.
+func NewInvitationMessage(ctx context.Context, to, organizationName, link string) (*Message, error) {
+	return render(
+		ctx,
+		to,
+		"You are invited",
+		emails.InvitationHtml(organizationName, link),
+		emails.INVITATION_TEXT,
+		emails.InvitationData{OrganizationName: organizationName, Link: link},
+	)
+}
+
+func NewAccountApprovedMessage(ctx context.Context, to, loginLink string) (*Message, error) {
+	return render(ctx, to, "Your account was approved", emails.AccountApprovedHtml(loginLink), emails.ACCOUNT_APPROVED_TEXT, emails.LinkData{Link: loginLink})
+}
+
+func NewAccountRejectedMessage(ctx context.Context, to string) (*Message, error) {
+	return render(ctx, to, "Your registration was declined", emails.AccountRejectedHtml(), emails.ACCOUNT_REJECTED_TEXT, nil)
+}
+
+func render(ctx context.Context, to, subject string, html templ.Component, textTemplate string, data any) (*Message, error) {
+	var htmlBody strings.Builder
+	if err := html.Render(ctx, &htmlBody); err != nil {
+		return nil, fmt.Errorf("failed to render html body: %w", err)
+	}
+
+	textBody, err := emails.RenderText(textTemplate, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render text body: %w", err)
+	}
+
+	return NewMessage(to, subject, textBody, htmlBody.String()), nil
+}
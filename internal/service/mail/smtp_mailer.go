@@ -0,0 +1,91 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/service/config"
+)
+
+const SMTP_TIMEOUT = 30 * time.Second
+
+// SmtpMailer submits messages to an SMTP server. STARTTLS is used whenever
+// the server offers it, credentials are only sent if configured.
+type SmtpMailer struct {
+	cfg  config.SmtpConfig
+	from *netmail.Address
+}
+
+func NewSmtpMailer(cfg config.SmtpConfig, from *netmail.Address) *SmtpMailer {
+	return &SmtpMailer{
+		cfg:  cfg,
+		from: from,
+	}
+}
+
+func (m *SmtpMailer) Send(ctx context.Context, message *Message) error {
+	data, err := encodeMessage(m.from, message, time.Now())
+	if err != nil {
+		return err
+	}
+	to, err := netmail.ParseAddress(message.To)
+	if err != nil {
+		return fmt.Errorf("%w: to %q: %w", ErrInvalidAddress, message.To, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, SMTP_TIMEOUT)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(m.cfg.Host, m.cfg.Port))
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return fmt.Errorf("failed to set smtp deadline: %w", err)
+		}
+	}
+
+	client, err := smtp.NewClient(conn, m.cfg.Host)
+	if err != nil {
+		return fmt.Errorf("failed to start smtp session: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.cfg.Host, MinVersion: tls.VersionTLS12}); err != nil {
+			return fmt.Errorf("failed to start tls: %w", err)
+		}
+	}
+	if m.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)); err != nil {
+			return fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+
+	if err := client.Mail(m.from.Address); err != nil {
+		return fmt.Errorf("failed to set sender: %w", err)
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return fmt.Errorf("failed to set recipient: %w", err)
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start message: %w", err)
+	}
+	if _, err := writer.Write(data); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+
+	return client.Quit()
+}
@@ -0,0 +1,21 @@
+package mfa
+
+type MfaEnrollmentDto struct {
+	Secret          string `json:"secret"`
+	ProvisioningUri string `json:"otpauthUri"`
+}
+
+func NewMfaEnrollmentDto(secret, provisioningUri string) *MfaEnrollmentDto {
+	return &MfaEnrollmentDto{
+		Secret:          secret,
+		ProvisioningUri: provisioningUri,
+	}
+}
+
+type RecoveryCodesDto struct {
+	Codes []string `json:"recoveryCodes"`
+}
+
+func NewRecoveryCodesDto(codes []string) *RecoveryCodesDto {
+	return &RecoveryCodesDto{Codes: codes}
+}
@@ -0,0 +1,320 @@
+package mfa
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	"github.com/fgeck/gotth-postgres/internal/service/loginThrottle"
+	"github.com/fgeck/gotth-postgres/internal/service/security/encryption"
+	"github.com/fgeck/gotth-postgres/internal/service/security/totp"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	RECOVERY_CODE_COUNT      = 10
+	RECOVERY_CODE_BYTES      = 10
+	RECOVERY_CODE_GROUP_SIZE = 4
+)
+
+var (
+	ErrMfaAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrMfaNotEnrolled    = errors.New("two-factor authentication is not set up")
+	ErrInvalidMfaCode    = errors.New("invalid two-factor authentication code")
+)
+
+type MfaServiceInterface interface {
+	IsEnabled(ctx context.Context, userID uuid.UUID) (bool, error)
+	BeginEnrollment(ctx context.Context, userID uuid.UUID) (*MfaEnrollmentDto, error)
+	ConfirmEnrollment(ctx context.Context, userID uuid.UUID, code, clientIP string) (*RecoveryCodesDto, error)
+	Verify(ctx context.Context, userID uuid.UUID, code string) error
+	Disable(ctx context.Context, userID uuid.UUID, code, clientIP string) error
+}
+
+type MfaService struct {
+	queries              repository.Querier
+	userService          user.UserServiceInterface
+	totpService          totp.TotpServiceInterface
+	encryptionService    encryption.EncryptionServiceInterface
+	loginThrottleService loginThrottle.LoginThrottleServiceInterface
+	issuer               string
+}
+
+func NewMfaService(
+	queries repository.Querier,
+	userService user.UserServiceInterface,
+	totpService totp.TotpServiceInterface,
+	encryptionService encryption.EncryptionServiceInterface,
+	loginThrottleService loginThrottle.LoginThrottleServiceInterface,
+	issuer string,
+) *MfaService {
+	return &MfaService{
+		queries:              queries,
+		userService:          userService,
+		totpService:          totpService,
+		encryptionService:    encryptionService,
+		loginThrottleService: loginThrottleService,
+		issuer:               issuer,
+	}
+}
+
+func (s *MfaService) IsEnabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	userMfa, err := s.queries.GetUserMfa(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get user mfa: %w", err)
+	}
+
+	return userMfa.EnabledAt.Valid, nil
+}
+
+// BeginEnrollment stores a fresh secret that only becomes active once the
+// user proved with ConfirmEnrollment that the authenticator app works.
+func (s *MfaService) BeginEnrollment(ctx context.Context, userID uuid.UUID) (*MfaEnrollmentDto, error) {
+	enabled, err := s.IsEnabled(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, ErrMfaAlreadyEnabled
+	}
+
+	userDto, err := s.userService.GetUserById(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	secret, err := s.totpService.GenerateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate totp secret: %w", err)
+	}
+
+	encryptedSecret, err := s.encryptionService.Encrypt([]byte(secret))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt totp secret: %w", err)
+	}
+
+	err = s.queries.UpsertPendingUserMfa(
+		ctx,
+		repository.UpsertPendingUserMfaParams{
+			UserID:          pgtype.UUID{Bytes: userID, Valid: true},
+			EncryptedSecret: encryptedSecret,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store totp secret: %w", err)
+	}
+
+	return NewMfaEnrollmentDto(secret, s.totpService.ProvisioningUri(s.issuer, userDto.Email, secret)), nil
+}
+
+func (s *MfaService) ConfirmEnrollment(ctx context.Context, userID uuid.UUID, code, clientIP string) (*RecoveryCodesDto, error) {
+	userMfa, err := s.getUserMfa(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if userMfa.EnabledAt.Valid {
+		return nil, ErrMfaAlreadyEnabled
+	}
+
+	err = s.throttled(ctx, userID, clientIP, func() error {
+		return s.verifyTotp(ctx, userMfa, code)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.queries.EnableUserMfa(ctx, userMfa.UserID); err != nil {
+		return nil, fmt.Errorf("failed to enable mfa: %w", err)
+	}
+
+	return s.regenerateRecoveryCodes(ctx, userMfa.UserID)
+}
+
+// Verify accepts either a current TOTP code or one of the unused recovery codes.
+func (s *MfaService) Verify(ctx context.Context, userID uuid.UUID, code string) error {
+	userMfa, err := s.getUserMfa(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !userMfa.EnabledAt.Valid {
+		return ErrMfaNotEnrolled
+	}
+
+	err = s.verifyTotp(ctx, userMfa, code)
+	if !errors.Is(err, ErrInvalidMfaCode) {
+		return err
+	}
+
+	used, err := s.queries.UseRecoveryCode(
+		ctx,
+		repository.UseRecoveryCodeParams{
+			UserID:   userMfa.UserID,
+			CodeHash: hashRecoveryCode(code),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	if used == 0 {
+		return ErrInvalidMfaCode
+	}
+
+	return nil
+}
+
+func (s *MfaService) Disable(ctx context.Context, userID uuid.UUID, code, clientIP string) error {
+	err := s.throttled(ctx, userID, clientIP, func() error {
+		return s.Verify(ctx, userID, code)
+	})
+	if err != nil {
+		return err
+	}
+
+	pgUserID := pgtype.UUID{Bytes: userID, Valid: true}
+	if err := s.queries.DeleteRecoveryCodes(ctx, pgUserID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	if err := s.queries.DeleteUserMfa(ctx, pgUserID); err != nil {
+		return fmt.Errorf("failed to delete user mfa: %w", err)
+	}
+
+	return nil
+}
+
+// throttled runs verify under the login throttle of the account. Wrong codes
+// count as failed logins like in VerifyMfaLogin, otherwise a signed-in session
+// could guess codes without limit.
+func (s *MfaService) throttled(ctx context.Context, userID uuid.UUID, clientIP string, verify func() error) error {
+	userDto, err := s.userService.GetUserById(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if err := s.loginThrottleService.Check(ctx, userDto.Email, clientIP); err != nil {
+		return err
+	}
+
+	err = verify()
+	if errors.Is(err, ErrInvalidMfaCode) {
+		if throttleErr := s.loginThrottleService.RecordFailure(ctx, userDto.Email, clientIP); throttleErr != nil {
+			return throttleErr
+		}
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.loginThrottleService.Reset(ctx, userDto.Email)
+}
+
+func (s *MfaService) getUserMfa(ctx context.Context, userID uuid.UUID) (repository.UserMfa, error) {
+	userMfa, err := s.queries.GetUserMfa(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if errors.Is(err, sql.ErrNoRows) {
+		return userMfa, ErrMfaNotEnrolled
+	}
+	if err != nil {
+		return userMfa, fmt.Errorf("failed to get user mfa: %w", err)
+	}
+
+	return userMfa, nil
+}
+
+func (s *MfaService) verifyTotp(ctx context.Context, userMfa repository.UserMfa, code string) error {
+	secret, err := s.encryptionService.Decrypt(userMfa.EncryptedSecret)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt totp secret: %w", err)
+	}
+
+	step, err := s.totpService.Validate(string(secret), code, userMfa.LastUsedStep)
+	if errors.Is(err, totp.ErrInvalidCode) || errors.Is(err, totp.ErrCodeReused) {
+		return ErrInvalidMfaCode
+	}
+	if err != nil {
+		return fmt.Errorf("failed to validate totp code: %w", err)
+	}
+
+	// The conditional update makes sure a code is accepted once even when
+	// two requests race with the same code.
+	updated, err := s.queries.UpdateMfaLastUsedStep(
+		ctx,
+		repository.UpdateMfaLastUsedStepParams{
+			UserID:       userMfa.UserID,
+			LastUsedStep: step,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store totp step: %w", err)
+	}
+	if updated == 0 {
+		return ErrInvalidMfaCode
+	}
+
+	return nil
+}
+
+func (s *MfaService) regenerateRecoveryCodes(ctx context.Context, userID pgtype.UUID) (*RecoveryCodesDto, error) {
+	if err := s.queries.DeleteRecoveryCodes(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	codes := make([]string, 0, RECOVERY_CODE_COUNT)
+	for range RECOVERY_CODE_COUNT {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+
+		err = s.queries.CreateRecoveryCode(
+			ctx,
+			repository.CreateRecoveryCodeParams{
+				UserID:   userID,
+				CodeHash: hashRecoveryCode(code),
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to store recovery code: %w", err)
+		}
+
+		codes = append(codes, code)
+	}
+
+	return NewRecoveryCodesDto(codes), nil
+}
+
+// generateRecoveryCode returns 80 random bits formatted as xxxx-xxxx-xxxx-xxxx.
+func generateRecoveryCode() (string, error) {
+	buf := make([]byte, RECOVERY_CODE_BYTES)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	encoded := strings.ToLower(base32.StdEncoding.EncodeToString(buf))
+	groups := make([]string, 0, len(encoded)/RECOVERY_CODE_GROUP_SIZE)
+	for i := 0; i < len(encoded); i += RECOVERY_CODE_GROUP_SIZE {
+		groups = append(groups, encoded[i:i+RECOVERY_CODE_GROUP_SIZE])
+	}
+
+	return strings.Join(groups, "-"), nil
+}
+
+// Recovery codes are random enough for a plain SHA-256, and hashing the
+// normalized form lets users type them with or without dashes.
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+
+	return hex.EncodeToString(sum[:])
+}
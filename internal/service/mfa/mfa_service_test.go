@@ -0,0 +1,340 @@
+//go:build unittest
+
+package mfa_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	repositoryMocks "github.com/fgeck/gotth-postgres/internal/repository/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/loginThrottle"
+	loginThrottleMocks "github.com/fgeck/gotth-postgres/internal/service/loginThrottle/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/mfa"
+	"github.com/fgeck/gotth-postgres/internal/service/security/encryption"
+	"github.com/fgeck/gotth-postgres/internal/service/security/totp"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	userMocks "github.com/fgeck/gotth-postgres/internal/service/user/mocks"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// base32 of the RFC 6238 SHA-1 test secret with its code at NOW_UNIX
+	TOTP_SECRET = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	TOTP_CODE   = "081804"
+	NOW_UNIX    = 1111111109
+	ISSUER      = "test-issuer"
+)
+
+func setupMfaServiceTest(t *testing.T) (
+	*repositoryMocks.MockQuerier,
+	*userMocks.MockUserServiceInterface,
+	*loginThrottleMocks.MockLoginThrottleServiceInterface,
+	*encryption.EncryptionService,
+	*mfa.MfaService,
+) {
+	mockQueries := repositoryMocks.NewMockQuerier(t)
+	mockUserService := userMocks.NewMockUserServiceInterface(t)
+	mockLoginThrottleService := loginThrottleMocks.NewMockLoginThrottleServiceInterface(t)
+	encryptionService, err := encryption.NewEncryptionService("test-encryption-key")
+	require.NoError(t, err)
+	totpService := totp.NewTotpServiceWithClock(func() time.Time { return time.Unix(NOW_UNIX, 0) })
+	mfaService := mfa.NewMfaService(mockQueries, mockUserService, totpService, encryptionService, mockLoginThrottleService, ISSUER)
+	return mockQueries, mockUserService, mockLoginThrottleService, encryptionService, mfaService
+}
+
+func userMfaRow(t *testing.T, encryptionService *encryption.EncryptionService, userID uuid.UUID, enabled bool) repository.UserMfa {
+	t.Helper()
+	encryptedSecret, err := encryptionService.Encrypt([]byte(TOTP_SECRET))
+	require.NoError(t, err)
+
+	return repository.UserMfa{
+		UserID:          pgtype.UUID{Bytes: userID, Valid: true},
+		EncryptedSecret: encryptedSecret,
+		EnabledAt:       pgtype.Timestamptz{Time: time.Now(), Valid: enabled},
+	}
+}
+
+func TestIsEnabled(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	pgUserID := pgtype.UUID{Bytes: userID, Valid: true}
+
+	t.Run("returns false without enrollment", func(t *testing.T) {
+		mockQueries, _, _, _, mfaService := setupMfaServiceTest(t)
+		mockQueries.On("GetUserMfa", ctx, pgUserID).Return(repository.UserMfa{}, sql.ErrNoRows)
+
+		enabled, err := mfaService.IsEnabled(ctx, userID)
+
+		require.NoError(t, err)
+		assert.False(t, enabled)
+	})
+
+	t.Run("returns false for a pending enrollment", func(t *testing.T) {
+		mockQueries, _, _, encryptionService, mfaService := setupMfaServiceTest(t)
+		mockQueries.On("GetUserMfa", ctx, pgUserID).Return(userMfaRow(t, encryptionService, userID, false), nil)
+
+		enabled, err := mfaService.IsEnabled(ctx, userID)
+
+		require.NoError(t, err)
+		assert.False(t, enabled)
+	})
+
+	t.Run("returns true for a confirmed enrollment", func(t *testing.T) {
+		mockQueries, _, _, encryptionService, mfaService := setupMfaServiceTest(t)
+		mockQueries.On("GetUserMfa", ctx, pgUserID).Return(userMfaRow(t, encryptionService, userID, true), nil)
+
+		enabled, err := mfaService.IsEnabled(ctx, userID)
+
+		require.NoError(t, err)
+		assert.True(t, enabled)
+	})
+}
+
+func TestBeginEnrollment(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	pgUserID := pgtype.UUID{Bytes: userID, Valid: true}
+
+	t.Run("stores an encrypted secret and returns the otpauth uri", func(t *testing.T) {
+		mockQueries, mockUserService, _, encryptionService, mfaService := setupMfaServiceTest(t)
+
+		var params repository.UpsertPendingUserMfaParams
+		mockQueries.On("GetUserMfa", ctx, pgUserID).Return(repository.UserMfa{}, sql.ErrNoRows)
+		mockUserService.On("GetUserById", ctx, userID).Return(&user.UserDto{ID: userID, Email: "user@example.com"}, nil)
+		mockQueries.On("UpsertPendingUserMfa", ctx, mock.Anything).
+			Run(func(args mock.Arguments) {
+				params = args.Get(1).(repository.UpsertPendingUserMfaParams)
+			}).
+			Return(nil)
+
+		enrollment, err := mfaService.BeginEnrollment(ctx, userID)
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, enrollment.Secret)
+		assert.Contains(t, enrollment.ProvisioningUri, "otpauth://totp/"+ISSUER+":user@example.com?")
+		assert.NotContains(t, params.EncryptedSecret, enrollment.Secret, "secret must be stored encrypted")
+		decrypted, err := encryptionService.Decrypt(params.EncryptedSecret)
+		require.NoError(t, err)
+		assert.Equal(t, enrollment.Secret, string(decrypted))
+	})
+
+	t.Run("fails when mfa is already enabled", func(t *testing.T) {
+		mockQueries, _, _, encryptionService, mfaService := setupMfaServiceTest(t)
+		mockQueries.On("GetUserMfa", ctx, pgUserID).Return(userMfaRow(t, encryptionService, userID, true), nil)
+
+		enrollment, err := mfaService.BeginEnrollment(ctx, userID)
+
+		require.ErrorIs(t, err, mfa.ErrMfaAlreadyEnabled)
+		assert.Nil(t, enrollment)
+	})
+}
+
+func TestConfirmEnrollment(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	pgUserID := pgtype.UUID{Bytes: userID, Valid: true}
+	email := "user@example.com"
+	clientIP := "192.0.2.1"
+	userDto := &user.UserDto{ID: userID, Email: email}
+
+	t.Run("enables mfa and returns recovery codes", func(t *testing.T) {
+		mockQueries, mockUserService, mockLoginThrottleService, encryptionService, mfaService := setupMfaServiceTest(t)
+
+		mockQueries.On("GetUserMfa", ctx, pgUserID).Return(userMfaRow(t, encryptionService, userID, false), nil)
+		mockUserService.On("GetUserById", ctx, userID).Return(userDto, nil)
+		mockLoginThrottleService.On("Check", ctx, email, clientIP).Return(nil)
+		mockQueries.On("UpdateMfaLastUsedStep", ctx, repository.UpdateMfaLastUsedStepParams{
+			UserID:       pgUserID,
+			LastUsedStep: NOW_UNIX / totp.PERIOD_SECONDS,
+		}).Return(int64(1), nil)
+		mockLoginThrottleService.On("Reset", ctx, email).Return(nil)
+		mockQueries.On("EnableUserMfa", ctx, pgUserID).Return(nil)
+		mockQueries.On("DeleteRecoveryCodes", ctx, pgUserID).Return(nil)
+		storedHashes := map[string]bool{}
+		mockQueries.On("CreateRecoveryCode", ctx, mock.Anything).
+			Run(func(args mock.Arguments) {
+				storedHashes[args.Get(1).(repository.CreateRecoveryCodeParams).CodeHash] = true
+			}).
+			Return(nil)
+
+		recoveryCodes, err := mfaService.ConfirmEnrollment(ctx, userID, TOTP_CODE, clientIP)
+
+		require.NoError(t, err)
+		require.Len(t, recoveryCodes.Codes, mfa.RECOVERY_CODE_COUNT)
+		assert.Len(t, storedHashes, mfa.RECOVERY_CODE_COUNT)
+		for _, code := range recoveryCodes.Codes {
+			assert.Regexp(t, regexp.MustCompile(`^[a-z2-7]{4}(-[a-z2-7]{4}){3}$`), code)
+			assert.False(t, storedHashes[code], "recovery codes must be stored hashed")
+		}
+	})
+
+	t.Run("records a failed attempt for a wrong code", func(t *testing.T) {
+		mockQueries, mockUserService, mockLoginThrottleService, encryptionService, mfaService := setupMfaServiceTest(t)
+		mockQueries.On("GetUserMfa", ctx, pgUserID).Return(userMfaRow(t, encryptionService, userID, false), nil)
+		mockUserService.On("GetUserById", ctx, userID).Return(userDto, nil)
+		mockLoginThrottleService.On("Check", ctx, email, clientIP).Return(nil)
+		mockLoginThrottleService.On("RecordFailure", ctx, email, clientIP).Return(nil)
+
+		recoveryCodes, err := mfaService.ConfirmEnrollment(ctx, userID, "000000", clientIP)
+
+		require.ErrorIs(t, err, mfa.ErrInvalidMfaCode)
+		assert.Nil(t, recoveryCodes)
+		mockQueries.AssertNotCalled(t, "EnableUserMfa", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails while the account is throttled", func(t *testing.T) {
+		mockQueries, mockUserService, mockLoginThrottleService, encryptionService, mfaService := setupMfaServiceTest(t)
+		mockQueries.On("GetUserMfa", ctx, pgUserID).Return(userMfaRow(t, encryptionService, userID, false), nil)
+		mockUserService.On("GetUserById", ctx, userID).Return(userDto, nil)
+		mockLoginThrottleService.On("Check", ctx, email, clientIP).Return(&loginThrottle.ThrottledError{RetryAfter: time.Minute})
+
+		recoveryCodes, err := mfaService.ConfirmEnrollment(ctx, userID, TOTP_CODE, clientIP)
+
+		var throttledErr *loginThrottle.ThrottledError
+		require.ErrorAs(t, err, &throttledErr)
+		assert.Nil(t, recoveryCodes)
+		mockQueries.AssertNotCalled(t, "UpdateMfaLastUsedStep", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails without a pending enrollment", func(t *testing.T) {
+		mockQueries, _, _, _, mfaService := setupMfaServiceTest(t)
+		mockQueries.On("GetUserMfa", ctx, pgUserID).Return(repository.UserMfa{}, sql.ErrNoRows)
+
+		recoveryCodes, err := mfaService.ConfirmEnrollment(ctx, userID, TOTP_CODE, clientIP)
+
+		require.ErrorIs(t, err, mfa.ErrMfaNotEnrolled)
+		assert.Nil(t, recoveryCodes)
+	})
+}
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	pgUserID := pgtype.UUID{Bytes: userID, Valid: true}
+
+	t.Run("accepts a valid totp code", func(t *testing.T) {
+		mockQueries, _, _, encryptionService, mfaService := setupMfaServiceTest(t)
+		mockQueries.On("GetUserMfa", ctx, pgUserID).Return(userMfaRow(t, encryptionService, userID, true), nil)
+		mockQueries.On("UpdateMfaLastUsedStep", ctx, mock.Anything).Return(int64(1), nil)
+
+		err := mfaService.Verify(ctx, userID, TOTP_CODE)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects a totp code raced by another request", func(t *testing.T) {
+		mockQueries, _, _, encryptionService, mfaService := setupMfaServiceTest(t)
+		mockQueries.On("GetUserMfa", ctx, pgUserID).Return(userMfaRow(t, encryptionService, userID, true), nil)
+		mockQueries.On("UpdateMfaLastUsedStep", ctx, mock.Anything).Return(int64(0), nil)
+		mockQueries.On("UseRecoveryCode", ctx, mock.Anything).Return(int64(0), nil)
+
+		err := mfaService.Verify(ctx, userID, TOTP_CODE)
+
+		require.ErrorIs(t, err, mfa.ErrInvalidMfaCode)
+	})
+
+	t.Run("accepts an unused recovery code in any spelling", func(t *testing.T) {
+		mockQueries, _, _, encryptionService, mfaService := setupMfaServiceTest(t)
+		mockQueries.On("GetUserMfa", ctx, pgUserID).Return(userMfaRow(t, encryptionService, userID, true), nil)
+
+		var hashes []string
+		mockQueries.On("UseRecoveryCode", ctx, mock.Anything).
+			Run(func(args mock.Arguments) {
+				hashes = append(hashes, args.Get(1).(repository.UseRecoveryCodeParams).CodeHash)
+			}).
+			Return(int64(1), nil)
+
+		require.NoError(t, mfaService.Verify(ctx, userID, "abcd-efgh-ijkl-mnop"))
+		require.NoError(t, mfaService.Verify(ctx, userID, "ABCD EFGH IJKL MNOP"))
+		require.Len(t, hashes, 2)
+		assert.Equal(t, hashes[0], hashes[1])
+	})
+
+	t.Run("rejects a used recovery code", func(t *testing.T) {
+		mockQueries, _, _, encryptionService, mfaService := setupMfaServiceTest(t)
+		mockQueries.On("GetUserMfa", ctx, pgUserID).Return(userMfaRow(t, encryptionService, userID, true), nil)
+		mockQueries.On("UseRecoveryCode", ctx, mock.Anything).Return(int64(0), nil)
+
+		err := mfaService.Verify(ctx, userID, "abcd-efgh-ijkl-mnop")
+
+		require.ErrorIs(t, err, mfa.ErrInvalidMfaCode)
+	})
+
+	t.Run("fails when mfa is only pending", func(t *testing.T) {
+		mockQueries, _, _, encryptionService, mfaService := setupMfaServiceTest(t)
+		mockQueries.On("GetUserMfa", ctx, pgUserID).Return(userMfaRow(t, encryptionService, userID, false), nil)
+
+		err := mfaService.Verify(ctx, userID, TOTP_CODE)
+
+		require.ErrorIs(t, err, mfa.ErrMfaNotEnrolled)
+	})
+
+	t.Run("fails when database error occurs", func(t *testing.T) {
+		mockQueries, _, _, _, mfaService := setupMfaServiceTest(t)
+		mockQueries.On("GetUserMfa", ctx, pgUserID).Return(repository.UserMfa{}, errors.New("database error"))
+
+		err := mfaService.Verify(ctx, userID, TOTP_CODE)
+
+		require.Error(t, err)
+		assert.Equal(t, "failed to get user mfa: database error", err.Error())
+	})
+}
+
+func TestDisable(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	pgUserID := pgtype.UUID{Bytes: userID, Valid: true}
+	email := "user@example.com"
+	clientIP := "192.0.2.1"
+	userDto := &user.UserDto{ID: userID, Email: email}
+
+	t.Run("removes secret and recovery codes after a valid code", func(t *testing.T) {
+		mockQueries, mockUserService, mockLoginThrottleService, encryptionService, mfaService := setupMfaServiceTest(t)
+		mockUserService.On("GetUserById", ctx, userID).Return(userDto, nil)
+		mockLoginThrottleService.On("Check", ctx, email, clientIP).Return(nil)
+		mockQueries.On("GetUserMfa", ctx, pgUserID).Return(userMfaRow(t, encryptionService, userID, true), nil)
+		mockQueries.On("UpdateMfaLastUsedStep", ctx, mock.Anything).Return(int64(1), nil)
+		mockLoginThrottleService.On("Reset", ctx, email).Return(nil)
+		mockQueries.On("DeleteRecoveryCodes", ctx, pgUserID).Return(nil)
+		mockQueries.On("DeleteUserMfa", ctx, pgUserID).Return(nil)
+
+		err := mfaService.Disable(ctx, userID, TOTP_CODE, clientIP)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("keeps mfa and records a failed attempt for an invalid code", func(t *testing.T) {
+		mockQueries, mockUserService, mockLoginThrottleService, encryptionService, mfaService := setupMfaServiceTest(t)
+		mockUserService.On("GetUserById", ctx, userID).Return(userDto, nil)
+		mockLoginThrottleService.On("Check", ctx, email, clientIP).Return(nil)
+		mockQueries.On("GetUserMfa", ctx, pgUserID).Return(userMfaRow(t, encryptionService, userID, true), nil)
+		mockQueries.On("UseRecoveryCode", ctx, mock.Anything).Return(int64(0), nil)
+		mockLoginThrottleService.On("RecordFailure", ctx, email, clientIP).Return(nil)
+
+		err := mfaService.Disable(ctx, userID, "123456", clientIP)
+
+		require.ErrorIs(t, err, mfa.ErrInvalidMfaCode)
+		mockQueries.AssertNotCalled(t, "DeleteUserMfa", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails while the account is throttled", func(t *testing.T) {
+		mockQueries, mockUserService, mockLoginThrottleService, _, mfaService := setupMfaServiceTest(t)
+		mockUserService.On("GetUserById", ctx, userID).Return(userDto, nil)
+		mockLoginThrottleService.On("Check", ctx, email, clientIP).Return(&loginThrottle.ThrottledError{RetryAfter: time.Minute})
+
+		err := mfaService.Disable(ctx, userID, TOTP_CODE, clientIP)
+
+		var throttledErr *loginThrottle.ThrottledError
+		require.ErrorAs(t, err, &throttledErr)
+		mockQueries.AssertNotCalled(t, "GetUserMfa", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,63 @@
+package organization
+
+import (
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	MIN_NAME_LENGTH = 2
+	MAX_NAME_LENGTH = 100
+)
+
+type OrganizationDto struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func NewOrganizationDto(organization repository.Organization) *OrganizationDto {
+	return &OrganizationDto{
+		ID:        uuid.UUID(organization.ID.Bytes),
+		Name:      organization.Name,
+		CreatedAt: organization.CreatedAt.Time,
+	}
+}
+
+// MembershipDto is an organization as seen by one of its members.
+type MembershipDto struct {
+	OrganizationID   uuid.UUID `json:"organizationId"`
+	OrganizationName string    `json:"organizationName"`
+	Role             string    `json:"role"`
+	JoinedAt         time.Time `json:"joinedAt"`
+}
+
+func NewMembershipDto(membership repository.GetMembershipRow) *MembershipDto {
+	return &MembershipDto{
+		OrganizationID:   uuid.UUID(membership.OrganizationID.Bytes),
+		OrganizationName: membership.OrganizationName,
+		Role:             membership.RoleName,
+		JoinedAt:         membership.CreatedAt.Time,
+	}
+}
+
+// MemberDto is a member as seen by the other members of an organization.
+type MemberDto struct {
+	UserID   uuid.UUID `json:"userId"`
+	Username string    `json:"username"`
+	Email    string    `json:"email"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joinedAt"`
+}
+
+func NewMemberDto(member repository.ListOrganizationMembersRow) *MemberDto {
+	return &MemberDto{
+		UserID:   uuid.UUID(member.UserID.Bytes),
+		Username: member.Username,
+		Email:    member.Email,
+		Role:     member.RoleName,
+		JoinedAt: member.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,400 @@
+package organization
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	"github.com/fgeck/gotth-postgres/internal/service/rbac"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrOrganizationNotFound = errors.New("organization not found")
+	ErrInvalidName          = fmt.Errorf("organization names have %d to %d characters", MIN_NAME_LENGTH, MAX_NAME_LENGTH)
+	ErrNotMember            = errors.New("user is not a member of the organization")
+	ErrAlreadyMember        = errors.New("user is already a member of the organization")
+	ErrUnknownRole          = errors.New("unknown role")
+	ErrLastOwner            = errors.New("an organization needs at least one owner")
+	ErrNoTenant             = errors.New("no active organization")
+)
+
+// WithTenant returns a context that scopes the tenant methods of the
+// OrganizationService to the given organization. Queries of a
+// repository.TenantQuerier cannot reach other organizations with it.
+func WithTenant(ctx context.Context, organizationID uuid.UUID) context.Context {
+	return repository.WithTenant(ctx, organizationID)
+}
+
+// TenantFromContext returns the organization set by WithTenant.
+func TenantFromContext(ctx context.Context) (uuid.UUID, bool) {
+	return repository.TenantFromContext(ctx)
+}
+
+type OrganizationServiceInterface interface {
+	CreateOrganization(ctx context.Context, ownerID uuid.UUID, name string) (*OrganizationDto, error)
+	ListMemberships(ctx context.Context, userID uuid.UUID) ([]*MembershipDto, error)
+	GetMembership(ctx context.Context, organizationID, userID uuid.UUID) (*MembershipDto, error)
+	LoadTenant(ctx context.Context, userDto *user.UserDto, organizationID uuid.UUID) error
+	GetTenant(ctx context.Context) (*OrganizationDto, error)
+	RenameTenant(ctx context.Context, name string) (*OrganizationDto, error)
+	ListMembers(ctx context.Context) ([]*MemberDto, error)
+	AddMember(ctx context.Context, email, role string) (*MemberDto, error)
+	UpdateMemberRole(ctx context.Context, userID uuid.UUID, role string) error
+	RemoveMember(ctx context.Context, userID uuid.UUID) error
+}
+
+// OrganizationService manages organizations and their members. The tenant
+// methods take the organization from the context, see WithTenant, so they
+// cannot reach into other organizations.
+type OrganizationService struct {
+	queries     repository.Querier
+	userService user.UserServiceInterface
+}
+
+func NewOrganizationService(queries repository.Querier, userService user.UserServiceInterface) *OrganizationService {
+	return &OrganizationService{
+		queries:     queries,
+		userService: userService,
+	}
+}
+
+// CreateOrganization makes the creator its first owner.
+func (s *OrganizationService) CreateOrganization(ctx context.Context, ownerID uuid.UUID, name string) (*OrganizationDto, error) {
+	name, err := validateName(name)
+	if err != nil {
+		return nil, err
+	}
+	ownerRole, err := s.getMembershipRole(ctx, rbac.ROLE_ORG_OWNER)
+	if err != nil {
+		return nil, err
+	}
+
+	organization, err := s.queries.CreateOrganization(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create organization: %w", err)
+	}
+	err = s.queries.CreateMembership(
+		ctx,
+		repository.CreateMembershipParams{
+			OrganizationID: organization.ID,
+			UserID:         pgtype.UUID{Bytes: ownerID, Valid: true},
+			RoleID:         ownerRole.ID,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add owner: %w", err)
+	}
+
+	return NewOrganizationDto(organization), nil
+}
+
+func (s *OrganizationService) ListMemberships(ctx context.Context, userID uuid.UUID) ([]*MembershipDto, error) {
+	memberships, err := s.queries.ListUserMemberships(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list memberships: %w", err)
+	}
+
+	dtos := make([]*MembershipDto, 0, len(memberships))
+	for _, membership := range memberships {
+		dtos = append(dtos, NewMembershipDto(repository.GetMembershipRow(membership)))
+	}
+
+	return dtos, nil
+}
+
+func (s *OrganizationService) GetMembership(ctx context.Context, organizationID, userID uuid.UUID) (*MembershipDto, error) {
+	membership, err := s.queries.GetMembership(
+		ctx,
+		repository.GetMembershipParams{
+			OrganizationID: pgtype.UUID{Bytes: organizationID, Valid: true},
+			UserID:         pgtype.UUID{Bytes: userID, Valid: true},
+		},
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotMember
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get membership: %w", err)
+	}
+
+	return NewMembershipDto(membership), nil
+}
+
+// LoadTenant lets the user act in the organization. Tenant permissions only
+// ever come from the membership role, the loaded account permissions keep
+// everything else.
+func (s *OrganizationService) LoadTenant(ctx context.Context, userDto *user.UserDto, organizationID uuid.UUID) error {
+	membership, err := s.GetMembership(ctx, organizationID, userDto.ID)
+	if err != nil {
+		return err
+	}
+	permissions, err := s.queries.ListMembershipPermissionNames(
+		ctx,
+		repository.ListMembershipPermissionNamesParams{
+			OrganizationID: pgtype.UUID{Bytes: organizationID, Valid: true},
+			UserID:         pgtype.UUID{Bytes: userDto.ID, Valid: true},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to list membership permissions: %w", err)
+	}
+
+	merged := slices.DeleteFunc(slices.Clone(userDto.Permissions), func(permission string) bool {
+		return slices.Contains(rbac.TENANT_PERMISSIONS, permission)
+	})
+	for _, permission := range permissions {
+		if slices.Contains(rbac.TENANT_PERMISSIONS, permission) {
+			merged = append(merged, permission)
+		}
+	}
+	slices.Sort(merged)
+
+	userDto.Permissions = slices.Compact(merged)
+	userDto.TenantID = organizationID
+	userDto.TenantRole = membership.Role
+
+	return nil
+}
+
+func (s *OrganizationService) GetTenant(ctx context.Context) (*OrganizationDto, error) {
+	organizationID, err := tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	organization, err := s.queries.GetOrganizationById(ctx, organizationID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrOrganizationNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	return NewOrganizationDto(organization), nil
+}
+
+func (s *OrganizationService) RenameTenant(ctx context.Context, name string) (*OrganizationDto, error) {
+	organizationID, err := tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name, err = validateName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	organization, err := s.queries.RenameOrganization(
+		ctx,
+		repository.RenameOrganizationParams{
+			ID:   organizationID,
+			Name: name,
+		},
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrOrganizationNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to rename organization: %w", err)
+	}
+
+	return NewOrganizationDto(organization), nil
+}
+
+func (s *OrganizationService) ListMembers(ctx context.Context) ([]*MemberDto, error) {
+	organizationID, err := tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.queries.ListOrganizationMembers(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+
+	dtos := make([]*MemberDto, 0, len(members))
+	for _, member := range members {
+		dtos = append(dtos, NewMemberDto(member))
+	}
+
+	return dtos, nil
+}
+
+// AddMember adds an existing account to the organization.
+func (s *OrganizationService) AddMember(ctx context.Context, email, role string) (*MemberDto, error) {
+	organizationID, err := tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	memberRole, err := s.getMembershipRole(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+	userDto, err := s.userService.GetUserByEmail(ctx, strings.TrimSpace(email))
+	if err != nil {
+		return nil, err
+	}
+
+	userID := pgtype.UUID{Bytes: userDto.ID, Valid: true}
+	_, err = s.queries.GetMembership(ctx, repository.GetMembershipParams{OrganizationID: organizationID, UserID: userID})
+	if err == nil {
+		return nil, ErrAlreadyMember
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get membership: %w", err)
+	}
+
+	err = s.queries.CreateMembership(
+		ctx,
+		repository.CreateMembershipParams{
+			OrganizationID: organizationID,
+			UserID:         userID,
+			RoleID:         memberRole.ID,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add member: %w", err)
+	}
+
+	return &MemberDto{
+		UserID:   userDto.ID,
+		Username: userDto.Username,
+		Email:    userDto.Email,
+		Role:     memberRole.Name,
+		JoinedAt: time.Now(),
+	}, nil
+}
+
+// UpdateMemberRole takes effect for the member with their next token refresh.
+func (s *OrganizationService) UpdateMemberRole(ctx context.Context, userID uuid.UUID, role string) error {
+	organizationID, err := tenant(ctx)
+	if err != nil {
+		return err
+	}
+	memberRole, err := s.getMembershipRole(ctx, role)
+	if err != nil {
+		return err
+	}
+	if memberRole.Name != rbac.ROLE_ORG_OWNER {
+		if err := s.keepAnOwner(ctx, organizationID, userID); err != nil {
+			return err
+		}
+	}
+
+	updated, err := s.queries.UpdateMembershipRole(
+		ctx,
+		repository.UpdateMembershipRoleParams{
+			OrganizationID: organizationID,
+			UserID:         pgtype.UUID{Bytes: userID, Valid: true},
+			RoleID:         memberRole.ID,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update member role: %w", err)
+	}
+	if updated == 0 {
+		return ErrNotMember
+	}
+
+	return nil
+}
+
+func (s *OrganizationService) RemoveMember(ctx context.Context, userID uuid.UUID) error {
+	organizationID, err := tenant(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.keepAnOwner(ctx, organizationID, userID); err != nil {
+		return err
+	}
+
+	deleted, err := s.queries.DeleteMembership(
+		ctx,
+		repository.DeleteMembershipParams{
+			OrganizationID: organizationID,
+			UserID:         pgtype.UUID{Bytes: userID, Valid: true},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to remove member: %w", err)
+	}
+	if deleted == 0 {
+		return ErrNotMember
+	}
+
+	return nil
+}
+
+// keepAnOwner fails if the user is the last owner of the organization, which
+// must not lose that role.
+func (s *OrganizationService) keepAnOwner(ctx context.Context, organizationID pgtype.UUID, userID uuid.UUID) error {
+	membership, err := s.GetMembership(ctx, uuid.UUID(organizationID.Bytes), userID)
+	if err != nil {
+		return err
+	}
+	if membership.Role != rbac.ROLE_ORG_OWNER {
+		return nil
+	}
+
+	owners, err := s.queries.CountOrganizationMembersWithRole(
+		ctx,
+		repository.CountOrganizationMembersWithRoleParams{
+			OrganizationID: organizationID,
+			Name:           rbac.ROLE_ORG_OWNER,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to count owners: %w", err)
+	}
+	if owners <= 1 {
+		return ErrLastOwner
+	}
+
+	return nil
+}
+
+// getMembershipRole does not accept the base roles of accounts, which mean
+// nothing inside of an organization.
+func (s *OrganizationService) getMembershipRole(ctx context.Context, name string) (repository.Role, error) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if name == user.UserRoleUser.Name || name == user.UserRoleAdmin.Name {
+		return repository.Role{}, ErrUnknownRole
+	}
+
+	role, err := s.queries.GetRoleByName(ctx, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.Role{}, ErrUnknownRole
+	}
+	if err != nil {
+		return repository.Role{}, fmt.Errorf("failed to get role: %w", err)
+	}
+
+	return role, nil
+}
+
+func tenant(ctx context.Context) (pgtype.UUID, error) {
+	organizationID, ok := TenantFromContext(ctx)
+	if !ok {
+		return pgtype.UUID{}, ErrNoTenant
+	}
+
+	return pgtype.UUID{Bytes: organizationID, Valid: true}, nil
+}
+
+func validateName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	length := utf8.RuneCountInString(name)
+	if length < MIN_NAME_LENGTH || length > MAX_NAME_LENGTH {
+		return "", ErrInvalidName
+	}
+
+	return name, nil
+}
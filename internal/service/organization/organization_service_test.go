@@ -0,0 +1,253 @@
+//go:build unittest
+
+package organization_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	repositoryMocks "github.com/fgeck/gotth-postgres/internal/repository/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/organization"
+	"github.com/fgeck/gotth-postgres/internal/service/rbac"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	userMocks "github.com/fgeck/gotth-postgres/internal/service/user/mocks"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ORGANIZATION_NAME = "Acme"
+	MEMBER_EMAIL      = "member@example.com"
+)
+
+type organizationServiceMocks struct {
+	queries     *repositoryMocks.MockQuerier
+	userService *userMocks.MockUserServiceInterface
+}
+
+func setupOrganizationServiceTest(t *testing.T) (*organizationServiceMocks, *organization.OrganizationService) {
+	mocks := &organizationServiceMocks{
+		queries:     repositoryMocks.NewMockQuerier(t),
+		userService: userMocks.NewMockUserServiceInterface(t),
+	}
+	service := organization.NewOrganizationService(mocks.queries, mocks.userService)
+	return mocks, service
+}
+
+func newRole(name string) repository.Role {
+	return repository.Role{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, Name: name}
+}
+
+func TestTenantFromContext(t *testing.T) {
+	organizationID := uuid.New()
+
+	tenantID, ok := organization.TenantFromContext(organization.WithTenant(context.Background(), organizationID))
+	assert.True(t, ok)
+	assert.Equal(t, organizationID, tenantID)
+
+	_, ok = organization.TenantFromContext(context.Background())
+	assert.False(t, ok)
+}
+
+func TestCreateOrganization(t *testing.T) {
+	ctx := context.Background()
+	ownerID := uuid.New()
+
+	t.Run("makes the creator the owner", func(t *testing.T) {
+		mocks, service := setupOrganizationServiceTest(t)
+		ownerRole := newRole(rbac.ROLE_ORG_OWNER)
+		created := repository.Organization{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, Name: ORGANIZATION_NAME}
+
+		mocks.queries.On("GetRoleByName", ctx, rbac.ROLE_ORG_OWNER).Return(ownerRole, nil)
+		mocks.queries.On("CreateOrganization", ctx, ORGANIZATION_NAME).Return(created, nil)
+		mocks.queries.On("CreateMembership", ctx, repository.CreateMembershipParams{
+			OrganizationID: created.ID,
+			UserID:         pgtype.UUID{Bytes: ownerID, Valid: true},
+			RoleID:         ownerRole.ID,
+		}).Return(nil)
+
+		dto, err := service.CreateOrganization(ctx, ownerID, "  "+ORGANIZATION_NAME+" ")
+
+		require.NoError(t, err)
+		assert.Equal(t, ORGANIZATION_NAME, dto.Name)
+	})
+
+	t.Run("rejects a too short name", func(t *testing.T) {
+		_, service := setupOrganizationServiceTest(t)
+
+		_, err := service.CreateOrganization(ctx, ownerID, "A")
+
+		require.ErrorIs(t, err, organization.ErrInvalidName)
+	})
+}
+
+func TestLoadTenant(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	organizationID := uuid.New()
+	membershipParams := repository.GetMembershipParams{
+		OrganizationID: pgtype.UUID{Bytes: organizationID, Valid: true},
+		UserID:         pgtype.UUID{Bytes: userID, Valid: true},
+	}
+
+	t.Run("adds only tenant permissions", func(t *testing.T) {
+		mocks, service := setupOrganizationServiceTest(t)
+
+		mocks.queries.On("GetMembership", ctx, membershipParams).Return(repository.GetMembershipRow{RoleName: "CUSTOM"}, nil)
+		mocks.queries.On("ListMembershipPermissionNames", ctx, repository.ListMembershipPermissionNamesParams(membershipParams)).
+			Return([]string{rbac.PERMISSION_MEMBERS_READ, rbac.PERMISSION_USERS_WRITE}, nil)
+
+		userDto := &user.UserDto{ID: userID, Permissions: []string{rbac.PERMISSION_MEMBERS_WRITE, rbac.PERMISSION_USERS_READ}}
+		require.NoError(t, service.LoadTenant(ctx, userDto, organizationID))
+
+		assert.Equal(t, organizationID, userDto.TenantID)
+		assert.Equal(t, "CUSTOM", userDto.TenantRole)
+		assert.Equal(t, []string{rbac.PERMISSION_MEMBERS_READ, rbac.PERMISSION_USERS_READ}, userDto.Permissions)
+	})
+
+	t.Run("fails for non members", func(t *testing.T) {
+		mocks, service := setupOrganizationServiceTest(t)
+
+		mocks.queries.On("GetMembership", ctx, membershipParams).Return(repository.GetMembershipRow{}, sql.ErrNoRows)
+
+		userDto := &user.UserDto{ID: userID}
+		require.ErrorIs(t, service.LoadTenant(ctx, userDto, organizationID), organization.ErrNotMember)
+		assert.Equal(t, uuid.Nil, userDto.TenantID)
+	})
+}
+
+func TestAddMember(t *testing.T) {
+	organizationID := uuid.New()
+	pgOrganizationID := pgtype.UUID{Bytes: organizationID, Valid: true}
+	ctx := organization.WithTenant(context.Background(), organizationID)
+	memberID := uuid.New()
+	pgMemberID := pgtype.UUID{Bytes: memberID, Valid: true}
+	memberRole := newRole(rbac.ROLE_ORG_MEMBER)
+	membershipParams := repository.GetMembershipParams{OrganizationID: pgOrganizationID, UserID: pgMemberID}
+
+	t.Run("adds an existing account", func(t *testing.T) {
+		mocks, service := setupOrganizationServiceTest(t)
+
+		mocks.queries.On("GetRoleByName", ctx, rbac.ROLE_ORG_MEMBER).Return(memberRole, nil)
+		mocks.userService.On("GetUserByEmail", ctx, MEMBER_EMAIL).Return(&user.UserDto{ID: memberID, Email: MEMBER_EMAIL}, nil)
+		mocks.queries.On("GetMembership", ctx, membershipParams).Return(repository.GetMembershipRow{}, sql.ErrNoRows)
+		mocks.queries.On("CreateMembership", ctx, repository.CreateMembershipParams{
+			OrganizationID: pgOrganizationID,
+			UserID:         pgMemberID,
+			RoleID:         memberRole.ID,
+		}).Return(nil)
+
+		member, err := service.AddMember(ctx, MEMBER_EMAIL, "org_member")
+
+		require.NoError(t, err)
+		assert.Equal(t, memberID, member.UserID)
+		assert.Equal(t, rbac.ROLE_ORG_MEMBER, member.Role)
+	})
+
+	t.Run("rejects members", func(t *testing.T) {
+		mocks, service := setupOrganizationServiceTest(t)
+
+		mocks.queries.On("GetRoleByName", ctx, rbac.ROLE_ORG_MEMBER).Return(memberRole, nil)
+		mocks.userService.On("GetUserByEmail", ctx, MEMBER_EMAIL).Return(&user.UserDto{ID: memberID, Email: MEMBER_EMAIL}, nil)
+		mocks.queries.On("GetMembership", ctx, membershipParams).Return(repository.GetMembershipRow{}, nil)
+
+		_, err := service.AddMember(ctx, MEMBER_EMAIL, rbac.ROLE_ORG_MEMBER)
+
+		require.ErrorIs(t, err, organization.ErrAlreadyMember)
+	})
+
+	t.Run("rejects base roles", func(t *testing.T) {
+		_, service := setupOrganizationServiceTest(t)
+
+		_, err := service.AddMember(ctx, MEMBER_EMAIL, user.UserRoleAdmin.Name)
+
+		require.ErrorIs(t, err, organization.ErrUnknownRole)
+	})
+
+	t.Run("needs a tenant", func(t *testing.T) {
+		_, service := setupOrganizationServiceTest(t)
+
+		_, err := service.AddMember(context.Background(), MEMBER_EMAIL, rbac.ROLE_ORG_MEMBER)
+
+		require.ErrorIs(t, err, organization.ErrNoTenant)
+	})
+}
+
+func TestRemoveMember(t *testing.T) {
+	organizationID := uuid.New()
+	pgOrganizationID := pgtype.UUID{Bytes: organizationID, Valid: true}
+	ctx := organization.WithTenant(context.Background(), organizationID)
+	memberID := uuid.New()
+	pgMemberID := pgtype.UUID{Bytes: memberID, Valid: true}
+	membershipParams := repository.GetMembershipParams{OrganizationID: pgOrganizationID, UserID: pgMemberID}
+	ownersParams := repository.CountOrganizationMembersWithRoleParams{OrganizationID: pgOrganizationID, Name: rbac.ROLE_ORG_OWNER}
+
+	t.Run("removes a member", func(t *testing.T) {
+		mocks, service := setupOrganizationServiceTest(t)
+
+		mocks.queries.On("GetMembership", ctx, membershipParams).Return(repository.GetMembershipRow{RoleName: rbac.ROLE_ORG_MEMBER}, nil)
+		mocks.queries.On("DeleteMembership", ctx, repository.DeleteMembershipParams(membershipParams)).Return(int64(1), nil)
+
+		require.NoError(t, service.RemoveMember(ctx, memberID))
+	})
+
+	t.Run("removes one of several owners", func(t *testing.T) {
+		mocks, service := setupOrganizationServiceTest(t)
+
+		mocks.queries.On("GetMembership", ctx, membershipParams).Return(repository.GetMembershipRow{RoleName: rbac.ROLE_ORG_OWNER}, nil)
+		mocks.queries.On("CountOrganizationMembersWithRole", ctx, ownersParams).Return(int64(2), nil)
+		mocks.queries.On("DeleteMembership", ctx, repository.DeleteMembershipParams(membershipParams)).Return(int64(1), nil)
+
+		require.NoError(t, service.RemoveMember(ctx, memberID))
+	})
+
+	t.Run("keeps the last owner", func(t *testing.T) {
+		mocks, service := setupOrganizationServiceTest(t)
+
+		mocks.queries.On("GetMembership", ctx, membershipParams).Return(repository.GetMembershipRow{RoleName: rbac.ROLE_ORG_OWNER}, nil)
+		mocks.queries.On("CountOrganizationMembersWithRole", ctx, ownersParams).Return(int64(1), nil)
+
+		require.ErrorIs(t, service.RemoveMember(ctx, memberID), organization.ErrLastOwner)
+		mocks.queries.AssertNotCalled(t, "DeleteMembership", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdateMemberRole(t *testing.T) {
+	organizationID := uuid.New()
+	pgOrganizationID := pgtype.UUID{Bytes: organizationID, Valid: true}
+	ctx := organization.WithTenant(context.Background(), organizationID)
+	memberID := uuid.New()
+	pgMemberID := pgtype.UUID{Bytes: memberID, Valid: true}
+	membershipParams := repository.GetMembershipParams{OrganizationID: pgOrganizationID, UserID: pgMemberID}
+
+	t.Run("demotes one of several owners", func(t *testing.T) {
+		mocks, service := setupOrganizationServiceTest(t)
+		memberRole := newRole(rbac.ROLE_ORG_MEMBER)
+
+		mocks.queries.On("GetRoleByName", ctx, rbac.ROLE_ORG_MEMBER).Return(memberRole, nil)
+		mocks.queries.On("GetMembership", ctx, membershipParams).Return(repository.GetMembershipRow{RoleName: rbac.ROLE_ORG_OWNER}, nil)
+		mocks.queries.On("CountOrganizationMembersWithRole", ctx, mock.Anything).Return(int64(2), nil)
+		mocks.queries.On("UpdateMembershipRole", ctx, repository.UpdateMembershipRoleParams{
+			OrganizationID: pgOrganizationID,
+			UserID:         pgMemberID,
+			RoleID:         memberRole.ID,
+		}).Return(int64(1), nil)
+
+		require.NoError(t, service.UpdateMemberRole(ctx, memberID, rbac.ROLE_ORG_MEMBER))
+	})
+
+	t.Run("fails for non members", func(t *testing.T) {
+		mocks, service := setupOrganizationServiceTest(t)
+		ownerRole := newRole(rbac.ROLE_ORG_OWNER)
+
+		mocks.queries.On("GetRoleByName", ctx, rbac.ROLE_ORG_OWNER).Return(ownerRole, nil)
+		mocks.queries.On("UpdateMembershipRole", ctx, mock.Anything).Return(int64(0), nil)
+
+		require.ErrorIs(t, service.UpdateMemberRole(ctx, memberID, rbac.ROLE_ORG_OWNER), organization.ErrNotMember)
+	})
+}
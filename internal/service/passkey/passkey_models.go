@@ -0,0 +1,57 @@
+package passkey
+
+import (
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	"github.com/fgeck/gotth-postgres/internal/service/security/webauthn"
+	"github.com/google/uuid"
+)
+
+type PasskeyDto struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+}
+
+func NewPasskeyDto(credential repository.WebauthnCredential) *PasskeyDto {
+	dto := &PasskeyDto{
+		ID:        uuid.UUID(credential.ID.Bytes),
+		Name:      credential.Name,
+		CreatedAt: credential.CreatedAt.Time,
+	}
+	if credential.LastUsedAt.Valid {
+		lastUsedAt := credential.LastUsedAt.Time
+		dto.LastUsedAt = &lastUsedAt
+	}
+
+	return dto
+}
+
+// The ceremony id references the stored challenge. It is handed to the client
+// in a cookie and has to be sent back to finish the ceremony.
+
+type RegistrationCeremonyDto struct {
+	CeremonyID uuid.UUID
+	Options    *webauthn.CredentialCreationOptions
+}
+
+func NewRegistrationCeremonyDto(ceremonyID uuid.UUID, options *webauthn.CredentialCreationOptions) *RegistrationCeremonyDto {
+	return &RegistrationCeremonyDto{
+		CeremonyID: ceremonyID,
+		Options:    options,
+	}
+}
+
+type LoginCeremonyDto struct {
+	CeremonyID uuid.UUID
+	Options    *webauthn.CredentialRequestOptions
+}
+
+func NewLoginCeremonyDto(ceremonyID uuid.UUID, options *webauthn.CredentialRequestOptions) *LoginCeremonyDto {
+	return &LoginCeremonyDto{
+		CeremonyID: ceremonyID,
+		Options:    options,
+	}
+}
@@ -0,0 +1,293 @@
+package passkey
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	"github.com/fgeck/gotth-postgres/internal/service/security/webauthn"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	CEREMONY_REGISTRATION = "registration"
+	CEREMONY_LOGIN        = "login"
+
+	DEFAULT_PASSKEY_NAME    = "Passkey"
+	PASSKEY_NAME_MAX_LENGTH = 64
+)
+
+var (
+	ErrCeremonyNotFound         = errors.New("passkey ceremony not found or expired")
+	ErrPasskeyNotFound          = errors.New("passkey not found")
+	ErrPasskeyAlreadyRegistered = errors.New("passkey is already registered")
+	ErrPasskeyNameTooLong       = errors.New("passkey name is too long")
+	ErrUserHandleMismatch       = errors.New("passkey does not belong to the returned user handle")
+)
+
+type PasskeyServiceInterface interface {
+	BeginRegistration(ctx context.Context, userID uuid.UUID) (*RegistrationCeremonyDto, error)
+	FinishRegistration(
+		ctx context.Context,
+		userID uuid.UUID,
+		ceremonyID uuid.UUID,
+		name string,
+		response *webauthn.RegistrationResponse,
+	) (*PasskeyDto, error)
+	BeginLogin(ctx context.Context) (*LoginCeremonyDto, error)
+	FinishLogin(ctx context.Context, ceremonyID uuid.UUID, response *webauthn.AuthenticationResponse) (uuid.UUID, error)
+	ListPasskeys(ctx context.Context, userID uuid.UUID) ([]*PasskeyDto, error)
+	DeletePasskey(ctx context.Context, userID, passkeyID uuid.UUID) error
+}
+
+type PasskeyService struct {
+	queries         repository.Querier
+	userService     user.UserServiceInterface
+	webauthnService webauthn.WebauthnServiceInterface
+}
+
+func NewPasskeyService(
+	queries repository.Querier,
+	userService user.UserServiceInterface,
+	webauthnService webauthn.WebauthnServiceInterface,
+) *PasskeyService {
+	return &PasskeyService{
+		queries:         queries,
+		userService:     userService,
+		webauthnService: webauthnService,
+	}
+}
+
+func (s *PasskeyService) BeginRegistration(ctx context.Context, userID uuid.UUID) (*RegistrationCeremonyDto, error) {
+	userDto, err := s.userService.GetUserById(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	credentials, err := s.queries.ListWebauthnCredentialsByUserId(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list passkeys: %w", err)
+	}
+	// Keeps the authenticator from registering a second credential for the
+	// same account.
+	excludeCredentialIDs := make([][]byte, 0, len(credentials))
+	for _, credential := range credentials {
+		excludeCredentialIDs = append(excludeCredentialIDs, credential.CredentialID)
+	}
+
+	challenge, err := s.createChallenge(ctx, pgtype.UUID{Bytes: userID, Valid: true}, CEREMONY_REGISTRATION)
+	if err != nil {
+		return nil, err
+	}
+
+	options := s.webauthnService.CreationOptions(
+		challenge.Challenge,
+		webauthn.NewUserEntity(userID[:], userDto.Email, userDto.Username),
+		excludeCredentialIDs,
+	)
+
+	return NewRegistrationCeremonyDto(uuid.UUID(challenge.ID.Bytes), options), nil
+}
+
+func (s *PasskeyService) FinishRegistration(
+	ctx context.Context,
+	userID uuid.UUID,
+	ceremonyID uuid.UUID,
+	name string,
+	response *webauthn.RegistrationResponse,
+) (*PasskeyDto, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = DEFAULT_PASSKEY_NAME
+	}
+	if len(name) > PASSKEY_NAME_MAX_LENGTH {
+		return nil, ErrPasskeyNameTooLong
+	}
+
+	challenge, err := s.consumeChallenge(ctx, ceremonyID, CEREMONY_REGISTRATION)
+	if err != nil {
+		return nil, err
+	}
+	if challenge.UserID.Bytes != userID {
+		return nil, ErrCeremonyNotFound
+	}
+
+	verified, err := s.webauthnService.VerifyRegistration(response, challenge.Challenge)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify passkey registration: %w", err)
+	}
+
+	_, err = s.queries.GetWebauthnCredentialByCredentialId(ctx, verified.CredentialID)
+	if err == nil {
+		return nil, ErrPasskeyAlreadyRegistered
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get passkey: %w", err)
+	}
+
+	credential, err := s.queries.CreateWebauthnCredential(
+		ctx,
+		repository.CreateWebauthnCredentialParams{
+			UserID:       pgtype.UUID{Bytes: userID, Valid: true},
+			CredentialID: verified.CredentialID,
+			PublicKey:    verified.PublicKey,
+			SignCount:    int64(verified.SignCount),
+			Name:         name,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store passkey: %w", err)
+	}
+
+	return NewPasskeyDto(credential), nil
+}
+
+func (s *PasskeyService) BeginLogin(ctx context.Context) (*LoginCeremonyDto, error) {
+	challenge, err := s.createChallenge(ctx, pgtype.UUID{}, CEREMONY_LOGIN)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewLoginCeremonyDto(uuid.UUID(challenge.ID.Bytes), s.webauthnService.RequestOptions(challenge.Challenge)), nil
+}
+
+// FinishLogin verifies the assertion of a discoverable credential and
+// returns the id of the user it belongs to.
+func (s *PasskeyService) FinishLogin(ctx context.Context, ceremonyID uuid.UUID, response *webauthn.AuthenticationResponse) (uuid.UUID, error) {
+	challenge, err := s.consumeChallenge(ctx, ceremonyID, CEREMONY_LOGIN)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	credentialID, err := response.CredentialID()
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: failed to decode credential id: %w", webauthn.ErrInvalidResponse, err)
+	}
+	credential, err := s.queries.GetWebauthnCredentialByCredentialId(ctx, credentialID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return uuid.Nil, ErrPasskeyNotFound
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to get passkey: %w", err)
+	}
+
+	userHandle, err := response.UserHandle()
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: failed to decode user handle: %w", webauthn.ErrInvalidResponse, err)
+	}
+	if len(userHandle) > 0 && !bytes.Equal(userHandle, credential.UserID.Bytes[:]) {
+		return uuid.Nil, ErrUserHandleMismatch
+	}
+
+	verified, err := s.webauthnService.VerifyAssertion(
+		response,
+		challenge.Challenge,
+		credential.PublicKey,
+		uint32(credential.SignCount),
+	)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to verify passkey assertion: %w", err)
+	}
+
+	err = s.queries.UpdateWebauthnCredentialSignCount(
+		ctx,
+		repository.UpdateWebauthnCredentialSignCountParams{
+			ID:        credential.ID,
+			SignCount: int64(verified.SignCount),
+		},
+	)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to update passkey sign count: %w", err)
+	}
+
+	return uuid.UUID(credential.UserID.Bytes), nil
+}
+
+func (s *PasskeyService) ListPasskeys(ctx context.Context, userID uuid.UUID) ([]*PasskeyDto, error) {
+	credentials, err := s.queries.ListWebauthnCredentialsByUserId(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list passkeys: %w", err)
+	}
+
+	passkeys := make([]*PasskeyDto, 0, len(credentials))
+	for _, credential := range credentials {
+		passkeys = append(passkeys, NewPasskeyDto(credential))
+	}
+
+	return passkeys, nil
+}
+
+func (s *PasskeyService) DeletePasskey(ctx context.Context, userID, passkeyID uuid.UUID) error {
+	deleted, err := s.queries.DeleteWebauthnCredential(
+		ctx,
+		repository.DeleteWebauthnCredentialParams{
+			ID:     pgtype.UUID{Bytes: passkeyID, Valid: true},
+			UserID: pgtype.UUID{Bytes: userID, Valid: true},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete passkey: %w", err)
+	}
+	if deleted == 0 {
+		return ErrPasskeyNotFound
+	}
+
+	return nil
+}
+
+func (s *PasskeyService) createChallenge(ctx context.Context, userID pgtype.UUID, ceremony string) (repository.WebauthnChallenge, error) {
+	// Abandoned ceremonies are cleaned up whenever a new one starts.
+	if err := s.queries.DeleteExpiredWebauthnChallenges(ctx); err != nil {
+		return repository.WebauthnChallenge{}, fmt.Errorf("failed to delete expired challenges: %w", err)
+	}
+
+	challenge, err := s.webauthnService.NewChallenge()
+	if err != nil {
+		return repository.WebauthnChallenge{}, fmt.Errorf("failed to generate challenge: %w", err)
+	}
+
+	stored, err := s.queries.CreateWebauthnChallenge(
+		ctx,
+		repository.CreateWebauthnChallengeParams{
+			UserID:    userID,
+			Ceremony:  ceremony,
+			Challenge: challenge,
+			ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(webauthn.CEREMONY_TIMEOUT), Valid: true},
+		},
+	)
+	if err != nil {
+		return repository.WebauthnChallenge{}, fmt.Errorf("failed to store challenge: %w", err)
+	}
+
+	return stored, nil
+}
+
+// consumeChallenge deletes the challenge while reading it, so every ceremony
+// can be finished at most once.
+func (s *PasskeyService) consumeChallenge(ctx context.Context, ceremonyID uuid.UUID, ceremony string) (repository.WebauthnChallenge, error) {
+	challenge, err := s.queries.ConsumeWebauthnChallenge(
+		ctx,
+		repository.ConsumeWebauthnChallengeParams{
+			ID:       pgtype.UUID{Bytes: ceremonyID, Valid: true},
+			Ceremony: ceremony,
+		},
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return challenge, ErrCeremonyNotFound
+	}
+	if err != nil {
+		return challenge, fmt.Errorf("failed to get challenge: %w", err)
+	}
+	if time.Now().After(challenge.ExpiresAt.Time) {
+		return challenge, ErrCeremonyNotFound
+	}
+
+	return challenge, nil
+}
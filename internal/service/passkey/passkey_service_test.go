@@ -0,0 +1,270 @@
+//go:build unittest
+
+package passkey_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	repositoryMocks "github.com/fgeck/gotth-postgres/internal/repository/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/passkey"
+	"github.com/fgeck/gotth-postgres/internal/service/security/webauthn"
+	webauthnMocks "github.com/fgeck/gotth-postgres/internal/service/security/webauthn/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	userMocks "github.com/fgeck/gotth-postgres/internal/service/user/mocks"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const CHALLENGE = "challenge"
+
+func setupPasskeyServiceTest(t *testing.T) (*repositoryMocks.MockQuerier, *userMocks.MockUserServiceInterface, *webauthnMocks.MockWebauthnServiceInterface, *passkey.PasskeyService) {
+	mockQueries := repositoryMocks.NewMockQuerier(t)
+	mockUserService := userMocks.NewMockUserServiceInterface(t)
+	mockWebauthnService := webauthnMocks.NewMockWebauthnServiceInterface(t)
+	passkeyService := passkey.NewPasskeyService(mockQueries, mockUserService, mockWebauthnService)
+	return mockQueries, mockUserService, mockWebauthnService, passkeyService
+}
+
+func challengeRow(userID pgtype.UUID, ceremony string, expiresAt time.Time) repository.WebauthnChallenge {
+	return repository.WebauthnChallenge{
+		ID:        pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		UserID:    userID,
+		Ceremony:  ceremony,
+		Challenge: CHALLENGE,
+		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	}
+}
+
+func TestBeginRegistration(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	pgUserID := pgtype.UUID{Bytes: userID, Valid: true}
+
+	t.Run("excludes already registered passkeys", func(t *testing.T) {
+		mockQueries, mockUserService, mockWebauthnService, service := setupPasskeyServiceTest(t)
+		challenge := challengeRow(pgUserID, passkey.CEREMONY_REGISTRATION, time.Now().Add(time.Minute))
+		options := &webauthn.CredentialCreationOptions{Challenge: CHALLENGE}
+
+		mockUserService.On("GetUserById", ctx, userID).Return(&user.UserDto{ID: userID, Username: "user", Email: "user@example.com"}, nil)
+		mockQueries.On("ListWebauthnCredentialsByUserId", ctx, pgUserID).Return([]repository.WebauthnCredential{{CredentialID: []byte("existing")}}, nil)
+		mockQueries.On("DeleteExpiredWebauthnChallenges", ctx).Return(nil)
+		mockWebauthnService.On("NewChallenge").Return(CHALLENGE, nil)
+		mockQueries.On("CreateWebauthnChallenge", ctx, mock.MatchedBy(func(params repository.CreateWebauthnChallengeParams) bool {
+			return params.UserID == pgUserID && params.Ceremony == passkey.CEREMONY_REGISTRATION && params.Challenge == CHALLENGE
+		})).Return(challenge, nil)
+		mockWebauthnService.On(
+			"CreationOptions",
+			CHALLENGE,
+			webauthn.NewUserEntity(userID[:], "user@example.com", "user"),
+			[][]byte{[]byte("existing")},
+		).Return(options)
+
+		result, err := service.BeginRegistration(ctx, userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, uuid.UUID(challenge.ID.Bytes), result.CeremonyID)
+		assert.Equal(t, options, result.Options)
+	})
+}
+
+func TestFinishRegistration(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	pgUserID := pgtype.UUID{Bytes: userID, Valid: true}
+	response := &webauthn.RegistrationResponse{ID: "credential"}
+	verified := &webauthn.VerifiedCredential{CredentialID: []byte("credential"), PublicKey: []byte("key"), SignCount: 1}
+
+	t.Run("stores a verified passkey", func(t *testing.T) {
+		mockQueries, _, mockWebauthnService, service := setupPasskeyServiceTest(t)
+		challenge := challengeRow(pgUserID, passkey.CEREMONY_REGISTRATION, time.Now().Add(time.Minute))
+		ceremonyID := uuid.UUID(challenge.ID.Bytes)
+
+		mockQueries.On("ConsumeWebauthnChallenge", ctx, repository.ConsumeWebauthnChallengeParams{
+			ID:       challenge.ID,
+			Ceremony: passkey.CEREMONY_REGISTRATION,
+		}).Return(challenge, nil)
+		mockWebauthnService.On("VerifyRegistration", response, CHALLENGE).Return(verified, nil)
+		mockQueries.On("GetWebauthnCredentialByCredentialId", ctx, verified.CredentialID).Return(repository.WebauthnCredential{}, sql.ErrNoRows)
+		mockQueries.On("CreateWebauthnCredential", ctx, repository.CreateWebauthnCredentialParams{
+			UserID:       pgUserID,
+			CredentialID: verified.CredentialID,
+			PublicKey:    verified.PublicKey,
+			SignCount:    1,
+			Name:         passkey.DEFAULT_PASSKEY_NAME,
+		}).Return(repository.WebauthnCredential{
+			ID:   pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			Name: passkey.DEFAULT_PASSKEY_NAME,
+		}, nil)
+
+		result, err := service.FinishRegistration(ctx, userID, ceremonyID, "  ", response)
+
+		require.NoError(t, err)
+		assert.Equal(t, passkey.DEFAULT_PASSKEY_NAME, result.Name)
+	})
+
+	t.Run("rejects a ceremony of another user", func(t *testing.T) {
+		mockQueries, _, _, service := setupPasskeyServiceTest(t)
+		challenge := challengeRow(pgtype.UUID{Bytes: uuid.New(), Valid: true}, passkey.CEREMONY_REGISTRATION, time.Now().Add(time.Minute))
+
+		mockQueries.On("ConsumeWebauthnChallenge", ctx, mock.Anything).Return(challenge, nil)
+
+		result, err := service.FinishRegistration(ctx, userID, uuid.UUID(challenge.ID.Bytes), "Laptop", response)
+
+		require.ErrorIs(t, err, passkey.ErrCeremonyNotFound)
+		assert.Nil(t, result)
+	})
+
+	t.Run("rejects an expired ceremony", func(t *testing.T) {
+		mockQueries, _, _, service := setupPasskeyServiceTest(t)
+		challenge := challengeRow(pgUserID, passkey.CEREMONY_REGISTRATION, time.Now().Add(-time.Minute))
+
+		mockQueries.On("ConsumeWebauthnChallenge", ctx, mock.Anything).Return(challenge, nil)
+
+		result, err := service.FinishRegistration(ctx, userID, uuid.UUID(challenge.ID.Bytes), "Laptop", response)
+
+		require.ErrorIs(t, err, passkey.ErrCeremonyNotFound)
+		assert.Nil(t, result)
+	})
+
+	t.Run("rejects a credential that is already registered", func(t *testing.T) {
+		mockQueries, _, mockWebauthnService, service := setupPasskeyServiceTest(t)
+		challenge := challengeRow(pgUserID, passkey.CEREMONY_REGISTRATION, time.Now().Add(time.Minute))
+
+		mockQueries.On("ConsumeWebauthnChallenge", ctx, mock.Anything).Return(challenge, nil)
+		mockWebauthnService.On("VerifyRegistration", response, CHALLENGE).Return(verified, nil)
+		mockQueries.On("GetWebauthnCredentialByCredentialId", ctx, verified.CredentialID).Return(repository.WebauthnCredential{}, nil)
+
+		result, err := service.FinishRegistration(ctx, userID, uuid.UUID(challenge.ID.Bytes), "Laptop", response)
+
+		require.ErrorIs(t, err, passkey.ErrPasskeyAlreadyRegistered)
+		assert.Nil(t, result)
+	})
+
+	t.Run("rejects a name that is too long", func(t *testing.T) {
+		_, _, _, service := setupPasskeyServiceTest(t)
+
+		name := strings.Repeat("x", passkey.PASSKEY_NAME_MAX_LENGTH+1)
+		result, err := service.FinishRegistration(ctx, userID, uuid.New(), name, response)
+
+		require.ErrorIs(t, err, passkey.ErrPasskeyNameTooLong)
+		assert.Nil(t, result)
+	})
+}
+
+func TestFinishLogin(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	pgUserID := pgtype.UUID{Bytes: userID, Valid: true}
+	credentialID := []byte("credential")
+	credential := repository.WebauthnCredential{
+		ID:           pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		UserID:       pgUserID,
+		CredentialID: credentialID,
+		PublicKey:    []byte("key"),
+		SignCount:    4,
+	}
+	newResponse := func(userHandle []byte) *webauthn.AuthenticationResponse {
+		return &webauthn.AuthenticationResponse{
+			RawID: base64.RawURLEncoding.EncodeToString(credentialID),
+			Response: webauthn.AssertionResponse{
+				UserHandle: base64.RawURLEncoding.EncodeToString(userHandle),
+			},
+		}
+	}
+
+	t.Run("returns the owner and stores the new sign count", func(t *testing.T) {
+		mockQueries, _, mockWebauthnService, service := setupPasskeyServiceTest(t)
+		challenge := challengeRow(pgtype.UUID{}, passkey.CEREMONY_LOGIN, time.Now().Add(time.Minute))
+		response := newResponse(userID[:])
+
+		mockQueries.On("ConsumeWebauthnChallenge", ctx, repository.ConsumeWebauthnChallengeParams{
+			ID:       challenge.ID,
+			Ceremony: passkey.CEREMONY_LOGIN,
+		}).Return(challenge, nil)
+		mockQueries.On("GetWebauthnCredentialByCredentialId", ctx, credentialID).Return(credential, nil)
+		mockWebauthnService.On("VerifyAssertion", response, CHALLENGE, credential.PublicKey, uint32(4)).
+			Return(&webauthn.VerifiedAssertion{SignCount: 5, UserVerified: true}, nil)
+		mockQueries.On("UpdateWebauthnCredentialSignCount", ctx, repository.UpdateWebauthnCredentialSignCountParams{
+			ID:        credential.ID,
+			SignCount: 5,
+		}).Return(nil)
+
+		result, err := service.FinishLogin(ctx, uuid.UUID(challenge.ID.Bytes), response)
+
+		require.NoError(t, err)
+		assert.Equal(t, userID, result)
+	})
+
+	t.Run("fails for an unknown credential", func(t *testing.T) {
+		mockQueries, _, _, service := setupPasskeyServiceTest(t)
+		challenge := challengeRow(pgtype.UUID{}, passkey.CEREMONY_LOGIN, time.Now().Add(time.Minute))
+
+		mockQueries.On("ConsumeWebauthnChallenge", ctx, mock.Anything).Return(challenge, nil)
+		mockQueries.On("GetWebauthnCredentialByCredentialId", ctx, credentialID).Return(repository.WebauthnCredential{}, sql.ErrNoRows)
+
+		result, err := service.FinishLogin(ctx, uuid.UUID(challenge.ID.Bytes), newResponse(userID[:]))
+
+		require.ErrorIs(t, err, passkey.ErrPasskeyNotFound)
+		assert.Equal(t, uuid.Nil, result)
+	})
+
+	t.Run("fails when the user handle belongs to someone else", func(t *testing.T) {
+		mockQueries, _, _, service := setupPasskeyServiceTest(t)
+		challenge := challengeRow(pgtype.UUID{}, passkey.CEREMONY_LOGIN, time.Now().Add(time.Minute))
+		otherUserID := uuid.New()
+
+		mockQueries.On("ConsumeWebauthnChallenge", ctx, mock.Anything).Return(challenge, nil)
+		mockQueries.On("GetWebauthnCredentialByCredentialId", ctx, credentialID).Return(credential, nil)
+
+		result, err := service.FinishLogin(ctx, uuid.UUID(challenge.ID.Bytes), newResponse(otherUserID[:]))
+
+		require.ErrorIs(t, err, passkey.ErrUserHandleMismatch)
+		assert.Equal(t, uuid.Nil, result)
+	})
+
+	t.Run("fails for an unknown ceremony", func(t *testing.T) {
+		mockQueries, _, _, service := setupPasskeyServiceTest(t)
+
+		mockQueries.On("ConsumeWebauthnChallenge", ctx, mock.Anything).Return(repository.WebauthnChallenge{}, sql.ErrNoRows)
+
+		result, err := service.FinishLogin(ctx, uuid.New(), newResponse(userID[:]))
+
+		require.ErrorIs(t, err, passkey.ErrCeremonyNotFound)
+		assert.Equal(t, uuid.Nil, result)
+	})
+}
+
+func TestDeletePasskey(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	passkeyID := uuid.New()
+	params := repository.DeleteWebauthnCredentialParams{
+		ID:     pgtype.UUID{Bytes: passkeyID, Valid: true},
+		UserID: pgtype.UUID{Bytes: userID, Valid: true},
+	}
+
+	t.Run("deletes an own passkey", func(t *testing.T) {
+		mockQueries, _, _, service := setupPasskeyServiceTest(t)
+
+		mockQueries.On("DeleteWebauthnCredential", ctx, params).Return(int64(1), nil)
+
+		require.NoError(t, service.DeletePasskey(ctx, userID, passkeyID))
+	})
+
+	t.Run("fails for a passkey of another user", func(t *testing.T) {
+		mockQueries, _, _, service := setupPasskeyServiceTest(t)
+
+		mockQueries.On("DeleteWebauthnCredential", ctx, params).Return(int64(0), nil)
+
+		require.ErrorIs(t, service.DeletePasskey(ctx, userID, passkeyID), passkey.ErrPasskeyNotFound)
+	})
+}
@@ -0,0 +1,167 @@
+package passwordHistory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	"github.com/fgeck/gotth-postgres/internal/service/config"
+	"github.com/fgeck/gotth-postgres/internal/service/security/password"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	"github.com/fgeck/gotth-postgres/internal/service/validation"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrPasswordReused = errors.New("password was used before")
+)
+
+type PasswordHistoryServiceInterface interface {
+	ValidateNewPassword(ctx context.Context, userDto *user.UserDto, newPassword string) error
+	ChangePassword(ctx context.Context, userDto *user.UserDto, newPassword string) error
+	IsExpired(userDto *user.UserDto) bool
+}
+
+// PasswordHistoryService is the common path for every password change. It
+// enforces the password policy, rejects recently used passwords and keeps
+// the history of previous hashes.
+type PasswordHistoryService struct {
+	queries         repository.Querier
+	userService     user.UserServiceInterface
+	passwordService password.PasswordServiceInterface
+	validator       validation.ValidationServiceInterface
+	cfg             config.PasswordHistoryConfig
+}
+
+func NewPasswordHistoryService(
+	queries repository.Querier,
+	userService user.UserServiceInterface,
+	passwordService password.PasswordServiceInterface,
+	validator validation.ValidationServiceInterface,
+	cfg config.PasswordHistoryConfig,
+) *PasswordHistoryService {
+	return &PasswordHistoryService{
+		queries:         queries,
+		userService:     userService,
+		passwordService: passwordService,
+		validator:       validator,
+		cfg:             cfg,
+	}
+}
+
+// ValidateNewPassword checks the password policy first and only then compares
+// against the current and the remembered hashes, since every comparison costs
+// a full password hash. The current password counts towards the reuse limit,
+// so the history only holds the reuseLimit-1 passwords before it.
+func (s *PasswordHistoryService) ValidateNewPassword(ctx context.Context, userDto *user.UserDto, newPassword string) error {
+	if err := s.validator.ValidatePassword(newPassword, userDto.Username, userDto.Email); err != nil {
+		return err
+	}
+	if s.cfg.ReuseLimit <= 0 {
+		return nil
+	}
+
+	if s.passwordService.ComparePassword(userDto.PasswordHash, newPassword) == nil {
+		return ErrPasswordReused
+	}
+
+	if s.cfg.ReuseLimit == 1 {
+		return nil
+	}
+
+	previousHashes, err := s.queries.GetRecentPasswordHashes(
+		ctx,
+		repository.GetRecentPasswordHashesParams{
+			UserID: pgtype.UUID{Bytes: userDto.ID, Valid: true},
+			Limit:  int32(s.cfg.ReuseLimit - 1),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get password history: %w", err)
+	}
+	for _, previousHash := range previousHashes {
+		if s.passwordService.ComparePassword(previousHash, newPassword) == nil {
+			return ErrPasswordReused
+		}
+	}
+
+	return nil
+}
+
+// ChangePassword stores the new password and moves the replaced hash into the
+// history. Callers validate the password with ValidateNewPassword first.
+func (s *PasswordHistoryService) ChangePassword(ctx context.Context, userDto *user.UserDto, newPassword string) error {
+	hashedPassword, err := s.passwordService.HashAndSaltPassword(newPassword)
+	if err != nil {
+		return fmt.Errorf("failed to salt and hash password: %w", err)
+	}
+
+	if err := s.userService.UpdatePassword(ctx, userDto.ID, hashedPassword); err != nil {
+		return err
+	}
+	if s.cfg.ReuseLimit <= 1 {
+		return nil
+	}
+
+	userID := pgtype.UUID{Bytes: userDto.ID, Valid: true}
+	err = s.queries.CreatePasswordHistoryEntry(
+		ctx,
+		repository.CreatePasswordHistoryEntryParams{
+			UserID:       userID,
+			PasswordHash: userDto.PasswordHash,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store password history: %w", err)
+	}
+
+	err = s.queries.PrunePasswordHistory(
+		ctx,
+		repository.PrunePasswordHistoryParams{
+			UserID: userID,
+			Limit:  int32(s.cfg.ReuseLimit - 1),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to prune password history: %w", err)
+	}
+
+	return nil
+}
+
+// IsExpired reports whether the user has to choose a new password before
+// logging in. Only the configured roles have expiring passwords, granted roles
+// are matched as well once they are loaded into the dto. An admin can require a
+// new password from any user.
+func (s *PasswordHistoryService) IsExpired(userDto *user.UserDto) bool {
+	if userDto.PasswordResetRequired {
+		return true
+	}
+	if s.cfg.MaxAge <= 0 {
+		return false
+	}
+
+	if !s.hasExpiringRole(userDto) {
+		return false
+	}
+
+	return time.Since(userDto.PasswordChangedAt) > s.cfg.MaxAge
+}
+
+func (s *PasswordHistoryService) hasExpiringRole(userDto *user.UserDto) bool {
+	for _, expiringRole := range s.cfg.ExpiringRoles {
+		if strings.EqualFold(expiringRole, userDto.Role.Name) {
+			return true
+		}
+		for _, role := range userDto.Roles {
+			if strings.EqualFold(expiringRole, role) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,236 @@
+//go:build unittest
+
+package passwordHistory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	repositoryMocks "github.com/fgeck/gotth-postgres/internal/repository/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/config"
+	"github.com/fgeck/gotth-postgres/internal/service/passwordHistory"
+	passwordMocks "github.com/fgeck/gotth-postgres/internal/service/security/password/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	userMocks "github.com/fgeck/gotth-postgres/internal/service/user/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/validation"
+	validationMocks "github.com/fgeck/gotth-postgres/internal/service/validation/mocks"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	NEW_PASSWORD  = "Quiet-Harbor-Lantern-42!"
+	NEW_HASH      = "newhash"
+	CURRENT_HASH  = "currenthash"
+	PREVIOUS_HASH = "previoushash"
+	REUSE_LIMIT   = 3
+)
+
+var historyConfig = config.PasswordHistoryConfig{
+	ReuseLimit:    REUSE_LIMIT,
+	MaxAge:        90 * 24 * time.Hour,
+	ExpiringRoles: []string{"admin"},
+}
+
+type passwordHistoryServiceMocks struct {
+	queries         *repositoryMocks.MockQuerier
+	userService     *userMocks.MockUserServiceInterface
+	passwordService *passwordMocks.MockPasswordServiceInterface
+	validator       *validationMocks.MockValidationServiceInterface
+}
+
+func setupPasswordHistoryServiceTest(t *testing.T, cfg config.PasswordHistoryConfig) (*passwordHistoryServiceMocks, *passwordHistory.PasswordHistoryService) {
+	mocks := &passwordHistoryServiceMocks{
+		queries:         repositoryMocks.NewMockQuerier(t),
+		userService:     userMocks.NewMockUserServiceInterface(t),
+		passwordService: passwordMocks.NewMockPasswordServiceInterface(t),
+		validator:       validationMocks.NewMockValidationServiceInterface(t),
+	}
+	service := passwordHistory.NewPasswordHistoryService(
+		mocks.queries,
+		mocks.userService,
+		mocks.passwordService,
+		mocks.validator,
+		cfg,
+	)
+	return mocks, service
+}
+
+func newUserDto() *user.UserDto {
+	return &user.UserDto{
+		ID:           uuid.New(),
+		Username:     "testuser",
+		Email:        "testuser@example.com",
+		PasswordHash: CURRENT_HASH,
+		Role:         user.UserRoleUser,
+	}
+}
+
+func recentHashesParams(userDto *user.UserDto) repository.GetRecentPasswordHashesParams {
+	return repository.GetRecentPasswordHashesParams{
+		UserID: pgtype.UUID{Bytes: userDto.ID, Valid: true},
+		Limit:  REUSE_LIMIT - 1,
+	}
+}
+
+func TestValidateNewPassword(t *testing.T) {
+	ctx := context.Background()
+	mismatch := errors.New("mismatch")
+
+	t.Run("accepts a password that was not used before", func(t *testing.T) {
+		mocks, service := setupPasswordHistoryServiceTest(t, historyConfig)
+		userDto := newUserDto()
+
+		mocks.validator.On("ValidatePassword", NEW_PASSWORD, userDto.Username, userDto.Email).Return(nil)
+		mocks.passwordService.On("ComparePassword", CURRENT_HASH, NEW_PASSWORD).Return(mismatch)
+		mocks.queries.On("GetRecentPasswordHashes", ctx, recentHashesParams(userDto)).Return([]string{PREVIOUS_HASH}, nil)
+		mocks.passwordService.On("ComparePassword", PREVIOUS_HASH, NEW_PASSWORD).Return(mismatch)
+
+		require.NoError(t, service.ValidateNewPassword(ctx, userDto, NEW_PASSWORD))
+	})
+
+	t.Run("rejects the current password", func(t *testing.T) {
+		mocks, service := setupPasswordHistoryServiceTest(t, historyConfig)
+		userDto := newUserDto()
+
+		mocks.validator.On("ValidatePassword", NEW_PASSWORD, userDto.Username, userDto.Email).Return(nil)
+		mocks.passwordService.On("ComparePassword", CURRENT_HASH, NEW_PASSWORD).Return(nil)
+
+		require.ErrorIs(t, service.ValidateNewPassword(ctx, userDto, NEW_PASSWORD), passwordHistory.ErrPasswordReused)
+	})
+
+	t.Run("rejects a remembered password", func(t *testing.T) {
+		mocks, service := setupPasswordHistoryServiceTest(t, historyConfig)
+		userDto := newUserDto()
+
+		mocks.validator.On("ValidatePassword", NEW_PASSWORD, userDto.Username, userDto.Email).Return(nil)
+		mocks.passwordService.On("ComparePassword", CURRENT_HASH, NEW_PASSWORD).Return(mismatch)
+		mocks.queries.On("GetRecentPasswordHashes", ctx, recentHashesParams(userDto)).Return([]string{"otherhash", PREVIOUS_HASH}, nil)
+		mocks.passwordService.On("ComparePassword", "otherhash", NEW_PASSWORD).Return(mismatch)
+		mocks.passwordService.On("ComparePassword", PREVIOUS_HASH, NEW_PASSWORD).Return(nil)
+
+		require.ErrorIs(t, service.ValidateNewPassword(ctx, userDto, NEW_PASSWORD), passwordHistory.ErrPasswordReused)
+	})
+
+	t.Run("checks the policy before any hash", func(t *testing.T) {
+		mocks, service := setupPasswordHistoryServiceTest(t, historyConfig)
+		userDto := newUserDto()
+		policyErr := &validation.PasswordPolicyError{Violations: []string{"must contain a number"}}
+
+		mocks.validator.On("ValidatePassword", "weak", userDto.Username, userDto.Email).Return(policyErr)
+
+		require.ErrorIs(t, service.ValidateNewPassword(ctx, userDto, "weak"), validation.ErrInvalidPassword)
+	})
+
+	t.Run("counts the current password towards the reuse limit", func(t *testing.T) {
+		mocks, service := setupPasswordHistoryServiceTest(t, config.PasswordHistoryConfig{ReuseLimit: 1})
+		userDto := newUserDto()
+
+		mocks.validator.On("ValidatePassword", NEW_PASSWORD, userDto.Username, userDto.Email).Return(nil)
+		mocks.passwordService.On("ComparePassword", CURRENT_HASH, NEW_PASSWORD).Return(mismatch)
+
+		require.NoError(t, service.ValidateNewPassword(ctx, userDto, NEW_PASSWORD))
+		mocks.queries.AssertNotCalled(t, "GetRecentPasswordHashes", mock.Anything, mock.Anything)
+	})
+
+	t.Run("only checks the policy without a reuse limit", func(t *testing.T) {
+		mocks, service := setupPasswordHistoryServiceTest(t, config.PasswordHistoryConfig{})
+		userDto := newUserDto()
+
+		mocks.validator.On("ValidatePassword", NEW_PASSWORD, userDto.Username, userDto.Email).Return(nil)
+
+		require.NoError(t, service.ValidateNewPassword(ctx, userDto, NEW_PASSWORD))
+	})
+}
+
+func TestChangePassword(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("stores the replaced hash and prunes the history", func(t *testing.T) {
+		mocks, service := setupPasswordHistoryServiceTest(t, historyConfig)
+		userDto := newUserDto()
+		userID := pgtype.UUID{Bytes: userDto.ID, Valid: true}
+
+		mocks.passwordService.On("HashAndSaltPassword", NEW_PASSWORD).Return(NEW_HASH, nil)
+		mocks.userService.On("UpdatePassword", ctx, userDto.ID, NEW_HASH).Return(nil)
+		mocks.queries.On("CreatePasswordHistoryEntry", ctx, repository.CreatePasswordHistoryEntryParams{
+			UserID:       userID,
+			PasswordHash: CURRENT_HASH,
+		}).Return(nil)
+		mocks.queries.On("PrunePasswordHistory", ctx, repository.PrunePasswordHistoryParams{
+			UserID: userID,
+			Limit:  REUSE_LIMIT - 1,
+		}).Return(nil)
+
+		require.NoError(t, service.ChangePassword(ctx, userDto, NEW_PASSWORD))
+	})
+
+	t.Run("keeps no history without a reuse limit", func(t *testing.T) {
+		mocks, service := setupPasswordHistoryServiceTest(t, config.PasswordHistoryConfig{})
+		userDto := newUserDto()
+
+		mocks.passwordService.On("HashAndSaltPassword", NEW_PASSWORD).Return(NEW_HASH, nil)
+		mocks.userService.On("UpdatePassword", ctx, userDto.ID, NEW_HASH).Return(nil)
+
+		require.NoError(t, service.ChangePassword(ctx, userDto, NEW_PASSWORD))
+	})
+
+	t.Run("keeps no history when only the current password is remembered", func(t *testing.T) {
+		mocks, service := setupPasswordHistoryServiceTest(t, config.PasswordHistoryConfig{ReuseLimit: 1})
+		userDto := newUserDto()
+
+		mocks.passwordService.On("HashAndSaltPassword", NEW_PASSWORD).Return(NEW_HASH, nil)
+		mocks.userService.On("UpdatePassword", ctx, userDto.ID, NEW_HASH).Return(nil)
+
+		require.NoError(t, service.ChangePassword(ctx, userDto, NEW_PASSWORD))
+	})
+
+	t.Run("fails when the password cannot be stored", func(t *testing.T) {
+		mocks, service := setupPasswordHistoryServiceTest(t, historyConfig)
+		userDto := newUserDto()
+		dbErr := errors.New("database error")
+
+		mocks.passwordService.On("HashAndSaltPassword", NEW_PASSWORD).Return(NEW_HASH, nil)
+		mocks.userService.On("UpdatePassword", ctx, userDto.ID, NEW_HASH).Return(dbErr)
+
+		require.ErrorIs(t, service.ChangePassword(ctx, userDto, NEW_PASSWORD), dbErr)
+	})
+}
+
+func TestIsExpired(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           config.PasswordHistoryConfig
+		role          user.UserRole
+		roles         []string
+		changedAt     time.Time
+		resetRequired bool
+		expected      bool
+	}{
+		{"old password of an expiring role", historyConfig, user.UserRoleAdmin, nil, time.Now().Add(-91 * 24 * time.Hour), false, true},
+		{"recent password of an expiring role", historyConfig, user.UserRoleAdmin, nil, time.Now().Add(-89 * 24 * time.Hour), false, false},
+		{"old password of another role", historyConfig, user.UserRoleUser, []string{"user", "auditor"}, time.Now().Add(-365 * 24 * time.Hour), false, false},
+		{"old password of a granted expiring role", historyConfig, user.UserRoleUser, []string{"user", "admin"}, time.Now().Add(-91 * 24 * time.Hour), false, true},
+		{"expiry disabled", config.PasswordHistoryConfig{ExpiringRoles: []string{"ADMIN"}}, user.UserRoleAdmin, nil, time.Time{}, false, false},
+		{"reset required by an admin", config.PasswordHistoryConfig{}, user.UserRoleUser, nil, time.Now(), true, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, service := setupPasswordHistoryServiceTest(t, test.cfg)
+			userDto := newUserDto()
+			userDto.Role = test.role
+			userDto.Roles = test.roles
+			userDto.PasswordChangedAt = test.changedAt
+			userDto.PasswordResetRequired = test.resetRequired
+
+			assert.Equal(t, test.expected, service.IsExpired(userDto))
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package passwordReset
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	"github.com/fgeck/gotth-postgres/internal/service/mail"
+	"github.com/fgeck/gotth-postgres/internal/service/passwordHistory"
+	"github.com/fgeck/gotth-postgres/internal/service/session"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	RESET_PASSWORD_PATH = "/reset-password"
+	LOGIN_PATH          = "/login"
+	RESET_TOKEN_BYTES   = 32
+	RESET_TOKEN_TTL     = 30 * time.Minute
+	// Minimum time between two reset emails for the same account.
+	RESEND_INTERVAL = time.Minute
+)
+
+var (
+	ErrInvalidResetToken = errors.New("password reset link is invalid or expired")
+	ErrResetThrottled    = errors.New("a password reset email was sent recently")
+)
+
+type PasswordResetServiceInterface interface {
+	RequestReset(ctx context.Context, email string) error
+	CheckReset(ctx context.Context, email string) (*user.UserDto, error)
+	SendReset(ctx context.Context, userDto *user.UserDto) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+	NotifyExistingAccount(ctx context.Context, email string) error
+}
+
+type PasswordResetService struct {
+	queries                repository.Querier
+	userService            user.UserServiceInterface
+	passwordHistoryService passwordHistory.PasswordHistoryServiceInterface
+	sessionService         session.SessionServiceInterface
+	mailer                 mail.Mailer
+	publicUrl              string
+}
+
+func NewPasswordResetService(
+	queries repository.Querier,
+	userService user.UserServiceInterface,
+	passwordHistoryService passwordHistory.PasswordHistoryServiceInterface,
+	sessionService session.SessionServiceInterface,
+	mailer mail.Mailer,
+	publicUrl string,
+) *PasswordResetService {
+	return &PasswordResetService{
+		queries:                queries,
+		userService:            userService,
+		passwordHistoryService: passwordHistoryService,
+		sessionService:         sessionService,
+		mailer:                 mailer,
+		publicUrl:              publicUrl,
+	}
+}
+
+// RequestReset sends a reset link if the address belongs to an account.
+// Unknown addresses are not reported back so that callers cannot probe for
+// registered emails. The time it takes and ErrResetThrottled still depend on
+// the address, callers must not pass them on.
+func (s *PasswordResetService) RequestReset(ctx context.Context, email string) error {
+	userDto, err := s.CheckReset(ctx, email)
+	if err != nil || userDto == nil {
+		return err
+	}
+
+	return s.SendReset(ctx, userDto)
+}
+
+// CheckReset is the first half of RequestReset. It returns the account a
+// reset link has to be sent to, nil for unknown addresses, or
+// ErrResetThrottled. It only reads, so it is cheap enough to run before
+// SendReset is handed off to the background.
+func (s *PasswordResetService) CheckReset(ctx context.Context, email string) (*user.UserDto, error) {
+	userDto, err := s.userService.GetUserByEmail(ctx, email)
+	if errors.Is(err, user.ErrUserNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	latest, err := s.queries.GetLatestPasswordResetToken(ctx, pgtype.UUID{Bytes: userDto.ID, Valid: true})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get latest password reset token: %w", err)
+	}
+	if err == nil && time.Since(latest.CreatedAt.Time) < RESEND_INTERVAL {
+		return nil, ErrResetThrottled
+	}
+
+	return userDto, nil
+}
+
+// SendReset issues a new reset link for an account returned by CheckReset and
+// emails it.
+func (s *PasswordResetService) SendReset(ctx context.Context, userDto *user.UserDto) error {
+	resetLink, err := s.issueResetLink(ctx, userDto.ID)
+	if err != nil {
+		return err
+	}
+
+	message, err := mail.NewPasswordResetMessage(ctx, userDto.Email, resetLink)
+	if err != nil {
+		return fmt.Errorf("failed to render password reset email: %w", err)
+	}
+	if err := s.mailer.Send(ctx, message); err != nil {
+		return fmt.Errorf("failed to send password reset email: %w", err)
+	}
+
+	return nil
+}
+
+// NotifyExistingAccount answers a registration attempt for an address that
+// already has an account. Instead of telling the client, the owner gets an
+// email with a login and a reset link.
+func (s *PasswordResetService) NotifyExistingAccount(ctx context.Context, email string) error {
+	userDto, err := s.userService.GetUserByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	resetLink, err := s.issueResetLink(ctx, userDto.ID)
+	if err != nil {
+		return err
+	}
+
+	message, err := mail.NewAccountExistsMessage(ctx, userDto.Email, s.publicUrl+LOGIN_PATH, resetLink)
+	if err != nil {
+		return fmt.Errorf("failed to render account exists email: %w", err)
+	}
+	if err := s.mailer.Send(ctx, message); err != nil {
+		return fmt.Errorf("failed to send account exists email: %w", err)
+	}
+
+	return nil
+}
+
+// issueResetLink stores a new reset token for the user and returns the link
+// containing it. Only the most recent link stays valid.
+func (s *PasswordResetService) issueResetLink(ctx context.Context, id uuid.UUID) (string, error) {
+	userID := pgtype.UUID{Bytes: id, Valid: true}
+	if err := s.queries.DeletePasswordResetTokensByUserId(ctx, userID); err != nil {
+		return "", fmt.Errorf("failed to delete password reset tokens: %w", err)
+	}
+
+	token, err := generateResetToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate password reset token: %w", err)
+	}
+
+	err = s.queries.CreatePasswordResetToken(
+		ctx,
+		repository.CreatePasswordResetTokenParams{
+			UserID:    userID,
+			TokenHash: hashResetToken(token),
+			ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(RESET_TOKEN_TTL), Valid: true},
+		},
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to store password reset token: %w", err)
+	}
+
+	return s.publicUrl + RESET_PASSWORD_PATH + "?token=" + url.QueryEscape(token), nil
+}
+
+// ResetPassword sets the new password and logs the user out everywhere, since
+// a reset usually means the old password can no longer be trusted.
+func (s *PasswordResetService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	tokenHash := hashResetToken(token)
+	resetToken, err := s.queries.GetPasswordResetToken(ctx, tokenHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidResetToken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get password reset token: %w", err)
+	}
+	userID := uuid.UUID(resetToken.UserID.Bytes)
+
+	userDto, err := s.userService.GetUserById(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// Validate before consuming so that a rejected password does not use up
+	// the link.
+	if err := s.passwordHistoryService.ValidateNewPassword(ctx, userDto, newPassword); err != nil {
+		return err
+	}
+
+	// Consuming can still fail if the link was used concurrently.
+	if _, err := s.queries.ConsumePasswordResetToken(ctx, tokenHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidResetToken
+		}
+
+		return fmt.Errorf("failed to consume password reset token: %w", err)
+	}
+
+	if err := s.passwordHistoryService.ChangePassword(ctx, userDto, newPassword); err != nil {
+		return err
+	}
+
+	if err := s.sessionService.RevokeAllUserSessions(ctx, userID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return nil
+}
+
+func generateResetToken() (string, error) {
+	buf := make([]byte, RESET_TOKEN_BYTES)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
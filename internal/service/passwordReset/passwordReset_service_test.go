@@ -0,0 +1,250 @@
+//go:build unittest
+
+package passwordReset_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	repositoryMocks "github.com/fgeck/gotth-postgres/internal/repository/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/mail"
+	mailMocks "github.com/fgeck/gotth-postgres/internal/service/mail/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/passwordHistory"
+	passwordHistoryMocks "github.com/fgeck/gotth-postgres/internal/service/passwordHistory/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/passwordReset"
+	sessionMocks "github.com/fgeck/gotth-postgres/internal/service/session/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	userMocks "github.com/fgeck/gotth-postgres/internal/service/user/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/validation"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	EMAIL        = "user@example.com"
+	RESET_TOKEN  = "reset-token"
+	NEW_PASSWORD = "N3w-Password!"
+)
+
+type passwordResetServiceMocks struct {
+	queries                *repositoryMocks.MockQuerier
+	userService            *userMocks.MockUserServiceInterface
+	passwordHistoryService *passwordHistoryMocks.MockPasswordHistoryServiceInterface
+	sessionService         *sessionMocks.MockSessionServiceInterface
+	mailer                 *mailMocks.MockMailer
+}
+
+func setupPasswordResetServiceTest(t *testing.T) (*passwordResetServiceMocks, *passwordReset.PasswordResetService) {
+	mocks := &passwordResetServiceMocks{
+		queries:                repositoryMocks.NewMockQuerier(t),
+		userService:            userMocks.NewMockUserServiceInterface(t),
+		passwordHistoryService: passwordHistoryMocks.NewMockPasswordHistoryServiceInterface(t),
+		sessionService:         sessionMocks.NewMockSessionServiceInterface(t),
+		mailer:                 mailMocks.NewMockMailer(t),
+	}
+	service := passwordReset.NewPasswordResetService(
+		mocks.queries,
+		mocks.userService,
+		mocks.passwordHistoryService,
+		mocks.sessionService,
+		mocks.mailer,
+		"http://localhost:8081",
+	)
+	return mocks, service
+}
+
+func TestRequestReset(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	pgUserID := pgtype.UUID{Bytes: userID, Valid: true}
+
+	t.Run("stores only the hash of a new token", func(t *testing.T) {
+		mocks, service := setupPasswordResetServiceTest(t)
+
+		mocks.userService.On("GetUserByEmail", ctx, EMAIL).Return(&user.UserDto{ID: userID, Email: EMAIL}, nil)
+		mocks.queries.On("GetLatestPasswordResetToken", ctx, pgUserID).Return(repository.PasswordResetToken{}, sql.ErrNoRows)
+		mocks.queries.On("DeletePasswordResetTokensByUserId", ctx, pgUserID).Return(nil)
+		mocks.queries.On("CreatePasswordResetToken", ctx, mock.MatchedBy(func(params repository.CreatePasswordResetTokenParams) bool {
+			return params.UserID == pgUserID &&
+				len(params.TokenHash) == 64 &&
+				params.ExpiresAt.Time.Before(time.Now().Add(passwordReset.RESET_TOKEN_TTL+time.Second))
+		})).Return(nil)
+		mocks.mailer.On("Send", ctx, mock.MatchedBy(func(message *mail.Message) bool {
+			return message.To == EMAIL && strings.Contains(message.TextBody, "http://localhost:8081/reset-password?token=")
+		})).Return(nil)
+
+		require.NoError(t, service.RequestReset(ctx, EMAIL))
+	})
+
+	t.Run("fails when the email cannot be sent", func(t *testing.T) {
+		mocks, service := setupPasswordResetServiceTest(t)
+
+		mocks.userService.On("GetUserByEmail", ctx, EMAIL).Return(&user.UserDto{ID: userID, Email: EMAIL}, nil)
+		mocks.queries.On("GetLatestPasswordResetToken", ctx, pgUserID).Return(repository.PasswordResetToken{}, sql.ErrNoRows)
+		mocks.queries.On("DeletePasswordResetTokensByUserId", ctx, pgUserID).Return(nil)
+		mocks.queries.On("CreatePasswordResetToken", ctx, mock.Anything).Return(nil)
+		mocks.mailer.On("Send", ctx, mock.Anything).Return(errors.New("connection refused"))
+
+		require.Error(t, service.RequestReset(ctx, EMAIL))
+	})
+
+	t.Run("does not send another email within the resend interval", func(t *testing.T) {
+		mocks, service := setupPasswordResetServiceTest(t)
+
+		mocks.userService.On("GetUserByEmail", ctx, EMAIL).Return(&user.UserDto{ID: userID, Email: EMAIL}, nil)
+		mocks.queries.On("GetLatestPasswordResetToken", ctx, pgUserID).Return(repository.PasswordResetToken{
+			CreatedAt: pgtype.Timestamptz{Time: time.Now().Add(-10 * time.Second), Valid: true},
+		}, nil)
+
+		require.ErrorIs(t, service.RequestReset(ctx, EMAIL), passwordReset.ErrResetThrottled)
+		mocks.queries.AssertNotCalled(t, "CreatePasswordResetToken", mock.Anything, mock.Anything)
+		mocks.mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+
+	t.Run("does not reveal unknown addresses", func(t *testing.T) {
+		mocks, service := setupPasswordResetServiceTest(t)
+
+		mocks.userService.On("GetUserByEmail", ctx, EMAIL).Return(nil, user.ErrUserNotFound)
+
+		require.NoError(t, service.RequestReset(ctx, EMAIL))
+	})
+}
+
+func TestCheckReset(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	pgUserID := pgtype.UUID{Bytes: userID, Valid: true}
+
+	t.Run("returns the account without issuing a token", func(t *testing.T) {
+		mocks, service := setupPasswordResetServiceTest(t)
+		userDto := &user.UserDto{ID: userID, Email: EMAIL}
+
+		mocks.userService.On("GetUserByEmail", ctx, EMAIL).Return(userDto, nil)
+		mocks.queries.On("GetLatestPasswordResetToken", ctx, pgUserID).Return(repository.PasswordResetToken{
+			CreatedAt: pgtype.Timestamptz{Time: time.Now().Add(-passwordReset.RESEND_INTERVAL), Valid: true},
+		}, nil)
+
+		result, err := service.CheckReset(ctx, EMAIL)
+
+		require.NoError(t, err)
+		assert.Equal(t, userDto, result)
+		mocks.queries.AssertNotCalled(t, "CreatePasswordResetToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns no account for unknown addresses", func(t *testing.T) {
+		mocks, service := setupPasswordResetServiceTest(t)
+
+		mocks.userService.On("GetUserByEmail", ctx, EMAIL).Return(nil, user.ErrUserNotFound)
+
+		result, err := service.CheckReset(ctx, EMAIL)
+
+		require.NoError(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestSendReset(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	pgUserID := pgtype.UUID{Bytes: userID, Valid: true}
+	mocks, service := setupPasswordResetServiceTest(t)
+
+	mocks.queries.On("DeletePasswordResetTokensByUserId", ctx, pgUserID).Return(nil)
+	mocks.queries.On("CreatePasswordResetToken", ctx, mock.Anything).Return(nil)
+	mocks.mailer.On("Send", ctx, mock.MatchedBy(func(message *mail.Message) bool {
+		return message.To == EMAIL && strings.Contains(message.TextBody, "http://localhost:8081/reset-password?token=")
+	})).Return(nil)
+
+	require.NoError(t, service.SendReset(ctx, &user.UserDto{ID: userID, Email: EMAIL}))
+}
+
+func TestNotifyExistingAccount(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	pgUserID := pgtype.UUID{Bytes: userID, Valid: true}
+	mocks, service := setupPasswordResetServiceTest(t)
+
+	mocks.userService.On("GetUserByEmail", ctx, EMAIL).Return(&user.UserDto{ID: userID, Email: EMAIL}, nil)
+	mocks.queries.On("DeletePasswordResetTokensByUserId", ctx, pgUserID).Return(nil)
+	mocks.queries.On("CreatePasswordResetToken", ctx, mock.Anything).Return(nil)
+	mocks.mailer.On("Send", ctx, mock.MatchedBy(func(message *mail.Message) bool {
+		return message.To == EMAIL &&
+			strings.Contains(message.TextBody, "http://localhost:8081/login") &&
+			strings.Contains(message.TextBody, "http://localhost:8081/reset-password?token=")
+	})).Return(nil)
+
+	require.NoError(t, service.NotifyExistingAccount(ctx, EMAIL))
+}
+
+func TestResetPassword(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	resetToken := repository.PasswordResetToken{UserID: pgtype.UUID{Bytes: userID, Valid: true}}
+	userDto := &user.UserDto{ID: userID, Username: "testuser", Email: "testuser@example.com"}
+
+	t.Run("updates the password and revokes all sessions", func(t *testing.T) {
+		mocks, service := setupPasswordResetServiceTest(t)
+
+		mocks.queries.On("GetPasswordResetToken", ctx, mock.AnythingOfType("string")).Return(resetToken, nil)
+		mocks.userService.On("GetUserById", ctx, userID).Return(userDto, nil)
+		mocks.passwordHistoryService.On("ValidateNewPassword", ctx, userDto, NEW_PASSWORD).Return(nil)
+		mocks.queries.On("ConsumePasswordResetToken", ctx, mock.AnythingOfType("string")).Return(resetToken, nil)
+		mocks.passwordHistoryService.On("ChangePassword", ctx, userDto, NEW_PASSWORD).Return(nil)
+		mocks.sessionService.On("RevokeAllUserSessions", ctx, userID).Return(nil)
+
+		require.NoError(t, service.ResetPassword(ctx, RESET_TOKEN, NEW_PASSWORD))
+	})
+
+	t.Run("rejects a used or expired token", func(t *testing.T) {
+		mocks, service := setupPasswordResetServiceTest(t)
+
+		mocks.queries.On("GetPasswordResetToken", ctx, mock.AnythingOfType("string")).Return(repository.PasswordResetToken{}, sql.ErrNoRows)
+
+		require.ErrorIs(t, service.ResetPassword(ctx, RESET_TOKEN, NEW_PASSWORD), passwordReset.ErrInvalidResetToken)
+	})
+
+	t.Run("rejects a token consumed concurrently", func(t *testing.T) {
+		mocks, service := setupPasswordResetServiceTest(t)
+
+		mocks.queries.On("GetPasswordResetToken", ctx, mock.AnythingOfType("string")).Return(resetToken, nil)
+		mocks.userService.On("GetUserById", ctx, userID).Return(userDto, nil)
+		mocks.passwordHistoryService.On("ValidateNewPassword", ctx, userDto, NEW_PASSWORD).Return(nil)
+		mocks.queries.On("ConsumePasswordResetToken", ctx, mock.AnythingOfType("string")).Return(repository.PasswordResetToken{}, sql.ErrNoRows)
+
+		require.ErrorIs(t, service.ResetPassword(ctx, RESET_TOKEN, NEW_PASSWORD), passwordReset.ErrInvalidResetToken)
+	})
+
+	t.Run("keeps the token when the password is rejected", func(t *testing.T) {
+		mocks, service := setupPasswordResetServiceTest(t)
+		policyErr := &validation.PasswordPolicyError{Violations: []string{"must contain a number"}}
+
+		mocks.queries.On("GetPasswordResetToken", ctx, mock.AnythingOfType("string")).Return(resetToken, nil)
+		mocks.userService.On("GetUserById", ctx, userID).Return(userDto, nil)
+		mocks.passwordHistoryService.On("ValidateNewPassword", ctx, userDto, "weak").Return(policyErr)
+
+		err := service.ResetPassword(ctx, RESET_TOKEN, "weak")
+
+		require.ErrorIs(t, err, validation.ErrInvalidPassword)
+		mocks.queries.AssertNotCalled(t, "ConsumePasswordResetToken", mock.Anything, mock.Anything)
+	})
+	t.Run("keeps the token when the password was used before", func(t *testing.T) {
+		mocks, service := setupPasswordResetServiceTest(t)
+
+		mocks.queries.On("GetPasswordResetToken", ctx, mock.AnythingOfType("string")).Return(resetToken, nil)
+		mocks.userService.On("GetUserById", ctx, userID).Return(userDto, nil)
+		mocks.passwordHistoryService.On("ValidateNewPassword", ctx, userDto, NEW_PASSWORD).Return(passwordHistory.ErrPasswordReused)
+
+		err := service.ResetPassword(ctx, RESET_TOKEN, NEW_PASSWORD)
+
+		require.ErrorIs(t, err, passwordHistory.ErrPasswordReused)
+		mocks.queries.AssertNotCalled(t, "ConsumePasswordResetToken", mock.Anything, mock.Anything)
+	})
+}
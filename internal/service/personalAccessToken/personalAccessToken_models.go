@@ -0,0 +1,44 @@
+package personalAccessToken
+
+import (
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	"github.com/google/uuid"
+)
+
+// PersonalAccessTokenDto never contains the token, only the prefix it starts
+// with.
+type PersonalAccessTokenDto struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	TokenPrefix string     `json:"tokenPrefix"`
+	Scopes      []string   `json:"scopes"`
+	ExpiresAt   time.Time  `json:"expiresAt"`
+	LastUsedAt  *time.Time `json:"lastUsedAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+func NewPersonalAccessTokenDto(token repository.PersonalAccessToken) *PersonalAccessTokenDto {
+	dto := &PersonalAccessTokenDto{
+		ID:          uuid.UUID(token.ID.Bytes),
+		Name:        token.Name,
+		TokenPrefix: token.TokenPrefix,
+		Scopes:      token.Scopes,
+		ExpiresAt:   token.ExpiresAt.Time,
+		CreatedAt:   token.CreatedAt.Time,
+	}
+	if token.LastUsedAt.Valid {
+		lastUsedAt := token.LastUsedAt.Time
+		dto.LastUsedAt = &lastUsedAt
+	}
+
+	return dto
+}
+
+// CreatedTokenDto is returned once when a token is created. The token cannot
+// be shown again later.
+type CreatedTokenDto struct {
+	*PersonalAccessTokenDto
+	Token string `json:"token"`
+}
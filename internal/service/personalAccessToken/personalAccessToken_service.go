@@ -0,0 +1,259 @@
+package personalAccessToken
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	"github.com/fgeck/gotth-postgres/internal/service/rbac"
+	"github.com/fgeck/gotth-postgres/internal/service/security/jwt"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	TOKEN_PREFIX        = "gpat_"
+	TOKEN_BYTES         = 32
+	TOKEN_PREFIX_LENGTH = len(TOKEN_PREFIX) + 6
+	MAX_NAME_LENGTH     = 100
+	DEFAULT_TOKEN_TTL   = 30 * 24 * time.Hour
+	MAX_TOKEN_TTL       = 365 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidToken     = errors.New("personal access token is invalid or expired")
+	ErrTokenNotFound    = errors.New("personal access token not found")
+	ErrInvalidName      = errors.New("token name must be between 1 and 100 characters")
+	ErrInvalidScope     = errors.New("scopes must be permissions of the user")
+	ErrInvalidExpiry    = errors.New("tokens expire after at most 365 days")
+	ErrCreatedWithToken = errors.New("personal access tokens cannot create further tokens")
+	ErrServiceAccount   = errors.New("service accounts use client credentials instead of personal access tokens")
+)
+
+type tokenContextKey struct{}
+
+// WithToken marks a request that was authenticated with a personal access
+// token instead of a session.
+func WithToken(ctx context.Context, tokenID uuid.UUID) context.Context {
+	return context.WithValue(ctx, tokenContextKey{}, tokenID)
+}
+
+// TokenFromContext returns the token set by WithToken.
+func TokenFromContext(ctx context.Context) (uuid.UUID, bool) {
+	tokenID, ok := ctx.Value(tokenContextKey{}).(uuid.UUID)
+
+	return tokenID, ok
+}
+
+type PersonalAccessTokenServiceInterface interface {
+	CreateToken(ctx context.Context, userID uuid.UUID, name string, scopes []string, ttl time.Duration) (*CreatedTokenDto, error)
+	ListTokens(ctx context.Context, userID uuid.UUID) ([]*PersonalAccessTokenDto, error)
+	RevokeToken(ctx context.Context, userID, id uuid.UUID) error
+	Authenticate(ctx context.Context, token string) (*jwt.JwtCustomClaims, error)
+}
+
+// PersonalAccessTokenService manages tokens that let scripts act as their
+// user without a session. Scopes can only narrow down what the user may do,
+// permissions the user loses later are gone from their tokens as well.
+type PersonalAccessTokenService struct {
+	queries     repository.Querier
+	userService user.UserServiceInterface
+	rbacService rbac.RbacServiceInterface
+}
+
+func NewPersonalAccessTokenService(
+	queries repository.Querier,
+	userService user.UserServiceInterface,
+	rbacService rbac.RbacServiceInterface,
+) *PersonalAccessTokenService {
+	return &PersonalAccessTokenService{
+		queries:     queries,
+		userService: userService,
+		rbacService: rbacService,
+	}
+}
+
+// CreateToken returns the only copy of the token. A ttl of zero means
+// DEFAULT_TOKEN_TTL.
+func (s *PersonalAccessTokenService) CreateToken(
+	ctx context.Context,
+	userID uuid.UUID,
+	name string,
+	scopes []string,
+	ttl time.Duration,
+) (*CreatedTokenDto, error) {
+	// Otherwise a leaked token could replace itself before it expires.
+	if _, ok := TokenFromContext(ctx); ok {
+		return nil, ErrCreatedWithToken
+	}
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > MAX_NAME_LENGTH {
+		return nil, ErrInvalidName
+	}
+	if ttl == 0 {
+		ttl = DEFAULT_TOKEN_TTL
+	}
+	if ttl < 0 || ttl > MAX_TOKEN_TTL {
+		return nil, ErrInvalidExpiry
+	}
+
+	userDto, err := s.userService.GetUserById(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if userDto.IsServiceAccount() {
+		return nil, ErrServiceAccount
+	}
+	if err := s.rbacService.LoadPermissions(ctx, userDto); err != nil {
+		return nil, err
+	}
+	normalized := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if !userDto.HasPermission(scope) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidScope, scope)
+		}
+		normalized = append(normalized, scope)
+	}
+	slices.Sort(normalized)
+	normalized = slices.Compact(normalized)
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate personal access token: %w", err)
+	}
+
+	created, err := s.queries.CreatePersonalAccessToken(
+		ctx,
+		repository.CreatePersonalAccessTokenParams{
+			UserID:      pgtype.UUID{Bytes: userID, Valid: true},
+			Name:        name,
+			TokenPrefix: token[:TOKEN_PREFIX_LENGTH],
+			TokenHash:   hashToken(token),
+			Scopes:      normalized,
+			ExpiresAt:   pgtype.Timestamptz{Time: time.Now().Add(ttl), Valid: true},
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create personal access token: %w", err)
+	}
+
+	return &CreatedTokenDto{PersonalAccessTokenDto: NewPersonalAccessTokenDto(created), Token: token}, nil
+}
+
+// ListTokens includes expired tokens, so that users see why a script stopped
+// working.
+func (s *PersonalAccessTokenService) ListTokens(ctx context.Context, userID uuid.UUID) ([]*PersonalAccessTokenDto, error) {
+	tokens, err := s.queries.ListUserPersonalAccessTokens(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list personal access tokens: %w", err)
+	}
+
+	dtos := make([]*PersonalAccessTokenDto, 0, len(tokens))
+	for _, token := range tokens {
+		dtos = append(dtos, NewPersonalAccessTokenDto(token))
+	}
+
+	return dtos, nil
+}
+
+func (s *PersonalAccessTokenService) RevokeToken(ctx context.Context, userID, id uuid.UUID) error {
+	deleted, err := s.queries.DeletePersonalAccessToken(
+		ctx,
+		repository.DeletePersonalAccessTokenParams{
+			ID:     pgtype.UUID{Bytes: id, Valid: true},
+			UserID: pgtype.UUID{Bytes: userID, Valid: true},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to revoke personal access token: %w", err)
+	}
+	if deleted == 0 {
+		return ErrTokenNotFound
+	}
+
+	return nil
+}
+
+// Authenticate returns the claims a request with the token acts with. They
+// are built from the current state of the user on every request and never
+// signed, the ID is the id of the token.
+func (s *PersonalAccessTokenService) Authenticate(ctx context.Context, token string) (*jwt.JwtCustomClaims, error) {
+	if !strings.HasPrefix(token, TOKEN_PREFIX) {
+		return nil, ErrInvalidToken
+	}
+	stored, err := s.queries.GetValidPersonalAccessToken(ctx, hashToken(token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get personal access token: %w", err)
+	}
+
+	userDto, err := s.userService.GetUserById(ctx, uuid.UUID(stored.UserID.Bytes))
+	if errors.Is(err, user.ErrUserNotFound) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if userDto.IsDisabled() {
+		return nil, user.ErrUserDisabled
+	}
+	if userDto.PendingApproval {
+		return nil, user.ErrUserPendingApproval
+	}
+	if err := s.rbacService.LoadPermissions(ctx, userDto); err != nil {
+		return nil, err
+	}
+
+	if err := s.queries.TouchPersonalAccessToken(ctx, stored.ID); err != nil {
+		return nil, fmt.Errorf("failed to update personal access token: %w", err)
+	}
+
+	permissions := make([]string, 0, len(stored.Scopes))
+	for _, scope := range stored.Scopes {
+		if userDto.HasPermission(scope) {
+			permissions = append(permissions, scope)
+		}
+	}
+	claims := jwt.NewJwtCustomClaims(
+		userDto.ID.String(),
+		userDto.Role.Name,
+		gojwt.RegisteredClaims{
+			ID:        uuid.UUID(stored.ID.Bytes).String(),
+			IssuedAt:  gojwt.NewNumericDate(time.Now()),
+			ExpiresAt: gojwt.NewNumericDate(stored.ExpiresAt.Time),
+		},
+	)
+	claims.Roles = userDto.Roles
+	claims.Permissions = permissions
+
+	return claims, nil
+}
+
+func generateToken() (string, error) {
+	buf := make([]byte, TOKEN_BYTES)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return TOKEN_PREFIX + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
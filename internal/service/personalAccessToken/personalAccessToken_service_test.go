@@ -0,0 +1,208 @@
+//go:build unittest
+
+package personalAccessToken_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	repositoryMocks "github.com/fgeck/gotth-postgres/internal/repository/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/personalAccessToken"
+	"github.com/fgeck/gotth-postgres/internal/service/rbac"
+	rbacMocks "github.com/fgeck/gotth-postgres/internal/service/rbac/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	userMocks "github.com/fgeck/gotth-postgres/internal/service/user/mocks"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const TOKEN = "gpat_0123456789abcdefghijklmnopqrstuvwxyzABCDE"
+
+type personalAccessTokenServiceMocks struct {
+	queries     *repositoryMocks.MockQuerier
+	userService *userMocks.MockUserServiceInterface
+	rbacService *rbacMocks.MockRbacServiceInterface
+}
+
+func setupPersonalAccessTokenServiceTest(t *testing.T) (*personalAccessTokenServiceMocks, *personalAccessToken.PersonalAccessTokenService) {
+	mocks := &personalAccessTokenServiceMocks{
+		queries:     repositoryMocks.NewMockQuerier(t),
+		userService: userMocks.NewMockUserServiceInterface(t),
+		rbacService: rbacMocks.NewMockRbacServiceInterface(t),
+	}
+	service := personalAccessToken.NewPersonalAccessTokenService(mocks.queries, mocks.userService, mocks.rbacService)
+	return mocks, service
+}
+
+func grantPermissions(mocks *personalAccessTokenServiceMocks, ctx context.Context, permissions ...string) {
+	mocks.rbacService.On("LoadPermissions", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*user.UserDto).Permissions = permissions
+	}).Return(nil)
+}
+
+func hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestCreateToken(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	userDto := &user.UserDto{ID: userID, Role: user.UserRoleUser}
+
+	t.Run("creates a hashed token with the given scopes", func(t *testing.T) {
+		mocks, service := setupPersonalAccessTokenServiceTest(t)
+		mocks.userService.On("GetUserById", ctx, userID).Return(userDto, nil)
+		grantPermissions(mocks, ctx, rbac.PERMISSION_USERS_READ, rbac.PERMISSION_USERS_WRITE)
+
+		var params repository.CreatePersonalAccessTokenParams
+		mocks.queries.On("CreatePersonalAccessToken", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			params = args.Get(1).(repository.CreatePersonalAccessTokenParams)
+		}).Return(repository.PersonalAccessToken{
+			ID:     pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			Name:   "ci",
+			Scopes: []string{rbac.PERMISSION_USERS_READ},
+		}, nil)
+
+		created, err := service.CreateToken(ctx, userID, " ci ", []string{"USERS:READ", rbac.PERMISSION_USERS_READ}, 0)
+
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(created.Token, personalAccessToken.TOKEN_PREFIX))
+		assert.Equal(t, hash(created.Token), params.TokenHash)
+		assert.True(t, strings.HasPrefix(created.Token, params.TokenPrefix))
+		assert.Equal(t, "ci", params.Name)
+		assert.Equal(t, []string{rbac.PERMISSION_USERS_READ}, params.Scopes)
+		assert.WithinDuration(t, time.Now().Add(personalAccessToken.DEFAULT_TOKEN_TTL), params.ExpiresAt.Time, time.Minute)
+	})
+
+	t.Run("rejects scopes the user does not have", func(t *testing.T) {
+		mocks, service := setupPersonalAccessTokenServiceTest(t)
+		mocks.userService.On("GetUserById", ctx, userID).Return(userDto, nil)
+		grantPermissions(mocks, ctx, rbac.PERMISSION_USERS_READ)
+
+		_, err := service.CreateToken(ctx, userID, "ci", []string{rbac.PERMISSION_USERS_WRITE}, time.Hour)
+
+		require.ErrorIs(t, err, personalAccessToken.ErrInvalidScope)
+		mocks.queries.AssertNotCalled(t, "CreatePersonalAccessToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects invalid names and expiries", func(t *testing.T) {
+		_, service := setupPersonalAccessTokenServiceTest(t)
+
+		_, err := service.CreateToken(ctx, userID, " ", nil, time.Hour)
+		require.ErrorIs(t, err, personalAccessToken.ErrInvalidName)
+
+		_, err = service.CreateToken(ctx, userID, "ci", nil, personalAccessToken.MAX_TOKEN_TTL+time.Hour)
+		require.ErrorIs(t, err, personalAccessToken.ErrInvalidExpiry)
+	})
+
+	t.Run("does not let tokens create tokens", func(t *testing.T) {
+		_, service := setupPersonalAccessTokenServiceTest(t)
+
+		_, err := service.CreateToken(personalAccessToken.WithToken(ctx, uuid.New()), userID, "ci", nil, time.Hour)
+
+		require.ErrorIs(t, err, personalAccessToken.ErrCreatedWithToken)
+	})
+
+	t.Run("does not create tokens for service accounts", func(t *testing.T) {
+		mocks, service := setupPersonalAccessTokenServiceTest(t)
+		mocks.userService.On("GetUserById", ctx, userID).Return(&user.UserDto{
+			ID:          userID,
+			Role:        user.UserRoleUser,
+			AccountType: user.ACCOUNT_TYPE_SERVICE,
+		}, nil)
+
+		_, err := service.CreateToken(ctx, userID, "ci", nil, time.Hour)
+
+		require.ErrorIs(t, err, personalAccessToken.ErrServiceAccount)
+	})
+}
+
+func TestRevokeToken(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	id := uuid.New()
+	params := repository.DeletePersonalAccessTokenParams{
+		ID:     pgtype.UUID{Bytes: id, Valid: true},
+		UserID: pgtype.UUID{Bytes: userID, Valid: true},
+	}
+
+	t.Run("deletes a token of the user", func(t *testing.T) {
+		mocks, service := setupPersonalAccessTokenServiceTest(t)
+		mocks.queries.On("DeletePersonalAccessToken", ctx, params).Return(int64(1), nil)
+
+		require.NoError(t, service.RevokeToken(ctx, userID, id))
+	})
+
+	t.Run("fails for tokens of other users", func(t *testing.T) {
+		mocks, service := setupPersonalAccessTokenServiceTest(t)
+		mocks.queries.On("DeletePersonalAccessToken", ctx, params).Return(int64(0), nil)
+
+		require.ErrorIs(t, service.RevokeToken(ctx, userID, id), personalAccessToken.ErrTokenNotFound)
+	})
+}
+
+func TestAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	stored := repository.PersonalAccessToken{
+		ID:        pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		UserID:    pgtype.UUID{Bytes: userID, Valid: true},
+		Scopes:    []string{rbac.PERMISSION_USERS_READ, rbac.PERMISSION_USERS_WRITE},
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+	}
+
+	t.Run("limits the permissions to the scopes", func(t *testing.T) {
+		mocks, service := setupPersonalAccessTokenServiceTest(t)
+		mocks.queries.On("GetValidPersonalAccessToken", ctx, hash(TOKEN)).Return(stored, nil)
+		mocks.userService.On("GetUserById", ctx, userID).Return(&user.UserDto{ID: userID, Role: user.UserRoleAdmin}, nil)
+		grantPermissions(mocks, ctx, rbac.PERMISSION_USERS_READ, rbac.PERMISSION_ROLES_READ)
+		mocks.queries.On("TouchPersonalAccessToken", ctx, stored.ID).Return(nil)
+
+		claims, err := service.Authenticate(ctx, TOKEN)
+
+		require.NoError(t, err)
+		assert.Equal(t, userID.String(), claims.UserId)
+		assert.Equal(t, user.UserRoleAdmin.Name, claims.UserRole)
+		assert.Equal(t, uuid.UUID(stored.ID.Bytes).String(), claims.ID)
+		assert.Equal(t, []string{rbac.PERMISSION_USERS_READ}, claims.Permissions)
+	})
+
+	t.Run("rejects unknown and expired tokens", func(t *testing.T) {
+		mocks, service := setupPersonalAccessTokenServiceTest(t)
+		mocks.queries.On("GetValidPersonalAccessToken", ctx, hash(TOKEN)).Return(repository.PersonalAccessToken{}, sql.ErrNoRows)
+
+		_, err := service.Authenticate(ctx, TOKEN)
+
+		require.ErrorIs(t, err, personalAccessToken.ErrInvalidToken)
+	})
+
+	t.Run("rejects values without the prefix", func(t *testing.T) {
+		_, service := setupPersonalAccessTokenServiceTest(t)
+
+		_, err := service.Authenticate(ctx, "eyJhbGciOiJIUzI1NiJ9.e30.sig")
+
+		require.ErrorIs(t, err, personalAccessToken.ErrInvalidToken)
+	})
+
+	t.Run("rejects tokens of disabled users", func(t *testing.T) {
+		mocks, service := setupPersonalAccessTokenServiceTest(t)
+		disabledAt := time.Now()
+		mocks.queries.On("GetValidPersonalAccessToken", ctx, hash(TOKEN)).Return(stored, nil)
+		mocks.userService.On("GetUserById", ctx, userID).Return(&user.UserDto{ID: userID, DisabledAt: &disabledAt}, nil)
+
+		_, err := service.Authenticate(ctx, TOKEN)
+
+		require.ErrorIs(t, err, user.ErrUserDisabled)
+		mocks.queries.AssertNotCalled(t, "TouchPersonalAccessToken", mock.Anything, mock.Anything)
+	})
+}
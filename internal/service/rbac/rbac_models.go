@@ -0,0 +1,75 @@
+package rbac
+
+import (
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	"github.com/google/uuid"
+)
+
+// Roles of organization members, seeded by migrations like USER and ADMIN.
+const (
+	ROLE_ORG_OWNER  = "ORG_OWNER"
+	ROLE_ORG_MEMBER = "ORG_MEMBER"
+)
+
+// Permissions are seeded by migrations, routes require them by these names.
+const (
+	PERMISSION_USERS_READ     = "users:read"
+	PERMISSION_USERS_WRITE    = "users:write"
+	PERMISSION_SESSIONS_WRITE = "sessions:write"
+	PERMISSION_LOCKOUTS_READ  = "lockouts:read"
+	PERMISSION_LOCKOUTS_WRITE = "lockouts:write"
+	PERMISSION_ROLES_READ     = "roles:read"
+	PERMISSION_ROLES_WRITE    = "roles:write"
+
+	PERMISSION_SERVICE_ACCOUNTS_READ  = "service_accounts:read"
+	PERMISSION_SERVICE_ACCOUNTS_WRITE = "service_accounts:write"
+
+	// Tenant permissions only apply to the active organization.
+	PERMISSION_ORGANIZATION_WRITE = "organization:write"
+	PERMISSION_MEMBERS_READ       = "members:read"
+	PERMISSION_MEMBERS_WRITE      = "members:write"
+)
+
+// TENANT_PERMISSIONS are the only permissions a membership role grants, so
+// that organization owners cannot hand out global permissions.
+var TENANT_PERMISSIONS = []string{
+	PERMISSION_ORGANIZATION_WRITE,
+	PERMISSION_MEMBERS_READ,
+	PERMISSION_MEMBERS_WRITE,
+}
+
+type RoleDto struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+func NewRoleDto(role repository.Role, permissions []string) *RoleDto {
+	if permissions == nil {
+		permissions = []string{}
+	}
+
+	return &RoleDto{
+		ID:          uuid.UUID(role.ID.Bytes),
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: permissions,
+		CreatedAt:   role.CreatedAt.Time,
+	}
+}
+
+type PermissionDto struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func NewPermissionDto(permission repository.Permission) *PermissionDto {
+	return &PermissionDto{
+		Name:        permission.Name,
+		Description: permission.Description,
+	}
+}
@@ -0,0 +1,360 @@
+package rbac
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrUnknownRole       = errors.New("unknown role")
+	ErrUnknownPermission = errors.New("unknown permission")
+	ErrInvalidRoleName   = errors.New("role names start with a letter and have 2 to 32 letters, digits or underscores")
+	ErrRoleExists        = errors.New("role already exists")
+	ErrBuiltInRole       = errors.New("built-in roles cannot be changed or deleted")
+	ErrRoleInUse         = errors.New("role is still held by organization members")
+	ErrRoleNotHeld       = errors.New("only roles whose permissions you hold can be assigned")
+	ErrPermissionNotHeld = errors.New("only permissions you hold can be granted")
+)
+
+var roleNameRegex = regexp.MustCompile(`^[A-Z][A-Z0-9_]{1,31}$`)
+
+type RbacServiceInterface interface {
+	LoadPermissions(ctx context.Context, userDto *user.UserDto) error
+	GetUserRoles(ctx context.Context, userID uuid.UUID) ([]string, error)
+	SetUserRoles(ctx context.Context, userID uuid.UUID, roles []string) error
+	CheckRolesHeld(ctx context.Context, permissions []string, roles []string) error
+	ListRoles(ctx context.Context) ([]*RoleDto, error)
+	ListPermissions(ctx context.Context) ([]*PermissionDto, error)
+	CreateRole(ctx context.Context, actorPermissions []string, name, description string, permissions []string) (*RoleDto, error)
+	SetRolePermissions(ctx context.Context, actorPermissions []string, name string, permissions []string) (*RoleDto, error)
+	DeleteRole(ctx context.Context, name string) error
+}
+
+// RbacService manages roles and the permissions they grant. Every user has
+// their base role and any number of further roles.
+type RbacService struct {
+	queries repository.Querier
+}
+
+func NewRbacService(queries repository.Querier) *RbacService {
+	return &RbacService{
+		queries: queries,
+	}
+}
+
+// LoadPermissions sets the roles and permissions of the user, which are put
+// into the access token.
+func (s *RbacService) LoadPermissions(ctx context.Context, userDto *user.UserDto) error {
+	roles, err := s.GetUserRoles(ctx, userDto.ID)
+	if err != nil {
+		return err
+	}
+	permissions, err := s.queries.ListUserPermissionNames(ctx, pgtype.UUID{Bytes: userDto.ID, Valid: true})
+	if err != nil {
+		return fmt.Errorf("failed to list user permissions: %w", err)
+	}
+
+	userDto.Roles = roles
+	userDto.Permissions = permissions
+
+	return nil
+}
+
+// GetUserRoles returns the base role and all further roles of the user.
+func (s *RbacService) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	roles, err := s.queries.ListUserRoleNames(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user roles: %w", err)
+	}
+
+	return roles, nil
+}
+
+// SetUserRoles replaces the roles granted on top of the base role.
+func (s *RbacService) SetUserRoles(ctx context.Context, userID uuid.UUID, roles []string) error {
+	known, err := s.queries.ListRoles(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list roles: %w", err)
+	}
+	names := make([]string, 0, len(roles))
+	for _, role := range roles {
+		name := normalizeRoleName(role)
+		if !slices.ContainsFunc(known, func(r repository.Role) bool { return r.Name == name }) {
+			return fmt.Errorf("%w: %s", ErrUnknownRole, role)
+		}
+		names = append(names, name)
+	}
+
+	// A single statement, so that the user never ends up with only a part of
+	// the roles.
+	err = s.queries.SetUserRoles(
+		ctx,
+		repository.SetUserRolesParams{
+			UserID: pgtype.UUID{Bytes: userID, Valid: true},
+			Roles:  names,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set user roles: %w", err)
+	}
+
+	return nil
+}
+
+// CheckRolesHeld returns ErrRoleNotHeld unless permissions contains every
+// permission of the roles, so that nobody can hand out more than they may do
+// themselves. Unknown roles grant nothing and pass.
+func (s *RbacService) CheckRolesHeld(ctx context.Context, permissions []string, roles []string) error {
+	rows, err := s.queries.ListRolePermissionNames(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list role permissions: %w", err)
+	}
+	for _, role := range roles {
+		name := normalizeRoleName(role)
+		for _, row := range rows {
+			if row.RoleName == name && !slices.Contains(permissions, row.PermissionName) {
+				return fmt.Errorf("%w: %s", ErrRoleNotHeld, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *RbacService) ListRoles(ctx context.Context) ([]*RoleDto, error) {
+	roles, err := s.queries.ListRoles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	rows, err := s.queries.ListRolePermissionNames(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list role permissions: %w", err)
+	}
+
+	permissionsByRole := make(map[string][]string, len(roles))
+	for _, row := range rows {
+		permissionsByRole[row.RoleName] = append(permissionsByRole[row.RoleName], row.PermissionName)
+	}
+
+	dtos := make([]*RoleDto, 0, len(roles))
+	for _, role := range roles {
+		dtos = append(dtos, NewRoleDto(role, permissionsByRole[role.Name]))
+	}
+
+	return dtos, nil
+}
+
+func (s *RbacService) ListPermissions(ctx context.Context) ([]*PermissionDto, error) {
+	permissions, err := s.queries.ListPermissions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list permissions: %w", err)
+	}
+
+	dtos := make([]*PermissionDto, 0, len(permissions))
+	for _, permission := range permissions {
+		dtos = append(dtos, NewPermissionDto(permission))
+	}
+
+	return dtos, nil
+}
+
+// CreateRole only grants permissions that the actor holds, see
+// checkPermissionsHeld.
+func (s *RbacService) CreateRole(
+	ctx context.Context,
+	actorPermissions []string,
+	name string,
+	description string,
+	permissions []string,
+) (*RoleDto, error) {
+	name = normalizeRoleName(name)
+	if !roleNameRegex.MatchString(name) {
+		return nil, ErrInvalidRoleName
+	}
+	if err := s.validatePermissions(ctx, permissions); err != nil {
+		return nil, err
+	}
+	if err := checkPermissionsHeld(actorPermissions, permissions); err != nil {
+		return nil, err
+	}
+
+	_, err := s.queries.GetRoleByName(ctx, name)
+	if err == nil {
+		return nil, ErrRoleExists
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get role: %w", err)
+	}
+
+	role, err := s.queries.CreateRole(
+		ctx,
+		repository.CreateRoleParams{
+			Name:        name,
+			Description: strings.TrimSpace(description),
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create role: %w", err)
+	}
+	if err := s.addRolePermissions(ctx, role, permissions); err != nil {
+		return nil, err
+	}
+
+	return NewRoleDto(role, sortedPermissions(permissions)), nil
+}
+
+// SetRolePermissions replaces the permissions of a role in one statement, so
+// that the role never ends up without its permissions. Users holding the role
+// get them with their next token refresh. Like CreateRole it only grants
+// permissions that the actor holds.
+func (s *RbacService) SetRolePermissions(
+	ctx context.Context,
+	actorPermissions []string,
+	name string,
+	permissions []string,
+) (*RoleDto, error) {
+	name = normalizeRoleName(name)
+	if isBuiltInRole(name) {
+		return nil, ErrBuiltInRole
+	}
+	if err := s.validatePermissions(ctx, permissions); err != nil {
+		return nil, err
+	}
+	if err := checkPermissionsHeld(actorPermissions, permissions); err != nil {
+		return nil, err
+	}
+
+	role, err := s.queries.GetRoleByName(ctx, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrUnknownRole
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get role: %w", err)
+	}
+
+	err = s.queries.SetRolePermissions(
+		ctx,
+		repository.SetRolePermissionsParams{
+			RoleID: role.ID,
+			// An empty array instead of NULL, which would keep every permission.
+			Permissions: append([]string{}, permissions...),
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set role permissions: %w", err)
+	}
+
+	return NewRoleDto(role, sortedPermissions(permissions)), nil
+}
+
+// DeleteRole also takes the role away from every user who had it. Roles of
+// organization members have to be replaced first.
+func (s *RbacService) DeleteRole(ctx context.Context, name string) error {
+	name = normalizeRoleName(name)
+	if isBuiltInRole(name) {
+		return ErrBuiltInRole
+	}
+
+	role, err := s.queries.GetRoleByName(ctx, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrUnknownRole
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get role: %w", err)
+	}
+	memberships, err := s.queries.CountRoleMemberships(ctx, role.ID)
+	if err != nil {
+		return fmt.Errorf("failed to count role memberships: %w", err)
+	}
+	if memberships > 0 {
+		return ErrRoleInUse
+	}
+
+	deleted, err := s.queries.DeleteRole(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+	if deleted == 0 {
+		return ErrUnknownRole
+	}
+
+	return nil
+}
+
+func (s *RbacService) addRolePermissions(ctx context.Context, role repository.Role, permissions []string) error {
+	if len(permissions) == 0 {
+		return nil
+	}
+	err := s.queries.AddRolePermissions(
+		ctx,
+		repository.AddRolePermissionsParams{
+			RoleID:      role.ID,
+			Permissions: permissions,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add role permissions: %w", err)
+	}
+
+	return nil
+}
+
+func (s *RbacService) validatePermissions(ctx context.Context, permissions []string) error {
+	if len(permissions) == 0 {
+		return nil
+	}
+	known, err := s.queries.ListPermissions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list permissions: %w", err)
+	}
+	for _, permission := range permissions {
+		if !slices.ContainsFunc(known, func(p repository.Permission) bool { return p.Name == permission }) {
+			return fmt.Errorf("%w: %s", ErrUnknownPermission, permission)
+		}
+	}
+
+	return nil
+}
+
+// checkPermissionsHeld returns ErrPermissionNotHeld unless the actor holds
+// every permission, otherwise anyone who may edit roles could grant
+// themselves any permission through a role they hold.
+func checkPermissionsHeld(actorPermissions []string, permissions []string) error {
+	for _, permission := range permissions {
+		if !slices.Contains(actorPermissions, permission) {
+			return fmt.Errorf("%w: %s", ErrPermissionNotHeld, permission)
+		}
+	}
+
+	return nil
+}
+
+func normalizeRoleName(name string) string {
+	return strings.ToUpper(strings.TrimSpace(name))
+}
+
+func isBuiltInRole(name string) bool {
+	switch name {
+	case user.UserRoleUser.Name, user.UserRoleAdmin.Name, ROLE_ORG_OWNER, ROLE_ORG_MEMBER:
+		return true
+	default:
+		return false
+	}
+}
+
+func sortedPermissions(permissions []string) []string {
+	sorted := slices.Clone(permissions)
+	slices.Sort(sorted)
+
+	return slices.Compact(sorted)
+}
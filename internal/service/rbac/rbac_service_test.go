@@ -0,0 +1,293 @@
+//go:build unittest
+
+package rbac_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	repositoryMocks "github.com/fgeck/gotth-postgres/internal/repository/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/rbac"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type rbacServiceMocks struct {
+	queries *repositoryMocks.MockQuerier
+}
+
+func setupRbacServiceTest(t *testing.T) (*rbacServiceMocks, *rbac.RbacService) {
+	mocks := &rbacServiceMocks{
+		queries: repositoryMocks.NewMockQuerier(t),
+	}
+	service := rbac.NewRbacService(mocks.queries)
+	return mocks, service
+}
+
+func newRole(name string) repository.Role {
+	return repository.Role{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, Name: name}
+}
+
+var knownPermissions = []repository.Permission{
+	{Name: rbac.PERMISSION_USERS_READ},
+	{Name: rbac.PERMISSION_USERS_WRITE},
+}
+
+var actorPermissions = []string{rbac.PERMISSION_USERS_READ, rbac.PERMISSION_USERS_WRITE}
+
+func TestLoadPermissions(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	pgUserID := pgtype.UUID{Bytes: userID, Valid: true}
+
+	mocks, service := setupRbacServiceTest(t)
+	mocks.queries.On("ListUserRoleNames", ctx, pgUserID).Return([]string{"SUPPORT", "USER"}, nil)
+	mocks.queries.On("ListUserPermissionNames", ctx, pgUserID).Return([]string{rbac.PERMISSION_USERS_READ}, nil)
+
+	userDto := &user.UserDto{ID: userID, Role: user.UserRoleUser}
+	require.NoError(t, service.LoadPermissions(ctx, userDto))
+
+	assert.Equal(t, []string{"SUPPORT", "USER"}, userDto.Roles)
+	assert.True(t, userDto.HasPermission(rbac.PERMISSION_USERS_READ))
+}
+
+func TestSetUserRoles(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	pgUserID := pgtype.UUID{Bytes: userID, Valid: true}
+	roles := []repository.Role{newRole("ADMIN"), newRole("SUPPORT"), newRole("USER")}
+
+	t.Run("replaces the granted roles", func(t *testing.T) {
+		mocks, service := setupRbacServiceTest(t)
+		mocks.queries.On("ListRoles", ctx).Return(roles, nil)
+		mocks.queries.On("SetUserRoles", ctx, repository.SetUserRolesParams{UserID: pgUserID, Roles: []string{"SUPPORT"}}).Return(nil)
+
+		require.NoError(t, service.SetUserRoles(ctx, userID, []string{" support"}))
+	})
+
+	t.Run("removes all granted roles", func(t *testing.T) {
+		mocks, service := setupRbacServiceTest(t)
+		mocks.queries.On("ListRoles", ctx).Return(roles, nil)
+		mocks.queries.On("SetUserRoles", ctx, repository.SetUserRolesParams{UserID: pgUserID, Roles: []string{}}).Return(nil)
+
+		require.NoError(t, service.SetUserRoles(ctx, userID, nil))
+	})
+
+	t.Run("rejects unknown roles", func(t *testing.T) {
+		mocks, service := setupRbacServiceTest(t)
+		mocks.queries.On("ListRoles", ctx).Return(roles, nil)
+
+		err := service.SetUserRoles(ctx, userID, []string{"AUDITOR"})
+
+		require.ErrorIs(t, err, rbac.ErrUnknownRole)
+		mocks.queries.AssertNotCalled(t, "SetUserRoles", mock.Anything, mock.Anything)
+	})
+}
+
+func TestCheckRolesHeld(t *testing.T) {
+	ctx := context.Background()
+	rows := []repository.ListRolePermissionNamesRow{
+		{RoleName: "ADMIN", PermissionName: rbac.PERMISSION_ROLES_WRITE},
+		{RoleName: "ADMIN", PermissionName: rbac.PERMISSION_USERS_WRITE},
+		{RoleName: "SUPPORT", PermissionName: rbac.PERMISSION_USERS_READ},
+	}
+
+	t.Run("accepts roles whose permissions are held", func(t *testing.T) {
+		mocks, service := setupRbacServiceTest(t)
+		mocks.queries.On("ListRolePermissionNames", ctx).Return(rows, nil)
+
+		err := service.CheckRolesHeld(ctx, []string{rbac.PERMISSION_USERS_READ, rbac.PERMISSION_USERS_WRITE}, []string{"support", "USER"})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects roles with permissions that are not held", func(t *testing.T) {
+		mocks, service := setupRbacServiceTest(t)
+		mocks.queries.On("ListRolePermissionNames", ctx).Return(rows, nil)
+
+		err := service.CheckRolesHeld(ctx, []string{rbac.PERMISSION_USERS_READ, rbac.PERMISSION_USERS_WRITE}, []string{"ADMIN"})
+
+		require.ErrorIs(t, err, rbac.ErrRoleNotHeld)
+	})
+}
+
+func TestListRoles(t *testing.T) {
+	ctx := context.Background()
+	mocks, service := setupRbacServiceTest(t)
+
+	mocks.queries.On("ListRoles", ctx).Return([]repository.Role{newRole("ADMIN"), newRole("USER")}, nil)
+	mocks.queries.On("ListRolePermissionNames", ctx).Return([]repository.ListRolePermissionNamesRow{
+		{RoleName: "ADMIN", PermissionName: rbac.PERMISSION_USERS_READ},
+		{RoleName: "ADMIN", PermissionName: rbac.PERMISSION_USERS_WRITE},
+	}, nil)
+
+	roles, err := service.ListRoles(ctx)
+
+	require.NoError(t, err)
+	require.Len(t, roles, 2)
+	assert.Equal(t, []string{rbac.PERMISSION_USERS_READ, rbac.PERMISSION_USERS_WRITE}, roles[0].Permissions)
+	assert.Empty(t, roles[1].Permissions)
+}
+
+func TestCreateRole(t *testing.T) {
+	ctx := context.Background()
+	role := newRole("SUPPORT")
+
+	t.Run("creates a role with permissions", func(t *testing.T) {
+		mocks, service := setupRbacServiceTest(t)
+		mocks.queries.On("ListPermissions", ctx).Return(knownPermissions, nil)
+		mocks.queries.On("GetRoleByName", ctx, "SUPPORT").Return(repository.Role{}, sql.ErrNoRows)
+		mocks.queries.On("CreateRole", ctx, repository.CreateRoleParams{Name: "SUPPORT", Description: "Helpdesk"}).Return(role, nil)
+		mocks.queries.On("AddRolePermissions", ctx, repository.AddRolePermissionsParams{
+			RoleID:      role.ID,
+			Permissions: []string{rbac.PERMISSION_USERS_READ},
+		}).Return(nil)
+
+		created, err := service.CreateRole(ctx, actorPermissions, "support", " Helpdesk ", []string{rbac.PERMISSION_USERS_READ})
+
+		require.NoError(t, err)
+		assert.Equal(t, "SUPPORT", created.Name)
+		assert.Equal(t, []string{rbac.PERMISSION_USERS_READ}, created.Permissions)
+	})
+
+	t.Run("rejects an invalid name", func(t *testing.T) {
+		_, service := setupRbacServiceTest(t)
+
+		_, err := service.CreateRole(ctx, actorPermissions, "help desk", "", nil)
+
+		require.ErrorIs(t, err, rbac.ErrInvalidRoleName)
+	})
+
+	t.Run("rejects unknown permissions", func(t *testing.T) {
+		mocks, service := setupRbacServiceTest(t)
+		mocks.queries.On("ListPermissions", ctx).Return(knownPermissions, nil)
+
+		_, err := service.CreateRole(ctx, actorPermissions, "SUPPORT", "", []string{"billing:write"})
+
+		require.ErrorIs(t, err, rbac.ErrUnknownPermission)
+	})
+
+	t.Run("rejects an existing name", func(t *testing.T) {
+		mocks, service := setupRbacServiceTest(t)
+		mocks.queries.On("GetRoleByName", ctx, "SUPPORT").Return(role, nil)
+
+		_, err := service.CreateRole(ctx, actorPermissions, "SUPPORT", "", nil)
+
+		require.ErrorIs(t, err, rbac.ErrRoleExists)
+	})
+
+	t.Run("does not grant permissions the actor does not hold", func(t *testing.T) {
+		mocks, service := setupRbacServiceTest(t)
+		mocks.queries.On("ListPermissions", ctx).Return(knownPermissions, nil)
+
+		_, err := service.CreateRole(ctx, []string{rbac.PERMISSION_USERS_READ}, "SUPPORT", "", []string{rbac.PERMISSION_USERS_WRITE})
+
+		require.ErrorIs(t, err, rbac.ErrPermissionNotHeld)
+		mocks.queries.AssertNotCalled(t, "CreateRole", mock.Anything, mock.Anything)
+	})
+}
+
+func TestSetRolePermissions(t *testing.T) {
+	ctx := context.Background()
+	role := newRole("SUPPORT")
+
+	t.Run("replaces the permissions", func(t *testing.T) {
+		mocks, service := setupRbacServiceTest(t)
+		mocks.queries.On("ListPermissions", ctx).Return(knownPermissions, nil)
+		mocks.queries.On("GetRoleByName", ctx, "SUPPORT").Return(role, nil)
+		mocks.queries.On("SetRolePermissions", ctx, repository.SetRolePermissionsParams{
+			RoleID:      role.ID,
+			Permissions: []string{rbac.PERMISSION_USERS_WRITE, rbac.PERMISSION_USERS_READ},
+		}).Return(nil)
+
+		updated, err := service.SetRolePermissions(ctx, actorPermissions, "SUPPORT", []string{rbac.PERMISSION_USERS_WRITE, rbac.PERMISSION_USERS_READ})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{rbac.PERMISSION_USERS_READ, rbac.PERMISSION_USERS_WRITE}, updated.Permissions)
+	})
+
+	t.Run("does not change built-in roles", func(t *testing.T) {
+		_, service := setupRbacServiceTest(t)
+
+		_, err := service.SetRolePermissions(ctx, actorPermissions, "admin", nil)
+
+		require.ErrorIs(t, err, rbac.ErrBuiltInRole)
+	})
+
+	t.Run("removes all permissions", func(t *testing.T) {
+		mocks, service := setupRbacServiceTest(t)
+		mocks.queries.On("GetRoleByName", ctx, "SUPPORT").Return(role, nil)
+		mocks.queries.On("SetRolePermissions", ctx, repository.SetRolePermissionsParams{
+			RoleID:      role.ID,
+			Permissions: []string{},
+		}).Return(nil)
+
+		updated, err := service.SetRolePermissions(ctx, actorPermissions, "SUPPORT", nil)
+
+		require.NoError(t, err)
+		assert.Empty(t, updated.Permissions)
+	})
+
+	t.Run("does not grant permissions the actor does not hold", func(t *testing.T) {
+		mocks, service := setupRbacServiceTest(t)
+		mocks.queries.On("ListPermissions", ctx).Return(knownPermissions, nil)
+
+		_, err := service.SetRolePermissions(ctx, []string{rbac.PERMISSION_USERS_READ}, "SUPPORT", []string{rbac.PERMISSION_USERS_WRITE})
+
+		require.ErrorIs(t, err, rbac.ErrPermissionNotHeld)
+		mocks.queries.AssertNotCalled(t, "SetRolePermissions", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects an unknown role", func(t *testing.T) {
+		mocks, service := setupRbacServiceTest(t)
+		mocks.queries.On("GetRoleByName", ctx, "AUDITOR").Return(repository.Role{}, sql.ErrNoRows)
+
+		_, err := service.SetRolePermissions(ctx, actorPermissions, "AUDITOR", nil)
+
+		require.ErrorIs(t, err, rbac.ErrUnknownRole)
+	})
+}
+
+func TestDeleteRole(t *testing.T) {
+	ctx := context.Background()
+
+	role := newRole("SUPPORT")
+
+	t.Run("deletes a role", func(t *testing.T) {
+		mocks, service := setupRbacServiceTest(t)
+		mocks.queries.On("GetRoleByName", ctx, "SUPPORT").Return(role, nil)
+		mocks.queries.On("CountRoleMemberships", ctx, role.ID).Return(int64(0), nil)
+		mocks.queries.On("DeleteRole", ctx, "SUPPORT").Return(int64(1), nil)
+
+		require.NoError(t, service.DeleteRole(ctx, "support"))
+	})
+
+	t.Run("does not delete built-in roles", func(t *testing.T) {
+		_, service := setupRbacServiceTest(t)
+
+		require.ErrorIs(t, service.DeleteRole(ctx, "USER"), rbac.ErrBuiltInRole)
+		require.ErrorIs(t, service.DeleteRole(ctx, rbac.ROLE_ORG_OWNER), rbac.ErrBuiltInRole)
+	})
+
+	t.Run("does not delete a role of organization members", func(t *testing.T) {
+		mocks, service := setupRbacServiceTest(t)
+		mocks.queries.On("GetRoleByName", ctx, "SUPPORT").Return(role, nil)
+		mocks.queries.On("CountRoleMemberships", ctx, role.ID).Return(int64(2), nil)
+
+		require.ErrorIs(t, service.DeleteRole(ctx, "SUPPORT"), rbac.ErrRoleInUse)
+		mocks.queries.AssertNotCalled(t, "DeleteRole", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects an unknown role", func(t *testing.T) {
+		mocks, service := setupRbacServiceTest(t)
+		mocks.queries.On("GetRoleByName", ctx, "AUDITOR").Return(repository.Role{}, sql.ErrNoRows)
+
+		require.ErrorIs(t, service.DeleteRole(ctx, "AUDITOR"), rbac.ErrUnknownRole)
+	})
+}
@@ -0,0 +1,107 @@
+package registration
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fgeck/gotth-postgres/internal/service/config"
+)
+
+const (
+	MODE_OPEN     = "open"
+	MODE_CLOSED   = "closed"
+	MODE_INVITE   = "invite"
+	MODE_DOMAIN   = "domain"
+	MODE_APPROVAL = "approval"
+)
+
+var (
+	ErrUnknownMode         = errors.New("unknown registration mode")
+	ErrNoAllowedDomains    = errors.New("registration mode domain needs allowed domains")
+	ErrRegistrationClosed  = errors.New("registration is closed")
+	ErrInvitationRequired  = errors.New("registration is by invitation only")
+	ErrEmailDomainRejected = errors.New("registration is not open for this email domain")
+)
+
+// RegistrationPolicy decides who may create an account. Admins can always
+// create accounts, invitations work in every mode but closed.
+type RegistrationPolicy struct {
+	mode            string
+	allowedDomains  []string
+	enumerationSafe bool
+}
+
+func NewRegistrationPolicy(cfg config.RegistrationConfig) (*RegistrationPolicy, error) {
+	mode := strings.ToLower(strings.TrimSpace(cfg.Mode))
+	switch mode {
+	case "":
+		mode = MODE_OPEN
+	case MODE_OPEN, MODE_CLOSED, MODE_INVITE, MODE_DOMAIN, MODE_APPROVAL:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownMode, cfg.Mode)
+	}
+
+	allowedDomains := make([]string, 0, len(cfg.AllowedDomains))
+	for _, domain := range cfg.AllowedDomains {
+		domain = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "@"))
+		if domain != "" {
+			allowedDomains = append(allowedDomains, domain)
+		}
+	}
+	if mode == MODE_DOMAIN && len(allowedDomains) == 0 {
+		return nil, ErrNoAllowedDomains
+	}
+
+	return &RegistrationPolicy{
+		mode:            mode,
+		allowedDomains:  allowedDomains,
+		enumerationSafe: cfg.EnumerationSafe,
+	}, nil
+}
+
+func (p *RegistrationPolicy) Mode() string {
+	return p.mode
+}
+
+// CheckSignUp tells whether the address may register itself. Allowed domains
+// have to match exactly, subdomains are not included.
+func (p *RegistrationPolicy) CheckSignUp(email string) error {
+	switch p.mode {
+	case MODE_CLOSED:
+		return ErrRegistrationClosed
+	case MODE_INVITE:
+		return ErrInvitationRequired
+	case MODE_DOMAIN:
+		at := strings.LastIndex(email, "@")
+		domain := strings.ToLower(email[at+1:])
+		for _, allowed := range p.allowedDomains {
+			if domain == allowed {
+				return nil
+			}
+		}
+
+		return ErrEmailDomainRejected
+	}
+
+	return nil
+}
+
+// CheckInvitation tells whether invitations may be sent and accepted.
+func (p *RegistrationPolicy) CheckInvitation() error {
+	if p.mode == MODE_CLOSED {
+		return ErrRegistrationClosed
+	}
+
+	return nil
+}
+
+// RequiresApproval tells whether accounts that registered themselves have to
+// be approved by an admin before they can log in.
+func (p *RegistrationPolicy) RequiresApproval() bool {
+	return p.mode == MODE_APPROVAL
+}
+
+func (p *RegistrationPolicy) EnumerationSafe() bool {
+	return p.enumerationSafe
+}
@@ -0,0 +1,73 @@
+//go:build unittest
+
+package registration_test
+
+import (
+	"testing"
+
+	"github.com/fgeck/gotth-postgres/internal/service/config"
+	"github.com/fgeck/gotth-postgres/internal/service/registration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRegistrationPolicy(t *testing.T) {
+	t.Run("defaults to open", func(t *testing.T) {
+		policy, err := registration.NewRegistrationPolicy(config.RegistrationConfig{})
+
+		require.NoError(t, err)
+		assert.Equal(t, registration.MODE_OPEN, policy.Mode())
+	})
+
+	t.Run("rejects unknown modes", func(t *testing.T) {
+		_, err := registration.NewRegistrationPolicy(config.RegistrationConfig{Mode: "sometimes"})
+
+		require.ErrorIs(t, err, registration.ErrUnknownMode)
+	})
+
+	t.Run("needs domains in domain mode", func(t *testing.T) {
+		_, err := registration.NewRegistrationPolicy(config.RegistrationConfig{Mode: registration.MODE_DOMAIN, AllowedDomains: []string{" "}})
+
+		require.ErrorIs(t, err, registration.ErrNoAllowedDomains)
+	})
+}
+
+func TestCheckSignUp(t *testing.T) {
+	newPolicy := func(t *testing.T, cfg config.RegistrationConfig) *registration.RegistrationPolicy {
+		policy, err := registration.NewRegistrationPolicy(cfg)
+		require.NoError(t, err)
+		return policy
+	}
+
+	t.Run("lets everybody sign up in open and approval mode", func(t *testing.T) {
+		for _, mode := range []string{registration.MODE_OPEN, registration.MODE_APPROVAL} {
+			assert.NoError(t, newPolicy(t, config.RegistrationConfig{Mode: mode}).CheckSignUp("user@example.com"))
+		}
+	})
+
+	t.Run("lets nobody sign up in closed and invite mode", func(t *testing.T) {
+		closed := newPolicy(t, config.RegistrationConfig{Mode: registration.MODE_CLOSED})
+		invite := newPolicy(t, config.RegistrationConfig{Mode: "Invite"})
+
+		require.ErrorIs(t, closed.CheckSignUp("user@example.com"), registration.ErrRegistrationClosed)
+		require.ErrorIs(t, invite.CheckSignUp("user@example.com"), registration.ErrInvitationRequired)
+	})
+
+	t.Run("only lets allowed domains sign up in domain mode", func(t *testing.T) {
+		policy := newPolicy(t, config.RegistrationConfig{Mode: registration.MODE_DOMAIN, AllowedDomains: []string{"@Example.com"}})
+
+		assert.NoError(t, policy.CheckSignUp("user@EXAMPLE.com"))
+		require.ErrorIs(t, policy.CheckSignUp("user@sub.example.com"), registration.ErrEmailDomainRejected)
+		require.ErrorIs(t, policy.CheckSignUp("user@example.com.evil.io"), registration.ErrEmailDomainRejected)
+	})
+}
+
+func TestCheckInvitation(t *testing.T) {
+	closed, err := registration.NewRegistrationPolicy(config.RegistrationConfig{Mode: registration.MODE_CLOSED})
+	require.NoError(t, err)
+	invite, err := registration.NewRegistrationPolicy(config.RegistrationConfig{Mode: registration.MODE_INVITE})
+	require.NoError(t, err)
+
+	require.ErrorIs(t, closed.CheckInvitation(), registration.ErrRegistrationClosed)
+	assert.NoError(t, invite.CheckInvitation())
+}
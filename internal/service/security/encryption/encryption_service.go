@@ -0,0 +1,68 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrCiphertextTooShort = errors.New("ciphertext too short")
+)
+
+type EncryptionServiceInterface interface {
+	Encrypt(plaintext []byte) (string, error)
+	Decrypt(ciphertext string) ([]byte, error)
+}
+
+// EncryptionService seals small secrets such as TOTP seeds with AES-256-GCM.
+type EncryptionService struct {
+	aead cipher.AEAD
+}
+
+// NewEncryptionService derives the AES key from the configured passphrase, so
+// any sufficiently random string can be used as key material.
+func NewEncryptionService(key string) (*EncryptionService, error) {
+	derivedKey := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(derivedKey[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gcm: %w", err)
+	}
+
+	return &EncryptionService{aead: aead}, nil
+}
+
+func (s *EncryptionService) Encrypt(plaintext []byte) (string, error) {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := s.aead.Seal(nonce, nonce, plaintext, nil)
+
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (s *EncryptionService) Decrypt(ciphertext string) ([]byte, error) {
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode ciphertext: %w", err)
+	}
+
+	if len(sealed) < s.aead.NonceSize() {
+		return nil, ErrCiphertextTooShort
+	}
+
+	nonce, sealed := sealed[:s.aead.NonceSize()], sealed[s.aead.NonceSize():]
+
+	return s.aead.Open(nil, nonce, sealed, nil)
+}
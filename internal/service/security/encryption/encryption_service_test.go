@@ -0,0 +1,55 @@
+//go:build unittest
+
+package encryption_test
+
+import (
+	"testing"
+
+	"github.com/fgeck/gotth-postgres/internal/service/security/encryption"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	t.Parallel()
+	encryptionService, err := encryption.NewEncryptionService("test-encryption-key")
+	require.NoError(t, err)
+
+	t.Run("round trips the plaintext", func(t *testing.T) {
+		t.Parallel()
+		ciphertext, err := encryptionService.Encrypt([]byte("secret"))
+		require.NoError(t, err)
+		assert.NotContains(t, ciphertext, "secret")
+
+		plaintext, err := encryptionService.Decrypt(ciphertext)
+		require.NoError(t, err)
+		assert.Equal(t, "secret", string(plaintext))
+	})
+
+	t.Run("uses a fresh nonce per encryption", func(t *testing.T) {
+		t.Parallel()
+		first, err := encryptionService.Encrypt([]byte("secret"))
+		require.NoError(t, err)
+		second, err := encryptionService.Encrypt([]byte("secret"))
+		require.NoError(t, err)
+
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("fails with a different key", func(t *testing.T) {
+		t.Parallel()
+		ciphertext, err := encryptionService.Encrypt([]byte("secret"))
+		require.NoError(t, err)
+		otherService, err := encryption.NewEncryptionService("other-key")
+		require.NoError(t, err)
+
+		_, err = otherService.Decrypt(ciphertext)
+		require.Error(t, err)
+	})
+
+	t.Run("fails for truncated ciphertext", func(t *testing.T) {
+		t.Parallel()
+		_, err := encryptionService.Decrypt("AAAA")
+		require.ErrorIs(t, err, encryption.ErrCiphertextTooShort)
+	})
+}
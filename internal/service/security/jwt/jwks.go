@@ -0,0 +1,69 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+)
+
+const (
+	EC_P256_COORDINATE_BYTES = 32
+)
+
+type Jwk struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+type JwkSet struct {
+	Keys []Jwk `json:"keys"`
+}
+
+func NewJwkSet(keys []*SigningKey) *JwkSet {
+	set := &JwkSet{Keys: make([]Jwk, 0, len(keys))}
+	for _, key := range keys {
+		if jwk, ok := NewJwk(key); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+
+	return set
+}
+
+// NewJwk encodes the public part of the key as described in RFC 7517/7518/8037.
+func NewJwk(key *SigningKey) (Jwk, bool) {
+	jwk := Jwk{Use: "sig", Kid: key.Kid, Alg: key.Method.Alg()}
+
+	switch public := key.verifyKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = encodeBase64Url(public.N.Bytes())
+		jwk.E = encodeBase64Url(big.NewInt(int64(public.E)).Bytes())
+	case *ecdsa.PublicKey:
+		jwk.Kty = "EC"
+		jwk.Crv = public.Curve.Params().Name
+		jwk.X = encodeBase64Url(public.X.FillBytes(make([]byte, EC_P256_COORDINATE_BYTES)))
+		jwk.Y = encodeBase64Url(public.Y.FillBytes(make([]byte, EC_P256_COORDINATE_BYTES)))
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = encodeBase64Url(public)
+	default:
+		return Jwk{}, false
+	}
+
+	return jwk, true
+}
+
+func encodeBase64Url(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
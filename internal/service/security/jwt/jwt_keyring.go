@@ -0,0 +1,281 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	ALGORITHM_HS256 = "HS256"
+	ALGORITHM_RS256 = "RS256"
+	ALGORITHM_ES256 = "ES256"
+	ALGORITHM_EDDSA = "EdDSA"
+	KID_HEADER      = "kid"
+	HMAC_KID        = "hmac"
+	// MIN_RSA_KEY_BITS only applies to keys of clients, the keys of the
+	// keyring are chosen by the operator.
+	MIN_RSA_KEY_BITS = 2048
+)
+
+var (
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+	ErrKeyAlgorithmMismatch = errors.New("key does not match signing algorithm")
+	ErrInvalidPem           = errors.New("no PEM block found")
+	ErrUnknownKid           = errors.New("unknown kid")
+	ErrNoActiveKey          = errors.New("active key must be able to sign")
+	ErrWeakKey              = errors.New("rsa keys need at least 2048 bits")
+)
+
+// SigningKey is a single entry of the Keyring. Keys without a private part
+// can only verify tokens, which is what retired keys look like during rotation.
+type SigningKey struct {
+	Kid       string
+	Method    gojwt.SigningMethod
+	signKey   any
+	verifyKey any
+}
+
+func NewHmacSigningKey(kid string, secret []byte) *SigningKey {
+	return &SigningKey{
+		Kid:       kid,
+		Method:    gojwt.SigningMethodHS256,
+		signKey:   secret,
+		verifyKey: secret,
+	}
+}
+
+func (k *SigningKey) CanSign() bool {
+	return k.signKey != nil
+}
+
+func (k *SigningKey) IsAsymmetric() bool {
+	_, ok := k.Method.(*gojwt.SigningMethodHMAC)
+	return !ok
+}
+
+// LoadSigningKey reads a PEM encoded key from disk. A private key may sign and
+// verify, a public key only verifies.
+func LoadSigningKey(kid, algorithm, privateKeyPath, publicKeyPath string) (*SigningKey, error) {
+	method, err := signingMethod(algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	if privateKeyPath != "" {
+		pemBytes, err := os.ReadFile(privateKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read private key %q: %w", kid, err)
+		}
+
+		return ParsePrivateSigningKey(kid, method, pemBytes)
+	}
+
+	pemBytes, err := os.ReadFile(publicKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key %q: %w", kid, err)
+	}
+
+	return ParsePublicSigningKey(kid, method, pemBytes)
+}
+
+func ParsePrivateSigningKey(kid string, method gojwt.SigningMethod, pemBytes []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, ErrInvalidPem
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key %q: %w", kid, err)
+	}
+
+	var public any
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		public = &k.PublicKey
+	case *ecdsa.PrivateKey:
+		public = &k.PublicKey
+	case ed25519.PrivateKey:
+		public = k.Public()
+	}
+	if err := checkKeyMatchesMethod(method, public); err != nil {
+		return nil, fmt.Errorf("%w: %q", err, kid)
+	}
+
+	return &SigningKey{Kid: kid, Method: method, signKey: key, verifyKey: public}, nil
+}
+
+func ParsePublicSigningKey(kid string, method gojwt.SigningMethod, pemBytes []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, ErrInvalidPem
+	}
+
+	public, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key %q: %w", kid, err)
+	}
+	if err := checkKeyMatchesMethod(method, public); err != nil {
+		return nil, fmt.Errorf("%w: %q", err, kid)
+	}
+
+	return &SigningKey{Kid: kid, Method: method, verifyKey: public}, nil
+}
+
+// ParsePublicKey is ParsePublicSigningKey for keys that come without an
+// algorithm, which follows from the type of the key instead.
+func ParsePublicKey(kid string, pemBytes []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, ErrInvalidPem
+	}
+
+	public, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key %q: %w", kid, err)
+	}
+	var method gojwt.SigningMethod
+	switch k := public.(type) {
+	case *rsa.PublicKey:
+		if k.N.BitLen() < MIN_RSA_KEY_BITS {
+			return nil, fmt.Errorf("%w: %q", ErrWeakKey, kid)
+		}
+		method = gojwt.SigningMethodRS256
+	case *ecdsa.PublicKey:
+		method = gojwt.SigningMethodES256
+	case ed25519.PublicKey:
+		method = gojwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, kid)
+	}
+	if err := checkKeyMatchesMethod(method, public); err != nil {
+		return nil, fmt.Errorf("%w: %q", err, kid)
+	}
+
+	return &SigningKey{Kid: kid, Method: method, verifyKey: public}, nil
+}
+
+func signingMethod(algorithm string) (gojwt.SigningMethod, error) {
+	switch algorithm {
+	case ALGORITHM_HS256:
+		return gojwt.SigningMethodHS256, nil
+	case ALGORITHM_RS256:
+		return gojwt.SigningMethodRS256, nil
+	case ALGORITHM_ES256:
+		return gojwt.SigningMethodES256, nil
+	case ALGORITHM_EDDSA:
+		return gojwt.SigningMethodEdDSA, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, algorithm)
+	}
+}
+
+func checkKeyMatchesMethod(method gojwt.SigningMethod, public any) error {
+	switch k := public.(type) {
+	case *rsa.PublicKey:
+		if method == gojwt.SigningMethodRS256 {
+			return nil
+		}
+	case *ecdsa.PublicKey:
+		if method == gojwt.SigningMethodES256 && k.Curve == elliptic.P256() {
+			return nil
+		}
+	case ed25519.PublicKey:
+		if method == gojwt.SigningMethodEdDSA {
+			return nil
+		}
+	}
+
+	return ErrKeyAlgorithmMismatch
+}
+
+// Keyring holds every key that is currently accepted for verification and the
+// one active key that signs new tokens. Rotating means adding a new key,
+// making it active and dropping the old one once its tokens have expired.
+type Keyring struct {
+	active *SigningKey
+	keys   map[string]*SigningKey
+}
+
+func NewKeyring(active *SigningKey, others ...*SigningKey) (*Keyring, error) {
+	if active == nil || !active.CanSign() {
+		return nil, ErrNoActiveKey
+	}
+
+	keys := map[string]*SigningKey{active.Kid: active}
+	for _, key := range others {
+		keys[key.Kid] = key
+	}
+
+	return &Keyring{active: active, keys: keys}, nil
+}
+
+func NewHmacKeyring(secret string) *Keyring {
+	key := NewHmacSigningKey(HMAC_KID, []byte(secret))
+
+	return &Keyring{active: key, keys: map[string]*SigningKey{key.Kid: key}}
+}
+
+func (k *Keyring) Active() *SigningKey {
+	return k.active
+}
+
+func (k *Keyring) Sign(claims gojwt.Claims) (string, error) {
+	token := gojwt.NewWithClaims(k.active.Method, claims)
+	token.Header[KID_HEADER] = k.active.Kid
+
+	return token.SignedString(k.active.signKey)
+}
+
+// Keyfunc selects the verification key by the kid header. Tokens without a
+// kid were issued before key rotation existed and are checked against the
+// active key.
+func (k *Keyring) Keyfunc(token *gojwt.Token) (any, error) {
+	key := k.active
+	if kid, ok := token.Header[KID_HEADER].(string); ok {
+		key, ok = k.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownKid, kid)
+		}
+	}
+
+	if token.Method.Alg() != key.Method.Alg() {
+		//nolint
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	return key.verifyKey, nil
+}
+
+// PublicKeys returns the asymmetric keys sorted by kid. HMAC secrets are
+// never part of it.
+func (k *Keyring) PublicKeys() []*SigningKey {
+	keys := make([]*SigningKey, 0, len(k.keys))
+	for _, key := range k.keys {
+		if key.IsAsymmetric() {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Kid < keys[j].Kid })
+
+	return keys
+}
@@ -0,0 +1,200 @@
+//go:build unittest
+
+package jwt_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/fgeck/gotth-postgres/internal/service/security/jwt"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func privateKeyPem(t *testing.T, key any) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func publicKeyPem(t *testing.T, key any) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func newUserDto() *user.UserDto {
+	return &user.UserDto{ID: uuid.New(), Role: user.UserRoleUser}
+}
+
+func TestAsymmetricSigning(t *testing.T) {
+	t.Parallel()
+	rsaKey := generatePrivateKey(t)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		method gojwt.SigningMethod
+		key    any
+		kty    string
+	}{
+		{"RS256", gojwt.SigningMethodRS256, rsaKey, "RSA"},
+		{"ES256", gojwt.SigningMethodES256, ecKey, "EC"},
+		{"EdDSA", gojwt.SigningMethodEdDSA, edKey, "OKP"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			signingKey, err := jwt.ParsePrivateSigningKey("kid-"+tt.name, tt.method, privateKeyPem(t, tt.key))
+			require.NoError(t, err)
+			keyring, err := jwt.NewKeyring(signingKey)
+			require.NoError(t, err)
+			jwtService := jwt.NewJwtServiceWithKeyring(keyring, "test-issuer", 3600)
+
+			token, err := jwtService.GenerateToken(newUserDto())
+			require.NoError(t, err)
+
+			parsed, _, err := gojwt.NewParser().ParseUnverified(token, &jwt.JwtCustomClaims{})
+			require.NoError(t, err)
+			assert.Equal(t, "kid-"+tt.name, parsed.Header["kid"])
+			assert.Equal(t, tt.method.Alg(), parsed.Header["alg"])
+
+			_, err = jwtService.ValidateAndExtractClaims(token)
+			require.NoError(t, err)
+
+			jwks := jwtService.PublicJwks()
+			require.Len(t, jwks.Keys, 1)
+			assert.Equal(t, tt.kty, jwks.Keys[0].Kty)
+			assert.Equal(t, "kid-"+tt.name, jwks.Keys[0].Kid)
+			assert.Equal(t, tt.method.Alg(), jwks.Keys[0].Alg)
+		})
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	t.Parallel()
+	oldKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	newKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	oldSigningKey, err := jwt.ParsePrivateSigningKey("old", gojwt.SigningMethodES256, privateKeyPem(t, oldKey))
+	require.NoError(t, err)
+	oldKeyring, err := jwt.NewKeyring(oldSigningKey)
+	require.NoError(t, err)
+	oldToken, err := jwt.NewJwtServiceWithKeyring(oldKeyring, "test-issuer", 3600).GenerateToken(newUserDto())
+	require.NoError(t, err)
+
+	retiredKey, err := jwt.ParsePublicSigningKey("old", gojwt.SigningMethodES256, publicKeyPem(t, &oldKey.PublicKey))
+	require.NoError(t, err)
+	activeKey, err := jwt.ParsePrivateSigningKey("new", gojwt.SigningMethodES256, privateKeyPem(t, newKey))
+	require.NoError(t, err)
+	keyring, err := jwt.NewKeyring(activeKey, retiredKey)
+	require.NoError(t, err)
+	jwtService := jwt.NewJwtServiceWithKeyring(keyring, "test-issuer", 3600)
+
+	t.Run("Tokens of the retired key stay valid", func(t *testing.T) {
+		t.Parallel()
+		_, err := jwtService.ValidateAndExtractClaims(oldToken)
+		require.NoError(t, err)
+	})
+
+	t.Run("New tokens are signed with the active key", func(t *testing.T) {
+		t.Parallel()
+		token, err := jwtService.GenerateToken(newUserDto())
+		require.NoError(t, err)
+		parsed, _, err := gojwt.NewParser().ParseUnverified(token, &jwt.JwtCustomClaims{})
+		require.NoError(t, err)
+		assert.Equal(t, "new", parsed.Header["kid"])
+	})
+
+	t.Run("JWKS publishes both keys", func(t *testing.T) {
+		t.Parallel()
+		jwks := jwtService.PublicJwks()
+		require.Len(t, jwks.Keys, 2)
+		assert.Equal(t, "new", jwks.Keys[0].Kid)
+		assert.Equal(t, "old", jwks.Keys[1].Kid)
+	})
+
+	t.Run("Tokens with an unknown kid are rejected", func(t *testing.T) {
+		t.Parallel()
+		otherKey, err := jwt.ParsePrivateSigningKey("unknown", gojwt.SigningMethodES256, privateKeyPem(t, oldKey))
+		require.NoError(t, err)
+		otherKeyring, err := jwt.NewKeyring(otherKey)
+		require.NoError(t, err)
+		token, err := jwt.NewJwtServiceWithKeyring(otherKeyring, "test-issuer", 3600).GenerateToken(newUserDto())
+		require.NoError(t, err)
+
+		_, err = jwtService.ValidateAndExtractClaims(token)
+		require.ErrorIs(t, err, jwt.ErrUnknownKid)
+	})
+
+	t.Run("A verify-only key cannot become active", func(t *testing.T) {
+		t.Parallel()
+		_, err := jwt.NewKeyring(retiredKey)
+		require.ErrorIs(t, err, jwt.ErrNoActiveKey)
+	})
+}
+
+func TestParsePrivateSigningKey(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Rejects key that does not match the algorithm", func(t *testing.T) {
+		t.Parallel()
+		_, err := jwt.ParsePrivateSigningKey("rsa", gojwt.SigningMethodES256, privateKeyPem(t, generatePrivateKey(t)))
+		require.ErrorIs(t, err, jwt.ErrKeyAlgorithmMismatch)
+	})
+
+	t.Run("Rejects invalid PEM", func(t *testing.T) {
+		t.Parallel()
+		_, err := jwt.ParsePrivateSigningKey("broken", gojwt.SigningMethodRS256, []byte("not a pem"))
+		require.ErrorIs(t, err, jwt.ErrInvalidPem)
+	})
+}
+
+func TestParsePublicKey(t *testing.T) {
+	t.Parallel()
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	weakRsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	t.Run("Detects the algorithm", func(t *testing.T) {
+		t.Parallel()
+		rsaKey, err := jwt.ParsePublicKey("rsa", publicKeyPem(t, &generatePrivateKey(t).PublicKey))
+		require.NoError(t, err)
+		assert.Equal(t, gojwt.SigningMethodRS256, rsaKey.Method)
+
+		key, err := jwt.ParsePublicKey("ec", publicKeyPem(t, &ecKey.PublicKey))
+		require.NoError(t, err)
+		assert.Equal(t, gojwt.SigningMethodES256, key.Method)
+	})
+
+	t.Run("Rejects short RSA keys", func(t *testing.T) {
+		t.Parallel()
+		_, err := jwt.ParsePublicKey("weak", publicKeyPem(t, &weakRsaKey.PublicKey))
+		require.ErrorIs(t, err, jwt.ErrWeakKey)
+	})
+}
+
+func TestHmacKeyringIsNotPublished(t *testing.T) {
+	t.Parallel()
+	jwtService := jwt.NewJwtService(TEST_SECRET, "test-issuer", 3600)
+
+	assert.Empty(t, jwtService.PublicJwks().Keys)
+}
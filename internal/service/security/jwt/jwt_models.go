@@ -1,20 +1,54 @@
 package jwt
 
 import (
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/service/user"
 	goJwt "github.com/golang-jwt/jwt/v5"
 )
 
 const (
 	USER_ID   = "userId"
 	USER_ROLE = "userRole"
+
+	PURPOSE_MFA_PENDING        = "mfa_pending"
+	PURPOSE_EMAIL_VERIFICATION = "email_verification"
+	PURPOSE_PASSWORD_CHANGE    = "password_change"
 )
 
 type JwtCustomClaims struct {
 	UserId   string `json:"userId"`
 	UserRole string `json:"userRole"`
+	// Roles and Permissions are a snapshot taken when the token was issued,
+	// changes take effect with the next refresh.
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	// TenantId is the active organization, TenantRole the role of the user
+	// in it. Both are empty outside of an organization.
+	TenantId   string `json:"tenantId,omitempty"`
+	TenantRole string `json:"tenantRole,omitempty"`
+	// Purpose is empty for regular access tokens. Tokens with a purpose carry
+	// a matching audience and are only accepted by the endpoint that asked
+	// for them.
+	Purpose string `json:"purpose,omitempty"`
+	// AccountType is only set for service accounts, whose tokens do not
+	// belong to a session.
+	AccountType string `json:"accountType,omitempty"`
 	goJwt.RegisteredClaims
 }
 
+func (c *JwtCustomClaims) IsServiceAccount() bool {
+	return c.AccountType == user.ACCOUNT_TYPE_SERVICE
+}
+
+// ClientAssertion is a validated client assertion. Its ID has to be recorded
+// until ExpiresAt, so that the assertion cannot be used a second time.
+type ClientAssertion struct {
+	Key       *SigningKey
+	ID        string
+	ExpiresAt time.Time
+}
+
 func NewJwtCustomClaims(userId, userRole string, standardClaims goJwt.RegisteredClaims) *JwtCustomClaims {
 	return &JwtCustomClaims{
 		UserId:           userId,
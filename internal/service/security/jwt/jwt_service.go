@@ -13,17 +13,30 @@ import (
 type JwtServiceInterface interface {
 	GenerateToken(user *user.UserDto) (string, error)
 	ValidateAndExtractClaims(givenToken string) (*JwtCustomClaims, error)
+	GenerateMfaPendingToken(user *user.UserDto) (string, error)
+	ValidateMfaPendingToken(givenToken string) (*JwtCustomClaims, error)
+	GenerateEmailVerificationToken(user *user.UserDto, tokenID uuid.UUID) (string, error)
+	ValidateEmailVerificationToken(givenToken string) (*JwtCustomClaims, error)
+	GeneratePasswordChangeToken(user *user.UserDto) (string, error)
+	ValidatePasswordChangeToken(givenToken string) (*JwtCustomClaims, error)
+	ValidateClientAssertion(assertion, clientID, audience string, keys []*SigningKey) (*ClientAssertion, error)
+	AccessTokenExpiration() time.Duration
+	PublicJwks() *JwkSet
 }
 
 type JwtService struct {
-	secretKey  string
+	keyring    *Keyring
 	issuer     string
 	expiration int64
 }
 
 func NewJwtService(secretKey, issuer string, expiration int64) *JwtService {
+	return NewJwtServiceWithKeyring(NewHmacKeyring(secretKey), issuer, expiration)
+}
+
+func NewJwtServiceWithKeyring(keyring *Keyring, issuer string, expiration int64) *JwtService {
 	return &JwtService{
-		secretKey:  secretKey,
+		keyring:    keyring,
 		issuer:     issuer,
 		expiration: expiration,
 	}
@@ -36,9 +49,56 @@ var (
 	ErrMissingUserIdClaim   = errors.New("missing userId claim")
 	ErrMissingUserRoleClaim = errors.New("missing userRole claim")
 	ErrInvalidClaims        = errors.New("userId or userRole claim is nil")
+	ErrUnexpectedPurpose    = errors.New("token was issued for a different purpose")
+	ErrInvalidAssertion     = errors.New("client assertion is invalid")
+)
+
+const (
+	MFA_PENDING_TOKEN_EXPIRATION        = 5 * time.Minute
+	EMAIL_VERIFICATION_TOKEN_EXPIRATION = 24 * time.Hour
+	PASSWORD_CHANGE_TOKEN_EXPIRATION    = 10 * time.Minute
+	MAX_CLIENT_ASSERTION_EXPIRATION     = 5 * time.Minute
 )
 
 func (s *JwtService) GenerateToken(user *user.UserDto) (string, error) {
+	return s.generate(user, "", uuid.New(), time.Duration(s.expiration)*time.Second)
+}
+
+// GenerateMfaPendingToken issues a short-lived token proving that the password
+// step of a login succeeded. It is rejected everywhere but the MFA verify step.
+func (s *JwtService) GenerateMfaPendingToken(user *user.UserDto) (string, error) {
+	return s.generate(user, PURPOSE_MFA_PENDING, uuid.New(), MFA_PENDING_TOKEN_EXPIRATION)
+}
+
+// GenerateEmailVerificationToken signs the token sent in verification links.
+// Its jti is the id of the stored token row, which makes the link single-use.
+func (s *JwtService) GenerateEmailVerificationToken(user *user.UserDto, tokenID uuid.UUID) (string, error) {
+	return s.generate(user, PURPOSE_EMAIL_VERIFICATION, tokenID, EMAIL_VERIFICATION_TOKEN_EXPIRATION)
+}
+
+// GeneratePasswordChangeToken is issued instead of a session when the password
+// of a login has expired. It only allows setting a new password.
+func (s *JwtService) GeneratePasswordChangeToken(user *user.UserDto) (string, error) {
+	return s.generate(user, PURPOSE_PASSWORD_CHANGE, uuid.New(), PASSWORD_CHANGE_TOKEN_EXPIRATION)
+}
+
+func (s *JwtService) ValidateAndExtractClaims(givenToken string) (*JwtCustomClaims, error) {
+	return s.validate(givenToken, "")
+}
+
+func (s *JwtService) ValidateMfaPendingToken(givenToken string) (*JwtCustomClaims, error) {
+	return s.validate(givenToken, PURPOSE_MFA_PENDING)
+}
+
+func (s *JwtService) ValidateEmailVerificationToken(givenToken string) (*JwtCustomClaims, error) {
+	return s.validate(givenToken, PURPOSE_EMAIL_VERIFICATION)
+}
+
+func (s *JwtService) ValidatePasswordChangeToken(givenToken string) (*JwtCustomClaims, error) {
+	return s.validate(givenToken, PURPOSE_PASSWORD_CHANGE)
+}
+
+func (s *JwtService) generate(user *user.UserDto, purpose string, tokenID uuid.UUID, expiration time.Duration) (string, error) {
 	if user.ID == uuid.Nil || user.ID.String() == "" {
 		return "", ErrEmptyUserId
 	}
@@ -51,31 +111,47 @@ func (s *JwtService) GenerateToken(user *user.UserDto) (string, error) {
 		user.ID.String(),
 		user.Role.Name,
 		gojwt.RegisteredClaims{
+			ID:        tokenID.String(),
 			Issuer:    s.issuer,
 			IssuedAt:  gojwt.NewNumericDate(now),
-			ExpiresAt: gojwt.NewNumericDate(now.Add(time.Duration(s.expiration) * time.Second)),
+			ExpiresAt: gojwt.NewNumericDate(now.Add(expiration)),
 			NotBefore: gojwt.NewNumericDate(now),
 		},
 	)
+	claims.Purpose = purpose
+	if purpose != "" {
+		claims.Audience = gojwt.ClaimStrings{s.audience(purpose)}
+	}
+	if user.IsServiceAccount() {
+		claims.AccountType = user.AccountType
+	}
+	claims.Roles = user.Roles
+	claims.Permissions = user.Permissions
+	if user.TenantID != uuid.Nil {
+		claims.TenantId = user.TenantID.String()
+		claims.TenantRole = user.TenantRole
+	}
 
-	token := gojwt.NewWithClaims(gojwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.secretKey))
+	return s.keyring.Sign(claims)
 }
 
-func (s *JwtService) ValidateAndExtractClaims(givenToken string) (*JwtCustomClaims, error) {
+func (s *JwtService) validate(givenToken, purpose string) (*JwtCustomClaims, error) {
+	options := []gojwt.ParserOption{gojwt.WithExpirationRequired()}
+	if purpose != "" {
+		options = append(options, gojwt.WithAudience(s.audience(purpose)))
+	}
+	parsedClaims := &JwtCustomClaims{}
 	token, err := gojwt.ParseWithClaims(
 		givenToken,
-		&JwtCustomClaims{},
-		func(token *gojwt.Token) (interface{}, error) {
-			// Ensure the signing method is HMAC
-			if _, ok := token.Method.(*gojwt.SigningMethodHMAC); !ok {
-				//nolint
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-			return []byte(s.secretKey), nil
-		},
+		parsedClaims,
+		s.keyring.Keyfunc,
+		options...,
 	)
-
+	// The signature is fine, but the token was issued for another endpoint.
+	if errors.Is(err, gojwt.ErrTokenInvalidAudience) ||
+		(errors.Is(err, gojwt.ErrTokenInvalidClaims) && parsedClaims.Purpose != purpose) {
+		return nil, ErrUnexpectedPurpose
+	}
 	if err != nil {
 		return nil, fmt.Errorf("invalid token: %w", err)
 	}
@@ -88,8 +164,65 @@ func (s *JwtService) ValidateAndExtractClaims(givenToken string) (*JwtCustomClai
 		if claims.UserRole == "" {
 			return nil, ErrMissingUserRoleClaim
 		}
+		// Access tokens have no audience, so a purpose token is rejected
+		// even if its purpose claim was dropped.
+		if claims.Purpose != purpose || (purpose == "" && len(claims.Audience) > 0) {
+			return nil, ErrUnexpectedPurpose
+		}
 		return claims, nil
 	}
 
 	return nil, ErrInvalidTokenClaims
 }
+
+// audience binds a purpose token to the endpoint that asked for it, so that
+// no other verifier of this issuer accepts it.
+func (s *JwtService) audience(purpose string) string {
+	return s.issuer + "/" + purpose
+}
+
+// ValidateClientAssertion checks a JWT that a client signed with one of its
+// own keys to authenticate, as described in RFC 7523. The client has to be
+// issuer and subject, the audience the endpoint it authenticates at, and the
+// token may be valid for MAX_CLIENT_ASSERTION_EXPIRATION at most. It has to
+// carry a jti, which the caller records to reject replays. It returns the
+// assertion together with the key that verified it.
+func (s *JwtService) ValidateClientAssertion(assertion, clientID, audience string, keys []*SigningKey) (*ClientAssertion, error) {
+	for _, key := range keys {
+		claims := &gojwt.RegisteredClaims{}
+		_, err := gojwt.ParseWithClaims(
+			assertion,
+			claims,
+			func(*gojwt.Token) (any, error) { return key.verifyKey, nil },
+			gojwt.WithValidMethods([]string{key.Method.Alg()}),
+			gojwt.WithExpirationRequired(),
+			gojwt.WithIssuer(clientID),
+			gojwt.WithSubject(clientID),
+			gojwt.WithAudience(audience),
+		)
+		if err != nil {
+			continue
+		}
+		if time.Until(claims.ExpiresAt.Time) > MAX_CLIENT_ASSERTION_EXPIRATION {
+			return nil, ErrInvalidAssertion
+		}
+		if claims.ID == "" {
+			return nil, ErrInvalidAssertion
+		}
+
+		return &ClientAssertion{Key: key, ID: claims.ID, ExpiresAt: claims.ExpiresAt.Time}, nil
+	}
+
+	return nil, ErrInvalidAssertion
+}
+
+// AccessTokenExpiration is how long tokens of GenerateToken are valid.
+func (s *JwtService) AccessTokenExpiration() time.Duration {
+	return time.Duration(s.expiration) * time.Second
+}
+
+// PublicJwks exposes the verification keys so that other services can check
+// tokens without knowing any secret.
+func (s *JwtService) PublicJwks() *JwkSet {
+	return NewJwkSet(s.keyring.PublicKeys())
+}
@@ -88,6 +88,90 @@ func TestValidateAndExtractClaims(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, userID.String(), extractedClaims.UserId)
 		assert.Equal(t, user.UserRoleAdmin.Name, extractedClaims.UserRole)
+		assert.NotEmpty(t, extractedClaims.ID, "token must carry a jti for revocation")
+	})
+
+	t.Run("Carries Roles and Permissions", func(t *testing.T) {
+		t.Parallel()
+		userDto := &user.UserDto{
+			ID:          uuid.New(),
+			Role:        user.UserRoleUser,
+			Roles:       []string{"SUPPORT", "USER"},
+			Permissions: []string{"users:read"},
+		}
+
+		token, err := jwtService.GenerateToken(userDto)
+		require.NoError(t, err)
+
+		extractedClaims, err := jwtService.ValidateAndExtractClaims(token)
+		require.NoError(t, err)
+		assert.Equal(t, userDto.Roles, extractedClaims.Roles)
+		assert.Equal(t, userDto.Permissions, extractedClaims.Permissions)
+	})
+
+	t.Run("Carries the active tenant", func(t *testing.T) {
+		t.Parallel()
+		tenantID := uuid.New()
+		userDto := &user.UserDto{
+			ID:         uuid.New(),
+			Role:       user.UserRoleUser,
+			TenantID:   tenantID,
+			TenantRole: "ORG_OWNER",
+		}
+
+		token, err := jwtService.GenerateToken(userDto)
+		require.NoError(t, err)
+
+		extractedClaims, err := jwtService.ValidateAndExtractClaims(token)
+		require.NoError(t, err)
+		assert.Equal(t, tenantID.String(), extractedClaims.TenantId)
+		assert.Equal(t, "ORG_OWNER", extractedClaims.TenantRole)
+	})
+
+	t.Run("Marks tokens of service accounts", func(t *testing.T) {
+		t.Parallel()
+		userDto := &user.UserDto{
+			ID:          uuid.New(),
+			Role:        user.UserRoleUser,
+			AccountType: user.ACCOUNT_TYPE_SERVICE,
+		}
+
+		token, err := jwtService.GenerateToken(userDto)
+		require.NoError(t, err)
+
+		extractedClaims, err := jwtService.ValidateAndExtractClaims(token)
+		require.NoError(t, err)
+		assert.True(t, extractedClaims.IsServiceAccount())
+	})
+
+	t.Run("Has no tenant outside of an organization", func(t *testing.T) {
+		t.Parallel()
+		userDto := &user.UserDto{ID: uuid.New(), Role: user.UserRoleUser}
+
+		token, err := jwtService.GenerateToken(userDto)
+		require.NoError(t, err)
+
+		extractedClaims, err := jwtService.ValidateAndExtractClaims(token)
+		require.NoError(t, err)
+		assert.Empty(t, extractedClaims.TenantId)
+	})
+
+	t.Run("No Expiration in Parsed Token", func(t *testing.T) {
+		t.Parallel()
+		claims := gojwt.MapClaims{
+			"userId":   uuid.New().String(),
+			"userRole": "admin",
+			"iss":      "test-issuer",
+			"iat":      time.Now().Unix(),
+		}
+
+		token := gojwt.NewWithClaims(gojwt.SigningMethodHS256, claims)
+		signedToken, err := token.SignedString([]byte(TEST_SECRET))
+		require.NoError(t, err)
+
+		extractedClaims, err := jwtService.ValidateAndExtractClaims(signedToken)
+		require.Error(t, err)
+		assert.Nil(t, extractedClaims)
 	})
 
 	t.Run("Invalid Token", func(t *testing.T) {
@@ -164,3 +248,224 @@ func TestValidateAndExtractClaims(t *testing.T) {
 		assert.Contains(t, err.Error(), "missing userRole claim")
 	})
 }
+
+func TestMfaPendingToken(t *testing.T) {
+	t.Parallel()
+	jwtService := jwt.NewJwtService(TEST_SECRET, "test-issuer", 3600)
+	userDto := &user.UserDto{
+		ID:   uuid.New(),
+		Role: user.UserRoleUser,
+	}
+
+	t.Run("Only the MFA verify step accepts it", func(t *testing.T) {
+		t.Parallel()
+		token, err := jwtService.GenerateMfaPendingToken(userDto)
+		require.NoError(t, err)
+
+		claims, err := jwtService.ValidateMfaPendingToken(token)
+		require.NoError(t, err)
+		assert.Equal(t, userDto.ID.String(), claims.UserId)
+		assert.WithinDuration(t, time.Now().Add(jwt.MFA_PENDING_TOKEN_EXPIRATION), claims.ExpiresAt.Time, time.Minute)
+
+		_, err = jwtService.ValidateAndExtractClaims(token)
+		require.ErrorIs(t, err, jwt.ErrUnexpectedPurpose)
+	})
+
+	t.Run("Is bound to the audience of its purpose", func(t *testing.T) {
+		t.Parallel()
+		token, err := jwtService.GenerateMfaPendingToken(userDto)
+		require.NoError(t, err)
+
+		claims, err := jwtService.ValidateMfaPendingToken(token)
+		require.NoError(t, err)
+		assert.Equal(t, gojwt.ClaimStrings{"test-issuer/" + jwt.PURPOSE_MFA_PENDING}, claims.Audience)
+	})
+
+	t.Run("Rejects a token with the purpose but another audience", func(t *testing.T) {
+		t.Parallel()
+		claims := gojwt.MapClaims{
+			"userId":   userDto.ID.String(),
+			"userRole": userDto.Role.Name,
+			"purpose":  jwt.PURPOSE_MFA_PENDING,
+			"aud":      "test-issuer/" + jwt.PURPOSE_PASSWORD_CHANGE,
+			"exp":      time.Now().Add(time.Minute).Unix(),
+		}
+		token, err := gojwt.NewWithClaims(gojwt.SigningMethodHS256, claims).SignedString([]byte(TEST_SECRET))
+		require.NoError(t, err)
+
+		_, err = jwtService.ValidateMfaPendingToken(token)
+		require.ErrorIs(t, err, jwt.ErrUnexpectedPurpose)
+	})
+
+	t.Run("Rejects a token with its audience as access token", func(t *testing.T) {
+		t.Parallel()
+		claims := gojwt.MapClaims{
+			"userId":   userDto.ID.String(),
+			"userRole": userDto.Role.Name,
+			"aud":      "test-issuer/" + jwt.PURPOSE_MFA_PENDING,
+			"exp":      time.Now().Add(time.Minute).Unix(),
+		}
+		token, err := gojwt.NewWithClaims(gojwt.SigningMethodHS256, claims).SignedString([]byte(TEST_SECRET))
+		require.NoError(t, err)
+
+		_, err = jwtService.ValidateAndExtractClaims(token)
+		require.ErrorIs(t, err, jwt.ErrUnexpectedPurpose)
+	})
+
+	t.Run("An access token is no MFA pending token", func(t *testing.T) {
+		t.Parallel()
+		token, err := jwtService.GenerateToken(userDto)
+		require.NoError(t, err)
+
+		_, err = jwtService.ValidateMfaPendingToken(token)
+		require.ErrorIs(t, err, jwt.ErrUnexpectedPurpose)
+	})
+}
+
+func TestEmailVerificationToken(t *testing.T) {
+	t.Parallel()
+	jwtService := jwt.NewJwtService(TEST_SECRET, "test-issuer", 3600)
+	userDto := &user.UserDto{
+		ID:   uuid.New(),
+		Role: user.UserRoleUser,
+	}
+	tokenID := uuid.New()
+
+	t.Run("Carries the token id and is rejected as access token", func(t *testing.T) {
+		t.Parallel()
+		token, err := jwtService.GenerateEmailVerificationToken(userDto, tokenID)
+		require.NoError(t, err)
+
+		claims, err := jwtService.ValidateEmailVerificationToken(token)
+		require.NoError(t, err)
+		assert.Equal(t, tokenID.String(), claims.ID)
+		assert.WithinDuration(t, time.Now().Add(jwt.EMAIL_VERIFICATION_TOKEN_EXPIRATION), claims.ExpiresAt.Time, time.Minute)
+
+		_, err = jwtService.ValidateAndExtractClaims(token)
+		require.ErrorIs(t, err, jwt.ErrUnexpectedPurpose)
+	})
+
+	t.Run("An MFA pending token is no verification token", func(t *testing.T) {
+		t.Parallel()
+		token, err := jwtService.GenerateMfaPendingToken(userDto)
+		require.NoError(t, err)
+
+		_, err = jwtService.ValidateEmailVerificationToken(token)
+		require.ErrorIs(t, err, jwt.ErrUnexpectedPurpose)
+	})
+}
+
+func TestPasswordChangeToken(t *testing.T) {
+	t.Parallel()
+	jwtService := jwt.NewJwtService(TEST_SECRET, "test-issuer", 3600)
+	userDto := &user.UserDto{
+		ID:   uuid.New(),
+		Role: user.UserRoleAdmin,
+	}
+
+	t.Run("Only the password change step accepts it", func(t *testing.T) {
+		t.Parallel()
+		token, err := jwtService.GeneratePasswordChangeToken(userDto)
+		require.NoError(t, err)
+
+		claims, err := jwtService.ValidatePasswordChangeToken(token)
+		require.NoError(t, err)
+		assert.Equal(t, userDto.ID.String(), claims.UserId)
+		assert.WithinDuration(t, time.Now().Add(jwt.PASSWORD_CHANGE_TOKEN_EXPIRATION), claims.ExpiresAt.Time, time.Minute)
+
+		_, err = jwtService.ValidateAndExtractClaims(token)
+		require.ErrorIs(t, err, jwt.ErrUnexpectedPurpose)
+		_, err = jwtService.ValidateMfaPendingToken(token)
+		require.ErrorIs(t, err, jwt.ErrUnexpectedPurpose)
+	})
+
+	t.Run("An MFA pending token is no password change token", func(t *testing.T) {
+		t.Parallel()
+		token, err := jwtService.GenerateMfaPendingToken(userDto)
+		require.NoError(t, err)
+
+		_, err = jwtService.ValidatePasswordChangeToken(token)
+		require.ErrorIs(t, err, jwt.ErrUnexpectedPurpose)
+	})
+}
+
+func TestValidateClientAssertion(t *testing.T) {
+	t.Parallel()
+	service := jwt.NewJwtService(TEST_SECRET, "test", 60)
+	clientID := uuid.New().String()
+	audience := "http://localhost:8081/oauth/token"
+	privateKey := generatePrivateKey(t)
+	publicKey, err := jwt.ParsePublicKey("client", publicKeyPem(t, &privateKey.PublicKey))
+	require.NoError(t, err)
+
+	sign := func(t *testing.T, claims gojwt.RegisteredClaims) string {
+		t.Helper()
+		assertion, err := gojwt.NewWithClaims(gojwt.SigningMethodRS256, claims).SignedString(privateKey)
+		require.NoError(t, err)
+		return assertion
+	}
+	validClaims := func() gojwt.RegisteredClaims {
+		return gojwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Issuer:    clientID,
+			Subject:   clientID,
+			Audience:  gojwt.ClaimStrings{audience},
+			ExpiresAt: gojwt.NewNumericDate(time.Now().Add(time.Minute)),
+		}
+	}
+
+	t.Run("Accepts an assertion signed with a client key", func(t *testing.T) {
+		t.Parallel()
+		otherKey, err := jwt.ParsePublicKey("other", publicKeyPem(t, &generatePrivateKey(t).PublicKey))
+		require.NoError(t, err)
+
+		claims := validClaims()
+
+		assertion, err := service.ValidateClientAssertion(sign(t, claims), clientID, audience, []*jwt.SigningKey{otherKey, publicKey})
+
+		require.NoError(t, err)
+		assert.Equal(t, "client", assertion.Key.Kid)
+		assert.Equal(t, claims.ID, assertion.ID)
+		assert.Equal(t, claims.ExpiresAt.Time, assertion.ExpiresAt)
+	})
+
+	t.Run("Rejects an assertion without jti", func(t *testing.T) {
+		t.Parallel()
+		claims := validClaims()
+		claims.ID = ""
+
+		_, err := service.ValidateClientAssertion(sign(t, claims), clientID, audience, []*jwt.SigningKey{publicKey})
+
+		require.ErrorIs(t, err, jwt.ErrInvalidAssertion)
+	})
+
+	t.Run("Rejects an assertion for another audience", func(t *testing.T) {
+		t.Parallel()
+		claims := validClaims()
+		claims.Audience = gojwt.ClaimStrings{"http://example.com/token"}
+
+		_, err := service.ValidateClientAssertion(sign(t, claims), clientID, audience, []*jwt.SigningKey{publicKey})
+
+		require.ErrorIs(t, err, jwt.ErrInvalidAssertion)
+	})
+
+	t.Run("Rejects a long-lived assertion", func(t *testing.T) {
+		t.Parallel()
+		claims := validClaims()
+		claims.ExpiresAt = gojwt.NewNumericDate(time.Now().Add(time.Hour))
+
+		_, err := service.ValidateClientAssertion(sign(t, claims), clientID, audience, []*jwt.SigningKey{publicKey})
+
+		require.ErrorIs(t, err, jwt.ErrInvalidAssertion)
+	})
+
+	t.Run("Rejects an assertion signed with another key", func(t *testing.T) {
+		t.Parallel()
+		otherKey, err := jwt.ParsePublicKey("other", publicKeyPem(t, &generatePrivateKey(t).PublicKey))
+		require.NoError(t, err)
+
+		_, err = service.ValidateClientAssertion(sign(t, validClaims()), clientID, audience, []*jwt.SigningKey{otherKey})
+
+		require.ErrorIs(t, err, jwt.ErrInvalidAssertion)
+	})
+}
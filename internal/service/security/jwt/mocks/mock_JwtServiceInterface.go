@@ -5,8 +5,11 @@
 package jwt
 
 import (
+	"time"
+
 	"github.com/fgeck/gotth-postgres/internal/service/security/jwt"
 	"github.com/fgeck/gotth-postgres/internal/service/user"
+	"github.com/google/uuid"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -37,6 +40,213 @@ func (_m *MockJwtServiceInterface) EXPECT() *MockJwtServiceInterface_Expecter {
 	return &MockJwtServiceInterface_Expecter{mock: &_m.Mock}
 }
 
+// AccessTokenExpiration provides a mock function for the type MockJwtServiceInterface
+func (_mock *MockJwtServiceInterface) AccessTokenExpiration() time.Duration {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for AccessTokenExpiration")
+	}
+
+	var r0 time.Duration
+	if returnFunc, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+	return r0
+}
+
+// MockJwtServiceInterface_AccessTokenExpiration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AccessTokenExpiration'
+type MockJwtServiceInterface_AccessTokenExpiration_Call struct {
+	*mock.Call
+}
+
+// AccessTokenExpiration is a helper method to define mock.On call
+func (_e *MockJwtServiceInterface_Expecter) AccessTokenExpiration() *MockJwtServiceInterface_AccessTokenExpiration_Call {
+	return &MockJwtServiceInterface_AccessTokenExpiration_Call{Call: _e.mock.On("AccessTokenExpiration")}
+}
+
+func (_c *MockJwtServiceInterface_AccessTokenExpiration_Call) Run(run func()) *MockJwtServiceInterface_AccessTokenExpiration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockJwtServiceInterface_AccessTokenExpiration_Call) Return(duration time.Duration) *MockJwtServiceInterface_AccessTokenExpiration_Call {
+	_c.Call.Return(duration)
+	return _c
+}
+
+func (_c *MockJwtServiceInterface_AccessTokenExpiration_Call) RunAndReturn(run func() time.Duration) *MockJwtServiceInterface_AccessTokenExpiration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GenerateEmailVerificationToken provides a mock function for the type MockJwtServiceInterface
+func (_mock *MockJwtServiceInterface) GenerateEmailVerificationToken(user1 *user.UserDto, tokenID uuid.UUID) (string, error) {
+	ret := _mock.Called(user1, tokenID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateEmailVerificationToken")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*user.UserDto, uuid.UUID) (string, error)); ok {
+		return returnFunc(user1, tokenID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*user.UserDto, uuid.UUID) string); ok {
+		r0 = returnFunc(user1, tokenID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(*user.UserDto, uuid.UUID) error); ok {
+		r1 = returnFunc(user1, tokenID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJwtServiceInterface_GenerateEmailVerificationToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateEmailVerificationToken'
+type MockJwtServiceInterface_GenerateEmailVerificationToken_Call struct {
+	*mock.Call
+}
+
+// GenerateEmailVerificationToken is a helper method to define mock.On call
+//   - user1
+//   - tokenID
+func (_e *MockJwtServiceInterface_Expecter) GenerateEmailVerificationToken(user1 interface{}, tokenID interface{}) *MockJwtServiceInterface_GenerateEmailVerificationToken_Call {
+	return &MockJwtServiceInterface_GenerateEmailVerificationToken_Call{Call: _e.mock.On("GenerateEmailVerificationToken", user1, tokenID)}
+}
+
+func (_c *MockJwtServiceInterface_GenerateEmailVerificationToken_Call) Run(run func(user1 *user.UserDto, tokenID uuid.UUID)) *MockJwtServiceInterface_GenerateEmailVerificationToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*user.UserDto), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockJwtServiceInterface_GenerateEmailVerificationToken_Call) Return(s string, err error) *MockJwtServiceInterface_GenerateEmailVerificationToken_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockJwtServiceInterface_GenerateEmailVerificationToken_Call) RunAndReturn(run func(user1 *user.UserDto, tokenID uuid.UUID) (string, error)) *MockJwtServiceInterface_GenerateEmailVerificationToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GenerateMfaPendingToken provides a mock function for the type MockJwtServiceInterface
+func (_mock *MockJwtServiceInterface) GenerateMfaPendingToken(user1 *user.UserDto) (string, error) {
+	ret := _mock.Called(user1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateMfaPendingToken")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*user.UserDto) (string, error)); ok {
+		return returnFunc(user1)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*user.UserDto) string); ok {
+		r0 = returnFunc(user1)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(*user.UserDto) error); ok {
+		r1 = returnFunc(user1)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJwtServiceInterface_GenerateMfaPendingToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateMfaPendingToken'
+type MockJwtServiceInterface_GenerateMfaPendingToken_Call struct {
+	*mock.Call
+}
+
+// GenerateMfaPendingToken is a helper method to define mock.On call
+//   - user1
+func (_e *MockJwtServiceInterface_Expecter) GenerateMfaPendingToken(user1 interface{}) *MockJwtServiceInterface_GenerateMfaPendingToken_Call {
+	return &MockJwtServiceInterface_GenerateMfaPendingToken_Call{Call: _e.mock.On("GenerateMfaPendingToken", user1)}
+}
+
+func (_c *MockJwtServiceInterface_GenerateMfaPendingToken_Call) Run(run func(user1 *user.UserDto)) *MockJwtServiceInterface_GenerateMfaPendingToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*user.UserDto))
+	})
+	return _c
+}
+
+func (_c *MockJwtServiceInterface_GenerateMfaPendingToken_Call) Return(s string, err error) *MockJwtServiceInterface_GenerateMfaPendingToken_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockJwtServiceInterface_GenerateMfaPendingToken_Call) RunAndReturn(run func(user1 *user.UserDto) (string, error)) *MockJwtServiceInterface_GenerateMfaPendingToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GeneratePasswordChangeToken provides a mock function for the type MockJwtServiceInterface
+func (_mock *MockJwtServiceInterface) GeneratePasswordChangeToken(user1 *user.UserDto) (string, error) {
+	ret := _mock.Called(user1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GeneratePasswordChangeToken")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*user.UserDto) (string, error)); ok {
+		return returnFunc(user1)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*user.UserDto) string); ok {
+		r0 = returnFunc(user1)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(*user.UserDto) error); ok {
+		r1 = returnFunc(user1)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJwtServiceInterface_GeneratePasswordChangeToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GeneratePasswordChangeToken'
+type MockJwtServiceInterface_GeneratePasswordChangeToken_Call struct {
+	*mock.Call
+}
+
+// GeneratePasswordChangeToken is a helper method to define mock.On call
+//   - user1
+func (_e *MockJwtServiceInterface_Expecter) GeneratePasswordChangeToken(user1 interface{}) *MockJwtServiceInterface_GeneratePasswordChangeToken_Call {
+	return &MockJwtServiceInterface_GeneratePasswordChangeToken_Call{Call: _e.mock.On("GeneratePasswordChangeToken", user1)}
+}
+
+func (_c *MockJwtServiceInterface_GeneratePasswordChangeToken_Call) Run(run func(user1 *user.UserDto)) *MockJwtServiceInterface_GeneratePasswordChangeToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*user.UserDto))
+	})
+	return _c
+}
+
+func (_c *MockJwtServiceInterface_GeneratePasswordChangeToken_Call) Return(s string, err error) *MockJwtServiceInterface_GeneratePasswordChangeToken_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockJwtServiceInterface_GeneratePasswordChangeToken_Call) RunAndReturn(run func(user1 *user.UserDto) (string, error)) *MockJwtServiceInterface_GeneratePasswordChangeToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GenerateToken provides a mock function for the type MockJwtServiceInterface
 func (_mock *MockJwtServiceInterface) GenerateToken(user1 *user.UserDto) (string, error) {
 	ret := _mock.Called(user1)
@@ -91,6 +301,52 @@ func (_c *MockJwtServiceInterface_GenerateToken_Call) RunAndReturn(run func(user
 	return _c
 }
 
+// PublicJwks provides a mock function for the type MockJwtServiceInterface
+func (_mock *MockJwtServiceInterface) PublicJwks() *jwt.JwkSet {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublicJwks")
+	}
+
+	var r0 *jwt.JwkSet
+	if returnFunc, ok := ret.Get(0).(func() *jwt.JwkSet); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*jwt.JwkSet)
+		}
+	}
+	return r0
+}
+
+// MockJwtServiceInterface_PublicJwks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublicJwks'
+type MockJwtServiceInterface_PublicJwks_Call struct {
+	*mock.Call
+}
+
+// PublicJwks is a helper method to define mock.On call
+func (_e *MockJwtServiceInterface_Expecter) PublicJwks() *MockJwtServiceInterface_PublicJwks_Call {
+	return &MockJwtServiceInterface_PublicJwks_Call{Call: _e.mock.On("PublicJwks")}
+}
+
+func (_c *MockJwtServiceInterface_PublicJwks_Call) Run(run func()) *MockJwtServiceInterface_PublicJwks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockJwtServiceInterface_PublicJwks_Call) Return(jwkSet *jwt.JwkSet) *MockJwtServiceInterface_PublicJwks_Call {
+	_c.Call.Return(jwkSet)
+	return _c
+}
+
+func (_c *MockJwtServiceInterface_PublicJwks_Call) RunAndReturn(run func() *jwt.JwkSet) *MockJwtServiceInterface_PublicJwks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ValidateAndExtractClaims provides a mock function for the type MockJwtServiceInterface
 func (_mock *MockJwtServiceInterface) ValidateAndExtractClaims(givenToken string) (*jwt.JwtCustomClaims, error) {
 	ret := _mock.Called(givenToken)
@@ -146,3 +402,230 @@ func (_c *MockJwtServiceInterface_ValidateAndExtractClaims_Call) RunAndReturn(ru
 	_c.Call.Return(run)
 	return _c
 }
+
+// ValidateClientAssertion provides a mock function for the type MockJwtServiceInterface
+func (_mock *MockJwtServiceInterface) ValidateClientAssertion(assertion string, clientID string, audience string, keys []*jwt.SigningKey) (*jwt.ClientAssertion, error) {
+	ret := _mock.Called(assertion, clientID, audience, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateClientAssertion")
+	}
+
+	var r0 *jwt.ClientAssertion
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, []*jwt.SigningKey) (*jwt.ClientAssertion, error)); ok {
+		return returnFunc(assertion, clientID, audience, keys)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, []*jwt.SigningKey) *jwt.ClientAssertion); ok {
+		r0 = returnFunc(assertion, clientID, audience, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*jwt.ClientAssertion)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string, string, []*jwt.SigningKey) error); ok {
+		r1 = returnFunc(assertion, clientID, audience, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJwtServiceInterface_ValidateClientAssertion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateClientAssertion'
+type MockJwtServiceInterface_ValidateClientAssertion_Call struct {
+	*mock.Call
+}
+
+// ValidateClientAssertion is a helper method to define mock.On call
+//   - assertion
+//   - clientID
+//   - audience
+//   - keys
+func (_e *MockJwtServiceInterface_Expecter) ValidateClientAssertion(assertion interface{}, clientID interface{}, audience interface{}, keys interface{}) *MockJwtServiceInterface_ValidateClientAssertion_Call {
+	return &MockJwtServiceInterface_ValidateClientAssertion_Call{Call: _e.mock.On("ValidateClientAssertion", assertion, clientID, audience, keys)}
+}
+
+func (_c *MockJwtServiceInterface_ValidateClientAssertion_Call) Run(run func(assertion string, clientID string, audience string, keys []*jwt.SigningKey)) *MockJwtServiceInterface_ValidateClientAssertion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string), args[3].([]*jwt.SigningKey))
+	})
+	return _c
+}
+
+func (_c *MockJwtServiceInterface_ValidateClientAssertion_Call) Return(clientAssertion *jwt.ClientAssertion, err error) *MockJwtServiceInterface_ValidateClientAssertion_Call {
+	_c.Call.Return(clientAssertion, err)
+	return _c
+}
+
+func (_c *MockJwtServiceInterface_ValidateClientAssertion_Call) RunAndReturn(run func(assertion string, clientID string, audience string, keys []*jwt.SigningKey) (*jwt.ClientAssertion, error)) *MockJwtServiceInterface_ValidateClientAssertion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidateEmailVerificationToken provides a mock function for the type MockJwtServiceInterface
+func (_mock *MockJwtServiceInterface) ValidateEmailVerificationToken(givenToken string) (*jwt.JwtCustomClaims, error) {
+	ret := _mock.Called(givenToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateEmailVerificationToken")
+	}
+
+	var r0 *jwt.JwtCustomClaims
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (*jwt.JwtCustomClaims, error)); ok {
+		return returnFunc(givenToken)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *jwt.JwtCustomClaims); ok {
+		r0 = returnFunc(givenToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*jwt.JwtCustomClaims)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(givenToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJwtServiceInterface_ValidateEmailVerificationToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateEmailVerificationToken'
+type MockJwtServiceInterface_ValidateEmailVerificationToken_Call struct {
+	*mock.Call
+}
+
+// ValidateEmailVerificationToken is a helper method to define mock.On call
+//   - givenToken
+func (_e *MockJwtServiceInterface_Expecter) ValidateEmailVerificationToken(givenToken interface{}) *MockJwtServiceInterface_ValidateEmailVerificationToken_Call {
+	return &MockJwtServiceInterface_ValidateEmailVerificationToken_Call{Call: _e.mock.On("ValidateEmailVerificationToken", givenToken)}
+}
+
+func (_c *MockJwtServiceInterface_ValidateEmailVerificationToken_Call) Run(run func(givenToken string)) *MockJwtServiceInterface_ValidateEmailVerificationToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockJwtServiceInterface_ValidateEmailVerificationToken_Call) Return(jwtCustomClaims *jwt.JwtCustomClaims, err error) *MockJwtServiceInterface_ValidateEmailVerificationToken_Call {
+	_c.Call.Return(jwtCustomClaims, err)
+	return _c
+}
+
+func (_c *MockJwtServiceInterface_ValidateEmailVerificationToken_Call) RunAndReturn(run func(givenToken string) (*jwt.JwtCustomClaims, error)) *MockJwtServiceInterface_ValidateEmailVerificationToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidateMfaPendingToken provides a mock function for the type MockJwtServiceInterface
+func (_mock *MockJwtServiceInterface) ValidateMfaPendingToken(givenToken string) (*jwt.JwtCustomClaims, error) {
+	ret := _mock.Called(givenToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateMfaPendingToken")
+	}
+
+	var r0 *jwt.JwtCustomClaims
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (*jwt.JwtCustomClaims, error)); ok {
+		return returnFunc(givenToken)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *jwt.JwtCustomClaims); ok {
+		r0 = returnFunc(givenToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*jwt.JwtCustomClaims)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(givenToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJwtServiceInterface_ValidateMfaPendingToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateMfaPendingToken'
+type MockJwtServiceInterface_ValidateMfaPendingToken_Call struct {
+	*mock.Call
+}
+
+// ValidateMfaPendingToken is a helper method to define mock.On call
+//   - givenToken
+func (_e *MockJwtServiceInterface_Expecter) ValidateMfaPendingToken(givenToken interface{}) *MockJwtServiceInterface_ValidateMfaPendingToken_Call {
+	return &MockJwtServiceInterface_ValidateMfaPendingToken_Call{Call: _e.mock.On("ValidateMfaPendingToken", givenToken)}
+}
+
+func (_c *MockJwtServiceInterface_ValidateMfaPendingToken_Call) Run(run func(givenToken string)) *MockJwtServiceInterface_ValidateMfaPendingToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockJwtServiceInterface_ValidateMfaPendingToken_Call) Return(jwtCustomClaims *jwt.JwtCustomClaims, err error) *MockJwtServiceInterface_ValidateMfaPendingToken_Call {
+	_c.Call.Return(jwtCustomClaims, err)
+	return _c
+}
+
+func (_c *MockJwtServiceInterface_ValidateMfaPendingToken_Call) RunAndReturn(run func(givenToken string) (*jwt.JwtCustomClaims, error)) *MockJwtServiceInterface_ValidateMfaPendingToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidatePasswordChangeToken provides a mock function for the type MockJwtServiceInterface
+func (_mock *MockJwtServiceInterface) ValidatePasswordChangeToken(givenToken string) (*jwt.JwtCustomClaims, error) {
+	ret := _mock.Called(givenToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidatePasswordChangeToken")
+	}
+
+	var r0 *jwt.JwtCustomClaims
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (*jwt.JwtCustomClaims, error)); ok {
+		return returnFunc(givenToken)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *jwt.JwtCustomClaims); ok {
+		r0 = returnFunc(givenToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*jwt.JwtCustomClaims)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(givenToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJwtServiceInterface_ValidatePasswordChangeToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidatePasswordChangeToken'
+type MockJwtServiceInterface_ValidatePasswordChangeToken_Call struct {
+	*mock.Call
+}
+
+// ValidatePasswordChangeToken is a helper method to define mock.On call
+//   - givenToken
+func (_e *MockJwtServiceInterface_Expecter) ValidatePasswordChangeToken(givenToken interface{}) *MockJwtServiceInterface_ValidatePasswordChangeToken_Call {
+	return &MockJwtServiceInterface_ValidatePasswordChangeToken_Call{Call: _e.mock.On("ValidatePasswordChangeToken", givenToken)}
+}
+
+func (_c *MockJwtServiceInterface_ValidatePasswordChangeToken_Call) Run(run func(givenToken string)) *MockJwtServiceInterface_ValidatePasswordChangeToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockJwtServiceInterface_ValidatePasswordChangeToken_Call) Return(jwtCustomClaims *jwt.JwtCustomClaims, err error) *MockJwtServiceInterface_ValidatePasswordChangeToken_Call {
+	_c.Call.Return(jwtCustomClaims, err)
+	return _c
+}
+
+func (_c *MockJwtServiceInterface_ValidatePasswordChangeToken_Call) RunAndReturn(run func(givenToken string) (*jwt.JwtCustomClaims, error)) *MockJwtServiceInterface_ValidatePasswordChangeToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,154 @@
+package password
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// BREACH_INDEX_MAGIC starts every index file, so that passing the text dump
+// instead of the index fails at startup rather than on every lookup.
+const BREACH_INDEX_MAGIC = "PWNDIDX1"
+
+var (
+	ErrInvalidBreachIndex = errors.New("invalid breached password index")
+	ErrInvalidBreachDump  = errors.New("invalid breached password dump")
+)
+
+type BreachCheckerInterface interface {
+	IsBreached(password string) (bool, error)
+}
+
+// BreachChecker looks passwords up in a local copy of the "Pwned Passwords"
+// list. The index holds the raw SHA-1 hashes in ascending order, so a lookup
+// is a binary search of about 30 reads and the file never has to fit into
+// memory.
+type BreachChecker struct {
+	index   *os.File
+	records int64
+}
+
+func NewBreachChecker(path string) (*BreachChecker, error) {
+	index, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open breached password index: %w", err)
+	}
+
+	info, err := index.Stat()
+	if err != nil {
+		index.Close()
+
+		return nil, fmt.Errorf("failed to stat breached password index: %w", err)
+	}
+
+	header := make([]byte, len(BREACH_INDEX_MAGIC))
+	if _, err := index.ReadAt(header, 0); err != nil || string(header) != BREACH_INDEX_MAGIC {
+		index.Close()
+
+		return nil, fmt.Errorf("%w: %s has no index header", ErrInvalidBreachIndex, path)
+	}
+
+	size := info.Size() - int64(len(BREACH_INDEX_MAGIC))
+	if size%sha1.Size != 0 {
+		index.Close()
+
+		return nil, fmt.Errorf("%w: %s is truncated", ErrInvalidBreachIndex, path)
+	}
+
+	return &BreachChecker{
+		index:   index,
+		records: size / sha1.Size,
+	}, nil
+}
+
+func (c *BreachChecker) IsBreached(password string) (bool, error) {
+	hash := sha1.Sum([]byte(password))
+	record := make([]byte, sha1.Size)
+
+	low, high := int64(0), c.records
+	for low < high {
+		middle := low + (high-low)/2
+		offset := int64(len(BREACH_INDEX_MAGIC)) + middle*sha1.Size
+		if _, err := c.index.ReadAt(record, offset); err != nil {
+			return false, fmt.Errorf("failed to read breached password index: %w", err)
+		}
+
+		switch bytes.Compare(record, hash[:]) {
+		case 0:
+			return true, nil
+		case -1:
+			low = middle + 1
+		default:
+			high = middle
+		}
+	}
+
+	return false, nil
+}
+
+func (c *BreachChecker) Close() error {
+	return c.index.Close()
+}
+
+// BuildBreachIndex converts a dump in the "Pwned Passwords" format ordered by
+// hash, one "SHA1:COUNT" line per password, into an index for BreachChecker.
+// Hashes seen fewer than minCount times are left out to keep the index small.
+// It returns the number of hashes written.
+func BuildBreachIndex(dump io.Reader, index io.Writer, minCount int) (int64, error) {
+	writer := bufio.NewWriter(index)
+	if _, err := writer.WriteString(BREACH_INDEX_MAGIC); err != nil {
+		return 0, fmt.Errorf("failed to write breached password index: %w", err)
+	}
+
+	scanner := bufio.NewScanner(dump)
+	var previous []byte
+	var written int64
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		hexHash, countText, _ := strings.Cut(line, ":")
+		hash, err := hex.DecodeString(hexHash)
+		if err != nil || len(hash) != sha1.Size {
+			return written, fmt.Errorf("%w: line %d is not a SHA-1 hash", ErrInvalidBreachDump, lineNumber)
+		}
+		// Lists without counts are indexed completely.
+		count := minCount
+		if countText != "" {
+			count, err = strconv.Atoi(countText)
+			if err != nil {
+				return written, fmt.Errorf("%w: line %d has an invalid count", ErrInvalidBreachDump, lineNumber)
+			}
+		}
+		if previous != nil && bytes.Compare(previous, hash) >= 0 {
+			return written, fmt.Errorf("%w: line %d is not ordered by hash", ErrInvalidBreachDump, lineNumber)
+		}
+		previous = hash
+
+		if count < minCount {
+			continue
+		}
+		if _, err := writer.Write(hash); err != nil {
+			return written, fmt.Errorf("failed to write breached password index: %w", err)
+		}
+		written++
+	}
+	if err := scanner.Err(); err != nil {
+		return written, fmt.Errorf("failed to read breached password dump: %w", err)
+	}
+
+	if err := writer.Flush(); err != nil {
+		return written, fmt.Errorf("failed to write breached password index: %w", err)
+	}
+
+	return written, nil
+}
@@ -0,0 +1,167 @@
+//go:build unittest
+
+package password_test
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/fgeck/gotth-postgres/internal/service/security/password"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sha1Hex(value string) string {
+	hash := sha1.Sum([]byte(value))
+
+	return strings.ToUpper(hex.EncodeToString(hash[:]))
+}
+
+// buildDump returns a dump in the Pwned Passwords format ordered by hash.
+func buildDump(counts map[string]int) string {
+	lines := make([]string, 0, len(counts))
+	for value, count := range counts {
+		lines = append(lines, fmt.Sprintf("%s:%d", sha1Hex(value), count))
+	}
+	sort.Strings(lines)
+
+	return strings.Join(lines, "\r\n") + "\r\n"
+}
+
+func writeIndex(t *testing.T, dump string, minCount int) string {
+	t.Helper()
+
+	var index bytes.Buffer
+	_, err := password.BuildBreachIndex(strings.NewReader(dump), &index, minCount)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "pwned.idx")
+	require.NoError(t, os.WriteFile(path, index.Bytes(), 0o600))
+
+	return path
+}
+
+func TestBreachChecker(t *testing.T) {
+	dump := buildDump(map[string]int{
+		"password": 9545824,
+		"123456":   42,
+		"qwerty":   7,
+		"letmein":  3,
+		"rare":     1,
+	})
+
+	t.Run("finds every indexed password", func(t *testing.T) {
+		checker, err := password.NewBreachChecker(writeIndex(t, dump, 1))
+		require.NoError(t, err)
+		defer checker.Close()
+
+		for _, value := range []string{"password", "123456", "qwerty", "letmein", "rare"} {
+			breached, err := checker.IsBreached(value)
+			require.NoError(t, err)
+			assert.True(t, breached, value)
+		}
+	})
+
+	t.Run("does not find other passwords", func(t *testing.T) {
+		checker, err := password.NewBreachChecker(writeIndex(t, dump, 1))
+		require.NoError(t, err)
+		defer checker.Close()
+
+		for _, value := range []string{"", "Password", "correct horse battery staple"} {
+			breached, err := checker.IsBreached(value)
+			require.NoError(t, err)
+			assert.False(t, breached, value)
+		}
+	})
+
+	t.Run("skips hashes below the minimum count", func(t *testing.T) {
+		checker, err := password.NewBreachChecker(writeIndex(t, dump, 5))
+		require.NoError(t, err)
+		defer checker.Close()
+
+		breached, err := checker.IsBreached("qwerty")
+		require.NoError(t, err)
+		assert.True(t, breached)
+
+		breached, err = checker.IsBreached("letmein")
+		require.NoError(t, err)
+		assert.False(t, breached)
+	})
+
+	t.Run("works on an empty index", func(t *testing.T) {
+		checker, err := password.NewBreachChecker(writeIndex(t, "", 1))
+		require.NoError(t, err)
+		defer checker.Close()
+
+		breached, err := checker.IsBreached("password")
+		require.NoError(t, err)
+		assert.False(t, breached)
+	})
+
+	t.Run("rejects a file without index header", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "dump.txt")
+		require.NoError(t, os.WriteFile(path, []byte(dump), 0o600))
+
+		_, err := password.NewBreachChecker(path)
+
+		require.ErrorIs(t, err, password.ErrInvalidBreachIndex)
+	})
+
+	t.Run("rejects a truncated index", func(t *testing.T) {
+		path := writeIndex(t, dump, 1)
+		index, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, index[:len(index)-1], 0o600))
+
+		_, err = password.NewBreachChecker(path)
+
+		require.ErrorIs(t, err, password.ErrInvalidBreachIndex)
+	})
+}
+
+func TestBuildBreachIndex(t *testing.T) {
+	t.Run("counts the written hashes", func(t *testing.T) {
+		dump := buildDump(map[string]int{"a": 1, "b": 2, "c": 3})
+
+		var index bytes.Buffer
+		written, err := password.BuildBreachIndex(strings.NewReader(dump), &index, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), written)
+		assert.Equal(t, len(password.BREACH_INDEX_MAGIC)+2*sha1.Size, index.Len())
+	})
+
+	t.Run("accepts hashes without counts", func(t *testing.T) {
+		dump := strings.ToLower(sha1Hex("a")) + "\n"
+
+		written, err := password.BuildBreachIndex(strings.NewReader(dump), &bytes.Buffer{}, 10)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), written)
+	})
+
+	tests := []struct {
+		name string
+		dump string
+	}{
+		{"rejects an unordered dump", sha1Hex("b") + ":1\n" + sha1Hex("a") + ":1\n"},
+		{"rejects duplicate hashes", sha1Hex("a") + ":1\n" + sha1Hex("a") + ":1\n"},
+		{"rejects invalid hashes", "not-a-hash:1\n"},
+		{"rejects short hashes", sha1Hex("a")[:35] + ":1\n"},
+		{"rejects invalid counts", sha1Hex("a") + ":many\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := password.BuildBreachIndex(strings.NewReader(test.dump), &bytes.Buffer{}, 1)
+
+			require.ErrorIs(t, err, password.ErrInvalidBreachDump)
+		})
+	}
+}
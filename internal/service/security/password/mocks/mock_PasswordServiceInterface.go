@@ -35,6 +35,40 @@ func (_m *MockPasswordServiceInterface) EXPECT() *MockPasswordServiceInterface_E
 	return &MockPasswordServiceInterface_Expecter{mock: &_m.Mock}
 }
 
+// CompareDummyPassword provides a mock function for the type MockPasswordServiceInterface
+func (_mock *MockPasswordServiceInterface) CompareDummyPassword(password string) {
+	_mock.Called(password)
+	return
+}
+
+// MockPasswordServiceInterface_CompareDummyPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompareDummyPassword'
+type MockPasswordServiceInterface_CompareDummyPassword_Call struct {
+	*mock.Call
+}
+
+// CompareDummyPassword is a helper method to define mock.On call
+//   - password
+func (_e *MockPasswordServiceInterface_Expecter) CompareDummyPassword(password interface{}) *MockPasswordServiceInterface_CompareDummyPassword_Call {
+	return &MockPasswordServiceInterface_CompareDummyPassword_Call{Call: _e.mock.On("CompareDummyPassword", password)}
+}
+
+func (_c *MockPasswordServiceInterface_CompareDummyPassword_Call) Run(run func(password string)) *MockPasswordServiceInterface_CompareDummyPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockPasswordServiceInterface_CompareDummyPassword_Call) Return() *MockPasswordServiceInterface_CompareDummyPassword_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockPasswordServiceInterface_CompareDummyPassword_Call) RunAndReturn(run func(password string)) *MockPasswordServiceInterface_CompareDummyPassword_Call {
+	_c.Run(run)
+	return _c
+}
+
 // ComparePassword provides a mock function for the type MockPasswordServiceInterface
 func (_mock *MockPasswordServiceInterface) ComparePassword(hashedPassword string, password string) error {
 	ret := _mock.Called(hashedPassword, password)
@@ -134,3 +168,48 @@ func (_c *MockPasswordServiceInterface_HashAndSaltPassword_Call) RunAndReturn(ru
 	_c.Call.Return(run)
 	return _c
 }
+
+// NeedsRehash provides a mock function for the type MockPasswordServiceInterface
+func (_mock *MockPasswordServiceInterface) NeedsRehash(hashedPassword string) bool {
+	ret := _mock.Called(hashedPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NeedsRehash")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = returnFunc(hashedPassword)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockPasswordServiceInterface_NeedsRehash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NeedsRehash'
+type MockPasswordServiceInterface_NeedsRehash_Call struct {
+	*mock.Call
+}
+
+// NeedsRehash is a helper method to define mock.On call
+//   - hashedPassword
+func (_e *MockPasswordServiceInterface_Expecter) NeedsRehash(hashedPassword interface{}) *MockPasswordServiceInterface_NeedsRehash_Call {
+	return &MockPasswordServiceInterface_NeedsRehash_Call{Call: _e.mock.On("NeedsRehash", hashedPassword)}
+}
+
+func (_c *MockPasswordServiceInterface_NeedsRehash_Call) Run(run func(hashedPassword string)) *MockPasswordServiceInterface_NeedsRehash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockPasswordServiceInterface_NeedsRehash_Call) Return(b bool) *MockPasswordServiceInterface_NeedsRehash_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockPasswordServiceInterface_NeedsRehash_Call) RunAndReturn(run func(hashedPassword string) bool) *MockPasswordServiceInterface_NeedsRehash_Call {
+	_c.Call.Return(run)
+	return _c
+}
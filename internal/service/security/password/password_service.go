@@ -1,35 +1,107 @@
 package password
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/fgeck/gotth-postgres/internal/service/config"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	ALGORITHM_ARGON2ID = "argon2id"
+	ALGORITHM_BCRYPT   = "bcrypt"
+)
+
+var (
+	ErrUnknownAlgorithm  = errors.New("unknown password hashing algorithm")
+	ErrUnknownHashFormat = errors.New("unknown password hash format")
+	ErrPasswordMismatch  = errors.New("password does not match")
+)
 
 type PasswordServiceInterface interface {
 	HashAndSaltPassword(password string) (string, error)
 	ComparePassword(hashedPassword, password string) error
+	NeedsRehash(hashedPassword string) bool
+	CompareDummyPassword(password string)
 }
 
+// PasswordService hashes new passwords with the configured algorithm and
+// verifies both argon2id and bcrypt hashes, so the algorithm can be changed
+// without invalidating stored passwords.
 type PasswordService struct {
-	hashFunc    func(password []byte, cost int) ([]byte, error)
-	compareFunc func(hashedPassword, password []byte) error
+	algorithm    string
+	bcryptCost   int
+	argon2Params Argon2Params
+	hashFunc     func(password []byte, cost int) ([]byte, error)
+	compareFunc  func(hashedPassword, password []byte) error
+	dummyOnce    sync.Once
+	dummyHash    string
 }
 
-func NewPasswordService() *PasswordService {
-	return &PasswordService{
-		hashFunc:    bcrypt.GenerateFromPassword,
-		compareFunc: bcrypt.CompareHashAndPassword,
+// NewPasswordService falls back to argon2id and its default parameters for
+// everything that is not configured.
+func NewPasswordService(cfg config.PasswordHashingConfig) (*PasswordService, error) {
+	algorithm := cfg.Algorithm
+	if algorithm == "" {
+		algorithm = ALGORITHM_ARGON2ID
+	}
+	if algorithm != ALGORITHM_ARGON2ID && algorithm != ALGORITHM_BCRYPT {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownAlgorithm, cfg.Algorithm)
+	}
+
+	bcryptCost := cfg.BcryptCost
+	if bcryptCost == 0 {
+		bcryptCost = bcrypt.DefaultCost
+	}
+	if bcryptCost < bcrypt.MinCost || bcryptCost > bcrypt.MaxCost {
+		return nil, fmt.Errorf("invalid bcrypt cost %d", bcryptCost)
 	}
+
+	argon2Params := DEFAULT_ARGON2_PARAMS
+	if cfg.Argon2.Memory != 0 {
+		argon2Params.Memory = cfg.Argon2.Memory
+	}
+	if cfg.Argon2.Iterations != 0 {
+		argon2Params.Iterations = cfg.Argon2.Iterations
+	}
+	if cfg.Argon2.Parallelism != 0 {
+		argon2Params.Parallelism = cfg.Argon2.Parallelism
+	}
+
+	return &PasswordService{
+		algorithm:    algorithm,
+		bcryptCost:   bcryptCost,
+		argon2Params: argon2Params,
+		hashFunc:     bcrypt.GenerateFromPassword,
+		compareFunc:  bcrypt.CompareHashAndPassword,
+	}, nil
 }
+
+// NewPasswordServiceWithCustomFuncs creates a bcrypt based service with
+// replaceable bcrypt functions.
 func NewPasswordServiceWithCustomFuncs(
 	hashFunc func(password []byte, cost int) ([]byte, error),
 	compareFunc func(hashedPassword, password []byte) error,
 ) *PasswordService {
 	return &PasswordService{
-		hashFunc:    hashFunc,
-		compareFunc: compareFunc,
+		algorithm:    ALGORITHM_BCRYPT,
+		bcryptCost:   bcrypt.DefaultCost,
+		argon2Params: DEFAULT_ARGON2_PARAMS,
+		hashFunc:     hashFunc,
+		compareFunc:  compareFunc,
 	}
 }
 
 func (s *PasswordService) HashAndSaltPassword(password string) (string, error) {
-	hashedPassword, err := s.hashFunc([]byte(password), bcrypt.DefaultCost)
+	if s.algorithm == ALGORITHM_ARGON2ID {
+		return hashArgon2id(password, s.argon2Params)
+	}
+
+	hashedPassword, err := s.hashFunc([]byte(password), s.bcryptCost)
 	if err != nil {
 		return "", err
 	}
@@ -37,6 +109,59 @@ func (s *PasswordService) HashAndSaltPassword(password string) (string, error) {
 	return string(hashedPassword), nil
 }
 
+// ComparePassword detects the algorithm from the prefix of the stored hash.
 func (s *PasswordService) ComparePassword(hashedPassword, password string) error {
-	return s.compareFunc([]byte(hashedPassword), []byte(password))
+	switch {
+	case strings.HasPrefix(hashedPassword, ARGON2ID_PREFIX):
+		return compareArgon2id(hashedPassword, password)
+	case isBcryptHash(hashedPassword):
+		return s.compareFunc([]byte(hashedPassword), []byte(password))
+	default:
+		return ErrUnknownHashFormat
+	}
+}
+
+// NeedsRehash reports whether a stored hash was created with another
+// algorithm or with weaker parameters than currently configured. It is only
+// meaningful after the password was verified against the hash.
+func (s *PasswordService) NeedsRehash(hashedPassword string) bool {
+	if s.algorithm == ALGORITHM_ARGON2ID {
+		params, _, _, err := decodeArgon2id(hashedPassword)
+		if err != nil {
+			return true
+		}
+
+		return params.Memory < s.argon2Params.Memory ||
+			params.Iterations < s.argon2Params.Iterations ||
+			params.Parallelism < s.argon2Params.Parallelism
+	}
+
+	if !isBcryptHash(hashedPassword) {
+		return true
+	}
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+
+	return err != nil || cost < s.bcryptCost
+}
+
+// CompareDummyPassword does the same work as ComparePassword against a hash
+// that matches no password. Logins for unknown accounts call it, so that the
+// response time does not reveal which accounts exist.
+func (s *PasswordService) CompareDummyPassword(password string) {
+	s.dummyOnce.Do(func() {
+		// Nobody knows the random password, a failed hash only skips the work.
+		s.dummyHash, _ = s.HashAndSaltPassword(rand.Text())
+	})
+
+	_ = s.ComparePassword(s.dummyHash, password)
+}
+
+func isBcryptHash(hashedPassword string) bool {
+	for _, prefix := range []string{"$2a$", "$2b$", "$2y$"} {
+		if strings.HasPrefix(hashedPassword, prefix) {
+			return true
+		}
+	}
+
+	return false
 }
@@ -4,8 +4,10 @@ package password_test
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
+	"github.com/fgeck/gotth-postgres/internal/service/config"
 	"github.com/fgeck/gotth-postgres/internal/service/security/password"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -45,13 +47,152 @@ func TestComparePassword(t *testing.T) {
 	service := password.NewPasswordServiceWithCustomFuncs(nil, mockCompareFunc)
 
 	t.Run("successfully compares password", func(t *testing.T) {
-		err := service.ComparePassword("mockHashedPassword", "validPassword")
+		err := service.ComparePassword("$2a$10$mockHashedPassword", "validPassword")
 		require.NoError(t, err)
 	})
 
 	t.Run("fails to compare password", func(t *testing.T) {
-		err := service.ComparePassword("mockHashedPassword", "wrongPassword")
+		err := service.ComparePassword("$2a$10$mockHashedPassword", "wrongPassword")
 		require.Error(t, err)
 		assert.Equal(t, "mock invalid password", err.Error())
 	})
 }
+
+// Small costs keep the tests fast.
+var argon2Config = config.PasswordHashingConfig{
+	Algorithm:  password.ALGORITHM_ARGON2ID,
+	BcryptCost: 4,
+	Argon2: config.Argon2Config{
+		Memory:      1024,
+		Iterations:  2,
+		Parallelism: 1,
+	},
+}
+
+func TestNewPasswordService(t *testing.T) {
+	t.Run("defaults to argon2id", func(t *testing.T) {
+		service, err := password.NewPasswordService(config.PasswordHashingConfig{})
+		require.NoError(t, err)
+
+		hashedPassword, err := service.HashAndSaltPassword("validPassword")
+
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(hashedPassword, "$argon2id$v=19$m=65536,t=3,p=4$"))
+	})
+
+	t.Run("rejects an unknown algorithm", func(t *testing.T) {
+		_, err := password.NewPasswordService(config.PasswordHashingConfig{Algorithm: "md5"})
+		require.ErrorIs(t, err, password.ErrUnknownAlgorithm)
+	})
+
+	t.Run("rejects an invalid bcrypt cost", func(t *testing.T) {
+		_, err := password.NewPasswordService(config.PasswordHashingConfig{Algorithm: password.ALGORITHM_BCRYPT, BcryptCost: 99})
+		require.Error(t, err)
+	})
+}
+
+func TestArgon2id(t *testing.T) {
+	service, err := password.NewPasswordService(argon2Config)
+	require.NoError(t, err)
+
+	hashedPassword, err := service.HashAndSaltPassword("validPassword")
+	require.NoError(t, err)
+
+	t.Run("encodes the parameters in PHC format", func(t *testing.T) {
+		parts := strings.Split(hashedPassword, "$")
+		require.Len(t, parts, 6)
+		assert.Equal(t, "argon2id", parts[1])
+		assert.Equal(t, "v=19", parts[2])
+		assert.Equal(t, "m=1024,t=2,p=1", parts[3])
+	})
+
+	t.Run("salts every hash", func(t *testing.T) {
+		other, err := service.HashAndSaltPassword("validPassword")
+		require.NoError(t, err)
+		assert.NotEqual(t, hashedPassword, other)
+	})
+
+	t.Run("accepts the right password", func(t *testing.T) {
+		require.NoError(t, service.ComparePassword(hashedPassword, "validPassword"))
+	})
+
+	t.Run("rejects a wrong password", func(t *testing.T) {
+		require.ErrorIs(t, service.ComparePassword(hashedPassword, "wrongPassword"), password.ErrPasswordMismatch)
+	})
+
+	t.Run("rejects a malformed hash", func(t *testing.T) {
+		require.ErrorIs(t, service.ComparePassword("$argon2id$v=19$m=1024$salt", "validPassword"), password.ErrUnknownHashFormat)
+		require.ErrorIs(t, service.ComparePassword("plaintext", "validPassword"), password.ErrUnknownHashFormat)
+	})
+}
+
+func TestCompareDetectsAlgorithm(t *testing.T) {
+	bcryptService, err := password.NewPasswordService(config.PasswordHashingConfig{Algorithm: password.ALGORITHM_BCRYPT, BcryptCost: 4})
+	require.NoError(t, err)
+	argon2Service, err := password.NewPasswordService(argon2Config)
+	require.NoError(t, err)
+
+	bcryptHash, err := bcryptService.HashAndSaltPassword("validPassword")
+	require.NoError(t, err)
+	argon2Hash, err := argon2Service.HashAndSaltPassword("validPassword")
+	require.NoError(t, err)
+
+	require.NoError(t, argon2Service.ComparePassword(bcryptHash, "validPassword"))
+	require.NoError(t, bcryptService.ComparePassword(argon2Hash, "validPassword"))
+}
+
+func TestNeedsRehash(t *testing.T) {
+	bcryptService, err := password.NewPasswordService(config.PasswordHashingConfig{Algorithm: password.ALGORITHM_BCRYPT, BcryptCost: 5})
+	require.NoError(t, err)
+	argon2Service, err := password.NewPasswordService(argon2Config)
+	require.NoError(t, err)
+	weakBcryptService, err := password.NewPasswordService(config.PasswordHashingConfig{Algorithm: password.ALGORITHM_BCRYPT, BcryptCost: 4})
+	require.NoError(t, err)
+	weakConfig := argon2Config
+	weakConfig.Argon2.Iterations = 1
+	weakArgon2Service, err := password.NewPasswordService(weakConfig)
+	require.NoError(t, err)
+
+	hash := func(service *password.PasswordService) string {
+		hashedPassword, err := service.HashAndSaltPassword("validPassword")
+		require.NoError(t, err)
+		return hashedPassword
+	}
+
+	t.Run("keeps hashes with the current parameters", func(t *testing.T) {
+		assert.False(t, argon2Service.NeedsRehash(hash(argon2Service)))
+		assert.False(t, bcryptService.NeedsRehash(hash(bcryptService)))
+	})
+
+	t.Run("upgrades hashes of the other algorithm", func(t *testing.T) {
+		assert.True(t, argon2Service.NeedsRehash(hash(bcryptService)))
+		assert.True(t, bcryptService.NeedsRehash(hash(argon2Service)))
+	})
+
+	t.Run("upgrades hashes with a lower cost", func(t *testing.T) {
+		assert.True(t, argon2Service.NeedsRehash(hash(weakArgon2Service)))
+		assert.True(t, bcryptService.NeedsRehash(hash(weakBcryptService)))
+	})
+
+	t.Run("keeps hashes with a higher cost", func(t *testing.T) {
+		assert.False(t, weakArgon2Service.NeedsRehash(hash(argon2Service)))
+		assert.False(t, weakBcryptService.NeedsRehash(hash(bcryptService)))
+	})
+}
+
+func TestCompareDummyPassword(t *testing.T) {
+	compared := 0
+	mockCompareFunc := func(hashedPassword, password []byte) error {
+		compared++
+		return errors.New("mock invalid password")
+	}
+	mockHashFunc := func(password []byte, cost int) ([]byte, error) {
+		return []byte("$2a$10$mockHashedPassword"), nil
+	}
+	service := password.NewPasswordServiceWithCustomFuncs(mockHashFunc, mockCompareFunc)
+
+	service.CompareDummyPassword("somePassword")
+	service.CompareDummyPassword("otherPassword")
+
+	assert.Equal(t, 2, compared)
+}
@@ -0,0 +1,40 @@
+package strength
+
+import (
+	"bufio"
+	"embed"
+	"strings"
+)
+
+// The lists are ordered by frequency, the line number is the rank of a word.
+//
+//go:embed words/*.txt
+var wordLists embed.FS
+
+var DICTIONARY_FILES = map[string]string{
+	DICTIONARY_PASSWORDS: "words/passwords.txt",
+	DICTIONARY_ENGLISH:   "words/english.txt",
+}
+
+func loadDictionaries() map[string]map[string]int {
+	dictionaries := make(map[string]map[string]int, len(DICTIONARY_FILES))
+	for name, file := range DICTIONARY_FILES {
+		content, err := wordLists.ReadFile(file)
+		if err != nil {
+			// The lists are embedded, failing to read them is a build error.
+			panic(err)
+		}
+
+		words := map[string]int{}
+		scanner := bufio.NewScanner(strings.NewReader(string(content)))
+		for scanner.Scan() {
+			word := strings.ToLower(strings.TrimSpace(scanner.Text()))
+			if _, ok := words[word]; !ok && word != "" {
+				words[word] = len(words) + 1
+			}
+		}
+		dictionaries[name] = words
+	}
+
+	return dictionaries
+}
@@ -0,0 +1,108 @@
+package strength
+
+import (
+	"slices"
+	"unicode"
+)
+
+var DEFAULT_SUGGESTIONS = []string{
+	"Use a few words, avoid common phrases.",
+	"No need for symbols, digits, or uppercase letters.",
+}
+
+// feedback explains the longest match of a weak password. Strong passwords
+// get no feedback at all.
+func feedback(score int, sequence []*Match) (string, []string) {
+	if len(sequence) == 0 {
+		return "", slices.Clone(DEFAULT_SUGGESTIONS)
+	}
+	if score > 2 {
+		return "", []string{}
+	}
+
+	longest := sequence[0]
+	for _, match := range sequence[1:] {
+		if len(match.Token) > len(longest.Token) {
+			longest = match
+		}
+	}
+
+	warning, suggestions := matchFeedback(longest, len(sequence) == 1)
+
+	return warning, append([]string{"Add another word or two. Uncommon words are better."}, suggestions...)
+}
+
+func matchFeedback(match *Match, isSoleMatch bool) (string, []string) {
+	switch match.Pattern {
+	case PATTERN_DICTIONARY:
+		return dictionaryFeedback(match, isSoleMatch)
+	case PATTERN_SPATIAL:
+		warning := "Short keyboard patterns are easy to guess."
+		if match.Turns == 1 {
+			warning = "Straight rows of keys are easy to guess."
+		}
+
+		return warning, []string{"Use a longer keyboard pattern with more turns."}
+	case PATTERN_REPEAT:
+		warning := `Repeats like "abcabcabc" are only slightly harder to guess than "abc".`
+		if len([]rune(match.BaseToken)) == 1 {
+			warning = `Repeats like "aaa" are easy to guess.`
+		}
+
+		return warning, []string{"Avoid repeated words and characters."}
+	case PATTERN_SEQUENCE:
+		return "Sequences like abc or 6543 are easy to guess.", []string{"Avoid sequences."}
+	case PATTERN_YEAR:
+		return "Recent years are easy to guess.", []string{"Avoid recent years.", "Avoid years that are associated with you."}
+	case PATTERN_DATE:
+		return "Dates are often easy to guess.", []string{"Avoid dates and years that are associated with you."}
+	default:
+		return "", []string{}
+	}
+}
+
+func dictionaryFeedback(match *Match, isSoleMatch bool) (string, []string) {
+	var warning string
+	switch match.DictionaryName {
+	case DICTIONARY_PASSWORDS:
+		switch {
+		case isSoleMatch && !match.Reversed && len(match.Substitutions) == 0 && match.Rank <= 10:
+			warning = "This is a top-10 common password."
+		case isSoleMatch && !match.Reversed && len(match.Substitutions) == 0 && match.Rank <= 100:
+			warning = "This is a top-100 common password."
+		case isSoleMatch:
+			warning = "This is a very common password."
+		default:
+			warning = "This is similar to a commonly used password."
+		}
+	case DICTIONARY_ENGLISH:
+		if isSoleMatch {
+			warning = "A word by itself is easy to guess."
+		}
+	case DICTIONARY_USER_INPUTS:
+		warning = "Avoid your username and email address."
+	}
+
+	suggestions := []string{}
+	runes := []rune(match.Token)
+	allUpper, startUpper := true, unicode.IsUpper(runes[0])
+	for _, char := range runes {
+		if unicode.IsLower(char) {
+			allUpper = false
+		}
+	}
+	switch {
+	case allUpper && string(runes) != match.MatchedWord:
+		suggestions = append(suggestions, "All-uppercase is almost as easy to guess as all-lowercase.")
+	case startUpper:
+		suggestions = append(suggestions, "Capitalization doesn't help very much.")
+	}
+	if match.Reversed && len(runes) >= 4 {
+		suggestions = append(suggestions, "Reversed words aren't much harder to guess.")
+	}
+	if len(match.Substitutions) > 0 {
+		suggestions = append(suggestions, "Predictable substitutions like '@' instead of 'a' don't help very much.")
+	}
+
+	return warning, suggestions
+}
@@ -0,0 +1,100 @@
+package strength
+
+import "strings"
+
+// Keys are given as unshifted and shifted character. The qwerty rows are
+// slanted: every key touches the two keys above and the two below it that
+// are half a key to the left and right.
+var (
+	QWERTY_GRAPH = newKeyboardGraph("qwerty", true, [][]string{
+		{"`~", "1!", "2@", "3#", "4$", "5%", "6^", "7&", "8*", "9(", "0)", "-_", "=+"},
+		{"", "qQ", "wW", "eE", "rR", "tT", "yY", "uU", "iI", "oO", "pP", "[{", "]}", "\\|"},
+		{"", "aA", "sS", "dD", "fF", "gG", "hH", "jJ", "kK", "lL", ";:", "'\""},
+		{"", "zZ", "xX", "cC", "vV", "bB", "nN", "mM", ",<", ".>", "/?"},
+	})
+	KEYPAD_GRAPH = newKeyboardGraph("keypad", false, [][]string{
+		{"", "/", "*", "-"},
+		{"7", "8", "9", "+"},
+		{"4", "5", "6"},
+		{"1", "2", "3"},
+		{"", "0", "."},
+	})
+)
+
+type keyboardGraph struct {
+	name              string
+	keys              map[rune]string
+	adjacency         map[string][]string
+	startingPositions float64
+	averageDegree     float64
+}
+
+func newKeyboardGraph(name string, slanted bool, rows [][]string) *keyboardGraph {
+	// Neighbors are listed clockwise starting on the left, so that a change
+	// of the index is a change of direction.
+	directions := [][2]int{{-1, 0}, {-1, -1}, {0, -1}, {1, -1}, {1, 0}, {1, 1}, {0, 1}, {-1, 1}}
+	if slanted {
+		directions = [][2]int{{-1, 0}, {0, -1}, {1, -1}, {1, 0}, {0, 1}, {-1, 1}}
+	}
+
+	keyAt := func(x, y int) string {
+		if y < 0 || y >= len(rows) || x < 0 || x >= len(rows[y]) {
+			return ""
+		}
+
+		return rows[y][x]
+	}
+
+	graph := &keyboardGraph{
+		name:      name,
+		keys:      map[rune]string{},
+		adjacency: map[string][]string{},
+	}
+	degrees := 0
+	for y, row := range rows {
+		for x, key := range row {
+			if key == "" {
+				continue
+			}
+			for _, char := range key {
+				graph.keys[char] = key
+			}
+
+			neighbors := make([]string, len(directions))
+			for i, direction := range directions {
+				neighbors[i] = keyAt(x+direction[0], y+direction[1])
+				if neighbors[i] != "" {
+					degrees++
+				}
+			}
+			graph.adjacency[key] = neighbors
+		}
+	}
+	graph.startingPositions = float64(len(graph.adjacency))
+	graph.averageDegree = float64(degrees) / graph.startingPositions
+
+	return graph
+}
+
+func (g *keyboardGraph) isShifted(char rune) bool {
+	key, ok := g.keys[char]
+
+	return ok && strings.IndexRune(key, char) > 0
+}
+
+// neighbor reports whether next is on a key next to the one of previous and
+// in which direction.
+func (g *keyboardGraph) neighbor(previous, next rune) (int, bool, bool) {
+	key, ok := g.keys[previous]
+	if !ok {
+		return -1, false, false
+	}
+
+	for direction, neighbor := range g.adjacency[key] {
+		if neighbor != "" && strings.ContainsRune(neighbor, next) {
+			return direction, g.isShifted(next), true
+		}
+	}
+
+	return -1, false, false
+}
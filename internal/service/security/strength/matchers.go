@@ -0,0 +1,603 @@
+package strength
+
+import (
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// Submatches with more substitution combinations than this are not
+	// worth the effort, nobody remembers passwords like that.
+	MAX_L33T_COMBINATIONS = 64
+	MAX_SEQUENCE_DELTA    = 5
+
+	DATE_MIN_YEAR  = 1000
+	DATE_MAX_YEAR  = 2050
+	MIN_YEAR_SPACE = 20
+)
+
+var (
+	L33T_TABLE = map[rune][]rune{
+		'a': {'4', '@'},
+		'b': {'8'},
+		'c': {'(', '{', '[', '<'},
+		'e': {'3'},
+		'g': {'6', '9'},
+		'i': {'1', '!', '|'},
+		'l': {'1', '|', '7'},
+		'o': {'0'},
+		's': {'$', '5'},
+		't': {'+', '7'},
+		'x': {'%'},
+		'z': {'2'},
+	}
+
+	// Possible day, month and year splits of a date without separators,
+	// keyed by its length.
+	DATE_SPLITS = map[int][][2]int{
+		4: {{1, 2}, {2, 3}},
+		5: {{1, 3}, {2, 3}},
+		6: {{1, 2}, {2, 4}, {4, 5}},
+		7: {{1, 3}, {2, 3}, {4, 5}, {4, 6}},
+		8: {{2, 4}, {4, 6}},
+	}
+
+	dateWithSeparator = regexp.MustCompile(`^(\d{1,4})([\s/\\_.-])(\d{1,2})([\s/\\_.-])(\d{1,4})$`)
+	recentYear        = regexp.MustCompile(`19\d\d|20\d\d`)
+)
+
+func (s *StrengthService) omnimatch(runes []rune, dictionaries map[string]map[string]int) []*Match {
+	matches := []*Match{}
+	matches = append(matches, dictionaryMatch(runes, dictionaries)...)
+	matches = append(matches, reverseDictionaryMatch(runes, dictionaries)...)
+	matches = append(matches, l33tMatch(runes, dictionaries)...)
+	for _, graph := range s.graphs {
+		matches = append(matches, spatialMatch(runes, graph)...)
+	}
+	matches = append(matches, s.repeatMatch(runes, dictionaries)...)
+	matches = append(matches, sequenceMatch(runes)...)
+	matches = append(matches, dateMatch(runes)...)
+	matches = append(matches, yearMatch(runes)...)
+
+	return matches
+}
+
+func dictionaryMatch(runes []rune, dictionaries map[string]map[string]int) []*Match {
+	lowered := []rune(strings.ToLower(string(runes)))
+
+	matches := []*Match{}
+	for name, words := range dictionaries {
+		for i := range lowered {
+			for j := i; j < len(lowered); j++ {
+				word := string(lowered[i : j+1])
+				rank, ok := words[word]
+				if !ok {
+					continue
+				}
+				matches = append(matches, &Match{
+					Pattern:        PATTERN_DICTIONARY,
+					I:              i,
+					J:              j,
+					Token:          string(runes[i : j+1]),
+					DictionaryName: name,
+					MatchedWord:    word,
+					Rank:           rank,
+				})
+			}
+		}
+	}
+
+	return matches
+}
+
+func reverseDictionaryMatch(runes []rune, dictionaries map[string]map[string]int) []*Match {
+	reversed := slices.Clone(runes)
+	slices.Reverse(reversed)
+
+	matches := dictionaryMatch(reversed, dictionaries)
+	for _, match := range matches {
+		match.Token = reverseString(match.Token)
+		match.Reversed = true
+		match.I, match.J = len(runes)-1-match.J, len(runes)-1-match.I
+	}
+
+	return matches
+}
+
+// l33tMatch undoes common substitutions like "@" for "a" before looking the
+// password up in the dictionaries. Characters standing for several letters
+// are tried with each of them.
+func l33tMatch(runes []rune, dictionaries map[string]map[string]int) []*Match {
+	substitutes := map[rune][]rune{}
+	for letter, candidates := range L33T_TABLE {
+		for _, candidate := range candidates {
+			if slices.Contains(runes, candidate) {
+				substitutes[candidate] = append(substitutes[candidate], letter)
+			}
+		}
+	}
+	if len(substitutes) == 0 {
+		return []*Match{}
+	}
+
+	matches := []*Match{}
+	for _, substitutions := range substitutionCombinations(substitutes) {
+		translated := make([]rune, len(runes))
+		for i, char := range runes {
+			if letter, ok := substitutions[char]; ok {
+				translated[i] = letter
+			} else {
+				translated[i] = char
+			}
+		}
+
+		for _, match := range dictionaryMatch(translated, dictionaries) {
+			token := runes[match.I : match.J+1]
+			used := map[rune]rune{}
+			for _, char := range token {
+				if letter, ok := substitutions[char]; ok {
+					used[char] = letter
+				}
+			}
+			// Single characters like "4" for "a" are matched by everything.
+			if len(used) == 0 || len(token) == 1 {
+				continue
+			}
+			match.Token = string(token)
+			match.Substitutions = used
+			matches = append(matches, match)
+		}
+	}
+
+	return matches
+}
+
+// substitutionCombinations returns every way to map the substitute
+// characters back to a single letter each.
+func substitutionCombinations(substitutes map[rune][]rune) []map[rune]rune {
+	keys := make([]rune, 0, len(substitutes))
+	for key := range substitutes {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	combinations := []map[rune]rune{{}}
+	for _, key := range keys {
+		letters := substitutes[key]
+		slices.Sort(letters)
+
+		next := make([]map[rune]rune, 0, len(combinations)*len(letters))
+		for _, combination := range combinations {
+			for _, letter := range letters {
+				extended := make(map[rune]rune, len(combination)+1)
+				for k, v := range combination {
+					extended[k] = v
+				}
+				extended[key] = letter
+				next = append(next, extended)
+			}
+		}
+		if len(next) > MAX_L33T_COMBINATIONS {
+			break
+		}
+		combinations = next
+	}
+
+	return combinations
+}
+
+func spatialMatch(runes []rune, graph *keyboardGraph) []*Match {
+	matches := []*Match{}
+
+	for i := 0; i < len(runes)-1; {
+		j := i + 1
+		lastDirection, turns, shiftedCount := -1, 0, 0
+		if graph.isShifted(runes[i]) {
+			shiftedCount++
+		}
+
+		for {
+			direction, shifted, found := -1, false, false
+			if j < len(runes) {
+				direction, shifted, found = graph.neighbor(runes[j-1], runes[j])
+			}
+			if found {
+				if shifted {
+					shiftedCount++
+				}
+				if direction != lastDirection {
+					turns++
+					lastDirection = direction
+				}
+				j++
+				continue
+			}
+
+			// Two adjacent keys are too common to count as a pattern.
+			if j-i > 2 {
+				matches = append(matches, &Match{
+					Pattern:      PATTERN_SPATIAL,
+					I:            i,
+					J:            j - 1,
+					Token:        string(runes[i:j]),
+					Graph:        graph.name,
+					Turns:        turns,
+					ShiftedCount: shiftedCount,
+				})
+			}
+			i = j
+
+			break
+		}
+	}
+
+	return matches
+}
+
+// repeatMatch finds the longest repetition starting at each position, like
+// "aaaa" or "abcabc". The base token is estimated on its own.
+func (s *StrengthService) repeatMatch(runes []rune, dictionaries map[string]map[string]int) []*Match {
+	matches := []*Match{}
+
+	for i := 0; i < len(runes); {
+		bestBase, bestCount := 0, 0
+		for base := 1; i+2*base <= len(runes); base++ {
+			count := 1
+			for i+(count+1)*base <= len(runes) &&
+				slices.Equal(runes[i:i+base], runes[i+count*base:i+(count+1)*base]) {
+				count++
+			}
+			if count >= 2 && base*count > bestBase*bestCount {
+				bestBase, bestCount = base, count
+			}
+		}
+		if bestCount == 0 {
+			i++
+			continue
+		}
+
+		baseRunes := runes[i : i+bestBase]
+		baseGuesses, _ := s.mostGuessableSequence(baseRunes, dictionaries)
+		j := i + bestBase*bestCount - 1
+		matches = append(matches, &Match{
+			Pattern:     PATTERN_REPEAT,
+			I:           i,
+			J:           j,
+			Token:       string(runes[i : j+1]),
+			BaseToken:   string(baseRunes),
+			BaseGuesses: baseGuesses,
+			RepeatCount: bestCount,
+		})
+		i = j + 1
+	}
+
+	return matches
+}
+
+// sequenceMatch finds runs with a constant step between characters, like
+// "abc", "9753" or "ZYX".
+func sequenceMatch(runes []rune) []*Match {
+	if len(runes) < 2 {
+		return []*Match{}
+	}
+
+	matches := []*Match{}
+	add := func(i, j int, delta rune) {
+		absDelta := delta
+		if absDelta < 0 {
+			absDelta = -absDelta
+		}
+		if (j-i > 1 || absDelta == 1) && absDelta > 0 && absDelta <= MAX_SEQUENCE_DELTA {
+			matches = append(matches, &Match{
+				Pattern:   PATTERN_SEQUENCE,
+				I:         i,
+				J:         j,
+				Token:     string(runes[i : j+1]),
+				Ascending: delta > 0,
+			})
+		}
+	}
+
+	i, lastDelta := 0, runes[1]-runes[0]
+	for k := 2; k < len(runes); k++ {
+		delta := runes[k] - runes[k-1]
+		if delta == lastDelta {
+			continue
+		}
+		add(i, k-1, lastDelta)
+		i, lastDelta = k-1, delta
+	}
+	add(i, len(runes)-1, lastDelta)
+
+	return matches
+}
+
+// dateMatch finds dates of 4 to 8 digits, with or without separators, in
+// day-month-year, month-day-year or year-month-day order. Of the possible
+// readings of a token the one closest to the current year wins.
+func dateMatch(runes []rune) []*Match {
+	matches := []*Match{}
+
+	for i := range runes {
+		for j := i + 3; j < i+8 && j < len(runes); j++ {
+			token := string(runes[i : j+1])
+			if !isDigits(token) {
+				continue
+			}
+
+			var best *Match
+			for _, split := range DATE_SPLITS[len(token)] {
+				year, ok := dateYear(token[:split[0]], token[split[0]:split[1]], token[split[1]:])
+				if ok && (best == nil || yearDistance(year) < yearDistance(best.Year)) {
+					best = &Match{Pattern: PATTERN_DATE, I: i, J: j, Token: token, Year: year}
+				}
+			}
+			if best != nil {
+				matches = append(matches, best)
+			}
+		}
+	}
+
+	for i := range runes {
+		for j := i + 5; j < i+10 && j < len(runes); j++ {
+			token := string(runes[i : j+1])
+			groups := dateWithSeparator.FindStringSubmatch(token)
+			if groups == nil || groups[2] != groups[4] {
+				continue
+			}
+			if year, ok := dateYear(groups[1], groups[3], groups[5]); ok {
+				matches = append(matches, &Match{Pattern: PATTERN_DATE, I: i, J: j, Token: token, Year: year, Separator: groups[2]})
+			}
+		}
+	}
+
+	// "1/1/91" also contains "1/1/9", only the longest reading is kept.
+	longest := make([]*Match, 0, len(matches))
+	for _, match := range matches {
+		contained := slices.ContainsFunc(matches, func(other *Match) bool {
+			return other != match && other.I <= match.I && other.J >= match.J
+		})
+		if !contained {
+			longest = append(longest, match)
+		}
+	}
+
+	return longest
+}
+
+func yearMatch(runes []rune) []*Match {
+	matches := []*Match{}
+
+	password := string(runes)
+	for _, location := range recentYear.FindAllStringIndex(password, -1) {
+		i := len([]rune(password[:location[0]]))
+		token := password[location[0]:location[1]]
+		year, _ := strconv.Atoi(token)
+		matches = append(matches, &Match{Pattern: PATTERN_YEAR, I: i, J: i + len(token) - 1, Token: token, Year: year})
+	}
+
+	return matches
+}
+
+// dateYear reads three numbers as a date and returns its year. Two digit
+// years are expanded to the closest century.
+func dateYear(first, second, third string) (int, bool) {
+	ints := [3]int{}
+	for i, part := range []string{first, second, third} {
+		value, err := strconv.Atoi(part)
+		if err != nil {
+			return 0, false
+		}
+		ints[i] = value
+	}
+
+	// The middle part is always the day or the month.
+	if ints[1] > 31 || ints[1] <= 0 {
+		return 0, false
+	}
+	over12, over31, under1 := 0, 0, 0
+	for _, value := range ints {
+		if (value > 99 && value < DATE_MIN_YEAR) || value > DATE_MAX_YEAR {
+			return 0, false
+		}
+		if value > 31 {
+			over31++
+		}
+		if value > 12 {
+			over12++
+		}
+		if value <= 0 {
+			under1++
+		}
+	}
+	if over31 >= 2 || over12 == 3 || under1 >= 2 {
+		return 0, false
+	}
+
+	readings := [][3]int{{ints[2], ints[0], ints[1]}, {ints[0], ints[1], ints[2]}}
+	for _, reading := range readings {
+		if reading[0] >= DATE_MIN_YEAR && reading[0] <= DATE_MAX_YEAR {
+			return reading[0], isDayMonth(reading[1], reading[2])
+		}
+	}
+	for _, reading := range readings {
+		if isDayMonth(reading[1], reading[2]) {
+			return twoToFourDigitYear(reading[0]), true
+		}
+	}
+
+	return 0, false
+}
+
+func isDayMonth(a, b int) bool {
+	return (a >= 1 && a <= 31 && b >= 1 && b <= 12) || (b >= 1 && b <= 31 && a >= 1 && a <= 12)
+}
+
+func twoToFourDigitYear(year int) int {
+	switch {
+	case year > 99:
+		return year
+	case year > 50:
+		return year + 1900
+	default:
+		return year + 2000
+	}
+}
+
+func dictionaryGuesses(match *Match) float64 {
+	guesses := float64(match.Rank) * uppercaseVariations(match.Token) * l33tVariations(match)
+	if match.Reversed {
+		guesses *= 2
+	}
+
+	return guesses
+}
+
+// uppercaseVariations counts the capitalizations an attacker tries before
+// the one used. Capitalizing the first or last letter or all of them is
+// common and only doubles the guesses.
+func uppercaseVariations(token string) float64 {
+	upper, lower := 0, 0
+	for _, char := range token {
+		if unicode.IsUpper(char) {
+			upper++
+		} else if unicode.IsLower(char) {
+			lower++
+		}
+	}
+	if upper == 0 {
+		return 1
+	}
+
+	runes := []rune(token)
+	startUpper := unicode.IsUpper(runes[0]) && upper == 1
+	endUpper := unicode.IsUpper(runes[len(runes)-1]) && upper == 1
+	if lower == 0 || startUpper || endUpper {
+		return 2
+	}
+
+	return variations(upper, lower)
+}
+
+func l33tVariations(match *Match) float64 {
+	result := 1.0
+	for substitute, letter := range match.Substitutions {
+		substituted, unsubstituted := 0, 0
+		for _, char := range strings.ToLower(match.Token) {
+			switch char {
+			case substitute:
+				substituted++
+			case letter:
+				unsubstituted++
+			}
+		}
+		if substituted == 0 || unsubstituted == 0 {
+			// Substituting every occurrence is the first thing to try.
+			result *= 2
+		} else {
+			result *= variations(substituted, unsubstituted)
+		}
+	}
+
+	return result
+}
+
+// variations sums the ways to pick up to min(a, b) of a+b positions.
+func variations(a, b int) float64 {
+	result := 0.0
+	for i := 1; i <= min(a, b); i++ {
+		result += binomial(a+b, i)
+	}
+
+	return result
+}
+
+func spatialGuesses(match *Match) float64 {
+	graph := QWERTY_GRAPH
+	if match.Graph == KEYPAD_GRAPH.name {
+		graph = KEYPAD_GRAPH
+	}
+
+	length := len([]rune(match.Token))
+	guesses := 0.0
+	for i := 2; i <= length; i++ {
+		for j := 1; j <= min(match.Turns, i-1); j++ {
+			guesses += binomial(i-1, j-1) * graph.startingPositions * math.Pow(graph.averageDegree, float64(j))
+		}
+	}
+
+	if match.ShiftedCount > 0 {
+		unshifted := length - match.ShiftedCount
+		if unshifted == 0 {
+			guesses *= 2
+		} else {
+			guesses *= variations(match.ShiftedCount, unshifted)
+		}
+	}
+
+	return guesses
+}
+
+func sequenceGuesses(match *Match) float64 {
+	first := []rune(match.Token)[0]
+
+	var base float64
+	switch {
+	case strings.ContainsRune("aAzZ019", first):
+		// Obvious starting points are tried first.
+		base = 4
+	case unicode.IsDigit(first):
+		base = 10
+	default:
+		base = 26
+	}
+	if !match.Ascending {
+		base *= 2
+	}
+
+	return base * float64(len([]rune(match.Token)))
+}
+
+func dateGuesses(match *Match) float64 {
+	guesses := yearSpace(match.Year) * 365
+	if match.Separator != "" {
+		guesses *= 4
+	}
+
+	return guesses
+}
+
+func yearSpace(year int) float64 {
+	return math.Max(float64(yearDistance(year)), MIN_YEAR_SPACE)
+}
+
+func yearDistance(year int) int {
+	distance := year - time.Now().Year()
+	if distance < 0 {
+		return -distance
+	}
+
+	return distance
+}
+
+func isDigits(token string) bool {
+	for _, char := range token {
+		if char < '0' || char > '9' {
+			return false
+		}
+	}
+
+	return token != ""
+}
+
+func reverseString(value string) string {
+	runes := []rune(value)
+	slices.Reverse(runes)
+
+	return string(runes)
+}
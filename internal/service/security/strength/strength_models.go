@@ -0,0 +1,57 @@
+package strength
+
+const (
+	PATTERN_DICTIONARY = "dictionary"
+	PATTERN_SPATIAL    = "spatial"
+	PATTERN_REPEAT     = "repeat"
+	PATTERN_SEQUENCE   = "sequence"
+	PATTERN_DATE       = "date"
+	PATTERN_YEAR       = "year"
+	PATTERN_BRUTEFORCE = "bruteforce"
+
+	DICTIONARY_PASSWORDS   = "passwords"
+	DICTIONARY_ENGLISH     = "english"
+	DICTIONARY_USER_INPUTS = "user_inputs"
+)
+
+// Match is a part of the password that follows a guessable pattern. I and J
+// are the rune indexes of its first and last character. Which of the other
+// fields are set depends on the pattern.
+type Match struct {
+	Pattern        string
+	I              int
+	J              int
+	Token          string
+	Guesses        float64
+	DictionaryName string
+	MatchedWord    string
+	Rank           int
+	Reversed       bool
+	Substitutions  map[rune]rune
+	Graph          string
+	Turns          int
+	ShiftedCount   int
+	BaseToken      string
+	BaseGuesses    float64
+	RepeatCount    int
+	Ascending      bool
+	Year           int
+	Separator      string
+}
+
+// Estimate describes how hard a password is to guess. Score ranges from 0
+// (too guessable) to MAX_SCORE (very unguessable), CrackTime assumes an
+// offline attack against a slow password hash.
+type Estimate struct {
+	Score        int      `json:"score"`
+	Guesses      float64  `json:"guesses"`
+	GuessesLog10 float64  `json:"guessesLog10"`
+	CrackTime    string   `json:"crackTime"`
+	Warning      string   `json:"warning,omitempty"`
+	Suggestions  []string `json:"suggestions"`
+	Sequence     []*Match `json:"-"`
+}
+
+func (e *Estimate) Label() string {
+	return SCORE_LABELS[e.Score]
+}
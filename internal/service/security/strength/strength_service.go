@@ -0,0 +1,331 @@
+package strength
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	MAX_SCORE = 4
+	// Longer passwords are only estimated up to this length, everything beyond
+	// is strong anyway and the matchers grow with the cube of the length.
+	MAX_ESTIMATE_LENGTH = 100
+
+	BRUTEFORCE_CARDINALITY              = 10
+	MIN_GUESSES_BEFORE_GROWING_SEQUENCE = 10000
+	MIN_SUBMATCH_GUESSES_SINGLE_CHAR    = 10
+	MIN_SUBMATCH_GUESSES_MULTI_CHAR     = 50
+	// Guesses per second of an offline attack on a slow hash like argon2id.
+	OFFLINE_SLOW_HASHING_RATE = 1e4
+)
+
+var SCORE_LABELS = [MAX_SCORE + 1]string{"Very weak", "Weak", "Fair", "Strong", "Very strong"}
+
+type StrengthServiceInterface interface {
+	Estimate(password string, userInputs ...string) *Estimate
+}
+
+// StrengthService estimates password strength in the style of zxcvbn: the
+// password is split into the sequence of dictionary words, keyboard patterns,
+// repeats, sequences, dates and brute forced characters that is the easiest
+// to guess, and the guesses of that sequence decide the score.
+type StrengthService struct {
+	dictionaries map[string]map[string]int
+	graphs       []*keyboardGraph
+}
+
+func NewStrengthService() *StrengthService {
+	return &StrengthService{
+		dictionaries: loadDictionaries(),
+		graphs:       []*keyboardGraph{QWERTY_GRAPH, KEYPAD_GRAPH},
+	}
+}
+
+// Estimate treats the user inputs, usually username and email address, as
+// an additional dictionary of very likely guesses.
+func (s *StrengthService) Estimate(password string, userInputs ...string) *Estimate {
+	runes := []rune(password)
+	if len(runes) > MAX_ESTIMATE_LENGTH {
+		runes = runes[:MAX_ESTIMATE_LENGTH]
+	}
+
+	dictionaries := s.dictionaries
+	if userDictionary := userInputDictionary(userInputs); len(userDictionary) > 0 {
+		dictionaries = make(map[string]map[string]int, len(s.dictionaries)+1)
+		for name, words := range s.dictionaries {
+			dictionaries[name] = words
+		}
+		dictionaries[DICTIONARY_USER_INPUTS] = userDictionary
+	}
+
+	guesses, sequence := s.mostGuessableSequence(runes, dictionaries)
+	guesses = math.Min(guesses, math.MaxFloat64)
+	score := scoreOf(guesses)
+	warning, suggestions := feedback(score, sequence)
+
+	return &Estimate{
+		Score:        score,
+		Guesses:      guesses,
+		GuessesLog10: math.Log10(guesses),
+		CrackTime:    displayCrackTime(guesses / OFFLINE_SLOW_HASHING_RATE),
+		Warning:      warning,
+		Suggestions:  suggestions,
+		Sequence:     sequence,
+	}
+}
+
+func userInputDictionary(userInputs []string) map[string]int {
+	isSeparator := func(char rune) bool {
+		return !unicode.IsLetter(char) && !unicode.IsDigit(char)
+	}
+
+	words := map[string]int{}
+	add := func(word string) {
+		if _, ok := words[word]; !ok && word != "" {
+			words[word] = len(words) + 1
+		}
+	}
+	for _, input := range userInputs {
+		input = strings.ToLower(input)
+		add(input)
+		for _, part := range strings.FieldsFunc(input, isSeparator) {
+			if utf8.RuneCountInString(part) >= 3 {
+				add(part)
+			}
+		}
+	}
+
+	return words
+}
+
+type optimalStep struct {
+	match   *Match
+	product float64
+	guesses float64
+}
+
+// mostGuessableSequence finds the sequence of non-overlapping matches
+// covering the password with the fewest total guesses. Gaps are filled with
+// brute force matches. Longer sequences are penalized because an attacker
+// also has to guess how many parts there are.
+func (s *StrengthService) mostGuessableSequence(runes []rune, dictionaries map[string]map[string]int) (float64, []*Match) {
+	n := len(runes)
+	if n == 0 {
+		return 1, []*Match{}
+	}
+
+	matchesByEnd := make([][]*Match, n)
+	for _, match := range s.omnimatch(runes, dictionaries) {
+		match.Guesses = estimateGuesses(match, n)
+		matchesByEnd[match.J] = append(matchesByEnd[match.J], match)
+	}
+
+	// optimal[k][l] is the best sequence of length l covering runes[:k+1].
+	optimal := make([]map[int]optimalStep, n)
+	for k := range optimal {
+		optimal[k] = map[int]optimalStep{}
+	}
+
+	update := func(match *Match, length int) {
+		k := match.J
+		product := match.Guesses
+		if length > 1 {
+			product *= optimal[match.I-1][length-1].product
+		}
+		guesses := factorial(length)*product + math.Pow(MIN_GUESSES_BEFORE_GROWING_SEQUENCE, float64(length-1))
+		for competingLength, competing := range optimal[k] {
+			if competingLength <= length && competing.guesses <= guesses {
+				return
+			}
+		}
+		optimal[k][length] = optimalStep{match: match, product: product, guesses: guesses}
+	}
+
+	for k := range n {
+		for _, match := range matchesByEnd[k] {
+			if match.I == 0 {
+				update(match, 1)
+				continue
+			}
+			for length := range optimal[match.I-1] {
+				update(match, length+1)
+			}
+		}
+
+		update(s.bruteforceMatch(runes, 0, k, n), 1)
+		for i := 1; i <= k; i++ {
+			bruteforce := s.bruteforceMatch(runes, i, k, n)
+			for length, step := range optimal[i-1] {
+				// Two brute force matches in a row are never better than one.
+				if step.match.Pattern != PATTERN_BRUTEFORCE {
+					update(bruteforce, length+1)
+				}
+			}
+		}
+	}
+
+	bestLength, bestGuesses := 0, math.Inf(1)
+	for length, step := range optimal[n-1] {
+		if step.guesses < bestGuesses || (step.guesses == bestGuesses && length < bestLength) {
+			bestLength, bestGuesses = length, step.guesses
+		}
+	}
+
+	sequence := make([]*Match, bestLength)
+	for k, length := n-1, bestLength; k >= 0; length-- {
+		match := optimal[k][length].match
+		sequence[length-1] = match
+		k = match.I - 1
+	}
+
+	return bestGuesses, sequence
+}
+
+func (s *StrengthService) bruteforceMatch(runes []rune, i, j, passwordLength int) *Match {
+	match := &Match{
+		Pattern: PATTERN_BRUTEFORCE,
+		I:       i,
+		J:       j,
+		Token:   string(runes[i : j+1]),
+	}
+	match.Guesses = estimateGuesses(match, passwordLength)
+
+	return match
+}
+
+// estimateGuesses never goes below the minimum for the token length when the
+// match covers only part of the password, single characters and short tokens
+// are cheap to brute force whatever pattern they follow.
+func estimateGuesses(match *Match, passwordLength int) float64 {
+	var guesses float64
+	switch match.Pattern {
+	case PATTERN_DICTIONARY:
+		guesses = dictionaryGuesses(match)
+	case PATTERN_SPATIAL:
+		guesses = spatialGuesses(match)
+	case PATTERN_REPEAT:
+		guesses = match.BaseGuesses * float64(match.RepeatCount)
+	case PATTERN_SEQUENCE:
+		guesses = sequenceGuesses(match)
+	case PATTERN_DATE:
+		guesses = dateGuesses(match)
+	case PATTERN_YEAR:
+		guesses = yearSpace(match.Year)
+	default:
+		guesses = bruteforceGuesses(match)
+	}
+
+	tokenLength := match.J - match.I + 1
+	if tokenLength < passwordLength {
+		minimum := float64(MIN_SUBMATCH_GUESSES_MULTI_CHAR)
+		if tokenLength == 1 {
+			minimum = MIN_SUBMATCH_GUESSES_SINGLE_CHAR
+		}
+		guesses = math.Max(guesses, minimum)
+	}
+
+	return guesses
+}
+
+func bruteforceGuesses(match *Match) float64 {
+	tokenLength := match.J - match.I + 1
+	guesses := math.Pow(BRUTEFORCE_CARDINALITY, float64(tokenLength))
+	if math.IsInf(guesses, 1) {
+		guesses = math.MaxFloat64
+	}
+
+	minimum := float64(MIN_SUBMATCH_GUESSES_MULTI_CHAR + 1)
+	if tokenLength == 1 {
+		minimum = MIN_SUBMATCH_GUESSES_SINGLE_CHAR + 1
+	}
+
+	return math.Max(guesses, minimum)
+}
+
+// scoreOf maps guesses to a score. Each step is a rough order of magnitude in
+// attack effort, from guessable online to safe against offline attacks.
+func scoreOf(guesses float64) int {
+	const delta = 5
+	switch {
+	case guesses < 1e3+delta:
+		return 0
+	case guesses < 1e6+delta:
+		return 1
+	case guesses < 1e8+delta:
+		return 2
+	case guesses < 1e10+delta:
+		return 3
+	default:
+		return 4
+	}
+}
+
+func displayCrackTime(seconds float64) string {
+	const (
+		minute  = 60
+		hour    = minute * 60
+		day     = hour * 24
+		month   = day * 31
+		year    = month * 12
+		century = year * 100
+	)
+
+	plural := func(value float64, unit string) string {
+		rounded := int(math.Round(value))
+		if rounded == 1 {
+			return fmt.Sprintf("1 %s", unit)
+		}
+
+		return fmt.Sprintf("%d %ss", rounded, unit)
+	}
+
+	switch {
+	case seconds < 1:
+		return "less than a second"
+	case seconds < minute:
+		return plural(seconds, "second")
+	case seconds < hour:
+		return plural(seconds/minute, "minute")
+	case seconds < day:
+		return plural(seconds/hour, "hour")
+	case seconds < month:
+		return plural(seconds/day, "day")
+	case seconds < year:
+		return plural(seconds/month, "month")
+	case seconds < century:
+		return plural(seconds/year, "year")
+	default:
+		return "centuries"
+	}
+}
+
+func factorial(n int) float64 {
+	result := 1.0
+	for i := 2; i <= n; i++ {
+		result *= float64(i)
+	}
+
+	return result
+}
+
+// binomial returns n choose k.
+func binomial(n, k int) float64 {
+	if k > n {
+		return 0
+	}
+	if k == 0 {
+		return 1
+	}
+
+	result := 1.0
+	for d := 1; d <= k; d++ {
+		result *= float64(n)
+		result /= float64(d)
+		n--
+	}
+
+	return result
+}
@@ -0,0 +1,127 @@
+//go:build unittest
+
+package strength_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/fgeck/gotth-postgres/internal/service/security/strength"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	USERNAME = "johndoe"
+	EMAIL    = "john.doe@example.com"
+)
+
+func estimate(t *testing.T, password string) *strength.Estimate {
+	t.Helper()
+
+	return strength.NewStrengthService().Estimate(password, USERNAME, EMAIL)
+}
+
+func patterns(estimate *strength.Estimate) []string {
+	result := make([]string, 0, len(estimate.Sequence))
+	for _, match := range estimate.Sequence {
+		result = append(result, match.Pattern)
+	}
+
+	return result
+}
+
+func TestEstimateMatchers(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		pattern  string
+	}{
+		{"common password", "password", strength.PATTERN_DICTIONARY},
+		{"capitalized word", "Summer", strength.PATTERN_DICTIONARY},
+		{"l33t substitutions", "P@ssw0rd", strength.PATTERN_DICTIONARY},
+		{"reversed word", "drowssap", strength.PATTERN_DICTIONARY},
+		{"keyboard row", "wertyu", strength.PATTERN_SPATIAL},
+		{"keyboard pattern with turns", "zxcfr4", strength.PATTERN_SPATIAL},
+		{"keypad", "789632", strength.PATTERN_SPATIAL},
+		{"repeated character", "zzzzzzzzz", strength.PATTERN_REPEAT},
+		{"repeated token", "xyqxyqxyq", strength.PATTERN_REPEAT},
+		{"ascending sequence", "lmnopq", strength.PATTERN_SEQUENCE},
+		{"descending digits", "97531", strength.PATTERN_SEQUENCE},
+		{"date with separators", "13.05.1987", strength.PATTERN_DATE},
+		{"date without separators", "19870513", strength.PATTERN_DATE},
+		{"recent year", "2019", strength.PATTERN_YEAR},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := estimate(t, test.password)
+
+			assert.Equal(t, []string{test.pattern}, patterns(result))
+			assert.LessOrEqual(t, result.Score, 1)
+			assert.NotEmpty(t, result.Warning)
+		})
+	}
+}
+
+func TestEstimateScores(t *testing.T) {
+	t.Run("an empty password is the weakest", func(t *testing.T) {
+		result := estimate(t, "")
+
+		assert.Equal(t, 0, result.Score)
+		assert.InDelta(t, 1, result.Guesses, 0)
+		assert.NotEmpty(t, result.Suggestions)
+	})
+
+	t.Run("the username and email address are guessed first", func(t *testing.T) {
+		result := estimate(t, "johndoe2024")
+
+		require.Equal(t, []string{strength.PATTERN_DICTIONARY, strength.PATTERN_YEAR}, patterns(result))
+		assert.Equal(t, strength.DICTIONARY_USER_INPUTS, result.Sequence[0].DictionaryName)
+		assert.Equal(t, "Avoid your username and email address.", result.Warning)
+	})
+
+	t.Run("random passwords get the highest score without feedback", func(t *testing.T) {
+		result := estimate(t, "xK9#mQ2$vL7!")
+
+		assert.Equal(t, strength.MAX_SCORE, result.Score)
+		assert.Equal(t, "centuries", estimate(t, "xK9#mQ2$vL7!wP4&").CrackTime)
+		assert.Empty(t, result.Warning)
+		assert.Empty(t, result.Suggestions)
+	})
+
+	t.Run("several uncommon words are strong", func(t *testing.T) {
+		assert.Equal(t, strength.MAX_SCORE, estimate(t, "correct horse battery staple").Score)
+	})
+
+	t.Run("scores grow with the guesses", func(t *testing.T) {
+		passwords := []string{"password", "summer1987", "Tablespoon9", "xK9#mQ2$vL7!"}
+
+		previous := -1
+		for _, password := range passwords {
+			result := estimate(t, password)
+			assert.Greater(t, result.Score, previous, password)
+			previous = result.Score
+		}
+	})
+
+	t.Run("very long passwords are estimated", func(t *testing.T) {
+		long := ""
+		for range 30 {
+			long += "aB3$x"
+		}
+
+		result := estimate(t, long)
+
+		assert.Equal(t, strength.PATTERN_REPEAT, result.Sequence[0].Pattern)
+		assert.False(t, math.IsNaN(result.Guesses) || math.IsInf(result.Guesses, 0))
+	})
+}
+
+func TestEstimateFeedback(t *testing.T) {
+	assert.Equal(t, "This is a top-10 common password.", estimate(t, "password").Warning)
+	assert.Equal(t, "Straight rows of keys are easy to guess.", estimate(t, "wertyu").Warning)
+	assert.Contains(t, estimate(t, "P@ssw0rd").Suggestions, "Predictable substitutions like '@' instead of 'a' don't help very much.")
+	assert.Contains(t, estimate(t, "Summer").Suggestions, "Capitalization doesn't help very much.")
+	assert.Contains(t, estimate(t, "drowssap").Suggestions, "Reversed words aren't much harder to guess.")
+}
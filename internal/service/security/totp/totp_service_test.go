@@ -0,0 +1,90 @@
+//go:build unittest
+
+package totp_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/service/security/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// base32 of the RFC 6238 SHA-1 test secret "12345678901234567890"
+const RFC_SECRET = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func fixedClock(unix int64) func() time.Time {
+	return func() time.Time { return time.Unix(unix, 0) }
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	// RFC 6238 Appendix B vectors, truncated to six digits.
+	vectors := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, vector := range vectors {
+		t.Run("RFC 6238 vector at "+time.Unix(vector.unix, 0).UTC().Format(time.RFC3339), func(t *testing.T) {
+			t.Parallel()
+			service := totp.NewTotpServiceWithClock(fixedClock(vector.unix))
+
+			step, err := service.Validate(RFC_SECRET, vector.code, 0)
+
+			require.NoError(t, err)
+			assert.Equal(t, vector.unix/totp.PERIOD_SECONDS, step)
+		})
+	}
+
+	t.Run("accepts the previous step to tolerate clock drift", func(t *testing.T) {
+		t.Parallel()
+		service := totp.NewTotpServiceWithClock(fixedClock(1111111109 + totp.PERIOD_SECONDS))
+
+		_, err := service.Validate(RFC_SECRET, "081804", 0)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects codes outside the allowed skew", func(t *testing.T) {
+		t.Parallel()
+		service := totp.NewTotpServiceWithClock(fixedClock(1111111109 + 3*totp.PERIOD_SECONDS))
+
+		_, err := service.Validate(RFC_SECRET, "081804", 0)
+
+		require.ErrorIs(t, err, totp.ErrInvalidCode)
+	})
+
+	t.Run("rejects a replayed code", func(t *testing.T) {
+		t.Parallel()
+		service := totp.NewTotpServiceWithClock(fixedClock(1111111109))
+
+		step, err := service.Validate(RFC_SECRET, "081804", 0)
+		require.NoError(t, err)
+
+		_, err = service.Validate(RFC_SECRET, "081804", step)
+		require.ErrorIs(t, err, totp.ErrCodeReused)
+	})
+}
+
+func TestGenerateSecret(t *testing.T) {
+	t.Parallel()
+	service := totp.NewTotpService()
+
+	secret, err := service.GenerateSecret()
+	require.NoError(t, err)
+	// 20 bytes are 32 base32 characters without padding
+	assert.Len(t, secret, 32)
+
+	uri := service.ProvisioningUri("gotth-postgres", "user@example.com", secret)
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/gotth-postgres:user@example.com?"))
+	assert.Contains(t, uri, "secret="+secret)
+}
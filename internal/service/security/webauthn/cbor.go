@@ -0,0 +1,168 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// Authenticators encode attestation objects and COSE keys in the CTAP2
+// canonical CBOR subset. Only definite-length items are allowed there, which
+// keeps the decoder below small.
+
+const (
+	CBOR_MAX_DEPTH = 16
+
+	cborUnsigned = 0
+	cborNegative = 1
+	cborBytes    = 2
+	cborText     = 3
+	cborArray    = 4
+	cborMap      = 5
+	cborTag      = 6
+	cborSimple   = 7
+)
+
+var (
+	ErrInvalidCbor     = errors.New("invalid cbor")
+	ErrUnsupportedCbor = errors.New("unsupported cbor item")
+)
+
+// decodeCbor decodes the first CBOR item in data and returns the remaining
+// bytes. Integers become int64, maps become map[any]any with int64 or string
+// keys.
+func decodeCbor(data []byte) (any, []byte, error) {
+	return decodeCborItem(data, 0)
+}
+
+func decodeCborItem(data []byte, depth int) (any, []byte, error) {
+	if depth > CBOR_MAX_DEPTH {
+		return nil, nil, ErrUnsupportedCbor
+	}
+	if len(data) == 0 {
+		return nil, nil, ErrInvalidCbor
+	}
+
+	major := data[0] >> 5
+	info := data[0] & 0x1f
+	data = data[1:]
+
+	if major == cborSimple {
+		switch info {
+		case 20:
+			return false, data, nil
+		case 21:
+			return true, data, nil
+		case 22:
+			return nil, data, nil
+		default:
+			return nil, nil, ErrUnsupportedCbor
+		}
+	}
+
+	arg, data, err := readCborArgument(info, data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch major {
+	case cborUnsigned:
+		if arg > math.MaxInt64 {
+			return nil, nil, ErrUnsupportedCbor
+		}
+		return int64(arg), data, nil
+	case cborNegative:
+		if arg > math.MaxInt64 {
+			return nil, nil, ErrUnsupportedCbor
+		}
+		return -1 - int64(arg), data, nil
+	case cborBytes, cborText:
+		if arg > uint64(len(data)) {
+			return nil, nil, ErrInvalidCbor
+		}
+		if major == cborText {
+			return string(data[:arg]), data[arg:], nil
+		}
+		return append([]byte(nil), data[:arg]...), data[arg:], nil
+	case cborArray:
+		// every item takes at least one byte
+		if arg > uint64(len(data)) {
+			return nil, nil, ErrInvalidCbor
+		}
+		items := make([]any, 0, arg)
+		for range arg {
+			var item any
+			item, data, err = decodeCborItem(data, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			items = append(items, item)
+		}
+		return items, data, nil
+	case cborMap:
+		if arg > uint64(len(data))/2 {
+			return nil, nil, ErrInvalidCbor
+		}
+		entries := make(map[any]any, arg)
+		for range arg {
+			var key, value any
+			key, data, err = decodeCborItem(data, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, nil, ErrUnsupportedCbor
+			}
+			value, data, err = decodeCborItem(data, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			entries[key] = value
+		}
+		return entries, data, nil
+	case cborTag:
+		// Tags carry no meaning for WebAuthn structures, only the content counts.
+		return decodeCborItem(data, depth+1)
+	}
+
+	return nil, nil, ErrUnsupportedCbor
+}
+
+func readCborArgument(info byte, data []byte) (uint64, []byte, error) {
+	var size int
+	switch {
+	case info < 24:
+		return uint64(info), data, nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	default:
+		// reserved values and indefinite lengths
+		return 0, nil, ErrUnsupportedCbor
+	}
+
+	if len(data) < size {
+		return 0, nil, ErrInvalidCbor
+	}
+
+	var arg uint64
+	switch size {
+	case 1:
+		arg = uint64(data[0])
+	case 2:
+		arg = uint64(binary.BigEndian.Uint16(data))
+	case 4:
+		arg = uint64(binary.BigEndian.Uint32(data))
+	case 8:
+		arg = binary.BigEndian.Uint64(data)
+	}
+
+	return arg, data[size:], nil
+}
@@ -0,0 +1,154 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// COSE algorithm identifiers, see https://www.iana.org/assignments/cose
+const (
+	COSE_ALG_ES256 = -7
+	COSE_ALG_EDDSA = -8
+	COSE_ALG_RS256 = -257
+
+	coseLabelKty = 1
+	coseLabelAlg = 3
+	// EC2 and OKP keys
+	coseLabelCrv = -1
+	coseLabelX   = -2
+	coseLabelY   = -3
+	// RSA keys
+	coseLabelN = -1
+	coseLabelE = -2
+
+	coseKtyOkp     = 1
+	coseKtyEc2     = 2
+	coseKtyRsa     = 3
+	coseCrvP256    = 1
+	coseCrvEd25519 = 6
+
+	RSA_MIN_KEY_BITS = 2048
+)
+
+var (
+	ErrUnsupportedKey = errors.New("unsupported credential public key")
+	ErrInvalidKey     = errors.New("invalid credential public key")
+	ErrBadSignature   = errors.New("invalid assertion signature")
+)
+
+type coseKey struct {
+	alg       int64
+	publicKey crypto.PublicKey
+}
+
+// parseCoseKey decodes a COSE_Key and returns the bytes following it.
+func parseCoseKey(data []byte) (*coseKey, []byte, error) {
+	item, rest, err := decodeCbor(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: failed to decode cose key: %w", ErrInvalidKey, err)
+	}
+	entries, ok := item.(map[any]any)
+	if !ok {
+		return nil, nil, ErrInvalidKey
+	}
+
+	kty, _ := entries[int64(coseLabelKty)].(int64)
+	alg, _ := entries[int64(coseLabelAlg)].(int64)
+
+	var publicKey crypto.PublicKey
+	switch {
+	case kty == coseKtyEc2 && alg == COSE_ALG_ES256:
+		publicKey, err = parseEc2Key(entries)
+	case kty == coseKtyOkp && alg == COSE_ALG_EDDSA:
+		publicKey, err = parseOkpKey(entries)
+	case kty == coseKtyRsa && alg == COSE_ALG_RS256:
+		publicKey, err = parseRsaKey(entries)
+	default:
+		return nil, nil, ErrUnsupportedKey
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &coseKey{alg: alg, publicKey: publicKey}, rest, nil
+}
+
+func parseEc2Key(entries map[any]any) (*ecdsa.PublicKey, error) {
+	crv, _ := entries[int64(coseLabelCrv)].(int64)
+	x, _ := entries[int64(coseLabelX)].([]byte)
+	y, _ := entries[int64(coseLabelY)].([]byte)
+	if crv != coseCrvP256 || len(x) != 32 || len(y) != 32 {
+		return nil, ErrInvalidKey
+	}
+
+	// ecdh rejects points that are not on the curve
+	uncompressed := append(append([]byte{4}, x...), y...)
+	if _, err := ecdh.P256().NewPublicKey(uncompressed); err != nil {
+		return nil, ErrInvalidKey
+	}
+
+	return &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(x),
+		Y:     new(big.Int).SetBytes(y),
+	}, nil
+}
+
+func parseOkpKey(entries map[any]any) (ed25519.PublicKey, error) {
+	crv, _ := entries[int64(coseLabelCrv)].(int64)
+	x, _ := entries[int64(coseLabelX)].([]byte)
+	if crv != coseCrvEd25519 || len(x) != ed25519.PublicKeySize {
+		return nil, ErrInvalidKey
+	}
+
+	return ed25519.PublicKey(x), nil
+}
+
+func parseRsaKey(entries map[any]any) (*rsa.PublicKey, error) {
+	n, _ := entries[int64(coseLabelN)].([]byte)
+	e, _ := entries[int64(coseLabelE)].([]byte)
+	if len(n) == 0 || len(e) == 0 || len(e) > 4 {
+		return nil, ErrInvalidKey
+	}
+
+	publicKey := &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}
+	if publicKey.N.BitLen() < RSA_MIN_KEY_BITS || publicKey.E < 3 {
+		return nil, ErrInvalidKey
+	}
+
+	return publicKey, nil
+}
+
+func (k *coseKey) verify(signed, signature []byte) error {
+	switch publicKey := k.publicKey.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(signed)
+		if !ecdsa.VerifyASN1(publicKey, digest[:], signature) {
+			return ErrBadSignature
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(publicKey, signed, signature) {
+			return ErrBadSignature
+		}
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(signed)
+		if err := rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], signature); err != nil {
+			return ErrBadSignature
+		}
+	default:
+		return ErrUnsupportedKey
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package webauthn
+
+import "encoding/base64"
+
+// The option and response types mirror the JSON serialization of the
+// WebAuthn Level 3 browser API (PublicKeyCredential.toJSON and
+// parseCreationOptionsFromJSON), so all binary values are base64url strings.
+
+const (
+	PUBLIC_KEY_CREDENTIAL_TYPE = "public-key"
+
+	USER_VERIFICATION_REQUIRED  = "required"
+	USER_VERIFICATION_PREFERRED = "preferred"
+	RESIDENT_KEY_REQUIRED       = "required"
+	ATTESTATION_NONE            = "none"
+)
+
+type RelyingPartyEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type UserEntity struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+func NewUserEntity(id []byte, name, displayName string) UserEntity {
+	return UserEntity{
+		ID:          encodeBase64Url(id),
+		Name:        name,
+		DisplayName: displayName,
+	}
+}
+
+type CredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int    `json:"alg"`
+}
+
+type CredentialDescriptor struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+type AuthenticatorSelection struct {
+	ResidentKey        string `json:"residentKey"`
+	RequireResidentKey bool   `json:"requireResidentKey"`
+	UserVerification   string `json:"userVerification"`
+}
+
+type CredentialCreationOptions struct {
+	Rp                     RelyingPartyEntity     `json:"rp"`
+	User                   UserEntity             `json:"user"`
+	Challenge              string                 `json:"challenge"`
+	PubKeyCredParams       []CredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                  `json:"timeout"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection AuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation"`
+}
+
+type CredentialRequestOptions struct {
+	Challenge        string                 `json:"challenge"`
+	Timeout          int64                  `json:"timeout"`
+	RpID             string                 `json:"rpId"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+type AttestationResponse struct {
+	ClientDataJSON    string `json:"clientDataJSON"`
+	AttestationObject string `json:"attestationObject"`
+}
+
+type RegistrationResponse struct {
+	ID       string              `json:"id"`
+	RawID    string              `json:"rawId"`
+	Type     string              `json:"type"`
+	Response AttestationResponse `json:"response"`
+}
+
+type AssertionResponse struct {
+	ClientDataJSON    string `json:"clientDataJSON"`
+	AuthenticatorData string `json:"authenticatorData"`
+	Signature         string `json:"signature"`
+	UserHandle        string `json:"userHandle"`
+}
+
+type AuthenticationResponse struct {
+	ID       string            `json:"id"`
+	RawID    string            `json:"rawId"`
+	Type     string            `json:"type"`
+	Response AssertionResponse `json:"response"`
+}
+
+func (r *AuthenticationResponse) CredentialID() ([]byte, error) {
+	return decodeBase64Url(r.RawID)
+}
+
+// UserHandle is only set for discoverable credentials.
+func (r *AuthenticationResponse) UserHandle() ([]byte, error) {
+	return decodeBase64Url(r.Response.UserHandle)
+}
+
+type clientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
+
+// VerifiedCredential is a freshly registered credential that can be stored.
+type VerifiedCredential struct {
+	CredentialID []byte
+	// PublicKey is the COSE encoded key exactly as sent by the authenticator.
+	PublicKey    []byte
+	SignCount    uint32
+	UserVerified bool
+}
+
+type VerifiedAssertion struct {
+	SignCount    uint32
+	UserVerified bool
+}
+
+func encodeBase64Url(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeBase64Url accepts padded and unpadded input since browsers and
+// polyfills disagree on padding.
+func decodeBase64Url(data string) ([]byte, error) {
+	for len(data)%4 != 0 {
+		data += "="
+	}
+
+	return base64.URLEncoding.DecodeString(data)
+}
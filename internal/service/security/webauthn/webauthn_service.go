@@ -0,0 +1,291 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+const (
+	CHALLENGE_BYTES        = 32
+	CEREMONY_TIMEOUT       = 5 * time.Minute
+	MAX_CREDENTIAL_ID_SIZE = 1023
+
+	CLIENT_DATA_TYPE_CREATE = "webauthn.create"
+	CLIENT_DATA_TYPE_GET    = "webauthn.get"
+
+	// authenticator data layout: rpIdHash(32) flags(1) signCount(4)
+	AUTHENTICATOR_DATA_MIN_SIZE = 37
+
+	flagUserPresent            = 0x01
+	flagUserVerified           = 0x04
+	flagAttestedCredentialData = 0x40
+)
+
+var (
+	ErrInvalidResponse          = errors.New("invalid webauthn response")
+	ErrChallengeMismatch        = errors.New("webauthn challenge mismatch")
+	ErrOriginNotAllowed         = errors.New("webauthn origin not allowed")
+	ErrRpIdMismatch             = errors.New("webauthn relying party id mismatch")
+	ErrUserNotPresent           = errors.New("user presence is required")
+	ErrUserVerificationRequired = errors.New("user verification is required")
+	ErrSignCountInvalid         = errors.New("sign count did not increase, the authenticator may be cloned")
+)
+
+type WebauthnServiceInterface interface {
+	NewChallenge() (string, error)
+	CreationOptions(challenge string, user UserEntity, excludeCredentialIDs [][]byte) *CredentialCreationOptions
+	RequestOptions(challenge string) *CredentialRequestOptions
+	VerifyRegistration(response *RegistrationResponse, challenge string) (*VerifiedCredential, error)
+	VerifyAssertion(response *AuthenticationResponse, challenge string, publicKey []byte, storedSignCount uint32) (*VerifiedAssertion, error)
+}
+
+// WebauthnService implements the relying party side of the registration and
+// authentication ceremonies. Attestation is not requested, so authenticators
+// are trusted on first use and attestation statements are not evaluated.
+type WebauthnService struct {
+	rpID    string
+	rpName  string
+	origins []string
+}
+
+func NewWebauthnService(rpID, rpName string, origins []string) *WebauthnService {
+	return &WebauthnService{
+		rpID:    rpID,
+		rpName:  rpName,
+		origins: origins,
+	}
+}
+
+func (s *WebauthnService) NewChallenge() (string, error) {
+	buf := make([]byte, CHALLENGE_BYTES)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return encodeBase64Url(buf), nil
+}
+
+// CreationOptions asks for a discoverable credential so that the passkey can
+// later be used without entering an email address.
+func (s *WebauthnService) CreationOptions(challenge string, user UserEntity, excludeCredentialIDs [][]byte) *CredentialCreationOptions {
+	return &CredentialCreationOptions{
+		Rp:        RelyingPartyEntity{ID: s.rpID, Name: s.rpName},
+		User:      user,
+		Challenge: challenge,
+		PubKeyCredParams: []CredentialParameter{
+			{Type: PUBLIC_KEY_CREDENTIAL_TYPE, Alg: COSE_ALG_ES256},
+			{Type: PUBLIC_KEY_CREDENTIAL_TYPE, Alg: COSE_ALG_EDDSA},
+			{Type: PUBLIC_KEY_CREDENTIAL_TYPE, Alg: COSE_ALG_RS256},
+		},
+		Timeout:            CEREMONY_TIMEOUT.Milliseconds(),
+		ExcludeCredentials: credentialDescriptors(excludeCredentialIDs),
+		AuthenticatorSelection: AuthenticatorSelection{
+			ResidentKey:        RESIDENT_KEY_REQUIRED,
+			RequireResidentKey: true,
+			UserVerification:   USER_VERIFICATION_PREFERRED,
+		},
+		Attestation: ATTESTATION_NONE,
+	}
+}
+
+// RequestOptions leaves allowCredentials empty, letting the browser offer all
+// discoverable credentials for this relying party.
+func (s *WebauthnService) RequestOptions(challenge string) *CredentialRequestOptions {
+	return &CredentialRequestOptions{
+		Challenge:        challenge,
+		Timeout:          CEREMONY_TIMEOUT.Milliseconds(),
+		RpID:             s.rpID,
+		AllowCredentials: []CredentialDescriptor{},
+		UserVerification: USER_VERIFICATION_REQUIRED,
+	}
+}
+
+func (s *WebauthnService) VerifyRegistration(response *RegistrationResponse, challenge string) (*VerifiedCredential, error) {
+	if response.Type != PUBLIC_KEY_CREDENTIAL_TYPE {
+		return nil, ErrInvalidResponse
+	}
+
+	clientDataJSON, err := decodeBase64Url(response.Response.ClientDataJSON)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decode client data: %w", ErrInvalidResponse, err)
+	}
+	if err := s.verifyClientData(clientDataJSON, CLIENT_DATA_TYPE_CREATE, challenge); err != nil {
+		return nil, err
+	}
+
+	attestationObject, err := decodeBase64Url(response.Response.AttestationObject)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decode attestation object: %w", ErrInvalidResponse, err)
+	}
+	item, _, err := decodeCbor(attestationObject)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decode attestation object: %w", ErrInvalidResponse, err)
+	}
+	attestation, ok := item.(map[any]any)
+	if !ok {
+		return nil, ErrInvalidResponse
+	}
+	authData, ok := attestation["authData"].([]byte)
+	if !ok {
+		return nil, ErrInvalidResponse
+	}
+
+	flags, signCount, err := s.verifyAuthenticatorData(authData)
+	if err != nil {
+		return nil, err
+	}
+	if flags&flagAttestedCredentialData == 0 {
+		return nil, ErrInvalidResponse
+	}
+
+	credentialID, publicKey, err := parseAttestedCredentialData(authData[AUTHENTICATOR_DATA_MIN_SIZE:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &VerifiedCredential{
+		CredentialID: credentialID,
+		PublicKey:    publicKey,
+		SignCount:    signCount,
+		UserVerified: flags&flagUserVerified != 0,
+	}, nil
+}
+
+// VerifyAssertion checks a login against the stored public key. Because the
+// credential replaces both password and second factor, user verification is
+// mandatory.
+func (s *WebauthnService) VerifyAssertion(
+	response *AuthenticationResponse,
+	challenge string,
+	publicKey []byte,
+	storedSignCount uint32,
+) (*VerifiedAssertion, error) {
+	if response.Type != PUBLIC_KEY_CREDENTIAL_TYPE {
+		return nil, ErrInvalidResponse
+	}
+
+	clientDataJSON, err := decodeBase64Url(response.Response.ClientDataJSON)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decode client data: %w", ErrInvalidResponse, err)
+	}
+	if err := s.verifyClientData(clientDataJSON, CLIENT_DATA_TYPE_GET, challenge); err != nil {
+		return nil, err
+	}
+
+	authData, err := decodeBase64Url(response.Response.AuthenticatorData)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decode authenticator data: %w", ErrInvalidResponse, err)
+	}
+	flags, signCount, err := s.verifyAuthenticatorData(authData)
+	if err != nil {
+		return nil, err
+	}
+	if flags&flagUserVerified == 0 {
+		return nil, ErrUserVerificationRequired
+	}
+
+	signature, err := decodeBase64Url(response.Response.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decode signature: %w", ErrInvalidResponse, err)
+	}
+	key, _, err := parseCoseKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	if err := key.verify(slices.Concat(authData, clientDataHash[:]), signature); err != nil {
+		return nil, err
+	}
+
+	// Authenticators without a counter always report zero. Otherwise the
+	// counter has to grow, or the credential may have been cloned.
+	if (signCount != 0 || storedSignCount != 0) && signCount <= storedSignCount {
+		return nil, ErrSignCountInvalid
+	}
+
+	return &VerifiedAssertion{
+		SignCount:    signCount,
+		UserVerified: true,
+	}, nil
+}
+
+func (s *WebauthnService) verifyClientData(clientDataJSON []byte, expectedType, challenge string) error {
+	var data clientData
+	if err := json.Unmarshal(clientDataJSON, &data); err != nil {
+		return fmt.Errorf("%w: failed to parse client data: %w", ErrInvalidResponse, err)
+	}
+
+	if data.Type != expectedType {
+		return ErrInvalidResponse
+	}
+	if subtle.ConstantTimeCompare([]byte(data.Challenge), []byte(challenge)) != 1 {
+		return ErrChallengeMismatch
+	}
+	if data.CrossOrigin || !slices.Contains(s.origins, data.Origin) {
+		return ErrOriginNotAllowed
+	}
+
+	return nil
+}
+
+func (s *WebauthnService) verifyAuthenticatorData(authData []byte) (byte, uint32, error) {
+	if len(authData) < AUTHENTICATOR_DATA_MIN_SIZE {
+		return 0, 0, ErrInvalidResponse
+	}
+
+	rpIDHash := sha256.Sum256([]byte(s.rpID))
+	if !bytes.Equal(authData[:32], rpIDHash[:]) {
+		return 0, 0, ErrRpIdMismatch
+	}
+
+	flags := authData[32]
+	if flags&flagUserPresent == 0 {
+		return 0, 0, ErrUserNotPresent
+	}
+
+	return flags, binary.BigEndian.Uint32(authData[33:37]), nil
+}
+
+// parseAttestedCredentialData reads aaguid(16) credentialIdLength(2)
+// credentialId and the COSE key. Extensions may follow the key.
+func parseAttestedCredentialData(data []byte) ([]byte, []byte, error) {
+	if len(data) < 18 {
+		return nil, nil, ErrInvalidResponse
+	}
+
+	idLength := int(binary.BigEndian.Uint16(data[16:18]))
+	data = data[18:]
+	if idLength == 0 || idLength > MAX_CREDENTIAL_ID_SIZE || idLength > len(data) {
+		return nil, nil, ErrInvalidResponse
+	}
+	credentialID := append([]byte(nil), data[:idLength]...)
+	data = data[idLength:]
+
+	_, rest, err := parseCoseKey(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	publicKey := append([]byte(nil), data[:len(data)-len(rest)]...)
+
+	return credentialID, publicKey, nil
+}
+
+func credentialDescriptors(credentialIDs [][]byte) []CredentialDescriptor {
+	descriptors := make([]CredentialDescriptor, 0, len(credentialIDs))
+	for _, id := range credentialIDs {
+		descriptors = append(descriptors, CredentialDescriptor{
+			Type: PUBLIC_KEY_CREDENTIAL_TYPE,
+			ID:   encodeBase64Url(id),
+		})
+	}
+
+	return descriptors
+}
@@ -0,0 +1,265 @@
+//go:build unittest
+
+package webauthn_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/fgeck/gotth-postgres/internal/service/security/webauthn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	RP_ID     = "localhost"
+	RP_ORIGIN = "http://localhost:8081"
+	CHALLENGE = "c29tZS1jaGFsbGVuZ2U"
+)
+
+// softAuthenticator plays the browser and authenticator side of the
+// ceremonies with an ES256 key.
+type softAuthenticator struct {
+	t            *testing.T
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+}
+
+func newSoftAuthenticator(t *testing.T) *softAuthenticator {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	return &softAuthenticator{t: t, key: key, credentialID: []byte("credential-id")}
+}
+
+func (a *softAuthenticator) clientData(typ, challenge, origin string) []byte {
+	data, err := json.Marshal(map[string]any{"type": typ, "challenge": challenge, "origin": origin})
+	require.NoError(a.t, err)
+	return data
+}
+
+func (a *softAuthenticator) authData(rpID string, flags byte, signCount uint32) []byte {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	data := append(rpIDHash[:], flags)
+	return binary.BigEndian.AppendUint32(data, signCount)
+}
+
+func (a *softAuthenticator) coseKey() []byte {
+	x := a.key.X.FillBytes(make([]byte, 32))
+	y := a.key.Y.FillBytes(make([]byte, 32))
+	// {1: 2, 3: -7, -1: 1, -2: x, -3: y}
+	return slices.Concat(
+		[]byte{0xa5, 0x01, 0x02, 0x03, 0x26, 0x20, 0x01, 0x21, 0x58, 0x20}, x,
+		[]byte{0x22, 0x58, 0x20}, y,
+	)
+}
+
+func (a *softAuthenticator) register(rpID, challenge, origin string, flags byte) *webauthn.RegistrationResponse {
+	authData := a.authData(rpID, flags|0x40, 0)
+	authData = append(authData, make([]byte, 16)...) // aaguid
+	authData = binary.BigEndian.AppendUint16(authData, uint16(len(a.credentialID)))
+	authData = append(authData, a.credentialID...)
+	authData = append(authData, a.coseKey()...)
+
+	// {"fmt": "none", "attStmt": {}, "authData": authData}
+	attestationObject := slices.Concat(
+		[]byte{0xa3, 0x63}, []byte("fmt"), []byte{0x64}, []byte("none"),
+		[]byte{0x67}, []byte("attStmt"), []byte{0xa0},
+		[]byte{0x68}, []byte("authData"), []byte{0x59}, binary.BigEndian.AppendUint16(nil, uint16(len(authData))), authData,
+	)
+
+	return &webauthn.RegistrationResponse{
+		ID:    encode(a.credentialID),
+		RawID: encode(a.credentialID),
+		Type:  "public-key",
+		Response: webauthn.AttestationResponse{
+			ClientDataJSON:    encode(a.clientData("webauthn.create", challenge, origin)),
+			AttestationObject: encode(attestationObject),
+		},
+	}
+}
+
+func (a *softAuthenticator) login(challenge string, flags byte, signCount uint32) *webauthn.AuthenticationResponse {
+	clientData := a.clientData("webauthn.get", challenge, RP_ORIGIN)
+	authData := a.authData(RP_ID, flags, signCount)
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(slices.Concat(authData, clientDataHash[:]))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	require.NoError(a.t, err)
+
+	return &webauthn.AuthenticationResponse{
+		ID:    encode(a.credentialID),
+		RawID: encode(a.credentialID),
+		Type:  "public-key",
+		Response: webauthn.AssertionResponse{
+			ClientDataJSON:    encode(clientData),
+			AuthenticatorData: encode(authData),
+			Signature:         encode(signature),
+			UserHandle:        encode([]byte("user-handle")),
+		},
+	}
+}
+
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func setupWebauthnServiceTest() *webauthn.WebauthnService {
+	return webauthn.NewWebauthnService(RP_ID, "test", []string{RP_ORIGIN})
+}
+
+func TestVerifyRegistration(t *testing.T) {
+	t.Parallel()
+
+	t.Run("accepts a valid registration", func(t *testing.T) {
+		t.Parallel()
+		service := setupWebauthnServiceTest()
+		authenticator := newSoftAuthenticator(t)
+
+		credential, err := service.VerifyRegistration(authenticator.register(RP_ID, CHALLENGE, RP_ORIGIN, 0x05), CHALLENGE)
+
+		require.NoError(t, err)
+		assert.Equal(t, authenticator.credentialID, credential.CredentialID)
+		assert.Equal(t, authenticator.coseKey(), credential.PublicKey)
+		assert.True(t, credential.UserVerified)
+	})
+
+	t.Run("rejects a different challenge", func(t *testing.T) {
+		t.Parallel()
+		service := setupWebauthnServiceTest()
+		authenticator := newSoftAuthenticator(t)
+
+		_, err := service.VerifyRegistration(authenticator.register(RP_ID, "other", RP_ORIGIN, 0x05), CHALLENGE)
+
+		require.ErrorIs(t, err, webauthn.ErrChallengeMismatch)
+	})
+
+	t.Run("rejects a foreign origin", func(t *testing.T) {
+		t.Parallel()
+		service := setupWebauthnServiceTest()
+		authenticator := newSoftAuthenticator(t)
+
+		_, err := service.VerifyRegistration(authenticator.register(RP_ID, CHALLENGE, "https://evil.example", 0x05), CHALLENGE)
+
+		require.ErrorIs(t, err, webauthn.ErrOriginNotAllowed)
+	})
+
+	t.Run("rejects a credential for another relying party", func(t *testing.T) {
+		t.Parallel()
+		service := setupWebauthnServiceTest()
+		authenticator := newSoftAuthenticator(t)
+
+		_, err := service.VerifyRegistration(authenticator.register("evil.example", CHALLENGE, RP_ORIGIN, 0x05), CHALLENGE)
+
+		require.ErrorIs(t, err, webauthn.ErrRpIdMismatch)
+	})
+
+	t.Run("rejects a registration without user presence", func(t *testing.T) {
+		t.Parallel()
+		service := setupWebauthnServiceTest()
+		authenticator := newSoftAuthenticator(t)
+
+		_, err := service.VerifyRegistration(authenticator.register(RP_ID, CHALLENGE, RP_ORIGIN, 0x00), CHALLENGE)
+
+		require.ErrorIs(t, err, webauthn.ErrUserNotPresent)
+	})
+
+	t.Run("rejects garbage", func(t *testing.T) {
+		t.Parallel()
+		service := setupWebauthnServiceTest()
+		response := newSoftAuthenticator(t).register(RP_ID, CHALLENGE, RP_ORIGIN, 0x05)
+		response.Response.AttestationObject = encode([]byte{0xbf, 0xff})
+
+		_, err := service.VerifyRegistration(response, CHALLENGE)
+
+		require.ErrorIs(t, err, webauthn.ErrInvalidResponse)
+	})
+}
+
+func TestVerifyAssertion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("accepts a valid assertion", func(t *testing.T) {
+		t.Parallel()
+		service := setupWebauthnServiceTest()
+		authenticator := newSoftAuthenticator(t)
+
+		assertion, err := service.VerifyAssertion(authenticator.login(CHALLENGE, 0x05, 8), CHALLENGE, authenticator.coseKey(), 7)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint32(8), assertion.SignCount)
+	})
+
+	t.Run("accepts authenticators without counter", func(t *testing.T) {
+		t.Parallel()
+		service := setupWebauthnServiceTest()
+		authenticator := newSoftAuthenticator(t)
+
+		_, err := service.VerifyAssertion(authenticator.login(CHALLENGE, 0x05, 0), CHALLENGE, authenticator.coseKey(), 0)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects a sign count that did not increase", func(t *testing.T) {
+		t.Parallel()
+		service := setupWebauthnServiceTest()
+		authenticator := newSoftAuthenticator(t)
+
+		_, err := service.VerifyAssertion(authenticator.login(CHALLENGE, 0x05, 7), CHALLENGE, authenticator.coseKey(), 7)
+
+		require.ErrorIs(t, err, webauthn.ErrSignCountInvalid)
+	})
+
+	t.Run("requires user verification", func(t *testing.T) {
+		t.Parallel()
+		service := setupWebauthnServiceTest()
+		authenticator := newSoftAuthenticator(t)
+
+		_, err := service.VerifyAssertion(authenticator.login(CHALLENGE, 0x01, 8), CHALLENGE, authenticator.coseKey(), 7)
+
+		require.ErrorIs(t, err, webauthn.ErrUserVerificationRequired)
+	})
+
+	t.Run("rejects a signature of another key", func(t *testing.T) {
+		t.Parallel()
+		service := setupWebauthnServiceTest()
+		authenticator := newSoftAuthenticator(t)
+		otherAuthenticator := newSoftAuthenticator(t)
+
+		_, err := service.VerifyAssertion(otherAuthenticator.login(CHALLENGE, 0x05, 8), CHALLENGE, authenticator.coseKey(), 7)
+
+		require.ErrorIs(t, err, webauthn.ErrBadSignature)
+	})
+
+	t.Run("rejects a replayed challenge", func(t *testing.T) {
+		t.Parallel()
+		service := setupWebauthnServiceTest()
+		authenticator := newSoftAuthenticator(t)
+
+		_, err := service.VerifyAssertion(authenticator.login("old-challenge", 0x05, 8), CHALLENGE, authenticator.coseKey(), 7)
+
+		require.ErrorIs(t, err, webauthn.ErrChallengeMismatch)
+	})
+}
+
+func TestCreationOptions(t *testing.T) {
+	t.Parallel()
+	service := setupWebauthnServiceTest()
+
+	options := service.CreationOptions(CHALLENGE, webauthn.NewUserEntity([]byte("user-id"), "user@example.com", "user"), [][]byte{[]byte("existing")})
+
+	assert.Equal(t, RP_ID, options.Rp.ID)
+	assert.Equal(t, encode([]byte("user-id")), options.User.ID)
+	assert.Equal(t, "required", options.AuthenticatorSelection.ResidentKey)
+	assert.Equal(t, "none", options.Attestation)
+	require.Len(t, options.ExcludeCredentials, 1)
+	assert.Equal(t, encode([]byte("existing")), options.ExcludeCredentials[0].ID)
+}
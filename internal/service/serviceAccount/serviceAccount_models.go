@@ -0,0 +1,93 @@
+package serviceAccount
+
+import (
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	"github.com/google/uuid"
+)
+
+// ServiceAccountDto uses the id of the account as OAuth2 client_id.
+type ServiceAccountDto struct {
+	ID          uuid.UUID        `json:"id"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Scopes      []string         `json:"scopes"`
+	DisabledAt  *time.Time       `json:"disabledAt,omitempty"`
+	Credentials []*CredentialDto `json:"credentials,omitempty"`
+	CreatedAt   time.Time        `json:"createdAt"`
+	UpdatedAt   time.Time        `json:"updatedAt"`
+}
+
+func NewServiceAccountDto(account repository.GetServiceAccountRow) *ServiceAccountDto {
+	dto := &ServiceAccountDto{
+		ID:          uuid.UUID(account.UserID.Bytes),
+		Name:        account.Username,
+		Description: account.Description,
+		Scopes:      account.Scopes,
+		CreatedAt:   account.CreatedAt.Time,
+		UpdatedAt:   account.UpdatedAt.Time,
+	}
+	if dto.Scopes == nil {
+		dto.Scopes = []string{}
+	}
+	if account.DisabledAt.Valid {
+		disabledAt := account.DisabledAt.Time
+		dto.DisabledAt = &disabledAt
+	}
+
+	return dto
+}
+
+// CredentialDto never contains the secret. Public keys are shown, they are
+// not confidential and help telling keys apart.
+type CredentialDto struct {
+	ID         uuid.UUID  `json:"id"`
+	Type       string     `json:"type"`
+	PublicKey  string     `json:"publicKey,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+func NewCredentialDto(credential repository.ServiceAccountCredential) *CredentialDto {
+	dto := &CredentialDto{
+		ID:        uuid.UUID(credential.ID.Bytes),
+		Type:      credential.CredentialType,
+		PublicKey: credential.PublicKey.String,
+		CreatedAt: credential.CreatedAt.Time,
+	}
+	if credential.LastUsedAt.Valid {
+		lastUsedAt := credential.LastUsedAt.Time
+		dto.LastUsedAt = &lastUsedAt
+	}
+
+	return dto
+}
+
+// CreatedSecretDto is returned once when a client secret is created. The
+// secret cannot be shown again later.
+type CreatedSecretDto struct {
+	*CredentialDto
+	ClientID     uuid.UUID `json:"clientId"`
+	ClientSecret string    `json:"clientSecret"`
+}
+
+// ClientCredentialsRequest holds the form parameters of a token request.
+// Either ClientSecret or ClientAssertion authenticates the client.
+type ClientCredentialsRequest struct {
+	GrantType           string
+	ClientID            string
+	ClientSecret        string
+	ClientAssertionType string
+	ClientAssertion     string
+	Scope               string
+}
+
+// AccessTokenDto is the token response of RFC 6749, which is why its fields
+// are snake_case unlike the rest of the API.
+type AccessTokenDto struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope,omitempty"`
+}
@@ -0,0 +1,545 @@
+package serviceAccount
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	"github.com/fgeck/gotth-postgres/internal/service/rbac"
+	"github.com/fgeck/gotth-postgres/internal/service/security/jwt"
+	"github.com/fgeck/gotth-postgres/internal/service/session"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	"github.com/fgeck/gotth-postgres/internal/service/validation"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	CLIENT_SECRET_PREFIX             = "gsas_"
+	CLIENT_SECRET_BYTES              = 32
+	EMAIL_DOMAIN                     = "service-accounts.invalid"
+	MAX_DESCRIPTION_LENGTH           = 500
+	CREDENTIAL_TYPE_SECRET           = "secret"
+	CREDENTIAL_TYPE_KEY              = "key"
+	GRANT_TYPE_CLIENT_CREDENTIALS    = "client_credentials"
+	CLIENT_ASSERTION_TYPE_JWT_BEARER = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+	TOKEN_PATH                       = "/oauth/token"
+	TOKEN_TYPE_BEARER                = "Bearer"
+	UNIQUE_VIOLATION                 = "23505"
+)
+
+var (
+	ErrServiceAccountNotFound = errors.New("service account not found")
+	ErrCredentialNotFound     = errors.New("credential not found")
+	ErrNameTaken              = errors.New("name is already taken")
+	ErrInvalidDescription     = errors.New("description must be at most 500 characters")
+	ErrUnknownScope           = errors.New("unknown scope")
+	ErrScopeNotHeld           = errors.New("only permissions you hold can be granted as scopes")
+	ErrInvalidPublicKey       = errors.New("public key must be a PEM encoded RSA, ECDSA P-256 or Ed25519 key")
+	ErrInvalidClient          = errors.New("client authentication failed")
+	ErrInvalidRequest         = errors.New("token request is malformed")
+	ErrUnsupportedGrantType   = errors.New("grant type is not supported")
+	ErrInvalidScope           = errors.New("requested scope was not granted")
+)
+
+type ServiceAccountServiceInterface interface {
+	CreateServiceAccount(ctx context.Context, creatorID uuid.UUID, creatorPermissions []string, name, description string, scopes []string) (*ServiceAccountDto, error)
+	ListServiceAccounts(ctx context.Context) ([]*ServiceAccountDto, error)
+	GetServiceAccount(ctx context.Context, id uuid.UUID) (*ServiceAccountDto, error)
+	SetScopes(ctx context.Context, actorPermissions []string, id uuid.UUID, scopes []string) (*ServiceAccountDto, error)
+	DeleteServiceAccount(ctx context.Context, id uuid.UUID) error
+	CreateClientSecret(ctx context.Context, id uuid.UUID) (*CreatedSecretDto, error)
+	AddPublicKey(ctx context.Context, id uuid.UUID, publicKey string) (*CredentialDto, error)
+	DeleteCredential(ctx context.Context, id, credentialID uuid.UUID) error
+	IssueToken(ctx context.Context, request ClientCredentialsRequest) (*AccessTokenDto, error)
+}
+
+// ServiceAccountService manages accounts that other systems use to call the
+// API. They have no password and never get a session, instead they exchange
+// a client secret or an assertion signed with their key for a short-lived
+// access token with the OAuth2 client_credentials grant. Their permissions
+// are the scopes an admin granted, not the permissions of roles.
+type ServiceAccountService struct {
+	queries        repository.Querier
+	userService    user.UserServiceInterface
+	validator      validation.ValidationServiceInterface
+	rbacService    rbac.RbacServiceInterface
+	sessionService session.SessionServiceInterface
+	jwtService     jwt.JwtServiceInterface
+	publicUrl      string
+}
+
+func NewServiceAccountService(
+	queries repository.Querier,
+	userService user.UserServiceInterface,
+	validator validation.ValidationServiceInterface,
+	rbacService rbac.RbacServiceInterface,
+	sessionService session.SessionServiceInterface,
+	jwtService jwt.JwtServiceInterface,
+	publicUrl string,
+) *ServiceAccountService {
+	return &ServiceAccountService{
+		queries:        queries,
+		userService:    userService,
+		validator:      validator,
+		rbacService:    rbacService,
+		sessionService: sessionService,
+		jwtService:     jwtService,
+		publicUrl:      publicUrl,
+	}
+}
+
+// CreateServiceAccount stores the account as a user, so that names are unique
+// across people and machines and audit entries can point to either. The email
+// address uses a reserved domain and never receives mail. The creator can only
+// grant scopes out of creatorPermissions, otherwise the account would let them
+// act beyond their own permissions.
+func (s *ServiceAccountService) CreateServiceAccount(
+	ctx context.Context,
+	creatorID uuid.UUID,
+	creatorPermissions []string,
+	name, description string,
+	scopes []string,
+) (*ServiceAccountDto, error) {
+	name = strings.TrimSpace(name)
+	if err := s.validator.ValidateUsername(name); err != nil {
+		return nil, err
+	}
+	description = strings.TrimSpace(description)
+	if len(description) > MAX_DESCRIPTION_LENGTH {
+		return nil, ErrInvalidDescription
+	}
+	scopes, err := s.normalizeScopes(ctx, scopes)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkScopesHeld(scopes, nil, creatorPermissions); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.userService.UserExistsByUsername(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrNameTaken
+	}
+
+	// The user and the service account are inserted by a single statement.
+	// Names only differing in case share the email address, the unique
+	// constraints catch them and concurrent requests for the same name.
+	created, err := s.queries.CreateServiceAccount(
+		ctx,
+		repository.CreateServiceAccountParams{
+			Username:    name,
+			Email:       strings.ToLower(name) + "@" + EMAIL_DOMAIN,
+			UserRole:    user.UserRoleUser.Name,
+			Description: description,
+			Scopes:      scopes,
+			CreatedBy:   pgtype.UUID{Bytes: creatorID, Valid: true},
+		},
+	)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == UNIQUE_VIOLATION {
+		return nil, ErrNameTaken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create service account: %w", err)
+	}
+
+	return s.GetServiceAccount(ctx, uuid.UUID(created.UserID.Bytes))
+}
+
+func (s *ServiceAccountService) ListServiceAccounts(ctx context.Context) ([]*ServiceAccountDto, error) {
+	accounts, err := s.queries.ListServiceAccounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+
+	dtos := make([]*ServiceAccountDto, 0, len(accounts))
+	for _, account := range accounts {
+		dtos = append(dtos, NewServiceAccountDto(repository.GetServiceAccountRow(account)))
+	}
+
+	return dtos, nil
+}
+
+// GetServiceAccount includes the credentials of the account.
+func (s *ServiceAccountService) GetServiceAccount(ctx context.Context, id uuid.UUID) (*ServiceAccountDto, error) {
+	account, err := s.getServiceAccount(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials, err := s.queries.ListServiceAccountCredentials(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service account credentials: %w", err)
+	}
+	dto := NewServiceAccountDto(account)
+	for _, credential := range credentials {
+		dto.Credentials = append(dto.Credentials, NewCredentialDto(credential))
+	}
+
+	return dto, nil
+}
+
+// SetScopes replaces the scopes of an account. Scopes it did not have yet
+// have to be in actorPermissions, granted ones can be kept or removed by
+// anyone. Tokens issued before lose their validity, so that a removed scope
+// cannot be used any longer.
+func (s *ServiceAccountService) SetScopes(
+	ctx context.Context,
+	actorPermissions []string,
+	id uuid.UUID,
+	scopes []string,
+) (*ServiceAccountDto, error) {
+	scopes, err := s.normalizeScopes(ctx, scopes)
+	if err != nil {
+		return nil, err
+	}
+	account, err := s.getServiceAccount(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkScopesHeld(scopes, account.Scopes, actorPermissions); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.queries.UpdateServiceAccountScopes(
+		ctx,
+		repository.UpdateServiceAccountScopesParams{
+			UserID: pgtype.UUID{Bytes: id, Valid: true},
+			Scopes: scopes,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update service account scopes: %w", err)
+	}
+	if updated == 0 {
+		return nil, ErrServiceAccountNotFound
+	}
+	if err := s.sessionService.RevokeAllUserSessions(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.GetServiceAccount(ctx, id)
+}
+
+// DeleteServiceAccount removes the user of the account together with its
+// credentials.
+func (s *ServiceAccountService) DeleteServiceAccount(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.getServiceAccount(ctx, id); err != nil {
+		return err
+	}
+	if err := s.queries.DeleteUser(ctx, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		return fmt.Errorf("failed to delete service account: %w", err)
+	}
+
+	return nil
+}
+
+// CreateClientSecret returns the only copy of the secret. An account may
+// have several secrets at once, which allows rotating them without downtime.
+func (s *ServiceAccountService) CreateClientSecret(ctx context.Context, id uuid.UUID) (*CreatedSecretDto, error) {
+	if _, err := s.getServiceAccount(ctx, id); err != nil {
+		return nil, err
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate client secret: %w", err)
+	}
+	credential, err := s.queries.CreateServiceAccountCredential(
+		ctx,
+		repository.CreateServiceAccountCredentialParams{
+			UserID:         pgtype.UUID{Bytes: id, Valid: true},
+			CredentialType: CREDENTIAL_TYPE_SECRET,
+			SecretHash:     pgtype.Text{String: hashSecret(secret), Valid: true},
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client secret: %w", err)
+	}
+
+	return &CreatedSecretDto{CredentialDto: NewCredentialDto(credential), ClientID: id, ClientSecret: secret}, nil
+}
+
+// AddPublicKey registers a key the account signs client assertions with.
+// The private key never leaves the client.
+func (s *ServiceAccountService) AddPublicKey(ctx context.Context, id uuid.UUID, publicKey string) (*CredentialDto, error) {
+	publicKey = strings.TrimSpace(publicKey)
+	if _, err := jwt.ParsePublicKey("", []byte(publicKey)); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPublicKey, err)
+	}
+	if _, err := s.getServiceAccount(ctx, id); err != nil {
+		return nil, err
+	}
+
+	credential, err := s.queries.CreateServiceAccountCredential(
+		ctx,
+		repository.CreateServiceAccountCredentialParams{
+			UserID:         pgtype.UUID{Bytes: id, Valid: true},
+			CredentialType: CREDENTIAL_TYPE_KEY,
+			PublicKey:      pgtype.Text{String: publicKey, Valid: true},
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add public key: %w", err)
+	}
+
+	return NewCredentialDto(credential), nil
+}
+
+// DeleteCredential stops new tokens being issued with the credential. Tokens
+// issued before stay valid until they expire.
+func (s *ServiceAccountService) DeleteCredential(ctx context.Context, id, credentialID uuid.UUID) error {
+	deleted, err := s.queries.DeleteServiceAccountCredential(
+		ctx,
+		repository.DeleteServiceAccountCredentialParams{
+			ID:     pgtype.UUID{Bytes: credentialID, Valid: true},
+			UserID: pgtype.UUID{Bytes: id, Valid: true},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete credential: %w", err)
+	}
+	if deleted == 0 {
+		return ErrCredentialNotFound
+	}
+
+	return nil
+}
+
+// IssueToken implements the client_credentials grant. Without a requested
+// scope the token carries all scopes of the account, otherwise exactly the
+// requested ones, each of which has to be granted.
+func (s *ServiceAccountService) IssueToken(ctx context.Context, request ClientCredentialsRequest) (*AccessTokenDto, error) {
+	if request.GrantType != GRANT_TYPE_CLIENT_CREDENTIALS {
+		return nil, ErrUnsupportedGrantType
+	}
+	clientID, err := uuid.Parse(request.ClientID)
+	if err != nil {
+		return nil, ErrInvalidClient
+	}
+	account, err := s.getServiceAccount(ctx, clientID)
+	if errors.Is(err, ErrServiceAccountNotFound) {
+		return nil, ErrInvalidClient
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	credentialID, err := s.authenticateClient(ctx, clientID, request)
+	if err != nil {
+		return nil, err
+	}
+	if account.DisabledAt.Valid {
+		return nil, ErrInvalidClient
+	}
+
+	scopes := account.Scopes
+	if requested := strings.Fields(request.Scope); len(requested) > 0 {
+		for _, scope := range requested {
+			if !slices.Contains(account.Scopes, scope) {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidScope, scope)
+			}
+		}
+		slices.Sort(requested)
+		scopes = slices.Compact(requested)
+	}
+
+	userDto, err := s.userService.GetUserById(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if !userDto.IsServiceAccount() {
+		return nil, ErrInvalidClient
+	}
+	userDto.Permissions = scopes
+
+	if err := s.queries.TouchServiceAccountCredential(ctx, credentialID); err != nil {
+		return nil, fmt.Errorf("failed to update credential: %w", err)
+	}
+	token, err := s.jwtService.GenerateToken(userDto)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	return &AccessTokenDto{
+		AccessToken: token,
+		TokenType:   TOKEN_TYPE_BEARER,
+		ExpiresIn:   int64(s.jwtService.AccessTokenExpiration().Seconds()),
+		Scope:       strings.Join(scopes, " "),
+	}, nil
+}
+
+// authenticateClient returns the id of the credential the request was
+// authenticated with.
+func (s *ServiceAccountService) authenticateClient(
+	ctx context.Context,
+	clientID uuid.UUID,
+	request ClientCredentialsRequest,
+) (pgtype.UUID, error) {
+	pgClientID := pgtype.UUID{Bytes: clientID, Valid: true}
+
+	switch {
+	case request.ClientAssertion != "" && request.ClientSecret != "":
+		return pgtype.UUID{}, ErrInvalidRequest
+	case request.ClientAssertion != "":
+		if request.ClientAssertionType != CLIENT_ASSERTION_TYPE_JWT_BEARER {
+			return pgtype.UUID{}, ErrInvalidRequest
+		}
+		credentials, err := s.queries.ListServiceAccountCredentials(ctx, pgClientID)
+		if err != nil {
+			return pgtype.UUID{}, fmt.Errorf("failed to list service account credentials: %w", err)
+		}
+		keys := make([]*jwt.SigningKey, 0, len(credentials))
+		for _, credential := range credentials {
+			if credential.CredentialType != CREDENTIAL_TYPE_KEY {
+				continue
+			}
+			// Keys are validated when they are added, a key failing here
+			// can only be skipped.
+			key, err := jwt.ParsePublicKey(uuid.UUID(credential.ID.Bytes).String(), []byte(credential.PublicKey.String))
+			if err != nil {
+				continue
+			}
+			keys = append(keys, key)
+		}
+
+		assertion, err := s.jwtService.ValidateClientAssertion(
+			request.ClientAssertion,
+			clientID.String(),
+			s.publicUrl+TOKEN_PATH,
+			keys,
+		)
+		if err != nil {
+			return pgtype.UUID{}, ErrInvalidClient
+		}
+		credentialID, err := uuid.Parse(assertion.Key.Kid)
+		if err != nil {
+			return pgtype.UUID{}, ErrInvalidClient
+		}
+		if err := s.useClientAssertion(ctx, pgClientID, assertion); err != nil {
+			return pgtype.UUID{}, err
+		}
+
+		return pgtype.UUID{Bytes: credentialID, Valid: true}, nil
+	case request.ClientSecret != "":
+		credential, err := s.queries.GetServiceAccountSecret(
+			ctx,
+			repository.GetServiceAccountSecretParams{
+				UserID:     pgClientID,
+				SecretHash: pgtype.Text{String: hashSecret(request.ClientSecret), Valid: true},
+			},
+		)
+		if errors.Is(err, sql.ErrNoRows) {
+			return pgtype.UUID{}, ErrInvalidClient
+		}
+		if err != nil {
+			return pgtype.UUID{}, fmt.Errorf("failed to get client secret: %w", err)
+		}
+
+		return credential.ID, nil
+	default:
+		return pgtype.UUID{}, ErrInvalidClient
+	}
+}
+
+// useClientAssertion records the jti of the assertion until it expires and
+// rejects it if it was used before.
+func (s *ServiceAccountService) useClientAssertion(ctx context.Context, clientID pgtype.UUID, assertion *jwt.ClientAssertion) error {
+	// Expired assertions are rejected anyway, so there is no need to keep them around.
+	if err := s.queries.DeleteExpiredClientAssertions(ctx); err != nil {
+		return fmt.Errorf("failed to delete expired client assertions: %w", err)
+	}
+	inserted, err := s.queries.UseClientAssertion(
+		ctx,
+		repository.UseClientAssertionParams{
+			UserID:    clientID,
+			Jti:       assertion.ID,
+			ExpiresAt: pgtype.Timestamptz{Time: assertion.ExpiresAt, Valid: true},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record client assertion: %w", err)
+	}
+	if inserted == 0 {
+		return ErrInvalidClient
+	}
+
+	return nil
+}
+
+func (s *ServiceAccountService) getServiceAccount(ctx context.Context, id uuid.UUID) (repository.GetServiceAccountRow, error) {
+	account, err := s.queries.GetServiceAccount(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.GetServiceAccountRow{}, ErrServiceAccountNotFound
+	}
+	if err != nil {
+		return repository.GetServiceAccountRow{}, fmt.Errorf("failed to get service account: %w", err)
+	}
+
+	return account, nil
+}
+
+// normalizeScopes accepts any known permission as scope but the tenant
+// permissions, as service accounts do not act in an organization.
+func (s *ServiceAccountService) normalizeScopes(ctx context.Context, scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return []string{}, nil
+	}
+	known, err := s.rbacService.ListPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if slices.Contains(rbac.TENANT_PERMISSIONS, scope) ||
+			!slices.ContainsFunc(known, func(p *rbac.PermissionDto) bool { return p.Name == scope }) {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownScope, scope)
+		}
+		normalized = append(normalized, scope)
+	}
+	slices.Sort(normalized)
+
+	return slices.Compact(normalized), nil
+}
+
+// checkScopesHeld makes sure that every scope not in granted is one of
+// permissions.
+func checkScopesHeld(scopes, granted, permissions []string) error {
+	for _, scope := range scopes {
+		if !slices.Contains(granted, scope) && !slices.Contains(permissions, scope) {
+			return fmt.Errorf("%w: %q", ErrScopeNotHeld, scope)
+		}
+	}
+
+	return nil
+}
+
+func generateSecret() (string, error) {
+	buf := make([]byte, CLIENT_SECRET_BYTES)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return CLIENT_SECRET_PREFIX + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,477 @@
+//go:build unittest
+
+package serviceAccount_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"database/sql"
+	"encoding/hex"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	repositoryMocks "github.com/fgeck/gotth-postgres/internal/repository/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/rbac"
+	rbacMocks "github.com/fgeck/gotth-postgres/internal/service/rbac/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/security/jwt"
+	jwtMocks "github.com/fgeck/gotth-postgres/internal/service/security/jwt/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/serviceAccount"
+	sessionMocks "github.com/fgeck/gotth-postgres/internal/service/session/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	userMocks "github.com/fgeck/gotth-postgres/internal/service/user/mocks"
+	validationMocks "github.com/fgeck/gotth-postgres/internal/service/validation/mocks"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	PUBLIC_URL = "http://localhost:8081"
+	SECRET     = "gsas_0123456789abcdefghijklmnopqrstuvwxyzABCDE"
+)
+
+type serviceAccountServiceMocks struct {
+	queries        *repositoryMocks.MockQuerier
+	userService    *userMocks.MockUserServiceInterface
+	validator      *validationMocks.MockValidationServiceInterface
+	rbacService    *rbacMocks.MockRbacServiceInterface
+	sessionService *sessionMocks.MockSessionServiceInterface
+	jwtService     *jwtMocks.MockJwtServiceInterface
+}
+
+func setupServiceAccountServiceTest(t *testing.T) (*serviceAccountServiceMocks, *serviceAccount.ServiceAccountService) {
+	mocks := &serviceAccountServiceMocks{
+		queries:        repositoryMocks.NewMockQuerier(t),
+		userService:    userMocks.NewMockUserServiceInterface(t),
+		validator:      validationMocks.NewMockValidationServiceInterface(t),
+		rbacService:    rbacMocks.NewMockRbacServiceInterface(t),
+		sessionService: sessionMocks.NewMockSessionServiceInterface(t),
+		jwtService:     jwtMocks.NewMockJwtServiceInterface(t),
+	}
+	service := serviceAccount.NewServiceAccountService(
+		mocks.queries,
+		mocks.userService,
+		mocks.validator,
+		mocks.rbacService,
+		mocks.sessionService,
+		mocks.jwtService,
+		PUBLIC_URL,
+	)
+	return mocks, service
+}
+
+var knownPermissions = []*rbac.PermissionDto{
+	{Name: rbac.PERMISSION_USERS_READ},
+	{Name: rbac.PERMISSION_USERS_WRITE},
+	{Name: rbac.PERMISSION_ROLES_WRITE},
+}
+
+var adminPermissions = []string{rbac.PERMISSION_USERS_READ, rbac.PERMISSION_USERS_WRITE}
+
+func hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func newAccount(id uuid.UUID, scopes ...string) repository.GetServiceAccountRow {
+	return repository.GetServiceAccountRow{
+		UserID:   pgtype.UUID{Bytes: id, Valid: true},
+		Username: "deploybot",
+		Scopes:   scopes,
+	}
+}
+
+func TestCreateServiceAccount(t *testing.T) {
+	ctx := context.Background()
+	creatorID := uuid.New()
+	id := uuid.New()
+
+	t.Run("creates a service account user with scopes", func(t *testing.T) {
+		mocks, service := setupServiceAccountServiceTest(t)
+		mocks.validator.On("ValidateUsername", "deploybot").Return(nil)
+		mocks.rbacService.On("ListPermissions", ctx).Return(knownPermissions, nil)
+		mocks.userService.On("UserExistsByUsername", ctx, "deploybot").Return(false, nil)
+		mocks.queries.On("CreateServiceAccount", ctx, repository.CreateServiceAccountParams{
+			Username:    "deploybot",
+			Email:       "deploybot@" + serviceAccount.EMAIL_DOMAIN,
+			UserRole:    user.UserRoleUser.Name,
+			Description: "Deploys",
+			Scopes:      []string{rbac.PERMISSION_USERS_READ},
+			CreatedBy:   pgtype.UUID{Bytes: creatorID, Valid: true},
+		}).Return(repository.ServiceAccount{UserID: pgtype.UUID{Bytes: id, Valid: true}}, nil)
+		mocks.queries.On("GetServiceAccount", ctx, pgtype.UUID{Bytes: id, Valid: true}).
+			Return(newAccount(id, rbac.PERMISSION_USERS_READ), nil)
+		mocks.queries.On("ListServiceAccountCredentials", ctx, pgtype.UUID{Bytes: id, Valid: true}).
+			Return([]repository.ServiceAccountCredential{}, nil)
+
+		created, err := service.CreateServiceAccount(ctx, creatorID, adminPermissions, " deploybot ", " Deploys ", []string{"USERS:READ"})
+
+		require.NoError(t, err)
+		assert.Equal(t, id, created.ID)
+		assert.Equal(t, []string{rbac.PERMISSION_USERS_READ}, created.Scopes)
+	})
+
+	t.Run("rejects unknown scopes", func(t *testing.T) {
+		mocks, service := setupServiceAccountServiceTest(t)
+		mocks.validator.On("ValidateUsername", "deploybot").Return(nil)
+		mocks.rbacService.On("ListPermissions", ctx).Return(knownPermissions, nil)
+
+		_, err := service.CreateServiceAccount(ctx, creatorID, adminPermissions, "deploybot", "", []string{"billing:write"})
+		require.ErrorIs(t, err, serviceAccount.ErrUnknownScope)
+
+		_, err = service.CreateServiceAccount(ctx, creatorID, adminPermissions, "deploybot", "", []string{rbac.PERMISSION_MEMBERS_READ})
+		require.ErrorIs(t, err, serviceAccount.ErrUnknownScope)
+	})
+
+	t.Run("rejects scopes the creator does not hold", func(t *testing.T) {
+		mocks, service := setupServiceAccountServiceTest(t)
+		mocks.validator.On("ValidateUsername", "deploybot").Return(nil)
+		mocks.rbacService.On("ListPermissions", ctx).Return(knownPermissions, nil)
+
+		_, err := service.CreateServiceAccount(ctx, creatorID, adminPermissions, "deploybot", "", []string{rbac.PERMISSION_ROLES_WRITE})
+
+		require.ErrorIs(t, err, serviceAccount.ErrScopeNotHeld)
+		mocks.queries.AssertNotCalled(t, "CreateServiceAccount", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a taken name", func(t *testing.T) {
+		mocks, service := setupServiceAccountServiceTest(t)
+		mocks.validator.On("ValidateUsername", "deploybot").Return(nil)
+		mocks.userService.On("UserExistsByUsername", ctx, "deploybot").Return(true, nil)
+
+		_, err := service.CreateServiceAccount(ctx, creatorID, adminPermissions, "deploybot", "", nil)
+
+		require.ErrorIs(t, err, serviceAccount.ErrNameTaken)
+		mocks.queries.AssertNotCalled(t, "CreateServiceAccount", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a name that only differs in case from a taken one", func(t *testing.T) {
+		mocks, service := setupServiceAccountServiceTest(t)
+		mocks.validator.On("ValidateUsername", "DeployBot").Return(nil)
+		mocks.userService.On("UserExistsByUsername", ctx, "DeployBot").Return(false, nil)
+		mocks.queries.On("CreateServiceAccount", ctx, mock.Anything).
+			Return(repository.ServiceAccount{}, &pgconn.PgError{Code: serviceAccount.UNIQUE_VIOLATION})
+
+		_, err := service.CreateServiceAccount(ctx, creatorID, adminPermissions, "DeployBot", "", nil)
+
+		require.ErrorIs(t, err, serviceAccount.ErrNameTaken)
+	})
+}
+
+func TestSetScopes(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+
+	t.Run("replaces the scopes and revokes issued tokens", func(t *testing.T) {
+		mocks, service := setupServiceAccountServiceTest(t)
+		mocks.rbacService.On("ListPermissions", ctx).Return(knownPermissions, nil)
+		mocks.queries.On("GetServiceAccount", ctx, pgtype.UUID{Bytes: id, Valid: true}).
+			Return(newAccount(id, rbac.PERMISSION_USERS_READ), nil).Once()
+		mocks.queries.On("UpdateServiceAccountScopes", ctx, repository.UpdateServiceAccountScopesParams{
+			UserID: pgtype.UUID{Bytes: id, Valid: true},
+			Scopes: []string{rbac.PERMISSION_USERS_READ, rbac.PERMISSION_USERS_WRITE},
+		}).Return(int64(1), nil)
+		mocks.sessionService.On("RevokeAllUserSessions", ctx, id).Return(nil)
+		mocks.queries.On("GetServiceAccount", ctx, pgtype.UUID{Bytes: id, Valid: true}).
+			Return(newAccount(id, rbac.PERMISSION_USERS_READ, rbac.PERMISSION_USERS_WRITE), nil)
+		mocks.queries.On("ListServiceAccountCredentials", ctx, pgtype.UUID{Bytes: id, Valid: true}).
+			Return([]repository.ServiceAccountCredential{}, nil)
+
+		updated, err := service.SetScopes(ctx, adminPermissions, id, []string{rbac.PERMISSION_USERS_WRITE, rbac.PERMISSION_USERS_READ})
+
+		require.NoError(t, err)
+		assert.Len(t, updated.Scopes, 2)
+	})
+
+	t.Run("keeps granted scopes the actor does not hold", func(t *testing.T) {
+		mocks, service := setupServiceAccountServiceTest(t)
+		mocks.rbacService.On("ListPermissions", ctx).Return(knownPermissions, nil)
+		mocks.queries.On("GetServiceAccount", ctx, pgtype.UUID{Bytes: id, Valid: true}).
+			Return(newAccount(id, rbac.PERMISSION_ROLES_WRITE), nil)
+		mocks.queries.On("UpdateServiceAccountScopes", ctx, repository.UpdateServiceAccountScopesParams{
+			UserID: pgtype.UUID{Bytes: id, Valid: true},
+			Scopes: []string{rbac.PERMISSION_ROLES_WRITE, rbac.PERMISSION_USERS_READ},
+		}).Return(int64(1), nil)
+		mocks.sessionService.On("RevokeAllUserSessions", ctx, id).Return(nil)
+		mocks.queries.On("ListServiceAccountCredentials", ctx, pgtype.UUID{Bytes: id, Valid: true}).
+			Return([]repository.ServiceAccountCredential{}, nil)
+
+		_, err := service.SetScopes(ctx, adminPermissions, id, []string{rbac.PERMISSION_USERS_READ, rbac.PERMISSION_ROLES_WRITE})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects new scopes the actor does not hold", func(t *testing.T) {
+		mocks, service := setupServiceAccountServiceTest(t)
+		mocks.rbacService.On("ListPermissions", ctx).Return(knownPermissions, nil)
+		mocks.queries.On("GetServiceAccount", ctx, pgtype.UUID{Bytes: id, Valid: true}).Return(newAccount(id), nil)
+
+		_, err := service.SetScopes(ctx, adminPermissions, id, []string{rbac.PERMISSION_ROLES_WRITE})
+
+		require.ErrorIs(t, err, serviceAccount.ErrScopeNotHeld)
+		mocks.queries.AssertNotCalled(t, "UpdateServiceAccountScopes", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects an unknown account", func(t *testing.T) {
+		mocks, service := setupServiceAccountServiceTest(t)
+		mocks.queries.On("GetServiceAccount", ctx, mock.Anything).Return(repository.GetServiceAccountRow{}, sql.ErrNoRows)
+
+		_, err := service.SetScopes(ctx, adminPermissions, id, nil)
+
+		require.ErrorIs(t, err, serviceAccount.ErrServiceAccountNotFound)
+		mocks.sessionService.AssertNotCalled(t, "RevokeAllUserSessions", mock.Anything, mock.Anything)
+	})
+}
+
+func TestCreateClientSecret(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+
+	mocks, service := setupServiceAccountServiceTest(t)
+	mocks.queries.On("GetServiceAccount", ctx, pgtype.UUID{Bytes: id, Valid: true}).Return(newAccount(id), nil)
+	var params repository.CreateServiceAccountCredentialParams
+	mocks.queries.On("CreateServiceAccountCredential", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		params = args.Get(1).(repository.CreateServiceAccountCredentialParams)
+	}).Return(repository.ServiceAccountCredential{CredentialType: serviceAccount.CREDENTIAL_TYPE_SECRET}, nil)
+
+	created, err := service.CreateClientSecret(ctx, id)
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(created.ClientSecret, serviceAccount.CLIENT_SECRET_PREFIX))
+	assert.Equal(t, id, created.ClientID)
+	assert.Equal(t, hash(created.ClientSecret), params.SecretHash.String)
+	assert.False(t, params.PublicKey.Valid)
+}
+
+func TestAddPublicKey(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+
+	t.Run("rejects an invalid key", func(t *testing.T) {
+		_, service := setupServiceAccountServiceTest(t)
+
+		_, err := service.AddPublicKey(ctx, id, "not a key")
+
+		require.ErrorIs(t, err, serviceAccount.ErrInvalidPublicKey)
+	})
+
+	t.Run("rejects an unknown account", func(t *testing.T) {
+		mocks, service := setupServiceAccountServiceTest(t)
+		mocks.queries.On("GetServiceAccount", ctx, mock.Anything).Return(repository.GetServiceAccountRow{}, sql.ErrNoRows)
+
+		_, err := service.AddPublicKey(ctx, id, generatePublicKeyPem(t))
+
+		require.ErrorIs(t, err, serviceAccount.ErrServiceAccountNotFound)
+	})
+}
+
+func TestIssueToken(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	pgID := pgtype.UUID{Bytes: id, Valid: true}
+	credentialID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	serviceUser := &user.UserDto{ID: id, Role: user.UserRoleUser, AccountType: user.ACCOUNT_TYPE_SERVICE}
+	request := func() serviceAccount.ClientCredentialsRequest {
+		return serviceAccount.ClientCredentialsRequest{
+			GrantType:    serviceAccount.GRANT_TYPE_CLIENT_CREDENTIALS,
+			ClientID:     id.String(),
+			ClientSecret: SECRET,
+		}
+	}
+	expectSecret := func(mocks *serviceAccountServiceMocks) {
+		mocks.queries.On("GetServiceAccountSecret", ctx, repository.GetServiceAccountSecretParams{
+			UserID:     pgID,
+			SecretHash: pgtype.Text{String: hash(SECRET), Valid: true},
+		}).Return(repository.ServiceAccountCredential{ID: credentialID}, nil)
+	}
+
+	t.Run("issues a token with all granted scopes", func(t *testing.T) {
+		mocks, service := setupServiceAccountServiceTest(t)
+		mocks.queries.On("GetServiceAccount", ctx, pgID).
+			Return(newAccount(id, rbac.PERMISSION_USERS_READ, rbac.PERMISSION_USERS_WRITE), nil)
+		expectSecret(mocks)
+		mocks.userService.On("GetUserById", ctx, id).Return(serviceUser, nil)
+		mocks.queries.On("TouchServiceAccountCredential", ctx, credentialID).Return(nil)
+		mocks.jwtService.On("GenerateToken", mock.MatchedBy(func(u *user.UserDto) bool {
+			return len(u.Permissions) == 2
+		})).Return("access-token", nil)
+		mocks.jwtService.On("AccessTokenExpiration").Return(15 * time.Minute)
+
+		token, err := service.IssueToken(ctx, request())
+
+		require.NoError(t, err)
+		assert.Equal(t, "access-token", token.AccessToken)
+		assert.Equal(t, serviceAccount.TOKEN_TYPE_BEARER, token.TokenType)
+		assert.Equal(t, int64(900), token.ExpiresIn)
+		assert.Equal(t, rbac.PERMISSION_USERS_READ+" "+rbac.PERMISSION_USERS_WRITE, token.Scope)
+	})
+
+	t.Run("narrows the token to the requested scope", func(t *testing.T) {
+		mocks, service := setupServiceAccountServiceTest(t)
+		mocks.queries.On("GetServiceAccount", ctx, pgID).
+			Return(newAccount(id, rbac.PERMISSION_USERS_READ, rbac.PERMISSION_USERS_WRITE), nil)
+		expectSecret(mocks)
+		mocks.userService.On("GetUserById", ctx, id).Return(serviceUser, nil)
+		mocks.queries.On("TouchServiceAccountCredential", ctx, credentialID).Return(nil)
+		mocks.jwtService.On("GenerateToken", mock.MatchedBy(func(u *user.UserDto) bool {
+			return len(u.Permissions) == 1 && u.Permissions[0] == rbac.PERMISSION_USERS_READ
+		})).Return("access-token", nil)
+		mocks.jwtService.On("AccessTokenExpiration").Return(15 * time.Minute)
+
+		req := request()
+		req.Scope = rbac.PERMISSION_USERS_READ
+		token, err := service.IssueToken(ctx, req)
+
+		require.NoError(t, err)
+		assert.Equal(t, rbac.PERMISSION_USERS_READ, token.Scope)
+	})
+
+	t.Run("rejects a scope that was not granted", func(t *testing.T) {
+		mocks, service := setupServiceAccountServiceTest(t)
+		mocks.queries.On("GetServiceAccount", ctx, pgID).Return(newAccount(id, rbac.PERMISSION_USERS_READ), nil)
+		expectSecret(mocks)
+
+		req := request()
+		req.Scope = rbac.PERMISSION_USERS_WRITE
+		_, err := service.IssueToken(ctx, req)
+
+		require.ErrorIs(t, err, serviceAccount.ErrInvalidScope)
+		mocks.jwtService.AssertNotCalled(t, "GenerateToken", mock.Anything)
+	})
+
+	t.Run("rejects a wrong secret", func(t *testing.T) {
+		mocks, service := setupServiceAccountServiceTest(t)
+		mocks.queries.On("GetServiceAccount", ctx, pgID).Return(newAccount(id), nil)
+		mocks.queries.On("GetServiceAccountSecret", ctx, mock.Anything).Return(repository.ServiceAccountCredential{}, sql.ErrNoRows)
+
+		_, err := service.IssueToken(ctx, request())
+
+		require.ErrorIs(t, err, serviceAccount.ErrInvalidClient)
+	})
+
+	t.Run("rejects an unknown client", func(t *testing.T) {
+		mocks, service := setupServiceAccountServiceTest(t)
+		mocks.queries.On("GetServiceAccount", ctx, pgID).Return(repository.GetServiceAccountRow{}, sql.ErrNoRows)
+
+		_, err := service.IssueToken(ctx, request())
+
+		require.ErrorIs(t, err, serviceAccount.ErrInvalidClient)
+	})
+
+	t.Run("rejects a disabled account", func(t *testing.T) {
+		mocks, service := setupServiceAccountServiceTest(t)
+		account := newAccount(id)
+		account.DisabledAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+		mocks.queries.On("GetServiceAccount", ctx, pgID).Return(account, nil)
+		expectSecret(mocks)
+
+		_, err := service.IssueToken(ctx, request())
+
+		require.ErrorIs(t, err, serviceAccount.ErrInvalidClient)
+	})
+
+	t.Run("rejects other grant types", func(t *testing.T) {
+		_, service := setupServiceAccountServiceTest(t)
+
+		req := request()
+		req.GrantType = "password"
+		_, err := service.IssueToken(ctx, req)
+
+		require.ErrorIs(t, err, serviceAccount.ErrUnsupportedGrantType)
+	})
+
+	assertion := &jwt.ClientAssertion{
+		Key:       &jwt.SigningKey{Kid: uuid.UUID(credentialID.Bytes).String()},
+		ID:        "assertion-id",
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+	useAssertionParams := repository.UseClientAssertionParams{
+		UserID:    pgID,
+		Jti:       assertion.ID,
+		ExpiresAt: pgtype.Timestamptz{Time: assertion.ExpiresAt, Valid: true},
+	}
+	expectAssertion := func(t *testing.T, mocks *serviceAccountServiceMocks) {
+		mocks.queries.On("ListServiceAccountCredentials", ctx, pgID).Return([]repository.ServiceAccountCredential{
+			{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, CredentialType: serviceAccount.CREDENTIAL_TYPE_SECRET},
+			{
+				ID:             credentialID,
+				CredentialType: serviceAccount.CREDENTIAL_TYPE_KEY,
+				PublicKey:      pgtype.Text{String: generatePublicKeyPem(t), Valid: true},
+			},
+		}, nil)
+		mocks.jwtService.On(
+			"ValidateClientAssertion",
+			"assertion",
+			id.String(),
+			PUBLIC_URL+serviceAccount.TOKEN_PATH,
+			mock.MatchedBy(func(keys []*jwt.SigningKey) bool { return len(keys) == 1 }),
+		).Return(assertion, nil)
+		mocks.queries.On("DeleteExpiredClientAssertions", ctx).Return(nil)
+	}
+	assertionRequest := serviceAccount.ClientCredentialsRequest{
+		GrantType:           serviceAccount.GRANT_TYPE_CLIENT_CREDENTIALS,
+		ClientID:            id.String(),
+		ClientAssertionType: serviceAccount.CLIENT_ASSERTION_TYPE_JWT_BEARER,
+		ClientAssertion:     "assertion",
+	}
+
+	t.Run("authenticates with a client assertion", func(t *testing.T) {
+		mocks, service := setupServiceAccountServiceTest(t)
+		mocks.queries.On("GetServiceAccount", ctx, pgID).Return(newAccount(id, rbac.PERMISSION_USERS_READ), nil)
+		expectAssertion(t, mocks)
+		mocks.queries.On("UseClientAssertion", ctx, useAssertionParams).Return(int64(1), nil)
+		mocks.userService.On("GetUserById", ctx, id).Return(serviceUser, nil)
+		mocks.queries.On("TouchServiceAccountCredential", ctx, credentialID).Return(nil)
+		mocks.jwtService.On("GenerateToken", mock.Anything).Return("access-token", nil)
+		mocks.jwtService.On("AccessTokenExpiration").Return(15 * time.Minute)
+
+		token, err := service.IssueToken(ctx, assertionRequest)
+
+		require.NoError(t, err)
+		assert.Equal(t, "access-token", token.AccessToken)
+	})
+
+	t.Run("rejects a replayed client assertion", func(t *testing.T) {
+		mocks, service := setupServiceAccountServiceTest(t)
+		mocks.queries.On("GetServiceAccount", ctx, pgID).Return(newAccount(id, rbac.PERMISSION_USERS_READ), nil)
+		expectAssertion(t, mocks)
+		mocks.queries.On("UseClientAssertion", ctx, useAssertionParams).Return(int64(0), nil)
+
+		_, err := service.IssueToken(ctx, assertionRequest)
+
+		require.ErrorIs(t, err, serviceAccount.ErrInvalidClient)
+		mocks.jwtService.AssertNotCalled(t, "GenerateToken", mock.Anything)
+	})
+
+	t.Run("rejects an assertion of an unknown type", func(t *testing.T) {
+		mocks, service := setupServiceAccountServiceTest(t)
+		mocks.queries.On("GetServiceAccount", ctx, pgID).Return(newAccount(id), nil)
+
+		_, err := service.IssueToken(ctx, serviceAccount.ClientCredentialsRequest{
+			GrantType:       serviceAccount.GRANT_TYPE_CLIENT_CREDENTIALS,
+			ClientID:        id.String(),
+			ClientAssertion: "assertion",
+		})
+
+		require.ErrorIs(t, err, serviceAccount.ErrInvalidRequest)
+	})
+}
+
+func generatePublicKeyPem(t *testing.T) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
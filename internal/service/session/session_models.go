@@ -0,0 +1,25 @@
+package session
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SessionTokenDto carries the organization the session acts in, uuid.Nil
+// when it acts outside of any.
+type SessionTokenDto struct {
+	UserID         uuid.UUID `json:"userId"`
+	OrganizationID uuid.UUID `json:"organizationId"`
+	RefreshToken   string    `json:"refreshToken"`
+	ExpiresAt      time.Time `json:"expiresAt"`
+}
+
+func NewSessionTokenDto(userID, organizationID uuid.UUID, refreshToken string, expiresAt time.Time) *SessionTokenDto {
+	return &SessionTokenDto{
+		UserID:         userID,
+		OrganizationID: organizationID,
+		RefreshToken:   refreshToken,
+		ExpiresAt:      expiresAt,
+	}
+}
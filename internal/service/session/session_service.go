@@ -0,0 +1,257 @@
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	REFRESH_TOKEN_BYTES = 32
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenExpired = errors.New("refresh token expired")
+	ErrRefreshTokenReused  = errors.New("refresh token reused")
+	ErrMissingTokenId      = errors.New("token has no jti claim")
+)
+
+type SessionServiceInterface interface {
+	CreateSession(ctx context.Context, userID uuid.UUID) (*SessionTokenDto, error)
+	RotateSession(ctx context.Context, refreshToken string) (*SessionTokenDto, error)
+	SwitchOrganization(ctx context.Context, refreshToken string, organizationID uuid.UUID) (*SessionTokenDto, error)
+	RevokeSession(ctx context.Context, refreshToken string) error
+	RevokeAllUserSessions(ctx context.Context, userID uuid.UUID) error
+	RevokeOtherUserSessions(ctx context.Context, userID uuid.UUID, refreshToken string) error
+	RevokeAccessToken(ctx context.Context, jti string, userID uuid.UUID, expiresAt time.Time) error
+	IsAccessTokenRevoked(ctx context.Context, jti string, userID uuid.UUID, issuedAt time.Time) (bool, error)
+}
+
+type SessionService struct {
+	queries         repository.Querier
+	refreshTokenTtl time.Duration
+}
+
+func NewSessionService(queries repository.Querier, refreshTokenTtl time.Duration) *SessionService {
+	return &SessionService{
+		queries:         queries,
+		refreshTokenTtl: refreshTokenTtl,
+	}
+}
+
+// CreateSession starts a new token family for the user and returns its first refresh token.
+func (s *SessionService) CreateSession(ctx context.Context, userID uuid.UUID) (*SessionTokenDto, error) {
+	return s.issueRefreshToken(ctx, userID, uuid.New(), pgtype.UUID{})
+}
+
+// RotateSession exchanges a refresh token for a new one of the same family.
+// Presenting a token that has already been rotated revokes the whole family,
+// since either the legitimate client or an attacker holds a stolen copy.
+func (s *SessionService) RotateSession(ctx context.Context, refreshToken string) (*SessionTokenDto, error) {
+	return s.rotate(ctx, refreshToken, nil)
+}
+
+// SwitchOrganization rotates the refresh token like RotateSession and lets
+// the session act in the given organization from then on, or outside of any
+// for uuid.Nil. Callers check the membership.
+func (s *SessionService) SwitchOrganization(ctx context.Context, refreshToken string, organizationID uuid.UUID) (*SessionTokenDto, error) {
+	return s.rotate(ctx, refreshToken, &organizationID)
+}
+
+func (s *SessionService) rotate(ctx context.Context, refreshToken string, organizationID *uuid.UUID) (*SessionTokenDto, error) {
+	session, err := s.queries.GetSessionByRefreshTokenHash(ctx, hashRefreshToken(refreshToken))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if session.RevokedAt.Valid {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if session.RotatedAt.Valid {
+		return nil, s.revokeReusedFamily(ctx, session.FamilyID)
+	}
+
+	if !session.ExpiresAt.Time.After(time.Now()) {
+		return nil, ErrRefreshTokenExpired
+	}
+
+	rotated, err := s.queries.MarkSessionRotated(ctx, session.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate session: %w", err)
+	}
+
+	// Another request rotated the same token in the meantime.
+	if rotated == 0 {
+		return nil, s.revokeReusedFamily(ctx, session.FamilyID)
+	}
+
+	organization := session.OrganizationID
+	if organizationID != nil {
+		organization = pgtype.UUID{Bytes: *organizationID, Valid: *organizationID != uuid.Nil}
+	}
+
+	return s.issueRefreshToken(ctx, uuid.UUID(session.UserID.Bytes), uuid.UUID(session.FamilyID.Bytes), organization)
+}
+
+// RevokeSession ends the token family the refresh token belongs to. Unknown
+// tokens are ignored so that logging out stays idempotent.
+func (s *SessionService) RevokeSession(ctx context.Context, refreshToken string) error {
+	if err := s.queries.RevokeSessionByRefreshTokenHash(ctx, hashRefreshToken(refreshToken)); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeAllUserSessions revokes every refresh token of the user and rejects
+// all access tokens issued before now, see revokeAccessTokens.
+func (s *SessionService) RevokeAllUserSessions(ctx context.Context, userID uuid.UUID) error {
+	pgUserID := pgtype.UUID{Bytes: userID, Valid: true}
+	if err := s.queries.RevokeUserSessions(ctx, pgUserID); err != nil {
+		return fmt.Errorf("failed to revoke user sessions: %w", err)
+	}
+
+	return s.revokeAccessTokens(ctx, pgUserID)
+}
+
+// RevokeOtherUserSessions logs the user out everywhere but on the calling
+// device. Only the session of refreshToken is kept, the access tokens of all
+// sessions are rejected, so the caller has to refresh its own right after.
+func (s *SessionService) RevokeOtherUserSessions(ctx context.Context, userID uuid.UUID, refreshToken string) error {
+	pgUserID := pgtype.UUID{Bytes: userID, Valid: true}
+	err := s.queries.RevokeOtherUserSessions(
+		ctx,
+		repository.RevokeOtherUserSessionsParams{
+			UserID:           pgUserID,
+			RefreshTokenHash: hashRefreshToken(refreshToken),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to revoke other user sessions: %w", err)
+	}
+
+	return s.revokeAccessTokens(ctx, pgUserID)
+}
+
+// revokeAccessTokens rejects all access tokens of the user that were issued
+// before the current second. Token issue times only have second precision, so
+// tokens of the current second are kept to not reject the token of a login or
+// refresh that directly follows the revocation.
+func (s *SessionService) revokeAccessTokens(ctx context.Context, pgUserID pgtype.UUID) error {
+	err := s.queries.RevokeUserTokensIssuedBefore(
+		ctx,
+		repository.RevokeUserTokensIssuedBeforeParams{
+			UserID:        pgUserID,
+			RevokedBefore: pgtype.Timestamptz{Time: time.Now().Truncate(time.Second), Valid: true},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to revoke user access tokens: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SessionService) RevokeAccessToken(ctx context.Context, jti string, userID uuid.UUID, expiresAt time.Time) error {
+	if jti == "" {
+		return ErrMissingTokenId
+	}
+
+	err := s.queries.RevokeToken(
+		ctx,
+		repository.RevokeTokenParams{
+			Jti:       jti,
+			UserID:    pgtype.UUID{Bytes: userID, Valid: true},
+			ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to revoke access token: %w", err)
+	}
+
+	// Expired tokens are rejected anyway, so there is no need to keep them around.
+	if err := s.queries.DeleteExpiredRevokedTokens(ctx); err != nil {
+		return fmt.Errorf("failed to delete expired revoked tokens: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SessionService) IsAccessTokenRevoked(ctx context.Context, jti string, userID uuid.UUID, issuedAt time.Time) (bool, error) {
+	return s.queries.IsTokenRevoked(
+		ctx,
+		repository.IsTokenRevokedParams{
+			Jti:      jti,
+			UserID:   pgtype.UUID{Bytes: userID, Valid: true},
+			IssuedAt: pgtype.Timestamptz{Time: issuedAt, Valid: true},
+		},
+	)
+}
+
+func (s *SessionService) issueRefreshToken(
+	ctx context.Context,
+	userID, familyID uuid.UUID,
+	organizationID pgtype.UUID,
+) (*SessionTokenDto, error) {
+	refreshToken, err := generateRefreshToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	expiresAt := time.Now().Add(s.refreshTokenTtl)
+	_, err = s.queries.CreateSession(
+		ctx,
+		repository.CreateSessionParams{
+			UserID:           pgtype.UUID{Bytes: userID, Valid: true},
+			FamilyID:         pgtype.UUID{Bytes: familyID, Valid: true},
+			RefreshTokenHash: hashRefreshToken(refreshToken),
+			ExpiresAt:        pgtype.Timestamptz{Time: expiresAt, Valid: true},
+			OrganizationID:   organizationID,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return NewSessionTokenDto(userID, uuid.UUID(organizationID.Bytes), refreshToken, expiresAt), nil
+}
+
+func (s *SessionService) revokeReusedFamily(ctx context.Context, familyID pgtype.UUID) error {
+	if err := s.queries.RevokeSessionFamily(ctx, familyID); err != nil {
+		return fmt.Errorf("failed to revoke session family: %w", err)
+	}
+
+	return ErrRefreshTokenReused
+}
+
+func generateRefreshToken() (string, error) {
+	buf := make([]byte, REFRESH_TOKEN_BYTES)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// Refresh tokens carry 256 bits of entropy, so a fast hash is sufficient to
+// keep them useless when the sessions table leaks.
+func hashRefreshToken(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+
+	return hex.EncodeToString(sum[:])
+}
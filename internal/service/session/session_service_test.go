@@ -0,0 +1,386 @@
+//go:build unittest
+
+package session_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	repositoryMocks "github.com/fgeck/gotth-postgres/internal/repository/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/session"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	REFRESH_TOKEN_TTL = time.Hour
+)
+
+func setupSessionServiceTest(t *testing.T) (*repositoryMocks.MockQuerier, *session.SessionService) {
+	mockQueries := repositoryMocks.NewMockQuerier(t)
+	sessionService := session.NewSessionService(mockQueries, REFRESH_TOKEN_TTL)
+	return mockQueries, sessionService
+}
+
+func TestCreateSession(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("successfully creates a session", func(t *testing.T) {
+		mockQueries, sessionService := setupSessionServiceTest(t)
+
+		var params repository.CreateSessionParams
+		mockQueries.On("CreateSession", ctx, mock.Anything).
+			Run(func(args mock.Arguments) {
+				params = args.Get(1).(repository.CreateSessionParams)
+			}).
+			Return(repository.Session{}, nil)
+
+		sessionToken, err := sessionService.CreateSession(ctx, userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, userID, sessionToken.UserID)
+		assert.NotEmpty(t, sessionToken.RefreshToken)
+		assert.WithinDuration(t, time.Now().Add(REFRESH_TOKEN_TTL), sessionToken.ExpiresAt, time.Minute)
+		assert.Equal(t, pgtype.UUID{Bytes: userID, Valid: true}, params.UserID)
+		assert.True(t, params.FamilyID.Valid)
+		assert.NotEqual(t, sessionToken.RefreshToken, params.RefreshTokenHash, "refresh token must be stored hashed")
+	})
+
+	t.Run("fails when database error occurs", func(t *testing.T) {
+		mockQueries, sessionService := setupSessionServiceTest(t)
+		mockQueries.On("CreateSession", ctx, mock.Anything).Return(repository.Session{}, errors.New("database error"))
+
+		sessionToken, err := sessionService.CreateSession(ctx, userID)
+
+		require.Error(t, err)
+		assert.Nil(t, sessionToken)
+		assert.Equal(t, "failed to create session: database error", err.Error())
+	})
+}
+
+func TestRotateSession(t *testing.T) {
+	ctx := context.Background()
+	userID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	familyID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	sessionID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	refreshToken := "some-refresh-token"
+
+	activeSession := func() repository.Session {
+		return repository.Session{
+			ID:        sessionID,
+			UserID:    userID,
+			FamilyID:  familyID,
+			ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+		}
+	}
+
+	t.Run("successfully rotates a session within the same family", func(t *testing.T) {
+		mockQueries, sessionService := setupSessionServiceTest(t)
+
+		var params repository.CreateSessionParams
+		mockQueries.On("GetSessionByRefreshTokenHash", ctx, mock.Anything).Return(activeSession(), nil)
+		mockQueries.On("MarkSessionRotated", ctx, sessionID).Return(int64(1), nil)
+		mockQueries.On("CreateSession", ctx, mock.Anything).
+			Run(func(args mock.Arguments) {
+				params = args.Get(1).(repository.CreateSessionParams)
+			}).
+			Return(repository.Session{}, nil)
+
+		sessionToken, err := sessionService.RotateSession(ctx, refreshToken)
+
+		require.NoError(t, err)
+		assert.Equal(t, uuid.UUID(userID.Bytes), sessionToken.UserID)
+		assert.NotEqual(t, refreshToken, sessionToken.RefreshToken)
+		assert.Equal(t, familyID, params.FamilyID)
+	})
+
+	t.Run("keeps the organization of the session", func(t *testing.T) {
+		mockQueries, sessionService := setupSessionServiceTest(t)
+		organizationID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+		current := activeSession()
+		current.OrganizationID = organizationID
+
+		var params repository.CreateSessionParams
+		mockQueries.On("GetSessionByRefreshTokenHash", ctx, mock.Anything).Return(current, nil)
+		mockQueries.On("MarkSessionRotated", ctx, sessionID).Return(int64(1), nil)
+		mockQueries.On("CreateSession", ctx, mock.Anything).
+			Run(func(args mock.Arguments) {
+				params = args.Get(1).(repository.CreateSessionParams)
+			}).
+			Return(repository.Session{}, nil)
+
+		sessionToken, err := sessionService.RotateSession(ctx, refreshToken)
+
+		require.NoError(t, err)
+		assert.Equal(t, organizationID, params.OrganizationID)
+		assert.Equal(t, uuid.UUID(organizationID.Bytes), sessionToken.OrganizationID)
+	})
+
+	t.Run("fails when refresh token is unknown", func(t *testing.T) {
+		mockQueries, sessionService := setupSessionServiceTest(t)
+		mockQueries.On("GetSessionByRefreshTokenHash", ctx, mock.Anything).Return(repository.Session{}, sql.ErrNoRows)
+
+		sessionToken, err := sessionService.RotateSession(ctx, refreshToken)
+
+		require.ErrorIs(t, err, session.ErrInvalidRefreshToken)
+		assert.Nil(t, sessionToken)
+	})
+
+	t.Run("fails when session is revoked", func(t *testing.T) {
+		mockQueries, sessionService := setupSessionServiceTest(t)
+		revoked := activeSession()
+		revoked.RevokedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+		mockQueries.On("GetSessionByRefreshTokenHash", ctx, mock.Anything).Return(revoked, nil)
+
+		sessionToken, err := sessionService.RotateSession(ctx, refreshToken)
+
+		require.ErrorIs(t, err, session.ErrInvalidRefreshToken)
+		assert.Nil(t, sessionToken)
+	})
+
+	t.Run("fails when session is expired", func(t *testing.T) {
+		mockQueries, sessionService := setupSessionServiceTest(t)
+		expired := activeSession()
+		expired.ExpiresAt = pgtype.Timestamptz{Time: time.Now().Add(-time.Minute), Valid: true}
+		mockQueries.On("GetSessionByRefreshTokenHash", ctx, mock.Anything).Return(expired, nil)
+
+		sessionToken, err := sessionService.RotateSession(ctx, refreshToken)
+
+		require.ErrorIs(t, err, session.ErrRefreshTokenExpired)
+		assert.Nil(t, sessionToken)
+	})
+
+	t.Run("revokes the family when a rotated token is reused", func(t *testing.T) {
+		mockQueries, sessionService := setupSessionServiceTest(t)
+		rotated := activeSession()
+		rotated.RotatedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+		mockQueries.On("GetSessionByRefreshTokenHash", ctx, mock.Anything).Return(rotated, nil)
+		mockQueries.On("RevokeSessionFamily", ctx, familyID).Return(nil)
+
+		sessionToken, err := sessionService.RotateSession(ctx, refreshToken)
+
+		require.ErrorIs(t, err, session.ErrRefreshTokenReused)
+		assert.Nil(t, sessionToken)
+	})
+
+	t.Run("revokes the family when the token was rotated concurrently", func(t *testing.T) {
+		mockQueries, sessionService := setupSessionServiceTest(t)
+		mockQueries.On("GetSessionByRefreshTokenHash", ctx, mock.Anything).Return(activeSession(), nil)
+		mockQueries.On("MarkSessionRotated", ctx, sessionID).Return(int64(0), nil)
+		mockQueries.On("RevokeSessionFamily", ctx, familyID).Return(nil)
+
+		sessionToken, err := sessionService.RotateSession(ctx, refreshToken)
+
+		require.ErrorIs(t, err, session.ErrRefreshTokenReused)
+		assert.Nil(t, sessionToken)
+	})
+}
+
+func TestRevokeSession(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("revokes the family of the refresh token", func(t *testing.T) {
+		mockQueries, sessionService := setupSessionServiceTest(t)
+		mockQueries.On("RevokeSessionByRefreshTokenHash", ctx, mock.Anything).Return(nil)
+
+		err := sessionService.RevokeSession(ctx, "some-refresh-token")
+
+		require.NoError(t, err)
+	})
+
+	t.Run("fails when database error occurs", func(t *testing.T) {
+		mockQueries, sessionService := setupSessionServiceTest(t)
+		mockQueries.On("RevokeSessionByRefreshTokenHash", ctx, mock.Anything).Return(errors.New("database error"))
+
+		err := sessionService.RevokeSession(ctx, "some-refresh-token")
+
+		require.Error(t, err)
+		assert.Equal(t, "failed to revoke session: database error", err.Error())
+	})
+}
+
+func TestRevokeAllUserSessions(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	pgUserID := pgtype.UUID{Bytes: userID, Valid: true}
+
+	t.Run("revokes sessions and access tokens of the user", func(t *testing.T) {
+		mockQueries, sessionService := setupSessionServiceTest(t)
+		mockQueries.On("RevokeUserSessions", ctx, pgUserID).Return(nil)
+		mockQueries.On("RevokeUserTokensIssuedBefore", ctx, mock.MatchedBy(func(p repository.RevokeUserTokensIssuedBeforeParams) bool {
+			return p.UserID == pgUserID && p.RevokedBefore.Valid
+		})).Return(nil)
+
+		err := sessionService.RevokeAllUserSessions(ctx, userID)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("keeps access tokens issued in the same second", func(t *testing.T) {
+		mockQueries, sessionService := setupSessionServiceTest(t)
+		var revokedBefore time.Time
+		mockQueries.On("RevokeUserSessions", ctx, pgUserID).Return(nil)
+		mockQueries.On("RevokeUserTokensIssuedBefore", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			revokedBefore = args.Get(1).(repository.RevokeUserTokensIssuedBeforeParams).RevokedBefore.Time
+		}).Return(nil)
+
+		err := sessionService.RevokeAllUserSessions(ctx, userID)
+		// A login right after the revocation gets a token with the issue time
+		// of the current second, which must not be older than revokedBefore.
+		issuedAt := time.Now().Truncate(time.Second)
+
+		require.NoError(t, err)
+		assert.Zero(t, revokedBefore.Nanosecond())
+		assert.False(t, revokedBefore.After(issuedAt))
+	})
+
+	t.Run("fails when sessions cannot be revoked", func(t *testing.T) {
+		mockQueries, sessionService := setupSessionServiceTest(t)
+		mockQueries.On("RevokeUserSessions", ctx, pgUserID).Return(errors.New("database error"))
+
+		err := sessionService.RevokeAllUserSessions(ctx, userID)
+
+		require.Error(t, err)
+		assert.Equal(t, "failed to revoke user sessions: database error", err.Error())
+	})
+}
+
+func TestRevokeOtherUserSessions(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	pgUserID := pgtype.UUID{Bytes: userID, Valid: true}
+
+	t.Run("keeps the calling session and revokes all access tokens", func(t *testing.T) {
+		mockQueries, sessionService := setupSessionServiceTest(t)
+		var revokedBefore time.Time
+		mockQueries.On("RevokeOtherUserSessions", ctx, mock.MatchedBy(func(p repository.RevokeOtherUserSessionsParams) bool {
+			return p.UserID == pgUserID && p.RefreshTokenHash != "" && p.RefreshTokenHash != "refresh-token"
+		})).Return(nil)
+		mockQueries.On("RevokeUserTokensIssuedBefore", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			revokedBefore = args.Get(1).(repository.RevokeUserTokensIssuedBeforeParams).RevokedBefore.Time
+		}).Return(nil)
+		// An access token refreshed by another device before the change.
+		refreshedAt := time.Now().Truncate(time.Second).Add(-time.Second)
+
+		err := sessionService.RevokeOtherUserSessions(ctx, userID, "refresh-token")
+
+		require.NoError(t, err)
+		assert.True(t, revokedBefore.After(refreshedAt))
+	})
+
+	t.Run("fails when sessions cannot be revoked", func(t *testing.T) {
+		mockQueries, sessionService := setupSessionServiceTest(t)
+		mockQueries.On("RevokeOtherUserSessions", ctx, mock.Anything).Return(errors.New("database error"))
+
+		err := sessionService.RevokeOtherUserSessions(ctx, userID, "refresh-token")
+
+		require.Error(t, err)
+		assert.Equal(t, "failed to revoke other user sessions: database error", err.Error())
+	})
+}
+
+func TestRevokeAccessToken(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	expiresAt := time.Now().Add(time.Minute)
+
+	t.Run("stores the jti until the token expires", func(t *testing.T) {
+		mockQueries, sessionService := setupSessionServiceTest(t)
+		mockQueries.On("RevokeToken", ctx, repository.RevokeTokenParams{
+			Jti:       "some-jti",
+			UserID:    pgtype.UUID{Bytes: userID, Valid: true},
+			ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+		}).Return(nil)
+		mockQueries.On("DeleteExpiredRevokedTokens", ctx).Return(nil)
+
+		err := sessionService.RevokeAccessToken(ctx, "some-jti", userID, expiresAt)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("fails without jti", func(t *testing.T) {
+		_, sessionService := setupSessionServiceTest(t)
+
+		err := sessionService.RevokeAccessToken(ctx, "", userID, expiresAt)
+
+		require.ErrorIs(t, err, session.ErrMissingTokenId)
+	})
+}
+
+func TestIsAccessTokenRevoked(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	issuedAt := time.Now()
+
+	mockQueries, sessionService := setupSessionServiceTest(t)
+	mockQueries.On("IsTokenRevoked", ctx, repository.IsTokenRevokedParams{
+		Jti:      "some-jti",
+		UserID:   pgtype.UUID{Bytes: userID, Valid: true},
+		IssuedAt: pgtype.Timestamptz{Time: issuedAt, Valid: true},
+	}).Return(true, nil)
+
+	revoked, err := sessionService.IsAccessTokenRevoked(ctx, "some-jti", userID, issuedAt)
+
+	require.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func TestSwitchOrganization(t *testing.T) {
+	ctx := context.Background()
+	sessionID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	current := repository.Session{
+		ID:             sessionID,
+		UserID:         pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		FamilyID:       pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		OrganizationID: pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		ExpiresAt:      pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+	}
+
+	t.Run("switches to another organization", func(t *testing.T) {
+		mockQueries, sessionService := setupSessionServiceTest(t)
+		organizationID := uuid.New()
+
+		var params repository.CreateSessionParams
+		mockQueries.On("GetSessionByRefreshTokenHash", ctx, mock.Anything).Return(current, nil)
+		mockQueries.On("MarkSessionRotated", ctx, sessionID).Return(int64(1), nil)
+		mockQueries.On("CreateSession", ctx, mock.Anything).
+			Run(func(args mock.Arguments) {
+				params = args.Get(1).(repository.CreateSessionParams)
+			}).
+			Return(repository.Session{}, nil)
+
+		sessionToken, err := sessionService.SwitchOrganization(ctx, "some-refresh-token", organizationID)
+
+		require.NoError(t, err)
+		assert.Equal(t, pgtype.UUID{Bytes: organizationID, Valid: true}, params.OrganizationID)
+		assert.Equal(t, organizationID, sessionToken.OrganizationID)
+		assert.Equal(t, current.FamilyID, params.FamilyID)
+	})
+
+	t.Run("leaves the organization for uuid.Nil", func(t *testing.T) {
+		mockQueries, sessionService := setupSessionServiceTest(t)
+
+		var params repository.CreateSessionParams
+		mockQueries.On("GetSessionByRefreshTokenHash", ctx, mock.Anything).Return(current, nil)
+		mockQueries.On("MarkSessionRotated", ctx, sessionID).Return(int64(1), nil)
+		mockQueries.On("CreateSession", ctx, mock.Anything).
+			Run(func(args mock.Arguments) {
+				params = args.Get(1).(repository.CreateSessionParams)
+			}).
+			Return(repository.Session{}, nil)
+
+		sessionToken, err := sessionService.SwitchOrganization(ctx, "some-refresh-token", uuid.Nil)
+
+		require.NoError(t, err)
+		assert.False(t, params.OrganizationID.Valid)
+		assert.Equal(t, uuid.Nil, sessionToken.OrganizationID)
+	})
+}
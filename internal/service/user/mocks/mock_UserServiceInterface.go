@@ -8,6 +8,7 @@ import (
 	"context"
 
 	"github.com/fgeck/gotth-postgres/internal/service/user"
+	"github.com/google/uuid"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -39,8 +40,8 @@ func (_m *MockUserServiceInterface) EXPECT() *MockUserServiceInterface_Expecter
 }
 
 // CreateUser provides a mock function for the type MockUserServiceInterface
-func (_mock *MockUserServiceInterface) CreateUser(ctx context.Context, username string, email string, passwordHash string) (*user.UserCreatedDto, error) {
-	ret := _mock.Called(ctx, username, email, passwordHash)
+func (_mock *MockUserServiceInterface) CreateUser(ctx context.Context, username string, email string, passwordHash string, pendingApproval bool) (*user.UserCreatedDto, error) {
+	ret := _mock.Called(ctx, username, email, passwordHash, pendingApproval)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateUser")
@@ -48,18 +49,18 @@ func (_mock *MockUserServiceInterface) CreateUser(ctx context.Context, username
 
 	var r0 *user.UserCreatedDto
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (*user.UserCreatedDto, error)); ok {
-		return returnFunc(ctx, username, email, passwordHash)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, bool) (*user.UserCreatedDto, error)); ok {
+		return returnFunc(ctx, username, email, passwordHash, pendingApproval)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) *user.UserCreatedDto); ok {
-		r0 = returnFunc(ctx, username, email, passwordHash)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, bool) *user.UserCreatedDto); ok {
+		r0 = returnFunc(ctx, username, email, passwordHash, pendingApproval)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*user.UserCreatedDto)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
-		r1 = returnFunc(ctx, username, email, passwordHash)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, bool) error); ok {
+		r1 = returnFunc(ctx, username, email, passwordHash, pendingApproval)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -76,13 +77,14 @@ type MockUserServiceInterface_CreateUser_Call struct {
 //   - username
 //   - email
 //   - passwordHash
-func (_e *MockUserServiceInterface_Expecter) CreateUser(ctx interface{}, username interface{}, email interface{}, passwordHash interface{}) *MockUserServiceInterface_CreateUser_Call {
-	return &MockUserServiceInterface_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, username, email, passwordHash)}
+//   - pendingApproval
+func (_e *MockUserServiceInterface_Expecter) CreateUser(ctx interface{}, username interface{}, email interface{}, passwordHash interface{}, pendingApproval interface{}) *MockUserServiceInterface_CreateUser_Call {
+	return &MockUserServiceInterface_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, username, email, passwordHash, pendingApproval)}
 }
 
-func (_c *MockUserServiceInterface_CreateUser_Call) Run(run func(ctx context.Context, username string, email string, passwordHash string)) *MockUserServiceInterface_CreateUser_Call {
+func (_c *MockUserServiceInterface_CreateUser_Call) Run(run func(ctx context.Context, username string, email string, passwordHash string, pendingApproval bool)) *MockUserServiceInterface_CreateUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(bool))
 	})
 	return _c
 }
@@ -92,7 +94,7 @@ func (_c *MockUserServiceInterface_CreateUser_Call) Return(userCreatedDto *user.
 	return _c
 }
 
-func (_c *MockUserServiceInterface_CreateUser_Call) RunAndReturn(run func(ctx context.Context, username string, email string, passwordHash string) (*user.UserCreatedDto, error)) *MockUserServiceInterface_CreateUser_Call {
+func (_c *MockUserServiceInterface_CreateUser_Call) RunAndReturn(run func(ctx context.Context, username string, email string, passwordHash string, pendingApproval bool) (*user.UserCreatedDto, error)) *MockUserServiceInterface_CreateUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -154,6 +156,170 @@ func (_c *MockUserServiceInterface_GetUserByEmail_Call) RunAndReturn(run func(ct
 	return _c
 }
 
+// GetUserById provides a mock function for the type MockUserServiceInterface
+func (_mock *MockUserServiceInterface) GetUserById(ctx context.Context, id uuid.UUID) (*user.UserDto, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserById")
+	}
+
+	var r0 *user.UserDto
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*user.UserDto, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *user.UserDto); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.UserDto)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserServiceInterface_GetUserById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserById'
+type MockUserServiceInterface_GetUserById_Call struct {
+	*mock.Call
+}
+
+// GetUserById is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockUserServiceInterface_Expecter) GetUserById(ctx interface{}, id interface{}) *MockUserServiceInterface_GetUserById_Call {
+	return &MockUserServiceInterface_GetUserById_Call{Call: _e.mock.On("GetUserById", ctx, id)}
+}
+
+func (_c *MockUserServiceInterface_GetUserById_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockUserServiceInterface_GetUserById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockUserServiceInterface_GetUserById_Call) Return(userDto *user.UserDto, err error) *MockUserServiceInterface_GetUserById_Call {
+	_c.Call.Return(userDto, err)
+	return _c
+}
+
+func (_c *MockUserServiceInterface_GetUserById_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*user.UserDto, error)) *MockUserServiceInterface_GetUserById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePassword provides a mock function for the type MockUserServiceInterface
+func (_mock *MockUserServiceInterface) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	ret := _mock.Called(ctx, id, passwordHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePassword")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = returnFunc(ctx, id, passwordHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserServiceInterface_UpdatePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePassword'
+type MockUserServiceInterface_UpdatePassword_Call struct {
+	*mock.Call
+}
+
+// UpdatePassword is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - passwordHash
+func (_e *MockUserServiceInterface_Expecter) UpdatePassword(ctx interface{}, id interface{}, passwordHash interface{}) *MockUserServiceInterface_UpdatePassword_Call {
+	return &MockUserServiceInterface_UpdatePassword_Call{Call: _e.mock.On("UpdatePassword", ctx, id, passwordHash)}
+}
+
+func (_c *MockUserServiceInterface_UpdatePassword_Call) Run(run func(ctx context.Context, id uuid.UUID, passwordHash string)) *MockUserServiceInterface_UpdatePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserServiceInterface_UpdatePassword_Call) Return(err error) *MockUserServiceInterface_UpdatePassword_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserServiceInterface_UpdatePassword_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, passwordHash string) error) *MockUserServiceInterface_UpdatePassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUser provides a mock function for the type MockUserServiceInterface
+func (_mock *MockUserServiceInterface) UpdateUser(ctx context.Context, id uuid.UUID, username string, email string, passwordHash string) (*user.UserDto, error) {
+	ret := _mock.Called(ctx, id, username, email, passwordHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUser")
+	}
+
+	var r0 *user.UserDto
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string, string) (*user.UserDto, error)); ok {
+		return returnFunc(ctx, id, username, email, passwordHash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string, string) *user.UserDto); ok {
+		r0 = returnFunc(ctx, id, username, email, passwordHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.UserDto)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, string, string) error); ok {
+		r1 = returnFunc(ctx, id, username, email, passwordHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserServiceInterface_UpdateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUser'
+type MockUserServiceInterface_UpdateUser_Call struct {
+	*mock.Call
+}
+
+// UpdateUser is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - username
+//   - email
+//   - passwordHash
+func (_e *MockUserServiceInterface_Expecter) UpdateUser(ctx interface{}, id interface{}, username interface{}, email interface{}, passwordHash interface{}) *MockUserServiceInterface_UpdateUser_Call {
+	return &MockUserServiceInterface_UpdateUser_Call{Call: _e.mock.On("UpdateUser", ctx, id, username, email, passwordHash)}
+}
+
+func (_c *MockUserServiceInterface_UpdateUser_Call) Run(run func(ctx context.Context, id uuid.UUID, username string, email string, passwordHash string)) *MockUserServiceInterface_UpdateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserServiceInterface_UpdateUser_Call) Return(userDto *user.UserDto, err error) *MockUserServiceInterface_UpdateUser_Call {
+	_c.Call.Return(userDto, err)
+	return _c
+}
+
+func (_c *MockUserServiceInterface_UpdateUser_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, username string, email string, passwordHash string) (*user.UserDto, error)) *MockUserServiceInterface_UpdateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UserExistsByEmail provides a mock function for the type MockUserServiceInterface
 func (_mock *MockUserServiceInterface) UserExistsByEmail(ctx context.Context, email string) (bool, error) {
 	ret := _mock.Called(ctx, email)
@@ -209,6 +375,61 @@ func (_c *MockUserServiceInterface_UserExistsByEmail_Call) RunAndReturn(run func
 	return _c
 }
 
+// UserExistsByUsername provides a mock function for the type MockUserServiceInterface
+func (_mock *MockUserServiceInterface) UserExistsByUsername(ctx context.Context, username string) (bool, error) {
+	ret := _mock.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserExistsByUsername")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return returnFunc(ctx, username)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(ctx, username)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserServiceInterface_UserExistsByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserExistsByUsername'
+type MockUserServiceInterface_UserExistsByUsername_Call struct {
+	*mock.Call
+}
+
+// UserExistsByUsername is a helper method to define mock.On call
+//   - ctx
+//   - username
+func (_e *MockUserServiceInterface_Expecter) UserExistsByUsername(ctx interface{}, username interface{}) *MockUserServiceInterface_UserExistsByUsername_Call {
+	return &MockUserServiceInterface_UserExistsByUsername_Call{Call: _e.mock.On("UserExistsByUsername", ctx, username)}
+}
+
+func (_c *MockUserServiceInterface_UserExistsByUsername_Call) Run(run func(ctx context.Context, username string)) *MockUserServiceInterface_UserExistsByUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserServiceInterface_UserExistsByUsername_Call) Return(b bool, err error) *MockUserServiceInterface_UserExistsByUsername_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockUserServiceInterface_UserExistsByUsername_Call) RunAndReturn(run func(ctx context.Context, username string) (bool, error)) *MockUserServiceInterface_UserExistsByUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ValidateCreateUserParams provides a mock function for the type MockUserServiceInterface
 func (_mock *MockUserServiceInterface) ValidateCreateUserParams(username string, email string, password string) error {
 	ret := _mock.Called(username, email, password)
@@ -1,33 +1,68 @@
 package user
 
 import (
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/fgeck/gotth-postgres/internal/repository"
 	"github.com/google/uuid"
 )
 
+const (
+	ACCOUNT_TYPE_HUMAN   = "human"
+	ACCOUNT_TYPE_SERVICE = "service"
+)
+
 type UserCreatedDto struct {
 	Username string `json:"username"`
 	Email    string `json:"email"`
 }
 
+// UserDto never serializes the password hash. Role is the base role of the
+// account, Roles and Permissions include further granted roles and are only
+// set once the rbac service loaded them. TenantID and TenantRole describe the
+// organization a session acts in, if any.
 type UserDto struct {
-	ID           uuid.UUID `json:"id"`
-	Username     string    `json:"username"`
-	Email        string    `json:"email"`
-	PasswordHash string    `json:"passwordHash"`
-	Role         UserRole  `json:"role"`
+	ID                    uuid.UUID  `json:"id"`
+	Username              string     `json:"username"`
+	Email                 string     `json:"email"`
+	PasswordHash          string     `json:"-"`
+	Role                  UserRole   `json:"role"`
+	Roles                 []string   `json:"roles,omitempty"`
+	Permissions           []string   `json:"permissions,omitempty"`
+	TenantID              uuid.UUID  `json:"-"`
+	TenantRole            string     `json:"-"`
+	EmailVerifiedAt       *time.Time `json:"emailVerifiedAt,omitempty"`
+	PasswordChangedAt     time.Time  `json:"passwordChangedAt"`
+	PasswordResetRequired bool       `json:"passwordResetRequired"`
+	DisabledAt            *time.Time `json:"disabledAt,omitempty"`
+	PendingApproval       bool       `json:"pendingApproval"`
+	AccountType           string     `json:"accountType"`
 }
 
 func NewUserDto(user repository.User) *UserDto {
-	return &UserDto{
-		ID:           uuid.UUID(user.ID.Bytes),
-		Username:     user.Username,
-		Email:        user.Email,
-		PasswordHash: user.PasswordHash,
-		Role:         UserRoleFromString(user.UserRole),
+	dto := &UserDto{
+		ID:                    uuid.UUID(user.ID.Bytes),
+		Username:              user.Username,
+		Email:                 user.Email,
+		PasswordHash:          user.PasswordHash,
+		Role:                  UserRoleFromString(user.UserRole),
+		PasswordChangedAt:     user.PasswordChangedAt.Time,
+		PasswordResetRequired: user.PasswordResetRequired,
+		PendingApproval:       user.PendingApproval,
+		AccountType:           user.AccountType,
+	}
+	if user.EmailVerifiedAt.Valid {
+		emailVerifiedAt := user.EmailVerifiedAt.Time
+		dto.EmailVerifiedAt = &emailVerifiedAt
+	}
+	if user.DisabledAt.Valid {
+		disabledAt := user.DisabledAt.Time
+		dto.DisabledAt = &disabledAt
 	}
+
+	return dto
 }
 
 var (
@@ -57,3 +92,21 @@ func (u *UserDto) IsAdmin() bool {
 func (u *UserDto) IsUser() bool {
 	return u.Role.Name == UserRoleUser.Name
 }
+
+func (u *UserDto) HasPermission(permission string) bool {
+	return slices.Contains(u.Permissions, permission)
+}
+
+func (u *UserDto) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// IsServiceAccount tells whether the account is a non-interactive identity
+// without a password.
+func (u *UserDto) IsServiceAccount() bool {
+	return u.AccountType == ACCOUNT_TYPE_SERVICE
+}
+
+func (u *UserDto) IsDisabled() bool {
+	return u.DisabledAt != nil
+}
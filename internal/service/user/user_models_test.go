@@ -3,10 +3,15 @@
 package user_test
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
+	"github.com/fgeck/gotth-postgres/internal/repository"
 	"github.com/fgeck/gotth-postgres/internal/service/user"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUSerRoleFromString(t *testing.T) {
@@ -69,3 +74,65 @@ func TestIsUser(t *testing.T) {
 		assert.False(t, userDto.IsUser())
 	})
 }
+
+func TestIsEmailVerified(t *testing.T) {
+	t.Parallel()
+	t.Run("returns true once the email was verified", func(t *testing.T) {
+		t.Parallel()
+		verifiedAt := time.Now()
+		userDto := user.NewUserDto(repository.User{EmailVerifiedAt: pgtype.Timestamptz{Time: verifiedAt, Valid: true}})
+		assert.True(t, userDto.IsEmailVerified())
+		assert.Equal(t, verifiedAt, *userDto.EmailVerifiedAt)
+	})
+	t.Run("returns false for an unverified email", func(t *testing.T) {
+		t.Parallel()
+		userDto := user.NewUserDto(repository.User{})
+		assert.False(t, userDto.IsEmailVerified())
+	})
+}
+
+func TestIsDisabled(t *testing.T) {
+	t.Parallel()
+	t.Run("returns true for a disabled user", func(t *testing.T) {
+		t.Parallel()
+		disabledAt := time.Now()
+		userDto := user.NewUserDto(repository.User{DisabledAt: pgtype.Timestamptz{Time: disabledAt, Valid: true}})
+		assert.True(t, userDto.IsDisabled())
+		assert.Equal(t, disabledAt, *userDto.DisabledAt)
+	})
+	t.Run("returns false for an active user", func(t *testing.T) {
+		t.Parallel()
+		userDto := user.NewUserDto(repository.User{})
+		assert.False(t, userDto.IsDisabled())
+	})
+}
+
+func TestHasPermission(t *testing.T) {
+	t.Parallel()
+	t.Run("returns true for a loaded permission", func(t *testing.T) {
+		t.Parallel()
+		userDto := &user.UserDto{Permissions: []string{"users:read", "users:write"}}
+		assert.True(t, userDto.HasPermission("users:write"))
+	})
+	t.Run("returns false for a missing permission", func(t *testing.T) {
+		t.Parallel()
+		userDto := &user.UserDto{Permissions: []string{"users:read"}}
+		assert.False(t, userDto.HasPermission("users:write"))
+	})
+	t.Run("returns false before permissions are loaded", func(t *testing.T) {
+		t.Parallel()
+		userDto := &user.UserDto{Role: user.UserRoleAdmin}
+		assert.False(t, userDto.HasPermission("users:read"))
+	})
+}
+
+func TestUserDtoJsonOmitsPasswordHash(t *testing.T) {
+	t.Parallel()
+	userDto := user.NewUserDto(repository.User{Username: "testuser", PasswordHash: "hashedpassword"})
+
+	encoded, err := json.Marshal(userDto)
+
+	require.NoError(t, err)
+	assert.NotContains(t, string(encoded), "hashedpassword")
+	assert.NotContains(t, string(encoded), "passwordHash")
+}
@@ -4,15 +4,22 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 
 	"github.com/fgeck/gotth-postgres/internal/repository"
 	"github.com/fgeck/gotth-postgres/internal/service/validation"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type UserServiceInterface interface {
-	CreateUser(ctx context.Context, username, email, passwordHash string) (*UserCreatedDto, error)
+	CreateUser(ctx context.Context, username, email, passwordHash string, pendingApproval bool) (*UserCreatedDto, error)
 	GetUserByEmail(ctx context.Context, email string) (*UserDto, error)
+	GetUserById(ctx context.Context, id uuid.UUID) (*UserDto, error)
 	UserExistsByEmail(ctx context.Context, email string) (bool, error)
+	UserExistsByUsername(ctx context.Context, username string) (bool, error)
+	UpdateUser(ctx context.Context, id uuid.UUID, username, email, passwordHash string) (*UserDto, error)
+	UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error
 	ValidateCreateUserParams(username, email, password string) error
 }
 
@@ -34,6 +41,10 @@ func NewUserCreatedDto(username, email string) *UserCreatedDto {
 
 var (
 	ErrUserNotFound = errors.New("user not found")
+	ErrUserDisabled = errors.New("user account is disabled")
+
+	ErrUserPendingApproval = errors.New("user account is waiting for approval")
+	ErrServiceAccount      = errors.New("service accounts cannot log in")
 )
 
 func (s *UserService) GetUserByEmail(ctx context.Context, email string) (*UserDto, error) {
@@ -46,18 +57,35 @@ func (s *UserService) GetUserByEmail(ctx context.Context, email string) (*UserDt
 	return NewUserDto(user), err
 }
 
+func (s *UserService) GetUserById(ctx context.Context, id uuid.UUID) (*UserDto, error) {
+	user, err := s.queries.GetUserById(ctx, pgtype.UUID{Bytes: id, Valid: true})
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrUserNotFound
+	}
+
+	return NewUserDto(user), err
+}
+
 func (s *UserService) UserExistsByEmail(ctx context.Context, email string) (bool, error) {
 	return s.queries.UserExistsByEmail(ctx, email)
 }
 
-func (s *UserService) CreateUser(ctx context.Context, username, email, hashedPassword string) (*UserCreatedDto, error) {
+func (s *UserService) UserExistsByUsername(ctx context.Context, username string) (bool, error) {
+	return s.queries.UserExistsByUsername(ctx, username)
+}
+
+// CreateUser creates an account with the USER role. With pendingApproval the
+// account cannot log in until an admin approves it.
+func (s *UserService) CreateUser(ctx context.Context, username, email, hashedPassword string, pendingApproval bool) (*UserCreatedDto, error) {
 	user, err := s.queries.CreateUser(
 		ctx,
 		repository.CreateUserParams{
-			Username:     username,
-			Email:        email,
-			PasswordHash: hashedPassword,
-			UserRole:     UserRoleUser.Name,
+			Username:        username,
+			Email:           email,
+			PasswordHash:    hashedPassword,
+			UserRole:        UserRoleUser.Name,
+			PendingApproval: pendingApproval,
 		},
 	)
 	if err != nil {
@@ -68,12 +96,47 @@ func (s *UserService) CreateUser(ctx context.Context, username, email, hashedPas
 	return NewUserCreatedDto(user.Username, user.Email), nil
 }
 
+func (s *UserService) UpdateUser(ctx context.Context, id uuid.UUID, username, email, passwordHash string) (*UserDto, error) {
+	user, err := s.queries.UpdateUser(
+		ctx,
+		repository.UpdateUserParams{
+			ID:           pgtype.UUID{Bytes: id, Valid: true},
+			Username:     username,
+			Email:        email,
+			PasswordHash: passwordHash,
+		},
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return NewUserDto(user), nil
+}
+
+func (s *UserService) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	err := s.queries.UpdateUserPassword(
+		ctx,
+		repository.UpdateUserPasswordParams{
+			ID:           pgtype.UUID{Bytes: id, Valid: true},
+			PasswordHash: passwordHash,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	return nil
+}
+
 func (s *UserService) ValidateCreateUserParams(username, email, password string) error {
 	if err := s.validator.ValidateEmail(email); err != nil {
 		return err
 	}
 
-	if err := s.validator.ValidatePassword(password); err != nil {
+	if err := s.validator.ValidatePassword(password, username, email); err != nil {
 		return err
 	}
 
@@ -13,6 +13,7 @@ import (
 	userfacing_errors "github.com/fgeck/gotth-postgres/internal/service/errors"
 	"github.com/fgeck/gotth-postgres/internal/service/user"
 	validationMocks "github.com/fgeck/gotth-postgres/internal/service/validation/mocks"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -40,7 +41,7 @@ func TestCreateUser(t *testing.T) {
 			Email:    email,
 		}, nil)
 
-		userDto, err := userService.CreateUser(ctx, username, email, passwordHash)
+		userDto, err := userService.CreateUser(ctx, username, email, passwordHash, false)
 
 		require.NoError(t, err)
 		assert.NotNil(t, userDto)
@@ -50,11 +51,32 @@ func TestCreateUser(t *testing.T) {
 		mockQueries.AssertExpectations(t)
 	})
 
+	t.Run("creates the account waiting for approval in the same insert", func(t *testing.T) {
+		mockQueries, _, userService := setupUserServiceTest(t)
+
+		mockQueries.On("CreateUser", ctx, repository.CreateUserParams{
+			Username:        username,
+			Email:           email,
+			PasswordHash:    passwordHash,
+			UserRole:        user.UserRoleUser.Name,
+			PendingApproval: true,
+		}).Return(repository.User{
+			Username:        username,
+			Email:           email,
+			PendingApproval: true,
+		}, nil)
+
+		userDto, err := userService.CreateUser(ctx, username, email, passwordHash, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, username, userDto.Username)
+	})
+
 	t.Run("fails when database error occurs", func(t *testing.T) {
 		mockQueries, _, userService := setupUserServiceTest(t)
 		mockQueries.On("CreateUser", ctx, mock.Anything).Return(repository.User{}, errors.New("database error"))
 
-		userDto, err := userService.CreateUser(ctx, username, email, passwordHash)
+		userDto, err := userService.CreateUser(ctx, username, email, passwordHash, false)
 
 		require.Error(t, err)
 		assert.Nil(t, userDto)
@@ -72,7 +94,7 @@ func TestValidateCreateUserParams(t *testing.T) {
 	t.Run("successfully validates parameters", func(t *testing.T) {
 		_, mockValidator, userService := setupUserServiceTest(t)
 		mockValidator.On("ValidateEmail", email).Return(nil)
-		mockValidator.On("ValidatePassword", password).Return(nil)
+		mockValidator.On("ValidatePassword", password, username, email).Return(nil)
 		mockValidator.On("ValidateUsername", username).Return(nil)
 
 		err := userService.ValidateCreateUserParams(username, email, password)
@@ -101,7 +123,7 @@ func TestValidateCreateUserParams(t *testing.T) {
 	t.Run("fails when password validation fails", func(t *testing.T) {
 		_, mockValidator, userService := setupUserServiceTest(t)
 		mockValidator.On("ValidateEmail", email).Return(nil)
-		mockValidator.On("ValidatePassword", password).Return(userfacing_errors.NewUserFacing("password too weak"))
+		mockValidator.On("ValidatePassword", password, username, email).Return(userfacing_errors.NewUserFacing("password too weak"))
 
 		err := userService.ValidateCreateUserParams(username, email, password)
 
@@ -118,7 +140,7 @@ func TestValidateCreateUserParams(t *testing.T) {
 	t.Run("fails when username validation fails", func(t *testing.T) {
 		_, mockValidator, userService := setupUserServiceTest(t)
 		mockValidator.On("ValidateEmail", email).Return(nil)
-		mockValidator.On("ValidatePassword", password).Return(nil)
+		mockValidator.On("ValidatePassword", password, username, email).Return(nil)
 		mockValidator.On("ValidateUsername", username).Return(userfacing_errors.NewUserFacing("username too short"))
 
 		err := userService.ValidateCreateUserParams(username, email, password)
@@ -176,6 +198,31 @@ func TestUserExistsByEmail(t *testing.T) {
 	})
 }
 
+func TestUserExistsByUsername(t *testing.T) {
+	ctx := context.Background()
+	username := "testuser"
+
+	t.Run("returns true when user exists", func(t *testing.T) {
+		mockQueries, _, userService := setupUserServiceTest(t)
+		mockQueries.On("UserExistsByUsername", ctx, username).Return(true, nil)
+
+		exists, err := userService.UserExistsByUsername(ctx, username)
+
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("returns false when user does not exist", func(t *testing.T) {
+		mockQueries, _, userService := setupUserServiceTest(t)
+		mockQueries.On("UserExistsByUsername", ctx, username).Return(false, nil)
+
+		exists, err := userService.UserExistsByUsername(ctx, username)
+
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
 func TestGetUserByEmail(t *testing.T) {
 	ctx := context.Background()
 	email := "testuser@example.com"
@@ -223,3 +270,105 @@ func TestGetUserByEmail(t *testing.T) {
 		mockQueries.AssertExpectations(t)
 	})
 }
+
+func TestGetUserById(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	pgId := pgtype.UUID{Bytes: id, Valid: true}
+
+	t.Run("successfully retrieves user", func(t *testing.T) {
+		mockQueries, _, userService := setupUserServiceTest(t)
+		mockQueries.On("GetUserById", ctx, pgId).Return(repository.User{
+			ID:       pgId,
+			Username: "testuser",
+			Email:    "testuser@example.com",
+			UserRole: "USER",
+		}, nil)
+
+		userDto, err := userService.GetUserById(ctx, id)
+
+		require.NoError(t, err)
+		assert.Equal(t, id, userDto.ID)
+		assert.Equal(t, "testuser", userDto.Username)
+		assert.Equal(t, user.UserRoleUser, userDto.Role)
+
+		mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("returns ErrUserNotFound when no rows found", func(t *testing.T) {
+		mockQueries, _, userService := setupUserServiceTest(t)
+		mockQueries.On("GetUserById", ctx, pgId).Return(repository.User{}, sql.ErrNoRows)
+
+		userDto, err := userService.GetUserById(ctx, id)
+
+		require.ErrorIs(t, err, user.ErrUserNotFound)
+		assert.Nil(t, userDto)
+
+		mockQueries.AssertExpectations(t)
+	})
+}
+
+func TestUpdateUser(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	params := repository.UpdateUserParams{
+		ID:           pgtype.UUID{Bytes: id, Valid: true},
+		Username:     "testuser",
+		Email:        "testuser@example.com",
+		PasswordHash: "newhashedpassword",
+	}
+
+	t.Run("returns the updated user", func(t *testing.T) {
+		mockQueries, _, userService := setupUserServiceTest(t)
+		mockQueries.On("UpdateUser", ctx, params).Return(repository.User{
+			ID:           params.ID,
+			Username:     params.Username,
+			Email:        params.Email,
+			PasswordHash: params.PasswordHash,
+			UserRole:     "user",
+		}, nil)
+
+		updated, err := userService.UpdateUser(ctx, id, params.Username, params.Email, params.PasswordHash)
+
+		require.NoError(t, err)
+		assert.Equal(t, id, updated.ID)
+		assert.Equal(t, params.PasswordHash, updated.PasswordHash)
+	})
+
+	t.Run("fails when user does not exist", func(t *testing.T) {
+		mockQueries, _, userService := setupUserServiceTest(t)
+		mockQueries.On("UpdateUser", ctx, params).Return(repository.User{}, sql.ErrNoRows)
+
+		_, err := userService.UpdateUser(ctx, id, params.Username, params.Email, params.PasswordHash)
+
+		require.ErrorIs(t, err, user.ErrUserNotFound)
+	})
+}
+
+func TestUpdatePassword(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	passwordHash := "newhashedpassword"
+
+	t.Run("stores the new password hash", func(t *testing.T) {
+		mockQueries, _, userService := setupUserServiceTest(t)
+		mockQueries.On("UpdateUserPassword", ctx, repository.UpdateUserPasswordParams{
+			ID:           pgtype.UUID{Bytes: id, Valid: true},
+			PasswordHash: passwordHash,
+		}).Return(nil)
+
+		err := userService.UpdatePassword(ctx, id, passwordHash)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("fails when database error occurs", func(t *testing.T) {
+		mockQueries, _, userService := setupUserServiceTest(t)
+		mockQueries.On("UpdateUserPassword", ctx, mock.Anything).Return(errors.New("database error"))
+
+		err := userService.UpdatePassword(ctx, id, passwordHash)
+
+		require.Error(t, err)
+		assert.Equal(t, "failed to update password: database error", err.Error())
+	})
+}
@@ -0,0 +1,75 @@
+package userAdmin
+
+import (
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	"github.com/google/uuid"
+)
+
+const (
+	STATUS_ACTIVE     = "active"
+	STATUS_DISABLED   = "disabled"
+	STATUS_PENDING    = "pending"
+	DEFAULT_PAGE_SIZE = 20
+	MAX_PAGE_SIZE     = 100
+)
+
+// UserFilter selects a page of users. Empty fields do not filter, Search
+// matches parts of the username or email address.
+type UserFilter struct {
+	Search   string
+	Role     string
+	Status   string
+	Page     int
+	PageSize int
+}
+
+// AdminUserDto is what admins see of a user. Like user.UserDto it never
+// contains the password hash. Roles are only set for a single user.
+type AdminUserDto struct {
+	ID                    uuid.UUID     `json:"id"`
+	Username              string        `json:"username"`
+	Email                 string        `json:"email"`
+	Role                  user.UserRole `json:"role"`
+	Roles                 []string      `json:"roles,omitempty"`
+	EmailVerifiedAt       *time.Time    `json:"emailVerifiedAt,omitempty"`
+	DisabledAt            *time.Time    `json:"disabledAt,omitempty"`
+	PasswordResetRequired bool          `json:"passwordResetRequired"`
+	PendingApproval       bool          `json:"pendingApproval"`
+	AccountType           string        `json:"accountType"`
+	PasswordChangedAt     time.Time     `json:"passwordChangedAt"`
+	CreatedAt             time.Time     `json:"createdAt"`
+	UpdatedAt             time.Time     `json:"updatedAt"`
+}
+
+func NewAdminUserDto(u repository.User) *AdminUserDto {
+	userDto := user.NewUserDto(u)
+
+	return &AdminUserDto{
+		ID:                    userDto.ID,
+		Username:              userDto.Username,
+		Email:                 userDto.Email,
+		Role:                  userDto.Role,
+		EmailVerifiedAt:       userDto.EmailVerifiedAt,
+		DisabledAt:            userDto.DisabledAt,
+		PasswordResetRequired: userDto.PasswordResetRequired,
+		PendingApproval:       userDto.PendingApproval,
+		AccountType:           userDto.AccountType,
+		PasswordChangedAt:     userDto.PasswordChangedAt,
+		CreatedAt:             u.CreatedAt.Time,
+		UpdatedAt:             u.UpdatedAt.Time,
+	}
+}
+
+func (u *AdminUserDto) IsDisabled() bool {
+	return u.DisabledAt != nil
+}
+
+type UserPageDto struct {
+	Users    []*AdminUserDto `json:"users"`
+	Page     int             `json:"page"`
+	PageSize int             `json:"pageSize"`
+	Total    int64           `json:"total"`
+}
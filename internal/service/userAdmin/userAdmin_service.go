@@ -0,0 +1,430 @@
+package userAdmin
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	"github.com/fgeck/gotth-postgres/internal/service/mail"
+	"github.com/fgeck/gotth-postgres/internal/service/rbac"
+	"github.com/fgeck/gotth-postgres/internal/service/security/password"
+	"github.com/fgeck/gotth-postgres/internal/service/session"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const LOGIN_PATH = "/login"
+
+var (
+	ErrUnknownRole      = errors.New("unknown role")
+	ErrUnknownStatus    = errors.New("unknown status")
+	ErrSelfModification = errors.New("admins cannot change their own role, status or account")
+	ErrUsernameTaken    = errors.New("username is already taken")
+	ErrEmailTaken       = errors.New("email address is already in use")
+	ErrNotPending       = errors.New("user is not waiting for approval")
+)
+
+type UserAdminServiceInterface interface {
+	ListUsers(ctx context.Context, filter UserFilter) (*UserPageDto, error)
+	GetUser(ctx context.Context, id uuid.UUID) (*AdminUserDto, error)
+	CreateUser(ctx context.Context, actorPermissions []string, username, email, password, role string) (*AdminUserDto, error)
+	UpdateRole(ctx context.Context, actorID uuid.UUID, actorPermissions []string, id uuid.UUID, role string) (*AdminUserDto, error)
+	UpdateRoles(ctx context.Context, actorID uuid.UUID, actorPermissions []string, id uuid.UUID, roles []string) (*AdminUserDto, error)
+	SetDisabled(ctx context.Context, actorID uuid.UUID, actorPermissions []string, id uuid.UUID, disabled bool) (*AdminUserDto, error)
+	ForcePasswordReset(ctx context.Context, actorID uuid.UUID, actorPermissions []string, id uuid.UUID) error
+	DeleteUser(ctx context.Context, actorID uuid.UUID, actorPermissions []string, id uuid.UUID) error
+	ApproveUser(ctx context.Context, id uuid.UUID) (*AdminUserDto, error)
+	RejectUser(ctx context.Context, id uuid.UUID) error
+}
+
+// UserAdminService manages the accounts of other users. Changes that affect
+// what a user may do log the user out everywhere, so that they take effect
+// before the current access tokens expire. Roles can only be given and taken
+// by admins holding all of their permissions, see rbac.CheckRolesHeld, and the
+// same goes for disabling, resetting and deleting the users holding them.
+type UserAdminService struct {
+	queries         repository.Querier
+	userService     user.UserServiceInterface
+	passwordService password.PasswordServiceInterface
+	sessionService  session.SessionServiceInterface
+	rbacService     rbac.RbacServiceInterface
+	mailer          mail.Mailer
+	publicUrl       string
+}
+
+func NewUserAdminService(
+	queries repository.Querier,
+	userService user.UserServiceInterface,
+	passwordService password.PasswordServiceInterface,
+	sessionService session.SessionServiceInterface,
+	rbacService rbac.RbacServiceInterface,
+	mailer mail.Mailer,
+	publicUrl string,
+) *UserAdminService {
+	return &UserAdminService{
+		queries:         queries,
+		userService:     userService,
+		passwordService: passwordService,
+		sessionService:  sessionService,
+		rbacService:     rbacService,
+		mailer:          mailer,
+		publicUrl:       publicUrl,
+	}
+}
+
+// ListUsers returns the newest users first. Page numbers start at 1.
+func (s *UserAdminService) ListUsers(ctx context.Context, filter UserFilter) (*UserPageDto, error) {
+	role := ""
+	if filter.Role != "" {
+		parsed, err := parseRole(filter.Role)
+		if err != nil {
+			return nil, err
+		}
+		role = parsed.Name
+	}
+	status := strings.ToLower(filter.Status)
+	if status != "" && status != STATUS_ACTIVE && status != STATUS_DISABLED && status != STATUS_PENDING {
+		return nil, ErrUnknownStatus
+	}
+	page := max(filter.Page, 1)
+	pageSize := filter.PageSize
+	if pageSize <= 0 {
+		pageSize = DEFAULT_PAGE_SIZE
+	}
+	pageSize = min(pageSize, MAX_PAGE_SIZE)
+	search := strings.TrimSpace(filter.Search)
+
+	users, err := s.queries.ListUsers(
+		ctx,
+		repository.ListUsersParams{
+			Search:     search,
+			Role:       role,
+			Status:     status,
+			PageLimit:  int32(pageSize),
+			PageOffset: int32((page - 1) * pageSize),
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	total, err := s.queries.CountUsers(
+		ctx,
+		repository.CountUsersParams{
+			Search: search,
+			Role:   role,
+			Status: status,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	dtos := make([]*AdminUserDto, 0, len(users))
+	for _, u := range users {
+		dtos = append(dtos, NewAdminUserDto(u))
+	}
+
+	return &UserPageDto{Users: dtos, Page: page, PageSize: pageSize, Total: total}, nil
+}
+
+func (s *UserAdminService) GetUser(ctx context.Context, id uuid.UUID) (*AdminUserDto, error) {
+	dto, err := s.findUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	dto.Roles, err = s.rbacService.GetUserRoles(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto, nil
+}
+
+// CreateUser runs the same validation as a registration. The password is only
+// a starting point, the user has to choose their own on the first login. The
+// admin vouches for the address, so it counts as verified. Both are set by the
+// same insert, an account never exists with the admin's password alone.
+func (s *UserAdminService) CreateUser(ctx context.Context, actorPermissions []string, username, email, password, role string) (*AdminUserDto, error) {
+	parsedRole, err := parseRole(role)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.rbacService.CheckRolesHeld(ctx, actorPermissions, []string{parsedRole.Name}); err != nil {
+		return nil, err
+	}
+	if err := s.userService.ValidateCreateUserParams(username, email, password); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.userService.UserExistsByUsername(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check username: %w", err)
+	}
+	if exists {
+		return nil, ErrUsernameTaken
+	}
+	exists, err = s.userService.UserExistsByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check email: %w", err)
+	}
+	if exists {
+		return nil, ErrEmailTaken
+	}
+
+	hashedPassword, err := s.passwordService.HashAndSaltPassword(password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to salt and hash password: %w", err)
+	}
+
+	created, err := s.queries.CreateAdminUser(
+		ctx,
+		repository.CreateAdminUserParams{
+			Username:     username,
+			Email:        email,
+			PasswordHash: hashedPassword,
+			UserRole:     parsedRole.Name,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	return NewAdminUserDto(created), nil
+}
+
+func (s *UserAdminService) UpdateRole(ctx context.Context, actorID uuid.UUID, actorPermissions []string, id uuid.UUID, role string) (*AdminUserDto, error) {
+	if actorID == id {
+		return nil, ErrSelfModification
+	}
+	parsedRole, err := parseRole(role)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.findUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Taking a role away is checked like granting it, otherwise an admin
+	// could demote admins with more permissions.
+	if err := s.rbacService.CheckRolesHeld(ctx, actorPermissions, []string{current.Role.Name, parsedRole.Name}); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.queries.UpdateUserRole(
+		ctx,
+		repository.UpdateUserRoleParams{
+			ID:       pgtype.UUID{Bytes: id, Valid: true},
+			UserRole: parsedRole.Name,
+		},
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, user.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update role: %w", err)
+	}
+
+	// Access tokens carry the role.
+	if err := s.sessionService.RevokeAllUserSessions(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return NewAdminUserDto(updated), nil
+}
+
+// UpdateRoles replaces the roles granted on top of the base role.
+func (s *UserAdminService) UpdateRoles(ctx context.Context, actorID uuid.UUID, actorPermissions []string, id uuid.UUID, roles []string) (*AdminUserDto, error) {
+	if actorID == id {
+		return nil, ErrSelfModification
+	}
+	if _, err := s.findUser(ctx, id); err != nil {
+		return nil, err
+	}
+	current, err := s.rbacService.GetUserRoles(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.rbacService.CheckRolesHeld(ctx, actorPermissions, append(current, roles...)); err != nil {
+		return nil, err
+	}
+
+	if err := s.rbacService.SetUserRoles(ctx, id, roles); err != nil {
+		return nil, err
+	}
+
+	// Access tokens carry the permissions of all roles.
+	if err := s.sessionService.RevokeAllUserSessions(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return s.GetUser(ctx, id)
+}
+
+func (s *UserAdminService) SetDisabled(
+	ctx context.Context,
+	actorID uuid.UUID,
+	actorPermissions []string,
+	id uuid.UUID,
+	disabled bool,
+) (*AdminUserDto, error) {
+	if actorID == id {
+		return nil, ErrSelfModification
+	}
+	if err := s.checkTargetHeld(ctx, actorPermissions, id); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.queries.SetUserDisabled(
+		ctx,
+		repository.SetUserDisabledParams{
+			ID:       pgtype.UUID{Bytes: id, Valid: true},
+			Disabled: disabled,
+		},
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, user.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user status: %w", err)
+	}
+
+	if disabled {
+		if err := s.sessionService.RevokeAllUserSessions(ctx, id); err != nil {
+			return nil, fmt.Errorf("failed to revoke sessions: %w", err)
+		}
+	}
+
+	return NewAdminUserDto(updated), nil
+}
+
+// ForcePasswordReset logs the user out everywhere. The next login with the
+// current password asks for a new one before a session is started.
+func (s *UserAdminService) ForcePasswordReset(ctx context.Context, actorID uuid.UUID, actorPermissions []string, id uuid.UUID) error {
+	if actorID == id {
+		return ErrSelfModification
+	}
+	if err := s.checkTargetHeld(ctx, actorPermissions, id); err != nil {
+		return err
+	}
+
+	updated, err := s.queries.RequireUserPasswordReset(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		return fmt.Errorf("failed to require password reset: %w", err)
+	}
+	if updated == 0 {
+		return user.ErrUserNotFound
+	}
+
+	if err := s.sessionService.RevokeAllUserSessions(ctx, id); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return nil
+}
+
+func (s *UserAdminService) DeleteUser(ctx context.Context, actorID uuid.UUID, actorPermissions []string, id uuid.UUID) error {
+	if actorID == id {
+		return ErrSelfModification
+	}
+	if _, err := s.findUser(ctx, id); err != nil {
+		return err
+	}
+	if err := s.checkTargetHeld(ctx, actorPermissions, id); err != nil {
+		return err
+	}
+
+	if err := s.queries.DeleteUser(ctx, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	return nil
+}
+
+// ApproveUser lets an account that registered in approval mode log in and
+// tells its owner by email.
+func (s *UserAdminService) ApproveUser(ctx context.Context, id uuid.UUID) (*AdminUserDto, error) {
+	approved, err := s.queries.ApproveUser(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, err := s.findUser(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrNotPending
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to approve user: %w", err)
+	}
+
+	message, err := mail.NewAccountApprovedMessage(ctx, approved.Email, s.publicUrl+LOGIN_PATH)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build approval email: %w", err)
+	}
+	if err := s.mailer.Send(ctx, message); err != nil {
+		return nil, fmt.Errorf("failed to send approval email: %w", err)
+	}
+
+	return NewAdminUserDto(approved), nil
+}
+
+// RejectUser deletes an account that is waiting for approval and tells its
+// owner by email. Approved accounts have to be deleted with DeleteUser.
+func (s *UserAdminService) RejectUser(ctx context.Context, id uuid.UUID) error {
+	rejected, err := s.findUser(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !rejected.PendingApproval {
+		return ErrNotPending
+	}
+
+	if err := s.queries.DeleteUser(ctx, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	message, err := mail.NewAccountRejectedMessage(ctx, rejected.Email)
+	if err != nil {
+		return fmt.Errorf("failed to build rejection email: %w", err)
+	}
+	if err := s.mailer.Send(ctx, message); err != nil {
+		return fmt.Errorf("failed to send rejection email: %w", err)
+	}
+
+	return nil
+}
+
+// checkTargetHeld keeps admins from acting on users whose roles grant more
+// than they hold themselves, such as a superadmin.
+func (s *UserAdminService) checkTargetHeld(ctx context.Context, actorPermissions []string, id uuid.UUID) error {
+	roles, err := s.rbacService.GetUserRoles(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return s.rbacService.CheckRolesHeld(ctx, actorPermissions, roles)
+}
+
+func (s *UserAdminService) findUser(ctx context.Context, id uuid.UUID) (*AdminUserDto, error) {
+	u, err := s.queries.GetUserById(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, user.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return NewAdminUserDto(u), nil
+}
+
+// parseRole only accepts the names of existing roles, unlike
+// user.UserRoleFromString which falls back to the user role.
+func parseRole(name string) (user.UserRole, error) {
+	role := user.UserRoleFromString(name)
+	if !strings.EqualFold(role.Name, name) {
+		return user.UserRole{}, ErrUnknownRole
+	}
+
+	return role, nil
+}
@@ -0,0 +1,598 @@
+//go:build unittest
+
+package userAdmin_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/repository"
+	repositoryMocks "github.com/fgeck/gotth-postgres/internal/repository/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/mail"
+	mailMocks "github.com/fgeck/gotth-postgres/internal/service/mail/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/rbac"
+	rbacMocks "github.com/fgeck/gotth-postgres/internal/service/rbac/mocks"
+	passwordMocks "github.com/fgeck/gotth-postgres/internal/service/security/password/mocks"
+	sessionMocks "github.com/fgeck/gotth-postgres/internal/service/session/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	userMocks "github.com/fgeck/gotth-postgres/internal/service/user/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/userAdmin"
+	"github.com/fgeck/gotth-postgres/internal/service/validation"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	USERNAME      = "testuser"
+	EMAIL         = "testuser@example.com"
+	PASSWORD      = "Quiet-Harbor-Lantern-42!"
+	PASSWORD_HASH = "hashedpassword"
+)
+
+// adminPermissions are the permissions of the acting admin.
+var adminPermissions = []string{rbac.PERMISSION_USERS_WRITE, rbac.PERMISSION_ROLES_WRITE}
+
+type userAdminServiceMocks struct {
+	queries         *repositoryMocks.MockQuerier
+	userService     *userMocks.MockUserServiceInterface
+	passwordService *passwordMocks.MockPasswordServiceInterface
+	sessionService  *sessionMocks.MockSessionServiceInterface
+	rbacService     *rbacMocks.MockRbacServiceInterface
+	mailer          *mailMocks.MockMailer
+}
+
+func setupUserAdminServiceTest(t *testing.T) (*userAdminServiceMocks, *userAdmin.UserAdminService) {
+	mocks := &userAdminServiceMocks{
+		queries:         repositoryMocks.NewMockQuerier(t),
+		userService:     userMocks.NewMockUserServiceInterface(t),
+		passwordService: passwordMocks.NewMockPasswordServiceInterface(t),
+		sessionService:  sessionMocks.NewMockSessionServiceInterface(t),
+		rbacService:     rbacMocks.NewMockRbacServiceInterface(t),
+		mailer:          mailMocks.NewMockMailer(t),
+	}
+	service := userAdmin.NewUserAdminService(
+		mocks.queries,
+		mocks.userService,
+		mocks.passwordService,
+		mocks.sessionService,
+		mocks.rbacService,
+		mocks.mailer,
+		"http://localhost:8081",
+	)
+	return mocks, service
+}
+
+func newUser(id uuid.UUID) repository.User {
+	return repository.User{
+		ID:           pgtype.UUID{Bytes: id, Valid: true},
+		Username:     USERNAME,
+		Email:        EMAIL,
+		PasswordHash: PASSWORD_HASH,
+		UserRole:     user.UserRoleUser.Name,
+	}
+}
+
+func TestListUsers(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("passes the filter and computes the offset", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+
+		mocks.queries.On("ListUsers", ctx, repository.ListUsersParams{
+			Search:     "test",
+			Role:       "ADMIN",
+			Status:     userAdmin.STATUS_DISABLED,
+			PageLimit:  10,
+			PageOffset: 20,
+		}).Return([]repository.User{newUser(uuid.New())}, nil)
+		mocks.queries.On("CountUsers", ctx, repository.CountUsersParams{
+			Search: "test",
+			Role:   "ADMIN",
+			Status: userAdmin.STATUS_DISABLED,
+		}).Return(int64(21), nil)
+
+		page, err := service.ListUsers(ctx, userAdmin.UserFilter{
+			Search:   " test ",
+			Role:     "admin",
+			Status:   "Disabled",
+			Page:     3,
+			PageSize: 10,
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, page.Users, 1)
+		assert.Equal(t, 3, page.Page)
+		assert.Equal(t, 10, page.PageSize)
+		assert.Equal(t, int64(21), page.Total)
+	})
+
+	t.Run("defaults and caps the page size", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+
+		mocks.queries.On("ListUsers", ctx, repository.ListUsersParams{PageLimit: userAdmin.MAX_PAGE_SIZE}).Return([]repository.User{}, nil)
+		mocks.queries.On("CountUsers", ctx, repository.CountUsersParams{}).Return(int64(0), nil)
+
+		page, err := service.ListUsers(ctx, userAdmin.UserFilter{PageSize: 1000})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, page.Page)
+		assert.Equal(t, userAdmin.MAX_PAGE_SIZE, page.PageSize)
+		assert.NotNil(t, page.Users)
+	})
+
+	t.Run("rejects unknown filter values", func(t *testing.T) {
+		_, service := setupUserAdminServiceTest(t)
+
+		_, err := service.ListUsers(ctx, userAdmin.UserFilter{Role: "owner"})
+		require.ErrorIs(t, err, userAdmin.ErrUnknownRole)
+
+		_, err = service.ListUsers(ctx, userAdmin.UserFilter{Status: "locked"})
+		require.ErrorIs(t, err, userAdmin.ErrUnknownStatus)
+	})
+}
+
+func TestGetUser(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+
+	t.Run("never serializes the password hash", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+
+		mocks.queries.On("GetUserById", ctx, pgtype.UUID{Bytes: id, Valid: true}).Return(newUser(id), nil)
+		mocks.rbacService.On("GetUserRoles", ctx, id).Return([]string{"USER"}, nil)
+
+		result, err := service.GetUser(ctx, id)
+		require.NoError(t, err)
+		encoded, err := json.Marshal(result)
+
+		require.NoError(t, err)
+		assert.Equal(t, USERNAME, result.Username)
+		assert.NotContains(t, string(encoded), PASSWORD_HASH)
+	})
+
+	t.Run("includes all roles of the user", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+
+		mocks.queries.On("GetUserById", ctx, pgtype.UUID{Bytes: id, Valid: true}).Return(newUser(id), nil)
+		mocks.rbacService.On("GetUserRoles", ctx, id).Return([]string{"SUPPORT", "USER"}, nil)
+
+		result, err := service.GetUser(ctx, id)
+
+		require.NoError(t, err)
+		assert.Equal(t, user.UserRoleUser, result.Role)
+		assert.Equal(t, []string{"SUPPORT", "USER"}, result.Roles)
+	})
+
+	t.Run("fails for an unknown user", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+
+		mocks.queries.On("GetUserById", ctx, pgtype.UUID{Bytes: id, Valid: true}).Return(repository.User{}, sql.ErrNoRows)
+
+		_, err := service.GetUser(ctx, id)
+
+		require.ErrorIs(t, err, user.ErrUserNotFound)
+	})
+}
+
+func TestCreateUser(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+
+	t.Run("creates a verified user with the role and requires a new password", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+		created := newUser(id)
+		created.UserRole = user.UserRoleAdmin.Name
+		created.EmailVerifiedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+		created.PasswordResetRequired = true
+
+		mocks.rbacService.On("CheckRolesHeld", ctx, adminPermissions, []string{user.UserRoleAdmin.Name}).Return(nil)
+		mocks.userService.On("ValidateCreateUserParams", USERNAME, EMAIL, PASSWORD).Return(nil)
+		mocks.userService.On("UserExistsByUsername", ctx, USERNAME).Return(false, nil)
+		mocks.userService.On("UserExistsByEmail", ctx, EMAIL).Return(false, nil)
+		mocks.passwordService.On("HashAndSaltPassword", PASSWORD).Return(PASSWORD_HASH, nil)
+		mocks.queries.On("CreateAdminUser", ctx, repository.CreateAdminUserParams{
+			Username:     USERNAME,
+			Email:        EMAIL,
+			PasswordHash: PASSWORD_HASH,
+			UserRole:     user.UserRoleAdmin.Name,
+		}).Return(created, nil)
+
+		result, err := service.CreateUser(ctx, adminPermissions, USERNAME, EMAIL, PASSWORD, "admin")
+
+		require.NoError(t, err)
+		assert.Equal(t, user.UserRoleAdmin, result.Role)
+		assert.True(t, result.PasswordResetRequired)
+		assert.NotNil(t, result.EmailVerifiedAt)
+	})
+
+	t.Run("runs the registration validation", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+
+		mocks.rbacService.On("CheckRolesHeld", ctx, adminPermissions, []string{user.UserRoleUser.Name}).Return(nil)
+		mocks.userService.On("ValidateCreateUserParams", USERNAME, "invalid", PASSWORD).Return(validation.ErrInvalidEmailFormat)
+
+		_, err := service.CreateUser(ctx, adminPermissions, USERNAME, "invalid", PASSWORD, "user")
+
+		require.ErrorIs(t, err, validation.ErrInvalidEmailFormat)
+	})
+
+	t.Run("rejects an email address that is in use", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+
+		mocks.rbacService.On("CheckRolesHeld", ctx, adminPermissions, []string{user.UserRoleUser.Name}).Return(nil)
+		mocks.userService.On("ValidateCreateUserParams", USERNAME, EMAIL, PASSWORD).Return(nil)
+		mocks.userService.On("UserExistsByUsername", ctx, USERNAME).Return(false, nil)
+		mocks.userService.On("UserExistsByEmail", ctx, EMAIL).Return(true, nil)
+
+		_, err := service.CreateUser(ctx, adminPermissions, USERNAME, EMAIL, PASSWORD, "user")
+
+		require.ErrorIs(t, err, userAdmin.ErrEmailTaken)
+	})
+
+	t.Run("rejects an unknown role", func(t *testing.T) {
+		_, service := setupUserAdminServiceTest(t)
+
+		_, err := service.CreateUser(ctx, adminPermissions, USERNAME, EMAIL, PASSWORD, "owner")
+
+		require.ErrorIs(t, err, userAdmin.ErrUnknownRole)
+	})
+
+	t.Run("rejects a role with permissions the admin does not hold", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+		permissions := []string{rbac.PERMISSION_USERS_WRITE}
+
+		mocks.rbacService.On("CheckRolesHeld", ctx, permissions, []string{user.UserRoleAdmin.Name}).Return(rbac.ErrRoleNotHeld)
+
+		_, err := service.CreateUser(ctx, permissions, USERNAME, EMAIL, PASSWORD, "admin")
+
+		require.ErrorIs(t, err, rbac.ErrRoleNotHeld)
+		mocks.queries.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdateRole(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	id := uuid.New()
+
+	t.Run("updates the role and logs the user out", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+		updated := newUser(id)
+		updated.UserRole = user.UserRoleAdmin.Name
+
+		mocks.queries.On("GetUserById", ctx, pgtype.UUID{Bytes: id, Valid: true}).Return(newUser(id), nil)
+		mocks.rbacService.On("CheckRolesHeld", ctx, adminPermissions, []string{user.UserRoleUser.Name, user.UserRoleAdmin.Name}).Return(nil)
+		mocks.queries.On("UpdateUserRole", ctx, repository.UpdateUserRoleParams{
+			ID:       pgtype.UUID{Bytes: id, Valid: true},
+			UserRole: user.UserRoleAdmin.Name,
+		}).Return(updated, nil)
+		mocks.sessionService.On("RevokeAllUserSessions", ctx, id).Return(nil)
+
+		result, err := service.UpdateRole(ctx, actorID, adminPermissions, id, "ADMIN")
+
+		require.NoError(t, err)
+		assert.Equal(t, user.UserRoleAdmin, result.Role)
+	})
+
+	t.Run("does not let admins change their own role", func(t *testing.T) {
+		_, service := setupUserAdminServiceTest(t)
+
+		_, err := service.UpdateRole(ctx, actorID, adminPermissions, actorID, "USER")
+
+		require.ErrorIs(t, err, userAdmin.ErrSelfModification)
+	})
+
+	t.Run("does not let admins demote users with permissions they do not hold", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+		permissions := []string{rbac.PERMISSION_USERS_WRITE}
+		admin := newUser(id)
+		admin.UserRole = user.UserRoleAdmin.Name
+
+		mocks.queries.On("GetUserById", ctx, pgtype.UUID{Bytes: id, Valid: true}).Return(admin, nil)
+		mocks.rbacService.On("CheckRolesHeld", ctx, permissions, []string{user.UserRoleAdmin.Name, user.UserRoleUser.Name}).Return(rbac.ErrRoleNotHeld)
+
+		_, err := service.UpdateRole(ctx, actorID, permissions, id, "USER")
+
+		require.ErrorIs(t, err, rbac.ErrRoleNotHeld)
+		mocks.queries.AssertNotCalled(t, "UpdateUserRole", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdateRoles(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	id := uuid.New()
+	pgID := pgtype.UUID{Bytes: id, Valid: true}
+
+	t.Run("replaces the roles and logs the user out", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+
+		mocks.queries.On("GetUserById", ctx, pgID).Return(newUser(id), nil)
+		mocks.rbacService.On("GetUserRoles", ctx, id).Return([]string{"USER"}, nil).Once()
+		mocks.rbacService.On("CheckRolesHeld", ctx, adminPermissions, []string{"USER", "SUPPORT"}).Return(nil)
+		mocks.rbacService.On("SetUserRoles", ctx, id, []string{"SUPPORT"}).Return(nil)
+		mocks.sessionService.On("RevokeAllUserSessions", ctx, id).Return(nil)
+		mocks.rbacService.On("GetUserRoles", ctx, id).Return([]string{"SUPPORT", "USER"}, nil)
+
+		result, err := service.UpdateRoles(ctx, actorID, adminPermissions, id, []string{"SUPPORT"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"SUPPORT", "USER"}, result.Roles)
+	})
+
+	t.Run("keeps the sessions of a rejected change", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+
+		mocks.queries.On("GetUserById", ctx, pgID).Return(newUser(id), nil)
+		mocks.rbacService.On("GetUserRoles", ctx, id).Return([]string{"USER"}, nil)
+		mocks.rbacService.On("CheckRolesHeld", ctx, adminPermissions, []string{"USER", "AUDITOR"}).Return(nil)
+		mocks.rbacService.On("SetUserRoles", ctx, id, []string{"AUDITOR"}).Return(rbac.ErrUnknownRole)
+
+		_, err := service.UpdateRoles(ctx, actorID, adminPermissions, id, []string{"AUDITOR"})
+
+		require.ErrorIs(t, err, rbac.ErrUnknownRole)
+		mocks.sessionService.AssertNotCalled(t, "RevokeAllUserSessions", mock.Anything, mock.Anything)
+	})
+
+	t.Run("does not let admins change their own roles", func(t *testing.T) {
+		_, service := setupUserAdminServiceTest(t)
+
+		_, err := service.UpdateRoles(ctx, actorID, adminPermissions, actorID, nil)
+
+		require.ErrorIs(t, err, userAdmin.ErrSelfModification)
+	})
+
+	t.Run("does not let admins grant roles with permissions they do not hold", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+		permissions := []string{rbac.PERMISSION_USERS_WRITE}
+
+		mocks.queries.On("GetUserById", ctx, pgID).Return(newUser(id), nil)
+		mocks.rbacService.On("GetUserRoles", ctx, id).Return([]string{"USER"}, nil)
+		mocks.rbacService.On("CheckRolesHeld", ctx, permissions, []string{"USER", "ADMIN"}).Return(rbac.ErrRoleNotHeld)
+
+		_, err := service.UpdateRoles(ctx, actorID, permissions, id, []string{"ADMIN"})
+
+		require.ErrorIs(t, err, rbac.ErrRoleNotHeld)
+		mocks.rbacService.AssertNotCalled(t, "SetUserRoles", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSetDisabled(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	id := uuid.New()
+	params := func(disabled bool) repository.SetUserDisabledParams {
+		return repository.SetUserDisabledParams{ID: pgtype.UUID{Bytes: id, Valid: true}, Disabled: disabled}
+	}
+
+	t.Run("disables the user and logs them out", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+		disabled := newUser(id)
+		disabled.DisabledAt = pgtype.Timestamptz{Valid: true}
+
+		mocks.rbacService.On("GetUserRoles", ctx, id).Return([]string{"USER"}, nil)
+		mocks.rbacService.On("CheckRolesHeld", ctx, adminPermissions, []string{"USER"}).Return(nil)
+		mocks.queries.On("SetUserDisabled", ctx, params(true)).Return(disabled, nil)
+		mocks.sessionService.On("RevokeAllUserSessions", ctx, id).Return(nil)
+
+		result, err := service.SetDisabled(ctx, actorID, adminPermissions, id, true)
+
+		require.NoError(t, err)
+		assert.True(t, result.IsDisabled())
+	})
+
+	t.Run("enables the user", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+
+		mocks.rbacService.On("GetUserRoles", ctx, id).Return([]string{"USER"}, nil)
+		mocks.rbacService.On("CheckRolesHeld", ctx, adminPermissions, []string{"USER"}).Return(nil)
+		mocks.queries.On("SetUserDisabled", ctx, params(false)).Return(newUser(id), nil)
+
+		result, err := service.SetDisabled(ctx, actorID, adminPermissions, id, false)
+
+		require.NoError(t, err)
+		assert.False(t, result.IsDisabled())
+		mocks.sessionService.AssertNotCalled(t, "RevokeAllUserSessions", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails for an unknown user", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+
+		mocks.rbacService.On("GetUserRoles", ctx, id).Return([]string{}, nil)
+		mocks.rbacService.On("CheckRolesHeld", ctx, adminPermissions, []string{}).Return(nil)
+		mocks.queries.On("SetUserDisabled", ctx, params(true)).Return(repository.User{}, sql.ErrNoRows)
+
+		_, err := service.SetDisabled(ctx, actorID, adminPermissions, id, true)
+
+		require.ErrorIs(t, err, user.ErrUserNotFound)
+	})
+
+	t.Run("does not disable users with more permissions", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+		permissions := []string{rbac.PERMISSION_USERS_WRITE}
+
+		mocks.rbacService.On("GetUserRoles", ctx, id).Return([]string{"ADMIN"}, nil)
+		mocks.rbacService.On("CheckRolesHeld", ctx, permissions, []string{"ADMIN"}).Return(rbac.ErrRoleNotHeld)
+
+		_, err := service.SetDisabled(ctx, actorID, permissions, id, true)
+
+		require.ErrorIs(t, err, rbac.ErrRoleNotHeld)
+		mocks.queries.AssertNotCalled(t, "SetUserDisabled", mock.Anything, mock.Anything)
+	})
+
+	t.Run("does not let admins disable themselves", func(t *testing.T) {
+		_, service := setupUserAdminServiceTest(t)
+
+		_, err := service.SetDisabled(ctx, actorID, adminPermissions, actorID, true)
+
+		require.ErrorIs(t, err, userAdmin.ErrSelfModification)
+	})
+}
+
+func TestForcePasswordReset(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	id := uuid.New()
+	pgID := pgtype.UUID{Bytes: id, Valid: true}
+
+	t.Run("requires a new password and logs the user out", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+
+		mocks.rbacService.On("GetUserRoles", ctx, id).Return([]string{"USER"}, nil)
+		mocks.rbacService.On("CheckRolesHeld", ctx, adminPermissions, []string{"USER"}).Return(nil)
+		mocks.queries.On("RequireUserPasswordReset", ctx, pgID).Return(int64(1), nil)
+		mocks.sessionService.On("RevokeAllUserSessions", ctx, id).Return(nil)
+
+		require.NoError(t, service.ForcePasswordReset(ctx, actorID, adminPermissions, id))
+	})
+
+	t.Run("fails for an unknown user", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+
+		mocks.rbacService.On("GetUserRoles", ctx, id).Return([]string{}, nil)
+		mocks.rbacService.On("CheckRolesHeld", ctx, adminPermissions, []string{}).Return(nil)
+		mocks.queries.On("RequireUserPasswordReset", ctx, pgID).Return(int64(0), nil)
+
+		require.ErrorIs(t, service.ForcePasswordReset(ctx, actorID, adminPermissions, id), user.ErrUserNotFound)
+	})
+
+	t.Run("does not reset users with more permissions", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+		permissions := []string{rbac.PERMISSION_USERS_WRITE}
+
+		mocks.rbacService.On("GetUserRoles", ctx, id).Return([]string{"ADMIN"}, nil)
+		mocks.rbacService.On("CheckRolesHeld", ctx, permissions, []string{"ADMIN"}).Return(rbac.ErrRoleNotHeld)
+
+		require.ErrorIs(t, service.ForcePasswordReset(ctx, actorID, permissions, id), rbac.ErrRoleNotHeld)
+		mocks.queries.AssertNotCalled(t, "RequireUserPasswordReset", mock.Anything, mock.Anything)
+	})
+
+	t.Run("does not let admins reset themselves", func(t *testing.T) {
+		_, service := setupUserAdminServiceTest(t)
+
+		require.ErrorIs(t, service.ForcePasswordReset(ctx, actorID, adminPermissions, actorID), userAdmin.ErrSelfModification)
+	})
+}
+
+func TestDeleteUser(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	id := uuid.New()
+	pgID := pgtype.UUID{Bytes: id, Valid: true}
+
+	t.Run("deletes the user", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+
+		mocks.queries.On("GetUserById", ctx, pgID).Return(newUser(id), nil)
+		mocks.rbacService.On("GetUserRoles", ctx, id).Return([]string{"USER"}, nil)
+		mocks.rbacService.On("CheckRolesHeld", ctx, adminPermissions, []string{"USER"}).Return(nil)
+		mocks.queries.On("DeleteUser", ctx, pgID).Return(nil)
+
+		require.NoError(t, service.DeleteUser(ctx, actorID, adminPermissions, id))
+	})
+
+	t.Run("fails for an unknown user", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+
+		mocks.queries.On("GetUserById", ctx, pgID).Return(repository.User{}, sql.ErrNoRows)
+
+		require.ErrorIs(t, service.DeleteUser(ctx, actorID, adminPermissions, id), user.ErrUserNotFound)
+	})
+
+	t.Run("does not delete users with more permissions", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+		permissions := []string{rbac.PERMISSION_USERS_WRITE}
+
+		mocks.queries.On("GetUserById", ctx, pgID).Return(newUser(id), nil)
+		mocks.rbacService.On("GetUserRoles", ctx, id).Return([]string{"SUPERADMIN", "USER"}, nil)
+		mocks.rbacService.On("CheckRolesHeld", ctx, permissions, []string{"SUPERADMIN", "USER"}).Return(rbac.ErrRoleNotHeld)
+
+		require.ErrorIs(t, service.DeleteUser(ctx, actorID, permissions, id), rbac.ErrRoleNotHeld)
+		mocks.queries.AssertNotCalled(t, "DeleteUser", mock.Anything, mock.Anything)
+	})
+
+	t.Run("does not let admins delete themselves", func(t *testing.T) {
+		_, service := setupUserAdminServiceTest(t)
+
+		require.ErrorIs(t, service.DeleteUser(ctx, actorID, adminPermissions, actorID), userAdmin.ErrSelfModification)
+	})
+}
+
+func TestApproveUser(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	pgID := pgtype.UUID{Bytes: id, Valid: true}
+
+	t.Run("approves the user and sends the login link", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+
+		mocks.queries.On("ApproveUser", ctx, pgID).Return(newUser(id), nil)
+		mocks.mailer.On("Send", ctx, mock.MatchedBy(func(message *mail.Message) bool {
+			return message.To == EMAIL && strings.Contains(message.TextBody, "http://localhost:8081/login")
+		})).Return(nil)
+
+		approved, err := service.ApproveUser(ctx, id)
+
+		require.NoError(t, err)
+		assert.False(t, approved.PendingApproval)
+	})
+
+	t.Run("fails for a user that is not waiting", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+
+		mocks.queries.On("ApproveUser", ctx, pgID).Return(repository.User{}, sql.ErrNoRows)
+		mocks.queries.On("GetUserById", ctx, pgID).Return(newUser(id), nil)
+
+		_, err := service.ApproveUser(ctx, id)
+
+		require.ErrorIs(t, err, userAdmin.ErrNotPending)
+		mocks.mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails for an unknown user", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+
+		mocks.queries.On("ApproveUser", ctx, pgID).Return(repository.User{}, sql.ErrNoRows)
+		mocks.queries.On("GetUserById", ctx, pgID).Return(repository.User{}, sql.ErrNoRows)
+
+		_, err := service.ApproveUser(ctx, id)
+
+		require.ErrorIs(t, err, user.ErrUserNotFound)
+	})
+}
+
+func TestRejectUser(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	pgID := pgtype.UUID{Bytes: id, Valid: true}
+
+	t.Run("deletes the user and tells them", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+		pending := newUser(id)
+		pending.PendingApproval = true
+
+		mocks.queries.On("GetUserById", ctx, pgID).Return(pending, nil)
+		mocks.queries.On("DeleteUser", ctx, pgID).Return(nil)
+		mocks.mailer.On("Send", ctx, mock.MatchedBy(func(message *mail.Message) bool {
+			return message.To == EMAIL
+		})).Return(nil)
+
+		require.NoError(t, service.RejectUser(ctx, id))
+	})
+
+	t.Run("does not delete approved users", func(t *testing.T) {
+		mocks, service := setupUserAdminServiceTest(t)
+
+		mocks.queries.On("GetUserById", ctx, pgID).Return(newUser(id), nil)
+
+		require.ErrorIs(t, service.RejectUser(ctx, id), userAdmin.ErrNotPending)
+		mocks.queries.AssertNotCalled(t, "DeleteUser", mock.Anything, mock.Anything)
+	})
+}
@@ -81,16 +81,16 @@ func (_c *MockValidationServiceInterface_ValidateEmail_Call) RunAndReturn(run fu
 }
 
 // ValidatePassword provides a mock function for the type MockValidationServiceInterface
-func (_mock *MockValidationServiceInterface) ValidatePassword(password string) error {
-	ret := _mock.Called(password)
+func (_mock *MockValidationServiceInterface) ValidatePassword(password string, username string, email string) error {
+	ret := _mock.Called(password, username, email)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ValidatePassword")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
-		r0 = returnFunc(password)
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = returnFunc(password, username, email)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -104,13 +104,15 @@ type MockValidationServiceInterface_ValidatePassword_Call struct {
 
 // ValidatePassword is a helper method to define mock.On call
 //   - password
-func (_e *MockValidationServiceInterface_Expecter) ValidatePassword(password interface{}) *MockValidationServiceInterface_ValidatePassword_Call {
-	return &MockValidationServiceInterface_ValidatePassword_Call{Call: _e.mock.On("ValidatePassword", password)}
+//   - username
+//   - email
+func (_e *MockValidationServiceInterface_Expecter) ValidatePassword(password interface{}, username interface{}, email interface{}) *MockValidationServiceInterface_ValidatePassword_Call {
+	return &MockValidationServiceInterface_ValidatePassword_Call{Call: _e.mock.On("ValidatePassword", password, username, email)}
 }
 
-func (_c *MockValidationServiceInterface_ValidatePassword_Call) Run(run func(password string)) *MockValidationServiceInterface_ValidatePassword_Call {
+func (_c *MockValidationServiceInterface_ValidatePassword_Call) Run(run func(password string, username string, email string)) *MockValidationServiceInterface_ValidatePassword_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -120,7 +122,7 @@ func (_c *MockValidationServiceInterface_ValidatePassword_Call) Return(err error
 	return _c
 }
 
-func (_c *MockValidationServiceInterface_ValidatePassword_Call) RunAndReturn(run func(password string) error) *MockValidationServiceInterface_ValidatePassword_Call {
+func (_c *MockValidationServiceInterface_ValidatePassword_Call) RunAndReturn(run func(password string, username string, email string) error) *MockValidationServiceInterface_ValidatePassword_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,189 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/fgeck/gotth-postgres/internal/service/config"
+	"github.com/fgeck/gotth-postgres/internal/service/security/strength"
+)
+
+const (
+	// NIST SP 800-63B asks for at least 8 characters and to allow at least 64.
+	NIST_MIN_LENGTH = 8
+	NIST_MAX_LENGTH = 64
+	// Shorter parts of a username or email address are too common to ban.
+	BANNED_PART_MIN_LENGTH = 3
+)
+
+// DEFAULT_PASSWORD_POLICY is used for every value missing in the config.
+var DEFAULT_PASSWORD_POLICY = config.PasswordPolicyConfig{
+	MinLength:      8,
+	MaxLength:      128,
+	RequireUpper:   true,
+	RequireLower:   true,
+	RequireDigit:   true,
+	RequireSpecial: true,
+}
+
+// PasswordPolicyError lists every rule a password violates.
+type PasswordPolicyError struct {
+	Violations []string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return "password " + strings.Join(e.Violations, ", ")
+}
+
+func (e *PasswordPolicyError) Is(target error) bool {
+	return target == ErrInvalidPassword
+}
+
+type PasswordPolicy struct {
+	cfg             config.PasswordPolicyConfig
+	strengthService strength.StrengthServiceInterface
+}
+
+// NewPasswordPolicy falls back to DEFAULT_PASSWORD_POLICY when no length
+// limit is configured. In NIST mode the composition rules are dropped and the
+// length limits raised to at least what SP 800-63B demands. The minimum
+// strength score is only enforced with a strengthService.
+func NewPasswordPolicy(cfg config.PasswordPolicyConfig, strengthService strength.StrengthServiceInterface) *PasswordPolicy {
+	if cfg.MinLength == 0 && cfg.MaxLength == 0 && !cfg.Nist {
+		banned, minScore := cfg.BannedWords, cfg.MinScore
+		cfg = DEFAULT_PASSWORD_POLICY
+		cfg.BannedWords = banned
+		cfg.MinScore = minScore
+	}
+
+	if cfg.Nist {
+		cfg.MinLength = max(cfg.MinLength, NIST_MIN_LENGTH)
+		if cfg.MaxLength != 0 {
+			cfg.MaxLength = max(cfg.MaxLength, NIST_MAX_LENGTH)
+		}
+		cfg.RequireUpper = false
+		cfg.RequireLower = false
+		cfg.RequireDigit = false
+		cfg.RequireSpecial = false
+	}
+
+	return &PasswordPolicy{
+		cfg:             cfg,
+		strengthService: strengthService,
+	}
+}
+
+func (p *PasswordPolicy) MinScore() int {
+	return p.cfg.MinScore
+}
+
+// Check returns all violated rules. The username and the parts of the email
+// address count as banned words.
+func (p *PasswordPolicy) Check(password, username, email string) []string {
+	violations := []string{}
+
+	length := utf8.RuneCountInString(password)
+	if length < p.cfg.MinLength {
+		violations = append(violations, fmt.Sprintf("must be at least %d characters long", p.cfg.MinLength))
+	}
+	if p.cfg.MaxLength > 0 && length > p.cfg.MaxLength {
+		violations = append(violations, fmt.Sprintf("must be at most %d characters long", p.cfg.MaxLength))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSpecial bool
+	for _, char := range password {
+		switch {
+		case unicode.IsUpper(char):
+			hasUpper = true
+		case unicode.IsLower(char):
+			hasLower = true
+		case unicode.IsDigit(char):
+			hasDigit = true
+		case unicode.IsPunct(char) || unicode.IsSymbol(char):
+			hasSpecial = true
+		}
+	}
+	if p.cfg.RequireUpper && !hasUpper {
+		violations = append(violations, "must contain an uppercase letter")
+	}
+	if p.cfg.RequireLower && !hasLower {
+		violations = append(violations, "must contain a lowercase letter")
+	}
+	if p.cfg.RequireDigit && !hasDigit {
+		violations = append(violations, "must contain a number")
+	}
+	if p.cfg.RequireSpecial && !hasSpecial {
+		violations = append(violations, "must contain a special character")
+	}
+
+	if p.cfg.MaxRepeatedChars > 0 && longestRun(password) > p.cfg.MaxRepeatedChars {
+		violations = append(
+			violations,
+			fmt.Sprintf("must not repeat a character more than %d times in a row", p.cfg.MaxRepeatedChars),
+		)
+	}
+
+	lowered := strings.ToLower(password)
+	for _, word := range p.cfg.BannedWords {
+		if word != "" && strings.Contains(lowered, strings.ToLower(word)) {
+			violations = append(violations, fmt.Sprintf("must not contain %q", word))
+		}
+	}
+	for _, part := range personalParts(username, email) {
+		if strings.Contains(lowered, part) {
+			violations = append(violations, "must not contain your username or email address")
+			break
+		}
+	}
+
+	if p.cfg.MinScore > 0 && p.strengthService != nil {
+		if estimate := p.strengthService.Estimate(password, username, email); estimate.Score < p.cfg.MinScore {
+			violations = append(violations, "must be harder to guess")
+		}
+	}
+
+	return violations
+}
+
+func longestRun(password string) int {
+	longest, current := 0, 0
+	var previous rune
+	for i, char := range []rune(password) {
+		if i > 0 && char == previous {
+			current++
+		} else {
+			current = 1
+		}
+		previous = char
+		longest = max(longest, current)
+	}
+
+	return longest
+}
+
+// personalParts splits the username and email address into lowercase words
+// that are long enough to be meaningful. The top level domain is skipped,
+// banning "com" would reject far too many passwords.
+func personalParts(username, email string) []string {
+	isSeparator := func(char rune) bool {
+		return !unicode.IsLetter(char) && !unicode.IsDigit(char)
+	}
+
+	email = strings.ToLower(email)
+	if i := strings.LastIndex(email, "."); i > strings.LastIndex(email, "@") {
+		email = email[:i]
+	}
+
+	parts := []string{}
+	for _, value := range []string{strings.ToLower(username), email} {
+		for _, part := range strings.FieldsFunc(value, isSeparator) {
+			if utf8.RuneCountInString(part) >= BANNED_PART_MIN_LENGTH {
+				parts = append(parts, part)
+			}
+		}
+	}
+
+	return parts
+}
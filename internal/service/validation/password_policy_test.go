@@ -0,0 +1,157 @@
+//go:build unittest
+
+package validation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fgeck/gotth-postgres/internal/service/config"
+	passwordMocks "github.com/fgeck/gotth-postgres/internal/service/security/password/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/security/strength"
+	strengthMocks "github.com/fgeck/gotth-postgres/internal/service/security/strength/mocks"
+	validation "github.com/fgeck/gotth-postgres/internal/service/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	USERNAME = "johndoe"
+	EMAIL    = "john.doe@example.com"
+)
+
+func TestPasswordPolicyCheck(t *testing.T) {
+	t.Run("reports every violation at once", func(t *testing.T) {
+		policy := validation.NewPasswordPolicy(config.PasswordPolicyConfig{}, nil)
+
+		violations := policy.Check("abc", USERNAME, EMAIL)
+
+		assert.Equal(t, []string{
+			"must be at least 8 characters long",
+			"must contain an uppercase letter",
+			"must contain a number",
+			"must contain a special character",
+		}, violations)
+	})
+
+	t.Run("accepts a password that meets the default policy", func(t *testing.T) {
+		policy := validation.NewPasswordPolicy(config.PasswordPolicyConfig{}, nil)
+
+		assert.Empty(t, policy.Check("SuperVal!d1@", USERNAME, EMAIL))
+	})
+
+	t.Run("enforces the maximum length", func(t *testing.T) {
+		policy := validation.NewPasswordPolicy(config.PasswordPolicyConfig{MinLength: 4, MaxLength: 6}, nil)
+
+		assert.Equal(t, []string{"must be at most 6 characters long"}, policy.Check("abcdefg", USERNAME, EMAIL))
+	})
+
+	t.Run("rejects long runs of the same character", func(t *testing.T) {
+		policy := validation.NewPasswordPolicy(config.PasswordPolicyConfig{MinLength: 8, MaxRepeatedChars: 3}, nil)
+
+		assert.Empty(t, policy.Check("aaabbbccc", USERNAME, EMAIL))
+		assert.Equal(
+			t,
+			[]string{"must not repeat a character more than 3 times in a row"},
+			policy.Check("aaaabbbccc", USERNAME, EMAIL),
+		)
+	})
+
+	t.Run("rejects banned words case insensitively", func(t *testing.T) {
+		policy := validation.NewPasswordPolicy(config.PasswordPolicyConfig{MinLength: 8, BannedWords: []string{"password"}}, nil)
+
+		assert.Equal(t, []string{`must not contain "password"`}, policy.Check("MyPassWord99", USERNAME, EMAIL))
+	})
+
+	t.Run("rejects the username and parts of the email address", func(t *testing.T) {
+		policy := validation.NewPasswordPolicy(config.PasswordPolicyConfig{MinLength: 8}, nil)
+		violation := []string{"must not contain your username or email address"}
+
+		assert.Equal(t, violation, policy.Check("xJohnDoe-2024", USERNAME, EMAIL))
+		assert.Equal(t, violation, policy.Check("hello-example", USERNAME, EMAIL))
+		assert.Empty(t, policy.Check("welcome.company", USERNAME, EMAIL))
+	})
+
+	t.Run("nist mode drops the composition rules", func(t *testing.T) {
+		policy := validation.NewPasswordPolicy(config.PasswordPolicyConfig{MinLength: 4, MaxLength: 20, RequireUpper: true, Nist: true}, nil)
+
+		assert.Equal(t, []string{"must be at least 8 characters long"}, policy.Check("short", USERNAME, EMAIL))
+		// The maximum length is raised to the 64 characters NIST asks for.
+		assert.Empty(t, policy.Check("correct horse battery staple", USERNAME, EMAIL))
+	})
+}
+
+func TestPasswordPolicyMinScore(t *testing.T) {
+	cfg := config.PasswordPolicyConfig{MinLength: 8, MinScore: 3}
+
+	t.Run("rejects passwords below the minimum score", func(t *testing.T) {
+		strengthService := strengthMocks.NewMockStrengthServiceInterface(t)
+		strengthService.On("Estimate", "summer1987", USERNAME, EMAIL).Return(&strength.Estimate{Score: 2})
+		policy := validation.NewPasswordPolicy(cfg, strengthService)
+
+		assert.Equal(t, []string{"must be harder to guess"}, policy.Check("summer1987", USERNAME, EMAIL))
+	})
+
+	t.Run("accepts passwords reaching the minimum score", func(t *testing.T) {
+		strengthService := strengthMocks.NewMockStrengthServiceInterface(t)
+		strengthService.On("Estimate", "summer1987", USERNAME, EMAIL).Return(&strength.Estimate{Score: 3})
+		policy := validation.NewPasswordPolicy(cfg, strengthService)
+
+		assert.Empty(t, policy.Check("summer1987", USERNAME, EMAIL))
+	})
+
+	t.Run("keeps the minimum score when falling back to the defaults", func(t *testing.T) {
+		policy := validation.NewPasswordPolicy(config.PasswordPolicyConfig{MinScore: 3}, strength.NewStrengthService())
+
+		assert.Equal(t, 3, policy.MinScore())
+		assert.Contains(t, policy.Check("Password1!", USERNAME, EMAIL), "must be harder to guess")
+	})
+}
+
+func TestValidatePasswordReturnsPolicyError(t *testing.T) {
+	vs := validation.NewValidationService(validation.NewPasswordPolicy(config.PasswordPolicyConfig{}, nil), nil)
+
+	err := vs.ValidatePassword("johndoe", USERNAME, EMAIL)
+
+	var policyErr *validation.PasswordPolicyError
+	require.ErrorAs(t, err, &policyErr)
+	assert.ErrorIs(t, err, validation.ErrInvalidPassword)
+	assert.Contains(t, policyErr.Violations, "must not contain your username or email address")
+}
+
+func TestValidatePasswordChecksBreaches(t *testing.T) {
+	t.Run("reports a breached password with the policy violations", func(t *testing.T) {
+		breachChecker := passwordMocks.NewMockBreachCheckerInterface(t)
+		vs := validation.NewValidationService(validation.NewPasswordPolicy(config.PasswordPolicyConfig{}, nil), breachChecker)
+		breachChecker.On("IsBreached", "password1").Return(true, nil)
+
+		err := vs.ValidatePassword("password1", USERNAME, EMAIL)
+
+		var policyErr *validation.PasswordPolicyError
+		require.ErrorAs(t, err, &policyErr)
+		assert.Equal(t, []string{
+			"must contain an uppercase letter",
+			"must contain a special character",
+			validation.BREACHED_PASSWORD_VIOLATION,
+		}, policyErr.Violations)
+	})
+
+	t.Run("accepts a password that was not breached", func(t *testing.T) {
+		breachChecker := passwordMocks.NewMockBreachCheckerInterface(t)
+		vs := validation.NewValidationService(validation.NewPasswordPolicy(config.PasswordPolicyConfig{}, nil), breachChecker)
+		breachChecker.On("IsBreached", "SuperVal!d1@").Return(false, nil)
+
+		require.NoError(t, vs.ValidatePassword("SuperVal!d1@", USERNAME, EMAIL))
+	})
+
+	t.Run("fails when the index cannot be read", func(t *testing.T) {
+		breachChecker := passwordMocks.NewMockBreachCheckerInterface(t)
+		vs := validation.NewValidationService(validation.NewPasswordPolicy(config.PasswordPolicyConfig{}, nil), breachChecker)
+		breachChecker.On("IsBreached", "SuperVal!d1@").Return(false, errors.New("read error"))
+
+		err := vs.ValidatePassword("SuperVal!d1@", USERNAME, EMAIL)
+
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, validation.ErrInvalidPassword)
+	})
+}
@@ -4,41 +4,54 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 	"unicode"
+
+	"github.com/fgeck/gotth-postgres/internal/service/security/password"
 )
 
 const (
-	PASSWORD_MIN_LENGTH = 8
 	USERNAME_MIN_LENGTH = 3
 	USERNAME_MAX_LENGTH = 30
 	USERNAME_REGEX      = `^[a-zA-Z0-9]+$`
 	EMAIL_REGEX         = `^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`
+
+	// RESERVED_EMAIL_TLD never receives mail (RFC 2606). Service accounts
+	// get addresses below it, which nobody else may claim.
+	RESERVED_EMAIL_TLD = ".invalid"
+
+	BREACHED_PASSWORD_VIOLATION = "must not appear in a known data breach"
 )
 
 var (
-	ErrInvalidEmailFormat = errors.New("invalid email format")
-	ErrInvalidUsername    = fmt.Errorf(
+	ErrInvalidEmailFormat  = errors.New("invalid email format")
+	ErrReservedEmailDomain = errors.New("email addresses of reserved domains cannot be used")
+	ErrInvalidUsername     = fmt.Errorf(
 		"username can only contain letters and numbers and must be between %d and %d characters long",
 		USERNAME_MIN_LENGTH,
 		USERNAME_MAX_LENGTH,
 	)
-	ErrInvalidPassword = fmt.Errorf(
-		"password must be at least %d characters long and include at least 1 uppercase letter, "+
-			"1 lowercase letter, 1 number, and 1 special character",
-		PASSWORD_MIN_LENGTH,
-	)
+	ErrInvalidPassword = errors.New("password does not meet the password policy")
 )
 
 type ValidationServiceInterface interface {
 	ValidateEmail(email string) error
-	ValidatePassword(password string) error
+	ValidatePassword(password, username, email string) error
 	ValidateUsername(username string) error
 }
 
-type ValidationService struct{}
+type ValidationService struct {
+	passwordPolicy *PasswordPolicy
+	breachChecker  password.BreachCheckerInterface
+}
 
-func NewValidationService() *ValidationService {
-	return &ValidationService{}
+// NewValidationService skips the breached password check when breachChecker
+// is nil.
+func NewValidationService(passwordPolicy *PasswordPolicy, breachChecker password.BreachCheckerInterface) *ValidationService {
+	return &ValidationService{
+		passwordPolicy: passwordPolicy,
+		breachChecker:  breachChecker,
+	}
 }
 
 func (v *ValidationService) ValidateEmail(email string) error {
@@ -46,32 +59,31 @@ func (v *ValidationService) ValidateEmail(email string) error {
 	if !matched {
 		return ErrInvalidEmailFormat
 	}
+	if strings.HasSuffix(strings.ToLower(email), RESERVED_EMAIL_TLD) {
+		return ErrReservedEmailDomain
+	}
 
 	return nil
 }
 
-func (v *ValidationService) ValidatePassword(password string) error {
-	var hasMinLen, hasUpper, hasLower, hasNumber, hasSpecial bool
+// ValidatePassword checks the password against the configured policy and the
+// breached password index and returns a PasswordPolicyError listing every
+// violated rule.
+func (v *ValidationService) ValidatePassword(password, username, email string) error {
+	violations := v.passwordPolicy.Check(password, username, email)
 
-	if len(password) >= PASSWORD_MIN_LENGTH {
-		hasMinLen = true
-	}
-
-	for _, char := range password {
-		switch {
-		case unicode.IsUpper(char):
-			hasUpper = true
-		case unicode.IsLower(char):
-			hasLower = true
-		case unicode.IsDigit(char):
-			hasNumber = true
-		case unicode.IsPunct(char) || unicode.IsSymbol(char):
-			hasSpecial = true
+	if v.breachChecker != nil {
+		breached, err := v.breachChecker.IsBreached(password)
+		if err != nil {
+			return fmt.Errorf("failed to check for breached password: %w", err)
+		}
+		if breached {
+			violations = append(violations, BREACHED_PASSWORD_VIOLATION)
 		}
 	}
 
-	if !hasMinLen || !hasUpper || !hasLower || !hasNumber || !hasSpecial {
-		return ErrInvalidPassword
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
 	}
 
 	return nil
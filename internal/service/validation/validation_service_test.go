@@ -5,13 +5,14 @@ package validation_test
 import (
 	"testing"
 
+	"github.com/fgeck/gotth-postgres/internal/service/config"
 	validation "github.com/fgeck/gotth-postgres/internal/service/validation"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestValidateEmail(t *testing.T) {
-	vs := validation.NewValidationService()
+	vs := validation.NewValidationService(validation.NewPasswordPolicy(config.PasswordPolicyConfig{}, nil), nil)
 
 	tests := []struct {
 		email    string
@@ -20,6 +21,8 @@ func TestValidateEmail(t *testing.T) {
 		{"valid.email@example.com", nil},
 		{"invalid-email", validation.ErrInvalidEmailFormat},
 		{"", validation.ErrInvalidEmailFormat},
+		{"deploybot@service-accounts.invalid", validation.ErrReservedEmailDomain},
+		{"someone@example.INVALID", validation.ErrReservedEmailDomain},
 	}
 
 	for _, test := range tests {
@@ -34,7 +37,7 @@ func TestValidateEmail(t *testing.T) {
 }
 
 func TestValidatePassword(t *testing.T) {
-	vs := validation.NewValidationService()
+	vs := validation.NewValidationService(validation.NewPasswordPolicy(config.PasswordPolicyConfig{}, nil), nil)
 
 	tests := []struct {
 		password string
@@ -48,18 +51,18 @@ func TestValidatePassword(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		err := vs.ValidatePassword(test.password)
+		err := vs.ValidatePassword(test.password, "testuser", "testuser@example.com")
 		if test.expected == nil {
 			require.NoError(t, err, "expected no error for password: %s", test.password)
 		} else {
 			require.Error(t, err, "expected an error for password: %s", test.password)
-			assert.Equal(t, test.expected, err, "unexpected error for password: %s", test.password)
+			assert.ErrorIs(t, err, test.expected, "unexpected error for password: %s", test.password)
 		}
 	}
 }
 
 func TestValidateUsername(t *testing.T) {
-	vs := validation.NewValidationService()
+	vs := validation.NewValidationService(validation.NewPasswordPolicy(config.PasswordPolicyConfig{}, nil), nil)
 
 	tests := []struct {
 		username string
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/fgeck/gotth-postgres/internal/service/account"
+	"github.com/fgeck/gotth-postgres/internal/service/loginRegister"
+	"github.com/fgeck/gotth-postgres/internal/service/passwordHistory"
+	"github.com/fgeck/gotth-postgres/internal/service/render"
+	"github.com/fgeck/gotth-postgres/internal/service/validation"
+	"github.com/fgeck/gotth-postgres/templates/views"
+	"github.com/google/uuid"
+	echo "github.com/labstack/echo/v4"
+)
+
+type AccountHandler struct {
+	accountService       account.AccountServiceInterface
+	loginRegisterService loginRegister.LoginRegisterServiceInterface
+}
+
+func NewAccountHandler(
+	accountService account.AccountServiceInterface,
+	loginRegisterService loginRegister.LoginRegisterServiceInterface,
+) *AccountHandler {
+	return &AccountHandler{
+		accountService:       accountService,
+		loginRegisterService: loginRegisterService,
+	}
+}
+
+// AccountPageHandler shows the account settings of the logged in user. Links
+// to other pages follow the permissions of the current access token.
+func (h *AccountHandler) AccountPageHandler(ctx echo.Context) error {
+	claims, err := currentClaims(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	userID, err := uuid.Parse(claims.UserId)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+
+	userDto, err := h.accountService.GetAccount(ctx.Request().Context(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+	userDto.Permissions = claims.Permissions
+
+	if err := render.Render(ctx, views.Account(userDto)); err != nil {
+		return fmt.Errorf("failed to render account view: %w", err)
+	}
+
+	return nil
+}
+
+func (h *AccountHandler) UpdateProfileHandler(ctx echo.Context) error {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+
+	email := strings.TrimSpace(ctx.FormValue("email"))
+	userDto, err := h.accountService.UpdateProfile(
+		ctx.Request().Context(),
+		userID,
+		strings.TrimSpace(ctx.FormValue("username")),
+		email,
+		ctx.FormValue("currentPassword"),
+	)
+	if err != nil {
+		return h.sendError(ctx, "failed to update profile", err)
+	}
+
+	message := "Your profile was saved."
+	if userDto.Email != email {
+		message += " Your email address changes once you confirm it with the link we sent to " + email + "."
+	}
+
+	return ctx.String(http.StatusOK, message)
+}
+
+// ChangePasswordHandler keeps the calling device logged in and logs out all
+// others. The change revokes every access token, including the one of this
+// request, so the calling session is refreshed right away.
+func (h *AccountHandler) ChangePasswordHandler(ctx echo.Context) error {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	refreshToken := cookieValue(ctx, REFRESH_TOKEN_COOKIE)
+
+	err = h.accountService.ChangePassword(
+		ctx.Request().Context(),
+		userID,
+		ctx.FormValue("currentPassword"),
+		ctx.FormValue("password"),
+		refreshToken,
+	)
+	if err != nil {
+		return h.sendError(ctx, "failed to change password", err)
+	}
+
+	tokens, err := h.loginRegisterService.RefreshTokens(ctx.Request().Context(), refreshToken)
+	if err != nil {
+		clearAuthCookies(ctx)
+		if stringErr := ctx.String(http.StatusOK, "Your password was changed. Please log in again."); stringErr != nil {
+			return fmt.Errorf("failed to send success response: %w", stringErr)
+		}
+
+		return fmt.Errorf("failed to refresh session after password change: %w", err)
+	}
+	setAuthCookies(ctx, tokens)
+
+	return ctx.String(http.StatusOK, "Your password was changed. All other devices were logged out.")
+}
+
+func (h *AccountHandler) sendError(ctx echo.Context, action string, err error) error {
+	status := http.StatusInternalServerError
+	message := "Something went wrong"
+	var policyErr *validation.PasswordPolicyError
+	switch {
+	case errors.As(err, &policyErr):
+		status = http.StatusBadRequest
+		message = "Password " + strings.Join(policyErr.Violations, ", ") + "."
+	case errors.Is(err, passwordHistory.ErrPasswordReused):
+		status = http.StatusBadRequest
+		message = "You have used this password before, please choose a new one."
+	case errors.Is(err, account.ErrInvalidCurrentPassword):
+		status = http.StatusForbidden
+		message = "Your current password is incorrect."
+	case errors.Is(err, account.ErrUsernameTaken):
+		status = http.StatusConflict
+		message = "This username is already taken."
+	case errors.Is(err, validation.ErrInvalidUsername),
+		errors.Is(err, validation.ErrInvalidEmailFormat),
+		errors.Is(err, validation.ErrReservedEmailDomain):
+		status = http.StatusBadRequest
+		message = err.Error()
+	}
+
+	wrappedErr := fmt.Errorf("%s: %w", action, err)
+	if stringErr := ctx.String(status, message); stringErr != nil {
+		return fmt.Errorf("failed to send error response: %w", stringErr)
+	}
+
+	return wrappedErr
+}
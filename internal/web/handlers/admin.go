@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/fgeck/gotth-postgres/internal/service/loginThrottle"
+	"github.com/fgeck/gotth-postgres/internal/service/rbac"
+	"github.com/fgeck/gotth-postgres/internal/service/session"
+	"github.com/fgeck/gotth-postgres/internal/service/userAdmin"
+	"github.com/google/uuid"
+	echo "github.com/labstack/echo/v4"
+)
+
+type AdminHandler struct {
+	sessionService       session.SessionServiceInterface
+	loginThrottleService loginThrottle.LoginThrottleServiceInterface
+	userAdminService     userAdmin.UserAdminServiceInterface
+	rbacService          rbac.RbacServiceInterface
+}
+
+func NewAdminHandler(
+	sessionService session.SessionServiceInterface,
+	loginThrottleService loginThrottle.LoginThrottleServiceInterface,
+	userAdminService userAdmin.UserAdminServiceInterface,
+	rbacService rbac.RbacServiceInterface,
+) *AdminHandler {
+	return &AdminHandler{
+		sessionService:       sessionService,
+		loginThrottleService: loginThrottleService,
+		userAdminService:     userAdminService,
+		rbacService:          rbacService,
+	}
+}
+
+func (h *AdminHandler) RevokeUserSessionsHandler(ctx echo.Context) error {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid user id"})
+	}
+
+	if err := h.sessionService.RevokeAllUserSessions(ctx.Request().Context(), userID); err != nil {
+		wrappedErr := fmt.Errorf("failed to revoke user sessions: %w", err)
+		jsonErr := ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to revoke user sessions"})
+		if jsonErr != nil {
+			return fmt.Errorf("failed to send error response: %w", jsonErr)
+		}
+
+		return wrappedErr
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// ListLockoutsHandler returns all accounts and client IPs that are currently
+// blocked from logging in.
+func (h *AdminHandler) ListLockoutsHandler(ctx echo.Context) error {
+	lockouts, err := h.loginThrottleService.ListLocked(ctx.Request().Context())
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to list lockouts: %w", err)
+		jsonErr := ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to list lockouts"})
+		if jsonErr != nil {
+			return fmt.Errorf("failed to send error response: %w", jsonErr)
+		}
+
+		return wrappedErr
+	}
+
+	return ctx.JSON(http.StatusOK, lockouts)
+}
+
+// ClearLockoutHandler lifts the lockout given by the scope ("account" or "ip")
+// and subject (email or IP address) query parameters.
+func (h *AdminHandler) ClearLockoutHandler(ctx echo.Context) error {
+	err := h.loginThrottleService.Clear(ctx.Request().Context(), ctx.QueryParam("scope"), ctx.QueryParam("subject"))
+	if err == nil {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	status := http.StatusInternalServerError
+	message := "Failed to clear lockout"
+	switch {
+	case errors.Is(err, loginThrottle.ErrUnknownScope):
+		status = http.StatusBadRequest
+		message = "Invalid scope"
+	case errors.Is(err, loginThrottle.ErrThrottleNotFound):
+		status = http.StatusNotFound
+		message = "Lockout not found"
+	}
+
+	wrappedErr := fmt.Errorf("failed to clear lockout: %w", err)
+	if jsonErr := ctx.JSON(status, map[string]string{"error": message}); jsonErr != nil {
+		return fmt.Errorf("failed to send error response: %w", jsonErr)
+	}
+
+	return wrappedErr
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/fgeck/gotth-postgres/internal/service/render"
+	"github.com/fgeck/gotth-postgres/templates/views/admin"
+	"github.com/google/uuid"
+	echo "github.com/labstack/echo/v4"
+)
+
+// UsersPageHandler renders the admin console. The other console handlers
+// answer htmx requests with the changed part of the page.
+func (h *AdminHandler) UsersPageHandler(ctx echo.Context) error {
+	actorID, err := currentUserID(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	filter := userFilterFromQuery(ctx)
+
+	page, err := h.userAdminService.ListUsers(ctx.Request().Context(), filter)
+	if err != nil {
+		return h.sendConsoleError(ctx, "failed to list users", err)
+	}
+
+	if err := render.Render(ctx, admin.Users(page, filter, actorID)); err != nil {
+		return fmt.Errorf("failed to render admin users view: %w", err)
+	}
+
+	return nil
+}
+
+func (h *AdminHandler) UsersTableHandler(ctx echo.Context) error {
+	actorID, err := currentUserID(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	filter := userFilterFromQuery(ctx)
+
+	page, err := h.userAdminService.ListUsers(ctx.Request().Context(), filter)
+	if err != nil {
+		return h.sendConsoleError(ctx, "failed to list users", err)
+	}
+
+	if err := render.Render(ctx, admin.UserTable(page, filter, actorID)); err != nil {
+		return fmt.Errorf("failed to render admin user table: %w", err)
+	}
+
+	return nil
+}
+
+func (h *AdminHandler) UpdateUserRoleRowHandler(ctx echo.Context) error {
+	claims, err := currentClaims(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	actorID, err := uuid.Parse(claims.UserId)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.String(http.StatusBadRequest, "Invalid user id")
+	}
+
+	updated, err := h.userAdminService.UpdateRole(ctx.Request().Context(), actorID, claims.Permissions, userID, ctx.FormValue("role"))
+	if err != nil {
+		return h.sendConsoleError(ctx, "failed to update role", err)
+	}
+
+	if err := render.Render(ctx, admin.UserRow(updated, actorID)); err != nil {
+		return fmt.Errorf("failed to render admin user row: %w", err)
+	}
+
+	return nil
+}
+
+func (h *AdminHandler) DisableUserRowHandler(ctx echo.Context) error {
+	return h.setUserDisabledRow(ctx, true)
+}
+
+func (h *AdminHandler) EnableUserRowHandler(ctx echo.Context) error {
+	return h.setUserDisabledRow(ctx, false)
+}
+
+func (h *AdminHandler) setUserDisabledRow(ctx echo.Context, disabled bool) error {
+	claims, err := currentClaims(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	actorID, err := uuid.Parse(claims.UserId)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.String(http.StatusBadRequest, "Invalid user id")
+	}
+
+	updated, err := h.userAdminService.SetDisabled(ctx.Request().Context(), actorID, claims.Permissions, userID, disabled)
+	if err != nil {
+		return h.sendConsoleError(ctx, "failed to update user status", err)
+	}
+
+	if err := render.Render(ctx, admin.UserRow(updated, actorID)); err != nil {
+		return fmt.Errorf("failed to render admin user row: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteUserRowHandler answers with an empty 200 instead of a 204, since htmx
+// does not swap the row away on a 204.
+func (h *AdminHandler) DeleteUserRowHandler(ctx echo.Context) error {
+	claims, err := currentClaims(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	actorID, err := uuid.Parse(claims.UserId)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.String(http.StatusBadRequest, "Invalid user id")
+	}
+
+	if err := h.userAdminService.DeleteUser(ctx.Request().Context(), actorID, claims.Permissions, userID); err != nil {
+		return h.sendConsoleError(ctx, "failed to delete user", err)
+	}
+
+	return ctx.String(http.StatusOK, "")
+}
+
+func (h *AdminHandler) sendConsoleError(ctx echo.Context, action string, err error) error {
+	status, message := userAdminErrorResponse(err)
+
+	wrappedErr := fmt.Errorf("%s: %w", action, err)
+	if stringErr := ctx.String(status, message); stringErr != nil {
+		return fmt.Errorf("failed to send error response: %w", stringErr)
+	}
+
+	return wrappedErr
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/fgeck/gotth-postgres/internal/service/rbac"
+	echo "github.com/labstack/echo/v4"
+)
+
+type createRoleRequest struct {
+	Name        string   `json:"name" form:"name"`
+	Description string   `json:"description" form:"description"`
+	Permissions []string `json:"permissions" form:"permissions"`
+}
+
+type updateRolePermissionsRequest struct {
+	Permissions []string `json:"permissions" form:"permissions"`
+}
+
+func (h *AdminHandler) ListRolesHandler(ctx echo.Context) error {
+	roles, err := h.rbacService.ListRoles(ctx.Request().Context())
+	if err != nil {
+		return h.sendRoleError(ctx, "failed to list roles", err)
+	}
+
+	return ctx.JSON(http.StatusOK, roles)
+}
+
+func (h *AdminHandler) ListPermissionsHandler(ctx echo.Context) error {
+	permissions, err := h.rbacService.ListPermissions(ctx.Request().Context())
+	if err != nil {
+		return h.sendRoleError(ctx, "failed to list permissions", err)
+	}
+
+	return ctx.JSON(http.StatusOK, permissions)
+}
+
+func (h *AdminHandler) CreateRoleHandler(ctx echo.Context) error {
+	claims, err := currentClaims(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	var request createRoleRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid role"})
+	}
+
+	role, err := h.rbacService.CreateRole(
+		ctx.Request().Context(),
+		claims.Permissions,
+		request.Name,
+		request.Description,
+		request.Permissions,
+	)
+	if err != nil {
+		return h.sendRoleError(ctx, "failed to create role", err)
+	}
+
+	return ctx.JSON(http.StatusCreated, role)
+}
+
+// UpdateRolePermissionsHandler replaces the permissions of a role. Users with
+// the role get them with their next token refresh.
+func (h *AdminHandler) UpdateRolePermissionsHandler(ctx echo.Context) error {
+	claims, err := currentClaims(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	var request updateRolePermissionsRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid permissions"})
+	}
+
+	role, err := h.rbacService.SetRolePermissions(
+		ctx.Request().Context(),
+		claims.Permissions,
+		ctx.Param("name"),
+		request.Permissions,
+	)
+	if err != nil {
+		return h.sendRoleError(ctx, "failed to update role permissions", err)
+	}
+
+	return ctx.JSON(http.StatusOK, role)
+}
+
+func (h *AdminHandler) DeleteRoleHandler(ctx echo.Context) error {
+	if err := h.rbacService.DeleteRole(ctx.Request().Context(), ctx.Param("name")); err != nil {
+		return h.sendRoleError(ctx, "failed to delete role", err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (h *AdminHandler) sendRoleError(ctx echo.Context, action string, err error) error {
+	status := http.StatusInternalServerError
+	message := "Something went wrong"
+	switch {
+	case errors.Is(err, rbac.ErrUnknownRole):
+		status = http.StatusNotFound
+		message = "Role not found"
+	case errors.Is(err, rbac.ErrRoleExists):
+		status = http.StatusConflict
+		message = "A role with this name already exists"
+	case errors.Is(err, rbac.ErrRoleInUse):
+		status = http.StatusConflict
+		message = err.Error()
+	case errors.Is(err, rbac.ErrBuiltInRole),
+		errors.Is(err, rbac.ErrPermissionNotHeld):
+		status = http.StatusForbidden
+		message = err.Error()
+	case errors.Is(err, rbac.ErrInvalidRoleName),
+		errors.Is(err, rbac.ErrUnknownPermission):
+		status = http.StatusBadRequest
+		message = err.Error()
+	}
+
+	wrappedErr := fmt.Errorf("%s: %w", action, err)
+	if jsonErr := ctx.JSON(status, map[string]string{"error": message}); jsonErr != nil {
+		return fmt.Errorf("failed to send error response: %w", jsonErr)
+	}
+
+	return wrappedErr
+}
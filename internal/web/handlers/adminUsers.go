@@ -0,0 +1,299 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/fgeck/gotth-postgres/internal/service/rbac"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	"github.com/fgeck/gotth-postgres/internal/service/userAdmin"
+	"github.com/fgeck/gotth-postgres/internal/service/validation"
+	"github.com/google/uuid"
+	echo "github.com/labstack/echo/v4"
+)
+
+type createUserRequest struct {
+	Username string `json:"username" form:"username"`
+	Email    string `json:"email" form:"email"`
+	Password string `json:"password" form:"password"`
+	Role     string `json:"role" form:"role"`
+}
+
+type updateRoleRequest struct {
+	Role string `json:"role" form:"role"`
+}
+
+type updateRolesRequest struct {
+	Roles []string `json:"roles" form:"roles"`
+}
+
+// ListUsersHandler supports the search, role, status ("active", "disabled" or
+// "pending"), page and pageSize query parameters.
+func (h *AdminHandler) ListUsersHandler(ctx echo.Context) error {
+	users, err := h.userAdminService.ListUsers(ctx.Request().Context(), userFilterFromQuery(ctx))
+	if err != nil {
+		return h.sendUserAdminError(ctx, "failed to list users", err)
+	}
+
+	return ctx.JSON(http.StatusOK, users)
+}
+
+func (h *AdminHandler) GetUserHandler(ctx echo.Context) error {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid user id"})
+	}
+
+	userDto, err := h.userAdminService.GetUser(ctx.Request().Context(), userID)
+	if err != nil {
+		return h.sendUserAdminError(ctx, "failed to get user", err)
+	}
+
+	return ctx.JSON(http.StatusOK, userDto)
+}
+
+func (h *AdminHandler) CreateUserHandler(ctx echo.Context) error {
+	claims, err := currentClaims(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	var request createUserRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid user"})
+	}
+
+	created, err := h.userAdminService.CreateUser(
+		ctx.Request().Context(),
+		claims.Permissions,
+		request.Username,
+		request.Email,
+		request.Password,
+		request.Role,
+	)
+	if err != nil {
+		return h.sendUserAdminError(ctx, "failed to create user", err)
+	}
+
+	return ctx.JSON(http.StatusCreated, created)
+}
+
+func (h *AdminHandler) UpdateUserRoleHandler(ctx echo.Context) error {
+	claims, err := currentClaims(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	actorID, err := uuid.Parse(claims.UserId)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid user id"})
+	}
+	var request updateRoleRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid role"})
+	}
+
+	updated, err := h.userAdminService.UpdateRole(ctx.Request().Context(), actorID, claims.Permissions, userID, request.Role)
+	if err != nil {
+		return h.sendUserAdminError(ctx, "failed to update role", err)
+	}
+
+	return ctx.JSON(http.StatusOK, updated)
+}
+
+// UpdateUserRolesHandler replaces the roles a user has on top of their base
+// role.
+func (h *AdminHandler) UpdateUserRolesHandler(ctx echo.Context) error {
+	claims, err := currentClaims(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	actorID, err := uuid.Parse(claims.UserId)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid user id"})
+	}
+	var request updateRolesRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid roles"})
+	}
+
+	updated, err := h.userAdminService.UpdateRoles(ctx.Request().Context(), actorID, claims.Permissions, userID, request.Roles)
+	if err != nil {
+		return h.sendUserAdminError(ctx, "failed to update roles", err)
+	}
+
+	return ctx.JSON(http.StatusOK, updated)
+}
+
+func (h *AdminHandler) DisableUserHandler(ctx echo.Context) error {
+	return h.setUserDisabled(ctx, true)
+}
+
+func (h *AdminHandler) EnableUserHandler(ctx echo.Context) error {
+	return h.setUserDisabled(ctx, false)
+}
+
+func (h *AdminHandler) setUserDisabled(ctx echo.Context, disabled bool) error {
+	claims, err := currentClaims(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	actorID, err := uuid.Parse(claims.UserId)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid user id"})
+	}
+
+	updated, err := h.userAdminService.SetDisabled(ctx.Request().Context(), actorID, claims.Permissions, userID, disabled)
+	if err != nil {
+		return h.sendUserAdminError(ctx, "failed to update user status", err)
+	}
+
+	return ctx.JSON(http.StatusOK, updated)
+}
+
+func (h *AdminHandler) ForcePasswordResetHandler(ctx echo.Context) error {
+	claims, err := currentClaims(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	actorID, err := uuid.Parse(claims.UserId)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid user id"})
+	}
+
+	if err := h.userAdminService.ForcePasswordReset(ctx.Request().Context(), actorID, claims.Permissions, userID); err != nil {
+		return h.sendUserAdminError(ctx, "failed to force password reset", err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (h *AdminHandler) DeleteUserHandler(ctx echo.Context) error {
+	claims, err := currentClaims(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	actorID, err := uuid.Parse(claims.UserId)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid user id"})
+	}
+
+	if err := h.userAdminService.DeleteUser(ctx.Request().Context(), actorID, claims.Permissions, userID); err != nil {
+		return h.sendUserAdminError(ctx, "failed to delete user", err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// ApproveUserHandler lets an account that registered in approval mode log in.
+func (h *AdminHandler) ApproveUserHandler(ctx echo.Context) error {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid user id"})
+	}
+
+	approved, err := h.userAdminService.ApproveUser(ctx.Request().Context(), userID)
+	if err != nil {
+		return h.sendUserAdminError(ctx, "failed to approve user", err)
+	}
+
+	return ctx.JSON(http.StatusOK, approved)
+}
+
+// RejectUserHandler deletes an account that is waiting for approval.
+func (h *AdminHandler) RejectUserHandler(ctx echo.Context) error {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid user id"})
+	}
+
+	if err := h.userAdminService.RejectUser(ctx.Request().Context(), userID); err != nil {
+		return h.sendUserAdminError(ctx, "failed to reject user", err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (h *AdminHandler) sendUserAdminError(ctx echo.Context, action string, err error) error {
+	status, message := userAdminErrorResponse(err)
+
+	wrappedErr := fmt.Errorf("%s: %w", action, err)
+	if jsonErr := ctx.JSON(status, map[string]string{"error": message}); jsonErr != nil {
+		return fmt.Errorf("failed to send error response: %w", jsonErr)
+	}
+
+	return wrappedErr
+}
+
+// userAdminErrorResponse is shared by the admin API and the admin console.
+func userAdminErrorResponse(err error) (int, string) {
+	status := http.StatusInternalServerError
+	message := "Something went wrong"
+	var policyErr *validation.PasswordPolicyError
+	switch {
+	case errors.Is(err, user.ErrUserNotFound):
+		status = http.StatusNotFound
+		message = "User not found"
+	case errors.Is(err, userAdmin.ErrSelfModification):
+		status = http.StatusForbidden
+		message = "You cannot change your own role, status or account here"
+	case errors.Is(err, rbac.ErrRoleNotHeld):
+		status = http.StatusForbidden
+		message = "You can only assign roles and manage users whose permissions you hold"
+	case errors.Is(err, userAdmin.ErrNotPending):
+		status = http.StatusConflict
+		message = "This user is not waiting for approval"
+	case errors.Is(err, userAdmin.ErrUsernameTaken):
+		status = http.StatusConflict
+		message = "This username is already taken"
+	case errors.Is(err, userAdmin.ErrEmailTaken):
+		status = http.StatusConflict
+		message = "This email address is already in use"
+	case errors.As(err, &policyErr):
+		status = http.StatusBadRequest
+		message = policyErr.Error()
+	case errors.Is(err, userAdmin.ErrUnknownRole),
+		errors.Is(err, rbac.ErrUnknownRole),
+		errors.Is(err, userAdmin.ErrUnknownStatus),
+		errors.Is(err, validation.ErrInvalidUsername),
+		errors.Is(err, validation.ErrInvalidEmailFormat),
+		errors.Is(err, validation.ErrReservedEmailDomain):
+		status = http.StatusBadRequest
+		message = err.Error()
+	}
+
+	return status, message
+}
+
+func userFilterFromQuery(ctx echo.Context) userAdmin.UserFilter {
+	page, _ := strconv.Atoi(ctx.QueryParam("page"))
+	pageSize, _ := strconv.Atoi(ctx.QueryParam("pageSize"))
+
+	return userAdmin.UserFilter{
+		Search:   ctx.QueryParam("search"),
+		Role:     ctx.QueryParam("role"),
+		Status:   ctx.QueryParam("status"),
+		Page:     page,
+		PageSize: pageSize,
+	}
+}
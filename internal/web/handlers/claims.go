@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/fgeck/gotth-postgres/internal/service/security/jwt"
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	echo "github.com/labstack/echo/v4"
+)
+
+var ErrMissingClaims = errors.New("missing jwt claims")
+
+// currentClaims returns the claims of the access token checked by
+// JwtAuthMiddleware.
+func currentClaims(ctx echo.Context) (*jwt.JwtCustomClaims, error) {
+	token, ok := ctx.Get("user").(*gojwt.Token)
+	if !ok {
+		return nil, ErrMissingClaims
+	}
+	claims, ok := token.Claims.(*jwt.JwtCustomClaims)
+	if !ok {
+		return nil, ErrMissingClaims
+	}
+
+	return claims, nil
+}
+
+// currentUserID returns the id of the user authenticated by JwtAuthMiddleware.
+func currentUserID(ctx echo.Context) (uuid.UUID, error) {
+	claims, err := currentClaims(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return uuid.Parse(claims.UserId)
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/fgeck/gotth-postgres/internal/service/loginRegister"
+	echo "github.com/labstack/echo/v4"
+)
+
+const (
+	ACCESS_TOKEN_COOKIE  = "token"
+	REFRESH_TOKEN_COOKIE = "refresh_token"
+	// The refresh token is only needed by the token endpoints below /api.
+	REFRESH_TOKEN_COOKIE_PATH = "/api"
+	MFA_PENDING_COOKIE        = "mfa_token"
+	// The MFA pending token is only accepted by the second login step.
+	MFA_PENDING_COOKIE_PATH = "/api/login/mfa"
+	PASSWORD_CHANGE_COOKIE  = "password_change_token"
+	// The password change token is only accepted by the expired password step.
+	PASSWORD_CHANGE_COOKIE_PATH = "/api/login/password"
+	// References the challenge of a running passkey ceremony.
+	PASSKEY_CEREMONY_COOKIE      = "passkey_ceremony"
+	PASSKEY_CEREMONY_COOKIE_PATH = "/api/passkeys"
+)
+
+func setAuthCookies(ctx echo.Context, tokens *loginRegister.TokensDto) {
+	ctx.SetCookie(
+		&http.Cookie{
+			Name:     ACCESS_TOKEN_COOKIE,
+			Value:    tokens.AccessToken,
+			Path:     "/",                  // Cookie is valid for the entire site
+			HttpOnly: true,                 // Prevent access via JavaScript
+			Secure:   true,                 // Only send the cookie over HTTPS
+			SameSite: http.SameSiteLaxMode, // Prevent CSRF attacks
+		},
+	)
+	ctx.SetCookie(
+		&http.Cookie{
+			Name:     REFRESH_TOKEN_COOKIE,
+			Value:    tokens.RefreshToken,
+			Path:     REFRESH_TOKEN_COOKIE_PATH,
+			Expires:  tokens.RefreshTokenExpiresAt,
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteStrictMode,
+		},
+	)
+}
+
+func setMfaPendingCookie(ctx echo.Context, tokens *loginRegister.TokensDto) {
+	ctx.SetCookie(
+		&http.Cookie{
+			Name:     MFA_PENDING_COOKIE,
+			Value:    tokens.MfaPendingToken,
+			Path:     MFA_PENDING_COOKIE_PATH,
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteStrictMode,
+		},
+	)
+}
+
+func clearMfaPendingCookie(ctx echo.Context) {
+	ctx.SetCookie(
+		&http.Cookie{
+			Name:     MFA_PENDING_COOKIE,
+			Value:    "",
+			Path:     MFA_PENDING_COOKIE_PATH,
+			MaxAge:   -1,
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteStrictMode,
+		},
+	)
+}
+
+func setPasswordChangeCookie(ctx echo.Context, tokens *loginRegister.TokensDto) {
+	ctx.SetCookie(
+		&http.Cookie{
+			Name:     PASSWORD_CHANGE_COOKIE,
+			Value:    tokens.PasswordChangeToken,
+			Path:     PASSWORD_CHANGE_COOKIE_PATH,
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteStrictMode,
+		},
+	)
+}
+
+func clearPasswordChangeCookie(ctx echo.Context) {
+	ctx.SetCookie(
+		&http.Cookie{
+			Name:     PASSWORD_CHANGE_COOKIE,
+			Value:    "",
+			Path:     PASSWORD_CHANGE_COOKIE_PATH,
+			MaxAge:   -1,
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteStrictMode,
+		},
+	)
+}
+
+func setPasskeyCeremonyCookie(ctx echo.Context, ceremonyID string, maxAge int) {
+	ctx.SetCookie(
+		&http.Cookie{
+			Name:     PASSKEY_CEREMONY_COOKIE,
+			Value:    ceremonyID,
+			Path:     PASSKEY_CEREMONY_COOKIE_PATH,
+			MaxAge:   maxAge,
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteStrictMode,
+		},
+	)
+}
+
+func clearAuthCookies(ctx echo.Context) {
+	ctx.SetCookie(
+		&http.Cookie{
+			Name:     ACCESS_TOKEN_COOKIE,
+			Value:    "",
+			Path:     "/",
+			MaxAge:   -1,
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteLaxMode,
+		},
+	)
+	ctx.SetCookie(
+		&http.Cookie{
+			Name:     REFRESH_TOKEN_COOKIE,
+			Value:    "",
+			Path:     REFRESH_TOKEN_COOKIE_PATH,
+			MaxAge:   -1,
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteStrictMode,
+		},
+	)
+}
+
+func cookieValue(ctx echo.Context, name string) string {
+	cookie, err := ctx.Cookie(name)
+	if err != nil {
+		return ""
+	}
+
+	return cookie.Value
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/fgeck/gotth-postgres/internal/service/emailChange"
+	"github.com/fgeck/gotth-postgres/internal/service/render"
+	"github.com/fgeck/gotth-postgres/templates/views"
+	echo "github.com/labstack/echo/v4"
+)
+
+type EmailChangeHandler struct {
+	emailChangeService emailChange.EmailChangeServiceInterface
+}
+
+func NewEmailChangeHandler(emailChangeService emailChange.EmailChangeServiceInterface) *EmailChangeHandler {
+	return &EmailChangeHandler{
+		emailChangeService: emailChangeService,
+	}
+}
+
+// ConfirmEmailChangePageHandler is the target of the link sent to the new
+// address. The change itself needs a POST so that link scanners of mail
+// providers cannot trigger it.
+func (h *EmailChangeHandler) ConfirmEmailChangePageHandler(ctx echo.Context) error {
+	if err := render.Render(ctx, views.ConfirmEmailChange(ctx.QueryParam("token"))); err != nil {
+		return fmt.Errorf("failed to render confirm email change view: %w", err)
+	}
+
+	return nil
+}
+
+func (h *EmailChangeHandler) ConfirmEmailChangeHandler(ctx echo.Context) error {
+	userDto, err := h.emailChangeService.ConfirmChange(ctx.Request().Context(), ctx.FormValue("token"))
+	if err != nil {
+		return h.sendError(ctx, "failed to confirm email change", err)
+	}
+
+	return ctx.String(http.StatusOK, "Your email address is now "+userDto.Email+".")
+}
+
+// RevertEmailChangePageHandler is the target of the link sent to the old
+// address.
+func (h *EmailChangeHandler) RevertEmailChangePageHandler(ctx echo.Context) error {
+	if err := render.Render(ctx, views.RevertEmailChange(ctx.QueryParam("token"))); err != nil {
+		return fmt.Errorf("failed to render revert email change view: %w", err)
+	}
+
+	return nil
+}
+
+func (h *EmailChangeHandler) RevertEmailChangeHandler(ctx echo.Context) error {
+	if err := h.emailChangeService.RevertChange(ctx.Request().Context(), ctx.FormValue("token")); err != nil {
+		return h.sendError(ctx, "failed to revert email change", err)
+	}
+
+	return ctx.String(http.StatusOK, "The email change was undone. Please choose a new password.")
+}
+
+func (h *EmailChangeHandler) sendError(ctx echo.Context, action string, err error) error {
+	status := http.StatusInternalServerError
+	message := "Something went wrong"
+	switch {
+	case errors.Is(err, emailChange.ErrInvalidChangeToken):
+		status = http.StatusBadRequest
+		message = "This link is invalid or expired."
+	case errors.Is(err, emailChange.ErrEmailTaken):
+		status = http.StatusConflict
+		message = "This email address is already in use by another account."
+	}
+
+	wrappedErr := fmt.Errorf("%s: %w", action, err)
+	if stringErr := ctx.String(status, message); stringErr != nil {
+		return fmt.Errorf("failed to send error response: %w", stringErr)
+	}
+
+	return wrappedErr
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/fgeck/gotth-postgres/internal/service/emailVerification"
+	"github.com/fgeck/gotth-postgres/internal/service/render"
+	"github.com/fgeck/gotth-postgres/templates/views"
+	echo "github.com/labstack/echo/v4"
+)
+
+type EmailVerificationHandler struct {
+	emailVerificationService emailVerification.EmailVerificationServiceInterface
+}
+
+func NewEmailVerificationHandler(emailVerificationService emailVerification.EmailVerificationServiceInterface) *EmailVerificationHandler {
+	return &EmailVerificationHandler{
+		emailVerificationService: emailVerificationService,
+	}
+}
+
+// VerifyEmailHandler is the target of the emailed verification link.
+func (h *EmailVerificationHandler) VerifyEmailHandler(ctx echo.Context) error {
+	err := h.emailVerificationService.VerifyEmail(ctx.Request().Context(), ctx.QueryParam("token"))
+	if err != nil && !errors.Is(err, emailVerification.ErrInvalidVerificationToken) {
+		return fmt.Errorf("failed to verify email: %w", err)
+	}
+
+	if renderErr := render.Render(ctx, views.VerifyEmail(err == nil)); renderErr != nil {
+		return fmt.Errorf("failed to render verify email view: %w", renderErr)
+	}
+
+	return nil
+}
+
+// ResendVerificationHandler answers the same for every address so that it
+// does not reveal which ones belong to an account. That includes throttled
+// resends, which only happen for unverified accounts.
+func (h *EmailVerificationHandler) ResendVerificationHandler(ctx echo.Context) error {
+	err := h.emailVerificationService.SendVerification(ctx.Request().Context(), ctx.FormValue("email"))
+	if err != nil && !errors.Is(err, emailVerification.ErrResendThrottled) {
+		wrappedErr := fmt.Errorf("failed to resend verification email: %w", err)
+		jsonErr := ctx.String(http.StatusInternalServerError, "Failed to send verification email")
+
+		if jsonErr != nil {
+			return fmt.Errorf("failed to send error response: %w", jsonErr)
+		}
+
+		return wrappedErr
+	}
+
+	return ctx.String(http.StatusAccepted, "If the address belongs to an unverified account, a new link is on its way.")
+}
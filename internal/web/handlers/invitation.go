@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/fgeck/gotth-postgres/internal/service/invitation"
+	"github.com/fgeck/gotth-postgres/internal/service/registration"
+	"github.com/fgeck/gotth-postgres/internal/service/render"
+	"github.com/fgeck/gotth-postgres/internal/service/validation"
+	"github.com/fgeck/gotth-postgres/templates/views"
+	"github.com/google/uuid"
+	echo "github.com/labstack/echo/v4"
+)
+
+type InvitationHandler struct {
+	invitationService invitation.InvitationServiceInterface
+}
+
+func NewInvitationHandler(invitationService invitation.InvitationServiceInterface) *InvitationHandler {
+	return &InvitationHandler{
+		invitationService: invitationService,
+	}
+}
+
+type createInvitationRequest struct {
+	Email string `json:"email" form:"email"`
+	Role  string `json:"role" form:"role"`
+}
+
+// AcceptInvitationPageHandler is the target of the emailed invitation link.
+func (h *InvitationHandler) AcceptInvitationPageHandler(ctx echo.Context) error {
+	token := ctx.QueryParam("token")
+	email := ""
+	pending, err := h.invitationService.GetInvitation(ctx.Request().Context(), token)
+	if err != nil && !errors.Is(err, invitation.ErrInvalidInvitation) {
+		return fmt.Errorf("failed to get invitation: %w", err)
+	}
+	if pending != nil {
+		email = pending.Email
+	}
+
+	if err := render.Render(ctx, views.AcceptInvitation(token, email)); err != nil {
+		return fmt.Errorf("failed to render accept invitation view: %w", err)
+	}
+
+	return nil
+}
+
+func (h *InvitationHandler) AcceptInvitationHandler(ctx echo.Context) error {
+	_, err := h.invitationService.AcceptInvitation(
+		ctx.Request().Context(),
+		ctx.FormValue("token"),
+		ctx.FormValue("username"),
+		ctx.FormValue("password"),
+	)
+	if err != nil {
+		status, message := invitationErrorResponse(err)
+
+		wrappedErr := fmt.Errorf("failed to accept invitation: %w", err)
+		if stringErr := ctx.String(status, message); stringErr != nil {
+			return fmt.Errorf("failed to send error response: %w", stringErr)
+		}
+
+		return wrappedErr
+	}
+
+	return ctx.String(http.StatusCreated, "Your account was created. You can log in now.")
+}
+
+// The handlers below serve admins as well as organizations, which only see
+// their own invitations behind RequireTenant.
+
+func (h *InvitationHandler) ListInvitationsHandler(ctx echo.Context) error {
+	invitations, err := h.invitationService.ListInvitations(ctx.Request().Context())
+	if err != nil {
+		return h.sendError(ctx, "failed to list invitations", err)
+	}
+
+	return ctx.JSON(http.StatusOK, invitations)
+}
+
+func (h *InvitationHandler) CreateInvitationHandler(ctx echo.Context) error {
+	inviterID, err := currentUserID(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	var request createInvitationRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid invitation"})
+	}
+
+	created, err := h.invitationService.Invite(ctx.Request().Context(), inviterID, request.Email, request.Role)
+	if err != nil {
+		return h.sendError(ctx, "failed to create invitation", err)
+	}
+
+	return ctx.JSON(http.StatusCreated, created)
+}
+
+func (h *InvitationHandler) ResendInvitationHandler(ctx echo.Context) error {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid invitation id"})
+	}
+
+	resent, err := h.invitationService.ResendInvitation(ctx.Request().Context(), id)
+	if err != nil {
+		return h.sendError(ctx, "failed to resend invitation", err)
+	}
+
+	return ctx.JSON(http.StatusOK, resent)
+}
+
+func (h *InvitationHandler) RevokeInvitationHandler(ctx echo.Context) error {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid invitation id"})
+	}
+
+	if err := h.invitationService.RevokeInvitation(ctx.Request().Context(), id); err != nil {
+		return h.sendError(ctx, "failed to revoke invitation", err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (h *InvitationHandler) sendError(ctx echo.Context, action string, err error) error {
+	status, message := invitationErrorResponse(err)
+
+	wrappedErr := fmt.Errorf("%s: %w", action, err)
+	if jsonErr := ctx.JSON(status, map[string]string{"error": message}); jsonErr != nil {
+		return fmt.Errorf("failed to send error response: %w", jsonErr)
+	}
+
+	return wrappedErr
+}
+
+func invitationErrorResponse(err error) (int, string) {
+	status := http.StatusInternalServerError
+	message := "Something went wrong"
+	var policyErr *validation.PasswordPolicyError
+	switch {
+	case errors.As(err, &policyErr):
+		status = http.StatusBadRequest
+		message = "Password " + strings.Join(policyErr.Violations, ", ") + "."
+	case errors.Is(err, invitation.ErrInvalidInvitation):
+		status = http.StatusBadRequest
+		message = "This invitation is invalid or expired. Please ask for a new one."
+	case errors.Is(err, invitation.ErrInvitationNotFound):
+		status = http.StatusNotFound
+		message = "Invitation not found"
+	case errors.Is(err, registration.ErrRegistrationClosed):
+		status = http.StatusForbidden
+		message = "Registration is closed."
+	case errors.Is(err, invitation.ErrUsernameTaken):
+		status = http.StatusConflict
+		message = "This username is already taken."
+	case errors.Is(err, invitation.ErrEmailTaken):
+		status = http.StatusConflict
+		message = "This email address is already in use."
+	case errors.Is(err, invitation.ErrUnknownRole),
+		errors.Is(err, validation.ErrInvalidUsername),
+		errors.Is(err, validation.ErrInvalidEmailFormat),
+		errors.Is(err, validation.ErrReservedEmailDomain):
+		status = http.StatusBadRequest
+		message = err.Error()
+	}
+
+	return status, message
+}
@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/fgeck/gotth-postgres/internal/service/security/jwt"
+	echo "github.com/labstack/echo/v4"
+)
+
+const (
+	JWKS_CACHE_CONTROL = "public, max-age=300"
+)
+
+type JwksHandler struct {
+	jwtService jwt.JwtServiceInterface
+}
+
+func NewJwksHandler(jwtService jwt.JwtServiceInterface) *JwksHandler {
+	return &JwksHandler{
+		jwtService: jwtService,
+	}
+}
+
+func (h *JwksHandler) JwksHandler(ctx echo.Context) error {
+	ctx.Response().Header().Set(echo.HeaderCacheControl, JWKS_CACHE_CONTROL)
+
+	return ctx.JSON(http.StatusOK, h.jwtService.PublicJwks())
+}
@@ -1,20 +1,37 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/fgeck/gotth-postgres/internal/service/emailVerification"
 	loginregister "github.com/fgeck/gotth-postgres/internal/service/loginRegister"
+	"github.com/fgeck/gotth-postgres/internal/service/loginThrottle"
+	"github.com/fgeck/gotth-postgres/internal/service/mfa"
+	"github.com/fgeck/gotth-postgres/internal/service/passwordHistory"
 	"github.com/fgeck/gotth-postgres/internal/service/render"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	"github.com/fgeck/gotth-postgres/internal/service/validation"
 
 	"github.com/fgeck/gotth-postgres/templates/views"
 	"github.com/labstack/echo/v4"
 )
 
+const CHANGE_PASSWORD_PATH = "/change-password"
+
 type LoginHandlerInterface interface {
 	LoginRegisterContainerHandler(ctx echo.Context) error
 	LoginFormHandler(ctx echo.Context) error
 	LoginHandler(ctx echo.Context) error
+	MfaLoginHandler(ctx echo.Context) error
+	ChangePasswordPageHandler(ctx echo.Context) error
+	ExpiredPasswordHandler(ctx echo.Context) error
+	LogoutHandler(ctx echo.Context) error
 }
 
 type LoginHandler struct {
@@ -47,10 +64,35 @@ func (h *LoginHandler) LoginHandler(ctx echo.Context) error {
 	username := ctx.FormValue("email")
 	password := ctx.FormValue("password")
 
-	token, err := h.loginRegisterService.LoginUser(ctx.Request().Context(), username, password)
+	tokens, err := h.loginRegisterService.LoginUser(ctx.Request().Context(), username, password, ctx.RealIP())
 	if err != nil {
+		status := http.StatusInternalServerError
+		message := "Failed to login user"
+		var throttledErr *loginThrottle.ThrottledError
+		if errors.As(err, &throttledErr) {
+			status = http.StatusTooManyRequests
+			message = "Too many failed login attempts, please try again later"
+			ctx.Response().Header().Set("Retry-After", retryAfterSeconds(throttledErr.RetryAfter))
+		}
+		if errors.Is(err, loginregister.ErrInvalidCredentials) {
+			status = http.StatusUnauthorized
+			message = "Invalid email or password"
+		}
+		if errors.Is(err, emailVerification.ErrEmailNotVerified) {
+			status = http.StatusForbidden
+			message = "Please verify your email address first"
+		}
+		if errors.Is(err, user.ErrUserDisabled) {
+			status = http.StatusForbidden
+			message = "This account is disabled"
+		}
+		if errors.Is(err, user.ErrUserPendingApproval) {
+			status = http.StatusForbidden
+			message = "This account is waiting for approval"
+		}
+
 		wrappedErr := fmt.Errorf("failed to login user: %w", err)
-		jsonErr := ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to login user"})
+		jsonErr := ctx.JSON(status, map[string]string{"error": message})
 
 		if jsonErr != nil {
 			return fmt.Errorf("failed to send error response: %w", jsonErr)
@@ -58,16 +100,156 @@ func (h *LoginHandler) LoginHandler(ctx echo.Context) error {
 
 		return wrappedErr
 	}
-	ctx.SetCookie(
-		&http.Cookie{
-			Name:     "token",
-			Value:    token,
-			Path:     "/",                  // Cookie is valid for the entire site
-			HttpOnly: true,                 // Prevent access via JavaScript
-			Secure:   true,                 // Only send the cookie over HTTPS
-			SameSite: http.SameSiteLaxMode, // Prevent CSRF attacks
-		},
+
+	if tokens.MfaRequired() {
+		setMfaPendingCookie(ctx, tokens)
+		if err := ctx.JSON(http.StatusAccepted, map[string]bool{"mfaRequired": true}); err != nil {
+			return fmt.Errorf("failed to send mfa required response: %w", err)
+		}
+
+		return nil
+	}
+	if tokens.PasswordChangeRequired() {
+		return sendPasswordChangeRequired(ctx, tokens)
+	}
+	setAuthCookies(ctx, tokens)
+
+	if err := ctx.String(http.StatusOK, "success"); err != nil {
+		return fmt.Errorf("failed to send success response: %w", err)
+	}
+
+	return nil
+}
+
+// MfaLoginHandler is the second login step for users with two-factor
+// authentication. It only accepts the MFA pending cookie set by LoginHandler.
+func (h *LoginHandler) MfaLoginHandler(ctx echo.Context) error {
+	mfaPendingToken := cookieValue(ctx, MFA_PENDING_COOKIE)
+	if mfaPendingToken == "" {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "Missing mfa token"})
+	}
+	code := ctx.FormValue("code")
+
+	tokens, err := h.loginRegisterService.VerifyMfaLogin(ctx.Request().Context(), mfaPendingToken, code, ctx.RealIP())
+	if err != nil {
+		status := http.StatusInternalServerError
+		message := "Failed to verify mfa code"
+		var throttledErr *loginThrottle.ThrottledError
+		if errors.As(err, &throttledErr) {
+			status = http.StatusTooManyRequests
+			message = "Too many failed login attempts, please try again later"
+			ctx.Response().Header().Set("Retry-After", retryAfterSeconds(throttledErr.RetryAfter))
+		}
+		if errors.Is(err, mfa.ErrInvalidMfaCode) {
+			status = http.StatusUnauthorized
+			message = "Invalid mfa code"
+		}
+		if errors.Is(err, user.ErrUserDisabled) {
+			status = http.StatusForbidden
+			message = "This account is disabled"
+		}
+		if errors.Is(err, user.ErrUserPendingApproval) {
+			status = http.StatusForbidden
+			message = "This account is waiting for approval"
+		}
+
+		wrappedErr := fmt.Errorf("failed to verify mfa login: %w", err)
+		if jsonErr := ctx.JSON(status, map[string]string{"error": message}); jsonErr != nil {
+			return fmt.Errorf("failed to send error response: %w", jsonErr)
+		}
+
+		return wrappedErr
+	}
+	clearMfaPendingCookie(ctx)
+	if tokens.PasswordChangeRequired() {
+		return sendPasswordChangeRequired(ctx, tokens)
+	}
+	setAuthCookies(ctx, tokens)
+
+	if err := ctx.String(http.StatusOK, "success"); err != nil {
+		return fmt.Errorf("failed to send success response: %w", err)
+	}
+
+	return nil
+}
+
+// ChangePasswordPageHandler is the interstitial shown when a login was
+// answered with a password change token.
+func (h *LoginHandler) ChangePasswordPageHandler(ctx echo.Context) error {
+	if err := render.Render(ctx, views.ChangeExpiredPassword()); err != nil {
+		return fmt.Errorf("failed to render change password view: %w", err)
+	}
+
+	return nil
+}
+
+// ExpiredPasswordHandler sets the new password of an expired login and only
+// then logs the user in. It only accepts the password change cookie.
+func (h *LoginHandler) ExpiredPasswordHandler(ctx echo.Context) error {
+	passwordChangeToken := cookieValue(ctx, PASSWORD_CHANGE_COOKIE)
+	if passwordChangeToken == "" {
+		return ctx.String(http.StatusUnauthorized, "Your login has expired, please log in again.")
+	}
+
+	tokens, err := h.loginRegisterService.ChangeExpiredPassword(
+		ctx.Request().Context(),
+		passwordChangeToken,
+		ctx.FormValue("password"),
 	)
+	if err != nil {
+		status := http.StatusInternalServerError
+		message := "Failed to change password"
+		var policyErr *validation.PasswordPolicyError
+		switch {
+		case errors.As(err, &policyErr):
+			status = http.StatusBadRequest
+			message = "Password " + strings.Join(policyErr.Violations, ", ") + "."
+		case errors.Is(err, passwordHistory.ErrPasswordReused):
+			status = http.StatusBadRequest
+			message = "You have used this password before, please choose a new one."
+		case errors.Is(err, loginregister.ErrInvalidPasswordChangeToken):
+			status = http.StatusUnauthorized
+			message = "Your login has expired, please log in again."
+		case errors.Is(err, user.ErrUserDisabled):
+			status = http.StatusForbidden
+			message = "This account is disabled."
+		case errors.Is(err, user.ErrUserPendingApproval):
+			status = http.StatusForbidden
+			message = "This account is waiting for approval."
+		}
+
+		wrappedErr := fmt.Errorf("failed to change expired password: %w", err)
+		if stringErr := ctx.String(status, message); stringErr != nil {
+			return fmt.Errorf("failed to send error response: %w", stringErr)
+		}
+
+		return wrappedErr
+	}
+	clearPasswordChangeCookie(ctx)
+	setAuthCookies(ctx, tokens)
+
+	if err := ctx.String(http.StatusOK, "Your password was changed."); err != nil {
+		return fmt.Errorf("failed to send success response: %w", err)
+	}
+
+	return nil
+}
+
+func (h *LoginHandler) LogoutHandler(ctx echo.Context) error {
+	accessToken := cookieValue(ctx, ACCESS_TOKEN_COOKIE)
+	refreshToken := cookieValue(ctx, REFRESH_TOKEN_COOKIE)
+	clearAuthCookies(ctx)
+
+	if err := h.loginRegisterService.LogoutUser(ctx.Request().Context(), accessToken, refreshToken); err != nil {
+		wrappedErr := fmt.Errorf("failed to logout user: %w", err)
+		jsonErr := ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to logout user"})
+
+		if jsonErr != nil {
+			return fmt.Errorf("failed to send error response: %w", jsonErr)
+		}
+
+		return wrappedErr
+	}
 
 	if err := ctx.String(http.StatusOK, "success"); err != nil {
 		return fmt.Errorf("failed to send success response: %w", err)
@@ -75,3 +257,23 @@ func (h *LoginHandler) LoginHandler(ctx echo.Context) error {
 
 	return nil
 }
+
+// sendPasswordChangeRequired hands out the password change cookie instead of a
+// session. htmx clients are sent straight to the change password page.
+func sendPasswordChangeRequired(ctx echo.Context, tokens *loginregister.TokensDto) error {
+	setPasswordChangeCookie(ctx, tokens)
+	if ctx.Request().Header.Get(HX_REQUEST_HEADER) == "true" {
+		ctx.Response().Header().Set(HX_REDIRECT_HEADER, CHANGE_PASSWORD_PATH)
+	}
+
+	if err := ctx.JSON(http.StatusAccepted, map[string]bool{"passwordChangeRequired": true}); err != nil {
+		return fmt.Errorf("failed to send password change required response: %w", err)
+	}
+
+	return nil
+}
+
+// retryAfterSeconds rounds up, so that clients never retry too early.
+func retryAfterSeconds(retryAfter time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(retryAfter.Seconds())))
+}
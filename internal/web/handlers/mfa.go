@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/fgeck/gotth-postgres/internal/service/loginThrottle"
+	"github.com/fgeck/gotth-postgres/internal/service/mfa"
+	echo "github.com/labstack/echo/v4"
+)
+
+type MfaHandler struct {
+	mfaService mfa.MfaServiceInterface
+}
+
+func NewMfaHandler(mfaService mfa.MfaServiceInterface) *MfaHandler {
+	return &MfaHandler{
+		mfaService: mfaService,
+	}
+}
+
+// EnrollHandler starts the TOTP enrollment. The returned otpauth URI is meant
+// to be rendered as QR code for the authenticator app.
+func (h *MfaHandler) EnrollHandler(ctx echo.Context) error {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+
+	enrollment, err := h.mfaService.BeginEnrollment(ctx.Request().Context(), userID)
+	if err != nil {
+		return h.sendError(ctx, "failed to begin mfa enrollment", err)
+	}
+
+	return ctx.JSON(http.StatusOK, enrollment)
+}
+
+func (h *MfaHandler) ConfirmHandler(ctx echo.Context) error {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+
+	recoveryCodes, err := h.mfaService.ConfirmEnrollment(ctx.Request().Context(), userID, ctx.FormValue("code"), ctx.RealIP())
+	if err != nil {
+		return h.sendError(ctx, "failed to confirm mfa enrollment", err)
+	}
+
+	return ctx.JSON(http.StatusOK, recoveryCodes)
+}
+
+func (h *MfaHandler) DisableHandler(ctx echo.Context) error {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+
+	if err := h.mfaService.Disable(ctx.Request().Context(), userID, ctx.FormValue("code"), ctx.RealIP()); err != nil {
+		return h.sendError(ctx, "failed to disable mfa", err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (h *MfaHandler) sendError(ctx echo.Context, action string, err error) error {
+	status := http.StatusInternalServerError
+	message := "Something went wrong"
+	var throttledErr *loginThrottle.ThrottledError
+	switch {
+	case errors.As(err, &throttledErr):
+		status = http.StatusTooManyRequests
+		message = "Too many failed attempts, please try again later"
+		ctx.Response().Header().Set("Retry-After", retryAfterSeconds(throttledErr.RetryAfter))
+	case errors.Is(err, mfa.ErrInvalidMfaCode),
+		errors.Is(err, mfa.ErrMfaAlreadyEnabled),
+		errors.Is(err, mfa.ErrMfaNotEnrolled):
+		status = http.StatusBadRequest
+		message = err.Error()
+	}
+
+	wrappedErr := fmt.Errorf("%s: %w", action, err)
+	if jsonErr := ctx.JSON(status, map[string]string{"error": message}); jsonErr != nil {
+		return fmt.Errorf("failed to send error response: %w", jsonErr)
+	}
+
+	return wrappedErr
+}
@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/fgeck/gotth-postgres/internal/service/loginRegister"
+	"github.com/fgeck/gotth-postgres/internal/service/organization"
+	"github.com/fgeck/gotth-postgres/internal/service/session"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	"github.com/google/uuid"
+	echo "github.com/labstack/echo/v4"
+)
+
+type OrganizationHandler struct {
+	organizationService  organization.OrganizationServiceInterface
+	loginRegisterService loginRegister.LoginRegisterServiceInterface
+}
+
+func NewOrganizationHandler(
+	organizationService organization.OrganizationServiceInterface,
+	loginRegisterService loginRegister.LoginRegisterServiceInterface,
+) *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationService:  organizationService,
+		loginRegisterService: loginRegisterService,
+	}
+}
+
+type organizationRequest struct {
+	Name string `json:"name" form:"name"`
+}
+
+type switchOrganizationRequest struct {
+	OrganizationID string `json:"organizationId" form:"organizationId"`
+}
+
+type addMemberRequest struct {
+	Email string `json:"email" form:"email"`
+	Role  string `json:"role" form:"role"`
+}
+
+type updateMemberRoleRequest struct {
+	Role string `json:"role" form:"role"`
+}
+
+// ListMembershipsHandler returns the organizations of the current user.
+func (h *OrganizationHandler) ListMembershipsHandler(ctx echo.Context) error {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+
+	memberships, err := h.organizationService.ListMemberships(ctx.Request().Context(), userID)
+	if err != nil {
+		return h.sendError(ctx, "failed to list memberships", err)
+	}
+
+	return ctx.JSON(http.StatusOK, memberships)
+}
+
+func (h *OrganizationHandler) CreateOrganizationHandler(ctx echo.Context) error {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	var request organizationRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid organization"})
+	}
+
+	created, err := h.organizationService.CreateOrganization(ctx.Request().Context(), userID, request.Name)
+	if err != nil {
+		return h.sendError(ctx, "failed to create organization", err)
+	}
+
+	return ctx.JSON(http.StatusCreated, created)
+}
+
+// SwitchOrganizationHandler replaces the auth cookies with tokens for the
+// given organization. An empty organization id leaves the current one.
+func (h *OrganizationHandler) SwitchOrganizationHandler(ctx echo.Context) error {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	refreshToken := cookieValue(ctx, REFRESH_TOKEN_COOKIE)
+	if refreshToken == "" {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "Missing refresh token"})
+	}
+	var request switchOrganizationRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid organization id"})
+	}
+	organizationID := uuid.Nil
+	if request.OrganizationID != "" {
+		organizationID, err = uuid.Parse(request.OrganizationID)
+		if err != nil {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid organization id"})
+		}
+	}
+
+	tokens, err := h.loginRegisterService.SwitchOrganization(ctx.Request().Context(), userID, refreshToken, organizationID)
+	if err != nil {
+		return h.sendError(ctx, "failed to switch organization", err)
+	}
+	setAuthCookies(ctx, tokens)
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// The handlers below run behind RequireTenant and act in the organization of
+// the access token.
+
+func (h *OrganizationHandler) GetTenantHandler(ctx echo.Context) error {
+	tenant, err := h.organizationService.GetTenant(ctx.Request().Context())
+	if err != nil {
+		return h.sendError(ctx, "failed to get organization", err)
+	}
+
+	return ctx.JSON(http.StatusOK, tenant)
+}
+
+func (h *OrganizationHandler) RenameTenantHandler(ctx echo.Context) error {
+	var request organizationRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid organization"})
+	}
+
+	renamed, err := h.organizationService.RenameTenant(ctx.Request().Context(), request.Name)
+	if err != nil {
+		return h.sendError(ctx, "failed to rename organization", err)
+	}
+
+	return ctx.JSON(http.StatusOK, renamed)
+}
+
+func (h *OrganizationHandler) ListMembersHandler(ctx echo.Context) error {
+	members, err := h.organizationService.ListMembers(ctx.Request().Context())
+	if err != nil {
+		return h.sendError(ctx, "failed to list members", err)
+	}
+
+	return ctx.JSON(http.StatusOK, members)
+}
+
+func (h *OrganizationHandler) AddMemberHandler(ctx echo.Context) error {
+	var request addMemberRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid member"})
+	}
+
+	member, err := h.organizationService.AddMember(ctx.Request().Context(), request.Email, request.Role)
+	if err != nil {
+		return h.sendError(ctx, "failed to add member", err)
+	}
+
+	return ctx.JSON(http.StatusCreated, member)
+}
+
+func (h *OrganizationHandler) UpdateMemberRoleHandler(ctx echo.Context) error {
+	memberID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid user id"})
+	}
+	var request updateMemberRoleRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid role"})
+	}
+
+	if err := h.organizationService.UpdateMemberRole(ctx.Request().Context(), memberID, request.Role); err != nil {
+		return h.sendError(ctx, "failed to update member role", err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (h *OrganizationHandler) RemoveMemberHandler(ctx echo.Context) error {
+	memberID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid user id"})
+	}
+
+	if err := h.organizationService.RemoveMember(ctx.Request().Context(), memberID); err != nil {
+		return h.sendError(ctx, "failed to remove member", err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (h *OrganizationHandler) sendError(ctx echo.Context, action string, err error) error {
+	status := http.StatusInternalServerError
+	message := "Something went wrong"
+	switch {
+	case errors.Is(err, organization.ErrOrganizationNotFound):
+		status = http.StatusNotFound
+		message = "Organization not found"
+	case errors.Is(err, organization.ErrNotMember),
+		errors.Is(err, user.ErrUserNotFound):
+		status = http.StatusNotFound
+		message = "Member not found"
+	case errors.Is(err, organization.ErrAlreadyMember),
+		errors.Is(err, organization.ErrLastOwner):
+		status = http.StatusConflict
+		message = err.Error()
+	case errors.Is(err, organization.ErrInvalidName),
+		errors.Is(err, organization.ErrUnknownRole):
+		status = http.StatusBadRequest
+		message = err.Error()
+	case errors.Is(err, organization.ErrNoTenant):
+		status = http.StatusForbidden
+		message = err.Error()
+	case errors.Is(err, session.ErrInvalidRefreshToken),
+		errors.Is(err, session.ErrRefreshTokenExpired),
+		errors.Is(err, session.ErrRefreshTokenReused),
+		errors.Is(err, user.ErrUserDisabled):
+		status = http.StatusUnauthorized
+		message = "Invalid refresh token"
+	}
+
+	wrappedErr := fmt.Errorf("%s: %w", action, err)
+	if jsonErr := ctx.JSON(status, map[string]string{"error": message}); jsonErr != nil {
+		return fmt.Errorf("failed to send error response: %w", jsonErr)
+	}
+
+	return wrappedErr
+}
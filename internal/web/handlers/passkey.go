@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/fgeck/gotth-postgres/internal/service/emailVerification"
+	"github.com/fgeck/gotth-postgres/internal/service/loginRegister"
+	"github.com/fgeck/gotth-postgres/internal/service/passkey"
+	"github.com/fgeck/gotth-postgres/internal/service/render"
+	"github.com/fgeck/gotth-postgres/internal/service/security/webauthn"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	"github.com/fgeck/gotth-postgres/templates/views"
+	"github.com/google/uuid"
+	echo "github.com/labstack/echo/v4"
+)
+
+type PasskeyHandler struct {
+	passkeyService       passkey.PasskeyServiceInterface
+	loginRegisterService loginRegister.LoginRegisterServiceInterface
+}
+
+func NewPasskeyHandler(
+	passkeyService passkey.PasskeyServiceInterface,
+	loginRegisterService loginRegister.LoginRegisterServiceInterface,
+) *PasskeyHandler {
+	return &PasskeyHandler{
+		passkeyService:       passkeyService,
+		loginRegisterService: loginRegisterService,
+	}
+}
+
+type finishPasskeyRegistrationRequest struct {
+	Name       string                        `json:"name"`
+	Credential webauthn.RegistrationResponse `json:"credential"`
+}
+
+func (h *PasskeyHandler) PasskeysPageHandler(ctx echo.Context) error {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+
+	passkeys, err := h.passkeyService.ListPasskeys(ctx.Request().Context(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to list passkeys: %w", err)
+	}
+
+	if err := render.Render(ctx, views.Passkeys(passkeys)); err != nil {
+		return fmt.Errorf("failed to render passkeys: %w", err)
+	}
+
+	return nil
+}
+
+func (h *PasskeyHandler) ListPasskeysHandler(ctx echo.Context) error {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+
+	passkeys, err := h.passkeyService.ListPasskeys(ctx.Request().Context(), userID)
+	if err != nil {
+		return h.sendError(ctx, "failed to list passkeys", err)
+	}
+
+	return ctx.JSON(http.StatusOK, passkeys)
+}
+
+func (h *PasskeyHandler) BeginRegistrationHandler(ctx echo.Context) error {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+
+	ceremony, err := h.passkeyService.BeginRegistration(ctx.Request().Context(), userID)
+	if err != nil {
+		return h.sendError(ctx, "failed to begin passkey registration", err)
+	}
+	setPasskeyCeremonyCookie(ctx, ceremony.CeremonyID.String(), int(webauthn.CEREMONY_TIMEOUT.Seconds()))
+
+	return ctx.JSON(http.StatusOK, ceremony.Options)
+}
+
+func (h *PasskeyHandler) FinishRegistrationHandler(ctx echo.Context) error {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	ceremonyID, err := uuid.Parse(cookieValue(ctx, PASSKEY_CEREMONY_COOKIE))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Missing passkey ceremony"})
+	}
+	var request finishPasskeyRegistrationRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid passkey credential"})
+	}
+	setPasskeyCeremonyCookie(ctx, "", -1)
+
+	created, err := h.passkeyService.FinishRegistration(
+		ctx.Request().Context(),
+		userID,
+		ceremonyID,
+		request.Name,
+		&request.Credential,
+	)
+	if err != nil {
+		return h.sendError(ctx, "failed to finish passkey registration", err)
+	}
+
+	return ctx.JSON(http.StatusCreated, created)
+}
+
+func (h *PasskeyHandler) DeletePasskeyHandler(ctx echo.Context) error {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	passkeyID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid passkey id"})
+	}
+
+	if err := h.passkeyService.DeletePasskey(ctx.Request().Context(), userID, passkeyID); err != nil {
+		return h.sendError(ctx, "failed to delete passkey", err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (h *PasskeyHandler) BeginLoginHandler(ctx echo.Context) error {
+	ceremony, err := h.passkeyService.BeginLogin(ctx.Request().Context())
+	if err != nil {
+		return h.sendError(ctx, "failed to begin passkey login", err)
+	}
+	setPasskeyCeremonyCookie(ctx, ceremony.CeremonyID.String(), int(webauthn.CEREMONY_TIMEOUT.Seconds()))
+
+	return ctx.JSON(http.StatusOK, ceremony.Options)
+}
+
+func (h *PasskeyHandler) FinishLoginHandler(ctx echo.Context) error {
+	ceremonyID, err := uuid.Parse(cookieValue(ctx, PASSKEY_CEREMONY_COOKIE))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Missing passkey ceremony"})
+	}
+	var response webauthn.AuthenticationResponse
+	if err := ctx.Bind(&response); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid passkey credential"})
+	}
+	setPasskeyCeremonyCookie(ctx, "", -1)
+
+	tokens, err := h.loginRegisterService.LoginWithPasskey(ctx.Request().Context(), ceremonyID, &response)
+	if err != nil {
+		return h.sendError(ctx, "failed to login with passkey", err)
+	}
+	setAuthCookies(ctx, tokens)
+
+	if err := ctx.String(http.StatusOK, "success"); err != nil {
+		return fmt.Errorf("failed to send success response: %w", err)
+	}
+
+	return nil
+}
+
+func (h *PasskeyHandler) sendError(ctx echo.Context, action string, err error) error {
+	status := http.StatusInternalServerError
+	message := "Something went wrong"
+	switch {
+	case errors.Is(err, passkey.ErrPasskeyNotFound):
+		status = http.StatusNotFound
+		message = err.Error()
+	case errors.Is(err, passkey.ErrPasskeyAlreadyRegistered),
+		errors.Is(err, passkey.ErrPasskeyNameTooLong),
+		errors.Is(err, passkey.ErrCeremonyNotFound):
+		status = http.StatusBadRequest
+		message = err.Error()
+	case errors.Is(err, passkey.ErrUserHandleMismatch),
+		errors.Is(err, webauthn.ErrInvalidResponse),
+		errors.Is(err, webauthn.ErrChallengeMismatch),
+		errors.Is(err, webauthn.ErrOriginNotAllowed),
+		errors.Is(err, webauthn.ErrRpIdMismatch),
+		errors.Is(err, webauthn.ErrUserNotPresent),
+		errors.Is(err, webauthn.ErrUserVerificationRequired),
+		errors.Is(err, webauthn.ErrSignCountInvalid),
+		errors.Is(err, webauthn.ErrBadSignature),
+		errors.Is(err, webauthn.ErrInvalidKey),
+		errors.Is(err, webauthn.ErrUnsupportedKey):
+		status = http.StatusUnauthorized
+		message = "Passkey verification failed"
+	case errors.Is(err, emailVerification.ErrEmailNotVerified):
+		status = http.StatusForbidden
+		message = "Please verify your email address first"
+	case errors.Is(err, user.ErrUserDisabled):
+		status = http.StatusForbidden
+		message = "This account is disabled"
+	case errors.Is(err, user.ErrUserPendingApproval):
+		status = http.StatusForbidden
+		message = "This account is waiting for approval"
+	}
+
+	wrappedErr := fmt.Errorf("%s: %w", action, err)
+	if jsonErr := ctx.JSON(status, map[string]string{"error": message}); jsonErr != nil {
+		return fmt.Errorf("failed to send error response: %w", jsonErr)
+	}
+
+	return wrappedErr
+}
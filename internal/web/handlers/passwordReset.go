@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/service/passwordHistory"
+	"github.com/fgeck/gotth-postgres/internal/service/passwordReset"
+	"github.com/fgeck/gotth-postgres/internal/service/render"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	"github.com/fgeck/gotth-postgres/internal/service/validation"
+	"github.com/fgeck/gotth-postgres/templates/views"
+	echo "github.com/labstack/echo/v4"
+)
+
+const (
+	FORGOT_PASSWORD_RESPONSE = "If an account exists for this address, we have sent a link to reset the password."
+	// Reset emails sent at the same time at most. Further requests are
+	// dropped until one of them is done.
+	MAX_PENDING_RESET_EMAILS = 8
+	RESET_EMAIL_TIMEOUT      = 30 * time.Second
+)
+
+type PasswordResetHandler struct {
+	passwordResetService passwordReset.PasswordResetServiceInterface
+	resetEmailSlots      chan struct{}
+}
+
+func NewPasswordResetHandler(passwordResetService passwordReset.PasswordResetServiceInterface) *PasswordResetHandler {
+	return &PasswordResetHandler{
+		passwordResetService: passwordResetService,
+		resetEmailSlots:      make(chan struct{}, MAX_PENDING_RESET_EMAILS),
+	}
+}
+
+func (h *PasswordResetHandler) ForgotPasswordPageHandler(ctx echo.Context) error {
+	if err := render.Render(ctx, views.ForgotPassword()); err != nil {
+		return fmt.Errorf("failed to render forgot password view: %w", err)
+	}
+
+	return nil
+}
+
+// ForgotPasswordHandler answers the same for known and unknown addresses. Only
+// the lookup and the resend throttle run before the answer. The email is sent
+// in the background, otherwise sending it would make the answer slower for
+// known addresses and errors would reveal them.
+func (h *PasswordResetHandler) ForgotPasswordHandler(ctx echo.Context) error {
+	userDto, err := h.passwordResetService.CheckReset(ctx.Request().Context(), ctx.FormValue("email"))
+	if err != nil && !errors.Is(err, passwordReset.ErrResetThrottled) {
+		log.Printf("Failed to request password reset: %v\n", err)
+	}
+	if err == nil && userDto != nil {
+		h.sendResetEmail(ctx.Request().Context(), userDto)
+	}
+
+	return ctx.String(http.StatusAccepted, FORGOT_PASSWORD_RESPONSE)
+}
+
+// sendResetEmail hands the email off to the background if one of the
+// MAX_PENDING_RESET_EMAILS slots is free and drops it otherwise, so that a
+// flood of requests cannot pile up goroutines waiting for the mail server.
+func (h *PasswordResetHandler) sendResetEmail(requestCtx context.Context, userDto *user.UserDto) {
+	select {
+	case h.resetEmailSlots <- struct{}{}:
+	default:
+		log.Printf("Dropped password reset email for user %s, too many are pending\n", userDto.ID)
+		return
+	}
+
+	// The context of the request is canceled once the answer is sent.
+	sendCtx, cancel := context.WithTimeout(context.WithoutCancel(requestCtx), RESET_EMAIL_TIMEOUT)
+	go func() {
+		defer func() { <-h.resetEmailSlots }()
+		defer cancel()
+		if err := h.passwordResetService.SendReset(sendCtx, userDto); err != nil {
+			log.Printf("Failed to send password reset email: %v\n", err)
+		}
+	}()
+}
+
+// ResetPasswordPageHandler is the target of the emailed reset link.
+func (h *PasswordResetHandler) ResetPasswordPageHandler(ctx echo.Context) error {
+	if err := render.Render(ctx, views.ResetPassword(ctx.QueryParam("token"))); err != nil {
+		return fmt.Errorf("failed to render reset password view: %w", err)
+	}
+
+	return nil
+}
+
+func (h *PasswordResetHandler) ResetPasswordHandler(ctx echo.Context) error {
+	err := h.passwordResetService.ResetPassword(
+		ctx.Request().Context(),
+		ctx.FormValue("token"),
+		ctx.FormValue("password"),
+	)
+	if err != nil {
+		status := http.StatusInternalServerError
+		message := "Failed to reset password"
+		var policyErr *validation.PasswordPolicyError
+		switch {
+		case errors.As(err, &policyErr):
+			status = http.StatusBadRequest
+			message = "Password " + strings.Join(policyErr.Violations, ", ") + "."
+		case errors.Is(err, passwordHistory.ErrPasswordReused):
+			status = http.StatusBadRequest
+			message = "You have used this password before, please choose a new one."
+		case errors.Is(err, passwordReset.ErrInvalidResetToken):
+			status = http.StatusBadRequest
+			message = "This reset link is invalid or expired. Please request a new one."
+		}
+
+		wrappedErr := fmt.Errorf("failed to reset password: %w", err)
+		if stringErr := ctx.String(status, message); stringErr != nil {
+			return fmt.Errorf("failed to send error response: %w", stringErr)
+		}
+
+		return wrappedErr
+	}
+
+	return ctx.String(http.StatusOK, "Your password was changed. You can log in now.")
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/fgeck/gotth-postgres/internal/service/render"
+	"github.com/fgeck/gotth-postgres/internal/service/security/strength"
+	"github.com/fgeck/gotth-postgres/templates/views"
+	echo "github.com/labstack/echo/v4"
+)
+
+const (
+	HX_REQUEST_HEADER  = "HX-Request"
+	HX_REDIRECT_HEADER = "HX-Redirect"
+)
+
+type PasswordStrengthHandler struct {
+	strengthService strength.StrengthServiceInterface
+	minScore        int
+}
+
+type passwordStrengthResponse struct {
+	*strength.Estimate
+	MinScore int `json:"minScore"`
+}
+
+func NewPasswordStrengthHandler(strengthService strength.StrengthServiceInterface, minScore int) *PasswordStrengthHandler {
+	return &PasswordStrengthHandler{
+		strengthService: strengthService,
+		minScore:        minScore,
+	}
+}
+
+// PasswordStrengthHandler estimates the password of the submitted form. htmx
+// requests get the strength meter shown below the password field, all others
+// the estimate as JSON.
+func (h *PasswordStrengthHandler) PasswordStrengthHandler(ctx echo.Context) error {
+	estimate := h.strengthService.Estimate(
+		ctx.FormValue("password"),
+		ctx.FormValue("username"),
+		ctx.FormValue("email"),
+	)
+
+	if ctx.Request().Header.Get(HX_REQUEST_HEADER) == "true" {
+		if err := render.Render(ctx, views.PasswordStrength(estimate, h.minScore)); err != nil {
+			return fmt.Errorf("failed to render password strength: %w", err)
+		}
+
+		return nil
+	}
+
+	return ctx.JSON(http.StatusOK, passwordStrengthResponse{Estimate: estimate, MinScore: h.minScore})
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/service/personalAccessToken"
+	"github.com/google/uuid"
+	echo "github.com/labstack/echo/v4"
+)
+
+type PersonalAccessTokenHandler struct {
+	personalAccessTokenService personalAccessToken.PersonalAccessTokenServiceInterface
+}
+
+func NewPersonalAccessTokenHandler(personalAccessTokenService personalAccessToken.PersonalAccessTokenServiceInterface) *PersonalAccessTokenHandler {
+	return &PersonalAccessTokenHandler{
+		personalAccessTokenService: personalAccessTokenService,
+	}
+}
+
+// createTokenRequest leaves ExpiresInDays at zero for the default expiry.
+type createTokenRequest struct {
+	Name          string   `json:"name" form:"name"`
+	Scopes        []string `json:"scopes" form:"scopes"`
+	ExpiresInDays int      `json:"expiresInDays" form:"expiresInDays"`
+}
+
+func (h *PersonalAccessTokenHandler) ListTokensHandler(ctx echo.Context) error {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+
+	tokens, err := h.personalAccessTokenService.ListTokens(ctx.Request().Context(), userID)
+	if err != nil {
+		return h.sendError(ctx, "failed to list personal access tokens", err)
+	}
+
+	return ctx.JSON(http.StatusOK, tokens)
+}
+
+// CreateTokenHandler answers with the token itself, which is not shown again.
+func (h *PersonalAccessTokenHandler) CreateTokenHandler(ctx echo.Context) error {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	var request createTokenRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid token"})
+	}
+
+	created, err := h.personalAccessTokenService.CreateToken(
+		ctx.Request().Context(),
+		userID,
+		request.Name,
+		request.Scopes,
+		time.Duration(request.ExpiresInDays)*24*time.Hour,
+	)
+	if err != nil {
+		return h.sendError(ctx, "failed to create personal access token", err)
+	}
+
+	return ctx.JSON(http.StatusCreated, created)
+}
+
+func (h *PersonalAccessTokenHandler) RevokeTokenHandler(ctx echo.Context) error {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	tokenID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid token id"})
+	}
+
+	if err := h.personalAccessTokenService.RevokeToken(ctx.Request().Context(), userID, tokenID); err != nil {
+		return h.sendError(ctx, "failed to revoke personal access token", err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (h *PersonalAccessTokenHandler) sendError(ctx echo.Context, action string, err error) error {
+	status := http.StatusInternalServerError
+	message := "Something went wrong"
+	switch {
+	case errors.Is(err, personalAccessToken.ErrTokenNotFound):
+		status = http.StatusNotFound
+		message = "Token not found"
+	case errors.Is(err, personalAccessToken.ErrCreatedWithToken),
+		errors.Is(err, personalAccessToken.ErrServiceAccount):
+		status = http.StatusForbidden
+		message = err.Error()
+	case errors.Is(err, personalAccessToken.ErrInvalidName),
+		errors.Is(err, personalAccessToken.ErrInvalidScope),
+		errors.Is(err, personalAccessToken.ErrInvalidExpiry):
+		status = http.StatusBadRequest
+		message = err.Error()
+	}
+
+	wrappedErr := fmt.Errorf("%s: %w", action, err)
+	if jsonErr := ctx.JSON(status, map[string]string{"error": message}); jsonErr != nil {
+		return fmt.Errorf("failed to send error response: %w", jsonErr)
+	}
+
+	return wrappedErr
+}
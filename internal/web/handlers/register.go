@@ -7,7 +7,9 @@ import (
 
 	customErrors "github.com/fgeck/gotth-postgres/internal/service/errors"
 	"github.com/fgeck/gotth-postgres/internal/service/loginRegister"
+	"github.com/fgeck/gotth-postgres/internal/service/registration"
 	"github.com/fgeck/gotth-postgres/internal/service/render"
+	"github.com/fgeck/gotth-postgres/internal/service/validation"
 	"github.com/fgeck/gotth-postgres/templates/views"
 	echo "github.com/labstack/echo/v4"
 )
@@ -37,6 +39,19 @@ func (r *RegisterHandler) RegisterUserHandler(ctx echo.Context) error {
 
 	user, err := r.loginRegisterService.RegisterUser(ctx.Request().Context(), username, email, password)
 	if err != nil {
+		var policyErr *validation.PasswordPolicyError
+		if errors.As(err, &policyErr) {
+			jsonErr := ctx.JSON(http.StatusBadRequest, map[string]any{
+				"error":      policyErr.Error(),
+				"violations": policyErr.Violations,
+			})
+			if jsonErr != nil {
+				return fmt.Errorf("failed to send error response: %w", jsonErr)
+			}
+
+			return err
+		}
+
 		var userfacingErr *customErrors.UserFacingError
 		if errors.As(err, &userfacingErr) {
 			jsonErr := ctx.JSON(http.StatusBadRequest, map[string]string{"error": userfacingErr.Error()})
@@ -46,8 +61,29 @@ func (r *RegisterHandler) RegisterUserHandler(ctx echo.Context) error {
 
 			return err
 		}
+		if errors.Is(err, registration.ErrRegistrationClosed) ||
+			errors.Is(err, registration.ErrInvitationRequired) ||
+			errors.Is(err, registration.ErrEmailDomainRejected) {
+			jsonErr := ctx.JSON(http.StatusForbidden, map[string]string{"error": registrationErrorMessage(err)})
+			if jsonErr != nil {
+				return fmt.Errorf("failed to send error response: %w", jsonErr)
+			}
+
+			return err
+		}
 		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to register user"})
 	}
 
 	return ctx.JSON(http.StatusCreated, user)
 }
+
+func registrationErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, registration.ErrInvitationRequired):
+		return "Registration is by invitation only"
+	case errors.Is(err, registration.ErrEmailDomainRejected):
+		return "Registration is not open for this email address"
+	default:
+		return "Registration is closed"
+	}
+}
@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/fgeck/gotth-postgres/internal/service/serviceAccount"
+	"github.com/fgeck/gotth-postgres/internal/service/validation"
+	"github.com/google/uuid"
+	echo "github.com/labstack/echo/v4"
+)
+
+const (
+	TOKEN_CACHE_CONTROL = "no-store"
+)
+
+type ServiceAccountHandler struct {
+	serviceAccountService serviceAccount.ServiceAccountServiceInterface
+}
+
+func NewServiceAccountHandler(serviceAccountService serviceAccount.ServiceAccountServiceInterface) *ServiceAccountHandler {
+	return &ServiceAccountHandler{
+		serviceAccountService: serviceAccountService,
+	}
+}
+
+type createServiceAccountRequest struct {
+	Name        string   `json:"name" form:"name"`
+	Description string   `json:"description" form:"description"`
+	Scopes      []string `json:"scopes" form:"scopes"`
+}
+
+type updateServiceAccountScopesRequest struct {
+	Scopes []string `json:"scopes" form:"scopes"`
+}
+
+type addPublicKeyRequest struct {
+	PublicKey string `json:"publicKey" form:"publicKey"`
+}
+
+func (h *ServiceAccountHandler) ListServiceAccountsHandler(ctx echo.Context) error {
+	accounts, err := h.serviceAccountService.ListServiceAccounts(ctx.Request().Context())
+	if err != nil {
+		return h.sendError(ctx, "failed to list service accounts", err)
+	}
+
+	return ctx.JSON(http.StatusOK, accounts)
+}
+
+func (h *ServiceAccountHandler) CreateServiceAccountHandler(ctx echo.Context) error {
+	claims, err := currentClaims(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	userID, err := uuid.Parse(claims.UserId)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	var request createServiceAccountRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid service account"})
+	}
+
+	account, err := h.serviceAccountService.CreateServiceAccount(
+		ctx.Request().Context(),
+		userID,
+		claims.Permissions,
+		request.Name,
+		request.Description,
+		request.Scopes,
+	)
+	if err != nil {
+		return h.sendError(ctx, "failed to create service account", err)
+	}
+
+	return ctx.JSON(http.StatusCreated, account)
+}
+
+func (h *ServiceAccountHandler) GetServiceAccountHandler(ctx echo.Context) error {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid service account id"})
+	}
+
+	account, err := h.serviceAccountService.GetServiceAccount(ctx.Request().Context(), id)
+	if err != nil {
+		return h.sendError(ctx, "failed to get service account", err)
+	}
+
+	return ctx.JSON(http.StatusOK, account)
+}
+
+// UpdateServiceAccountScopesHandler replaces the scopes. Tokens the account
+// holds stop working and have to be requested again.
+func (h *ServiceAccountHandler) UpdateServiceAccountScopesHandler(ctx echo.Context) error {
+	claims, err := currentClaims(ctx)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid service account id"})
+	}
+	var request updateServiceAccountScopesRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid scopes"})
+	}
+
+	account, err := h.serviceAccountService.SetScopes(ctx.Request().Context(), claims.Permissions, id, request.Scopes)
+	if err != nil {
+		return h.sendError(ctx, "failed to update service account scopes", err)
+	}
+
+	return ctx.JSON(http.StatusOK, account)
+}
+
+func (h *ServiceAccountHandler) DeleteServiceAccountHandler(ctx echo.Context) error {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid service account id"})
+	}
+
+	if err := h.serviceAccountService.DeleteServiceAccount(ctx.Request().Context(), id); err != nil {
+		return h.sendError(ctx, "failed to delete service account", err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// CreateClientSecretHandler answers with the secret itself, which is not
+// shown again.
+func (h *ServiceAccountHandler) CreateClientSecretHandler(ctx echo.Context) error {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid service account id"})
+	}
+
+	created, err := h.serviceAccountService.CreateClientSecret(ctx.Request().Context(), id)
+	if err != nil {
+		return h.sendError(ctx, "failed to create client secret", err)
+	}
+
+	return ctx.JSON(http.StatusCreated, created)
+}
+
+func (h *ServiceAccountHandler) AddPublicKeyHandler(ctx echo.Context) error {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid service account id"})
+	}
+	var request addPublicKeyRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid public key"})
+	}
+
+	credential, err := h.serviceAccountService.AddPublicKey(ctx.Request().Context(), id, request.PublicKey)
+	if err != nil {
+		return h.sendError(ctx, "failed to add public key", err)
+	}
+
+	return ctx.JSON(http.StatusCreated, credential)
+}
+
+func (h *ServiceAccountHandler) DeleteCredentialHandler(ctx echo.Context) error {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid service account id"})
+	}
+	credentialID, err := uuid.Parse(ctx.Param("credentialId"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid credential id"})
+	}
+
+	if err := h.serviceAccountService.DeleteCredential(ctx.Request().Context(), id, credentialID); err != nil {
+		return h.sendError(ctx, "failed to delete credential", err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// TokenHandler is the OAuth2 token endpoint for the client_credentials
+// grant. Clients send their credentials in the form or, for secrets, with
+// HTTP Basic authentication.
+func (h *ServiceAccountHandler) TokenHandler(ctx echo.Context) error {
+	ctx.Response().Header().Set(echo.HeaderCacheControl, TOKEN_CACHE_CONTROL)
+
+	request := serviceAccount.ClientCredentialsRequest{
+		GrantType:           ctx.FormValue("grant_type"),
+		ClientID:            ctx.FormValue("client_id"),
+		ClientSecret:        ctx.FormValue("client_secret"),
+		ClientAssertionType: ctx.FormValue("client_assertion_type"),
+		ClientAssertion:     ctx.FormValue("client_assertion"),
+		Scope:               ctx.FormValue("scope"),
+	}
+	if username, password, ok := ctx.Request().BasicAuth(); ok {
+		// Only one way of authenticating is allowed per request.
+		if request.ClientSecret != "" || request.ClientAssertion != "" {
+			return h.sendTokenError(ctx, "failed to issue access token", serviceAccount.ErrInvalidRequest)
+		}
+		// Basic credentials are form-encoded before, see RFC 6749 2.3.1.
+		clientID, idErr := url.QueryUnescape(username)
+		clientSecret, secretErr := url.QueryUnescape(password)
+		if idErr != nil || secretErr != nil {
+			return h.sendTokenError(ctx, "failed to issue access token", serviceAccount.ErrInvalidRequest)
+		}
+		request.ClientID = clientID
+		request.ClientSecret = clientSecret
+	}
+
+	token, err := h.serviceAccountService.IssueToken(ctx.Request().Context(), request)
+	if err != nil {
+		return h.sendTokenError(ctx, "failed to issue access token", err)
+	}
+
+	return ctx.JSON(http.StatusOK, token)
+}
+
+func (h *ServiceAccountHandler) sendError(ctx echo.Context, action string, err error) error {
+	status := http.StatusInternalServerError
+	message := "Something went wrong"
+	switch {
+	case errors.Is(err, serviceAccount.ErrServiceAccountNotFound):
+		status = http.StatusNotFound
+		message = "Service account not found"
+	case errors.Is(err, serviceAccount.ErrCredentialNotFound):
+		status = http.StatusNotFound
+		message = "Credential not found"
+	case errors.Is(err, serviceAccount.ErrScopeNotHeld):
+		status = http.StatusForbidden
+		message = err.Error()
+	case errors.Is(err, serviceAccount.ErrNameTaken):
+		status = http.StatusConflict
+		message = "This name is already taken"
+	case errors.Is(err, serviceAccount.ErrUnknownScope),
+		errors.Is(err, serviceAccount.ErrInvalidDescription),
+		errors.Is(err, serviceAccount.ErrInvalidPublicKey),
+		errors.Is(err, validation.ErrInvalidUsername):
+		status = http.StatusBadRequest
+		message = err.Error()
+	}
+
+	wrappedErr := fmt.Errorf("%s: %w", action, err)
+	if jsonErr := ctx.JSON(status, map[string]string{"error": message}); jsonErr != nil {
+		return fmt.Errorf("failed to send error response: %w", jsonErr)
+	}
+
+	return wrappedErr
+}
+
+// sendTokenError answers with the error codes of RFC 6749 5.2, which clients
+// of the token endpoint expect instead of the messages of the other APIs.
+func (h *ServiceAccountHandler) sendTokenError(ctx echo.Context, action string, err error) error {
+	status := http.StatusBadRequest
+	code := ""
+	switch {
+	case errors.Is(err, serviceAccount.ErrInvalidClient):
+		status = http.StatusUnauthorized
+		code = "invalid_client"
+		if _, _, ok := ctx.Request().BasicAuth(); ok {
+			ctx.Response().Header().Set(echo.HeaderWWWAuthenticate, `Basic realm="token"`)
+		}
+	case errors.Is(err, serviceAccount.ErrInvalidRequest):
+		code = "invalid_request"
+	case errors.Is(err, serviceAccount.ErrUnsupportedGrantType):
+		code = "unsupported_grant_type"
+	case errors.Is(err, serviceAccount.ErrInvalidScope):
+		code = "invalid_scope"
+	default:
+		status = http.StatusInternalServerError
+		code = "server_error"
+	}
+
+	wrappedErr := fmt.Errorf("%s: %w", action, err)
+	if jsonErr := ctx.JSON(status, map[string]string{"error": code}); jsonErr != nil {
+		return fmt.Errorf("failed to send error response: %w", jsonErr)
+	}
+
+	return wrappedErr
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/fgeck/gotth-postgres/internal/service/loginRegister"
+	"github.com/fgeck/gotth-postgres/internal/service/session"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	echo "github.com/labstack/echo/v4"
+)
+
+type TokenHandler struct {
+	loginRegisterService loginRegister.LoginRegisterServiceInterface
+}
+
+func NewTokenHandler(loginRegisterService loginRegister.LoginRegisterServiceInterface) *TokenHandler {
+	return &TokenHandler{
+		loginRegisterService: loginRegisterService,
+	}
+}
+
+func (h *TokenHandler) RefreshTokenHandler(ctx echo.Context) error {
+	refreshToken := cookieValue(ctx, REFRESH_TOKEN_COOKIE)
+	if refreshToken == "" {
+		return ctx.JSON(http.StatusUnauthorized, map[string]string{"error": "Missing refresh token"})
+	}
+
+	tokens, err := h.loginRegisterService.RefreshTokens(ctx.Request().Context(), refreshToken)
+	if err != nil {
+		status := http.StatusInternalServerError
+		message := "Failed to refresh token"
+		if errors.Is(err, session.ErrInvalidRefreshToken) ||
+			errors.Is(err, session.ErrRefreshTokenExpired) ||
+			errors.Is(err, session.ErrRefreshTokenReused) ||
+			errors.Is(err, user.ErrUserDisabled) {
+			status = http.StatusUnauthorized
+			message = "Invalid refresh token"
+		}
+
+		wrappedErr := fmt.Errorf("failed to refresh token: %w", err)
+		if jsonErr := ctx.JSON(status, map[string]string{"error": message}); jsonErr != nil {
+			return fmt.Errorf("failed to send error response: %w", jsonErr)
+		}
+
+		return wrappedErr
+	}
+	setAuthCookies(ctx, tokens)
+
+	if err := ctx.String(http.StatusOK, "success"); err != nil {
+		return fmt.Errorf("failed to send success response: %w", err)
+	}
+
+	return nil
+}
@@ -1,31 +1,164 @@
 package middleware
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fgeck/gotth-postgres/internal/service/personalAccessToken"
 	"github.com/fgeck/gotth-postgres/internal/service/security/jwt"
+	"github.com/fgeck/gotth-postgres/internal/service/session"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
 	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	echojwt "github.com/labstack/echo-jwt/v4"
 	"github.com/labstack/echo/v4"
 )
 
+var (
+	ErrTokenRevoked = echo.NewHTTPError(http.StatusUnauthorized, "token has been revoked")
+)
+
 type AuthenticationMiddlewareInterface interface {
 	JwtAuthMiddleware(jwtSecret string) echo.MiddlewareFunc
+	RequireSession() echo.MiddlewareFunc
 }
 type AuthenticationMiddleware struct {
-	jwtSecret string
+	keyring                    *jwt.Keyring
+	sessionService             session.SessionServiceInterface
+	personalAccessTokenService personalAccessToken.PersonalAccessTokenServiceInterface
 }
 
-func NewAuthenticationMiddleware(jwtSecret string) *AuthenticationMiddleware {
+func NewAuthenticationMiddleware(
+	keyring *jwt.Keyring,
+	sessionService session.SessionServiceInterface,
+	personalAccessTokenService personalAccessToken.PersonalAccessTokenServiceInterface,
+) *AuthenticationMiddleware {
 	return &AuthenticationMiddleware{
-		jwtSecret: jwtSecret,
+		keyring:                    keyring,
+		sessionService:             sessionService,
+		personalAccessTokenService: personalAccessTokenService,
 	}
 }
 
+// JwtAuthMiddleware accepts the access token of a session in the token cookie
+// and, in an "Authorization: Bearer" header, a personal access token or an
+// access token issued to a service account. All of them end up as
+// *jwt.JwtCustomClaims in the "user" context key, so handlers and
+// RequirePermission do not need to tell them apart.
 func (a *AuthenticationMiddleware) JwtAuthMiddleware() echo.MiddlewareFunc {
-	return echojwt.WithConfig(echojwt.Config{
-		SigningKey:  []byte(a.jwtSecret),
-		TokenLookup: "cookie:token",
+	jwtMiddleware := echojwt.WithConfig(echojwt.Config{
+		KeyFunc:     a.keyring.Keyfunc,
+		TokenLookup: "header:Authorization:Bearer ,cookie:token",
 		NewClaimsFunc: func(c echo.Context) gojwt.Claims {
 			return new(jwt.JwtCustomClaims)
 		},
 	})
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		checkAccessToken := jwtMiddleware(func(c echo.Context) error {
+			token, ok := c.Get("user").(*gojwt.Token)
+			if !ok {
+				return echo.ErrUnauthorized
+			}
+			claims, ok := token.Claims.(*jwt.JwtCustomClaims)
+			if !ok {
+				return echo.ErrUnauthorized
+			}
+			// Purpose-bound tokens such as the MFA pending token are no
+			// access tokens. They are recognized by their audience too.
+			if claims.Purpose != "" || len(claims.Audience) > 0 {
+				return echo.ErrUnauthorized
+			}
+
+			revoked, err := a.isRevoked(c, claims)
+			if err != nil {
+				return fmt.Errorf("failed to check token revocation: %w", err)
+			}
+			if revoked {
+				return ErrTokenRevoked
+			}
+
+			return next(c)
+		})
+
+		return func(c echo.Context) error {
+			if token, ok := bearerToken(c); ok && strings.HasPrefix(token, personalAccessToken.TOKEN_PREFIX) {
+				return a.authenticatePersonalAccessToken(c, token, next)
+			}
+
+			return checkAccessToken(c)
+		}
+	}
+}
+
+// RequireSession rejects requests authenticated with a personal access token,
+// whatever its scopes, or with the access token of a service account. Routes
+// managing credentials, sessions or the account itself use it, so that a
+// leaked token cannot be turned into a login, for example by registering a
+// passkey. It has to run after JwtAuthMiddleware.
+func (a *AuthenticationMiddleware) RequireSession() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if _, ok := personalAccessToken.TokenFromContext(c.Request().Context()); ok {
+				return echo.ErrForbidden
+			}
+			if token, ok := c.Get("user").(*gojwt.Token); ok {
+				if claims, ok := token.Claims.(*jwt.JwtCustomClaims); ok && claims.IsServiceAccount() {
+					return echo.ErrForbidden
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func (a *AuthenticationMiddleware) authenticatePersonalAccessToken(c echo.Context, token string, next echo.HandlerFunc) error {
+	ctx := c.Request().Context()
+	claims, err := a.personalAccessTokenService.Authenticate(ctx, token)
+	if errors.Is(err, personalAccessToken.ErrInvalidToken) ||
+		errors.Is(err, user.ErrUserDisabled) ||
+		errors.Is(err, user.ErrUserPendingApproval) {
+		return echo.ErrUnauthorized
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check personal access token: %w", err)
+	}
+	tokenID, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+
+	c.Set("user", &gojwt.Token{Claims: claims, Valid: true})
+	c.SetRequest(c.Request().WithContext(personalAccessToken.WithToken(ctx, tokenID)))
+
+	return next(c)
+}
+
+func bearerToken(c echo.Context) (string, bool) {
+	header := c.Request().Header.Get(echo.HeaderAuthorization)
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	return strings.TrimSpace(token), true
+}
+
+func (a *AuthenticationMiddleware) isRevoked(c echo.Context, claims *jwt.JwtCustomClaims) (bool, error) {
+	// An unparsable userId simply matches no per-user revocation.
+	userID, err := uuid.Parse(claims.UserId)
+	if err != nil {
+		userID = uuid.Nil
+	}
+
+	var issuedAt time.Time
+	if claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Time
+	}
+
+	return a.sessionService.IsAccessTokenRevoked(c.Request().Context(), claims.ID, userID, issuedAt)
 }
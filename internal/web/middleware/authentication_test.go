@@ -6,22 +6,48 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/fgeck/gotth-postgres/internal/service/personalAccessToken"
+	personalAccessTokenMocks "github.com/fgeck/gotth-postgres/internal/service/personalAccessToken/mocks"
 	"github.com/fgeck/gotth-postgres/internal/service/security/jwt"
+	sessionMocks "github.com/fgeck/gotth-postgres/internal/service/session/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/user"
 	mw "github.com/fgeck/gotth-postgres/internal/web/middleware"
 	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
+func setupJwtAuthMiddlewareTest(t *testing.T, jwtSecret string) (*sessionMocks.MockSessionServiceInterface, echo.MiddlewareFunc) {
+	t.Helper()
+	mockSessionService := sessionMocks.NewMockSessionServiceInterface(t)
+	mockPersonalAccessTokenService := personalAccessTokenMocks.NewMockPersonalAccessTokenServiceInterface(t)
+	middleware := mw.NewAuthenticationMiddleware(jwt.NewHmacKeyring(jwtSecret), mockSessionService, mockPersonalAccessTokenService).JwtAuthMiddleware()
+	return mockSessionService, middleware
+}
+
+func setupPersonalAccessTokenAuthTest(t *testing.T) (*personalAccessTokenMocks.MockPersonalAccessTokenServiceInterface, echo.MiddlewareFunc) {
+	t.Helper()
+	mockPersonalAccessTokenService := personalAccessTokenMocks.NewMockPersonalAccessTokenServiceInterface(t)
+	middleware := mw.NewAuthenticationMiddleware(
+		jwt.NewHmacKeyring("testsecret"),
+		sessionMocks.NewMockSessionServiceInterface(t),
+		mockPersonalAccessTokenService,
+	).JwtAuthMiddleware()
+	return mockPersonalAccessTokenService, middleware
+}
+
 func TestJwtAuthMiddleware(t *testing.T) {
 	t.Parallel()
 	jwtSecret := "testsecret"
-	middleware := mw.NewAuthenticationMiddleware(jwtSecret).JwtAuthMiddleware()
 
 	t.Run("No token provided", func(t *testing.T) {
 		t.Parallel()
+		_, middleware := setupJwtAuthMiddlewareTest(t, jwtSecret)
 		e := echo.New()
 		req := httptest.NewRequest(http.MethodGet, "/", nil)
 		rec := httptest.NewRecorder()
@@ -40,6 +66,7 @@ func TestJwtAuthMiddleware(t *testing.T) {
 
 	t.Run("Invalid token provided", func(t *testing.T) {
 		t.Parallel()
+		_, middleware := setupJwtAuthMiddlewareTest(t, jwtSecret)
 		e := echo.New()
 		req := httptest.NewRequest(http.MethodGet, "/", nil)
 		req.AddCookie(&http.Cookie{Name: "token", Value: "invalidtoken"})
@@ -59,6 +86,8 @@ func TestJwtAuthMiddleware(t *testing.T) {
 
 	t.Run("Valid token provided", func(t *testing.T) {
 		t.Parallel()
+		mockSessionService, middleware := setupJwtAuthMiddlewareTest(t, jwtSecret)
+		mockSessionService.On("IsAccessTokenRevoked", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
 		e := echo.New()
 		token := gojwt.NewWithClaims(gojwt.SigningMethodHS256, &jwt.JwtCustomClaims{
 			RegisteredClaims: gojwt.RegisteredClaims{
@@ -81,4 +110,258 @@ func TestJwtAuthMiddleware(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 		assert.Equal(t, "success", rec.Body.String())
 	})
+	t.Run("Revoked token provided", func(t *testing.T) {
+		t.Parallel()
+		mockSessionService, middleware := setupJwtAuthMiddlewareTest(t, jwtSecret)
+		userID := uuid.New()
+		issuedAt := time.Now().Truncate(time.Second)
+		mockSessionService.On("IsAccessTokenRevoked", mock.Anything, "revoked-jti", userID, issuedAt).Return(true, nil)
+
+		e := echo.New()
+		token := gojwt.NewWithClaims(gojwt.SigningMethodHS256, &jwt.JwtCustomClaims{
+			UserId: userID.String(),
+			RegisteredClaims: gojwt.RegisteredClaims{
+				ID:       "revoked-jti",
+				Issuer:   "test",
+				IssuedAt: gojwt.NewNumericDate(issuedAt),
+			},
+		})
+		tokenString, _ := token.SignedString([]byte(jwtSecret))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.AddCookie(&http.Cookie{Name: "token", Value: tokenString})
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		handler := middleware(func(c echo.Context) error {
+			return c.String(http.StatusOK, "success")
+		})
+
+		err := handler(c)
+		require.Error(t, err)
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+	})
+
+	t.Run("MFA pending token provided", func(t *testing.T) {
+		t.Parallel()
+		_, middleware := setupJwtAuthMiddlewareTest(t, jwtSecret)
+		e := echo.New()
+		token := gojwt.NewWithClaims(gojwt.SigningMethodHS256, &jwt.JwtCustomClaims{
+			UserId:  uuid.New().String(),
+			Purpose: jwt.PURPOSE_MFA_PENDING,
+			RegisteredClaims: gojwt.RegisteredClaims{
+				Issuer: "test",
+			},
+		})
+		tokenString, _ := token.SignedString([]byte(jwtSecret))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.AddCookie(&http.Cookie{Name: "token", Value: tokenString})
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		handler := middleware(func(c echo.Context) error {
+			return c.String(http.StatusOK, "success")
+		})
+
+		err := handler(c)
+		require.ErrorIs(t, err, echo.ErrUnauthorized)
+	})
+
+	t.Run("Token with an audience provided", func(t *testing.T) {
+		t.Parallel()
+		_, middleware := setupJwtAuthMiddlewareTest(t, jwtSecret)
+		e := echo.New()
+		token := gojwt.NewWithClaims(gojwt.SigningMethodHS256, &jwt.JwtCustomClaims{
+			UserId: uuid.New().String(),
+			RegisteredClaims: gojwt.RegisteredClaims{
+				Issuer:   "test",
+				Audience: gojwt.ClaimStrings{"test/" + jwt.PURPOSE_PASSWORD_CHANGE},
+			},
+		})
+		tokenString, _ := token.SignedString([]byte(jwtSecret))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.AddCookie(&http.Cookie{Name: "token", Value: tokenString})
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		handler := middleware(func(c echo.Context) error {
+			return c.String(http.StatusOK, "success")
+		})
+
+		err := handler(c)
+		require.ErrorIs(t, err, echo.ErrUnauthorized)
+	})
+
+	t.Run("Valid bearer token provided", func(t *testing.T) {
+		t.Parallel()
+		mockSessionService, middleware := setupJwtAuthMiddlewareTest(t, jwtSecret)
+		mockSessionService.On("IsAccessTokenRevoked", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+		e := echo.New()
+		token := gojwt.NewWithClaims(gojwt.SigningMethodHS256, &jwt.JwtCustomClaims{
+			UserId:      uuid.New().String(),
+			Permissions: []string{"users:read"},
+			RegisteredClaims: gojwt.RegisteredClaims{
+				Issuer: "test",
+			},
+		})
+		tokenString, _ := token.SignedString([]byte(jwtSecret))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+tokenString)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		handler := middleware(func(c echo.Context) error {
+			_, ok := personalAccessToken.TokenFromContext(c.Request().Context())
+			assert.False(t, ok)
+			return c.String(http.StatusOK, "success")
+		})
+
+		require.NoError(t, handler(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestPersonalAccessTokenAuth(t *testing.T) {
+	t.Parallel()
+	const pat = personalAccessToken.TOKEN_PREFIX + "secret"
+
+	t.Run("accepts a valid token", func(t *testing.T) {
+		t.Parallel()
+		mockService, middleware := setupPersonalAccessTokenAuthTest(t)
+		tokenID := uuid.New()
+		claims := &jwt.JwtCustomClaims{
+			UserId:           uuid.New().String(),
+			Permissions:      []string{"users:read"},
+			RegisteredClaims: gojwt.RegisteredClaims{ID: tokenID.String()},
+		}
+		mockService.On("Authenticate", mock.Anything, pat).Return(claims, nil)
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+pat)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		handler := middleware(func(c echo.Context) error {
+			token := c.Get("user").(*gojwt.Token)
+			assert.Same(t, claims, token.Claims)
+			markedID, ok := personalAccessToken.TokenFromContext(c.Request().Context())
+			assert.True(t, ok)
+			assert.Equal(t, tokenID, markedID)
+			return c.String(http.StatusOK, "success")
+		})
+
+		require.NoError(t, handler(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("rejects an invalid token", func(t *testing.T) {
+		t.Parallel()
+		mockService, middleware := setupPersonalAccessTokenAuthTest(t)
+		mockService.On("Authenticate", mock.Anything, pat).Return(nil, personalAccessToken.ErrInvalidToken)
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+pat)
+		c := e.NewContext(req, httptest.NewRecorder())
+
+		handler := middleware(func(c echo.Context) error {
+			return c.String(http.StatusOK, "success")
+		})
+
+		require.ErrorIs(t, handler(c), echo.ErrUnauthorized)
+	})
+}
+
+func TestRequireSession(t *testing.T) {
+	t.Parallel()
+	const pat = personalAccessToken.TOKEN_PREFIX + "secret"
+	jwtSecret := "testsecret"
+
+	t.Run("Rejects a personal access token without scopes", func(t *testing.T) {
+		t.Parallel()
+		mockService := personalAccessTokenMocks.NewMockPersonalAccessTokenServiceInterface(t)
+		authenticationMiddleware := mw.NewAuthenticationMiddleware(
+			jwt.NewHmacKeyring(jwtSecret),
+			sessionMocks.NewMockSessionServiceInterface(t),
+			mockService,
+		)
+		mockService.On("Authenticate", mock.Anything, pat).Return(&jwt.JwtCustomClaims{
+			UserId:           uuid.New().String(),
+			RegisteredClaims: gojwt.RegisteredClaims{ID: uuid.New().String()},
+		}, nil)
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/api/passkeys/register/begin", nil)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+pat)
+		c := e.NewContext(req, httptest.NewRecorder())
+
+		handler := authenticationMiddleware.JwtAuthMiddleware()(authenticationMiddleware.RequireSession()(func(c echo.Context) error {
+			return c.String(http.StatusOK, "success")
+		}))
+
+		require.ErrorIs(t, handler(c), echo.ErrForbidden)
+	})
+
+	t.Run("Rejects the access token of a service account", func(t *testing.T) {
+		t.Parallel()
+		mockSessionService := sessionMocks.NewMockSessionServiceInterface(t)
+		authenticationMiddleware := mw.NewAuthenticationMiddleware(
+			jwt.NewHmacKeyring(jwtSecret),
+			mockSessionService,
+			personalAccessTokenMocks.NewMockPersonalAccessTokenServiceInterface(t),
+		)
+		mockSessionService.On("IsAccessTokenRevoked", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+		token := gojwt.NewWithClaims(gojwt.SigningMethodHS256, &jwt.JwtCustomClaims{
+			UserId:           uuid.New().String(),
+			AccountType:      user.ACCOUNT_TYPE_SERVICE,
+			RegisteredClaims: gojwt.RegisteredClaims{Issuer: "test"},
+		})
+		tokenString, _ := token.SignedString([]byte(jwtSecret))
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/api/account/profile", nil)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+tokenString)
+		c := e.NewContext(req, httptest.NewRecorder())
+
+		handler := authenticationMiddleware.JwtAuthMiddleware()(authenticationMiddleware.RequireSession()(func(c echo.Context) error {
+			return c.String(http.StatusOK, "success")
+		}))
+
+		require.ErrorIs(t, handler(c), echo.ErrForbidden)
+	})
+
+	t.Run("Accepts the access token of a session", func(t *testing.T) {
+		t.Parallel()
+		mockSessionService := sessionMocks.NewMockSessionServiceInterface(t)
+		authenticationMiddleware := mw.NewAuthenticationMiddleware(
+			jwt.NewHmacKeyring(jwtSecret),
+			mockSessionService,
+			personalAccessTokenMocks.NewMockPersonalAccessTokenServiceInterface(t),
+		)
+		mockSessionService.On("IsAccessTokenRevoked", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+		token := gojwt.NewWithClaims(gojwt.SigningMethodHS256, &jwt.JwtCustomClaims{
+			UserId:           uuid.New().String(),
+			RegisteredClaims: gojwt.RegisteredClaims{Issuer: "test"},
+		})
+		tokenString, _ := token.SignedString([]byte(jwtSecret))
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/api/passkeys/register/begin", nil)
+		req.AddCookie(&http.Cookie{Name: "token", Value: tokenString})
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		handler := authenticationMiddleware.JwtAuthMiddleware()(authenticationMiddleware.RequireSession()(func(c echo.Context) error {
+			return c.String(http.StatusOK, "success")
+		}))
+
+		require.NoError(t, handler(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
 }
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"slices"
 	"strings"
 
 	"github.com/fgeck/gotth-postgres/internal/service/security/jwt"
@@ -11,6 +12,7 @@ import (
 
 type AuthorizationMiddlewareInterface interface {
 	RequireAdminMiddleware() echo.MiddlewareFunc
+	RequirePermission(permission string) echo.MiddlewareFunc
 }
 
 type AuthorizationMiddleware struct{}
@@ -22,18 +24,41 @@ func NewAuthorizationMiddleware() *AuthorizationMiddleware {
 func (a *AuthorizationMiddleware) RequireAdminMiddleware() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			token, ok := c.Get("user").(*gojwt.Token)
+			claims, ok := claimsFromContext(c)
 			if !ok {
 				return echo.ErrForbidden
 			}
-			claims, ok := token.Claims.(*jwt.JwtCustomClaims)
+			if claims.UserRole == "" || strings.ToUpper(claims.UserRole) != user.UserRoleAdmin.Name {
+				return echo.ErrForbidden
+			}
+			return next(c)
+		}
+	}
+}
+
+// RequirePermission checks the permissions carried in the access token, so it
+// has to run after JwtAuthMiddleware.
+func (a *AuthorizationMiddleware) RequirePermission(permission string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			claims, ok := claimsFromContext(c)
 			if !ok {
 				return echo.ErrForbidden
 			}
-			if claims.UserRole == "" || strings.ToUpper(claims.UserRole) != user.UserRoleAdmin.Name {
+			if !slices.Contains(claims.Permissions, permission) {
 				return echo.ErrForbidden
 			}
 			return next(c)
 		}
 	}
 }
+
+func claimsFromContext(c echo.Context) (*jwt.JwtCustomClaims, bool) {
+	token, ok := c.Get("user").(*gojwt.Token)
+	if !ok {
+		return nil, false
+	}
+	claims, ok := token.Claims.(*jwt.JwtCustomClaims)
+
+	return claims, ok
+}
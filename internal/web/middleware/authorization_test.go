@@ -119,3 +119,67 @@ func TestRequireAdminMiddleware(t *testing.T) {
 		assert.Equal(t, "success", rec.Body.String())
 	})
 }
+
+func TestRequirePermission(t *testing.T) {
+	t.Parallel()
+	middleware := mw.NewAuthorizationMiddleware().RequirePermission("users:write")
+
+	t.Run("No token in context", func(t *testing.T) {
+		t.Parallel()
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		handler := middleware(func(c echo.Context) error {
+			return c.String(http.StatusOK, "success")
+		})
+
+		err := handler(c)
+		require.Error(t, err)
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusForbidden, httpErr.Code)
+	})
+
+	t.Run("Permission is missing", func(t *testing.T) {
+		t.Parallel()
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		claims := &jwt.JwtCustomClaims{UserRole: user.UserRoleAdmin.Name, Permissions: []string{"users:read"}}
+		token := &gojwt.Token{Claims: claims}
+		c.Set("user", token)
+
+		handler := middleware(func(c echo.Context) error {
+			return c.String(http.StatusOK, "success")
+		})
+
+		err := handler(c)
+		require.Error(t, err)
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusForbidden, httpErr.Code)
+	})
+
+	t.Run("Permission is granted", func(t *testing.T) {
+		t.Parallel()
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		claims := &jwt.JwtCustomClaims{UserRole: user.UserRoleUser.Name, Permissions: []string{"users:read", "users:write"}}
+		token := &gojwt.Token{Claims: claims}
+		c.Set("user", token)
+
+		handler := middleware(func(c echo.Context) error {
+			return c.String(http.StatusOK, "success")
+		})
+
+		err := handler(c)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "success", rec.Body.String())
+	})
+}
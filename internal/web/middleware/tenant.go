@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/fgeck/gotth-postgres/internal/service/organization"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	ErrTenantChanged = echo.NewHTTPError(http.StatusUnauthorized, "organization role has changed")
+)
+
+type TenantMiddlewareInterface interface {
+	RequireTenant() echo.MiddlewareFunc
+}
+
+type TenantMiddleware struct {
+	organizationService organization.OrganizationServiceInterface
+}
+
+func NewTenantMiddleware(organizationService organization.OrganizationServiceInterface) *TenantMiddleware {
+	return &TenantMiddleware{
+		organizationService: organizationService,
+	}
+}
+
+// RequireTenant scopes the request to the organization of the access token,
+// see organization.WithTenant. The repository.TenantQuerier of the services
+// then rejects queries of other organizations. It has to run after
+// JwtAuthMiddleware. The
+// membership is checked on every request, so removed members lose access
+// right away and a changed role asks the client to refresh its tokens.
+func (t *TenantMiddleware) RequireTenant() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			claims, ok := claimsFromContext(c)
+			if !ok || claims.TenantId == "" {
+				return echo.ErrForbidden
+			}
+			tenantID, err := uuid.Parse(claims.TenantId)
+			if err != nil {
+				return echo.ErrForbidden
+			}
+			userID, err := uuid.Parse(claims.UserId)
+			if err != nil {
+				return echo.ErrForbidden
+			}
+
+			membership, err := t.organizationService.GetMembership(c.Request().Context(), tenantID, userID)
+			if errors.Is(err, organization.ErrNotMember) {
+				return echo.ErrForbidden
+			}
+			if err != nil {
+				return err
+			}
+			if membership.Role != claims.TenantRole {
+				return ErrTenantChanged
+			}
+
+			ctx := organization.WithTenant(c.Request().Context(), tenantID)
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
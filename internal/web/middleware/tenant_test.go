@@ -0,0 +1,95 @@
+//go:build unittest
+
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fgeck/gotth-postgres/internal/service/organization"
+	organizationMocks "github.com/fgeck/gotth-postgres/internal/service/organization/mocks"
+	"github.com/fgeck/gotth-postgres/internal/service/rbac"
+	"github.com/fgeck/gotth-postgres/internal/service/security/jwt"
+	mw "github.com/fgeck/gotth-postgres/internal/web/middleware"
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequireTenant(t *testing.T) {
+	userID := uuid.New()
+	tenantID := uuid.New()
+
+	newContext := func(claims *jwt.JwtCustomClaims) echo.Context {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		c := e.NewContext(req, httptest.NewRecorder())
+		c.Set("user", &gojwt.Token{Claims: claims})
+		return c
+	}
+	tenantClaims := &jwt.JwtCustomClaims{
+		UserId:     userID.String(),
+		TenantId:   tenantID.String(),
+		TenantRole: rbac.ROLE_ORG_MEMBER,
+	}
+
+	t.Run("scopes the request to the tenant", func(t *testing.T) {
+		organizationService := organizationMocks.NewMockOrganizationServiceInterface(t)
+		organizationService.On("GetMembership", mock.Anything, tenantID, userID).
+			Return(&organization.MembershipDto{OrganizationID: tenantID, Role: rbac.ROLE_ORG_MEMBER}, nil)
+		middleware := mw.NewTenantMiddleware(organizationService).RequireTenant()
+
+		var scopedTo uuid.UUID
+		err := middleware(func(c echo.Context) error {
+			scopedTo, _ = organization.TenantFromContext(c.Request().Context())
+			return nil
+		})(newContext(tenantClaims))
+
+		require.NoError(t, err)
+		assert.Equal(t, tenantID, scopedTo)
+	})
+
+	t.Run("forbids tokens without a tenant", func(t *testing.T) {
+		middleware := mw.NewTenantMiddleware(organizationMocks.NewMockOrganizationServiceInterface(t)).RequireTenant()
+
+		err := middleware(func(c echo.Context) error {
+			return nil
+		})(newContext(&jwt.JwtCustomClaims{UserId: userID.String()}))
+
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusForbidden, httpErr.Code)
+	})
+
+	t.Run("forbids removed members", func(t *testing.T) {
+		organizationService := organizationMocks.NewMockOrganizationServiceInterface(t)
+		organizationService.On("GetMembership", mock.Anything, tenantID, userID).Return(nil, organization.ErrNotMember)
+		middleware := mw.NewTenantMiddleware(organizationService).RequireTenant()
+
+		err := middleware(func(c echo.Context) error {
+			return nil
+		})(newContext(tenantClaims))
+
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusForbidden, httpErr.Code)
+	})
+
+	t.Run("rejects tokens with an outdated role", func(t *testing.T) {
+		organizationService := organizationMocks.NewMockOrganizationServiceInterface(t)
+		organizationService.On("GetMembership", mock.Anything, tenantID, userID).
+			Return(&organization.MembershipDto{OrganizationID: tenantID, Role: rbac.ROLE_ORG_OWNER}, nil)
+		middleware := mw.NewTenantMiddleware(organizationService).RequireTenant()
+
+		err := middleware(func(c echo.Context) error {
+			return nil
+		})(newContext(tenantClaims))
+
+		require.ErrorIs(t, err, mw.ErrTenantChanged)
+	})
+
+}
@@ -10,11 +10,32 @@ import (
 	"time"
 
 	"github.com/fgeck/gotth-postgres/internal/repository"
+	"github.com/fgeck/gotth-postgres/internal/service/account"
 	"github.com/fgeck/gotth-postgres/internal/service/config"
+	"github.com/fgeck/gotth-postgres/internal/service/emailChange"
+	"github.com/fgeck/gotth-postgres/internal/service/emailVerification"
+	"github.com/fgeck/gotth-postgres/internal/service/invitation"
 	"github.com/fgeck/gotth-postgres/internal/service/loginRegister"
+	"github.com/fgeck/gotth-postgres/internal/service/loginThrottle"
+	"github.com/fgeck/gotth-postgres/internal/service/mail"
+	"github.com/fgeck/gotth-postgres/internal/service/mfa"
+	"github.com/fgeck/gotth-postgres/internal/service/organization"
+	"github.com/fgeck/gotth-postgres/internal/service/passkey"
+	"github.com/fgeck/gotth-postgres/internal/service/passwordHistory"
+	"github.com/fgeck/gotth-postgres/internal/service/passwordReset"
+	"github.com/fgeck/gotth-postgres/internal/service/personalAccessToken"
+	"github.com/fgeck/gotth-postgres/internal/service/rbac"
+	"github.com/fgeck/gotth-postgres/internal/service/registration"
+	"github.com/fgeck/gotth-postgres/internal/service/security/encryption"
 	"github.com/fgeck/gotth-postgres/internal/service/security/jwt"
 	"github.com/fgeck/gotth-postgres/internal/service/security/password"
+	"github.com/fgeck/gotth-postgres/internal/service/security/strength"
+	"github.com/fgeck/gotth-postgres/internal/service/security/totp"
+	"github.com/fgeck/gotth-postgres/internal/service/security/webauthn"
+	"github.com/fgeck/gotth-postgres/internal/service/serviceAccount"
+	"github.com/fgeck/gotth-postgres/internal/service/session"
 	"github.com/fgeck/gotth-postgres/internal/service/user"
+	"github.com/fgeck/gotth-postgres/internal/service/userAdmin"
 	"github.com/fgeck/gotth-postgres/internal/service/validation"
 	"github.com/fgeck/gotth-postgres/internal/web/handlers"
 	mw "github.com/fgeck/gotth-postgres/internal/web/middleware"
@@ -28,9 +49,10 @@ import (
 )
 
 const (
-	TWENTY_FOUR_HOURS_IN_SECONDS = 24 * 60 * 60
-	ISSUER                       = "gotth-postgres"
-	CONTEXT_TIMEOUT              = 10 * time.Second
+	FIFTEEN_MINUTES_IN_SECONDS = 15 * 60
+	REFRESH_TOKEN_TTL          = 30 * 24 * time.Hour
+	ISSUER                     = "gotth-postgres"
+	CONTEXT_TIMEOUT            = 10 * time.Second
 )
 
 func InitServer(e *echo.Echo, cfg *config.Config) {
@@ -38,22 +60,154 @@ func InitServer(e *echo.Echo, cfg *config.Config) {
 	ctx, cancel := context.WithTimeout(context.Background(), CONTEXT_TIMEOUT)
 	defer cancel()
 	queries := connectToDatabase(ctx, cfg)
-	createAdminUser(ctx, queries, cfg)
+	passwordService, err := password.NewPasswordService(cfg.App.PasswordHashing)
+	if err != nil {
+		panic(err)
+	}
+	registrationPolicy, err := registration.NewRegistrationPolicy(cfg.App.Registration)
+	if err != nil {
+		panic(err)
+	}
+	createAdminUser(ctx, queries, passwordService, cfg)
 
 	// Services
-	validator := validation.NewValidationService()
+	strengthService := strength.NewStrengthService()
+	validator := validation.NewValidationService(
+		validation.NewPasswordPolicy(cfg.App.PasswordPolicy, strengthService),
+		loadBreachChecker(cfg),
+	)
 	userService := user.NewUserService(queries, validator)
-	passwordService := password.NewPasswordService()
-	jwtService := jwt.NewJwtService(cfg.App.JwtSecret, ISSUER, TWENTY_FOUR_HOURS_IN_SECONDS)
-	loginRegisterService := loginRegister.NewLoginRegisterService(userService, passwordService, jwtService)
+	keyring := loadJwtKeyring(cfg)
+	jwtService := jwt.NewJwtServiceWithKeyring(keyring, ISSUER, FIFTEEN_MINUTES_IN_SECONDS)
+	sessionService := session.NewSessionService(queries, REFRESH_TOKEN_TTL)
+	encryptionService, err := encryption.NewEncryptionService(cfg.App.MfaEncryptionKey)
+	if err != nil {
+		panic(err)
+	}
+	loginThrottleService := loginThrottle.NewLoginThrottleService(queries, cfg.App.LoginThrottle)
+	mfaService := mfa.NewMfaService(queries, userService, totp.NewTotpService(), encryptionService, loginThrottleService, ISSUER)
+	webauthnService := webauthn.NewWebauthnService(cfg.App.Webauthn.RpID, cfg.App.Webauthn.RpName, cfg.App.Webauthn.Origins)
+	passkeyService := passkey.NewPasskeyService(queries, userService, webauthnService)
+	mailer, err := mail.NewMailer(cfg.App.Mail)
+	if err != nil {
+		panic(err)
+	}
+	emailVerificationService := emailVerification.NewEmailVerificationService(
+		queries,
+		userService,
+		jwtService,
+		mailer,
+		cfg.App.PublicUrl,
+		cfg.App.RequireVerifiedEmail,
+	)
+	passwordHistoryService := passwordHistory.NewPasswordHistoryService(
+		queries,
+		userService,
+		passwordService,
+		validator,
+		cfg.App.PasswordHistory,
+	)
+	passwordResetService := passwordReset.NewPasswordResetService(
+		queries,
+		userService,
+		passwordHistoryService,
+		sessionService,
+		mailer,
+		cfg.App.PublicUrl,
+	)
+	emailChangeService := emailChange.NewEmailChangeService(
+		queries,
+		userService,
+		sessionService,
+		mailer,
+		cfg.App.PublicUrl,
+	)
+	accountService := account.NewAccountService(
+		userService,
+		passwordService,
+		passwordHistoryService,
+		sessionService,
+		emailChangeService,
+		passwordResetService,
+		validator,
+	)
+	rbacService := rbac.NewRbacService(queries)
+	// Services with organization scoped queries check them against the tenant
+	// of the request, see RequireTenant.
+	tenantQueries := repository.NewTenantQuerier(queries)
+	organizationService := organization.NewOrganizationService(tenantQueries, userService)
+	personalAccessTokenService := personalAccessToken.NewPersonalAccessTokenService(queries, userService, rbacService)
+	serviceAccountService := serviceAccount.NewServiceAccountService(
+		queries,
+		userService,
+		validator,
+		rbacService,
+		sessionService,
+		jwtService,
+		cfg.App.PublicUrl,
+	)
+	invitationService := invitation.NewInvitationService(
+		tenantQueries,
+		userService,
+		passwordService,
+		validator,
+		mailer,
+		registrationPolicy,
+		cfg.App.PublicUrl,
+	)
+	userAdminService := userAdmin.NewUserAdminService(
+		queries,
+		userService,
+		passwordService,
+		sessionService,
+		rbacService,
+		mailer,
+		cfg.App.PublicUrl,
+	)
+	loginRegisterService := loginRegister.NewLoginRegisterService(
+		userService,
+		passwordService,
+		jwtService,
+		sessionService,
+		mfaService,
+		passkeyService,
+		emailVerificationService,
+		loginThrottleService,
+		passwordResetService,
+		passwordHistoryService,
+		rbacService,
+		organizationService,
+		registrationPolicy,
+	)
 
 	// Handlers
 	registerHandler := handlers.NewRegisterHandler(loginRegisterService)
 	loginHandler := handlers.NewLoginHandler(loginRegisterService)
+	tokenHandler := handlers.NewTokenHandler(loginRegisterService)
+	adminHandler := handlers.NewAdminHandler(sessionService, loginThrottleService, userAdminService, rbacService)
+	jwksHandler := handlers.NewJwksHandler(jwtService)
+	mfaHandler := handlers.NewMfaHandler(mfaService)
+	passkeyHandler := handlers.NewPasskeyHandler(passkeyService, loginRegisterService)
+	emailVerificationHandler := handlers.NewEmailVerificationHandler(emailVerificationService)
+	passwordResetHandler := handlers.NewPasswordResetHandler(passwordResetService)
+	accountHandler := handlers.NewAccountHandler(accountService, loginRegisterService)
+	emailChangeHandler := handlers.NewEmailChangeHandler(emailChangeService)
+	passwordStrengthHandler := handlers.NewPasswordStrengthHandler(strengthService, cfg.App.PasswordPolicy.MinScore)
+	organizationHandler := handlers.NewOrganizationHandler(organizationService, loginRegisterService)
+	invitationHandler := handlers.NewInvitationHandler(invitationService)
+	personalAccessTokenHandler := handlers.NewPersonalAccessTokenHandler(personalAccessTokenService)
+	serviceAccountHandler := handlers.NewServiceAccountHandler(serviceAccountService)
 
 	// Middlewares
-	authenticationMiddleware := mw.NewAuthenticationMiddleware(cfg.App.JwtSecret)
+	authenticationMiddleware := mw.NewAuthenticationMiddleware(keyring, sessionService, personalAccessTokenService)
 	authorizationMiddleware := mw.NewAuthorizationMiddleware()
+	tenantMiddleware := mw.NewTenantMiddleware(organizationService)
+	// Failed logins are throttled per client IP, which must not be taken from
+	// headers the client controls.
+	e.IPExtractor = echo.ExtractIPDirect()
+	if cfg.App.TrustProxy {
+		e.IPExtractor = echo.ExtractIPFromXFFHeader()
+	}
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
 
@@ -63,8 +217,30 @@ func InitServer(e *echo.Echo, cfg *config.Config) {
 	e.GET("/login", loginHandler.LoginRegisterContainerHandler)
 	e.GET("/loginForm", loginHandler.LoginFormHandler)
 	e.POST("/api/login", loginHandler.LoginHandler)
+	e.POST("/api/login/mfa", loginHandler.MfaLoginHandler)
+	e.GET("/change-password", loginHandler.ChangePasswordPageHandler)
+	e.POST("/api/login/password", loginHandler.ExpiredPasswordHandler)
 	e.GET("/registerForm", registerHandler.RegisterFormHandler)
 	e.POST("/api/register", registerHandler.RegisterUserHandler)
+	e.GET("/verify-email", emailVerificationHandler.VerifyEmailHandler)
+	e.POST("/api/verify-email/resend", emailVerificationHandler.ResendVerificationHandler)
+	e.GET("/confirm-email-change", emailChangeHandler.ConfirmEmailChangePageHandler)
+	e.POST("/api/email-change/confirm", emailChangeHandler.ConfirmEmailChangeHandler)
+	e.GET("/revert-email-change", emailChangeHandler.RevertEmailChangePageHandler)
+	e.POST("/api/email-change/revert", emailChangeHandler.RevertEmailChangeHandler)
+	e.GET("/forgot-password", passwordResetHandler.ForgotPasswordPageHandler)
+	e.POST("/api/password/forgot", passwordResetHandler.ForgotPasswordHandler)
+	e.GET("/reset-password", passwordResetHandler.ResetPasswordPageHandler)
+	e.POST("/api/password/reset", passwordResetHandler.ResetPasswordHandler)
+	e.GET("/accept-invite", invitationHandler.AcceptInvitationPageHandler)
+	e.POST("/api/invitations/accept", invitationHandler.AcceptInvitationHandler)
+	e.POST("/api/password/strength", passwordStrengthHandler.PasswordStrengthHandler)
+	e.POST("/api/token/refresh", tokenHandler.RefreshTokenHandler)
+	e.POST(serviceAccount.TOKEN_PATH, serviceAccountHandler.TokenHandler)
+	e.POST("/api/logout", loginHandler.LogoutHandler)
+	e.GET("/.well-known/jwks.json", jwksHandler.JwksHandler)
+	e.POST("/api/passkeys/login/begin", passkeyHandler.BeginLoginHandler)
+	e.POST("/api/passkeys/login/finish", passkeyHandler.FinishLoginHandler)
 
 	// JWT Middleware only
 	res := e.Group("/restricted")
@@ -85,24 +261,144 @@ func InitServer(e *echo.Echo, cfg *config.Config) {
 		return c.String(http.StatusOK, "Welcome "+name+" with role: "+role+"!")
 	})
 
-	// Admin Routes (requires "UserRole" == "admin")
+	// Two-factor authentication management for the logged in user (sessions only)
+	mfaGroup := e.Group("/api/mfa")
+	mfaGroup.Use(authenticationMiddleware.JwtAuthMiddleware(), authenticationMiddleware.RequireSession())
+	mfaGroup.POST("/enroll", mfaHandler.EnrollHandler)
+	mfaGroup.POST("/confirm", mfaHandler.ConfirmHandler)
+	mfaGroup.POST("/disable", mfaHandler.DisableHandler)
+
+	// Account settings of the logged in user (sessions only)
+	e.GET("/account", accountHandler.AccountPageHandler, authenticationMiddleware.JwtAuthMiddleware())
+	accountGroup := e.Group("/api/account")
+	accountGroup.Use(authenticationMiddleware.JwtAuthMiddleware(), authenticationMiddleware.RequireSession())
+	accountGroup.POST("/profile", accountHandler.UpdateProfileHandler)
+	accountGroup.POST("/password", accountHandler.ChangePasswordHandler)
+	accountGroup.GET("/tokens", personalAccessTokenHandler.ListTokensHandler)
+	accountGroup.POST("/tokens", personalAccessTokenHandler.CreateTokenHandler)
+	accountGroup.DELETE("/tokens/:id", personalAccessTokenHandler.RevokeTokenHandler)
+
+	// Passkey management for the logged in user (sessions only)
+	e.GET("/passkeys", passkeyHandler.PasskeysPageHandler, authenticationMiddleware.JwtAuthMiddleware())
+	passkeyGroup := e.Group("/api/passkeys")
+	passkeyGroup.Use(authenticationMiddleware.JwtAuthMiddleware(), authenticationMiddleware.RequireSession())
+	passkeyGroup.GET("", passkeyHandler.ListPasskeysHandler)
+	passkeyGroup.POST("/register/begin", passkeyHandler.BeginRegistrationHandler)
+	passkeyGroup.POST("/register/finish", passkeyHandler.FinishRegistrationHandler)
+	passkeyGroup.DELETE("/:id", passkeyHandler.DeletePasskeyHandler)
+
+	requirePermission := authorizationMiddleware.RequirePermission
+
+	// Organizations of the logged in user
+	organizationGroup := e.Group("/api/organizations")
+	organizationGroup.Use(authenticationMiddleware.JwtAuthMiddleware())
+	organizationGroup.GET("", organizationHandler.ListMembershipsHandler)
+	organizationGroup.POST("", organizationHandler.CreateOrganizationHandler)
+	organizationGroup.POST("/switch", organizationHandler.SwitchOrganizationHandler, authenticationMiddleware.RequireSession())
+
+	// The organization the logged in user acts in (each requires its own tenant permission)
+	tenantGroup := e.Group("/api/tenant")
+	tenantGroup.Use(authenticationMiddleware.JwtAuthMiddleware(), tenantMiddleware.RequireTenant())
+	tenantGroup.GET("", organizationHandler.GetTenantHandler)
+	tenantGroup.PUT("", organizationHandler.RenameTenantHandler, requirePermission(rbac.PERMISSION_ORGANIZATION_WRITE))
+	tenantGroup.GET("/members", organizationHandler.ListMembersHandler, requirePermission(rbac.PERMISSION_MEMBERS_READ))
+	tenantGroup.POST("/members", organizationHandler.AddMemberHandler, requirePermission(rbac.PERMISSION_MEMBERS_WRITE))
+	tenantGroup.PUT("/members/:id/role", organizationHandler.UpdateMemberRoleHandler, requirePermission(rbac.PERMISSION_MEMBERS_WRITE))
+	tenantGroup.DELETE("/members/:id", organizationHandler.RemoveMemberHandler, requirePermission(rbac.PERMISSION_MEMBERS_WRITE))
+	tenantGroup.GET("/invitations", invitationHandler.ListInvitationsHandler, requirePermission(rbac.PERMISSION_MEMBERS_READ))
+	tenantGroup.POST("/invitations", invitationHandler.CreateInvitationHandler, requirePermission(rbac.PERMISSION_MEMBERS_WRITE))
+	tenantGroup.POST("/invitations/:id/resend", invitationHandler.ResendInvitationHandler, requirePermission(rbac.PERMISSION_MEMBERS_WRITE))
+	tenantGroup.DELETE("/invitations/:id", invitationHandler.RevokeInvitationHandler, requirePermission(rbac.PERMISSION_MEMBERS_WRITE))
+
+	// Admin console (requires the "users:read" permission, changes "users:write")
+	adminConsoleGroup := e.Group("/admin")
+	adminConsoleGroup.Use(authenticationMiddleware.JwtAuthMiddleware(), requirePermission(rbac.PERMISSION_USERS_READ))
+	adminConsoleGroup.GET("/users", adminHandler.UsersPageHandler)
+	adminConsoleGroup.GET("/users/table", adminHandler.UsersTableHandler)
+	adminConsoleGroup.PUT("/users/:id/role", adminHandler.UpdateUserRoleRowHandler, requirePermission(rbac.PERMISSION_USERS_WRITE))
+	adminConsoleGroup.POST("/users/:id/disable", adminHandler.DisableUserRowHandler, requirePermission(rbac.PERMISSION_USERS_WRITE))
+	adminConsoleGroup.POST("/users/:id/enable", adminHandler.EnableUserRowHandler, requirePermission(rbac.PERMISSION_USERS_WRITE))
+	adminConsoleGroup.DELETE("/users/:id", adminHandler.DeleteUserRowHandler, requirePermission(rbac.PERMISSION_USERS_WRITE))
+
+	// Admin Routes (each requires its own permission)
 	adminGroup := e.Group("/api/admin")
-	adminGroup.Use(authenticationMiddleware.JwtAuthMiddleware(), authorizationMiddleware.RequireAdminMiddleware())
-	// for testing purposes
-	adminGroup.GET("/users", func(c echo.Context) error {
-		token, ok := c.Get("user").(*gojwt.Token)
-		if !ok {
-			return echo.ErrForbidden
+	adminGroup.Use(authenticationMiddleware.JwtAuthMiddleware())
+	adminGroup.GET("/users", adminHandler.ListUsersHandler, requirePermission(rbac.PERMISSION_USERS_READ))
+	adminGroup.POST("/users", adminHandler.CreateUserHandler, requirePermission(rbac.PERMISSION_USERS_WRITE))
+	adminGroup.GET("/users/:id", adminHandler.GetUserHandler, requirePermission(rbac.PERMISSION_USERS_READ))
+	adminGroup.DELETE("/users/:id", adminHandler.DeleteUserHandler, requirePermission(rbac.PERMISSION_USERS_WRITE))
+	adminGroup.PUT("/users/:id/role", adminHandler.UpdateUserRoleHandler, requirePermission(rbac.PERMISSION_USERS_WRITE))
+	adminGroup.PUT("/users/:id/roles", adminHandler.UpdateUserRolesHandler, requirePermission(rbac.PERMISSION_USERS_WRITE))
+	adminGroup.POST("/users/:id/disable", adminHandler.DisableUserHandler, requirePermission(rbac.PERMISSION_USERS_WRITE))
+	adminGroup.POST("/users/:id/enable", adminHandler.EnableUserHandler, requirePermission(rbac.PERMISSION_USERS_WRITE))
+	adminGroup.POST("/users/:id/password-reset", adminHandler.ForcePasswordResetHandler, requirePermission(rbac.PERMISSION_USERS_WRITE))
+	adminGroup.POST("/users/:id/approve", adminHandler.ApproveUserHandler, requirePermission(rbac.PERMISSION_USERS_WRITE))
+	adminGroup.POST("/users/:id/reject", adminHandler.RejectUserHandler, requirePermission(rbac.PERMISSION_USERS_WRITE))
+	adminGroup.GET("/invitations", invitationHandler.ListInvitationsHandler, requirePermission(rbac.PERMISSION_USERS_READ))
+	adminGroup.POST("/invitations", invitationHandler.CreateInvitationHandler, requirePermission(rbac.PERMISSION_USERS_WRITE))
+	adminGroup.POST("/invitations/:id/resend", invitationHandler.ResendInvitationHandler, requirePermission(rbac.PERMISSION_USERS_WRITE))
+	adminGroup.DELETE("/invitations/:id", invitationHandler.RevokeInvitationHandler, requirePermission(rbac.PERMISSION_USERS_WRITE))
+	adminGroup.DELETE("/users/:id/sessions", adminHandler.RevokeUserSessionsHandler, requirePermission(rbac.PERMISSION_SESSIONS_WRITE))
+	adminGroup.GET("/lockouts", adminHandler.ListLockoutsHandler, requirePermission(rbac.PERMISSION_LOCKOUTS_READ))
+	adminGroup.DELETE("/lockouts", adminHandler.ClearLockoutHandler, requirePermission(rbac.PERMISSION_LOCKOUTS_WRITE))
+	adminGroup.GET("/roles", adminHandler.ListRolesHandler, requirePermission(rbac.PERMISSION_ROLES_READ))
+	adminGroup.POST("/roles", adminHandler.CreateRoleHandler, requirePermission(rbac.PERMISSION_ROLES_WRITE))
+	adminGroup.PUT("/roles/:name/permissions", adminHandler.UpdateRolePermissionsHandler, requirePermission(rbac.PERMISSION_ROLES_WRITE))
+	adminGroup.DELETE("/roles/:name", adminHandler.DeleteRoleHandler, requirePermission(rbac.PERMISSION_ROLES_WRITE))
+	adminGroup.GET("/permissions", adminHandler.ListPermissionsHandler, requirePermission(rbac.PERMISSION_ROLES_READ))
+	adminGroup.GET("/service-accounts", serviceAccountHandler.ListServiceAccountsHandler, requirePermission(rbac.PERMISSION_SERVICE_ACCOUNTS_READ))
+	adminGroup.POST("/service-accounts", serviceAccountHandler.CreateServiceAccountHandler, requirePermission(rbac.PERMISSION_SERVICE_ACCOUNTS_WRITE))
+	adminGroup.GET("/service-accounts/:id", serviceAccountHandler.GetServiceAccountHandler, requirePermission(rbac.PERMISSION_SERVICE_ACCOUNTS_READ))
+	adminGroup.DELETE("/service-accounts/:id", serviceAccountHandler.DeleteServiceAccountHandler, requirePermission(rbac.PERMISSION_SERVICE_ACCOUNTS_WRITE))
+	adminGroup.PUT("/service-accounts/:id/scopes", serviceAccountHandler.UpdateServiceAccountScopesHandler, requirePermission(rbac.PERMISSION_SERVICE_ACCOUNTS_WRITE))
+	adminGroup.POST("/service-accounts/:id/secrets", serviceAccountHandler.CreateClientSecretHandler, requirePermission(rbac.PERMISSION_SERVICE_ACCOUNTS_WRITE))
+	adminGroup.POST("/service-accounts/:id/keys", serviceAccountHandler.AddPublicKeyHandler, requirePermission(rbac.PERMISSION_SERVICE_ACCOUNTS_WRITE))
+	adminGroup.DELETE("/service-accounts/:id/credentials/:credentialId", serviceAccountHandler.DeleteCredentialHandler, requirePermission(rbac.PERMISSION_SERVICE_ACCOUNTS_WRITE))
+}
+
+// loadJwtKeyring falls back to the shared HMAC secret unless asymmetric keys
+// are configured.
+func loadJwtKeyring(cfg *config.Config) *jwt.Keyring {
+	if cfg.App.JwtActiveKid == "" {
+		return jwt.NewHmacKeyring(cfg.App.JwtSecret)
+	}
+
+	var active *jwt.SigningKey
+	others := make([]*jwt.SigningKey, 0, len(cfg.App.JwtKeys))
+	for _, keyCfg := range cfg.App.JwtKeys {
+		key, err := jwt.LoadSigningKey(keyCfg.Kid, keyCfg.Algorithm, keyCfg.PrivateKeyPath, keyCfg.PublicKeyPath)
+		if err != nil {
+			panic(err)
 		}
-		claims, ok := token.Claims.(*jwt.JwtCustomClaims)
-		if !ok {
-			return echo.ErrForbidden
+
+		if key.Kid == cfg.App.JwtActiveKid {
+			active = key
+		} else {
+			others = append(others, key)
 		}
-		name := claims.UserId
-		role := claims.UserRole
+	}
 
-		return c.String(http.StatusOK, "Welcome "+name+" with role: "+role+"!e")
-	})
+	keyring, err := jwt.NewKeyring(active, others...)
+	if err != nil {
+		panic(fmt.Errorf("failed to load jwt key %q: %w", cfg.App.JwtActiveKid, err))
+	}
+
+	return keyring
+}
+
+// loadBreachChecker returns nil when no index is configured, which disables
+// the breached password check.
+func loadBreachChecker(cfg *config.Config) password.BreachCheckerInterface {
+	if cfg.App.PasswordPolicy.BreachIndex == "" {
+		return nil
+	}
+
+	breachChecker, err := password.NewBreachChecker(cfg.App.PasswordPolicy.BreachIndex)
+	if err != nil {
+		panic(err)
+	}
+
+	return breachChecker
 }
 
 func connectToDatabase(ctx context.Context, cfg *config.Config) *repository.Queries {
@@ -142,11 +438,11 @@ func connectToDatabase(ctx context.Context, cfg *config.Config) *repository.Quer
 	return queries
 }
 
-func createAdminUser(ctx context.Context, queries *repository.Queries, cfg *config.Config) {
+func createAdminUser(ctx context.Context, queries *repository.Queries, passwordService *password.PasswordService, cfg *config.Config) {
 	adminName := cfg.App.AdminUser
 	adminPassword := cfg.App.AdminPassword
 	adminEmail := cfg.App.AdminEmail
-	hashedPassword, err := password.NewPasswordService().HashAndSaltPassword(adminPassword)
+	hashedPassword, err := passwordService.HashAndSaltPassword(adminPassword)
 	if err != nil {
 		log.Printf("Error hashing password: %v\n", err)
 		return
@@ -167,7 +463,7 @@ func createAdminUser(ctx context.Context, queries *repository.Queries, cfg *conf
 		Username:     adminName,
 		Email:        adminEmail,
 		PasswordHash: hashedPassword,
-		UserRole:     "admin",
+		UserRole:     "ADMIN",
 	}
 	user, err := queries.CreateUser(ctx, userParams)
 	if err != nil {
@@ -175,6 +471,13 @@ func createAdminUser(ctx context.Context, queries *repository.Queries, cfg *conf
 		return
 	}
 
+	// The configured admin address is trusted, it never receives a link.
+	_, err = queries.MarkUserEmailVerified(ctx, repository.MarkUserEmailVerifiedParams{ID: user.ID, Email: user.Email})
+	if err != nil {
+		log.Printf("Error marking admin email as verified: %v\n", err)
+		return
+	}
+
 	log.Printf("Admin user created successfully:\n"+
 		"	id: %q\n	email: %q\n	username: %q\n",
 		user.ID,
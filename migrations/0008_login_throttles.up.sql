@@ -0,0 +1,16 @@
+-- Failed login attempts, counted per account (normalized email) and per
+-- client IP. Keeping them in the database lets every instance see the same
+-- counters. Failures older than the configured window start a new count.
+-- locked_until holds both the short backoff delays and the lockout once the
+-- threshold is reached.
+CREATE TABLE login_throttles (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    scope TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    failed_attempts INTEGER NOT NULL DEFAULT 1,
+    last_failed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    locked_until TIMESTAMP WITH TIME ZONE,
+    UNIQUE (scope, subject)
+);
+
+CREATE INDEX login_throttles_locked_until_idx ON login_throttles (locked_until);
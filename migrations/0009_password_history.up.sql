@@ -0,0 +1,14 @@
+-- password_changed_at drives password expiry. Existing accounts start their
+-- first period when this migration runs.
+ALTER TABLE users ADD COLUMN password_changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
+
+-- Hashes of previous passwords, so that they cannot be chosen again. Only the
+-- most recent ones, as many as the configured reuse limit, are kept.
+CREATE TABLE password_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    password_hash TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX password_history_user_id_created_at_idx ON password_history (user_id, created_at DESC);
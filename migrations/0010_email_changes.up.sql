@@ -0,0 +1,19 @@
+-- A requested change of the email address. The new address only replaces the
+-- old one once it is confirmed with the link sent to it. The old address gets
+-- a revert link that also works after the change was confirmed, so that the
+-- owner can take the account back if somebody else changed the address. Only
+-- SHA-256 hashes of both tokens are stored.
+CREATE TABLE email_changes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    old_email TEXT NOT NULL,
+    new_email TEXT NOT NULL,
+    confirm_token_hash TEXT UNIQUE NOT NULL,
+    revert_token_hash TEXT UNIQUE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revert_expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    confirmed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX email_changes_user_id_idx ON email_changes (user_id);
@@ -0,0 +1,7 @@
+-- Disabled users cannot log in or refresh their sessions. An admin can also
+-- require a user to choose a new password on the next login, the flag is
+-- cleared once the password changes.
+ALTER TABLE users ADD COLUMN disabled_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users ADD COLUMN password_reset_required BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX users_created_at_idx ON users (created_at DESC);
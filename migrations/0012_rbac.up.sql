@@ -0,0 +1,54 @@
+-- Roles group permissions. USER and ADMIN are built in, admins can add more.
+-- users.user_role stays the base role of an account and now has to name an
+-- existing role. Further roles are granted through user_roles, a user has the
+-- permissions of all their roles.
+CREATE TABLE roles (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name TEXT UNIQUE NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Permissions are checked by the application, so they are only added by
+-- migrations.
+CREATE TABLE permissions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name TEXT UNIQUE NOT NULL,
+    description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE role_permissions (
+    role_id UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    permission_id UUID NOT NULL REFERENCES permissions(id) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission_id)
+);
+
+CREATE TABLE user_roles (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role_id UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, role_id)
+);
+
+CREATE INDEX user_roles_role_id_idx ON user_roles (role_id);
+
+INSERT INTO roles (name, description) VALUES
+    ('USER', 'Every account'),
+    ('ADMIN', 'Full access to the admin API and console');
+
+INSERT INTO permissions (name, description) VALUES
+    ('users:read', 'List and view users'),
+    ('users:write', 'Create, change, disable and delete users'),
+    ('sessions:write', 'Log users out of their sessions'),
+    ('lockouts:read', 'List login lockouts'),
+    ('lockouts:write', 'Clear login lockouts'),
+    ('roles:read', 'List roles and permissions'),
+    ('roles:write', 'Create, change and delete roles');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p WHERE r.name = 'ADMIN';
+
+-- Roles used to be matched case-insensitively.
+UPDATE users SET user_role = UPPER(user_role);
+UPDATE users SET user_role = 'USER' WHERE user_role NOT IN ('USER', 'ADMIN');
+ALTER TABLE users ADD CONSTRAINT users_user_role_fkey FOREIGN KEY (user_role) REFERENCES roles(name);
@@ -0,0 +1,39 @@
+-- Organizations are the tenants of the application. Users keep a single
+-- global account and join organizations through memberships, each with a role
+-- that only applies inside that organization.
+CREATE TABLE organizations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE memberships (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role_id UUID NOT NULL REFERENCES roles(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, user_id)
+);
+
+CREATE INDEX memberships_user_id_idx ON memberships (user_id);
+
+-- The organization a session acts in. Rotated refresh tokens keep it.
+ALTER TABLE sessions ADD COLUMN organization_id UUID REFERENCES organizations(id) ON DELETE SET NULL;
+
+INSERT INTO roles (name, description) VALUES
+    ('ORG_OWNER', 'Manages an organization and its members'),
+    ('ORG_MEMBER', 'Belongs to an organization');
+
+INSERT INTO permissions (name, description) VALUES
+    ('organization:write', 'Rename the active organization'),
+    ('members:read', 'List the members of the active organization'),
+    ('members:write', 'Add, change and remove members of the active organization');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p
+WHERE r.name = 'ORG_OWNER' AND p.name IN ('organization:write', 'members:read', 'members:write');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p
+WHERE r.name = 'ORG_MEMBER' AND p.name = 'members:read';
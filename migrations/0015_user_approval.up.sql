@@ -0,0 +1,3 @@
+-- Accounts registered while sign ups need approval cannot log in until an
+-- admin approves them. Rejected accounts are deleted.
+ALTER TABLE users ADD COLUMN pending_approval BOOLEAN NOT NULL DEFAULT FALSE;
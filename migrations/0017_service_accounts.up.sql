@@ -0,0 +1,38 @@
+-- Service accounts are users without a password that cannot log in. They get
+-- access tokens through the OAuth2 client_credentials grant instead, with the
+-- scopes an admin granted them as permissions. Their email address is a
+-- placeholder in the reserved .invalid domain.
+ALTER TABLE users ADD COLUMN account_type TEXT NOT NULL DEFAULT 'human'
+    CHECK (account_type IN ('human', 'service'));
+
+CREATE TABLE service_accounts (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    description TEXT NOT NULL DEFAULT '',
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- A service account authenticates with a client secret, of which only the
+-- SHA-256 hash is stored, or with a JWT signed by the private key belonging
+-- to a registered public key. Several credentials allow rotating them.
+CREATE TABLE service_account_credentials (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES service_accounts(user_id) ON DELETE CASCADE,
+    credential_type TEXT NOT NULL CHECK (credential_type IN ('secret', 'key')),
+    secret_hash TEXT UNIQUE,
+    public_key TEXT,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX service_account_credentials_user_id_idx ON service_account_credentials (user_id);
+
+INSERT INTO permissions (name, description) VALUES
+    ('service_accounts:read', 'List service accounts and their credentials'),
+    ('service_accounts:write', 'Create and delete service accounts, change their scopes and credentials');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p
+WHERE r.name = 'ADMIN' AND p.name IN ('service_accounts:read', 'service_accounts:write');
@@ -0,0 +1,11 @@
+-- The jti of every client assertion a service account authenticated with is
+-- kept until the assertion expires, so that a captured assertion cannot be
+-- replayed (RFC 7523, section 3).
+CREATE TABLE used_client_assertions (
+    user_id UUID NOT NULL REFERENCES service_accounts(user_id) ON DELETE CASCADE,
+    jti TEXT NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (user_id, jti)
+);
+
+CREATE INDEX used_client_assertions_expires_at_idx ON used_client_assertions (expires_at);
@@ -0,0 +1,76 @@
+// Passkey ceremonies. The server sends and expects the JSON form of the
+// WebAuthn options and credentials, with binary values as base64url strings.
+(function () {
+  function toBuffer(value) {
+    const base64 = value.replace(/-/g, "+").replace(/_/g, "/");
+    const padded = base64 + "=".repeat((4 - (base64.length % 4)) % 4);
+    return Uint8Array.from(atob(padded), (c) => c.charCodeAt(0)).buffer;
+  }
+
+  function toBase64Url(buffer) {
+    if (!buffer) {
+      return "";
+    }
+    const bytes = String.fromCharCode(...new Uint8Array(buffer));
+    return btoa(bytes).replace(/\+/g, "-").replace(/\//g, "_").replace(/=+$/, "");
+  }
+
+  async function postJson(url, body) {
+    const response = await fetch(url, {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      body: body === undefined ? undefined : JSON.stringify(body),
+    });
+    if (!response.ok) {
+      const error = await response.json().catch(() => ({}));
+      throw new Error(error.error || "Request failed");
+    }
+    return response;
+  }
+
+  function descriptors(list) {
+    return (list || []).map((descriptor) => ({ ...descriptor, id: toBuffer(descriptor.id) }));
+  }
+
+  async function registerPasskey(name) {
+    const options = await (await postJson("/api/passkeys/register/begin")).json();
+    options.challenge = toBuffer(options.challenge);
+    options.user.id = toBuffer(options.user.id);
+    options.excludeCredentials = descriptors(options.excludeCredentials);
+
+    const credential = await navigator.credentials.create({ publicKey: options });
+    await postJson("/api/passkeys/register/finish", {
+      name: name,
+      credential: {
+        id: credential.id,
+        rawId: toBase64Url(credential.rawId),
+        type: credential.type,
+        response: {
+          clientDataJSON: toBase64Url(credential.response.clientDataJSON),
+          attestationObject: toBase64Url(credential.response.attestationObject),
+        },
+      },
+    });
+  }
+
+  async function loginWithPasskey() {
+    const options = await (await postJson("/api/passkeys/login/begin")).json();
+    options.challenge = toBuffer(options.challenge);
+    options.allowCredentials = descriptors(options.allowCredentials);
+
+    const credential = await navigator.credentials.get({ publicKey: options });
+    await postJson("/api/passkeys/login/finish", {
+      id: credential.id,
+      rawId: toBase64Url(credential.rawId),
+      type: credential.type,
+      response: {
+        clientDataJSON: toBase64Url(credential.response.clientDataJSON),
+        authenticatorData: toBase64Url(credential.response.authenticatorData),
+        signature: toBase64Url(credential.response.signature),
+        userHandle: toBase64Url(credential.response.userHandle),
+      },
+    });
+  }
+
+  window.passkeys = { registerPasskey, loginWithPasskey };
+})();
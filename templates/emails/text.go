@@ -0,0 +1,53 @@
+package emails
+
+import (
+	"embed"
+	"strings"
+	"text/template"
+)
+
+//go:embed *.txt
+var textFiles embed.FS
+
+var textTemplates = template.Must(template.ParseFS(textFiles, "*.txt"))
+
+const (
+	VERIFY_EMAIL_TEXT         = "verifyEmail.txt"
+	PASSWORD_RESET_TEXT       = "passwordReset.txt"
+	ACCOUNT_EXISTS_TEXT       = "accountExists.txt"
+	EMAIL_CHANGE_CONFIRM_TEXT = "emailChangeConfirm.txt"
+	EMAIL_CHANGE_NOTICE_TEXT  = "emailChangeNotice.txt"
+	INVITATION_TEXT           = "invitation.txt"
+	ACCOUNT_APPROVED_TEXT     = "accountApproved.txt"
+	ACCOUNT_REJECTED_TEXT     = "accountRejected.txt"
+)
+
+// LinkData is passed to every plaintext template containing a single link.
+type LinkData struct {
+	Link string
+}
+
+type AccountExistsData struct {
+	LoginLink string
+	ResetLink string
+}
+
+type EmailChangeNoticeData struct {
+	NewEmail   string
+	RevertLink string
+}
+
+type InvitationData struct {
+	OrganizationName string
+	Link             string
+}
+
+// RenderText renders the plaintext alternative of an email.
+func RenderText(name string, data any) (string, error) {
+	var buf strings.Builder
+	if err := textTemplates.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
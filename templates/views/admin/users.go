@@ -0,0 +1,44 @@
+package admin
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/fgeck/gotth-postgres/internal/service/user"
+	"github.com/fgeck/gotth-postgres/internal/service/userAdmin"
+)
+
+const USERS_TABLE_PATH = "/admin/users/table"
+
+// usersTableUrl keeps the current filter when switching pages.
+func usersTableUrl(filter userAdmin.UserFilter, page int) string {
+	query := url.Values{}
+	if filter.Search != "" {
+		query.Set("search", filter.Search)
+	}
+	if filter.Role != "" {
+		query.Set("role", filter.Role)
+	}
+	if filter.Status != "" {
+		query.Set("status", filter.Status)
+	}
+	query.Set("page", strconv.Itoa(page))
+
+	return USERS_TABLE_PATH + "?" + query.Encode()
+}
+
+func userPath(u *userAdmin.AdminUserDto, action string) string {
+	return "/admin/users/" + u.ID.String() + action
+}
+
+func lastPage(page *userAdmin.UserPageDto) int {
+	if page.Total == 0 {
+		return 1
+	}
+
+	return int((page.Total + int64(page.PageSize) - 1) / int64(page.PageSize))
+}
+
+func roles() []user.UserRole {
+	return []user.UserRole{user.UserRoleUser, user.UserRoleAdmin}
+}